@@ -0,0 +1,101 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/internal/bundle"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
+)
+
+const (
+	flagInstance     = "instance"
+	flagFile         = "file"
+	flagTransportKey = "transport-key"
+	flagBatchSize    = "batch-size"
+	envTransportKey  = "ZITADEL_TRANSPORTKEY"
+)
+
+func NewExport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Exports an instance to a bundle file",
+		Long: `Exports all events, unique constraints and assets of an instance to a versioned bundle file.
+Secrets are encrypted with a transport key, which must be provided again on import.
+The key used for the encryption is derived from the transport key with argon2id and a random salt stored in the bundle.
+The transport key can be passed by flag or by the environment variable ` + envTransportKey + `.
+
+Example:
+zitadel export --instance 236118452394016516 --file instance.bundle --masterkeyFromEnv --config zitadel.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := MustNewConfig(viper.GetViper())
+			masterKey, err := key.MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			instanceID, _ := cmd.Flags().GetString(flagInstance)
+			if instanceID == "" {
+				return errors.New("instance must be provided")
+			}
+			file, _ := cmd.Flags().GetString(flagFile)
+			return export(cmd.Context(), config, masterKey, instanceID, transportKey(cmd), file)
+		},
+	}
+	key.AddMasterKeyFlag(cmd)
+	cmd.Flags().String(flagInstance, "", "id of the instance to export")
+	cmd.Flags().String(flagFile, "", "path of the bundle file, the bundle is written to stdout if empty")
+	cmd.Flags().String(flagTransportKey, "", "passphrase the secrets of the bundle are encrypted with")
+	return cmd
+}
+
+func export(ctx context.Context, config *Config, masterKey, instanceID, transportKey, file string) (err error) {
+	dbClient, err := database.Connect(config.Database, false, dialect.DBPurposeQuery)
+	if err != nil {
+		return err
+	}
+	keyStorage, err := cryptoDB.NewKeyStorage(dbClient, masterKey)
+	if err != nil {
+		return err
+	}
+	exporter, err := bundle.NewExporter(dbClient, keyStorage, transportKey)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			closeErr := f.Close()
+			if err == nil {
+				err = closeErr
+			}
+		}()
+		out = f
+	}
+	if err = exporter.Export(ctx, instanceID, out); err != nil {
+		return err
+	}
+	logging.WithFields("instance", instanceID, "file", file).Info("instance exported")
+	return nil
+}
+
+func transportKey(cmd *cobra.Command) string {
+	transportKey, _ := cmd.Flags().GetString(flagTransportKey)
+	if transportKey != "" {
+		return transportKey
+	}
+	return os.Getenv(envTransportKey)
+}
@@ -0,0 +1,83 @@
+package bundle
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/internal/bundle"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
+)
+
+func NewImport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Imports an instance from a bundle file",
+		Long: `Imports an instance from a bundle file created by zitadel export.
+The import is idempotent, records which already exist are skipped, so a failed import can be repeated.
+The encryption keys used by the exporting installation must exist with the same ids.
+Projections are built by ZITADEL after the import.
+
+Example:
+zitadel import --file instance.bundle --masterkeyFromEnv --config zitadel.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := MustNewConfig(viper.GetViper())
+			masterKey, err := key.MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			file, _ := cmd.Flags().GetString(flagFile)
+			batchSize, _ := cmd.Flags().GetInt(flagBatchSize)
+			return importBundle(cmd.Context(), config, masterKey, transportKey(cmd), file, batchSize)
+		},
+	}
+	key.AddMasterKeyFlag(cmd)
+	cmd.Flags().String(flagFile, "", "path of the bundle file, the bundle is read from stdin if empty")
+	cmd.Flags().String(flagTransportKey, "", "passphrase the secrets of the bundle are encrypted with")
+	cmd.Flags().Int(flagBatchSize, 1000, "amount of records stored per transaction")
+	return cmd
+}
+
+func importBundle(ctx context.Context, config *Config, masterKey, transportKey, file string, batchSize int) error {
+	dbClient, err := database.Connect(config.Database, false, dialect.DBPurposeEventPusher)
+	if err != nil {
+		return err
+	}
+	keyStorage, err := cryptoDB.NewKeyStorage(dbClient, masterKey)
+	if err != nil {
+		return err
+	}
+	importer, err := bundle.NewImporter(dbClient, keyStorage, transportKey, batchSize)
+	if err != nil {
+		return err
+	}
+
+	var in io.Reader = os.Stdin
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	header, stats, err := importer.Import(ctx, in)
+	if err != nil {
+		return err
+	}
+	logging.WithFields(
+		"instance", header.InstanceID,
+		"exported_by", header.ZitadelVersion,
+		"events", stats.Events,
+		"unique_constraints", stats.UniqueConstraints,
+		"assets", stats.Assets,
+	).Info("instance imported")
+	return nil
+}
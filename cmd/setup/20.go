@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 20.sql
+	addSkippedToFailedEvents string
+)
+
+type AddSkippedToFailedEvents struct {
+	dbClient *database.DB
+}
+
+func (mig *AddSkippedToFailedEvents) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addSkippedToFailedEvents)
+	return err
+}
+
+func (mig *AddSkippedToFailedEvents) String() string {
+	return "20_add_skipped_to_failed_events"
+}
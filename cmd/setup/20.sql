@@ -0,0 +1 @@
+ALTER TABLE projections.failed_events2 ADD COLUMN IF NOT EXISTS skipped BOOLEAN NOT NULL DEFAULT FALSE, ADD COLUMN IF NOT EXISTS skip_reason TEXT;
\ No newline at end of file
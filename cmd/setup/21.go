@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 21.sql
+	addEventsArchiveTable string
+)
+
+type AddEventsArchiveTable struct {
+	dbClient *database.DB
+}
+
+func (mig *AddEventsArchiveTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addEventsArchiveTable)
+	return err
+}
+
+func (mig *AddEventsArchiveTable) String() string {
+	return "21_add_events_archive_table"
+}
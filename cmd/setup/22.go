@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 22.sql
+	addInstancePurgesTable string
+)
+
+type AddInstancePurgesTable struct {
+	dbClient *database.DB
+}
+
+func (mig *AddInstancePurgesTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addInstancePurgesTable)
+	return err
+}
+
+func (mig *AddInstancePurgesTable) String() string {
+	return "22_add_instance_purges_table"
+}
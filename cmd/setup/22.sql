@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS system.instance_purges (
+    instance_id TEXT NOT NULL
+    , table_name TEXT NOT NULL
+    , "position" SMALLINT NOT NULL
+    , deleted_rows BIGINT NOT NULL DEFAULT 0
+    , done BOOLEAN NOT NULL DEFAULT FALSE
+    , error TEXT
+    , created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+    , changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+
+    , PRIMARY KEY (instance_id, table_name)
+);
\ No newline at end of file
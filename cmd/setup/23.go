@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 23.sql
+	addMagicLinkVerificationToUserSessions string
+)
+
+type AddMagicLinkVerificationToUserSessions struct {
+	dbClient *database.DB
+}
+
+func (mig *AddMagicLinkVerificationToUserSessions) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addMagicLinkVerificationToUserSessions)
+	return err
+}
+
+func (mig *AddMagicLinkVerificationToUserSessions) String() string {
+	return "23_add_magic_link_verification_to_user_sessions"
+}
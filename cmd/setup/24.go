@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 24.sql
+	addPhoneLoginVerificationToUserSessions string
+)
+
+type AddPhoneLoginVerificationToUserSessions struct {
+	dbClient *database.DB
+}
+
+func (mig *AddPhoneLoginVerificationToUserSessions) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addPhoneLoginVerificationToUserSessions)
+	return err
+}
+
+func (mig *AddPhoneLoginVerificationToUserSessions) String() string {
+	return "24_add_phone_login_verification_to_user_sessions"
+}
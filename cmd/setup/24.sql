@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS auth.user_sessions ADD COLUMN IF NOT EXISTS phone_login_verification TIMESTAMPTZ NULL;
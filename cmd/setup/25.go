@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 25.sql
+	addChannelsToQuotaNotifications string
+)
+
+type AddChannelsToQuotaNotifications struct {
+	dbClient *database.DB
+}
+
+func (mig *AddChannelsToQuotaNotifications) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addChannelsToQuotaNotifications)
+	return err
+}
+
+func (mig *AddChannelsToQuotaNotifications) String() string {
+	return "25_add_channels_to_quota_notifications"
+}
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS projections.quotas_notifications ADD COLUMN IF NOT EXISTS channel SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE IF EXISTS projections.quotas_notifications ADD COLUMN IF NOT EXISTS recipients TEXT[];
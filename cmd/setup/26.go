@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 26.sql
+	addConsentRequiredToOIDCApps string
+)
+
+type AddConsentRequiredToOIDCApps struct {
+	dbClient *database.DB
+}
+
+func (mig *AddConsentRequiredToOIDCApps) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addConsentRequiredToOIDCApps)
+	return err
+}
+
+func (mig *AddConsentRequiredToOIDCApps) String() string {
+	return "26_add_consent_required_to_oidc_apps"
+}
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 27.sql
+	addLevelOfAssuranceColumns string
+)
+
+type AddLevelOfAssuranceColumns struct {
+	dbClient *database.DB
+}
+
+func (mig *AddLevelOfAssuranceColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addLevelOfAssuranceColumns)
+	return err
+}
+
+func (mig *AddLevelOfAssuranceColumns) String() string {
+	return "27_add_level_of_assurance_columns"
+}
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS projections.auth_requests ADD COLUMN IF NOT EXISTS level_of_assurance SMALLINT DEFAULT 0;
+ALTER TABLE IF EXISTS auth.tokens ADD COLUMN IF NOT EXISTS amr TEXT[];
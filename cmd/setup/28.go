@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 28.sql
+	addX509VerificationToUserSessions string
+)
+
+type AddX509VerificationToUserSessions struct {
+	dbClient *database.DB
+}
+
+func (mig *AddX509VerificationToUserSessions) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addX509VerificationToUserSessions)
+	return err
+}
+
+func (mig *AddX509VerificationToUserSessions) String() string {
+	return "28_add_x509_verification_to_user_sessions"
+}
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS auth.user_sessions ADD COLUMN IF NOT EXISTS x509_verification TIMESTAMPTZ NULL;
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 29.sql
+	addCertificateThumbprintToTokens string
+)
+
+type AddCertificateThumbprintToTokens struct {
+	dbClient *database.DB
+}
+
+func (mig *AddCertificateThumbprintToTokens) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addCertificateThumbprintToTokens)
+	return err
+}
+
+func (mig *AddCertificateThumbprintToTokens) String() string {
+	return "29_add_certificate_thumbprint_to_tokens"
+}
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS auth.tokens ADD COLUMN IF NOT EXISTS certificate_thumbprint TEXT;
@@ -77,6 +77,16 @@ type Steps struct {
 	s17AddOffsetToUniqueConstraints *AddOffsetToCurrentStates
 	s18AddLowerFieldsToLoginNames   *AddLowerFieldsToLoginNames
 	s19AddCurrentStatesIndex        *AddCurrentSequencesIndex
+	s20AddSkippedToFailedEvents     *AddSkippedToFailedEvents
+	s21AddEventsArchiveTable        *AddEventsArchiveTable
+	s22AddInstancePurgesTable       *AddInstancePurgesTable
+	s23AddMagicLinkVerification     *AddMagicLinkVerificationToUserSessions
+	s24AddPhoneLoginVerification    *AddPhoneLoginVerificationToUserSessions
+	s25AddQuotaNotificationChannels *AddChannelsToQuotaNotifications
+	s26AddConsentRequiredToOIDCApps *AddConsentRequiredToOIDCApps
+	s27AddLevelOfAssuranceColumns   *AddLevelOfAssuranceColumns
+	s28AddX509Verification          *AddX509VerificationToUserSessions
+	s29AddCertificateThumbprint     *AddCertificateThumbprintToTokens
 }
 
 type encryptionKeyConfig struct {
@@ -110,6 +110,16 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.s17AddOffsetToUniqueConstraints = &AddOffsetToCurrentStates{dbClient: queryDBClient}
 	steps.s18AddLowerFieldsToLoginNames = &AddLowerFieldsToLoginNames{dbClient: queryDBClient}
 	steps.s19AddCurrentStatesIndex = &AddCurrentSequencesIndex{dbClient: queryDBClient}
+	steps.s20AddSkippedToFailedEvents = &AddSkippedToFailedEvents{dbClient: queryDBClient}
+	steps.s21AddEventsArchiveTable = &AddEventsArchiveTable{dbClient: esPusherDBClient}
+	steps.s22AddInstancePurgesTable = &AddInstancePurgesTable{dbClient: queryDBClient}
+	steps.s23AddMagicLinkVerification = &AddMagicLinkVerificationToUserSessions{dbClient: queryDBClient}
+	steps.s24AddPhoneLoginVerification = &AddPhoneLoginVerificationToUserSessions{dbClient: queryDBClient}
+	steps.s25AddQuotaNotificationChannels = &AddChannelsToQuotaNotifications{dbClient: queryDBClient}
+	steps.s26AddConsentRequiredToOIDCApps = &AddConsentRequiredToOIDCApps{dbClient: queryDBClient}
+	steps.s27AddLevelOfAssuranceColumns = &AddLevelOfAssuranceColumns{dbClient: queryDBClient}
+	steps.s28AddX509Verification = &AddX509VerificationToUserSessions{dbClient: queryDBClient}
+	steps.s29AddCertificateThumbprint = &AddCertificateThumbprintToTokens{dbClient: queryDBClient}
 
 	err = projection.Create(ctx, projectionDBClient, eventstoreClient, config.Projections, nil, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -156,6 +166,26 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.WithFields("name", steps.s17AddOffsetToUniqueConstraints.String()).OnError(err).Fatal("migration failed")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s19AddCurrentStatesIndex)
 	logging.WithFields("name", steps.s19AddCurrentStatesIndex.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s20AddSkippedToFailedEvents)
+	logging.WithFields("name", steps.s20AddSkippedToFailedEvents.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s21AddEventsArchiveTable)
+	logging.WithFields("name", steps.s21AddEventsArchiveTable.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s22AddInstancePurgesTable)
+	logging.WithFields("name", steps.s22AddInstancePurgesTable.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s23AddMagicLinkVerification)
+	logging.WithFields("name", steps.s23AddMagicLinkVerification.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s24AddPhoneLoginVerification)
+	logging.WithFields("name", steps.s24AddPhoneLoginVerification.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s25AddQuotaNotificationChannels)
+	logging.WithFields("name", steps.s25AddQuotaNotificationChannels.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s26AddConsentRequiredToOIDCApps)
+	logging.WithFields("name", steps.s26AddConsentRequiredToOIDCApps.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s27AddLevelOfAssuranceColumns)
+	logging.WithFields("name", steps.s27AddLevelOfAssuranceColumns.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s28AddX509Verification)
+	logging.WithFields("name", steps.s28AddX509Verification.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s29AddCertificateThumbprint)
+	logging.WithFields("name", steps.s29AddCertificateThumbprint.String()).OnError(err).Fatal("migration failed")
 
 	for _, repeatableStep := range repeatableSteps {
 		err = migration.Migrate(ctx, eventstoreClient, repeatableStep)
@@ -26,9 +26,11 @@ import (
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/archive"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
+	"github.com/zitadel/zitadel/internal/purge"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	static_config "github.com/zitadel/zitadel/internal/static/config"
 	metrics "github.com/zitadel/zitadel/internal/telemetry/metrics/config"
@@ -67,9 +69,12 @@ type Config struct {
 	Machine           *id.Config
 	Actions           *actions.Config
 	Eventstore        *eventstore.Config
+	EventArchive      *archive.Config
+	InstancePurge     *purge.Config
 	LogStore          *logstore.Configs
 	Quotas            *QuotasConfig
 	Telemetry         *handlers.TelemetryPusherConfig
+	Notifications     *handlers.NotificationWorkerConfig
 }
 
 type QuotasConfig struct {
@@ -77,7 +82,10 @@ type QuotasConfig struct {
 		logstore.EmitterConfig  `mapstructure:",squash"`
 		middleware.AccessConfig `mapstructure:",squash"`
 	}
-	Execution *logstore.EmitterConfig
+	Execution     *logstore.EmitterConfig
+	Notifications struct {
+		Enabled bool
+	}
 }
 
 func MustNewConfig(v *viper.Viper) *Config {
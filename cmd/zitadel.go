@@ -13,6 +13,7 @@ import (
 
 	"github.com/zitadel/zitadel/cmd/admin"
 	"github.com/zitadel/zitadel/cmd/build"
+	"github.com/zitadel/zitadel/cmd/bundle"
 	"github.com/zitadel/zitadel/cmd/initialise"
 	"github.com/zitadel/zitadel/cmd/key"
 	"github.com/zitadel/zitadel/cmd/ready"
@@ -57,6 +58,8 @@ func New(out io.Writer, in io.Reader, args []string, server chan<- *start.Server
 		start.NewStartFromSetup(server),
 		key.New(),
 		ready.New(),
+		bundle.NewExport(),
+		bundle.NewImport(),
 	)
 
 	cmd.InitDefaultVersionFlag()
@@ -49,7 +49,7 @@ func New(
 	queries *query.Queries,
 	verifier internal_authz.APITokenVerifier,
 	authZ internal_authz.Config,
-	tlsConfig *tls.Config, http2HostName, http1HostName string,
+	tlsConfig *tls.Config, http2HostName, http1HostName, clientCertificateHeader string,
 	accessInterceptor *http_mw.AccessInterceptor,
 ) (_ *API, err error) {
 	api := &API{
@@ -62,6 +62,8 @@ func New(
 		accessInterceptor: accessInterceptor,
 	}
 
+	// client certificates are needed for the verification of certificate-bound access tokens
+	api.router.Use(http_mw.ClientCertificateHandler(clientCertificateHeader))
 	api.grpcServer = server.CreateServer(api.verifier, authZ, queries, http2HostName, tlsConfig, accessInterceptor.AccessService())
 	api.grpcGateway, err = server.CreateGateway(ctx, port, http1HostName, accessInterceptor, tlsConfig)
 	if err != nil {
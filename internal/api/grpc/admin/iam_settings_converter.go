@@ -1,6 +1,8 @@
 package admin
 
 import (
+	"net/http"
+
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
@@ -8,6 +10,7 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
@@ -102,6 +105,10 @@ func SecretGeneratorTypeToPb(generatorType domain.SecretGeneratorType) settings_
 		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_OTP_SMS
 	case domain.SecretGeneratorTypeOTPEmail:
 		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_OTP_EMAIL
+	case domain.SecretGeneratorTypeMagicLinkCode:
+		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_MAGIC_LINK_CODE
+	case domain.SecretGeneratorTypeEmailChangeRevertCode:
+		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_EMAIL_CHANGE_REVERT_CODE
 	default:
 		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_UNSPECIFIED
 	}
@@ -125,6 +132,10 @@ func SecretGeneratorTypeToDomain(generatorType settings_pb.SecretGeneratorType)
 		return domain.SecretGeneratorTypeOTPSMS
 	case settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_OTP_EMAIL:
 		return domain.SecretGeneratorTypeOTPEmail
+	case settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_MAGIC_LINK_CODE:
+		return domain.SecretGeneratorTypeMagicLinkCode
+	case settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_EMAIL_CHANGE_REVERT_CODE:
+		return domain.SecretGeneratorTypeEmailChangeRevertCode
 	default:
 		return domain.SecretGeneratorTypeUnspecified
 	}
@@ -137,9 +148,13 @@ func AddSMTPToConfig(req *admin_pb.AddSMTPConfigRequest) *smtp.Config {
 		FromName:       req.SenderName,
 		ReplyToAddress: req.ReplyToAddress,
 		SMTP: smtp.SMTP{
-			Host:     req.Host,
-			User:     req.User,
-			Password: req.Password,
+			Host:          req.Host,
+			User:          req.User,
+			Password:      req.Password,
+			AuthMechanism: smtpAuthMechanismToDomain(req.AuthMechanism),
+			XOAuth2:       smtpXOAuth2ToConfig(req.Xoauth2),
+			TLSMode:       smtpTLSModeToDomain(req.TlsMode),
+			Timeout:       req.Timeout.AsDuration(),
 		},
 	}
 }
@@ -151,14 +166,57 @@ func UpdateSMTPToConfig(req *admin_pb.UpdateSMTPConfigRequest) *smtp.Config {
 		FromName:       req.SenderName,
 		ReplyToAddress: req.ReplyToAddress,
 		SMTP: smtp.SMTP{
-			Host: req.Host,
-			User: req.User,
+			Host:          req.Host,
+			User:          req.User,
+			AuthMechanism: smtpAuthMechanismToDomain(req.AuthMechanism),
+			XOAuth2:       smtpXOAuth2ToConfig(req.Xoauth2),
+			TLSMode:       smtpTLSModeToDomain(req.TlsMode),
+			Timeout:       req.Timeout.AsDuration(),
+		},
+	}
+}
+
+func TestSMTPToConfig(req *admin_pb.TestSMTPConfigRequest) *smtp.Config {
+	return &smtp.Config{
+		Tls:      req.Tls,
+		From:     req.SenderAddress,
+		FromName: req.SenderName,
+		SMTP: smtp.SMTP{
+			Host:          req.Host,
+			User:          req.User,
+			Password:      req.Password,
+			AuthMechanism: smtpAuthMechanismToDomain(req.AuthMechanism),
+			XOAuth2:       smtpXOAuth2ToConfig(req.Xoauth2),
+			TLSMode:       smtpTLSModeToDomain(req.TlsMode),
+			Timeout:       req.Timeout.AsDuration(),
 		},
 	}
 }
 
+func listSMTPConfigsToModel(req *admin_pb.ListSMTPConfigsRequest) (*query.SMTPConfigsSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.SMTPConfigsSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+	}, nil
+}
+
+func SMTPConfigsToPb(configs []*query.SMTPConfig) []*settings_pb.SMTPConfig {
+	c := make([]*settings_pb.SMTPConfig, len(configs))
+	for i, config := range configs {
+		c[i] = SMTPConfigToPb(config)
+	}
+	return c
+}
+
 func SMTPConfigToPb(smtp *query.SMTPConfig) *settings_pb.SMTPConfig {
 	mapped := &settings_pb.SMTPConfig{
+		Id:             smtp.ID,
+		Description:    smtp.Description,
+		State:          smtpStateToPb(smtp.State),
 		Tls:            smtp.TLS,
 		SenderAddress:  smtp.SenderAddress,
 		SenderName:     smtp.SenderName,
@@ -167,9 +225,144 @@ func SMTPConfigToPb(smtp *query.SMTPConfig) *settings_pb.SMTPConfig {
 		User:           smtp.User,
 		Details:        obj_grpc.ToViewDetailsPb(smtp.Sequence, smtp.CreationDate, smtp.ChangeDate, smtp.AggregateID),
 	}
+	if smtp.HTTPConfig != nil {
+		mapped.Http = HTTPConfigToPb(smtp.HTTPConfig)
+		return mapped
+	}
+	mapped.AuthMechanism = smtpAuthMechanismToPb(smtp.AuthMechanism)
+	mapped.TlsMode = smtpTLSModeToPb(smtp.TLSMode)
+	if smtp.Timeout > 0 {
+		mapped.Timeout = durationpb.New(smtp.Timeout)
+	}
+	if smtp.XOAuth2 != nil {
+		mapped.Xoauth2 = &settings_pb.SMTPXOAuth2{
+			TokenEndpoint: smtp.XOAuth2.TokenEndpoint,
+			ClientId:      smtp.XOAuth2.ClientID,
+			Scopes:        smtp.XOAuth2.Scopes,
+		}
+	}
 	return mapped
 }
 
+func smtpXOAuth2ToConfig(xoauth2 *settings_pb.SMTPXOAuth2) *smtp.XOAuth2 {
+	if xoauth2 == nil {
+		return nil
+	}
+	return &smtp.XOAuth2{
+		TokenEndpoint: xoauth2.TokenEndpoint,
+		ClientID:      xoauth2.ClientId,
+		Scopes:        xoauth2.Scopes,
+	}
+}
+
+func smtpAuthMechanismToDomain(mechanism settings_pb.SMTPAuthMechanism) domain.SMTPAuthMechanism {
+	switch mechanism {
+	case settings_pb.SMTPAuthMechanism_SMTP_AUTH_MECHANISM_PLAIN:
+		return domain.SMTPAuthMechanismPlain
+	case settings_pb.SMTPAuthMechanism_SMTP_AUTH_MECHANISM_LOGIN:
+		return domain.SMTPAuthMechanismLogin
+	case settings_pb.SMTPAuthMechanism_SMTP_AUTH_MECHANISM_CRAM_MD5:
+		return domain.SMTPAuthMechanismCRAMMD5
+	case settings_pb.SMTPAuthMechanism_SMTP_AUTH_MECHANISM_XOAUTH2:
+		return domain.SMTPAuthMechanismXOAuth2
+	default:
+		return domain.SMTPAuthMechanismUnspecified
+	}
+}
+
+func smtpAuthMechanismToPb(mechanism domain.SMTPAuthMechanism) settings_pb.SMTPAuthMechanism {
+	switch mechanism {
+	case domain.SMTPAuthMechanismPlain:
+		return settings_pb.SMTPAuthMechanism_SMTP_AUTH_MECHANISM_PLAIN
+	case domain.SMTPAuthMechanismLogin:
+		return settings_pb.SMTPAuthMechanism_SMTP_AUTH_MECHANISM_LOGIN
+	case domain.SMTPAuthMechanismCRAMMD5:
+		return settings_pb.SMTPAuthMechanism_SMTP_AUTH_MECHANISM_CRAM_MD5
+	case domain.SMTPAuthMechanismXOAuth2:
+		return settings_pb.SMTPAuthMechanism_SMTP_AUTH_MECHANISM_XOAUTH2
+	default:
+		return settings_pb.SMTPAuthMechanism_SMTP_AUTH_MECHANISM_UNSPECIFIED
+	}
+}
+
+func smtpTLSModeToDomain(mode settings_pb.SMTPTLSMode) domain.SMTPTLSMode {
+	switch mode {
+	case settings_pb.SMTPTLSMode_SMTP_TLS_MODE_NONE:
+		return domain.SMTPTLSModeNone
+	case settings_pb.SMTPTLSMode_SMTP_TLS_MODE_STARTTLS:
+		return domain.SMTPTLSModeStartTLS
+	case settings_pb.SMTPTLSMode_SMTP_TLS_MODE_IMPLICIT:
+		return domain.SMTPTLSModeImplicit
+	default:
+		return domain.SMTPTLSModeUnspecified
+	}
+}
+
+func smtpTLSModeToPb(mode domain.SMTPTLSMode) settings_pb.SMTPTLSMode {
+	switch mode {
+	case domain.SMTPTLSModeNone:
+		return settings_pb.SMTPTLSMode_SMTP_TLS_MODE_NONE
+	case domain.SMTPTLSModeStartTLS:
+		return settings_pb.SMTPTLSMode_SMTP_TLS_MODE_STARTTLS
+	case domain.SMTPTLSModeImplicit:
+		return settings_pb.SMTPTLSMode_SMTP_TLS_MODE_IMPLICIT
+	default:
+		return settings_pb.SMTPTLSMode_SMTP_TLS_MODE_UNSPECIFIED
+	}
+}
+
+func smtpStateToPb(state domain.SMTPConfigState) settings_pb.SMTPConfigState {
+	switch state {
+	case domain.SMTPConfigStateActive:
+		return settings_pb.SMTPConfigState_SMTP_CONFIG_ACTIVE
+	case domain.SMTPConfigStateInactive:
+		return settings_pb.SMTPConfigState_SMTP_CONFIG_INACTIVE
+	default:
+		return settings_pb.SMTPConfigState_SMTP_CONFIG_STATE_UNSPECIFIED
+	}
+}
+
+func AddSMTPConfigHTTPToConfig(req *admin_pb.AddSMTPConfigHTTPRequest) *webhook.Config {
+	return &webhook.Config{
+		CallURL:    req.Endpoint,
+		Method:     http.MethodPost,
+		Headers:    headersToHTTPHeader(req.Headers),
+		SigningKey: req.SigningKey,
+	}
+}
+
+func UpdateSMTPConfigHTTPToConfig(req *admin_pb.UpdateSMTPConfigHTTPRequest) *webhook.Config {
+	return &webhook.Config{
+		CallURL:    req.Endpoint,
+		Method:     http.MethodPost,
+		Headers:    headersToHTTPHeader(req.Headers),
+		SigningKey: req.SigningKey,
+	}
+}
+
+func HTTPConfigToPb(config *query.HTTP) *settings_pb.HTTPConfig {
+	headers := make(map[string]string, len(config.Headers))
+	for key := range config.Headers {
+		headers[key] = config.Headers.Get(key)
+	}
+	return &settings_pb.HTTPConfig{
+		Endpoint:      config.Endpoint,
+		Headers:       headers,
+		HasSigningKey: config.SigningKey != nil,
+	}
+}
+
+func headersToHTTPHeader(headers map[string]string) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	httpHeaders := make(http.Header, len(headers))
+	for key, value := range headers {
+		httpHeaders.Set(key, value)
+	}
+	return httpHeaders
+}
+
 func SecurityPolicyToPb(policy *query.SecurityPolicy) *settings_pb.SecurityPolicy {
 	return &settings_pb.SecurityPolicy{
 		Details:               obj_grpc.ToViewDetailsPb(policy.Sequence, policy.CreationDate, policy.ChangeDate, policy.AggregateID),
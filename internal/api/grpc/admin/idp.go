@@ -457,6 +457,150 @@ func (s *Server) RegenerateSAMLProviderCertificate(ctx context.Context, req *adm
 	}, nil
 }
 
+func (s *Server) GetProviderGroupMapping(ctx context.Context, req *admin_pb.GetProviderGroupMappingRequest) (*admin_pb.GetProviderGroupMappingResponse, error) {
+	mapping, err := s.query.IDPGroupMappingByIDPID(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetProviderGroupMappingResponse{Mapping: idp_grpc.GroupMappingToPb(mapping)}, nil
+}
+
+func (s *Server) SetProviderGroupMapping(ctx context.Context, req *admin_pb.SetProviderGroupMappingRequest) (*admin_pb.SetProviderGroupMappingResponse, error) {
+	details, err := s.command.SetInstanceIDPGroupMapping(ctx, req.Id, idp_grpc.GroupMappingToDomain(req.Mapping))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetProviderGroupMappingResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderAttributeMapping(ctx context.Context, req *admin_pb.GetProviderAttributeMappingRequest) (*admin_pb.GetProviderAttributeMappingResponse, error) {
+	mapping, err := s.query.IDPAttributeMappingByIDPID(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetProviderAttributeMappingResponse{Mapping: idp_grpc.AttributeMappingToPb(mapping)}, nil
+}
+
+func (s *Server) SetProviderAttributeMapping(ctx context.Context, req *admin_pb.SetProviderAttributeMappingRequest) (*admin_pb.SetProviderAttributeMappingResponse, error) {
+	details, err := s.command.SetInstanceIDPAttributeMapping(ctx, req.Id, idp_grpc.AttributeMappingToDomain(req.Mapping))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetProviderAttributeMappingResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) PreviewProviderAttributeMapping(ctx context.Context, req *admin_pb.PreviewProviderAttributeMappingRequest) (*admin_pb.PreviewProviderAttributeMappingResponse, error) {
+	mapping := idp_grpc.AttributeMappingToDomain(req.Mapping)
+	if req.Mapping == nil {
+		var err error
+		mapping, err = s.query.IDPAttributeMappingByIDPID(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+		if err != nil {
+			return nil, err
+		}
+	}
+	user, err := idp_grpc.PreviewAttributeMapping(mapping, req.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.PreviewProviderAttributeMappingResponse{User: user}, nil
+}
+
+func (s *Server) GetProviderLDAPSync(ctx context.Context, req *admin_pb.GetProviderLDAPSyncRequest) (*admin_pb.GetProviderLDAPSyncResponse, error) {
+	sync, err := s.query.IDPLDAPSyncByIDPID(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetProviderLDAPSyncResponse{Sync: idp_grpc.LDAPSyncToPb(sync)}, nil
+}
+
+func (s *Server) SetProviderLDAPSync(ctx context.Context, req *admin_pb.SetProviderLDAPSyncRequest) (*admin_pb.SetProviderLDAPSyncResponse, error) {
+	details, err := s.command.SetInstanceIDPLDAPSync(ctx, req.Id, idp_grpc.LDAPSyncToDomain(req.Sync))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetProviderLDAPSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RunProviderLDAPSync(ctx context.Context, req *admin_pb.RunProviderLDAPSyncRequest) (*admin_pb.RunProviderLDAPSyncResponse, error) {
+	run, err := s.command.SyncLDAPIDP(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RunProviderLDAPSyncResponse{Run: idp_grpc.LDAPSyncRunToPb(run)}, nil
+}
+
+func (s *Server) ListProviderLDAPSyncRuns(ctx context.Context, req *admin_pb.ListProviderLDAPSyncRunsRequest) (*admin_pb.ListProviderLDAPSyncRunsResponse, error) {
+	limit := uint64(req.Limit)
+	if limit == 0 {
+		limit = idp_grpc.DefaultLDAPSyncRunsLimit
+	}
+	runs, err := s.query.IDPLDAPSyncRuns(ctx, req.Id, authz.GetInstance(ctx).InstanceID(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListProviderLDAPSyncRunsResponse{Result: idp_grpc.LDAPSyncRunsToPb(runs)}, nil
+}
+
+func (s *Server) GetProviderTokenVault(ctx context.Context, req *admin_pb.GetProviderTokenVaultRequest) (*admin_pb.GetProviderTokenVaultResponse, error) {
+	enabled, err := s.query.IDPTokenVaultEnabled(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetProviderTokenVaultResponse{TokenVault: idp_grpc.TokenVaultToPb(enabled)}, nil
+}
+
+func (s *Server) SetProviderTokenVault(ctx context.Context, req *admin_pb.SetProviderTokenVaultRequest) (*admin_pb.SetProviderTokenVaultResponse, error) {
+	details, err := s.command.SetInstanceIDPTokenVault(ctx, req.Id, req.GetTokenVault().GetEnabled())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetProviderTokenVaultResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderLogoutPropagation(ctx context.Context, req *admin_pb.GetProviderLogoutPropagationRequest) (*admin_pb.GetProviderLogoutPropagationResponse, error) {
+	enabled, err := s.query.IDPLogoutPropagationEnabled(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetProviderLogoutPropagationResponse{LogoutPropagation: idp_grpc.LogoutPropagationToPb(enabled)}, nil
+}
+
+func (s *Server) SetProviderLogoutPropagation(ctx context.Context, req *admin_pb.SetProviderLogoutPropagationRequest) (*admin_pb.SetProviderLogoutPropagationResponse, error) {
+	details, err := s.command.SetInstanceIDPLogoutPropagation(ctx, req.Id, req.GetLogoutPropagation().GetEnabled())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetProviderLogoutPropagationResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderIDPInitiatedLogin(ctx context.Context, req *admin_pb.GetProviderIDPInitiatedLoginRequest) (*admin_pb.GetProviderIDPInitiatedLoginResponse, error) {
+	login, err := s.query.IDPInitiatedLogin(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetProviderIDPInitiatedLoginResponse{IdpInitiatedLogin: idp_grpc.IDPInitiatedLoginToPb(login)}, nil
+}
+
+func (s *Server) SetProviderIDPInitiatedLogin(ctx context.Context, req *admin_pb.SetProviderIDPInitiatedLoginRequest) (*admin_pb.SetProviderIDPInitiatedLoginResponse, error) {
+	details, err := s.command.SetInstanceIDPInitiatedLogin(ctx, req.Id, idp_grpc.IDPInitiatedLoginToDomain(req.GetIdpInitiatedLogin()))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetProviderIDPInitiatedLoginResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) DeleteProvider(ctx context.Context, req *admin_pb.DeleteProviderRequest) (*admin_pb.DeleteProviderResponse, error) {
 	details, err := s.command.DeleteInstanceProvider(ctx, req.Id)
 	if err != nil {
@@ -0,0 +1,33 @@
+package admin
+
+import (
+	"context"
+
+	object_pb "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListNotifications(ctx context.Context, req *admin.ListNotificationsRequest) (*admin.ListNotificationsResponse, error) {
+	queries, err := listNotificationsToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.query.SearchNotifications(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin.ListNotificationsResponse{
+		Result:  notificationsToPb(resp.Notifications),
+		Details: object_pb.ToListDetails(resp.Count, resp.Sequence, resp.LastRun),
+	}, nil
+}
+
+func (s *Server) ResendNotification(ctx context.Context, req *admin.ResendNotificationRequest) (*admin.ResendNotificationResponse, error) {
+	details, err := s.command.ResendNotification(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin.ResendNotificationResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
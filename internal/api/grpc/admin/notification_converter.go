@@ -0,0 +1,149 @@
+package admin
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+	notification_pb "github.com/zitadel/zitadel/pkg/grpc/notification"
+)
+
+func listNotificationsToModel(req *admin_pb.ListNotificationsRequest) (*query.NotificationSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := notificationQueriesToModel(req.GetQueries())
+	if err != nil {
+		return nil, err
+	}
+	return &query.NotificationSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: notificationFieldNameToSortingColumn(req.SortingColumn),
+		},
+		Queries: queries,
+	}, nil
+}
+
+func notificationQueriesToModel(queries []*notification_pb.NotificationQuery) (q []query.SearchQuery, err error) {
+	q = make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = notificationQueryToModel(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func notificationQueryToModel(notificationQuery *notification_pb.NotificationQuery) (query.SearchQuery, error) {
+	switch q := notificationQuery.Query.(type) {
+	case *notification_pb.NotificationQuery_StateQuery:
+		return query.NewNotificationStateSearchQuery(notificationStateToDomain(q.StateQuery.GetState()))
+	case *notification_pb.NotificationQuery_ChannelQuery:
+		return query.NewNotificationChannelSearchQuery(notificationChannelToDomain(q.ChannelQuery.GetChannel()))
+	case *notification_pb.NotificationQuery_RecipientQuery:
+		return query.NewNotificationRecipientSearchQuery(object.TextMethodToQuery(q.RecipientQuery.GetMethod()), q.RecipientQuery.GetRecipient())
+	case *notification_pb.NotificationQuery_TriggeringAggregateIdQuery:
+		return query.NewNotificationTriggeringAggregateIDSearchQuery(q.TriggeringAggregateIdQuery.GetTriggeringAggregateId())
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "ADMIN-Ohd4u", "List.Query.Invalid")
+	}
+}
+
+func notificationFieldNameToSortingColumn(field notification_pb.NotificationFieldName) query.Column {
+	switch field {
+	case notification_pb.NotificationFieldName_NOTIFICATION_FIELD_NAME_CHANGE_DATE:
+		return query.NotificationOutboxColumnChangeDate
+	case notification_pb.NotificationFieldName_NOTIFICATION_FIELD_NAME_NEXT_ATTEMPT:
+		return query.NotificationOutboxColumnNextAttempt
+	default:
+		return query.NotificationOutboxColumnCreationDate
+	}
+}
+
+func notificationsToPb(notifications []*query.Notification) []*notification_pb.Notification {
+	resp := make([]*notification_pb.Notification, len(notifications))
+	for i, notification := range notifications {
+		resp[i] = notificationToPb(notification)
+	}
+	return resp
+}
+
+func notificationToPb(n *query.Notification) *notification_pb.Notification {
+	notification := &notification_pb.Notification{
+		Details:               object.ToViewDetailsPb(n.Sequence, n.CreationDate, n.ChangeDate, n.ResourceOwner),
+		Id:                    n.ID,
+		State:                 notificationStateToPb(n.State),
+		Channel:               notificationChannelToPb(n.Channel),
+		Recipient:             n.Recipient,
+		TriggeringAggregateId: n.TriggeringAggregateID,
+		TriggeringEventType:   string(n.TriggeringEventType),
+		Attempts:              uint32(n.Attempts),
+		LastError:             n.LastError,
+	}
+	if n.State == domain.NotificationStateRetrying {
+		notification.NextAttempt = timestamppb.New(n.NextAttempt)
+	}
+	return notification
+}
+
+func notificationStateToPb(state domain.NotificationState) notification_pb.NotificationState {
+	switch state {
+	case domain.NotificationStatePending:
+		return notification_pb.NotificationState_NOTIFICATION_STATE_PENDING
+	case domain.NotificationStateSent:
+		return notification_pb.NotificationState_NOTIFICATION_STATE_SENT
+	case domain.NotificationStateRetrying:
+		return notification_pb.NotificationState_NOTIFICATION_STATE_RETRYING
+	case domain.NotificationStateFailed:
+		return notification_pb.NotificationState_NOTIFICATION_STATE_FAILED
+	case domain.NotificationStateUnspecified:
+		return notification_pb.NotificationState_NOTIFICATION_STATE_UNSPECIFIED
+	default:
+		return notification_pb.NotificationState_NOTIFICATION_STATE_UNSPECIFIED
+	}
+}
+
+func notificationStateToDomain(state notification_pb.NotificationState) domain.NotificationState {
+	switch state {
+	case notification_pb.NotificationState_NOTIFICATION_STATE_PENDING:
+		return domain.NotificationStatePending
+	case notification_pb.NotificationState_NOTIFICATION_STATE_SENT:
+		return domain.NotificationStateSent
+	case notification_pb.NotificationState_NOTIFICATION_STATE_RETRYING:
+		return domain.NotificationStateRetrying
+	case notification_pb.NotificationState_NOTIFICATION_STATE_FAILED:
+		return domain.NotificationStateFailed
+	case notification_pb.NotificationState_NOTIFICATION_STATE_UNSPECIFIED:
+		return domain.NotificationStateUnspecified
+	default:
+		return domain.NotificationStateUnspecified
+	}
+}
+
+func notificationChannelToPb(channel domain.NotificationType) notification_pb.NotificationChannel {
+	switch channel {
+	case domain.NotificationTypeEmail:
+		return notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL
+	case domain.NotificationTypeSms:
+		return notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_SMS
+	default:
+		return notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_UNSPECIFIED
+	}
+}
+
+func notificationChannelToDomain(channel notification_pb.NotificationChannel) domain.NotificationType {
+	switch channel {
+	case notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_SMS:
+		return domain.NotificationTypeSms
+	case notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL,
+		notification_pb.NotificationChannel_NOTIFICATION_CHANNEL_UNSPECIFIED:
+		return domain.NotificationTypeEmail
+	default:
+		return domain.NotificationTypeEmail
+	}
+}
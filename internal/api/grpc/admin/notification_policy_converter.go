@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func AddNotificationPolicyToDomain(req *admin_pb.AddNotificationPolicyRequest) *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		PasswordChange:         req.PasswordChange,
+		UnknownUserAgentSignIn: req.UnknownUserAgentSignIn,
+		MFAAdded:               req.MfaAdded,
+		MFARemoved:             req.MfaRemoved,
+		EmailChanged:           req.EmailChanged,
+		PhoneChanged:           req.PhoneChanged,
+		AccountLocked:          req.AccountLocked,
+		MachineCredentialAdded: req.MachineCredentialAdded,
+	}
+}
+
+func UpdateNotificationPolicyToDomain(req *admin_pb.UpdateNotificationPolicyRequest) *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		PasswordChange:         req.PasswordChange,
+		UnknownUserAgentSignIn: req.UnknownUserAgentSignIn,
+		MFAAdded:               req.MfaAdded,
+		MFARemoved:             req.MfaRemoved,
+		EmailChanged:           req.EmailChanged,
+		PhoneChanged:           req.PhoneChanged,
+		AccountLocked:          req.AccountLocked,
+		MachineCredentialAdded: req.MachineCredentialAdded,
+	}
+}
@@ -1,9 +1,12 @@
 package admin
 
 import (
+	"net/http"
+
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 	settings_pb "github.com/zitadel/zitadel/pkg/grpc/settings"
@@ -41,6 +44,11 @@ func SMSConfigToPb(config *query.SMSConfig) settings_pb.SMSConfig {
 	if config.TwilioConfig != nil {
 		return TwilioConfigToPb(config.TwilioConfig)
 	}
+	if config.HTTPConfig != nil {
+		return &settings_pb.SMSProvider_Http{
+			Http: HTTPConfigToPb(config.HTTPConfig),
+		}
+	}
 	return nil
 }
 
@@ -78,3 +86,21 @@ func UpdateSMSConfigTwilioToConfig(req *admin_pb.UpdateSMSProviderTwilioRequest)
 		SenderNumber: req.SenderNumber,
 	}
 }
+
+func AddSMSConfigHTTPToConfig(req *admin_pb.AddSMSProviderHTTPRequest) *webhook.Config {
+	return &webhook.Config{
+		CallURL:    req.Endpoint,
+		Method:     http.MethodPost,
+		Headers:    headersToHTTPHeader(req.Headers),
+		SigningKey: req.SigningKey,
+	}
+}
+
+func UpdateSMSConfigHTTPToConfig(req *admin_pb.UpdateSMSProviderHTTPRequest) *webhook.Config {
+	return &webhook.Config{
+		CallURL:    req.Endpoint,
+		Method:     http.MethodPost,
+		Headers:    headersToHTTPHeader(req.Headers),
+		SigningKey: req.SigningKey,
+	}
+}
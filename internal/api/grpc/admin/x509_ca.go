@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	object_pb "github.com/zitadel/zitadel/internal/api/grpc/object"
+	x509_grpc "github.com/zitadel/zitadel/internal/api/grpc/x509"
+	"github.com/zitadel/zitadel/internal/command"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListX509CAs(ctx context.Context, _ *admin_pb.ListX509CAsRequest) (*admin_pb.ListX509CAsResponse, error) {
+	cas, err := s.query.X509CAsByResourceOwner(ctx, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListX509CAsResponse{Result: x509_grpc.CAsToPb(cas)}, nil
+}
+
+func (s *Server) AddX509CA(ctx context.Context, req *admin_pb.AddX509CARequest) (*admin_pb.AddX509CAResponse, error) {
+	id, details, err := s.command.AddInstanceX509CA(ctx, &command.AddX509CA{
+		Name:        req.GetName(),
+		Certificate: []byte(req.GetCertificate()),
+		CRL:         req.GetCrl(),
+		UserMapping: x509_grpc.UserMappingToDomain(req.GetUserMapping()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddX509CAResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) SetX509CACRL(ctx context.Context, req *admin_pb.SetX509CACRLRequest) (*admin_pb.SetX509CACRLResponse, error) {
+	details, err := s.command.SetInstanceX509CACRL(ctx, req.GetId(), req.GetCrl())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetX509CACRLResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveX509CA(ctx context.Context, req *admin_pb.RemoveX509CARequest) (*admin_pb.RemoveX509CAResponse, error) {
+	details, err := s.command.RemoveInstanceX509CA(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveX509CAResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
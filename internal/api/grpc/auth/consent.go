@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/pkg/grpc/auth"
+)
+
+func (s *Server) ListMyConsents(ctx context.Context, req *auth.ListMyConsentsRequest) (*auth.ListMyConsentsResponse, error) {
+	queries, err := ListMyConsentsRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchUserConsents(ctx, true, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ListMyConsentsResponse{
+		Result:  user_grpc.ConsentsToPb(res.Consents),
+		Details: object.ToListDetails(res.Count, res.Sequence, res.LastRun),
+	}, nil
+}
+
+func (s *Server) RevokeMyConsent(ctx context.Context, req *auth.RevokeMyConsentRequest) (*auth.RevokeMyConsentResponse, error) {
+	ctxData := authz.GetCtxData(ctx)
+	details, err := s.command.RevokeUserConsent(ctx, ctxData.UserID, ctxData.ResourceOwner, req.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.RevokeMyConsentResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func ListMyConsentsRequestToQuery(ctx context.Context, req *auth.ListMyConsentsRequest) (*query.UserConsentSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	userIDQuery, err := query.NewUserConsentUserIDSearchQuery(authz.GetCtxData(ctx).UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &query.UserConsentSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{userIDQuery},
+	}, nil
+}
@@ -15,8 +15,12 @@ func (s *Server) GetMyEmail(ctx context.Context, _ *auth_pb.GetMyEmailRequest) (
 	if err != nil {
 		return nil, err
 	}
+	pendingChange, err := s.query.PendingEmailChange(ctx, authz.GetCtxData(ctx).UserID, email.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
 	return &auth_pb.GetMyEmailResponse{
-		Email: user.ModelEmailToPb(email),
+		Email: user.PendingEmailChangeToPb(user.ModelEmailToPb(email), pendingChange),
 		Details: object.ToViewDetailsPb(
 			email.Sequence,
 			email.CreationDate,
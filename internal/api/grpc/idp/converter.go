@@ -3,12 +3,16 @@ package idp
 import (
 	"github.com/crewjam/saml"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
+	providers "github.com/zitadel/zitadel/internal/idp"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/zerrors"
 	idp_pb "github.com/zitadel/zitadel/pkg/grpc/idp"
 )
 
@@ -298,6 +302,207 @@ func LDAPAttributesToCommand(attributes *idp_pb.LDAPAttributes) idp.LDAPAttribut
 	}
 }
 
+func GroupMappingToDomain(mapping *idp_pb.IDPGroupMapping) *domain.IDPGroupMapping {
+	if mapping == nil {
+		return &domain.IDPGroupMapping{}
+	}
+	rules := make([]*domain.IDPGroupMappingRule, len(mapping.GetRules()))
+	for i, rule := range mapping.GetRules() {
+		rules[i] = &domain.IDPGroupMappingRule{
+			Group:          rule.GetGroup(),
+			ProjectID:      rule.GetProjectId(),
+			RoleKeys:       rule.GetRoleKeys(),
+			OrgMemberRoles: rule.GetOrgMemberRoles(),
+		}
+	}
+	return &domain.IDPGroupMapping{
+		GroupsAttribute: mapping.GetGroupsAttribute(),
+		Rules:           rules,
+	}
+}
+
+func GroupMappingToPb(mapping *domain.IDPGroupMapping) *idp_pb.IDPGroupMapping {
+	rules := make([]*idp_pb.IDPGroupMappingRule, len(mapping.Rules))
+	for i, rule := range mapping.Rules {
+		rules[i] = &idp_pb.IDPGroupMappingRule{
+			Group:          rule.Group,
+			ProjectId:      rule.ProjectID,
+			RoleKeys:       rule.RoleKeys,
+			OrgMemberRoles: rule.OrgMemberRoles,
+		}
+	}
+	return &idp_pb.IDPGroupMapping{
+		GroupsAttribute: mapping.GroupsAttribute,
+		Rules:           rules,
+	}
+}
+
+func AttributeMappingToDomain(mapping *idp_pb.IDPAttributeMapping) *domain.IDPAttributeMapping {
+	if mapping == nil {
+		return &domain.IDPAttributeMapping{}
+	}
+	metadata := make([]*domain.IDPMetadataMapping, len(mapping.GetMetadata()))
+	for i, entry := range mapping.GetMetadata() {
+		metadata[i] = &domain.IDPMetadataMapping{
+			Key:        entry.GetKey(),
+			Expression: entry.GetExpression(),
+		}
+	}
+	return &domain.IDPAttributeMapping{
+		FirstName:         mapping.GetFirstName(),
+		LastName:          mapping.GetLastName(),
+		DisplayName:       mapping.GetDisplayName(),
+		NickName:          mapping.GetNickName(),
+		PreferredUsername: mapping.GetPreferredUsername(),
+		Email:             mapping.GetEmail(),
+		EmailVerified:     mapping.GetEmailVerified(),
+		Phone:             mapping.GetPhone(),
+		PhoneVerified:     mapping.GetPhoneVerified(),
+		PreferredLanguage: mapping.GetPreferredLanguage(),
+		Metadata:          metadata,
+	}
+}
+
+func AttributeMappingToPb(mapping *domain.IDPAttributeMapping) *idp_pb.IDPAttributeMapping {
+	metadata := make([]*idp_pb.IDPMetadataMapping, len(mapping.Metadata))
+	for i, entry := range mapping.Metadata {
+		metadata[i] = &idp_pb.IDPMetadataMapping{
+			Key:        entry.Key,
+			Expression: entry.Expression,
+		}
+	}
+	return &idp_pb.IDPAttributeMapping{
+		FirstName:         mapping.FirstName,
+		LastName:          mapping.LastName,
+		DisplayName:       mapping.DisplayName,
+		NickName:          mapping.NickName,
+		PreferredUsername: mapping.PreferredUsername,
+		Email:             mapping.Email,
+		EmailVerified:     mapping.EmailVerified,
+		Phone:             mapping.Phone,
+		PhoneVerified:     mapping.PhoneVerified,
+		PreferredLanguage: mapping.PreferredLanguage,
+		Metadata:          metadata,
+	}
+}
+
+// PreviewAttributeMapping maps the sample payload of an external user with the mapping.
+func PreviewAttributeMapping(mapping *domain.IDPAttributeMapping, payload *structpb.Struct) (*idp_pb.IDPAttributeMappingPreview, error) {
+	if !mapping.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "IDP-ieM7o", "Errors.IDPConfig.AttributeMappingInvalid")
+	}
+	claims := payload.AsMap()
+	user := new(domain.ExternalUser)
+	mapping.Apply(user, func(path string) []string {
+		return providers.ClaimValues(claims, path)
+	})
+	metadata := make([]*idp_pb.IDPMetadataPreview, len(user.Metadatas))
+	for i, entry := range user.Metadatas {
+		metadata[i] = &idp_pb.IDPMetadataPreview{
+			Key:   entry.Key,
+			Value: string(entry.Value),
+		}
+	}
+	preview := &idp_pb.IDPAttributeMappingPreview{
+		FirstName:         user.FirstName,
+		LastName:          user.LastName,
+		DisplayName:       user.DisplayName,
+		NickName:          user.NickName,
+		PreferredUsername: user.PreferredUsername,
+		Email:             string(user.Email),
+		EmailVerified:     user.IsEmailVerified,
+		Phone:             string(user.Phone),
+		PhoneVerified:     user.IsPhoneVerified,
+		Metadata:          metadata,
+	}
+	if !user.PreferredLanguage.IsRoot() {
+		preview.PreferredLanguage = user.PreferredLanguage.String()
+	}
+	return preview, nil
+}
+
+// DefaultLDAPSyncRunsLimit is the number of runs returned if the request does not specify a limit
+const DefaultLDAPSyncRunsLimit = 20
+
+func LDAPSyncToDomain(sync *idp_pb.IDPLDAPSync) *domain.IDPLDAPSync {
+	return &domain.IDPLDAPSync{
+		Enabled:         sync.GetEnabled(),
+		Interval:        sync.GetInterval().AsDuration(),
+		SearchBase:      sync.GetSearchBase(),
+		Filter:          sync.GetFilter(),
+		CreateUsers:     sync.GetCreateUsers(),
+		UpdateUsers:     sync.GetUpdateUsers(),
+		DeactivateUsers: sync.GetDeactivateUsers(),
+	}
+}
+
+func LDAPSyncToPb(sync *domain.IDPLDAPSync) *idp_pb.IDPLDAPSync {
+	return &idp_pb.IDPLDAPSync{
+		Enabled:         sync.Enabled,
+		Interval:        durationpb.New(sync.Interval),
+		SearchBase:      sync.SearchBase,
+		Filter:          sync.Filter,
+		CreateUsers:     sync.CreateUsers,
+		UpdateUsers:     sync.UpdateUsers,
+		DeactivateUsers: sync.DeactivateUsers,
+	}
+}
+
+func LDAPSyncRunsToPb(runs []*domain.IDPLDAPSyncRun) []*idp_pb.IDPLDAPSyncRun {
+	result := make([]*idp_pb.IDPLDAPSyncRun, len(runs))
+	for i, run := range runs {
+		result[i] = LDAPSyncRunToPb(run)
+	}
+	return result
+}
+
+func LDAPSyncRunToPb(run *domain.IDPLDAPSyncRun) *idp_pb.IDPLDAPSyncRun {
+	return &idp_pb.IDPLDAPSyncRun{
+		StartedAt:   timestamppb.New(run.StartedAt),
+		FinishedAt:  timestamppb.New(run.FinishedAt),
+		Users:       run.Users,
+		Created:     run.Created,
+		Updated:     run.Updated,
+		Deactivated: run.Deactivated,
+		Failed:      run.Failed,
+		Error:       run.Error,
+	}
+}
+
+func TokenVaultToPb(enabled bool) *idp_pb.IDPTokenVault {
+	return &idp_pb.IDPTokenVault{Enabled: enabled}
+}
+
+func LogoutPropagationToPb(enabled bool) *idp_pb.IDPLogoutPropagation {
+	return &idp_pb.IDPLogoutPropagation{Enabled: enabled}
+}
+
+func IDPInitiatedLoginToPb(login *domain.IDPInitiatedLogin) *idp_pb.IDPInitiatedLogin {
+	return &idp_pb.IDPInitiatedLogin{
+		Enabled:            login.Enabled,
+		DefaultRedirectUri: login.DefaultRedirectURI,
+	}
+}
+
+func IDPInitiatedLoginToDomain(login *idp_pb.IDPInitiatedLogin) *domain.IDPInitiatedLogin {
+	return &domain.IDPInitiatedLogin{
+		Enabled:            login.GetEnabled(),
+		DefaultRedirectURI: login.GetDefaultRedirectUri(),
+	}
+}
+
+func LinkTokensToPb(tokens *domain.IDPLinkTokens) *idp_pb.IDPLinkTokens {
+	pb := &idp_pb.IDPLinkTokens{
+		AccessToken: tokens.AccessToken,
+		TokenType:   tokens.TokenType,
+		IdToken:     tokens.IDToken,
+	}
+	if !tokens.Expiry.IsZero() {
+		pb.Expiry = timestamppb.New(tokens.Expiry)
+	}
+	return pb
+}
+
 func AzureADTenantToCommand(tenant *idp_pb.AzureADTenant) string {
 	if tenant == nil {
 		return string(azuread.CommonTenant)
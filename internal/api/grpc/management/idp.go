@@ -449,6 +449,150 @@ func (s *Server) RegenerateSAMLProviderCertificate(ctx context.Context, req *mgm
 	}, nil
 }
 
+func (s *Server) GetProviderGroupMapping(ctx context.Context, req *mgmt_pb.GetProviderGroupMappingRequest) (*mgmt_pb.GetProviderGroupMappingResponse, error) {
+	mapping, err := s.query.IDPGroupMappingByIDPID(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetProviderGroupMappingResponse{Mapping: idp_grpc.GroupMappingToPb(mapping)}, nil
+}
+
+func (s *Server) SetProviderGroupMapping(ctx context.Context, req *mgmt_pb.SetProviderGroupMappingRequest) (*mgmt_pb.SetProviderGroupMappingResponse, error) {
+	details, err := s.command.SetOrgIDPGroupMapping(ctx, authz.GetCtxData(ctx).OrgID, req.Id, idp_grpc.GroupMappingToDomain(req.Mapping))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProviderGroupMappingResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderAttributeMapping(ctx context.Context, req *mgmt_pb.GetProviderAttributeMappingRequest) (*mgmt_pb.GetProviderAttributeMappingResponse, error) {
+	mapping, err := s.query.IDPAttributeMappingByIDPID(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetProviderAttributeMappingResponse{Mapping: idp_grpc.AttributeMappingToPb(mapping)}, nil
+}
+
+func (s *Server) SetProviderAttributeMapping(ctx context.Context, req *mgmt_pb.SetProviderAttributeMappingRequest) (*mgmt_pb.SetProviderAttributeMappingResponse, error) {
+	details, err := s.command.SetOrgIDPAttributeMapping(ctx, authz.GetCtxData(ctx).OrgID, req.Id, idp_grpc.AttributeMappingToDomain(req.Mapping))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProviderAttributeMappingResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) PreviewProviderAttributeMapping(ctx context.Context, req *mgmt_pb.PreviewProviderAttributeMappingRequest) (*mgmt_pb.PreviewProviderAttributeMappingResponse, error) {
+	mapping := idp_grpc.AttributeMappingToDomain(req.Mapping)
+	if req.Mapping == nil {
+		var err error
+		mapping, err = s.query.IDPAttributeMappingByIDPID(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	user, err := idp_grpc.PreviewAttributeMapping(mapping, req.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.PreviewProviderAttributeMappingResponse{User: user}, nil
+}
+
+func (s *Server) GetProviderLDAPSync(ctx context.Context, req *mgmt_pb.GetProviderLDAPSyncRequest) (*mgmt_pb.GetProviderLDAPSyncResponse, error) {
+	sync, err := s.query.IDPLDAPSyncByIDPID(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetProviderLDAPSyncResponse{Sync: idp_grpc.LDAPSyncToPb(sync)}, nil
+}
+
+func (s *Server) SetProviderLDAPSync(ctx context.Context, req *mgmt_pb.SetProviderLDAPSyncRequest) (*mgmt_pb.SetProviderLDAPSyncResponse, error) {
+	details, err := s.command.SetOrgIDPLDAPSync(ctx, authz.GetCtxData(ctx).OrgID, req.Id, idp_grpc.LDAPSyncToDomain(req.Sync))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProviderLDAPSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RunProviderLDAPSync(ctx context.Context, req *mgmt_pb.RunProviderLDAPSyncRequest) (*mgmt_pb.RunProviderLDAPSyncResponse, error) {
+	run, err := s.command.SyncLDAPIDP(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RunProviderLDAPSyncResponse{Run: idp_grpc.LDAPSyncRunToPb(run)}, nil
+}
+
+func (s *Server) ListProviderLDAPSyncRuns(ctx context.Context, req *mgmt_pb.ListProviderLDAPSyncRunsRequest) (*mgmt_pb.ListProviderLDAPSyncRunsResponse, error) {
+	limit := uint64(req.Limit)
+	if limit == 0 {
+		limit = idp_grpc.DefaultLDAPSyncRunsLimit
+	}
+	runs, err := s.query.IDPLDAPSyncRuns(ctx, req.Id, authz.GetCtxData(ctx).OrgID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListProviderLDAPSyncRunsResponse{Result: idp_grpc.LDAPSyncRunsToPb(runs)}, nil
+}
+
+func (s *Server) GetProviderTokenVault(ctx context.Context, req *mgmt_pb.GetProviderTokenVaultRequest) (*mgmt_pb.GetProviderTokenVaultResponse, error) {
+	enabled, err := s.query.IDPTokenVaultEnabled(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetProviderTokenVaultResponse{TokenVault: idp_grpc.TokenVaultToPb(enabled)}, nil
+}
+
+func (s *Server) SetProviderTokenVault(ctx context.Context, req *mgmt_pb.SetProviderTokenVaultRequest) (*mgmt_pb.SetProviderTokenVaultResponse, error) {
+	details, err := s.command.SetOrgIDPTokenVault(ctx, authz.GetCtxData(ctx).OrgID, req.Id, req.GetTokenVault().GetEnabled())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProviderTokenVaultResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderLogoutPropagation(ctx context.Context, req *mgmt_pb.GetProviderLogoutPropagationRequest) (*mgmt_pb.GetProviderLogoutPropagationResponse, error) {
+	enabled, err := s.query.IDPLogoutPropagationEnabled(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetProviderLogoutPropagationResponse{LogoutPropagation: idp_grpc.LogoutPropagationToPb(enabled)}, nil
+}
+
+func (s *Server) SetProviderLogoutPropagation(ctx context.Context, req *mgmt_pb.SetProviderLogoutPropagationRequest) (*mgmt_pb.SetProviderLogoutPropagationResponse, error) {
+	details, err := s.command.SetOrgIDPLogoutPropagation(ctx, authz.GetCtxData(ctx).OrgID, req.Id, req.GetLogoutPropagation().GetEnabled())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProviderLogoutPropagationResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderIDPInitiatedLogin(ctx context.Context, req *mgmt_pb.GetProviderIDPInitiatedLoginRequest) (*mgmt_pb.GetProviderIDPInitiatedLoginResponse, error) {
+	login, err := s.query.IDPInitiatedLogin(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetProviderIDPInitiatedLoginResponse{IdpInitiatedLogin: idp_grpc.IDPInitiatedLoginToPb(login)}, nil
+}
+
+func (s *Server) SetProviderIDPInitiatedLogin(ctx context.Context, req *mgmt_pb.SetProviderIDPInitiatedLoginRequest) (*mgmt_pb.SetProviderIDPInitiatedLoginResponse, error) {
+	details, err := s.command.SetOrgIDPInitiatedLogin(ctx, authz.GetCtxData(ctx).OrgID, req.Id, idp_grpc.IDPInitiatedLoginToDomain(req.GetIdpInitiatedLogin()))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProviderIDPInitiatedLoginResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) DeleteProvider(ctx context.Context, req *mgmt_pb.DeleteProviderRequest) (*mgmt_pb.DeleteProviderResponse, error) {
 	details, err := s.command.DeleteOrgProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id)
 	if err != nil {
@@ -408,3 +408,35 @@ func (s *Server) BulkRemoveOrgMetadata(ctx context.Context, req *mgmt_pb.BulkRem
 		Details: obj_grpc.DomainToChangeDetailsPb(result),
 	}, nil
 }
+
+func (s *Server) GetOrgNotificationProviders(ctx context.Context, req *mgmt_pb.GetOrgNotificationProvidersRequest) (*mgmt_pb.GetOrgNotificationProvidersResponse, error) {
+	providers, err := s.query.OrgNotificationProviders(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetOrgNotificationProvidersResponse{
+		Details:      object.ToViewDetailsPb(providers.Sequence, providers.CreationDate, providers.ChangeDate, providers.OrgID),
+		SmtpConfigId: providers.SMTPConfigID,
+		SmsConfigId:  providers.SMSConfigID,
+	}, nil
+}
+
+func (s *Server) SetOrgNotificationProviders(ctx context.Context, req *mgmt_pb.SetOrgNotificationProvidersRequest) (*mgmt_pb.SetOrgNotificationProvidersResponse, error) {
+	details, err := s.command.SetOrgNotificationProviders(ctx, authz.GetCtxData(ctx).OrgID, req.SmtpConfigId, req.SmsConfigId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetOrgNotificationProvidersResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveOrgNotificationProviders(ctx context.Context, req *mgmt_pb.RemoveOrgNotificationProvidersRequest) (*mgmt_pb.RemoveOrgNotificationProvidersResponse, error) {
+	details, err := s.command.RemoveOrgNotificationProviders(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveOrgNotificationProvidersResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
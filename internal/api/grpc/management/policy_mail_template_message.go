@@ -0,0 +1,87 @@
+package management
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListCustomMailTemplateMessages(ctx context.Context, req *mgmt_pb.ListCustomMailTemplateMessagesRequest) (*mgmt_pb.ListCustomMailTemplateMessagesResponse, error) {
+	messages, err := s.query.MailTemplateMessagesByOrg(ctx, authz.GetCtxData(ctx).OrgID, req.MessageType)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListCustomMailTemplateMessagesResponse{
+		Details: object.ToListDetails(messages.Count, messages.Sequence, messages.LastRun),
+		Result:  mailTemplateMessagesToPb(messages.Messages),
+	}, nil
+}
+
+func (s *Server) SetCustomMailTemplateMessage(ctx context.Context, req *mgmt_pb.SetCustomMailTemplateMessageRequest) (*mgmt_pb.SetCustomMailTemplateMessageResponse, error) {
+	details, err := s.command.SetOrgMailTemplateMessage(ctx, authz.GetCtxData(ctx).OrgID, setMailTemplateMessageToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomMailTemplateMessageResponse{
+		Details: object.ChangeToDetailsPb(
+			details.Sequence,
+			details.EventDate,
+			details.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) PreviewCustomMailTemplateMessage(ctx context.Context, req *mgmt_pb.PreviewCustomMailTemplateMessageRequest) (*mgmt_pb.PreviewCustomMailTemplateMessageResponse, error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	text, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, orgID, req.MessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.query.ActiveLabelPolicyByOrg(ctx, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+	templateData, err := types.GetPreviewTemplateData(ctx, text, policy)
+	if err != nil {
+		return &mgmt_pb.PreviewCustomMailTemplateMessageResponse{ValidationErrors: []string{err.Error()}}, nil
+	}
+	html, err := templates.GetParsedTemplate(string(req.Template), templateData)
+	if err != nil {
+		return &mgmt_pb.PreviewCustomMailTemplateMessageResponse{ValidationErrors: []string{err.Error()}}, nil
+	}
+	return &mgmt_pb.PreviewCustomMailTemplateMessageResponse{Html: html}, nil
+}
+
+func (s *Server) ActivateCustomMailTemplateMessage(ctx context.Context, req *mgmt_pb.ActivateCustomMailTemplateMessageRequest) (*mgmt_pb.ActivateCustomMailTemplateMessageResponse, error) {
+	details, err := s.command.ActivateOrgMailTemplateMessage(ctx, authz.GetCtxData(ctx).OrgID, req.MessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ActivateCustomMailTemplateMessageResponse{
+		Details: object.ChangeToDetailsPb(
+			details.Sequence,
+			details.EventDate,
+			details.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomMailTemplateMessageToDefault(ctx context.Context, req *mgmt_pb.ResetCustomMailTemplateMessageToDefaultRequest) (*mgmt_pb.ResetCustomMailTemplateMessageToDefaultResponse, error) {
+	details, err := s.command.RemoveOrgMailTemplateMessage(ctx, authz.GetCtxData(ctx).OrgID, req.MessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomMailTemplateMessageToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			details.Sequence,
+			details.EventDate,
+			details.ResourceOwner,
+		),
+	}, nil
+}
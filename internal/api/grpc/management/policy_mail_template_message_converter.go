@@ -0,0 +1,42 @@
+package management
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
+)
+
+func setMailTemplateMessageToDomain(req *mgmt_pb.SetCustomMailTemplateMessageRequest) *domain.MailTemplateMessage {
+	return &domain.MailTemplateMessage{
+		MessageType: req.MessageType,
+		Language:    language.Make(req.Language),
+		Template:    req.Template,
+	}
+}
+
+func mailTemplateMessagesToPb(messages []*query.MailTemplateMessage) []*policy_pb.MailTemplateMessage {
+	result := make([]*policy_pb.MailTemplateMessage, len(messages))
+	for i, message := range messages {
+		result[i] = mailTemplateMessageToPb(message)
+	}
+	return result
+}
+
+func mailTemplateMessageToPb(message *query.MailTemplateMessage) *policy_pb.MailTemplateMessage {
+	return &policy_pb.MailTemplateMessage{
+		Details: object.ToViewDetailsPb(
+			message.Sequence,
+			message.CreationDate,
+			message.ChangeDate,
+			message.OrgID,
+		),
+		MessageType: message.MessageType,
+		Language:    message.Language.String(),
+		Active:      message.ActiveStoreKey != "",
+		Preview:     message.StoreKey != message.ActiveStoreKey,
+	}
+}
@@ -0,0 +1,32 @@
+package management
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func AddNotificationPolicyToDomain(req *mgmt_pb.AddCustomNotificationPolicyRequest) *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		PasswordChange:         req.PasswordChange,
+		UnknownUserAgentSignIn: req.UnknownUserAgentSignIn,
+		MFAAdded:               req.MfaAdded,
+		MFARemoved:             req.MfaRemoved,
+		EmailChanged:           req.EmailChanged,
+		PhoneChanged:           req.PhoneChanged,
+		AccountLocked:          req.AccountLocked,
+		MachineCredentialAdded: req.MachineCredentialAdded,
+	}
+}
+
+func UpdateNotificationPolicyToDomain(req *mgmt_pb.UpdateCustomNotificationPolicyRequest) *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		PasswordChange:         req.PasswordChange,
+		UnknownUserAgentSignIn: req.UnknownUserAgentSignIn,
+		MFAAdded:               req.MfaAdded,
+		MFARemoved:             req.MfaRemoved,
+		EmailChanged:           req.EmailChanged,
+		PhoneChanged:           req.PhoneChanged,
+		AccountLocked:          req.AccountLocked,
+		MachineCredentialAdded: req.MachineCredentialAdded,
+	}
+}
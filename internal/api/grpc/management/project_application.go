@@ -308,3 +308,27 @@ func (s *Server) RemoveAppKey(ctx context.Context, req *mgmt_pb.RemoveAppKeyRequ
 		Details: object_grpc.DomainToChangeDetailsPb(details),
 	}, nil
 }
+
+func (s *Server) GetAppTLSClientAuth(ctx context.Context, req *mgmt_pb.GetAppTLSClientAuthRequest) (*mgmt_pb.GetAppTLSClientAuthResponse, error) {
+	tlsClientAuth, err := s.query.AppTLSClientAuth(ctx, req.ProjectId, req.AppId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetAppTLSClientAuthResponse{
+		SubjectDn:   tlsClientAuth.SubjectDN,
+		Certificate: tlsClientAuth.Certificate,
+	}, nil
+}
+
+func (s *Server) SetAppTLSClientAuth(ctx context.Context, req *mgmt_pb.SetAppTLSClientAuthRequest) (*mgmt_pb.SetAppTLSClientAuthResponse, error) {
+	details, err := s.command.SetApplicationTLSClientAuth(ctx, req.ProjectId, req.AppId, authz.GetCtxData(ctx).OrgID, &domain.ApplicationTLSClientAuth{
+		SubjectDN:   req.SubjectDn,
+		Certificate: req.Certificate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetAppTLSClientAuthResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
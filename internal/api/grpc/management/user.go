@@ -439,8 +439,12 @@ func (s *Server) GetHumanEmail(ctx context.Context, req *mgmt_pb.GetHumanEmailRe
 	if err != nil {
 		return nil, err
 	}
+	pendingChange, err := s.query.PendingEmailChange(ctx, req.UserId, email.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
 	return &mgmt_pb.GetHumanEmailResponse{
-		Email: user_grpc.EmailToPb(email),
+		Email: user_grpc.PendingEmailChangeToPb(user_grpc.EmailToPb(email), pendingChange),
 		Details: obj_grpc.ToViewDetailsPb(
 			email.Sequence,
 			email.CreationDate,
@@ -913,6 +917,26 @@ func (s *Server) RemoveHumanLinkedIDP(ctx context.Context, req *mgmt_pb.RemoveHu
 	}, nil
 }
 
+func (s *Server) GetHumanLinkedIDPTokens(ctx context.Context, req *mgmt_pb.GetHumanLinkedIDPTokensRequest) (*mgmt_pb.GetHumanLinkedIDPTokensResponse, error) {
+	tokens, err := s.command.UserIDPLinkTokens(ctx, req.UserId, authz.GetCtxData(ctx).OrgID, req.IdpId, req.LinkedUserId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetHumanLinkedIDPTokensResponse{
+		Tokens: idp_grpc.LinkTokensToPb(tokens),
+	}, nil
+}
+
+func (s *Server) RemoveHumanLinkedIDPTokens(ctx context.Context, req *mgmt_pb.RemoveHumanLinkedIDPTokensRequest) (*mgmt_pb.RemoveHumanLinkedIDPTokensResponse, error) {
+	objectDetails, err := s.command.RemoveUserIDPLinkTokens(ctx, req.UserId, authz.GetCtxData(ctx).OrgID, req.IdpId, req.LinkedUserId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveHumanLinkedIDPTokensResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
 func (s *Server) ListUserMemberships(ctx context.Context, req *mgmt_pb.ListUserMembershipsRequest) (*mgmt_pb.ListUserMembershipsResponse, error) {
 	request, err := ListUserMembershipsRequestToModel(ctx, req)
 	if err != nil {
@@ -0,0 +1,55 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	object_pb "github.com/zitadel/zitadel/internal/api/grpc/object"
+	x509_grpc "github.com/zitadel/zitadel/internal/api/grpc/x509"
+	"github.com/zitadel/zitadel/internal/command"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListX509CAs(ctx context.Context, _ *mgmt_pb.ListX509CAsRequest) (*mgmt_pb.ListX509CAsResponse, error) {
+	cas, err := s.query.X509CAsByResourceOwner(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListX509CAsResponse{Result: x509_grpc.CAsToPb(cas)}, nil
+}
+
+func (s *Server) AddX509CA(ctx context.Context, req *mgmt_pb.AddX509CARequest) (*mgmt_pb.AddX509CAResponse, error) {
+	id, details, err := s.command.AddOrgX509CA(ctx, authz.GetCtxData(ctx).OrgID, &command.AddX509CA{
+		Name:        req.GetName(),
+		Certificate: []byte(req.GetCertificate()),
+		CRL:         req.GetCrl(),
+		UserMapping: x509_grpc.UserMappingToDomain(req.GetUserMapping()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddX509CAResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) SetX509CACRL(ctx context.Context, req *mgmt_pb.SetX509CACRLRequest) (*mgmt_pb.SetX509CACRLResponse, error) {
+	details, err := s.command.SetOrgX509CACRL(ctx, authz.GetCtxData(ctx).OrgID, req.GetId(), req.GetCrl())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetX509CACRLResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveX509CA(ctx context.Context, req *mgmt_pb.RemoveX509CARequest) (*mgmt_pb.RemoveX509CAResponse, error) {
+	details, err := s.command.RemoveOrgX509CA(ctx, authz.GetCtxData(ctx).OrgID, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveX509CAResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
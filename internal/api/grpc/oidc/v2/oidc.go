@@ -31,15 +31,16 @@ func (s *Server) GetAuthRequest(ctx context.Context, req *oidc_pb.GetAuthRequest
 
 func authRequestToPb(a *query.AuthRequest) *oidc_pb.AuthRequest {
 	pba := &oidc_pb.AuthRequest{
-		Id:           a.ID,
-		CreationDate: timestamppb.New(a.CreationDate),
-		ClientId:     a.ClientID,
-		Scope:        a.Scope,
-		RedirectUri:  a.RedirectURI,
-		Prompt:       promptsToPb(a.Prompt),
-		UiLocales:    a.UiLocales,
-		LoginHint:    a.LoginHint,
-		HintUserId:   a.HintUserID,
+		Id:               a.ID,
+		CreationDate:     timestamppb.New(a.CreationDate),
+		ClientId:         a.ClientID,
+		Scope:            a.Scope,
+		RedirectUri:      a.RedirectURI,
+		Prompt:           promptsToPb(a.Prompt),
+		UiLocales:        a.UiLocales,
+		LoginHint:        a.LoginHint,
+		HintUserId:       a.HintUserID,
+		LevelOfAssurance: levelOfAssuranceToPb(a.LevelOfAssurance),
 	}
 	if a.MaxAge != nil {
 		pba.MaxAge = durationpb.New(*a.MaxAge)
@@ -74,6 +75,21 @@ func promptToPb(p domain.Prompt) oidc_pb.Prompt {
 	}
 }
 
+func levelOfAssuranceToPb(level domain.LevelOfAssurance) oidc_pb.LevelOfAssurance {
+	switch level {
+	case domain.LevelOfAssuranceNone:
+		return oidc_pb.LevelOfAssurance_LEVEL_OF_ASSURANCE_UNSPECIFIED
+	case domain.LevelOfAssurancePassword:
+		return oidc_pb.LevelOfAssurance_LEVEL_OF_ASSURANCE_PASSWORD
+	case domain.LevelOfAssuranceMFA:
+		return oidc_pb.LevelOfAssurance_LEVEL_OF_ASSURANCE_MFA
+	case domain.LevelOfAssurancePhishingResistant:
+		return oidc_pb.LevelOfAssurance_LEVEL_OF_ASSURANCE_PHISHING_RESISTANT
+	default:
+		return oidc_pb.LevelOfAssurance_LEVEL_OF_ASSURANCE_UNSPECIFIED
+	}
+}
+
 func (s *Server) CreateCallback(ctx context.Context, req *oidc_pb.CreateCallbackRequest) (*oidc_pb.CreateCallbackResponse, error) {
 	switch v := req.GetCallbackKind().(type) {
 	case *oidc_pb.CreateCallbackRequest_Error:
@@ -90,7 +106,7 @@ func (s *Server) failAuthRequest(ctx context.Context, authRequestID string, ae *
 	if err != nil {
 		return nil, err
 	}
-	authReq := &oidc.AuthRequestV2{CurrentAuthRequest: aar}
+	authReq := s.op.AuthRequestV2(aar)
 	callback, err := oidc.CreateErrorCallbackURL(authReq, errorReasonToOIDC(ae.GetError()), ae.GetErrorDescription(), ae.GetErrorUri(), s.op.Provider())
 	if err != nil {
 		return nil, err
@@ -102,11 +118,22 @@ func (s *Server) failAuthRequest(ctx context.Context, authRequestID string, ae *
 }
 
 func (s *Server) linkSessionToAuthRequest(ctx context.Context, authRequestID string, session *oidc_pb.Session) (*oidc_pb.CreateCallbackResponse, error) {
-	details, aar, err := s.command.LinkSessionToAuthRequest(ctx, authRequestID, session.GetSessionId(), session.GetSessionToken(), true)
+	details, aar, err := s.command.LinkSessionToAuthRequest(ctx, authRequestID, session.GetSessionId(), session.GetSessionToken(), true, session.GetConsentGranted())
 	if err != nil {
 		return nil, err
 	}
-	authReq := &oidc.AuthRequestV2{CurrentAuthRequest: aar}
+	authReq := s.op.AuthRequestV2(aar)
+	if aar.FailureReason == domain.OIDCErrorReasonConsentRequired {
+		// the user did not consent yet, but must not be asked (prompt=none)
+		callback, err := oidc.CreateErrorCallbackURL(authReq, errorReasonToOIDC(oidc_pb.ErrorReason_ERROR_REASON_CONSENT_REQUIRED), "", "", s.op.Provider())
+		if err != nil {
+			return nil, err
+		}
+		return &oidc_pb.CreateCallbackResponse{
+			Details:     object.DomainToDetailsPb(details),
+			CallbackUrl: callback,
+		}, nil
+	}
 	ctx = op.ContextWithIssuer(ctx, http.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), s.externalSecure))
 	var callback string
 	if aar.ResponseType == domain.OIDCResponseTypeCode {
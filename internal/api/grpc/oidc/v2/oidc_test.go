@@ -32,10 +32,11 @@ func Test_authRequestToPb(t *testing.T) {
 			domain.PromptCreate,
 			999,
 		},
-		UiLocales:  []string{"en", "fi"},
-		LoginHint:  gu.Ptr("foo@bar.com"),
-		MaxAge:     gu.Ptr(time.Minute),
-		HintUserID: gu.Ptr("userID"),
+		UiLocales:        []string{"en", "fi"},
+		LoginHint:        gu.Ptr("foo@bar.com"),
+		MaxAge:           gu.Ptr(time.Minute),
+		HintUserID:       gu.Ptr("userID"),
+		LevelOfAssurance: domain.LevelOfAssuranceMFA,
 	}
 	want := &oidc_pb.AuthRequest{
 		Id:           "authID",
@@ -51,11 +52,12 @@ func Test_authRequestToPb(t *testing.T) {
 			oidc_pb.Prompt_PROMPT_CREATE,
 			oidc_pb.Prompt_PROMPT_UNSPECIFIED,
 		},
-		UiLocales:  []string{"en", "fi"},
-		Scope:      []string{"a", "b", "c"},
-		LoginHint:  gu.Ptr("foo@bar.com"),
-		MaxAge:     durationpb.New(time.Minute),
-		HintUserId: gu.Ptr("userID"),
+		UiLocales:        []string{"en", "fi"},
+		Scope:            []string{"a", "b", "c"},
+		LoginHint:        gu.Ptr("foo@bar.com"),
+		MaxAge:           durationpb.New(time.Minute),
+		HintUserId:       gu.Ptr("userID"),
+		LevelOfAssurance: oidc_pb.LevelOfAssurance_LEVEL_OF_ASSURANCE_MFA,
 	}
 	got := authRequestToPb(arg)
 	if !proto.Equal(want, got) {
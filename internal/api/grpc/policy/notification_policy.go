@@ -8,8 +8,15 @@ import (
 
 func ModelNotificationPolicyToPb(policy *query.NotificationPolicy) *policy_pb.NotificationPolicy {
 	return &policy_pb.NotificationPolicy{
-		IsDefault:      policy.IsDefault,
-		PasswordChange: policy.PasswordChange,
+		IsDefault:              policy.IsDefault,
+		PasswordChange:         policy.PasswordChange,
+		UnknownUserAgentSignIn: policy.UnknownUserAgentSignIn,
+		MfaAdded:               policy.MFAAdded,
+		MfaRemoved:             policy.MFARemoved,
+		EmailChanged:           policy.EmailChanged,
+		PhoneChanged:           policy.PhoneChanged,
+		AccountLocked:          policy.AccountLocked,
+		MachineCredentialAdded: policy.MachineCredentialAdded,
 		Details: object.ToViewDetailsPb(
 			policy.Sequence,
 			policy.CreationDate,
@@ -43,6 +43,7 @@ var (
 		runtime.WithIncomingHeaderMatcher(headerMatcher),
 		runtime.WithOutgoingHeaderMatcher(runtime.DefaultHeaderMatcher),
 		runtime.WithForwardResponseOption(responseForwarder),
+		runtime.WithMetadata(middleware.GatewayClientCertificateMetadata),
 	}
 
 	headerMatcher = runtime.HeaderMatcherFunc(
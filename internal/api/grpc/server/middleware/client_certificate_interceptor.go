@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/zitadel/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	GatewayClientCertificate = "x-zitadel-gateway-client-certificate"
+)
+
+// gatewayCertificateKey authenticates the client certificates forwarded by the gRPC gateway of this process,
+// so that they cannot be set by the callers themselves.
+var gatewayCertificateKey = func() []byte {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	logging.OnError(err).Fatal("unable to generate gateway certificate key")
+	return key
+}()
+
+// ClientCertificateInterceptor stores the client certificates forwarded by the gRPC gateway in the context,
+// as the gateway calls the gRPC server on a connection of its own.
+// Certificates of direct gRPC calls are already set by the http middleware of the router.
+func ClientCertificateInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if len(http_util.ClientCertificatesFromCtx(ctx)) == 0 {
+			if certificates := gatewayClientCertificates(ctx); len(certificates) > 0 {
+				ctx = http_util.ClientCertificatesToCtx(ctx, certificates)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// GatewayClientCertificateMetadata forwards the client certificates of the request
+// to the gRPC server as metadata of the call of the gRPC gateway.
+func GatewayClientCertificateMetadata(ctx context.Context, _ *http.Request) metadata.MD {
+	certificates := http_util.ClientCertificatesFromCtx(ctx)
+	if len(certificates) == 0 {
+		return nil
+	}
+	encoded := make([]string, len(certificates))
+	for i, certificate := range certificates {
+		encoded[i] = base64.StdEncoding.EncodeToString(certificate.Raw)
+	}
+	value := strings.Join(encoded, ",")
+	return metadata.Pairs(GatewayClientCertificate, gatewayCertificateMAC(value)+"."+value)
+}
+
+func gatewayClientCertificates(ctx context.Context) []*x509.Certificate {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	for _, signed := range md.Get(GatewayClientCertificate) {
+		mac, value, ok := strings.Cut(signed, ".")
+		if !ok || !hmac.Equal([]byte(mac), []byte(gatewayCertificateMAC(value))) {
+			continue
+		}
+		certificates, err := domain.ParseX509ClientCertificates(value)
+		if err != nil {
+			return nil
+		}
+		return certificates
+	}
+	return nil
+}
+
+func gatewayCertificateMAC(value string) string {
+	mac := hmac.New(sha256.New, gatewayCertificateKey)
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
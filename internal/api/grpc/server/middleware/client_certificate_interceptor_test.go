@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+)
+
+func testClientCertificate(t *testing.T) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func Test_ClientCertificateInterceptor(t *testing.T) {
+	clientCert := testClientCertificate(t)
+	otherCert := testClientCertificate(t)
+	gatewayMD := GatewayClientCertificateMetadata(http_util.ClientCertificatesToCtx(context.Background(), []*x509.Certificate{clientCert}), nil)
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []*x509.Certificate
+	}{
+		{
+			name: "no certificate",
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.MD{}),
+		},
+		{
+			name: "certificate of request",
+			ctx:  http_util.ClientCertificatesToCtx(context.Background(), []*x509.Certificate{otherCert}),
+			want: []*x509.Certificate{otherCert},
+		},
+		{
+			name: "certificate of gateway",
+			ctx:  metadata.NewIncomingContext(context.Background(), gatewayMD),
+			want: []*x509.Certificate{clientCert},
+		},
+		{
+			name: "certificate set by caller, ignored",
+			ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+				GatewayClientCertificate, "mac."+base64.StdEncoding.EncodeToString(otherCert.Raw),
+			)),
+		},
+		{
+			name: "certificate set by caller and gateway, gateway used",
+			ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+				GatewayClientCertificate, "mac."+base64.StdEncoding.EncodeToString(otherCert.Raw),
+				GatewayClientCertificate, gatewayMD.Get(GatewayClientCertificate)[0],
+			)),
+			want: []*x509.Certificate{clientCert},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []*x509.Certificate
+			_, err := ClientCertificateInterceptor()(tt.ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				got = http_util.ClientCertificatesFromCtx(ctx)
+				return nil, nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -172,13 +172,15 @@ func factorsToPb(s *query.Session) *session.Factors {
 		return nil
 	}
 	return &session.Factors{
-		User:     user,
-		Password: passwordFactorToPb(s.PasswordFactor),
-		WebAuthN: webAuthNFactorToPb(s.WebAuthNFactor),
-		Intent:   intentFactorToPb(s.IntentFactor),
-		Totp:     totpFactorToPb(s.TOTPFactor),
-		OtpSms:   otpFactorToPb(s.OTPSMSFactor),
-		OtpEmail: otpFactorToPb(s.OTPEmailFactor),
+		User:      user,
+		Password:  passwordFactorToPb(s.PasswordFactor),
+		WebAuthN:  webAuthNFactorToPb(s.WebAuthNFactor),
+		Intent:    intentFactorToPb(s.IntentFactor),
+		Totp:      totpFactorToPb(s.TOTPFactor),
+		OtpSms:    otpFactorToPb(s.OTPSMSFactor),
+		OtpEmail:  otpFactorToPb(s.OTPEmailFactor),
+		MagicLink: magicLinkFactorToPb(s.MagicLinkFactor),
+		X509:      x509FactorToPb(s.X509Factor),
 	}
 }
 
@@ -228,6 +230,24 @@ func otpFactorToPb(factor query.SessionOTPFactor) *session.OTPFactor {
 	}
 }
 
+func magicLinkFactorToPb(factor query.SessionMagicLinkFactor) *session.MagicLinkFactor {
+	if factor.MagicLinkCheckedAt.IsZero() {
+		return nil
+	}
+	return &session.MagicLinkFactor{
+		VerifiedAt: timestamppb.New(factor.MagicLinkCheckedAt),
+	}
+}
+
+func x509FactorToPb(factor query.SessionX509Factor) *session.X509Factor {
+	if factor.X509CheckedAt.IsZero() {
+		return nil
+	}
+	return &session.X509Factor{
+		VerifiedAt: timestamppb.New(factor.X509CheckedAt),
+	}
+}
+
 func userFactorToPb(factor query.SessionUserFactor) *session.UserFactor {
 	if factor.UserID == "" || factor.UserCheckedAt.IsZero() {
 		return nil
@@ -371,6 +391,16 @@ func (s *Server) checksToCommand(ctx context.Context, checks *session.Checks) ([
 	if otp := checks.GetOtpEmail(); otp != nil {
 		sessionChecks = append(sessionChecks, command.CheckOTPEmail(otp.GetCode()))
 	}
+	if magicLink := checks.GetMagicLink(); magicLink != nil {
+		sessionChecks = append(sessionChecks, command.CheckMagicLink(magicLink.GetCode(), magicLink.GetFingerprintId()))
+	}
+	if x509 := checks.GetX509(); x509 != nil {
+		certificates, err := domain.ParseX509ClientCertificates(x509.GetCertificate())
+		if err != nil {
+			return nil, err
+		}
+		sessionChecks = append(sessionChecks, command.CheckX509(certificates, x509.GetSignature()))
+	}
 	return sessionChecks, nil
 }
 
@@ -397,6 +427,18 @@ func (s *Server) challengesToCommand(challenges *session.RequestChallenges, cmds
 		resp.OtpEmail = challenge
 		cmds = append(cmds, cmd)
 	}
+	if req := challenges.GetMagicLink(); req != nil {
+		challenge, cmd, err := s.createMagicLinkChallengeCommand(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		resp.MagicLink = challenge
+		cmds = append(cmds, cmd)
+	}
+	if req := challenges.GetX509(); req != nil {
+		resp.X509 = new(string)
+		cmds = append(cmds, command.CreateX509Challenge(resp.X509))
+	}
 	return resp, cmds, nil
 }
 
@@ -429,10 +471,22 @@ func (s *Server) createOTPSMSChallengeCommand(req *session.RequestChallenges_OTP
 		return challenge, s.command.CreateOTPSMSChallengeReturnCode(challenge)
 	}
 
-	return nil, s.command.CreateOTPSMSChallenge()
+	return nil, s.command.CreateOTPSMSChallenge(phoneChannelToDomain(req.GetChannel()))
 
 }
 
+func phoneChannelToDomain(channel session.PhoneChannel) domain.PhoneChannel {
+	switch channel {
+	case session.PhoneChannel_PHONE_CHANNEL_WHATSAPP:
+		return domain.PhoneChannelWhatsApp
+	case session.PhoneChannel_PHONE_CHANNEL_UNSPECIFIED,
+		session.PhoneChannel_PHONE_CHANNEL_SMS:
+		return domain.PhoneChannelSMS
+	default:
+		return domain.PhoneChannelSMS
+	}
+}
+
 func (s *Server) createOTPEmailChallengeCommand(req *session.RequestChallenges_OTPEmail) (*string, command.SessionCommand, error) {
 	switch t := req.GetDeliveryType().(type) {
 	case *session.RequestChallenges_OTPEmail_SendCode_:
@@ -451,6 +505,24 @@ func (s *Server) createOTPEmailChallengeCommand(req *session.RequestChallenges_O
 	}
 }
 
+func (s *Server) createMagicLinkChallengeCommand(req *session.RequestChallenges_MagicLink) (*string, command.SessionCommand, error) {
+	switch t := req.GetDeliveryType().(type) {
+	case *session.RequestChallenges_MagicLink_SendLink_:
+		cmd, err := s.command.CreateMagicLinkChallengeURLTemplate(t.SendLink.GetUrlTemplate())
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, cmd, nil
+	case *session.RequestChallenges_MagicLink_ReturnCode_:
+		challenge := new(string)
+		return challenge, s.command.CreateMagicLinkChallengeReturnCode(challenge), nil
+	case nil:
+		return nil, s.command.CreateMagicLinkChallenge(), nil
+	default:
+		return nil, nil, zerrors.ThrowUnimplementedf(nil, "SESSION-Ie2ai", "delivery_type oneOf %T in MagicLinkChallenge not implemented", t)
+	}
+}
+
 func userCheck(user *session.CheckUser) (userSearch, error) {
 	if user == nil {
 		return nil, nil
@@ -30,6 +30,8 @@ func loginSettingsToPb(current *query.LoginPolicy) *settings.LoginSettings {
 		AllowDomainDiscovery:       current.AllowDomainDiscovery,
 		DisableLoginWithEmail:      current.DisableLoginWithEmail,
 		DisableLoginWithPhone:      current.DisableLoginWithPhone,
+		AllowMagicLink:             current.AllowMagicLink,
+		AllowPhoneOTPLogin:         current.AllowPhoneOTPLogin,
 		DefaultRedirectUri:         current.DefaultRedirectURI,
 		PasswordCheckLifetime:      durationpb.New(current.PasswordCheckLifetime),
 		ExternalLoginCheckLifetime: durationpb.New(current.ExternalLoginCheckLifetime),
@@ -32,6 +32,8 @@ func Test_loginSettingsToPb(t *testing.T) {
 		AllowDomainDiscovery:       true,
 		DisableLoginWithEmail:      true,
 		DisableLoginWithPhone:      true,
+		AllowMagicLink:             true,
+		AllowPhoneOTPLogin:         true,
 		DefaultRedirectURI:         "example.com",
 		PasswordCheckLifetime:      time.Hour,
 		ExternalLoginCheckLifetime: time.Minute,
@@ -62,6 +64,8 @@ func Test_loginSettingsToPb(t *testing.T) {
 		AllowDomainDiscovery:       true,
 		DisableLoginWithEmail:      true,
 		DisableLoginWithPhone:      true,
+		AllowMagicLink:             true,
+		AllowPhoneOTPLogin:         true,
 		DefaultRedirectUri:         "example.com",
 		PasswordCheckLifetime:      durationpb.New(time.Hour),
 		ExternalLoginCheckLifetime: durationpb.New(time.Minute),
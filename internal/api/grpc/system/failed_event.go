@@ -22,3 +22,35 @@ func (s *Server) RemoveFailedEvent(ctx context.Context, req *system_pb.RemoveFai
 	}
 	return &system_pb.RemoveFailedEventResponse{}, nil
 }
+
+func (s *Server) GetFailedEvent(ctx context.Context, req *system_pb.GetFailedEventRequest) (*system_pb.GetFailedEventResponse, error) {
+	details, err := s.query.FailedEventByID(ctx, req.ViewName, req.InstanceId, req.AggregateType, req.AggregateId, req.FailedSequence)
+	if err != nil {
+		return nil, err
+	}
+	return FailedEventDetailsToPb(s.database, details)
+}
+
+func (s *Server) RetryFailedEvent(ctx context.Context, req *system_pb.RetryFailedEventRequest) (*system_pb.RetryFailedEventResponse, error) {
+	err := s.query.RetryFailedEvent(ctx, req.ViewName, req.InstanceId, req.AggregateType, req.AggregateId, req.FailedSequence)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.RetryFailedEventResponse{}, nil
+}
+
+func (s *Server) RetryFailedEvents(ctx context.Context, req *system_pb.RetryFailedEventsRequest) (*system_pb.RetryFailedEventsResponse, error) {
+	stillFailing, err := s.query.RetryFailedEvents(ctx, req.ViewName, req.InstanceId)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.RetryFailedEventsResponse{StillFailing: stillFailing}, nil
+}
+
+func (s *Server) SkipFailedEvent(ctx context.Context, req *system_pb.SkipFailedEventRequest) (*system_pb.SkipFailedEventResponse, error) {
+	err := s.query.SkipFailedEvent(ctx, req.ViewName, req.InstanceId, req.AggregateType, req.AggregateId, req.FailedSequence, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.SkipFailedEventResponse{}, nil
+}
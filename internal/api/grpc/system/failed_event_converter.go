@@ -1,9 +1,11 @@
 package system
 
 import (
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
 	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
 )
 
@@ -20,12 +22,39 @@ func FailedEventToPb(database string, failedEvent *query.FailedEvent) *system_pb
 	if !failedEvent.LastFailed.IsZero() {
 		lastFailed = timestamppb.New(failedEvent.LastFailed)
 	}
+	var eventDate *timestamppb.Timestamp
+	if !failedEvent.EventDate.IsZero() {
+		eventDate = timestamppb.New(failedEvent.EventDate)
+	}
 	return &system_pb.FailedEvent{
-		Database:       database,
-		ViewName:       failedEvent.ProjectionName,
-		FailedSequence: failedEvent.FailedSequence,
-		FailureCount:   failedEvent.FailureCount,
-		ErrorMessage:   failedEvent.Error,
-		LastFailed:     lastFailed,
+		Database:          database,
+		ViewName:          failedEvent.ProjectionName,
+		FailedSequence:    failedEvent.FailedSequence,
+		FailureCount:      failedEvent.FailureCount,
+		ErrorMessage:      failedEvent.Error,
+		LastFailed:        lastFailed,
+		InstanceId:        failedEvent.InstanceID,
+		AggregateType:     failedEvent.AggregateType,
+		AggregateId:       failedEvent.AggregateID,
+		EventCreationDate: eventDate,
+		Skipped:           failedEvent.Skipped,
+		SkipReason:        failedEvent.SkipReason,
+	}
+}
+
+func FailedEventDetailsToPb(database string, details *query.FailedEventDetails) (*system_pb.GetFailedEventResponse, error) {
+	var payload *structpb.Struct
+	if len(details.Payload) > 0 {
+		payload = new(structpb.Struct)
+		if err := payload.UnmarshalJSON(details.Payload); err != nil {
+			return nil, zerrors.ThrowInternal(err, "SYSTE-3mTso", "Errors.Internal")
+		}
 	}
+	return &system_pb.GetFailedEventResponse{
+		FailedEvent:  FailedEventToPb(database, details.FailedEvent),
+		EventType:    details.EventType,
+		EditorUserId: details.Creator,
+		Position:     details.Position,
+		Payload:      payload,
+	}, nil
 }
@@ -41,13 +41,18 @@ func (s *Server) GetInstance(ctx context.Context, req *system_pb.GetInstanceRequ
 }
 
 func (s *Server) AddInstance(ctx context.Context, req *system_pb.AddInstanceRequest) (*system_pb.AddInstanceResponse, error) {
-	id, _, _, details, err := s.command.SetUpInstance(ctx, AddInstancePbToSetupInstance(req, s.defaultInstance, s.externalDomain))
+	setup := AddInstancePbToSetupInstance(req, s.defaultInstance, s.externalDomain)
+	if err := s.setupTemplate(ctx, setup, req.GetTemplateInstanceId(), req.GetTemplateData()); err != nil {
+		return nil, err
+	}
+	id, _, _, details, err := s.command.SetUpInstance(ctx, setup)
 	if err != nil {
 		return nil, err
 	}
 	return &system_pb.AddInstanceResponse{
 		InstanceId: id,
 		Details:    object.AddToDetailsPb(details.Sequence, details.EventDate, details.ResourceOwner),
+		AppSecrets: templateAppSecretsToPb(setup.Template),
 	}, nil
 }
 
@@ -62,7 +67,11 @@ func (s *Server) UpdateInstance(ctx context.Context, req *system_pb.UpdateInstan
 }
 
 func (s *Server) CreateInstance(ctx context.Context, req *system_pb.CreateInstanceRequest) (*system_pb.CreateInstanceResponse, error) {
-	id, pat, key, details, err := s.command.SetUpInstance(ctx, CreateInstancePbToSetupInstance(req, s.defaultInstance, s.externalDomain))
+	setup := CreateInstancePbToSetupInstance(req, s.defaultInstance, s.externalDomain)
+	if err := s.setupTemplate(ctx, setup, req.GetTemplateInstanceId(), req.GetTemplateData()); err != nil {
+		return nil, err
+	}
+	id, pat, key, details, err := s.command.SetUpInstance(ctx, setup)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +89,7 @@ func (s *Server) CreateInstance(ctx context.Context, req *system_pb.CreateInstan
 		MachineKey: machineKey,
 		InstanceId: id,
 		Details:    object.AddToDetailsPb(details.Sequence, details.EventDate, details.ResourceOwner),
+		AppSecrets: templateAppSecretsToPb(setup.Template),
 	}, nil
 }
 
@@ -88,6 +98,11 @@ func (s *Server) RemoveInstance(ctx context.Context, req *system_pb.RemoveInstan
 	if err != nil {
 		return nil, err
 	}
+	if req.Purge {
+		if err = s.purger.Start(ctx, req.InstanceId); err != nil {
+			return nil, err
+		}
+	}
 	return &system_pb.RemoveInstanceResponse{
 		Details: object.AddToDetailsPb(details.Sequence, details.EventDate, details.ResourceOwner),
 	}, nil
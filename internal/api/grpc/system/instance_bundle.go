@@ -0,0 +1,40 @@
+package system
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/zitadel/zitadel/internal/bundle"
+	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func (s *Server) ExportInstanceBundle(ctx context.Context, req *system_pb.ExportInstanceBundleRequest) (*system_pb.ExportInstanceBundleResponse, error) {
+	exporter, err := bundle.NewExporter(s.db, s.keyStorage, req.GetTransportKey())
+	if err != nil {
+		return nil, err
+	}
+	data := new(bytes.Buffer)
+	if err = exporter.Export(ctx, req.GetInstanceId(), data); err != nil {
+		return nil, err
+	}
+	return &system_pb.ExportInstanceBundleResponse{
+		Bundle: data.Bytes(),
+	}, nil
+}
+
+func (s *Server) ImportInstanceBundle(ctx context.Context, req *system_pb.ImportInstanceBundleRequest) (*system_pb.ImportInstanceBundleResponse, error) {
+	importer, err := bundle.NewImporter(s.db, s.keyStorage, req.GetTransportKey(), 0)
+	if err != nil {
+		return nil, err
+	}
+	header, stats, err := importer.Import(ctx, bytes.NewReader(req.GetBundle()))
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.ImportInstanceBundleResponse{
+		InstanceId:        header.InstanceID,
+		Events:            uint64(stats.Events),
+		UniqueConstraints: uint64(stats.UniqueConstraints),
+		Assets:            uint64(stats.Assets),
+	}, nil
+}
@@ -0,0 +1,24 @@
+package system
+
+import (
+	"context"
+
+	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func (s *Server) PurgeInstance(ctx context.Context, req *system_pb.PurgeInstanceRequest) (*system_pb.PurgeInstanceResponse, error) {
+	if err := s.purger.Start(ctx, req.InstanceId); err != nil {
+		return nil, err
+	}
+	return &system_pb.PurgeInstanceResponse{}, nil
+}
+
+func (s *Server) GetInstancePurge(ctx context.Context, req *system_pb.GetInstancePurgeRequest) (*system_pb.GetInstancePurgeResponse, error) {
+	progress, err := s.purger.Progress(ctx, req.InstanceId)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.GetInstancePurgeResponse{
+		Purge: instancePurgeToPb(progress),
+	}, nil
+}
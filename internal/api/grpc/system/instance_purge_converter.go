@@ -0,0 +1,29 @@
+package system
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/purge"
+	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func instancePurgeToPb(progress *purge.Progress) *system_pb.InstancePurge {
+	tables := make([]*system_pb.InstancePurgeTable, len(progress.Tables))
+	for i, table := range progress.Tables {
+		tables[i] = &system_pb.InstancePurgeTable{
+			Table:       table.Table,
+			DeletedRows: table.DeletedRows,
+			Done:        table.Done,
+			Error:       table.Error,
+			ChangeDate:  timestamppb.New(table.ChangedAt),
+		}
+	}
+	return &system_pb.InstancePurge{
+		InstanceId:   progress.InstanceID,
+		Done:         progress.Done(),
+		TotalTables:  uint32(len(progress.Tables)),
+		PurgedTables: uint32(progress.PurgedTables()),
+		DeletedRows:  progress.DeletedRows(),
+		Tables:       tables,
+	}
+}
@@ -0,0 +1,276 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+var templateMessageTextTypes = []string{
+	domain.InitCodeMessageType,
+	domain.PasswordResetMessageType,
+	domain.VerifyEmailMessageType,
+	domain.VerifyPhoneMessageType,
+	domain.VerifySMSOTPMessageType,
+	domain.VerifyEmailOTPMessageType,
+	domain.DomainClaimedMessageType,
+	domain.PasswordlessRegistrationMessageType,
+	domain.PasswordChangeMessageType,
+	domain.UnknownUserAgentSignInMessageType,
+	domain.MFAAddedMessageType,
+	domain.MFARemovedMessageType,
+	domain.EmailChangedMessageType,
+	domain.PhoneChangedMessageType,
+	domain.AccountLockedMessageType,
+	domain.MachineCredentialAddedMessageType,
+	domain.MagicLinkMessageType,
+	domain.EmailChangeRequestedMessageType,
+}
+
+func (s *Server) ExportInstanceTemplate(ctx context.Context, req *system_pb.ExportInstanceTemplateRequest) (*system_pb.ExportInstanceTemplateResponse, error) {
+	template, err := s.instanceTemplateByID(ctx, req.GetInstanceId())
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(template)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "SYSTEM-m3Ahd", "Errors.Internal")
+	}
+	return &system_pb.ExportInstanceTemplateResponse{
+		Template: data,
+	}, nil
+}
+
+// setupTemplate sets the template of the new instance
+// either read from an existing instance or from an exported template
+func (s *Server) setupTemplate(ctx context.Context, setup *command.InstanceSetup, templateInstanceID string, exported []byte) (err error) {
+	switch {
+	case templateInstanceID != "":
+		setup.Template, err = s.instanceTemplateByID(ctx, templateInstanceID)
+		return err
+	case len(exported) > 0:
+		setup.Template = new(command.InstanceTemplate)
+		if err = json.Unmarshal(exported, setup.Template); err != nil {
+			return zerrors.ThrowInvalidArgument(err, "SYSTEM-Lw9vx", "Errors.Instance.Template.Invalid")
+		}
+	}
+	return nil
+}
+
+// instanceTemplateByID reads the template of the instance with the given id.
+// The system API is not bound to an instance, so the instance has to be set in the context
+func (s *Server) instanceTemplateByID(ctx context.Context, instanceID string) (*command.InstanceTemplate, error) {
+	ctx = authz.WithInstanceID(ctx, instanceID)
+	instance, err := s.query.InstanceByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.instanceTemplate(authz.WithInstance(ctx, instance))
+}
+
+// instanceTemplate reads the settings of the instance in the context
+// and the projects, actions and flows of its default organisation
+func (s *Server) instanceTemplate(ctx context.Context) (_ *command.InstanceTemplate, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instance := authz.GetInstance(ctx)
+	template := &command.InstanceTemplate{
+		DefaultLanguage: instance.DefaultLanguage(),
+	}
+	if err = s.templateSettings(ctx, template); err != nil {
+		return nil, err
+	}
+	if template.LabelPolicyAssets, err = s.templateLabelPolicyAssets(ctx, instance.InstanceID()); err != nil {
+		return nil, err
+	}
+	if template.MessageTexts, err = s.templateMessageTexts(ctx, instance.InstanceID()); err != nil {
+		return nil, err
+	}
+	if template.LoginTexts, err = s.templateLoginTexts(ctx, instance.InstanceID()); err != nil {
+		return nil, err
+	}
+	if template.Projects, err = s.templateProjects(ctx, instance.DefaultOrganisationID(), instance.ProjectID()); err != nil {
+		return nil, err
+	}
+	if template.Actions, template.Flows, err = s.templateActionsAndFlows(ctx, instance.DefaultOrganisationID()); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (s *Server) templateSettings(ctx context.Context, template *command.InstanceTemplate) error {
+	passwordComplexity, err := s.query.DefaultPasswordComplexityPolicy(ctx, false)
+	if err != nil {
+		return err
+	}
+	template.PasswordComplexityPolicy = passwordComplexityPolicyToTemplate(passwordComplexity)
+	passwordAge, err := s.query.DefaultPasswordAgePolicy(ctx, false)
+	if err != nil {
+		return err
+	}
+	template.PasswordAgePolicy = passwordAgePolicyToTemplate(passwordAge)
+	domainPolicy, err := s.query.DefaultDomainPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	template.DomainPolicy = domainPolicyToTemplate(domainPolicy)
+	loginPolicy, err := s.query.DefaultLoginPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	template.LoginPolicy = loginPolicyToTemplate(loginPolicy)
+	notificationPolicy, err := s.query.DefaultNotificationPolicy(ctx, false)
+	if err != nil {
+		return err
+	}
+	template.NotificationPolicy = notificationPolicyToTemplate(notificationPolicy)
+	privacyPolicy, err := s.query.DefaultPrivacyPolicy(ctx, false)
+	if err != nil {
+		return err
+	}
+	template.PrivacyPolicy = privacyPolicyToTemplate(privacyPolicy)
+	labelPolicy, err := s.query.DefaultActiveLabelPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	template.LabelPolicy = labelPolicyToTemplate(labelPolicy)
+	lockoutPolicy, err := s.query.DefaultLockoutPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	template.LockoutPolicy = lockoutPolicyToTemplate(lockoutPolicy)
+	oidcSettings, err := s.query.OIDCSettingsByAggID(ctx, authz.GetInstance(ctx).InstanceID())
+	if err != nil && !zerrors.IsNotFound(err) {
+		return err
+	}
+	template.OIDCSettings = oidcSettingsToTemplate(oidcSettings)
+	return nil
+}
+
+// templateLabelPolicyAssets reads the logos, icons and font of the active label policy from the static storage
+func (s *Server) templateLabelPolicyAssets(ctx context.Context, instanceID string) ([]*command.TemplateLabelPolicyAsset, error) {
+	policy, err := s.query.DefaultActiveLabelPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := []struct {
+		assetType command.TemplateLabelPolicyAssetType
+		name      string
+	}{
+		{command.TemplateLabelPolicyLogo, policy.Light.LogoURL},
+		{command.TemplateLabelPolicyLogoDark, policy.Dark.LogoURL},
+		{command.TemplateLabelPolicyIcon, policy.Light.IconURL},
+		{command.TemplateLabelPolicyIconDark, policy.Dark.IconURL},
+		{command.TemplateLabelPolicyFont, policy.FontURL},
+	}
+	assets := make([]*command.TemplateLabelPolicyAsset, 0, len(names))
+	for _, asset := range names {
+		if asset.name == "" {
+			continue
+		}
+		data, getInfo, err := s.static.GetObject(ctx, instanceID, instanceID, asset.name)
+		if err != nil {
+			return nil, err
+		}
+		info, err := getInfo()
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, &command.TemplateLabelPolicyAsset{
+			Type:        asset.assetType,
+			Name:        asset.name,
+			ContentType: info.ContentType,
+			Data:        data,
+		})
+	}
+	return assets, nil
+}
+
+func (s *Server) templateMessageTexts(ctx context.Context, instanceID string) ([]*domain.CustomMessageText, error) {
+	texts := make([]*domain.CustomMessageText, 0)
+	for _, lang := range domain.LanguagesToStrings(i18n.SupportedLanguages()) {
+		for _, messageType := range templateMessageTextTypes {
+			text, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, instanceID, messageType, lang, false)
+			if err != nil {
+				return nil, err
+			}
+			if !text.IsDefault {
+				texts = append(texts, messageTextToTemplate(text))
+			}
+		}
+	}
+	return texts, nil
+}
+
+func (s *Server) templateLoginTexts(ctx context.Context, instanceID string) ([]*command.TemplateLoginText, error) {
+	texts, err := s.query.CustomTextListByTemplate(ctx, instanceID, domain.LoginCustomText, false)
+	if err != nil {
+		return nil, err
+	}
+	return loginTextsToTemplate(texts.CustomTexts), nil
+}
+
+func (s *Server) templateProjects(ctx context.Context, orgID, zitadelProjectID string) ([]*command.TemplateProject, error) {
+	projectSearch, err := query.NewProjectResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := s.query.SearchProjects(ctx, &query.ProjectSearchQueries{Queries: []query.SearchQuery{projectSearch}})
+	if err != nil {
+		return nil, err
+	}
+	templateProjects := make([]*command.TemplateProject, 0, len(projects.Projects))
+	for _, project := range projects.Projects {
+		// the ZITADEL project is created during the setup of every instance
+		if project.ID == zitadelProjectID {
+			continue
+		}
+		roleSearch, err := query.NewProjectRoleProjectIDSearchQuery(project.ID)
+		if err != nil {
+			return nil, err
+		}
+		roles, err := s.query.SearchProjectRoles(ctx, false, &query.ProjectRoleSearchQueries{Queries: []query.SearchQuery{roleSearch}})
+		if err != nil {
+			return nil, err
+		}
+		appSearch, err := query.NewAppProjectIDSearchQuery(project.ID)
+		if err != nil {
+			return nil, err
+		}
+		apps, err := s.query.SearchApps(ctx, &query.AppSearchQueries{Queries: []query.SearchQuery{appSearch}}, false)
+		if err != nil {
+			return nil, err
+		}
+		templateProjects = append(templateProjects, projectToTemplate(project, roles.ProjectRoles, apps.Apps))
+	}
+	return templateProjects, nil
+}
+
+func (s *Server) templateActionsAndFlows(ctx context.Context, orgID string) ([]*command.TemplateAction, []*command.TemplateFlow, error) {
+	actionSearch, err := query.NewActionResourceOwnerQuery(orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	actions, err := s.query.SearchActions(ctx, &query.ActionSearchQueries{Queries: []query.SearchQuery{actionSearch}}, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	flows := make([]*command.TemplateFlow, 0)
+	for flowType := domain.FlowTypeUnspecified + 1; flowType.Valid(); flowType++ {
+		flow, err := s.query.GetFlow(ctx, flowType, orgID)
+		if err != nil {
+			return nil, nil, err
+		}
+		flows = append(flows, flowToTemplate(flow)...)
+	}
+	return actionsToTemplate(actions.Actions), flows, nil
+}
@@ -0,0 +1,248 @@
+package system
+
+import (
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func passwordComplexityPolicyToTemplate(policy *query.PasswordComplexityPolicy) *command.InstancePasswordComplexityPolicy {
+	return &command.InstancePasswordComplexityPolicy{
+		MinLength:    policy.MinLength,
+		HasLowercase: policy.HasLowercase,
+		HasUppercase: policy.HasUppercase,
+		HasNumber:    policy.HasNumber,
+		HasSymbol:    policy.HasSymbol,
+	}
+}
+
+func passwordAgePolicyToTemplate(policy *query.PasswordAgePolicy) *command.InstancePasswordAgePolicy {
+	return &command.InstancePasswordAgePolicy{
+		ExpireWarnDays: policy.ExpireWarnDays,
+		MaxAgeDays:     policy.MaxAgeDays,
+	}
+}
+
+func domainPolicyToTemplate(policy *query.DomainPolicy) *command.InstanceDomainPolicy {
+	return &command.InstanceDomainPolicy{
+		UserLoginMustBeDomain:                  policy.UserLoginMustBeDomain,
+		ValidateOrgDomains:                     policy.ValidateOrgDomains,
+		SMTPSenderAddressMatchesInstanceDomain: policy.SMTPSenderAddressMatchesInstanceDomain,
+	}
+}
+
+func loginPolicyToTemplate(policy *query.LoginPolicy) *command.InstanceLoginPolicy {
+	return &command.InstanceLoginPolicy{
+		AllowUsernamePassword:      policy.AllowUsernamePassword,
+		AllowRegister:              policy.AllowRegister,
+		AllowExternalIDP:           policy.AllowExternalIDPs,
+		ForceMFA:                   policy.ForceMFA,
+		ForceMFALocalOnly:          policy.ForceMFALocalOnly,
+		HidePasswordReset:          policy.HidePasswordReset,
+		IgnoreUnknownUsername:      policy.IgnoreUnknownUsernames,
+		AllowDomainDiscovery:       policy.AllowDomainDiscovery,
+		DisableLoginWithEmail:      policy.DisableLoginWithEmail,
+		DisableLoginWithPhone:      policy.DisableLoginWithPhone,
+		AllowMagicLink:             policy.AllowMagicLink,
+		AllowPhoneOTPLogin:         policy.AllowPhoneOTPLogin,
+		PasswordlessType:           policy.PasswordlessType,
+		DefaultRedirectURI:         policy.DefaultRedirectURI,
+		PasswordCheckLifetime:      policy.PasswordCheckLifetime,
+		ExternalLoginCheckLifetime: policy.ExternalLoginCheckLifetime,
+		MfaInitSkipLifetime:        policy.MFAInitSkipLifetime,
+		SecondFactorCheckLifetime:  policy.SecondFactorCheckLifetime,
+		MultiFactorCheckLifetime:   policy.MultiFactorCheckLifetime,
+	}
+}
+
+func notificationPolicyToTemplate(policy *query.NotificationPolicy) *command.InstanceNotificationPolicy {
+	return &command.InstanceNotificationPolicy{
+		PasswordChange:         policy.PasswordChange,
+		UnknownUserAgentSignIn: policy.UnknownUserAgentSignIn,
+		MFAAdded:               policy.MFAAdded,
+		MFARemoved:             policy.MFARemoved,
+		EmailChanged:           policy.EmailChanged,
+		PhoneChanged:           policy.PhoneChanged,
+		AccountLocked:          policy.AccountLocked,
+		MachineCredentialAdded: policy.MachineCredentialAdded,
+	}
+}
+
+func privacyPolicyToTemplate(policy *query.PrivacyPolicy) *command.InstancePrivacyPolicy {
+	return &command.InstancePrivacyPolicy{
+		TOSLink:      policy.TOSLink,
+		PrivacyLink:  policy.PrivacyLink,
+		HelpLink:     policy.HelpLink,
+		SupportEmail: policy.SupportEmail,
+	}
+}
+
+func labelPolicyToTemplate(policy *query.LabelPolicy) *command.InstanceLabelPolicy {
+	return &command.InstanceLabelPolicy{
+		PrimaryColor:        policy.Light.PrimaryColor,
+		BackgroundColor:     policy.Light.BackgroundColor,
+		WarnColor:           policy.Light.WarnColor,
+		FontColor:           policy.Light.FontColor,
+		PrimaryColorDark:    policy.Dark.PrimaryColor,
+		BackgroundColorDark: policy.Dark.BackgroundColor,
+		WarnColorDark:       policy.Dark.WarnColor,
+		FontColorDark:       policy.Dark.FontColor,
+		HideLoginNameSuffix: policy.HideLoginNameSuffix,
+		ErrorMsgPopup:       policy.ShouldErrorPopup,
+		DisableWatermark:    policy.WatermarkDisabled,
+		ThemeMode:           policy.ThemeMode,
+	}
+}
+
+func lockoutPolicyToTemplate(policy *query.LockoutPolicy) *command.InstanceLockoutPolicy {
+	return &command.InstanceLockoutPolicy{
+		MaxAttempts:              policy.MaxPasswordAttempts,
+		ShouldShowLockoutFailure: policy.ShowFailures,
+	}
+}
+
+func oidcSettingsToTemplate(settings *query.OIDCSettings) *command.OIDCSettings {
+	if settings == nil {
+		return nil
+	}
+	return &command.OIDCSettings{
+		AccessTokenLifetime:        settings.AccessTokenLifetime,
+		IdTokenLifetime:            settings.IdTokenLifetime,
+		RefreshTokenIdleExpiration: settings.RefreshTokenIdleExpiration,
+		RefreshTokenExpiration:     settings.RefreshTokenExpiration,
+	}
+}
+
+func messageTextToTemplate(text *query.MessageText) *domain.CustomMessageText {
+	return &domain.CustomMessageText{
+		MessageTextType: text.Type,
+		Language:        text.Language,
+		Title:           text.Title,
+		PreHeader:       text.PreHeader,
+		Subject:         text.Subject,
+		Greeting:        text.Greeting,
+		Text:            text.Text,
+		ButtonText:      text.ButtonText,
+		FooterText:      text.Footer,
+	}
+}
+
+func loginTextsToTemplate(texts []*query.CustomText) []*command.TemplateLoginText {
+	templateTexts := make([]*command.TemplateLoginText, len(texts))
+	for i, text := range texts {
+		templateTexts[i] = &command.TemplateLoginText{
+			Language: text.Language,
+			Key:      text.Key,
+			Text:     text.Text,
+		}
+	}
+	return templateTexts
+}
+
+func projectToTemplate(project *query.Project, roles []*query.ProjectRole, apps []*query.App) *command.TemplateProject {
+	templateProject := &command.TemplateProject{
+		ID:                     project.ID,
+		Name:                   project.Name,
+		ProjectRoleAssertion:   project.ProjectRoleAssertion,
+		ProjectRoleCheck:       project.ProjectRoleCheck,
+		HasProjectCheck:        project.HasProjectCheck,
+		PrivateLabelingSetting: project.PrivateLabelingSetting,
+		Roles:                  make([]*command.TemplateProjectRole, len(roles)),
+		OIDCApps:               make([]*command.TemplateOIDCApp, 0),
+		APIApps:                make([]*command.TemplateAPIApp, 0),
+		SAMLApps:               make([]*command.TemplateSAMLApp, 0),
+	}
+	for i, role := range roles {
+		templateProject.Roles[i] = &command.TemplateProjectRole{
+			Key:         role.Key,
+			DisplayName: role.DisplayName,
+			Group:       role.Group,
+		}
+	}
+	for _, app := range apps {
+		if app.OIDCConfig != nil {
+			templateProject.OIDCApps = append(templateProject.OIDCApps, &command.TemplateOIDCApp{
+				Name:                        app.Name,
+				Version:                     app.OIDCConfig.Version,
+				RedirectUris:                app.OIDCConfig.RedirectURIs,
+				ResponseTypes:               app.OIDCConfig.ResponseTypes,
+				GrantTypes:                  app.OIDCConfig.GrantTypes,
+				ApplicationType:             app.OIDCConfig.AppType,
+				AuthMethodType:              app.OIDCConfig.AuthMethodType,
+				PostLogoutRedirectUris:      app.OIDCConfig.PostLogoutRedirectURIs,
+				DevMode:                     app.OIDCConfig.IsDevMode,
+				AccessTokenType:             app.OIDCConfig.AccessTokenType,
+				AccessTokenRoleAssertion:    app.OIDCConfig.AssertAccessTokenRole,
+				IDTokenRoleAssertion:        app.OIDCConfig.AssertIDTokenRole,
+				IDTokenUserinfoAssertion:    app.OIDCConfig.AssertIDTokenUserinfo,
+				ClockSkew:                   app.OIDCConfig.ClockSkew,
+				AdditionalOrigins:           app.OIDCConfig.AdditionalOrigins,
+				SkipSuccessPageForNativeApp: app.OIDCConfig.SkipNativeAppSuccessPage,
+				ConsentRequired:             app.OIDCConfig.ConsentRequired,
+			})
+		}
+		if app.APIConfig != nil {
+			templateProject.APIApps = append(templateProject.APIApps, &command.TemplateAPIApp{
+				Name:           app.Name,
+				AuthMethodType: app.APIConfig.AuthMethodType,
+			})
+		}
+		if app.SAMLConfig != nil {
+			templateProject.SAMLApps = append(templateProject.SAMLApps, &command.TemplateSAMLApp{
+				Name:        app.Name,
+				Metadata:    app.SAMLConfig.Metadata,
+				MetadataURL: app.SAMLConfig.MetadataURL,
+			})
+		}
+	}
+	return templateProject
+}
+
+func actionsToTemplate(actions []*query.Action) []*command.TemplateAction {
+	templateActions := make([]*command.TemplateAction, len(actions))
+	for i, action := range actions {
+		templateActions[i] = &command.TemplateAction{
+			ID:            action.ID,
+			Name:          action.Name,
+			Script:        action.Script,
+			Timeout:       action.Timeout(),
+			AllowedToFail: action.AllowedToFail,
+		}
+	}
+	return templateActions
+}
+
+func flowToTemplate(flow *query.Flow) []*command.TemplateFlow {
+	flows := make([]*command.TemplateFlow, 0, len(flow.TriggerActions))
+	for triggerType, actions := range flow.TriggerActions {
+		ids := make([]string, len(actions))
+		for i, action := range actions {
+			ids[i] = action.ID
+		}
+		flows = append(flows, &command.TemplateFlow{
+			FlowType:    flow.Type,
+			TriggerType: triggerType,
+			ActionIDs:   ids,
+		})
+	}
+	return flows
+}
+
+func templateAppSecretsToPb(template *command.InstanceTemplate) []*system_pb.TemplateAppSecret {
+	if template == nil {
+		return nil
+	}
+	secrets := template.AppSecrets()
+	pbSecrets := make([]*system_pb.TemplateAppSecret, len(secrets))
+	for i, secret := range secrets {
+		pbSecrets[i] = &system_pb.TemplateAppSecret{
+			ProjectId:    secret.ProjectID,
+			AppId:        secret.AppID,
+			Name:         secret.Name,
+			ClientId:     secret.ClientID,
+			ClientSecret: secret.ClientSecret,
+		}
+	}
+	return pbSecrets
+}
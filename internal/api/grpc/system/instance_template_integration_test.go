@@ -0,0 +1,141 @@
+//go:build integration
+
+package system_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/integration"
+	"github.com/zitadel/zitadel/pkg/grpc/admin"
+	"github.com/zitadel/zitadel/pkg/grpc/app"
+	"github.com/zitadel/zitadel/pkg/grpc/management"
+	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
+)
+
+func TestServer_ExportInstanceTemplate(t *testing.T) {
+	_, instanceID, iamOwnerCtx := Tester.UseIsolatedInstance(t, CTX, SystemCTX)
+	_, err := Tester.Client.Admin.UpdatePrivacyPolicy(iamOwnerCtx, &admin.UpdatePrivacyPolicyRequest{
+		TosLink:      "https://template.zitadel.localhost/tos",
+		PrivacyLink:  "https://template.zitadel.localhost/privacy",
+		HelpLink:     "https://template.zitadel.localhost/help",
+		SupportEmail: "support@template.zitadel.localhost",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		req     *system_pb.ExportInstanceTemplateRequest
+		want    *command.InstancePrivacyPolicy
+		wantErr bool
+	}{
+		{
+			name: "non-existing instance",
+			req: &system_pb.ExportInstanceTemplateRequest{
+				InstanceId: "foo",
+			},
+			wantErr: true,
+		},
+		{
+			name: "isolated instance",
+			req: &system_pb.ExportInstanceTemplateRequest{
+				InstanceId: instanceID,
+			},
+			want: &command.InstancePrivacyPolicy{
+				TOSLink:      "https://template.zitadel.localhost/tos",
+				PrivacyLink:  "https://template.zitadel.localhost/privacy",
+				HelpLink:     "https://template.zitadel.localhost/help",
+				SupportEmail: domain.EmailAddress("support@template.zitadel.localhost"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr {
+				_, err := Tester.Client.System.ExportInstanceTemplate(SystemCTX, tt.req)
+				require.Error(t, err)
+				return
+			}
+			require.EventuallyWithT(t, func(collectT *assert.CollectT) {
+				resp, err := Tester.Client.System.ExportInstanceTemplate(SystemCTX, tt.req)
+				if !assert.NoError(collectT, err) {
+					return
+				}
+				template := new(command.InstanceTemplate)
+				if !assert.NoError(collectT, json.Unmarshal(resp.GetTemplate(), template)) {
+					return
+				}
+				assert.Equal(collectT, tt.want, template.PrivacyPolicy)
+			}, time.Minute, time.Second)
+		})
+	}
+}
+
+func TestServer_CreateInstance_Template(t *testing.T) {
+	_, templateID, iamOwnerCtx := Tester.UseIsolatedInstance(t, CTX, SystemCTX)
+	project, err := Tester.Client.Mgmt.AddProject(iamOwnerCtx, &management.AddProjectRequest{
+		Name: "template",
+	})
+	require.NoError(t, err)
+	_, err = Tester.Client.Mgmt.AddAPIApp(iamOwnerCtx, &management.AddAPIAppRequest{
+		ProjectId:      project.GetId(),
+		Name:           "api",
+		AuthMethodType: app.APIAuthMethodType_API_AUTH_METHOD_TYPE_BASIC,
+	})
+	require.NoError(t, err)
+	_, err = Tester.Client.Mgmt.AddSAMLApp(iamOwnerCtx, &management.AddSAMLAppRequest{
+		ProjectId: project.GetId(),
+		Name:      "saml",
+		Metadata: &management.AddSAMLAppRequest_MetadataXml{
+			MetadataXml: []byte(`<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://template.zitadel.localhost/saml/metadata">
+	<md:SPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+		<md:AssertionConsumerService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://template.zitadel.localhost/saml/acs" index="1" />
+	</md:SPSSODescriptor>
+</md:EntityDescriptor>`),
+		},
+	})
+	require.NoError(t, err)
+
+	require.EventuallyWithT(t, func(collectT *assert.CollectT) {
+		exported, err := Tester.Client.System.ExportInstanceTemplate(SystemCTX, &system_pb.ExportInstanceTemplateRequest{
+			InstanceId: templateID,
+		})
+		if !assert.NoError(collectT, err) {
+			return
+		}
+		template := new(command.InstanceTemplate)
+		if !assert.NoError(collectT, json.Unmarshal(exported.GetTemplate(), template)) {
+			return
+		}
+		if assert.Len(collectT, template.Projects, 1) {
+			assert.Len(collectT, template.Projects[0].APIApps, 1)
+			assert.Len(collectT, template.Projects[0].SAMLApps, 1)
+		}
+	}, time.Minute, time.Second)
+
+	resp, err := Tester.Client.System.CreateInstance(SystemCTX, &system_pb.CreateInstanceRequest{
+		InstanceName: "templated",
+		CustomDomain: integration.RandString(5) + ".integration.localhost",
+		Owner: &system_pb.CreateInstanceRequest_Machine_{
+			Machine: &system_pb.CreateInstanceRequest_Machine{
+				UserName: "owner",
+				Name:     "owner",
+			},
+		},
+		Template: &system_pb.CreateInstanceRequest_TemplateInstanceId{
+			TemplateInstanceId: templateID,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetAppSecrets(), 1)
+	assert.Equal(t, "api", resp.GetAppSecrets()[0].GetName())
+	assert.NotEmpty(t, resp.GetAppSecrets()[0].GetClientId())
+	assert.NotEmpty(t, resp.GetAppSecrets()[0].GetClientSecret())
+}
@@ -33,6 +33,8 @@ func instanceQuotaUnitPbToCommand(unit quota.Unit) command.QuotaUnit {
 		return command.QuotaRequestsAllAuthenticated
 	case quota.Unit_UNIT_ACTIONS_ALL_RUN_SECONDS:
 		return command.QuotaActionsAllRunsSeconds
+	case quota.Unit_UNIT_NOTIFICATIONS_ALL_SENT:
+		return command.QuotaNotificationsAllSent
 	case quota.Unit_UNIT_UNIMPLEMENTED:
 		fallthrough
 	default:
@@ -44,10 +46,25 @@ func instanceQuotaNotificationsPbToCommand(req []*quota.Notification) command.Qu
 	notifications := make([]*command.QuotaNotification, len(req))
 	for idx, item := range req {
 		notifications[idx] = &command.QuotaNotification{
-			Percent: uint16(item.Percent),
-			Repeat:  item.Repeat,
-			CallURL: item.CallUrl,
+			Percent:    uint16(item.Percent),
+			Repeat:     item.Repeat,
+			CallURL:    item.CallUrl,
+			Channel:    instanceQuotaNotificationChannelPbToCommand(item.Channel),
+			Recipients: item.Recipients,
 		}
 	}
 	return notifications
 }
+
+func instanceQuotaNotificationChannelPbToCommand(channel quota.NotificationChannel) command.QuotaNotificationChannel {
+	switch channel {
+	case quota.NotificationChannel_NOTIFICATION_CHANNEL_WEBHOOK:
+		return command.QuotaNotificationChannelWebhook
+	case quota.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL:
+		return command.QuotaNotificationChannelEmail
+	case quota.NotificationChannel_NOTIFICATION_CHANNEL_EVENT:
+		return command.QuotaNotificationChannelEvent
+	default:
+		return command.QuotaNotificationChannel(channel.String())
+	}
+}
@@ -7,7 +7,11 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/server"
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/purge"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/static"
 	"github.com/zitadel/zitadel/pkg/grpc/system"
 )
 
@@ -24,6 +28,10 @@ type Server struct {
 	query           *query.Queries
 	defaultInstance command.InstanceSetup
 	externalDomain  string
+	purger          *purge.Purger
+	static          static.Storage
+	db              *database.DB
+	keyStorage      crypto.KeyStorage
 }
 
 type Config struct {
@@ -36,6 +44,10 @@ func CreateServer(
 	database string,
 	defaultInstance command.InstanceSetup,
 	externalDomain string,
+	purger *purge.Purger,
+	static static.Storage,
+	db *database.DB,
+	keyStorage crypto.KeyStorage,
 ) *Server {
 	return &Server{
 		command:         command,
@@ -43,6 +55,10 @@ func CreateServer(
 		database:        database,
 		defaultInstance: defaultInstance,
 		externalDomain:  externalDomain,
+		purger:          purger,
+		static:          static,
+		db:              db,
+		keyStorage:      keyStorage,
 	}
 }
 
@@ -1,6 +1,8 @@
 package user
 
 import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -96,6 +98,16 @@ func EmailToPb(email *query.Email) *user_pb.Email {
 	}
 }
 
+// PendingEmailChangeToPb adds the pending change of the email address, if there is one
+func PendingEmailChangeToPb(email *user_pb.Email, change *query.PendingEmailChange) *user_pb.Email {
+	if change == nil {
+		return email
+	}
+	email.PendingEmail = string(change.Email)
+	email.PendingEmailRequestDate = timestamppb.New(change.RequestDate)
+	return email
+}
+
 func PhoneToPb(phone *query.Phone) *user_pb.Phone {
 	return &user_pb.Phone{
 		Phone:           phone.Phone,
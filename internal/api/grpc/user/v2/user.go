@@ -427,6 +427,14 @@ func (s *Server) startLDAPIntent(ctx context.Context, idpID string, ldapCredenti
 		}
 		return nil, err
 	}
+	if userID != "" {
+		if err := s.command.ApplyIDPGroupMapping(ctx, intentWriteModel.IDPID, userID, "", externalUser); err != nil {
+			if err := s.command.FailIDPIntent(ctx, intentWriteModel, err.Error()); err != nil {
+				return nil, err
+			}
+			return nil, err
+		}
+	}
 	token, err := s.command.SucceedLDAPIDPIntent(ctx, intentWriteModel, externalUser, userID, attributes)
 	if err != nil {
 		return nil, err
@@ -522,7 +530,8 @@ func idpIntentToIDPIntentPb(intent *command.IDPIntentWriteModel, alg crypto.Encr
 			UserName:       intent.IDPUserName,
 			RawInformation: rawInformation,
 		},
-		UserId: intent.UserID,
+		UserId:       intent.UserID,
+		AddHumanUser: mappedUserToAddHumanUserPb(intent),
 	}
 	if intent.IDPIDToken != "" || intent.IDPAccessToken != nil {
 		information.IdpInformation.Access, err = idpOAuthTokensToPb(intent.IDPIDToken, intent.IDPAccessToken, alg)
@@ -550,6 +559,62 @@ func idpIntentToIDPIntentPb(intent *command.IDPIntentWriteModel, alg crypto.Encr
 	return information, nil
 }
 
+// mappedUserToAddHumanUserPb returns the user of the identity provider mapped by the attribute mapping of the IDP
+// and linked to the identity provider, so it can be used to create the user
+func mappedUserToAddHumanUserPb(intent *command.IDPIntentWriteModel) *user.AddHumanUserRequest {
+	mappedUser := intent.MappedUser
+	if mappedUser == nil {
+		return nil
+	}
+	addHumanUser := &user.AddHumanUserRequest{
+		Profile: &user.SetHumanProfile{
+			GivenName:  mappedUser.FirstName,
+			FamilyName: mappedUser.LastName,
+		},
+		Email: &user.SetHumanEmail{
+			Email: mappedUser.Email,
+		},
+		Metadata: make([]*user.SetMetadataEntry, len(mappedUser.Metadata)),
+		IdpLinks: []*user.IDPLink{
+			{
+				IdpId:    intent.IDPID,
+				UserId:   intent.IDPUserID,
+				UserName: intent.IDPUserName,
+			},
+		},
+	}
+	if mappedUser.Username != "" {
+		addHumanUser.Username = &mappedUser.Username
+	}
+	if mappedUser.NickName != "" {
+		addHumanUser.Profile.NickName = &mappedUser.NickName
+	}
+	if mappedUser.DisplayName != "" {
+		addHumanUser.Profile.DisplayName = &mappedUser.DisplayName
+	}
+	if mappedUser.PreferredLanguage != "" {
+		addHumanUser.Profile.PreferredLanguage = &mappedUser.PreferredLanguage
+	}
+	if mappedUser.EmailVerified {
+		addHumanUser.Email.Verification = &user.SetHumanEmail_IsVerified{IsVerified: true}
+	}
+	if mappedUser.Phone != "" {
+		addHumanUser.Phone = &user.SetHumanPhone{
+			Phone: mappedUser.Phone,
+		}
+		if mappedUser.PhoneVerified {
+			addHumanUser.Phone.Verification = &user.SetHumanPhone_IsVerified{IsVerified: true}
+		}
+	}
+	for i, metadata := range mappedUser.Metadata {
+		addHumanUser.Metadata[i] = &user.SetMetadataEntry{
+			Key:   metadata.Key,
+			Value: metadata.Value,
+		}
+	}
+	return addHumanUser
+}
+
 func idpOAuthTokensToPb(idpIDToken string, idpAccessToken *crypto.CryptoValue, alg crypto.EncryptionAlgorithm) (_ *user.IDPInformation_Oauth, err error) {
 	var idToken *string
 	if idpIDToken != "" {
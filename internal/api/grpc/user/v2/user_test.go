@@ -17,6 +17,7 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	"github.com/zitadel/zitadel/internal/zerrors"
 	object_pb "github.com/zitadel/zitadel/pkg/grpc/object/v2beta"
 	user "github.com/zitadel/zitadel/pkg/grpc/user/v2beta"
@@ -198,6 +199,79 @@ func Test_idpIntentToIDPIntentPb(t *testing.T) {
 				},
 				err: nil,
 			},
+		}, {
+			"successful oauth with mapped user",
+			args{
+				intent: &command.IDPIntentWriteModel{
+					WriteModel: eventstore.WriteModel{
+						AggregateID:       "intentID",
+						ProcessedSequence: 123,
+						ResourceOwner:     "ro",
+						InstanceID:        "instanceID",
+						ChangeDate:        time.Date(2019, 4, 1, 1, 1, 1, 1, time.Local),
+					},
+					IDPID:   "idpID",
+					IDPUser: []byte(`{"userID": "idpUserID", "username": "username"}`),
+					MappedUser: &idpintent.MappedUser{
+						Username:          "mapped",
+						FirstName:         "first",
+						LastName:          "last",
+						DisplayName:       "first last",
+						PreferredLanguage: "de",
+						Email:             "user@example.com",
+						EmailVerified:     true,
+						Metadata: []*idpintent.MappedMetadata{
+							{Key: "country", Value: []byte("CH")},
+						},
+					},
+					IDPUserID:   "idpUserID",
+					IDPUserName: "username",
+					State:       domain.IDPIntentStateSucceeded,
+				},
+				alg: decryption(nil),
+			},
+			res{
+				resp: &user.RetrieveIdentityProviderIntentResponse{
+					Details: &object_pb.Details{
+						Sequence:      123,
+						ChangeDate:    timestamppb.New(time.Date(2019, 4, 1, 1, 1, 1, 1, time.Local)),
+						ResourceOwner: "ro",
+					},
+					IdpInformation: &user.IDPInformation{
+						IdpId:    "idpID",
+						UserId:   "idpUserID",
+						UserName: "username",
+						RawInformation: func() *structpb.Struct {
+							s, err := structpb.NewStruct(map[string]interface{}{
+								"userID":   "idpUserID",
+								"username": "username",
+							})
+							require.NoError(t, err)
+							return s
+						}(),
+					},
+					AddHumanUser: &user.AddHumanUserRequest{
+						Username: gu.Ptr("mapped"),
+						Profile: &user.SetHumanProfile{
+							GivenName:         "first",
+							FamilyName:        "last",
+							DisplayName:       gu.Ptr("first last"),
+							PreferredLanguage: gu.Ptr("de"),
+						},
+						Email: &user.SetHumanEmail{
+							Email:        "user@example.com",
+							Verification: &user.SetHumanEmail_IsVerified{IsVerified: true},
+						},
+						Metadata: []*user.SetMetadataEntry{
+							{Key: "country", Value: []byte("CH")},
+						},
+						IdpLinks: []*user.IDPLink{
+							{IdpId: "idpID", UserId: "idpUserID", UserName: "username"},
+						},
+					},
+				},
+				err: nil,
+			},
 		}, {
 			"successful ldap",
 			args{
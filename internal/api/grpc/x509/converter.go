@@ -0,0 +1,58 @@
+package x509
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	x509_pb "github.com/zitadel/zitadel/pkg/grpc/x509"
+)
+
+func CAsToPb(cas []*domain.X509CA) []*x509_pb.X509CA {
+	resp := make([]*x509_pb.X509CA, len(cas))
+	for i, ca := range cas {
+		resp[i] = CAToPb(ca)
+	}
+	return resp
+}
+
+func CAToPb(ca *domain.X509CA) *x509_pb.X509CA {
+	return &x509_pb.X509CA{
+		Id:          ca.ID,
+		Name:        ca.Name,
+		Certificate: string(ca.Certificate),
+		Crl:         ca.CRL,
+		UserMapping: UserMappingToPb(ca.UserMapping),
+	}
+}
+
+func UserMappingToPb(mapping domain.X509UserMapping) x509_pb.X509UserMapping {
+	switch mapping {
+	case domain.X509UserMappingSubjectCommonName:
+		return x509_pb.X509UserMapping_X509_USER_MAPPING_SUBJECT_COMMON_NAME
+	case domain.X509UserMappingSubjectEmail:
+		return x509_pb.X509UserMapping_X509_USER_MAPPING_SUBJECT_EMAIL
+	case domain.X509UserMappingSANEmail:
+		return x509_pb.X509UserMapping_X509_USER_MAPPING_SAN_EMAIL
+	case domain.X509UserMappingSANUPN:
+		return x509_pb.X509UserMapping_X509_USER_MAPPING_SAN_UPN
+	case domain.X509UserMappingUnspecified:
+		return x509_pb.X509UserMapping_X509_USER_MAPPING_UNSPECIFIED
+	default:
+		return x509_pb.X509UserMapping_X509_USER_MAPPING_UNSPECIFIED
+	}
+}
+
+func UserMappingToDomain(mapping x509_pb.X509UserMapping) domain.X509UserMapping {
+	switch mapping {
+	case x509_pb.X509UserMapping_X509_USER_MAPPING_SUBJECT_COMMON_NAME:
+		return domain.X509UserMappingSubjectCommonName
+	case x509_pb.X509UserMapping_X509_USER_MAPPING_SUBJECT_EMAIL:
+		return domain.X509UserMappingSubjectEmail
+	case x509_pb.X509UserMapping_X509_USER_MAPPING_SAN_EMAIL:
+		return domain.X509UserMappingSANEmail
+	case x509_pb.X509UserMapping_X509_USER_MAPPING_SAN_UPN:
+		return domain.X509UserMappingSANUPN
+	case x509_pb.X509UserMapping_X509_USER_MAPPING_UNSPECIFIED:
+		return domain.X509UserMappingUnspecified
+	default:
+		return domain.X509UserMappingUnspecified
+	}
+}
@@ -0,0 +1,20 @@
+package http
+
+import (
+	"context"
+	"crypto/x509"
+)
+
+type clientCertificatesKey struct{}
+
+// ClientCertificatesToCtx stores the client certificate (followed by optional intermediates) of the request in the context.
+func ClientCertificatesToCtx(ctx context.Context, certificates []*x509.Certificate) context.Context {
+	return context.WithValue(ctx, clientCertificatesKey{}, certificates)
+}
+
+// ClientCertificatesFromCtx returns the client certificate (followed by optional intermediates) of the request.
+// The certificates are not verified, as this depends on their usage.
+func ClientCertificatesFromCtx(ctx context.Context) []*x509.Certificate {
+	certificates, _ := ctx.Value(clientCertificatesKey{}).([]*x509.Certificate)
+	return certificates
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"crypto/x509"
+	"net/http"
+
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// ClientCertificateHandler reads the client certificate (followed by optional intermediates)
+// from the TLS connection or, if configured, from the header of the TLS terminating proxy
+// and stores it in the context for the authentication of clients with mutual TLS
+// and the verification of certificate-bound access tokens.
+// The certificates are not verified here, as this depends on their usage.
+func ClientCertificateHandler(header string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if certificates := clientCertificatesFromRequest(r, header); len(certificates) > 0 {
+				r = r.WithContext(http_util.ClientCertificatesToCtx(r.Context(), certificates))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func clientCertificatesFromRequest(r *http.Request, header string) []*x509.Certificate {
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return r.TLS.PeerCertificates
+	}
+	if header == "" {
+		return nil
+	}
+	value := r.Header.Get(header)
+	if value == "" {
+		return nil
+	}
+	certificates, err := domain.ParseX509ClientCertificates(value)
+	if err != nil {
+		return nil
+	}
+	return certificates
+}
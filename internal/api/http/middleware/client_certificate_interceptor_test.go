@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/config/network"
+)
+
+func testTLSCertificate(t *testing.T, commonName string) (*x509.Certificate, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return cert,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// certificateRecorder returns a handler storing the client certificates found by the [ClientCertificateHandler]
+func certificateRecorder(header string, got *[]*x509.Certificate) http.Handler {
+	return ClientCertificateHandler(header)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*got = http_util.ClientCertificatesFromCtx(r.Context())
+	}))
+}
+
+func TestClientCertificateHandler_TLS(t *testing.T) {
+	_, serverCert, serverKey := testTLSCertificate(t, "server")
+	clientCert, clientCertPEM, clientKeyPEM := testTLSCertificate(t, "client")
+	clientKeyPair, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		requestClientCert bool
+		want              []*x509.Certificate
+	}{
+		{
+			name:              "client certificate not requested",
+			requestClientCert: false,
+		},
+		{
+			name:              "client certificate requested",
+			requestClientCert: true,
+			want:              []*x509.Certificate{clientCert},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []*x509.Certificate
+			server := httptest.NewUnstartedServer(certificateRecorder("", &got))
+			server.TLS, err = (&network.TLS{
+				Enabled:           true,
+				Cert:              serverCert,
+				Key:               serverKey,
+				RequestClientCert: tt.requestClientCert,
+			}).Config()
+			require.NoError(t, err)
+			server.StartTLS()
+			defer server.Close()
+
+			client := server.Client()
+			client.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{clientKeyPair}
+			resp, err := client.Get(server.URL)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClientCertificateHandler_Header(t *testing.T) {
+	clientCert, clientCertPEM, _ := testTLSCertificate(t, "client")
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   []*x509.Certificate
+	}{
+		{
+			name:  "header not configured",
+			value: url.PathEscape(string(clientCertPEM)),
+		},
+		{
+			name:   "header missing",
+			header: "X-Client-Cert",
+		},
+		{
+			name:   "invalid certificate",
+			header: "X-Client-Cert",
+			value:  "invalid",
+		},
+		{
+			name:   "escaped pem",
+			header: "X-Client-Cert",
+			value:  url.PathEscape(string(clientCertPEM)),
+			want:   []*x509.Certificate{clientCert},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []*x509.Certificate
+			r := httptest.NewRequest(http.MethodPost, "/oauth/v2/token", nil)
+			r.Header.Set("X-Client-Cert", tt.value)
+			certificateRecorder(tt.header, &got).ServeHTTP(httptest.NewRecorder(), r)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -8,8 +8,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
 
 	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
 	"github.com/gorilla/mux"
 	"github.com/zitadel/logging"
 
@@ -39,12 +43,15 @@ const (
 
 	idpPrefix = "/{" + varIDPID + ":[0-9]+}"
 
-	callbackPath    = "/callback"
-	metadataPath    = idpPrefix + "/saml/metadata"
-	acsPath         = idpPrefix + "/saml/acs"
-	certificatePath = idpPrefix + "/saml/certificate"
+	callbackPath       = "/callback"
+	logoutCallbackPath = "/logout/callback"
+	metadataPath       = idpPrefix + "/saml/metadata"
+	acsPath            = idpPrefix + "/saml/acs"
+	sloPath            = idpPrefix + "/saml/slo"
+	certificatePath    = idpPrefix + "/saml/certificate"
 
 	paramIntentID         = "id"
+	paramState            = "state"
 	paramToken            = "token"
 	paramUserID           = "user"
 	paramError            = "error"
@@ -85,6 +92,13 @@ func CallbackURL(externalSecure bool) func(ctx context.Context) string {
 	}
 }
 
+// LogoutCallbackURL generates the instance specific URL to the handler, where IDPs redirect to after terminating the session of the user
+func LogoutCallbackURL(externalSecure bool) func(ctx context.Context) string {
+	return func(ctx context.Context) string {
+		return http_utils.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), externalSecure) + HandlerPrefix + logoutCallbackPath
+	}
+}
+
 func SAMLRootURL(externalSecure bool) func(ctx context.Context, idpID string) string {
 	return func(ctx context.Context, idpID string) string {
 		return http_utils.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), externalSecure) + HandlerPrefix + "/" + idpID + "/"
@@ -120,6 +134,8 @@ func NewHandler(
 	router.HandleFunc(metadataPath, h.handleMetadata)
 	router.HandleFunc(certificatePath, h.handleCertificate)
 	router.HandleFunc(acsPath, h.handleACS)
+	router.HandleFunc(logoutCallbackPath, h.handleLogoutCallback)
+	router.HandleFunc(sloPath, h.handleSLO)
 	return router
 }
 
@@ -238,7 +254,7 @@ func (h *Handler) handleACS(w http.ResponseWriter, r *http.Request) {
 	intent, err := h.commands.GetActiveIntent(ctx, data.RelayState)
 	if err != nil {
 		if zerrors.IsNotFound(err) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			h.handleUnsolicitedResponse(w, r, data, sp, err)
 			return
 		}
 		redirectToFailureURLErr(w, r, intent, err)
@@ -258,11 +274,60 @@ func (h *Handler) handleACS(w http.ResponseWriter, r *http.Request) {
 		redirectToFailureURLErr(w, r, intent, err)
 		return
 	}
+	h.succeedSAMLIntent(w, r, intent, idpUser, session.Assertion)
+}
+
+// handleUnsolicitedResponse handles a response of a SAML IDP, which was not requested by an intent (IdP-initiated login).
+// If enabled for the IDP, an intent is created for the response and handed to the target of the RelayState
+// or the default redirect uri, where it can be used like any other intent, e.g. to create a session.
+// Otherwise, the response is rejected with the error of the missing intent (intentErr).
+func (h *Handler) handleUnsolicitedResponse(w http.ResponseWriter, r *http.Request, data *externalSAMLIDPCallbackData, sp *samlsp.Middleware, intentErr error) {
+	ctx := r.Context()
+	login, err := h.queries.IDPInitiatedLogin(ctx, data.IDPID, "")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !login.Enabled {
+		http.Error(w, intentErr.Error(), http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := login.RedirectURI(data.RelayState)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
+	session := saml2.Session{
+		ServiceProvider: sp,
+		Request:         r,
+		IDPInitiated:    true,
+	}
+	idpUser, err := session.FetchUser(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// the ID of the assertion can only be used once, replayed responses are rejected
+	intent, err := h.commands.CreateIDPInitiatedSAMLIntent(ctx, data.IDPID, session.Assertion.ID, redirectURI)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.succeedSAMLIntent(w, r, intent, idpUser, session.Assertion)
+}
+
+func (h *Handler) succeedSAMLIntent(w http.ResponseWriter, r *http.Request, intent *command.IDPIntentWriteModel, idpUser idp.User, assertion *saml.Assertion) {
+	ctx := r.Context()
 	userID, err := h.checkExternalUser(ctx, intent.IDPID, idpUser.GetID())
 	logging.WithFields("intent", intent.AggregateID).OnError(err).Error("could not check if idp user already exists")
 
-	token, err := h.commands.SucceedSAMLIDPIntent(ctx, intent, idpUser, userID, session.Assertion)
+	if err := h.applyGroupMapping(ctx, intent, userID, idpUser); err != nil {
+		redirectToFailureURLErr(w, r, intent, err)
+		return
+	}
+
+	token, err := h.commands.SucceedSAMLIDPIntent(ctx, intent, idpUser, userID, assertion)
 	if err != nil {
 		redirectToFailureURLErr(w, r, intent, zerrors.ThrowInternal(err, "IDP-JdD3g", "Errors.Intent.TokenCreationFailed"))
 		return
@@ -318,6 +383,12 @@ func (h *Handler) handleCallback(w http.ResponseWriter, r *http.Request) {
 		logging.WithFields("intent", intent.AggregateID).OnError(err).Error("migration check failed")
 	}
 
+	if err := h.applyGroupMapping(ctx, intent, userID, idpUser); err != nil {
+		redirectToFailureURLErr(w, r, intent, err)
+		return
+	}
+	h.storeIDPLinkTokens(ctx, intent, userID, idpUser, idpSession)
+
 	token, err := h.commands.SucceedIDPIntent(ctx, intent, idpUser, idpSession, userID)
 	if err != nil {
 		redirectToFailureURLErr(w, r, intent, zerrors.ThrowInternal(err, "IDP-JdD3g", "Errors.Intent.TokenCreationFailed"))
@@ -365,6 +436,110 @@ func redirectToSuccessURL(w http.ResponseWriter, r *http.Request, intent *comman
 	http.Redirect(w, r, intent.SuccessURL.String(), http.StatusFound)
 }
 
+// handleLogoutCallback is called by OIDC IDPs after the session of the user was terminated (RP-initiated logout)
+// and redirects the user agent to the redirect uri of the ZITADEL logout.
+func (h *Handler) handleLogoutCallback(w http.ResponseWriter, r *http.Request) {
+	redirectURI, err := h.logoutRedirectURI(r.Context(), r.FormValue(paramState))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, redirectURI, http.StatusFound)
+}
+
+// handleSLO receives the LogoutResponse of SAML IDPs after the session of the user was terminated (single logout)
+// and redirects the user agent to the redirect uri of the ZITADEL logout.
+// The user is already logged out of ZITADEL, so an invalid LogoutResponse is only logged.
+func (h *Handler) handleSLO(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	data := parseSAMLRequest(r)
+	if data.Response == "" {
+		http.Error(w, zerrors.ThrowInvalidArgument(nil, "SAML-Ohg3b", "Errors.Intent.ResponseInvalid").Error(), http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := h.logoutRedirectURI(ctx, data.RelayState)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = h.validateLogoutResponse(ctx, r, data.IDPID)
+	logging.WithFields("idp", data.IDPID).OnError(err).Warn("invalid saml logout response")
+	http.Redirect(w, r, redirectURI, http.StatusFound)
+}
+
+// logoutRedirectURI returns the redirect uri stored under the state of the logout at the IDP,
+// after checking it again against the post logout redirect uris of the client.
+func (h *Handler) logoutRedirectURI(ctx context.Context, state string) (string, error) {
+	redirect, err := h.commands.FinishIDPLogout(ctx, state)
+	if err != nil {
+		return "", err
+	}
+	if isRelativeURI(redirect.RedirectURI) {
+		return redirect.RedirectURI, nil
+	}
+	if redirect.ClientID == "" {
+		return "", zerrors.ThrowInvalidArgument(nil, "IDP-eiM4o", "Errors.IDPConfig.LogoutStateInvalid")
+	}
+	client, err := h.queries.GetOIDCClientByID(ctx, redirect.ClientID, false)
+	if err != nil {
+		return "", err
+	}
+	if !postLogoutRedirectURIAllowed(redirect.RedirectURI, client.PostLogoutRedirectURIs, client.IsDevMode) {
+		return "", zerrors.ThrowInvalidArgument(nil, "IDP-Xoh7d", "Errors.IDPConfig.LogoutStateInvalid")
+	}
+	return redirect.RedirectURI, nil
+}
+
+// isRelativeURI returns true for paths on the same host, such as the logged out page of the login
+func isRelativeURI(uri string) bool {
+	return strings.HasPrefix(uri, "/") && !strings.HasPrefix(uri, "//") && !strings.HasPrefix(uri, "/\\")
+}
+
+// postLogoutRedirectURIAllowed checks the redirectURI against the post logout redirect uris of the client.
+// The state parameter of the end_session request is appended to the redirectURI by the OP and therefore ignored.
+// Clients in dev mode allow glob patterns, matching the validation of the end_session endpoint.
+func postLogoutRedirectURIAllowed(redirectURI string, postLogoutRedirectURIs []string, devMode bool) bool {
+	redirect, err := url.Parse(redirectURI)
+	if err != nil {
+		return false
+	}
+	params := redirect.Query()
+	params.Del("state")
+	redirect.RawQuery = params.Encode()
+	for _, uri := range postLogoutRedirectURIs {
+		registered, err := url.Parse(uri)
+		if err != nil {
+			continue
+		}
+		registered.RawQuery = registered.Query().Encode()
+		if registered.String() == redirect.String() {
+			return true
+		}
+		if devMode {
+			if match, _ := path.Match(uri, redirect.String()); match {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (h *Handler) validateLogoutResponse(ctx context.Context, r *http.Request, idpID string) error {
+	provider, err := h.getProvider(ctx, idpID)
+	if err != nil {
+		return err
+	}
+	samlProvider, ok := provider.(*saml2.Provider)
+	if !ok {
+		return zerrors.ThrowInvalidArgument(nil, "SAML-Aeh4i", "Errors.Intent.IDPInvalid")
+	}
+	sp, err := samlProvider.GetSP()
+	if err != nil {
+		return err
+	}
+	return sp.ServiceProvider.ValidateLogoutResponseRequest(r)
+}
+
 func redirectToFailureURLErr(w http.ResponseWriter, r *http.Request, i *command.IDPIntentWriteModel, err error) {
 	msg := err.Error()
 	var description string
@@ -415,6 +590,30 @@ func (h *Handler) fetchIDPUserFromCode(ctx context.Context, identityProvider idp
 	return user, session, nil
 }
 
+// applyGroupMapping applies the group mapping of the IDP to an already linked user
+// and fails the intent if that's not possible.
+func (h *Handler) applyGroupMapping(ctx context.Context, intent *command.IDPIntentWriteModel, userID string, idpUser idp.User) error {
+	if userID == "" {
+		return nil
+	}
+	err := h.commands.ApplyIDPGroupMapping(ctx, intent.IDPID, userID, "", idpUser)
+	if err != nil {
+		cmdErr := h.commands.FailIDPIntent(ctx, intent, err.Error())
+		logging.WithFields("intent", intent.AggregateID).OnError(cmdErr).Error("failed to push failed event on idp intent")
+	}
+	return err
+}
+
+// storeIDPLinkTokens stores the tokens of the session on the IDP link of an already linked user.
+// The intent must not fail because of the token vault, so errors are only logged.
+func (h *Handler) storeIDPLinkTokens(ctx context.Context, intent *command.IDPIntentWriteModel, userID string, idpUser idp.User, idpSession idp.Session) {
+	if userID == "" {
+		return
+	}
+	err := h.commands.StoreUserIDPLinkTokens(ctx, userID, "", intent.IDPID, idpUser.GetID(), idpSession)
+	logging.WithFields("intent", intent.AggregateID).OnError(err).Error("failed to store idp tokens")
+}
+
 func (h *Handler) checkExternalUser(ctx context.Context, idpID, externalUserID string) (userID string, err error) {
 	idQuery, err := query.NewIDPUserLinkIDPIDSearchQuery(idpID)
 	if err != nil {
@@ -218,3 +218,99 @@ func Test_parseCallbackRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_isRelativeURI(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  string
+		want bool
+	}{
+		{
+			name: "path",
+			uri:  "/ui/login/logout/done",
+			want: true,
+		},
+		{
+			name: "absolute url",
+			uri:  "https://evil.io/",
+		},
+		{
+			name: "protocol relative url",
+			uri:  "//evil.io/",
+		},
+		{
+			name: "backslash",
+			uri:  "/\\evil.io/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRelativeURI(tt.uri))
+		})
+	}
+}
+
+func Test_postLogoutRedirectURIAllowed(t *testing.T) {
+	type args struct {
+		redirectURI            string
+		postLogoutRedirectURIs []string
+		devMode                bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "not registered",
+			args: args{
+				redirectURI:            "https://evil.io/",
+				postLogoutRedirectURIs: []string{"https://example.com/logged-out"},
+			},
+		},
+		{
+			name: "registered",
+			args: args{
+				redirectURI:            "https://example.com/logged-out",
+				postLogoutRedirectURIs: []string{"https://example.com/logged-out"},
+			},
+			want: true,
+		},
+		{
+			name: "registered with state",
+			args: args{
+				redirectURI:            "https://example.com/logged-out?foo=bar&state=state1",
+				postLogoutRedirectURIs: []string{"https://example.com/logged-out?foo=bar"},
+			},
+			want: true,
+		},
+		{
+			name: "registered with other query",
+			args: args{
+				redirectURI:            "https://example.com/logged-out?foo=baz",
+				postLogoutRedirectURIs: []string{"https://example.com/logged-out?foo=bar"},
+			},
+		},
+		{
+			name: "glob without dev mode",
+			args: args{
+				redirectURI:            "https://example.com/logged-out",
+				postLogoutRedirectURIs: []string{"https://example.com/*"},
+			},
+		},
+		{
+			name: "glob with dev mode",
+			args: args{
+				redirectURI:            "https://example.com/logged-out",
+				postLogoutRedirectURIs: []string{"https://example.com/*"},
+				devMode:                true,
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, postLogoutRedirectURIAllowed(tt.args.redirectURI, tt.args.postLogoutRedirectURIs, tt.args.devMode))
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/op"
 
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/user/model"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -25,6 +26,10 @@ type accessToken struct {
 	tokenCreation   time.Time
 	tokenExpiration time.Time
 	isPAT           bool
+	// levelOfAssurance reached by the authentication the token was issued for
+	levelOfAssurance domain.LevelOfAssurance
+	// certificateThumbprint of the client certificate the token is bound to
+	certificateThumbprint string
 }
 
 func (s *Server) verifyAccessToken(ctx context.Context, tkn string) (*accessToken, error) {
@@ -62,28 +67,32 @@ func (s *Server) verifyAccessToken(ctx context.Context, tkn string) (*accessToke
 
 func accessTokenV1(tokenID, subject string, token *model.TokenView) *accessToken {
 	return &accessToken{
-		tokenID:         tokenID,
-		userID:          token.UserID,
-		subject:         subject,
-		clientID:        token.ApplicationID,
-		audience:        token.Audience,
-		scope:           token.Scopes,
-		tokenCreation:   token.CreationDate,
-		tokenExpiration: token.Expiration,
-		isPAT:           token.IsPAT,
+		tokenID:               tokenID,
+		userID:                token.UserID,
+		subject:               subject,
+		clientID:              token.ApplicationID,
+		audience:              token.Audience,
+		scope:                 token.Scopes,
+		tokenCreation:         token.CreationDate,
+		tokenExpiration:       token.Expiration,
+		isPAT:                 token.IsPAT,
+		levelOfAssurance:      levelOfAssuranceFromAMR(token.AuthMethodsReferences),
+		certificateThumbprint: token.CertificateThumbprint,
 	}
 }
 
 func accessTokenV2(tokenID, subject string, token *query.OIDCSessionAccessTokenReadModel) *accessToken {
 	return &accessToken{
-		tokenID:         tokenID,
-		userID:          token.UserID,
-		subject:         subject,
-		clientID:        token.ClientID,
-		audience:        token.Audience,
-		scope:           token.Scope,
-		tokenCreation:   token.AccessTokenCreation,
-		tokenExpiration: token.AccessTokenExpiration,
+		tokenID:               tokenID,
+		userID:                token.UserID,
+		subject:               subject,
+		clientID:              token.ClientID,
+		audience:              token.Audience,
+		scope:                 token.Scope,
+		tokenCreation:         token.AccessTokenCreation,
+		tokenExpiration:       token.AccessTokenExpiration,
+		levelOfAssurance:      domain.LevelOfAssuranceFromAuthMethods(token.AuthMethods),
+		certificateThumbprint: token.CertificateThumbprint,
 	}
 }
 
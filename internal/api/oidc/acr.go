@@ -0,0 +1,72 @@
+package oidc
+
+import "github.com/zitadel/zitadel/internal/domain"
+
+// ACRConfig defines the Authentication Context Class Reference values for the levels of assurance.
+// Clients can request them using the `acr_values` parameter and the reached value
+// is returned in the `acr` claim of the id_token and the introspection response.
+// Levels with an empty value are not supported.
+type ACRConfig struct {
+	Password          string
+	MFA               string
+	PhishingResistant string
+}
+
+// Supported returns the configured values ordered by their level of assurance,
+// as advertised in the discovery endpoint.
+func (c ACRConfig) Supported() []string {
+	supported := make([]string, 0, 3)
+	for _, value := range []string{c.Password, c.MFA, c.PhishingResistant} {
+		if value != "" {
+			supported = append(supported, value)
+		}
+	}
+	if len(supported) == 0 {
+		return nil
+	}
+	return supported
+}
+
+// LevelsOfAssurance maps the requested `acr_values` to the levels of assurance.
+// Unknown values are ignored, as the `acr` claim is requested as voluntary claim.
+func (c ACRConfig) LevelsOfAssurance(acrValues []string) []domain.LevelOfAssurance {
+	if len(acrValues) == 0 {
+		return nil
+	}
+	levels := make([]domain.LevelOfAssurance, 0, len(acrValues))
+	for _, value := range acrValues {
+		if level := c.levelOfAssurance(value); level != domain.LevelOfAssuranceNone {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
+
+func (c ACRConfig) levelOfAssurance(value string) domain.LevelOfAssurance {
+	switch value {
+	case "":
+		return domain.LevelOfAssuranceNone
+	case c.PhishingResistant:
+		return domain.LevelOfAssurancePhishingResistant
+	case c.MFA:
+		return domain.LevelOfAssuranceMFA
+	case c.Password:
+		return domain.LevelOfAssurancePassword
+	default:
+		return domain.LevelOfAssuranceNone
+	}
+}
+
+// ACR returns the value of the highest supported level, which is satisfied by the reached level of assurance.
+func (c ACRConfig) ACR(level domain.LevelOfAssurance) string {
+	switch {
+	case level >= domain.LevelOfAssurancePhishingResistant && c.PhishingResistant != "":
+		return c.PhishingResistant
+	case level >= domain.LevelOfAssuranceMFA && c.MFA != "":
+		return c.MFA
+	case level >= domain.LevelOfAssurancePassword && c.Password != "":
+		return c.Password
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,134 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var testACRConfig = ACRConfig{
+	Password:          "urn:zitadel:acr:password",
+	MFA:               "urn:zitadel:acr:mfa",
+	PhishingResistant: "phr",
+}
+
+func TestACRConfig_Supported(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ACRConfig
+		want   []string
+	}{
+		{
+			"none configured",
+			ACRConfig{},
+			nil,
+		},
+		{
+			"all configured",
+			testACRConfig,
+			[]string{"urn:zitadel:acr:password", "urn:zitadel:acr:mfa", "phr"},
+		},
+		{
+			"partially configured",
+			ACRConfig{MFA: "mfa"},
+			[]string{"mfa"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.Supported())
+		})
+	}
+}
+
+func TestACRConfig_LevelsOfAssurance(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    ACRConfig
+		acrValues []string
+		want      []domain.LevelOfAssurance
+	}{
+		{
+			"no values",
+			testACRConfig,
+			nil,
+			nil,
+		},
+		{
+			"unknown and empty values ignored",
+			testACRConfig,
+			[]string{"unknown", ""},
+			[]domain.LevelOfAssurance{},
+		},
+		{
+			"not configured, ignored",
+			ACRConfig{},
+			[]string{"phr", ""},
+			[]domain.LevelOfAssurance{},
+		},
+		{
+			"multiple values",
+			testACRConfig,
+			[]string{"phr", "unknown", "urn:zitadel:acr:mfa"},
+			[]domain.LevelOfAssurance{domain.LevelOfAssurancePhishingResistant, domain.LevelOfAssuranceMFA},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.LevelsOfAssurance(tt.acrValues))
+		})
+	}
+}
+
+func TestACRConfig_ACR(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ACRConfig
+		level  domain.LevelOfAssurance
+		want   string
+	}{
+		{
+			"none reached",
+			testACRConfig,
+			domain.LevelOfAssuranceNone,
+			"",
+		},
+		{
+			"password",
+			testACRConfig,
+			domain.LevelOfAssurancePassword,
+			"urn:zitadel:acr:password",
+		},
+		{
+			"mfa",
+			testACRConfig,
+			domain.LevelOfAssuranceMFA,
+			"urn:zitadel:acr:mfa",
+		},
+		{
+			"phishing resistant",
+			testACRConfig,
+			domain.LevelOfAssurancePhishingResistant,
+			"phr",
+		},
+		{
+			"phishing resistant not configured, mfa",
+			ACRConfig{Password: "pwd", MFA: "mfa"},
+			domain.LevelOfAssurancePhishingResistant,
+			"mfa",
+		},
+		{
+			"lower level not configured",
+			ACRConfig{MFA: "mfa"},
+			domain.LevelOfAssurancePassword,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.ACR(tt.level))
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package oidc
 
-import "github.com/zitadel/zitadel/internal/domain"
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
 
 const (
 	// Password states that the users password has been verified
@@ -40,7 +44,9 @@ func AuthMethodTypesToAMR(methodTypes []domain.UserAuthMethodType) []string {
 			// a user could use multiple (t)otp, which is a factor, but still will be returned as a single `otp` entry
 			otp++
 			factors++
-		case domain.UserAuthMethodTypeIDP:
+		case domain.UserAuthMethodTypeIDP,
+			domain.UserAuthMethodTypeMagicLink,
+			domain.UserAuthMethodTypeX509:
 			// no AMR value according to specification
 			factors++
 		case domain.UserAuthMethodTypeUnspecified:
@@ -55,3 +61,18 @@ func AuthMethodTypesToAMR(methodTypes []domain.UserAuthMethodType) []string {
 	}
 	return amr
 }
+
+// levelOfAssuranceFromAMR maps Authentication Method Reference Values (e.g. stored on V1 tokens)
+// back to the reached level of assurance.
+func levelOfAssuranceFromAMR(amr []string) domain.LevelOfAssurance {
+	if len(amr) == 0 {
+		return domain.LevelOfAssuranceNone
+	}
+	if !slices.Contains(amr, MFA) {
+		return domain.LevelOfAssurancePassword
+	}
+	if slices.Contains(amr, UserPresence) {
+		return domain.LevelOfAssurancePhishingResistant
+	}
+	return domain.LevelOfAssuranceMFA
+}
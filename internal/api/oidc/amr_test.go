@@ -88,3 +88,37 @@ func TestAMR(t *testing.T) {
 		})
 	}
 }
+
+func Test_levelOfAssuranceFromAMR(t *testing.T) {
+	tests := []struct {
+		name string
+		amr  []string
+		want domain.LevelOfAssurance
+	}{
+		{
+			"no amr",
+			nil,
+			domain.LevelOfAssuranceNone,
+		},
+		{
+			"pwd",
+			[]string{Password, PWD},
+			domain.LevelOfAssurancePassword,
+		},
+		{
+			"pwd and otp",
+			[]string{PWD, OTP, MFA},
+			domain.LevelOfAssuranceMFA,
+		},
+		{
+			"passkey",
+			[]string{UserPresence, MFA},
+			domain.LevelOfAssurancePhishingResistant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, levelOfAssuranceFromAMR(tt.amr))
+		})
+	}
+}
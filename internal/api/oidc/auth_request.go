@@ -3,6 +3,7 @@ package oidc
 import (
 	"context"
 	"encoding/base64"
+	"slices"
 	"strings"
 	"time"
 
@@ -52,6 +53,10 @@ func (o *OPStorage) createAuthRequestLoginClient(ctx context.Context, req *oidc.
 		return nil, err
 	}
 	audience = domain.AddAudScopeToAudience(ctx, audience, scope)
+	app, err := o.query.AppByOIDCClientID(ctx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
 	authRequest := &command.AuthRequest{
 		LoginClient:   loginClient,
 		ClientID:      req.ClientID,
@@ -65,6 +70,11 @@ func (o *OPStorage) createAuthRequestLoginClient(ctx context.Context, req *oidc.
 		Prompt:        PromptToBusiness(req.Prompt),
 		UILocales:     UILocalesToBusiness(req.UILocales),
 		MaxAge:        MaxAgeToBusiness(req.MaxAge),
+
+		ConsentRequired: app.OIDCConfig != nil && app.OIDCConfig.ConsentRequired,
+	}
+	if levels := o.acr.LevelsOfAssurance(req.ACRValues); len(levels) > 0 {
+		authRequest.LevelOfAssurance = slices.Min(levels)
 	}
 	if req.LoginHint != "" {
 		authRequest.LoginHint = &req.LoginHint
@@ -77,7 +87,7 @@ func (o *OPStorage) createAuthRequestLoginClient(ctx context.Context, req *oidc.
 	if err != nil {
 		return nil, err
 	}
-	return &AuthRequestV2{aar}, nil
+	return &AuthRequestV2{CurrentAuthRequest: aar, acr: o.acr}, nil
 }
 
 func (o *OPStorage) createAuthRequest(ctx context.Context, req *oidc.AuthRequest, userID string) (_ op.AuthRequest, err error) {
@@ -89,12 +99,12 @@ func (o *OPStorage) createAuthRequest(ctx context.Context, req *oidc.AuthRequest
 	if err != nil {
 		return nil, zerrors.ThrowPreconditionFailed(err, "OIDC-Gqrfg", "Errors.Internal")
 	}
-	authRequest := CreateAuthRequestToBusiness(ctx, req, userAgentID, userID)
+	authRequest := CreateAuthRequestToBusiness(ctx, req, o.acr, userAgentID, userID)
 	resp, err := o.repo.CreateAuthRequest(ctx, authRequest)
 	if err != nil {
 		return nil, err
 	}
-	return AuthRequestFromBusiness(resp)
+	return AuthRequestFromBusiness(resp, o.acr)
 }
 
 func (o *OPStorage) audienceFromProjectID(ctx context.Context, projectID string) ([]string, error) {
@@ -119,7 +129,7 @@ func (o *OPStorage) AuthRequestByID(ctx context.Context, id string) (_ op.AuthRe
 		if err != nil {
 			return nil, err
 		}
-		return &AuthRequestV2{req}, nil
+		return &AuthRequestV2{CurrentAuthRequest: req, acr: o.acr}, nil
 	}
 
 	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
@@ -130,7 +140,7 @@ func (o *OPStorage) AuthRequestByID(ctx context.Context, id string) (_ op.AuthRe
 	if err != nil {
 		return nil, err
 	}
-	return AuthRequestFromBusiness(resp)
+	return AuthRequestFromBusiness(resp, o.acr)
 }
 
 func (o *OPStorage) AuthRequestByCode(ctx context.Context, code string) (_ op.AuthRequest, err error) {
@@ -146,13 +156,13 @@ func (o *OPStorage) AuthRequestByCode(ctx context.Context, code string) (_ op.Au
 		if err != nil {
 			return nil, err
 		}
-		return &AuthRequestV2{authReq}, nil
+		return &AuthRequestV2{CurrentAuthRequest: authReq, acr: o.acr}, nil
 	}
 	resp, err := o.repo.AuthRequestByCode(ctx, code)
 	if err != nil {
 		return nil, err
 	}
-	return AuthRequestFromBusiness(resp)
+	return AuthRequestFromBusiness(resp, o.acr)
 }
 
 // decryptGrant decrypts a code or refresh_token
@@ -191,17 +201,20 @@ func (o *OPStorage) CreateAccessToken(ctx context.Context, req op.TokenRequest)
 	defer func() { span.EndWithError(err) }()
 
 	var userAgentID, applicationID, userOrgID string
+	var authMethodsReferences []string
 	switch authReq := req.(type) {
 	case *AuthRequest:
 		userAgentID = authReq.AgentID
 		applicationID = authReq.ApplicationID
 		userOrgID = authReq.UserOrgID
+		authMethodsReferences = authReq.GetAMR()
 	case *AuthRequestV2:
 		// trigger activity log for authentication for user
 		activity.Trigger(ctx, "", authReq.CurrentAuthRequest.UserID, activity.OIDCAccessToken)
-		return o.command.AddOIDCSessionAccessToken(setContextUserSystem(ctx), authReq.GetID())
+		return o.command.AddOIDCSessionAccessToken(setContextUserSystem(ctx), authReq.GetID(), certificateThumbprintFromContext(ctx))
 	case op.IDTokenRequest:
 		applicationID = authReq.GetClientID()
+		authMethodsReferences = authReq.GetAMR()
 	}
 
 	accessTokenLifetime, _, _, _, err := o.getOIDCSettings(ctx)
@@ -209,7 +222,7 @@ func (o *OPStorage) CreateAccessToken(ctx context.Context, req op.TokenRequest)
 		return "", time.Time{}, err
 	}
 
-	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), accessTokenLifetime) //PLANNED: lifetime from client
+	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), authMethodsReferences, accessTokenLifetime, certificateThumbprintFromContext(ctx)) //PLANNED: lifetime from client
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -228,11 +241,11 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 	case *AuthRequestV2:
 		// trigger activity log for authentication for user
 		activity.Trigger(ctx, "", tokenReq.GetSubject(), activity.OIDCRefreshToken)
-		return o.command.AddOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.GetID())
+		return o.command.AddOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.GetID(), certificateThumbprintFromContext(ctx))
 	case *RefreshTokenRequestV2:
 		// trigger activity log for authentication for user
 		activity.Trigger(ctx, "", tokenReq.GetSubject(), activity.OIDCRefreshToken)
-		return o.command.ExchangeOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.OIDCSessionWriteModel.AggregateID, refreshToken, tokenReq.RequestedScopes)
+		return o.command.ExchangeOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.OIDCSessionWriteModel.AggregateID, refreshToken, tokenReq.RequestedScopes, certificateThumbprintFromContext(ctx))
 	}
 
 	userAgentID, applicationID, userOrgID, authTime, authMethodsReferences := getInfoFromRequest(req)
@@ -251,7 +264,7 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 
 	resp, token, err := o.command.AddAccessAndRefreshToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(),
 		refreshToken, req.GetAudience(), scopes, authMethodsReferences, accessTokenLifetime,
-		refreshTokenIdleExpiration, refreshTokenExpiration, authTime) //PLANNED: lifetime from client
+		refreshTokenIdleExpiration, refreshTokenExpiration, authTime, certificateThumbprintFromContext(ctx)) //PLANNED: lifetime from client
 	if err != nil {
 		if zerrors.IsErrorInvalidArgument(err) {
 			err = oidc.ErrInvalidGrant().WithParent(err)
@@ -337,7 +350,8 @@ func (o *OPStorage) TerminateSessionFromRequest(ctx context.Context, endSessionR
 	// and if not provided, terminate the session using the V1 method
 	headers, _ := http_utils.HeadersFromCtx(ctx)
 	if loginClient := headers.Get(LoginClientHeader); loginClient == "" {
-		return endSessionRequest.RedirectURI, o.TerminateSession(ctx, endSessionRequest.UserID, endSessionRequest.ClientID)
+		redirectURI = o.userAgentIDPLogoutURL(ctx, endSessionRequest.ClientID, endSessionRequest.RedirectURI)
+		return redirectURI, o.TerminateSession(ctx, endSessionRequest.UserID, endSessionRequest.ClientID)
 	}
 
 	// in case there are not id_token_hint, redirect to the UI and let it decide which session to terminate
@@ -346,11 +360,43 @@ func (o *OPStorage) TerminateSessionFromRequest(ctx context.Context, endSessionR
 	}
 
 	// terminate the session of the id_token_hint
+	redirectURI = o.sessionIDPLogoutURL(ctx, endSessionRequest.IDTokenHintClaims.SessionID, endSessionRequest.ClientID, endSessionRequest.RedirectURI)
 	_, err = o.command.TerminateSessionWithoutTokenCheck(ctx, endSessionRequest.IDTokenHintClaims.SessionID)
 	if err != nil {
 		return "", err
 	}
-	return endSessionRequest.RedirectURI, nil
+	return redirectURI, nil
+}
+
+// userAgentIDPLogoutURL returns the URL to terminate the session at the IDP, the user(s) of the user agent authenticated with,
+// if logout propagation is enabled on the IDP. The IDP will send the user agent back to the redirectURI afterwards.
+// The logout must not fail because of the IDP, so the redirectURI is returned in case of an error.
+func (o *OPStorage) userAgentIDPLogoutURL(ctx context.Context, clientID, redirectURI string) string {
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return redirectURI
+	}
+	userIDs, err := o.repo.UserSessionUserIDsByAgentID(ctx, userAgentID)
+	if err != nil || len(userIDs) == 0 {
+		return redirectURI
+	}
+	logoutURL, err := o.command.UserAgentIDPLogoutURL(ctx, userAgentID, userIDs, clientID, redirectURI, o.idpLogoutCallbackURL(ctx), o.idpSAMLRootURL)
+	logging.WithFields("userAgentID", userAgentID).OnError(err).Error("unable to create idp logout url")
+	if logoutURL == "" {
+		return redirectURI
+	}
+	return logoutURL
+}
+
+// sessionIDPLogoutURL returns the URL to terminate the session at the IDP, the user of the (v2) session authenticated with.
+// See [OPStorage.userAgentIDPLogoutURL] for details.
+func (o *OPStorage) sessionIDPLogoutURL(ctx context.Context, sessionID, clientID, redirectURI string) string {
+	logoutURL, err := o.command.SessionIDPLogoutURL(ctx, sessionID, clientID, redirectURI, o.idpLogoutCallbackURL(ctx), o.idpSAMLRootURL)
+	logging.WithFields("sessionID", sessionID).OnError(err).Error("unable to create idp logout url")
+	if logoutURL == "" {
+		return redirectURI
+	}
+	return logoutURL
 }
 
 func (o *OPStorage) RevokeToken(ctx context.Context, token, userID, clientID string) (err *oidc.Error) {
@@ -19,6 +19,7 @@ import (
 
 type AuthRequest struct {
 	*domain.AuthRequest
+	acr ACRConfig
 }
 
 func (a *AuthRequest) GetID() string {
@@ -26,8 +27,7 @@ func (a *AuthRequest) GetID() string {
 }
 
 func (a *AuthRequest) GetACR() string {
-	// return a.
-	return "" //PLANNED: impl
+	return a.acr.ACR(a.LevelOfAssurance())
 }
 
 func (a *AuthRequest) GetAMR() []string {
@@ -94,14 +94,14 @@ func (a *AuthRequest) oidc() *domain.AuthRequestOIDC {
 	return a.Request.(*domain.AuthRequestOIDC)
 }
 
-func AuthRequestFromBusiness(authReq *domain.AuthRequest) (_ op.AuthRequest, err error) {
+func AuthRequestFromBusiness(authReq *domain.AuthRequest, acr ACRConfig) (_ op.AuthRequest, err error) {
 	if _, ok := authReq.Request.(*domain.AuthRequestOIDC); !ok {
 		return nil, zerrors.ThrowInvalidArgument(nil, "OIDC-Haz7A", "auth request is not of type oidc")
 	}
-	return &AuthRequest{authReq}, nil
+	return &AuthRequest{AuthRequest: authReq, acr: acr}, nil
 }
 
-func CreateAuthRequestToBusiness(ctx context.Context, authReq *oidc.AuthRequest, userAgentID, userID string) *domain.AuthRequest {
+func CreateAuthRequestToBusiness(ctx context.Context, authReq *oidc.AuthRequest, acr ACRConfig, userAgentID, userID string) *domain.AuthRequest {
 	return &domain.AuthRequest{
 		CreationDate:        time.Now(),
 		AgentID:             userAgentID,
@@ -110,7 +110,7 @@ func CreateAuthRequestToBusiness(ctx context.Context, authReq *oidc.AuthRequest,
 		CallbackURI:         authReq.RedirectURI,
 		TransferState:       authReq.State,
 		Prompt:              PromptToBusiness(authReq.Prompt),
-		PossibleLOAs:        ACRValuesToBusiness(authReq.ACRValues),
+		PossibleLOAs:        acr.LevelsOfAssurance(authReq.ACRValues),
 		UiLocales:           UILocalesToBusiness(authReq.UILocales),
 		LoginHint:           authReq.LoginHint,
 		SelectedIDPConfigID: GetSelectedIDPIDFromScopes(authReq.Scopes),
@@ -173,10 +173,6 @@ func PromptToBusiness(oidcPrompt []string) []domain.Prompt {
 	return prompts
 }
 
-func ACRValuesToBusiness(values []string) []domain.LevelOfAssurance {
-	return nil
-}
-
 func UILocalesToBusiness(tags []language.Tag) []string {
 	if tags == nil {
 		return nil
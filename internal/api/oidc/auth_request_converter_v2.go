@@ -6,10 +6,18 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
 )
 
 type AuthRequestV2 struct {
 	*command.CurrentAuthRequest
+	acr ACRConfig
+}
+
+// AuthRequestV2 wraps the auth request of the login client (V2),
+// so the achieved level of assurance can be returned as `acr` claim.
+func (s *Server) AuthRequestV2(authRequest *command.CurrentAuthRequest) *AuthRequestV2 {
+	return &AuthRequestV2{CurrentAuthRequest: authRequest, acr: s.acr}
 }
 
 func (a *AuthRequestV2) GetID() string {
@@ -17,7 +25,7 @@ func (a *AuthRequestV2) GetID() string {
 }
 
 func (a *AuthRequestV2) GetACR() string {
-	return "" //PLANNED: impl
+	return a.acr.ACR(domain.LevelOfAssuranceFromAuthMethods(a.AuthMethods))
 }
 
 func (a *AuthRequestV2) GetAMR() []string {
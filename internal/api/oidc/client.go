@@ -112,6 +112,9 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo *oidc.Use
 		if err = o.isOriginAllowed(ctx, token.ClientID, origin); err != nil {
 			return err
 		}
+		if err = verifyCertificateBinding(ctx, token.CertificateThumbprint); err != nil {
+			return err
+		}
 		return o.setUserinfo(ctx, userInfo, token.UserID, token.ClientID, token.Scope, nil)
 	}
 
@@ -124,6 +127,9 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo *oidc.Use
 			return err
 		}
 	}
+	if err = verifyCertificateBinding(ctx, token.CertificateThumbprint); err != nil {
+		return err
+	}
 	return o.setUserinfo(ctx, userInfo, token.UserID, token.ApplicationID, token.Scopes, nil)
 }
 
@@ -173,7 +179,7 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 		return o.introspect(ctx, introspection,
 			tokenID, token.UserID, token.ClientID, clientID, projectID,
 			token.Audience, token.Scope,
-			token.AccessTokenCreation, token.AccessTokenExpiration)
+			token.AccessTokenCreation, token.AccessTokenExpiration, token.CertificateThumbprint)
 	}
 
 	token, err := o.repo.TokenByIDs(ctx, subject, tokenID)
@@ -193,7 +199,7 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 	return o.introspect(ctx, introspection,
 		token.ID, token.UserID, token.ApplicationID, clientID, projectID,
 		token.Audience, token.Scopes,
-		token.CreationDate, token.Expiration)
+		token.CreationDate, token.Expiration, token.CertificateThumbprint)
 }
 
 func (o *OPStorage) ClientCredentialsTokenRequest(ctx context.Context, clientID string, scope []string) (op.TokenRequest, error) {
@@ -241,6 +247,7 @@ func (o *OPStorage) introspect(
 	tokenID, subject, tokenClientID, introspectionClientID, introspectionProjectID string,
 	audience, scope []string,
 	tokenCreation, tokenExpiration time.Time,
+	certificateThumbprint string,
 ) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -262,6 +269,9 @@ func (o *OPStorage) introspect(
 			introspection.Audience = audience
 			introspection.Issuer = op.IssuerFromContext(ctx)
 			introspection.JWTID = tokenID
+			if certificateThumbprint != "" {
+				introspection.Claims = appendClaim(introspection.Claims, ClaimConfirmation, confirmationClaim(certificateThumbprint))
+			}
 			return nil
 		}
 	}
@@ -606,6 +616,9 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 			claims = appendClaim(claims, fmt.Sprintf(ClaimProjectRolesFormat, projectID), roles)
 		}
 	}
+	if thumbprint := certificateThumbprintFromContext(ctx); thumbprint != "" {
+		claims = appendClaim(claims, ClaimConfirmation, confirmationClaim(thumbprint))
+	}
 
 	return o.privateClaimsFlows(ctx, userID, userGrants, claims)
 }
@@ -930,18 +943,24 @@ func (s *Server) VerifyClient(ctx context.Context, r *op.Request[op.ClientCreden
 		}
 	}
 
+	var certificateThumbprint string
 	switch client.AuthMethodType {
 	case domain.OIDCAuthMethodTypeBasic, domain.OIDCAuthMethodTypePost:
 		err = s.verifyClientSecret(ctx, client, r.Data.ClientSecret)
 	case domain.OIDCAuthMethodTypePrivateKeyJWT:
 		err = s.verifyClientAssertion(ctx, client, r.Data.ClientAssertion)
+	case domain.OIDCAuthMethodTypeTLSClientAuth, domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		certificateThumbprint, err = s.verifyClientCertificate(ctx, client.ProjectID, client.AppID, client.ResourceOwner,
+			client.AuthMethodType == domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth)
 	case domain.OIDCAuthMethodTypeNone:
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	return ClientFromBusiness(client, s.defaultLoginURL, s.defaultLoginURLV2), nil
+	opClient := ClientFromBusiness(client, s.defaultLoginURL, s.defaultLoginURLV2)
+	opClient.certificateThumbprint = certificateThumbprint
+	return opClient, nil
 }
 
 func (s *Server) verifyClientAssertion(ctx context.Context, client *query.OIDCClient, assertion string) (err error) {
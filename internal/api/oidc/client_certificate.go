@@ -0,0 +1,103 @@
+package oidc
+
+import (
+	"context"
+	"crypto/x509"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// AuthMethodTLSClientAuth authenticates the client with a certificate issued by a trusted CA (RFC 8705)
+	AuthMethodTLSClientAuth oidc.AuthMethod = "tls_client_auth"
+	// AuthMethodSelfSignedTLSClientAuth authenticates the client with a registered self-signed certificate (RFC 8705)
+	AuthMethodSelfSignedTLSClientAuth oidc.AuthMethod = "self_signed_tls_client_auth"
+
+	// ClaimConfirmation contains the thumbprint of the client certificate a token is bound to (RFC 8705)
+	ClaimConfirmation = "cnf"
+	// ConfirmationX509Thumbprint is the confirmation method of certificate-bound access tokens
+	ConfirmationX509Thumbprint = "x5t#S256"
+)
+
+type certificateThumbprintKey struct{}
+
+// bindToClientCertificate passes the thumbprint of the certificate the client authenticated with to the storage,
+// so that the issued access tokens are bound to it.
+func bindToClientCertificate(ctx context.Context, client op.Client) context.Context {
+	c, ok := client.(*Client)
+	if !ok || c.certificateThumbprint == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, certificateThumbprintKey{}, c.certificateThumbprint)
+}
+
+func certificateThumbprintFromContext(ctx context.Context) string {
+	thumbprint, _ := ctx.Value(certificateThumbprintKey{}).(string)
+	return thumbprint
+}
+
+// verifyCertificateBinding checks that a certificate-bound access token is presented with the certificate it is bound to.
+func verifyCertificateBinding(ctx context.Context, thumbprint string) error {
+	return domain.VerifyX509CertificateBinding(http_utils.ClientCertificatesFromCtx(ctx), thumbprint)
+}
+
+func confirmationClaim(thumbprint string) map[string]string {
+	return map[string]string{ConfirmationX509Thumbprint: thumbprint}
+}
+
+// verifyClientCertificate authenticates a client with the certificate of the request and returns its thumbprint.
+// Self-signed certificates must match the one registered for the application,
+// others must be issued by a trusted CA of the organization (resourceOwner) or instance and match the registered subject.
+func (s *Server) verifyClientCertificate(ctx context.Context, projectID, appID, resourceOwner string, selfSigned bool) (_ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	certificates := http_utils.ClientCertificatesFromCtx(ctx)
+	if len(certificates) == 0 {
+		return "", oidc.ErrInvalidClient().WithDescription("client certificate missing")
+	}
+	tlsClientAuth, err := s.query.AppTLSClientAuth(ctx, projectID, appID)
+	if err != nil {
+		return "", err
+	}
+	leaf, now := certificates[0], time.Now()
+	if selfSigned {
+		if err = tlsClientAuth.VerifySelfSigned(leaf, now); err != nil {
+			return "", oidc.ErrInvalidClient().WithParent(err).WithDescription("invalid client certificate")
+		}
+		return domain.X509CertificateThumbprint(leaf), nil
+	}
+	if !tlsClientAuth.MatchesSubjectDN(leaf) {
+		return "", oidc.ErrInvalidClient().WithDescription("client certificate subject mismatch")
+	}
+	orgCAs, err := s.query.X509CAsByResourceOwner(ctx, resourceOwner)
+	if err != nil {
+		return "", err
+	}
+	instanceCAs, err := s.query.X509CAsByResourceOwner(ctx, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return "", err
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	for _, ca := range append(orgCAs, instanceCAs...) {
+		issued, err := ca.VerifyClientCertificate(leaf, intermediates, now)
+		if !issued {
+			continue
+		}
+		if err != nil {
+			return "", oidc.ErrInvalidClient().WithParent(err).WithDescription("invalid client certificate")
+		}
+		return domain.X509CertificateThumbprint(leaf), nil
+	}
+	return "", oidc.ErrInvalidClient().WithDescription("client certificate not trusted")
+}
@@ -18,9 +18,11 @@ type Client struct {
 	defaultLoginURL   string
 	defaultLoginURLV2 string
 	allowedScopes     []string
+	// certificateThumbprint of the client certificate, if the client authenticated with mutual TLS
+	certificateThumbprint string
 }
 
-func ClientFromBusiness(client *query.OIDCClient, defaultLoginURL, defaultLoginURLV2 string) op.Client {
+func ClientFromBusiness(client *query.OIDCClient, defaultLoginURL, defaultLoginURLV2 string) *Client {
 	allowedScopes := make([]string, len(client.ProjectRoleKeys))
 	for i, roleKey := range client.ProjectRoleKeys {
 		allowedScopes[i] = ScopeProjectRolePrefix + roleKey
@@ -171,6 +173,10 @@ func authMethodToOIDC(authType domain.OIDCAuthMethodType) oidc.AuthMethod {
 		return oidc.AuthMethodNone
 	case domain.OIDCAuthMethodTypePrivateKeyJWT:
 		return oidc.AuthMethodPrivateKeyJWT
+	case domain.OIDCAuthMethodTypeTLSClientAuth:
+		return AuthMethodTLSClientAuth
+	case domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		return AuthMethodSelfSignedTLSClientAuth
 	default:
 		return oidc.AuthMethodBasic
 	}
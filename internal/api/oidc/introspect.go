@@ -112,6 +112,13 @@ func (s *Server) Introspect(ctx context.Context, r *op.Request[op.IntrospectionR
 		Issuer:     op.IssuerFromContext(ctx),
 		JWTID:      token.tokenID,
 	}
+	if acr := s.acr.ACR(token.levelOfAssurance); acr != "" {
+		userInfo.AppendClaims("acr", acr)
+	}
+	// the resource server must verify that the token is presented with the certificate it is bound to
+	if token.certificateThumbprint != "" {
+		userInfo.AppendClaims(ClaimConfirmation, confirmationClaim(token.certificateThumbprint))
+	}
 	introspectionResp.SetUserInfo(userInfo)
 	return op.NewResponse(introspectionResp), nil
 }
@@ -133,6 +140,13 @@ func (s *Server) introspectionClientAuth(ctx context.Context, cc *op.ClientCrede
 			return "", "", err
 		}
 
+		if tlsClientAuth, selfSigned := client.TLSClientAuth(); tlsClientAuth {
+			if _, err := s.verifyClientCertificate(ctx, client.ProjectID, client.AppID, client.ResourceOwner, selfSigned); err != nil {
+				return "", "", oidc.ErrUnauthorizedClient().WithParent(err)
+			}
+			return client.ClientID, client.ProjectID, nil
+		}
+
 		if cc.ClientAssertion != "" {
 			verifier := op.NewJWTProfileVerifierKeySet(keySetMap(client.PublicKeys), op.IssuerFromContext(ctx), time.Hour, time.Second)
 			if _, err := op.VerifyJWTAssertion(ctx, cc.ClientAssertion, verifier); err != nil {
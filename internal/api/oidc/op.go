@@ -13,6 +13,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/assets"
 	http_utils "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/idp"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	"github.com/zitadel/zitadel/internal/auth/repository"
 	"github.com/zitadel/zitadel/internal/command"
@@ -40,8 +41,10 @@ type Config struct {
 	Cache                             *middleware.CacheConfig
 	CustomEndpoints                   *EndpointConfig
 	DeviceAuth                        *DeviceAuthorizationConfig
+	TLSClientCertificateHeader        string
 	DefaultLoginURLV2                 string
 	DefaultLogoutURLV2                string
+	ACR                               ACRConfig
 	Features                          Features
 }
 
@@ -82,6 +85,9 @@ type OPStorage struct {
 	encAlg                            crypto.EncryptionAlgorithm
 	locker                            crdb.Locker
 	assetAPIPrefix                    func(ctx context.Context) string
+	acr                               ACRConfig
+	idpLogoutCallbackURL              func(ctx context.Context) string
+	idpSAMLRootURL                    func(ctx context.Context, idpID string) string
 }
 
 func NewServer(
@@ -137,6 +143,7 @@ func NewServer(
 		hashAlg:                    crypto.NewBCrypt(10), // as we are only verifying in oidc, the cost is already part of the hash string and the config here is irrelevant.
 		signingKeyAlgorithm:        config.SigningKeyAlgorithm,
 		assetAPIPrefix:             assets.AssetAPI(externalSecure),
+		acr:                        config.ACR,
 	}
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
 	server.Handler = op.RegisterLegacyServer(server, op.WithHTTPMiddleware(
@@ -146,6 +153,7 @@ func NewServer(
 		instanceHandler,
 		userAgentCookie,
 		http_utils.CopyHeadersToContext,
+		middleware.ClientCertificateHandler(config.TLSClientCertificateHeader),
 		accessHandler.HandleIgnorePathPrefixes(ignoredQuotaLimitEndpoint(config.CustomEndpoints)),
 		middleware.ActivityHandler,
 	))
@@ -202,6 +210,9 @@ func newStorage(config Config, command *command.Commands, query *query.Queries,
 		encAlg:                            encAlg,
 		locker:                            crdb.NewLocker(db.DB, locksTable, signingKey),
 		assetAPIPrefix:                    assets.AssetAPI(externalSecure),
+		acr:                               config.ACR,
+		idpLogoutCallbackURL:              idp.LogoutCallbackURL(externalSecure),
+		idpSAMLRootURL:                    idp.SAMLRootURL(externalSecure),
 	}
 }
 
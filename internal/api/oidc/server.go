@@ -38,6 +38,7 @@ type Server struct {
 	hashAlg             crypto.HashAlgorithm
 	signingKeyAlgorithm string
 	assetAPIPrefix      func(ctx context.Context) string
+	acr                 ACRConfig
 }
 
 func endpoints(endpointConfig *EndpointConfig) op.Endpoints {
@@ -119,7 +120,16 @@ func (s *Server) Discovery(ctx context.Context, r *op.Request[struct{}]) (_ *op.
 	if len(allowedLanguages) == 0 {
 		allowedLanguages = i18n.SupportedLanguages()
 	}
-	return op.NewResponse(s.createDiscoveryConfig(ctx, allowedLanguages)), nil
+	return op.NewResponse(&discoveryConfiguration{
+		DiscoveryConfiguration:                s.createDiscoveryConfig(ctx, allowedLanguages),
+		TLSClientCertificateBoundAccessTokens: true,
+	}), nil
+}
+
+// discoveryConfiguration extends the discovery document of the library with the metadata of mutual TLS (RFC 8705).
+type discoveryConfiguration struct {
+	*oidc.DiscoveryConfiguration
+	TLSClientCertificateBoundAccessTokens bool `json:"tls_client_certificate_bound_access_tokens,omitempty"`
 }
 
 func (s *Server) Keys(ctx context.Context, r *op.Request[struct{}]) (_ *op.Response, err error) {
@@ -154,14 +164,14 @@ func (s *Server) CodeExchange(ctx context.Context, r *op.ClientRequest[oidc.Acce
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.CodeExchange(ctx, r)
+	return s.LegacyServer.CodeExchange(bindToClientCertificate(ctx, r.Client), r)
 }
 
 func (s *Server) RefreshToken(ctx context.Context, r *op.ClientRequest[oidc.RefreshTokenRequest]) (_ *op.Response, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.RefreshToken(ctx, r)
+	return s.LegacyServer.RefreshToken(bindToClientCertificate(ctx, r.Client), r)
 }
 
 func (s *Server) JWTProfile(ctx context.Context, r *op.Request[oidc.JWTProfileGrantRequest]) (_ *op.Response, err error) {
@@ -175,7 +185,7 @@ func (s *Server) TokenExchange(ctx context.Context, r *op.ClientRequest[oidc.Tok
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.TokenExchange(ctx, r)
+	return s.LegacyServer.TokenExchange(bindToClientCertificate(ctx, r.Client), r)
 }
 
 func (s *Server) ClientCredentialsExchange(ctx context.Context, r *op.ClientRequest[oidc.ClientCredentialsRequest]) (_ *op.Response, err error) {
@@ -189,7 +199,7 @@ func (s *Server) DeviceToken(ctx context.Context, r *op.ClientRequest[oidc.Devic
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.DeviceToken(ctx, r)
+	return s.LegacyServer.DeviceToken(bindToClientCertificate(ctx, r.Client), r)
 }
 
 func (s *Server) UserInfo(ctx context.Context, r *op.Request[oidc.UserInfoRequest]) (_ *op.Response, err error) {
@@ -231,14 +241,15 @@ func (s *Server) createDiscoveryConfig(ctx context.Context, supportedUILocales o
 		SubjectTypesSupported:                      op.SubjectTypes(s.Provider()),
 		IDTokenSigningAlgValuesSupported:           []string{s.signingKeyAlgorithm},
 		RequestObjectSigningAlgValuesSupported:     op.RequestObjectSigAlgorithms(s.Provider()),
-		TokenEndpointAuthMethodsSupported:          op.AuthMethodsTokenEndpoint(s.Provider()),
+		TokenEndpointAuthMethodsSupported:          append(op.AuthMethodsTokenEndpoint(s.Provider()), AuthMethodTLSClientAuth, AuthMethodSelfSignedTLSClientAuth),
 		TokenEndpointAuthSigningAlgValuesSupported: op.TokenSigAlgorithms(s.Provider()),
 		IntrospectionEndpointAuthSigningAlgValuesSupported: op.IntrospectionSigAlgorithms(s.Provider()),
-		IntrospectionEndpointAuthMethodsSupported:          op.AuthMethodsIntrospectionEndpoint(s.Provider()),
+		IntrospectionEndpointAuthMethodsSupported:          append(op.AuthMethodsIntrospectionEndpoint(s.Provider()), AuthMethodTLSClientAuth, AuthMethodSelfSignedTLSClientAuth),
 		RevocationEndpointAuthSigningAlgValuesSupported:    op.RevocationSigAlgorithms(s.Provider()),
 		RevocationEndpointAuthMethodsSupported:             op.AuthMethodsRevocationEndpoint(s.Provider()),
 		ClaimsSupported:                                    op.SupportedClaims(s.Provider()),
 		CodeChallengeMethodsSupported:                      op.CodeChallengeMethods(s.Provider()),
+		ACRValuesSupported:                                 s.acr.Supported(),
 		UILocalesSupported:                                 supportedUILocales,
 		RequestParameterSupported:                          s.Provider().RequestObjectSupported(),
 	}
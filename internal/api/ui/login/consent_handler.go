@@ -0,0 +1,161 @@
+package login
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/schema"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	tmplConsent = "consent"
+)
+
+type consentFormData struct {
+	Deny bool `schema:"deny"`
+}
+
+type consentData struct {
+	userData
+	AppName string
+	Scopes  []consentScope
+}
+
+type consentScope struct {
+	Scope       string
+	Description string
+}
+
+// handleConsent stores the consent of the user for the client of the auth request
+// or returns an access_denied error to the client if the user denied it.
+func (l *Login) handleConsent(w http.ResponseWriter, r *http.Request) {
+	formData := new(consentFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, formData)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.defaultRedirect(w, r)
+		return
+	}
+	if formData.Deny {
+		l.redirectConsentDenied(w, r, authReq)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	err = l.authRepo.GrantConsent(setContext(r.Context(), authReq.UserOrgID), authReq.ID, userAgentID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
+
+func (l *Login) renderConsent(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, step *domain.ConsentStep, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	translator := l.getTranslator(r.Context(), authReq)
+	data := &consentData{
+		userData: l.getUserData(r, authReq, translator, "Consent.Title", "Consent.Description", errID, errMessage),
+		AppName:  authReq.ApplicationID,
+		Scopes:   consentScopes(translator, step.Scopes),
+	}
+	app, err := l.query.AppByOIDCClientID(r.Context(), authReq.ApplicationID)
+	logging.WithFields("client_id", authReq.ApplicationID).OnError(err).Warn("unable to get app name for consent")
+	if err == nil {
+		data.AppName = app.Name
+	}
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplConsent], data, nil)
+}
+
+// consentScopes returns the requested scopes with a translated description.
+// Reserved ZITADEL scopes (e.g. for roles or the organization) are described by their prefix,
+// unknown scopes are shown as requested.
+func consentScopes(translator *i18n.Translator, scopes []string) []consentScope {
+	consentScopes := make([]consentScope, 0, len(scopes))
+	for _, scope := range scopes {
+		consentScopes = append(consentScopes, consentScope{
+			Scope:       scope,
+			Description: consentScopeDescription(translator, scope),
+		})
+	}
+	return consentScopes
+}
+
+func consentScopeDescription(translator *i18n.Translator, scope string) string {
+	if key, ok := consentScopeKeys[scope]; ok {
+		return translator.LocalizeWithoutArgs(key)
+	}
+	for _, prefix := range consentScopePrefixes {
+		if strings.HasPrefix(scope, prefix.prefix) {
+			return translator.LocalizeWithoutArgs(prefix.key)
+		}
+	}
+	return scope
+}
+
+var (
+	consentScopeKeys = map[string]string{
+		oidc.ScopeOpenID:                     "Consent.Scopes.OpenID",
+		oidc.ScopeProfile:                    "Consent.Scopes.Profile",
+		oidc.ScopeEmail:                      "Consent.Scopes.Email",
+		oidc.ScopePhone:                      "Consent.Scopes.Phone",
+		oidc.ScopeAddress:                    "Consent.Scopes.Address",
+		oidc.ScopeOfflineAccess:              "Consent.Scopes.OfflineAccess",
+		"urn:zitadel:iam:user:metadata":      "Consent.Scopes.Metadata",
+		"urn:zitadel:iam:user:resourceowner": "Consent.Scopes.Organization",
+	}
+	consentScopePrefixes = []struct {
+		prefix string
+		key    string
+	}{
+		{prefix: domain.OrgIDScope, key: "Consent.Scopes.Organization"},
+		{prefix: domain.OrgDomainPrimaryScope, key: "Consent.Scopes.Organization"},
+		{prefix: domain.ProjectIDScope, key: "Consent.Scopes.Project"},
+		{prefix: "urn:zitadel:iam:org:project:role:", key: "Consent.Scopes.Roles"},
+	}
+)
+
+// redirectConsentDenied returns an access_denied error to the client as described in
+// https://openid.net/specs/openid-connect-core-1_0.html#AuthError
+func (l *Login) redirectConsentDenied(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest) {
+	oidcRequest, ok := authReq.Request.(*domain.AuthRequestOIDC)
+	if !ok {
+		l.renderInternalError(w, r, authReq, zerrors.ThrowInternal(nil, "LOGIN-Ohm3e", "Errors.AuthRequest.RequestTypeNotSupported"))
+		return
+	}
+	oidcErr := oidc.ErrAccessDenied().WithDescription("the user denied the consent")
+	oidcErr.State = authReq.TransferState
+	callback, err := op.AuthResponseURL(authReq.CallbackURI, consentResponseTypeToOIDC(oidcRequest.ResponseType), "", oidcErr, schema.NewEncoder())
+	if err != nil {
+		l.renderInternalError(w, r, authReq, err)
+		return
+	}
+	err = l.authRepo.DeleteAuthRequest(r.Context(), authReq.ID)
+	logging.WithFields("auth_req_id", authReq.ID).OnError(err).Warn("unable to delete denied auth request")
+	http.Redirect(w, r, callback, http.StatusFound)
+}
+
+// consentResponseTypeToOIDC maps the response type of the auth request,
+// so the error is returned in the query or fragment as expected by the client.
+func consentResponseTypeToOIDC(responseType domain.OIDCResponseType) oidc.ResponseType {
+	switch responseType {
+	case domain.OIDCResponseTypeIDTokenToken:
+		return oidc.ResponseTypeIDToken
+	case domain.OIDCResponseTypeIDToken:
+		return oidc.ResponseTypeIDTokenOnly
+	default:
+		return oidc.ResponseTypeCode
+	}
+}
@@ -153,6 +153,9 @@ const (
 	authMethodOTPEmail     authMethod = "OTP Email"
 	authMethodU2F          authMethod = "U2F"
 	authMethodPasswordless authMethod = "passwordless"
+	authMethodMagicLink    authMethod = "magic link"
+	authMethodPhoneLogin   authMethod = "phone login"
+	authMethodX509         authMethod = "X.509"
 )
 
 func (l *Login) runPostInternalAuthenticationActions(
@@ -393,6 +393,15 @@ func (l *Login) handleExternalUserAuthenticated(
 	callback func(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest),
 ) {
 	externalUser := mapIDPUserToExternalUser(user, provider.ID)
+	// apply the declarative attribute mapping of the IDP (before any actions are run)
+	attributeMapping, err := l.query.IDPAttributeMappingByIDPID(r.Context(), provider.ID, "")
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	attributeMapping.Apply(externalUser, func(path string) []string {
+		return idp.Attribute(user, path)
+	})
 	// ensure the linked IDP is added to the login policy
 	if err := l.authRepo.SelectExternalIDP(r.Context(), authReq.ID, provider.ID, authReq.AgentID); err != nil {
 		l.renderError(w, r, authReq, err)
@@ -415,7 +424,6 @@ func (l *Login) handleExternalUserAuthenticated(
 			externalErr = nil
 		}
 	}
-	var err error
 	// read current auth request state (incl. authorized user)
 	authReq, err = l.authRepo.AuthRequestByID(r.Context(), authReq.ID, authReq.AgentID)
 	if err != nil {
@@ -429,7 +437,7 @@ func (l *Login) handleExternalUserAuthenticated(
 	}
 	// if action is done and no user linked then link or register
 	if zerrors.IsNotFound(externalErr) {
-		l.externalUserNotExisting(w, r, authReq, provider, externalUser, externalUserChange)
+		l.externalUserNotExisting(w, r, authReq, provider, externalUser, user, session, externalUserChange)
 		return
 	}
 	if provider.IsAutoUpdate || externalUserChange {
@@ -446,6 +454,13 @@ func (l *Login) handleExternalUserAuthenticated(
 			return
 		}
 	}
+	err = l.command.ApplyIDPGroupMapping(setContext(r.Context(), authReq.UserOrgID), provider.ID, authReq.UserID, authReq.UserOrgID, user)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.storeIDPLinkTokens(r.Context(), authReq, authReq.UserOrgID, provider.ID, user.GetID(), session)
+	l.storeIDPLogoutHint(r.Context(), authReq, authReq.UserOrgID, provider.ID, session)
 	callback(w, r, authReq)
 }
 
@@ -456,7 +471,7 @@ func (l *Login) handleExternalUserAuthenticated(
 // * external not found overview:
 //   - creation by user
 //   - linking to existing user
-func (l *Login) externalUserNotExisting(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, provider *query.IDPTemplate, externalUser *domain.ExternalUser, changed bool) {
+func (l *Login) externalUserNotExisting(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, provider *query.IDPTemplate, externalUser *domain.ExternalUser, idpUser idp.User, session idp.Session, changed bool) {
 	resourceOwner := authz.GetInstance(r.Context()).DefaultOrganisationID()
 
 	if authReq.RequestedOrgID != "" && authReq.RequestedOrgID != resourceOwner {
@@ -488,11 +503,11 @@ func (l *Login) externalUserNotExisting(w http.ResponseWriter, r *http.Request,
 			return
 		}
 	}
-	l.autoCreateExternalUser(w, r, authReq)
+	l.autoCreateExternalUser(w, r, authReq, idpUser, session)
 }
 
 // autoCreateExternalUser takes the externalUser and creates it automatically (without user interaction)
-func (l *Login) autoCreateExternalUser(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest) {
+func (l *Login) autoCreateExternalUser(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, idpUser idp.User, session idp.Session) {
 	if len(authReq.LinkingUsers) == 0 {
 		l.renderError(w, r, authReq, zerrors.ThrowPreconditionFailed(nil, "LOGIN-asfg3", "Errors.ExternalIDP.NoExternalUserData"))
 		return
@@ -501,7 +516,7 @@ func (l *Login) autoCreateExternalUser(w http.ResponseWriter, r *http.Request, a
 	// TODO (LS): how do we get multiple and why do we use the last of them (taken as is)?
 	linkingUser := authReq.LinkingUsers[len(authReq.LinkingUsers)-1]
 
-	l.registerExternalUser(w, r, authReq, linkingUser)
+	l.registerExternalUser(w, r, authReq, linkingUser, idpUser, session)
 }
 
 // renderExternalNotFoundOption renders a page, where the user is able to edit the IDP data,
@@ -630,14 +645,17 @@ func (l *Login) handleExternalNotFoundOptionCheck(w http.ResponseWriter, r *http
 		return
 	}
 	linkingUser := mapExternalNotFoundOptionFormDataToLoginUser(data)
-	l.registerExternalUser(w, r, authReq, linkingUser)
+	// the groups and tokens of the IDP user are no longer available, they will be mapped and stored on the next login
+	l.registerExternalUser(w, r, authReq, linkingUser, nil, nil)
 }
 
 // registerExternalUser creates an externalUser with the provided data
 // incl. execution of pre and post creation actions
 //
 // it is called from either the [autoCreateExternalUser] or [handleExternalNotFoundOptionCheck]
-func (l *Login) registerExternalUser(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, externalUser *domain.ExternalUser) {
+// if the idpUser is provided, the group mapping of the IDP is applied to the created user
+// and the tokens of the session are stored on its link (if the token vault of the IDP is enabled)
+func (l *Login) registerExternalUser(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, externalUser *domain.ExternalUser, idpUser idp.User, session idp.Session) {
 	resourceOwner := authz.GetInstance(r.Context()).DefaultOrganisationID()
 
 	if authReq.RequestedOrgID != "" && authReq.RequestedOrgID != resourceOwner {
@@ -677,9 +695,32 @@ func (l *Login) registerExternalUser(w http.ResponseWriter, r *http.Request, aut
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	if idpUser != nil {
+		err = l.command.ApplyIDPGroupMapping(setContext(r.Context(), resourceOwner), externalIDP.IDPConfigID, authReq.UserID, resourceOwner, idpUser)
+		if err != nil {
+			l.renderError(w, r, authReq, err)
+			return
+		}
+		l.storeIDPLinkTokens(r.Context(), authReq, resourceOwner, externalIDP.IDPConfigID, externalIDP.ExternalUserID, session)
+		l.storeIDPLogoutHint(r.Context(), authReq, resourceOwner, externalIDP.IDPConfigID, session)
+	}
 	l.renderNextStep(w, r, authReq)
 }
 
+// storeIDPLinkTokens stores the tokens of the session on the IDP link of the user.
+// The login must not fail because of the token vault, so errors are only logged.
+func (l *Login) storeIDPLinkTokens(ctx context.Context, authReq *domain.AuthRequest, resourceOwner, idpID, externalUserID string, session idp.Session) {
+	err := l.command.StoreUserIDPLinkTokens(setContext(ctx, resourceOwner), authReq.UserID, resourceOwner, idpID, externalUserID, session)
+	logging.WithFields("authReq", authReq.ID, "user", authReq.UserID).OnError(err).Error("unable to store idp tokens")
+}
+
+// storeIDPLogoutHint stores the id_token / NameID of the session for the user agent,
+// so that the session at the IDP can be terminated, when the user logs out.
+func (l *Login) storeIDPLogoutHint(ctx context.Context, authReq *domain.AuthRequest, resourceOwner, idpID string, session idp.Session) {
+	err := l.command.StoreUserIDPLogoutHint(setContext(ctx, resourceOwner), authReq.UserID, resourceOwner, idpID, authReq.AgentID, session)
+	logging.WithFields("authReq", authReq.ID, "user", authReq.UserID).OnError(err).Error("unable to store idp logout hint")
+}
+
 // updateExternalUser will update the existing user (email, phone, profile) with data provided by the IDP
 func (l *Login) updateExternalUser(ctx context.Context, authReq *domain.AuthRequest, externalUser *domain.ExternalUser) error {
 	user, err := l.query.GetUserByID(ctx, true, authReq.UserID)
@@ -870,6 +911,20 @@ func (l *Login) ldapProvider(ctx context.Context, identityProvider *query.IDPTem
 	if identityProvider.LDAPIDPTemplate.LDAPAttributes.ProfileAttribute != "" {
 		opts = append(opts, ldap.WithProfileAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.ProfileAttribute))
 	}
+	groupMapping, err := l.query.IDPGroupMappingByIDPID(ctx, identityProvider.ID, "")
+	if err != nil {
+		return nil, err
+	}
+	if groupMapping.GroupsAttribute != "" {
+		opts = append(opts, ldap.WithAdditionalAttributes(groupMapping.GroupsAttribute))
+	}
+	attributeMapping, err := l.query.IDPAttributeMappingByIDPID(ctx, identityProvider.ID, "")
+	if err != nil {
+		return nil, err
+	}
+	if paths := attributeMapping.Paths(); len(paths) > 0 {
+		opts = append(opts, ldap.WithAdditionalAttributes(paths...))
+	}
 	return ldap.New(
 		identityProvider.Name,
 		identityProvider.Servers,
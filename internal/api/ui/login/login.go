@@ -39,6 +39,8 @@ type Login struct {
 	idpConfigAlg        crypto.EncryptionAlgorithm
 	userCodeAlg         crypto.EncryptionAlgorithm
 	featureCheck        feature.Checker
+
+	x509ClientCertificateHeader string
 }
 
 type Config struct {
@@ -47,8 +49,13 @@ type Config struct {
 	Cache              middleware.CacheConfig
 	AssetCache         middleware.CacheConfig
 
+	// X509ClientCertificateHeader is the header in which the TLS terminating proxy forwards the verified client certificate.
+	// Authentication with X.509 client certificates is not offered if empty.
+	X509ClientCertificateHeader string
+
 	// LoginV2
-	DefaultOTPEmailURLV2 string
+	DefaultOTPEmailURLV2  string
+	DefaultMagicLinkURLV2 string
 }
 
 const (
@@ -89,6 +96,8 @@ func CreateLogin(config Config,
 		idpConfigAlg:        idpConfigAlg,
 		userCodeAlg:         userCodeAlg,
 		featureCheck:        featureCheck,
+
+		x509ClientCertificateHeader: config.X509ClientCertificateHeader,
 	}
 	csrfInterceptor := createCSRFInterceptor(config.CSRFCookieName, csrfCookieKey, externalSecure, login.csrfErrorHandler())
 	cacheInterceptor := createCacheInterceptor(config.Cache.MaxAge, config.Cache.SharedMaxAge, assetCache)
@@ -0,0 +1,81 @@
+package login
+
+import (
+	"fmt"
+	"net/http"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	tmplMagicLinkSent = "magic_link_sent"
+)
+
+type magicLinkFormData struct {
+	Resend bool `schema:"resend"`
+}
+
+func MagicLinkLink(origin, authRequestID, userID, code string) string {
+	return fmt.Sprintf("%s%s?%s=%s&%s=%s&%s=%s", externalLink(origin), EndpointMagicLinkVerify, QueryAuthRequestID, authRequestID, queryUserID, userID, queryCode, code)
+}
+
+// handleMagicLink sends a sign-in link to the verified email address of the user of the auth request.
+func (l *Login) handleMagicLink(w http.ResponseWriter, r *http.Request) {
+	formData := new(magicLinkFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, formData)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.defaultRedirect(w, r)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	err = l.authRepo.SendMagicLink(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, userAgentID, domain.BrowserInfoFromRequest(r))
+	if err != nil {
+		l.renderPassword(w, r, authReq, err)
+		return
+	}
+	l.renderMagicLinkSent(w, r, authReq, nil)
+}
+
+func (l *Login) renderMagicLinkSent(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	translator := l.getTranslator(r.Context(), authReq)
+	data := l.getUserData(r, authReq, translator, "MagicLink.Title", "MagicLink.Description", errID, errMessage)
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMagicLinkSent], data, nil)
+}
+
+// handleMagicLinkVerify handles the link of the email sent by [handleMagicLink].
+// The auth request and therefore the link can only be used from the user agent it was requested from.
+func (l *Login) handleMagicLinkVerify(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.getAuthRequest(r)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.defaultRedirect(w, r)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	err = l.authRepo.VerifyMagicLink(setContext(r.Context(), authReq.UserOrgID), r.FormValue(queryUserID), authReq.UserOrgID, r.FormValue(queryCode), authReq.ID, userAgentID, domain.BrowserInfoFromRequest(r))
+
+	metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodMagicLink, err)
+	if err == nil && actionErr == nil && len(metadata) > 0 {
+		_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
+	} else if actionErr != nil && err == nil {
+		err = actionErr
+	}
+
+	if err != nil {
+		l.renderMagicLinkSent(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
@@ -0,0 +1,73 @@
+package login
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	tmplMailChangeRevert = "mail_change_revert"
+)
+
+type mailChangeRevertFormData struct {
+	Code   string `schema:"code"`
+	UserID string `schema:"userID"`
+	OrgID  string `schema:"orgID"`
+}
+
+type mailChangeRevertData struct {
+	baseData
+	UserID   string
+	Code     string
+	Reverted bool
+}
+
+// MailChangeRevertLink returns the link sent to the previous email address of the user,
+// which allows to revert the change of the email address.
+func MailChangeRevertLink(origin, userID, code, orgID string) string {
+	return fmt.Sprintf("%s%s?userID=%s&code=%s&orgID=%s", externalLink(origin), EndpointMailChangeRevert, userID, code, orgID)
+}
+
+// handleMailChangeRevert only renders the confirmation page,
+// so that links opened by (automated) mail scanners don't revert the change.
+func (l *Login) handleMailChangeRevert(w http.ResponseWriter, r *http.Request) {
+	data := &mailChangeRevertFormData{
+		Code:   r.FormValue(queryCode),
+		UserID: r.FormValue(queryUserID),
+		OrgID:  r.FormValue(queryOrgID),
+	}
+	l.renderMailChangeRevert(w, r, data, false, nil)
+}
+
+func (l *Login) handleMailChangeRevertCheck(w http.ResponseWriter, r *http.Request) {
+	data := new(mailChangeRevertFormData)
+	err := l.getParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	_, err = l.command.RevertHumanEmailChange(setContext(r.Context(), data.OrgID), data.UserID, data.OrgID, data.Code)
+	l.renderMailChangeRevert(w, r, data, err == nil, err)
+}
+
+func (l *Login) renderMailChangeRevert(w http.ResponseWriter, r *http.Request, formData *mailChangeRevertFormData, reverted bool, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	translator := l.getTranslator(r.Context(), nil)
+	titleKey, descriptionKey := "EmailChangeRevert.Title", "EmailChangeRevert.Description"
+	if reverted {
+		titleKey, descriptionKey = "EmailChangeRevertDone.Title", "EmailChangeRevertDone.Description"
+	}
+	data := mailChangeRevertData{
+		baseData: l.getBaseData(r, nil, translator, titleKey, descriptionKey, errID, errMessage),
+		UserID:   formData.UserID,
+		Code:     formData.Code,
+		Reverted: reverted,
+	}
+	if formData.OrgID != "" {
+		l.customTexts(r.Context(), translator, formData.OrgID)
+	}
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMailChangeRevert], data, nil)
+}
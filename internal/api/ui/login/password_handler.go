@@ -28,6 +28,15 @@ func (l *Login) renderPassword(w http.ResponseWriter, r *http.Request, authReq *
 			}
 			return true
 		},
+		"showMagicLink": func() bool {
+			return authReq.LoginPolicy != nil && authReq.LoginPolicy.AllowMagicLink
+		},
+		"showPhoneLogin": func() bool {
+			return authReq.LoginPolicy != nil && authReq.LoginPolicy.AllowPhoneOTPLogin
+		},
+		"showX509": func() bool {
+			return l.x509ClientCertificate(r) != ""
+		},
 	}
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplPassword], data, funcs)
 }
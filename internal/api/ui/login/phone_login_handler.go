@@ -0,0 +1,82 @@
+package login
+
+import (
+	"net/http"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	tmplPhoneLogin = "phone_login"
+)
+
+type phoneLoginFormData struct {
+	Channel string `schema:"channel"`
+	Code    string `schema:"code"`
+}
+
+type phoneLoginData struct {
+	userData
+	CodeSent bool
+}
+
+// handlePhoneLogin sends a one time code to the phone of the user of the auth request over the requested channel
+// or checks the code entered by the user.
+// The code can only be checked from the user agent it was requested from.
+func (l *Login) handlePhoneLogin(w http.ResponseWriter, r *http.Request) {
+	formData := new(phoneLoginFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, formData)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.defaultRedirect(w, r)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	if formData.Channel != "" {
+		err = l.authRepo.SendPhoneLoginCode(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, userAgentID, phoneChannelFromForm(formData.Channel), domain.BrowserInfoFromRequest(r))
+		l.renderPhoneLogin(w, r, authReq, err == nil, err)
+		return
+	}
+	if formData.Code == "" {
+		l.renderPhoneLogin(w, r, authReq, false, nil)
+		return
+	}
+	err = l.authRepo.VerifyPhoneLoginCode(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, formData.Code, authReq.ID, userAgentID, domain.BrowserInfoFromRequest(r))
+
+	metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodPhoneLogin, err)
+	if err == nil && actionErr == nil && len(metadata) > 0 {
+		_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
+	} else if actionErr != nil && err == nil {
+		err = actionErr
+	}
+
+	if err != nil {
+		l.renderPhoneLogin(w, r, authReq, true, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
+
+func (l *Login) renderPhoneLogin(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, codeSent bool, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	translator := l.getTranslator(r.Context(), authReq)
+	data := &phoneLoginData{
+		userData: l.getUserData(r, authReq, translator, "PhoneLogin.Title", "PhoneLogin.Description", errID, errMessage),
+		CodeSent: codeSent,
+	}
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplPhoneLogin], data, nil)
+}
+
+func phoneChannelFromForm(channel string) domain.PhoneChannel {
+	if channel == domain.PhoneChannelWhatsApp.String() {
+		return domain.PhoneChannelWhatsApp
+	}
+	return domain.PhoneChannelSMS
+}
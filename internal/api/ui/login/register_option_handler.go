@@ -16,6 +16,7 @@ const (
 
 type registerOptionFormData struct {
 	UsernamePassword bool `schema:"usernamepassword"`
+	Phone            bool `schema:"phone"`
 }
 
 type registerOptionData struct {
@@ -39,7 +40,8 @@ func (l *Login) renderRegisterOption(w http.ResponseWriter, r *http.Request, aut
 	}
 	allowed := registrationAllowed(authReq)
 	externalAllowed := externalRegistrationAllowed(authReq)
-	if err == nil {
+	phoneAllowed := phoneRegistrationAllowed(authReq)
+	if err == nil && !phoneAllowed {
 		// if only external allowed with a single idp then use that
 		if !allowed && externalAllowed && len(authReq.AllowedExternalIDPs) == 1 {
 			l.handleIDP(w, r, authReq, authReq.AllowedExternalIDPs[0].IDPConfigID)
@@ -63,6 +65,9 @@ func (l *Login) renderRegisterOption(w http.ResponseWriter, r *http.Request, aut
 		"hasExternalLogin": func() bool {
 			return externalAllowed
 		},
+		"hasPhoneRegistration": func() bool {
+			return phoneAllowed
+		},
 	}
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplRegisterOption], data, funcs)
 }
@@ -78,6 +83,10 @@ func (l *Login) handleRegisterOptionCheck(w http.ResponseWriter, r *http.Request
 		l.handleRegister(w, r)
 		return
 	}
+	if data.Phone {
+		l.handleRegisterPhone(w, r)
+		return
+	}
 	l.handleRegisterOption(w, r)
 }
 
@@ -85,6 +94,10 @@ func registrationAllowed(authReq *domain.AuthRequest) bool {
 	return authReq != nil && authReq.LoginPolicy != nil && authReq.LoginPolicy.AllowRegister && authReq.LoginPolicy.AllowUsernamePassword
 }
 
+func phoneRegistrationAllowed(authReq *domain.AuthRequest) bool {
+	return authReq != nil && authReq.LoginPolicy != nil && authReq.LoginPolicy.AllowRegister && authReq.LoginPolicy.AllowPhoneOTPLogin
+}
+
 func externalRegistrationAllowed(authReq *domain.AuthRequest) bool {
 	return authReq != nil && authReq.LoginPolicy != nil && authReq.LoginPolicy.AllowExternalIDP && authReq.AllowedExternalIDPs != nil && len(authReq.AllowedExternalIDPs) > 0
 }
@@ -0,0 +1,129 @@
+package login
+
+import (
+	"net/http"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	tmplRegisterPhone = "registerphone"
+)
+
+type registerPhoneFormData struct {
+	Phone     string `schema:"phone"`
+	Firstname string `schema:"firstname"`
+	Lastname  string `schema:"lastname"`
+	Language  string `schema:"language"`
+}
+
+type registerPhoneData struct {
+	baseData
+	registerPhoneFormData
+}
+
+func (l *Login) handleRegisterPhone(w http.ResponseWriter, r *http.Request) {
+	data := new(registerPhoneFormData)
+	authRequest, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authRequest, err)
+		return
+	}
+	l.renderRegisterPhone(w, r, authRequest, data, nil)
+}
+
+// handleRegisterPhoneCheck registers a user with a phone number only (phone-first sign-up).
+// The user is then directly asked to sign in with a one time code sent to the phone, which also verifies the number.
+func (l *Login) handleRegisterPhoneCheck(w http.ResponseWriter, r *http.Request) {
+	data := new(registerPhoneFormData)
+	authRequest, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authRequest, err)
+		return
+	}
+	// the phone login is bound to the auth request
+	if authRequest == nil {
+		l.renderRegisterPhone(w, r, authRequest, data, zerrors.ThrowInvalidArgument(nil, "LOGIN-ii4Ch", "Errors.AuthRequest.NotFound"))
+		return
+	}
+	resourceOwner := authz.GetInstance(r.Context()).DefaultOrganisationID()
+	if authRequest.RequestedOrgID != "" && authRequest.RequestedOrgID != resourceOwner {
+		resourceOwner = authRequest.RequestedOrgID
+	}
+
+	user, metadatas, err := l.runPreCreationActions(authRequest, r, data.toHumanDomain(), make([]*domain.Metadata, 0), resourceOwner, domain.FlowTypeInternalAuthentication)
+	if err != nil {
+		l.renderRegisterPhone(w, r, authRequest, data, err)
+		return
+	}
+	user, err = l.command.RegisterHumanWithPhone(setContext(r.Context(), resourceOwner), resourceOwner, user)
+	if err != nil {
+		l.renderRegisterPhone(w, r, authRequest, data, err)
+		return
+	}
+
+	if len(metadatas) > 0 {
+		_, err = l.command.BulkSetUserMetadata(r.Context(), user.AggregateID, resourceOwner, metadatas...)
+		if err != nil {
+			l.renderRegisterPhone(w, r, authRequest, data, err)
+			return
+		}
+	}
+
+	userGrants, err := l.runPostCreationActions(user.AggregateID, authRequest, r, resourceOwner, domain.FlowTypeInternalAuthentication)
+	if err != nil {
+		l.renderError(w, r, authRequest, err)
+		return
+	}
+
+	err = l.appendUserGrants(r.Context(), userGrants, resourceOwner)
+	if err != nil {
+		l.renderError(w, r, authRequest, err)
+		return
+	}
+
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	err = l.authRepo.SelectUser(r.Context(), authRequest.ID, user.AggregateID, userAgentID)
+	if err != nil {
+		l.renderRegisterPhone(w, r, authRequest, data, err)
+		return
+	}
+	l.renderNextStep(w, r, authRequest)
+}
+
+func (l *Login) renderRegisterPhone(w http.ResponseWriter, r *http.Request, authRequest *domain.AuthRequest, formData *registerPhoneFormData, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	translator := l.getTranslator(r.Context(), authRequest)
+	if formData == nil {
+		formData = new(registerPhoneFormData)
+	}
+	if formData.Language == "" {
+		formData.Language = l.renderer.ReqLang(translator, r).String()
+	}
+	data := registerPhoneData{
+		baseData:              l.getBaseData(r, authRequest, translator, "RegistrationPhone.Title", "RegistrationPhone.Description", errID, errMessage),
+		registerPhoneFormData: *formData,
+	}
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplRegisterPhone], data, nil)
+}
+
+func (d registerPhoneFormData) toHumanDomain() *domain.Human {
+	return &domain.Human{
+		Profile: &domain.Profile{
+			FirstName:         d.Firstname,
+			LastName:          d.Lastname,
+			PreferredLanguage: language.Make(d.Language),
+		},
+		Phone: &domain.Phone{
+			PhoneNumber: domain.PhoneNumber(d.Phone),
+		},
+	}
+}
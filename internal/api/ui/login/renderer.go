@@ -50,6 +50,8 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		tmplLogin:                        "login.html",
 		tmplUserSelection:                "select_user.html",
 		tmplPassword:                     "password.html",
+		tmplMagicLinkSent:                "magic_link_sent.html",
+		tmplPhoneLogin:                   "phone_login.html",
 		tmplPasswordlessVerification:     "passwordless.html",
 		tmplPasswordlessRegistration:     "passwordless_registration.html",
 		tmplPasswordlessRegistrationDone: "passwordless_registration_done.html",
@@ -64,6 +66,7 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		tmplMFAInitDone:                  "mfa_init_done.html",
 		tmplMailVerification:             "mail_verification.html",
 		tmplMailVerified:                 "mail_verified.html",
+		tmplMailChangeRevert:             "mail_change_revert.html",
 		tmplInitPassword:                 "init_password.html",
 		tmplInitPasswordDone:             "init_password_done.html",
 		tmplInitUser:                     "init_user.html",
@@ -73,11 +76,13 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		tmplChangePasswordDone:           "change_password_done.html",
 		tmplRegisterOption:               "register_option.html",
 		tmplRegister:                     "register.html",
+		tmplRegisterPhone:                "register_phone.html",
 		tmplLogoutDone:                   "logout_done.html",
 		tmplRegisterOrg:                  "register_org.html",
 		tmplChangeUsername:               "change_username.html",
 		tmplChangeUsernameDone:           "change_username_done.html",
 		tmplLinkUsersDone:                "link_users_done.html",
+		tmplConsent:                      "consent.html",
 		tmplExternalNotFoundOption:       "external_not_found_option.html",
 		tmplLoginSuccess:                 "login_success.html",
 		tmplLDAPLogin:                    "ldap_login.html",
@@ -160,6 +165,24 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		"passwordUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointPassword)
 		},
+		"magicLinkUrl": func(id string) string {
+			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s", EndpointMagicLink, QueryAuthRequestID, id))
+		},
+		"magicLinkSendUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointMagicLink)
+		},
+		"consentUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointConsent)
+		},
+		"phoneLoginUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointPhoneLogin)
+		},
+		"phoneLoginSendUrl": func(id, channel string) string {
+			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s&channel=%s", EndpointPhoneLogin, QueryAuthRequestID, id, channel))
+		},
+		"x509Url": func(id string) string {
+			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s", EndpointX509, QueryAuthRequestID, id))
+		},
 		"mfaVerifyUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointMFAVerify)
 		},
@@ -187,6 +210,9 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		"mailVerificationUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointMailVerification)
 		},
+		"mailChangeRevertUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointMailChangeRevert)
+		},
 		"initPasswordUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointInitPassword)
 		},
@@ -202,6 +228,9 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		"registrationUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointRegister)
 		},
+		"phoneRegistrationUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointRegisterPhone)
+		},
 		"orgRegistrationUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointRegisterOrg)
 		},
@@ -292,6 +321,8 @@ func (l *Login) chooseNextStep(w http.ResponseWriter, r *http.Request, authReq *
 		l.renderInitPassword(w, r, authReq, authReq.UserID, "", err)
 	case *domain.PasswordStep:
 		l.renderPassword(w, r, authReq, nil)
+	case *domain.PhoneLoginStep:
+		l.renderPhoneLogin(w, r, authReq, false, err)
 	case *domain.PasswordlessStep:
 		l.renderPasswordlessVerification(w, r, authReq, step.PasswordSet, nil)
 	case *domain.PasswordlessRegistrationPromptStep:
@@ -322,6 +353,8 @@ func (l *Login) chooseNextStep(w http.ResponseWriter, r *http.Request, authReq *
 		l.renderExternalNotFoundOption(w, r, authReq, nil, nil, nil, err)
 	case *domain.ExternalLoginStep:
 		l.handleExternalLoginStep(w, r, authReq, step.SelectedIDPConfigID)
+	case *domain.ConsentStep:
+		l.renderConsent(w, r, authReq, step, err)
 	case *domain.GrantRequiredStep:
 		l.renderInternalError(w, r, authReq, zerrors.ThrowPreconditionFailed(nil, "APP-asb43", "Errors.User.GrantRequired"))
 	case *domain.ProjectRequiredStep:
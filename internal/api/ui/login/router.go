@@ -29,6 +29,11 @@ const (
 	EndpointInitPassword                  = "/password/init"
 	EndpointChangePassword                = "/password/change"
 	EndpointPasswordReset                 = "/password/reset"
+	EndpointMagicLink                     = "/magiclink"
+	EndpointMagicLinkVerify               = "/magiclink/verify"
+	EndpointPhoneLogin                    = "/phonelogin"
+	EndpointX509                          = "/x509"
+	EndpointConsent                       = "/consent"
 	EndpointInitUser                      = "/user/init"
 	EndpointMFAVerify                     = "/mfa/verify"
 	EndpointMFAPrompt                     = "/mfa/prompt"
@@ -39,8 +44,10 @@ const (
 	EndpointU2FVerification               = "/mfa/u2f/verify"
 	EndpointMailVerification              = "/mail/verification"
 	EndpointMailVerified                  = "/mail/verified"
+	EndpointMailChangeRevert              = "/mail/revert"
 	EndpointRegisterOption                = "/register/option"
 	EndpointRegister                      = "/register"
+	EndpointRegisterPhone                 = "/register/phone"
 	EndpointExternalRegister              = "/register/externalidp"
 	EndpointExternalRegisterCallback      = "/register/externalidp/callback"
 	EndpointRegisterOrg                   = "/register/org"
@@ -90,6 +97,11 @@ func CreateRouter(login *Login, interceptors ...mux.MiddlewareFunc) *mux.Router
 	router.HandleFunc(EndpointInitPassword, login.handleInitPassword).Methods(http.MethodGet)
 	router.HandleFunc(EndpointInitPassword, login.handleInitPasswordCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointPasswordReset, login.handlePasswordReset).Methods(http.MethodGet)
+	router.HandleFunc(EndpointMagicLink, login.handleMagicLink).Methods(http.MethodGet, http.MethodPost)
+	router.HandleFunc(EndpointMagicLinkVerify, login.handleMagicLinkVerify).Methods(http.MethodGet)
+	router.HandleFunc(EndpointPhoneLogin, login.handlePhoneLogin).Methods(http.MethodGet, http.MethodPost)
+	router.HandleFunc(EndpointX509, login.handleX509).Methods(http.MethodGet)
+	router.HandleFunc(EndpointConsent, login.handleConsent).Methods(http.MethodPost)
 	router.HandleFunc(EndpointInitUser, login.handleInitUser).Methods(http.MethodGet)
 	router.HandleFunc(EndpointInitUser, login.handleInitUserCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointMFAVerify, login.handleMFAVerify).Methods(http.MethodPost)
@@ -103,12 +115,16 @@ func CreateRouter(login *Login, interceptors ...mux.MiddlewareFunc) *mux.Router
 	router.HandleFunc(EndpointU2FVerification, login.handleU2FVerification).Methods(http.MethodPost)
 	router.HandleFunc(EndpointMailVerification, login.handleMailVerification).Methods(http.MethodGet)
 	router.HandleFunc(EndpointMailVerification, login.handleMailVerificationCheck).Methods(http.MethodPost)
+	router.HandleFunc(EndpointMailChangeRevert, login.handleMailChangeRevert).Methods(http.MethodGet)
+	router.HandleFunc(EndpointMailChangeRevert, login.handleMailChangeRevertCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointChangePassword, login.handleChangePassword).Methods(http.MethodPost)
 	router.HandleFunc(EndpointRegisterOption, login.handleRegisterOption).Methods(http.MethodGet)
 	router.HandleFunc(EndpointRegisterOption, login.handleRegisterOptionCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointExternalNotFoundOption, login.handleExternalNotFoundOptionCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointRegister, login.handleRegister).Methods(http.MethodGet)
 	router.HandleFunc(EndpointRegister, login.handleRegisterCheck).Methods(http.MethodPost)
+	router.HandleFunc(EndpointRegisterPhone, login.handleRegisterPhone).Methods(http.MethodGet)
+	router.HandleFunc(EndpointRegisterPhone, login.handleRegisterPhoneCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointExternalRegister, login.handleExternalRegister).Methods(http.MethodGet)
 	router.HandleFunc(EndpointExternalRegisterCallback, login.handleExternalLoginCallback).Methods(http.MethodGet)
 	router.HandleFunc(EndpointLogoutDone, login.handleLogoutDone).Methods(http.MethodGet)
@@ -0,0 +1,49 @@
+package login
+
+import (
+	"net/http"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// handleX509 authenticates the user of the auth request with the client certificate,
+// which the TLS terminating proxy verified and forwarded in the configured header.
+func (l *Login) handleX509(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.getAuthRequest(r)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.defaultRedirect(w, r)
+		return
+	}
+	certificates, err := domain.ParseX509ClientCertificates(l.x509ClientCertificate(r))
+	if err == nil {
+		userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+		err = l.authRepo.VerifyX509(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, userAgentID, certificates, domain.BrowserInfoFromRequest(r))
+	}
+
+	metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodX509, err)
+	if err == nil && actionErr == nil && len(metadata) > 0 {
+		_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
+	} else if actionErr != nil && err == nil {
+		err = actionErr
+	}
+
+	if err != nil {
+		l.renderPassword(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
+
+// x509ClientCertificate returns the client certificate forwarded by the proxy,
+// which is empty if no header is configured or the user agent did not present any certificate.
+func (l *Login) x509ClientCertificate(r *http.Request) string {
+	if l.x509ClientCertificateHeader == "" {
+		return ""
+	}
+	return r.Header.Get(l.x509ClientCertificateHeader)
+}
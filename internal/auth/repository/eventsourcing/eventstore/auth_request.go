@@ -2,6 +2,8 @@ package eventstore
 
 import (
 	"context"
+	"crypto/x509"
+	"slices"
 	"strings"
 	"time"
 
@@ -49,6 +51,7 @@ type AuthRequestRepo struct {
 	ProjectProvider           projectProvider
 	ApplicationProvider       applicationProvider
 	CustomTextProvider        customTextProvider
+	UserConsentProvider       userConsentProvider
 
 	FeatureCheck feature.Checker
 
@@ -120,6 +123,10 @@ type customTextProvider interface {
 	CustomTextListByTemplate(ctx context.Context, aggregateID string, text string, withOwnerRemoved bool) (texts *query.CustomTexts, err error)
 }
 
+type userConsentProvider interface {
+	UserConsentByClientID(ctx context.Context, shouldTriggerBulk bool, userID, clientID string) (*query.UserConsent, error)
+}
+
 func (repo *AuthRequestRepo) Health(ctx context.Context) error {
 	return repo.AuthRequests.Health(ctx)
 }
@@ -434,6 +441,58 @@ func (repo *AuthRequestRepo) VerifyMFAOTPEmail(ctx context.Context, userID, reso
 	return repo.Command.HumanCheckOTPEmail(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
 }
 
+func (repo *AuthRequestRepo) SendMagicLink(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanRequestMagicLink(ctx, userID, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) VerifyMagicLink(ctx context.Context, userID, resourceOwner, code, authRequestID, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckMagicLink(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) SendPhoneLoginCode(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, channel domain.PhoneChannel, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanRequestPhoneLogin(ctx, userID, resourceOwner, channel, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) VerifyPhoneLoginCode(ctx context.Context, userID, resourceOwner, code, authRequestID, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckPhoneLogin(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) VerifyX509(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, certificates []*x509.Certificate, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckX509(ctx, userID, resourceOwner, certificates, request.WithCurrentInfo(info))
+}
+
 func (repo *AuthRequestRepo) BeginMFAU2FLogin(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (login *domain.WebAuthNLogin, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -528,6 +587,25 @@ func (repo *AuthRequestRepo) LinkExternalUsers(ctx context.Context, authReqID, u
 	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
 }
 
+func (repo *AuthRequestRepo) GrantConsent(ctx context.Context, authReqID, userAgentID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequest(ctx, authReqID, userAgentID)
+	if err != nil {
+		return err
+	}
+	oidcRequest, ok := request.Request.(*domain.AuthRequestOIDC)
+	if !ok {
+		return zerrors.ThrowPreconditionFailed(nil, "EVENT-Ahb2e", "Errors.AuthRequest.RequestTypeNotSupported")
+	}
+	_, err = repo.Command.GrantUserConsent(ctx, request.UserID, request.UserOrgID, request.ApplicationID, oidcRequest.Scopes)
+	if err != nil {
+		return err
+	}
+	request.ConsentGranted = true
+	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
+}
+
 func (repo *AuthRequestRepo) ResetLinkingUsers(ctx context.Context, authReqID, userAgentID string) error {
 	request, err := repo.getAuthRequest(ctx, authReqID, userAgentID)
 	if err != nil {
@@ -931,6 +1009,8 @@ func queryLoginPolicyToDomain(policy *query.LoginPolicy) *domain.LoginPolicy {
 		MultiFactorCheckLifetime:   policy.MultiFactorCheckLifetime,
 		DisableLoginWithEmail:      policy.DisableLoginWithEmail,
 		DisableLoginWithPhone:      policy.DisableLoginWithPhone,
+		AllowMagicLink:             policy.AllowMagicLink,
+		AllowPhoneOTPLogin:         policy.AllowPhoneOTPLogin,
 	}
 }
 
@@ -1047,21 +1127,22 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 	if user.PasswordChangeRequired {
 		steps = append(steps, &domain.ChangePasswordStep{})
 	}
-	if !user.IsEmailVerified {
+	// users signed up with only a phone number (phone-first sign-up) have no email address to verify
+	emailVerificationRequired := !user.IsEmailVerified && user.Email != ""
+	if emailVerificationRequired {
 		steps = append(steps, &domain.VerifyEMailStep{})
 	}
 	if user.UsernameChangeRequired {
 		steps = append(steps, &domain.ChangeUsernameStep{})
 	}
 
-	if user.PasswordChangeRequired || !user.IsEmailVerified || user.UsernameChangeRequired {
+	if user.PasswordChangeRequired || emailVerificationRequired || user.UsernameChangeRequired {
 		return steps, nil
 	}
 
 	if request.LinkingUsers != nil && len(request.LinkingUsers) != 0 {
 		return append(steps, &domain.LinkUsersStep{}), nil
 	}
-	//PLANNED: consent step
 
 	missing, err := projectRequired(ctx, request, repo.ProjectProvider)
 	if err != nil {
@@ -1079,6 +1160,14 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		return append(steps, &domain.GrantRequiredStep{}), nil
 	}
 
+	scopes, required, err := consentRequired(ctx, request, repo.ApplicationProvider, repo.UserConsentProvider)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		return append(steps, &domain.ConsentStep{Scopes: scopes}), nil
+	}
+
 	ok, err = repo.hasSucceededPage(ctx, request, repo.ApplicationProvider)
 	if err != nil {
 		return nil, err
@@ -1190,6 +1279,30 @@ func (repo *AuthRequestRepo) firstFactorChecked(request *domain.AuthRequest, use
 		}
 	}
 
+	if request.LoginPolicy.AllowMagicLink &&
+		checkVerificationTimeMaxAge(userSession.MagicLinkVerification, request.LoginPolicy.PasswordCheckLifetime, request) {
+		request.MagicLinkVerified = true
+		request.AuthTime = userSession.MagicLinkVerification
+		return nil
+	}
+
+	if request.LoginPolicy.AllowPhoneOTPLogin &&
+		checkVerificationTimeMaxAge(userSession.PhoneLoginVerification, request.LoginPolicy.PasswordCheckLifetime, request) {
+		request.PhoneLoginVerified = true
+		request.AuthTime = userSession.PhoneLoginVerification
+		return nil
+	}
+
+	if checkVerificationTimeMaxAge(userSession.X509Verification, request.LoginPolicy.PasswordCheckLifetime, request) {
+		request.X509Verified = true
+		request.AuthTime = userSession.X509Verification
+		return nil
+	}
+
+	if request.LoginPolicy.AllowPhoneOTPLogin && !user.PasswordSet && user.Email == "" {
+		return &domain.PhoneLoginStep{}
+	}
+
 	if user.PasswordlessInitRequired {
 		return &domain.PasswordlessRegistrationPromptStep{}
 	}
@@ -1211,10 +1324,24 @@ func (repo *AuthRequestRepo) firstFactorChecked(request *domain.AuthRequest, use
 
 func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView, request *domain.AuthRequest, user *user_model.UserView, isInternalAuthentication bool) (domain.NextStep, bool, error) {
 	mfaLevel := request.MFALevel()
+	// a step-up requested by the client is already satisfied by a passwordless (passkey) authentication
+	if mfaLevel >= domain.MFALevelSecondFactor &&
+		checkVerificationTimeMaxAge(userSession.MultiFactorVerification, request.LoginPolicy.MultiFactorCheckLifetime, request) {
+		request.MFAsVerified = append(request.MFAsVerified, userSession.MultiFactorVerificationType)
+		request.AuthTime = userSession.MultiFactorVerification
+		return nil, true, nil
+	}
+	phishingResistant := request.RequestedLevelOfAssurance() == domain.LevelOfAssurancePhishingResistant
 	allowedProviders, required := user.MFATypesAllowed(mfaLevel, request.LoginPolicy, isInternalAuthentication)
+	if phishingResistant {
+		allowedProviders = domain.PhishingResistantMFATypes(allowedProviders)
+	}
 	promptRequired := (user.MFAMaxSetUp < mfaLevel) || (len(allowedProviders) == 0 && required)
 	if promptRequired || !repo.mfaSkippedOrSetUp(user, request) {
 		types := user.MFATypesSetupPossible(mfaLevel, request.LoginPolicy)
+		if phishingResistant {
+			types = domain.PhishingResistantMFATypes(types)
+		}
 		if promptRequired && len(types) == 0 {
 			return nil, false, zerrors.ThrowPreconditionFailed(nil, "LOGIN-5Hm8s", "Errors.Login.LoginPolicy.MFA.ForceAndNotConfigured")
 		}
@@ -1235,7 +1362,8 @@ func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView,
 		}
 		fallthrough
 	case domain.MFALevelSecondFactor:
-		if checkVerificationTimeMaxAge(userSession.SecondFactorVerification, request.LoginPolicy.SecondFactorCheckLifetime, request) {
+		if (!phishingResistant || userSession.SecondFactorVerificationType.IsPhishingResistant()) &&
+			checkVerificationTimeMaxAge(userSession.SecondFactorVerification, request.LoginPolicy.SecondFactorCheckLifetime, request) {
 			request.MFAsVerified = append(request.MFAsVerified, userSession.SecondFactorVerificationType)
 			request.AuthTime = userSession.SecondFactorVerification
 			return nil, true, nil
@@ -1638,6 +1766,39 @@ func userGrantRequired(ctx context.Context, request *domain.AuthRequest, user *u
 	return len(grants) == 0, nil
 }
 
+// consentRequired checks if the user has to consent to the client accessing the requested scopes.
+// This is the case if the application requires a consent and the user did not yet consent to all of the scopes
+// or if the client explicitly asks for it (prompt=consent).
+func consentRequired(ctx context.Context, request *domain.AuthRequest, applicationProvider applicationProvider, userConsentProvider userConsentProvider) (_ []string, _ bool, err error) {
+	oidcRequest, ok := request.Request.(*domain.AuthRequestOIDC)
+	if !ok || request.ConsentGranted {
+		return nil, false, nil
+	}
+	if domain.IsPrompt(request.Prompt, domain.PromptConsent) {
+		return oidcRequest.Scopes, true, nil
+	}
+	app, err := applicationProvider.AppByOIDCClientID(ctx, request.ApplicationID)
+	if err != nil {
+		return nil, false, err
+	}
+	if !app.OIDCConfig.ConsentRequired {
+		return nil, false, nil
+	}
+	consent, err := userConsentProvider.UserConsentByClientID(ctx, false, request.UserID, request.ApplicationID)
+	if zerrors.IsNotFound(err) {
+		return oidcRequest.Scopes, true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	for _, scope := range oidcRequest.Scopes {
+		if !slices.Contains(consent.Scopes, scope) {
+			return oidcRequest.Scopes, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
 func projectRequired(ctx context.Context, request *domain.AuthRequest, projectProvider projectProvider) (missingGrant bool, err error) {
 	var project *query.Project
 	switch request.Request.Type() {
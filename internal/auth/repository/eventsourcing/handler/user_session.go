@@ -116,6 +116,30 @@ func (s *UserSession) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanPasswordlessTokenCheckFailedType,
 					Reduce: s.Reduce,
 				},
+				{
+					Event:  user.HumanMagicLinkCheckSucceededType,
+					Reduce: s.Reduce,
+				},
+				{
+					Event:  user.HumanMagicLinkCheckFailedType,
+					Reduce: s.Reduce,
+				},
+				{
+					Event:  user.HumanPhoneLoginCheckSucceededType,
+					Reduce: s.Reduce,
+				},
+				{
+					Event:  user.HumanPhoneLoginCheckFailedType,
+					Reduce: s.Reduce,
+				},
+				{
+					Event:  user.HumanX509CheckSucceededType,
+					Reduce: s.Reduce,
+				},
+				{
+					Event:  user.HumanX509CheckFailedType,
+					Reduce: s.Reduce,
+				},
 				{
 					Event:  user.HumanSignedOutType,
 					Reduce: s.Reduce,
@@ -233,6 +257,12 @@ func (u *UserSession) Reduce(event eventstore.Event) (_ *handler.Statement, err
 			user.HumanU2FTokenCheckFailedType,
 			user.HumanPasswordlessTokenCheckSucceededType,
 			user.HumanPasswordlessTokenCheckFailedType,
+			user.HumanMagicLinkCheckSucceededType,
+			user.HumanMagicLinkCheckFailedType,
+			user.HumanPhoneLoginCheckSucceededType,
+			user.HumanPhoneLoginCheckFailedType,
+			user.HumanX509CheckSucceededType,
+			user.HumanX509CheckFailedType,
 			user.HumanSignedOutType:
 
 			eventData, err := view_model.UserSessionFromEvent(event)
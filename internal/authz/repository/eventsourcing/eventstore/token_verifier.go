@@ -125,6 +125,9 @@ func (repo *TokenVerifierRepo) verifyAccessTokenV1(ctx context.Context, tokenID,
 	if err = verifyAudience(token.Audience, verifierClientID, projectID); err != nil {
 		return "", "", "", "", "", err
 	}
+	if err = domain.VerifyX509CertificateBinding(http_util.ClientCertificatesFromCtx(ctx), token.CertificateThumbprint); err != nil {
+		return "", "", "", "", "", err
+	}
 	return token.UserID, token.UserAgentID, token.ApplicationID, token.PreferredLanguage, token.ResourceOwner, nil
 }
 
@@ -139,6 +142,9 @@ func (repo *TokenVerifierRepo) verifyAccessTokenV2(ctx context.Context, token, v
 	if err = verifyAudience(activeToken.Audience, verifierClientID, projectID); err != nil {
 		return "", "", "", err
 	}
+	if err = domain.VerifyX509CertificateBinding(http_util.ClientCertificatesFromCtx(ctx), activeToken.CertificateThumbprint); err != nil {
+		return "", "", "", err
+	}
 	if err = repo.checkAuthentication(ctx, activeToken.AuthMethods, activeToken.UserID); err != nil {
 		return "", "", "", err
 	}
@@ -214,6 +220,12 @@ func authMethodsFromSession(session *query.Session) []domain.UserAuthMethodType
 	if !session.OTPEmailFactor.OTPCheckedAt.IsZero() {
 		types = append(types, domain.UserAuthMethodTypeOTPEmail)
 	}
+	if !session.MagicLinkFactor.MagicLinkCheckedAt.IsZero() {
+		types = append(types, domain.UserAuthMethodTypeMagicLink)
+	}
+	if !session.X509Factor.X509CheckedAt.IsZero() {
+		types = append(types, domain.UserAuthMethodTypeX509)
+	}
 	return types
 }
 
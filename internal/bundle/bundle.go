@@ -0,0 +1,153 @@
+// Package bundle reads and writes portable instance bundles.
+//
+// A bundle is a gzip compressed stream of JSON records.
+// The first record is the [Header], all following records contain either an event,
+// a unique constraint or an asset of the exported instance.
+// Because ZITADEL is event sourced, the events of an instance contain all of its data,
+// projections are rebuilt by the importing installation.
+//
+// Bundles are created and imported by the export and import commands of the CLI
+// and the ExportInstanceBundle and ImportInstanceBundle endpoints of the system API.
+// They are not exposed through the ExportData and ImportData endpoints of the admin API,
+// because an import must be able to create the instance itself, which exceeds the permissions of an instance administrator.
+package bundle
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Version is the current version of the bundle format.
+// Bundles with a different version are rejected on import.
+const Version uint16 = 2
+
+type Header struct {
+	Version        uint16    `json:"version"`
+	ZitadelVersion string    `json:"zitadelVersion"`
+	InstanceID     string    `json:"instanceId"`
+	ExportedAt     time.Time `json:"exportedAt"`
+	// KeyDerivation describes how the transport key of the secrets was derived from the passphrase
+	KeyDerivation *KeyDerivation `json:"keyDerivation"`
+}
+
+// Record contains exactly one of its fields
+type Record struct {
+	Event            *Event            `json:"event,omitempty"`
+	UniqueConstraint *UniqueConstraint `json:"uniqueConstraint,omitempty"`
+	Asset            *Asset            `json:"asset,omitempty"`
+}
+
+type Event struct {
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	EventType     string          `json:"eventType"`
+	Sequence      uint64          `json:"sequence"`
+	Revision      uint16          `json:"revision"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+	Creator       string          `json:"creator"`
+	Owner         string          `json:"owner"`
+	Position      float64         `json:"position"`
+	InTxOrder     uint32          `json:"inTxOrder"`
+}
+
+type UniqueConstraint struct {
+	UniqueType  string `json:"uniqueType"`
+	UniqueField string `json:"uniqueField"`
+	// Global is set for constraints unique over all instances (e.g. the domains of the instance)
+	Global bool `json:"global,omitempty"`
+}
+
+type Asset struct {
+	AssetType     int32     `json:"assetType"`
+	ResourceOwner string    `json:"resourceOwner"`
+	Name          string    `json:"name"`
+	ContentType   string    `json:"contentType"`
+	Data          []byte    `json:"data"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// Writer writes the records of a bundle, [Writer.Close] must be called to flush the stream
+type Writer struct {
+	gzip    *gzip.Writer
+	encoder *json.Encoder
+}
+
+func NewWriter(w io.Writer, header *Header) (*Writer, error) {
+	gz := gzip.NewWriter(w)
+	writer := &Writer{
+		gzip:    gz,
+		encoder: json.NewEncoder(gz),
+	}
+	if err := writer.encoder.Encode(header); err != nil {
+		return nil, zerrors.ThrowInternal(err, "BUNDL-Kf3sa", "unable to write header")
+	}
+	return writer, nil
+}
+
+func (w *Writer) Write(record *Record) error {
+	if err := w.encoder.Encode(record); err != nil {
+		return zerrors.ThrowInternal(err, "BUNDL-Ie8wq", "unable to write record")
+	}
+	return nil
+}
+
+func (w *Writer) Close() error {
+	return w.gzip.Close()
+}
+
+// Reader reads the records of a bundle
+type Reader struct {
+	Header  *Header
+	gzip    *gzip.Reader
+	decoder *json.Decoder
+}
+
+// NewReader reads the header of the bundle and
+// returns an error if the version or the key derivation of the bundle is not supported
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "BUNDL-Zn3vc", "Errors.Bundle.Invalid")
+	}
+	reader := &Reader{
+		Header:  new(Header),
+		gzip:    gz,
+		decoder: json.NewDecoder(gz),
+	}
+	if err = reader.decoder.Decode(reader.Header); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "BUNDL-4Ymxo", "Errors.Bundle.Invalid")
+	}
+	// bundles of version 1 used an unsalted transport key and are not supported anymore
+	if reader.Header.Version != Version {
+		return nil, zerrors.ThrowInvalidArgument(nil, "BUNDL-1l0pz", "Errors.Bundle.VersionNotSupported")
+	}
+	if reader.Header.InstanceID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "BUNDL-s8Ydf", "Errors.Bundle.Invalid")
+	}
+	if err = reader.Header.KeyDerivation.validate(); err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// Next returns the next record of the bundle or [io.EOF] if all records were read
+func (r *Reader) Next() (*Record, error) {
+	record := new(Record)
+	if err := r.decoder.Decode(record); err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, zerrors.ThrowInvalidArgument(err, "BUNDL-zU1Ax", "Errors.Bundle.Invalid")
+	}
+	return record, nil
+}
+
+func (r *Reader) Close() error {
+	return r.gzip.Close()
+}
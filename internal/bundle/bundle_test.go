@@ -0,0 +1,122 @@
+package bundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestWriterReader(t *testing.T) {
+	keyDerivation, err := newKeyDerivation()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	header := &Header{
+		Version:        Version,
+		ZitadelVersion: "v2.0.0",
+		InstanceID:     "instance",
+		ExportedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		KeyDerivation:  keyDerivation,
+	}
+	records := []*Record{
+		{
+			Event: &Event{
+				AggregateType: "user",
+				AggregateID:   "user1",
+				EventType:     "user.human.added",
+				Sequence:      1,
+				Revision:      1,
+				CreatedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Payload:       json.RawMessage(`{"userName":"user"}`),
+				Creator:       "creator",
+				Owner:         "org",
+				Position:      1704067200.123,
+				InTxOrder:     0,
+			},
+		},
+		{
+			UniqueConstraint: &UniqueConstraint{
+				UniqueType:  "usernames",
+				UniqueField: "user",
+			},
+		},
+		{
+			Asset: &Asset{
+				AssetType:     1,
+				ResourceOwner: "org",
+				Name:          "logo",
+				ContentType:   "image/png",
+				Data:          []byte("data"),
+				UpdatedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	writer, err := NewWriter(buf, header)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, record := range records {
+		if err = writer.Write(record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reader, err := NewReader(buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(reader.Header, header) {
+		t.Errorf("header = %v, want %v", reader.Header, header)
+	}
+	for _, want := range records {
+		got, err := reader.Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("record = %v, want %v", got, want)
+		}
+	}
+	if _, err = reader.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestNewReader_unsupportedVersion(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writer, err := NewWriter(buf, &Header{Version: Version + 1, InstanceID: "instance"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = NewReader(buf); !zerrors.IsErrorInvalidArgument(err) {
+		t.Errorf("expected invalid argument, got %v", err)
+	}
+}
+
+func TestNewReader_missingKeyDerivation(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writer, err := NewWriter(buf, &Header{Version: Version, InstanceID: "instance"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = NewReader(buf); !zerrors.IsErrorInvalidArgument(err) {
+		t.Errorf("expected invalid argument, got %v", err)
+	}
+}
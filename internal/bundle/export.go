@@ -0,0 +1,189 @@
+package bundle
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/build"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	exportEventsStmt = `SELECT aggregate_type, aggregate_id, event_type, "sequence", revision, created_at, payload, creator, "owner", "position", in_tx_order` +
+		` FROM eventstore.events2 WHERE instance_id = $1 ORDER BY "position", in_tx_order`
+	// constraints stored as global (without instance id) are exported if they are domains of the instance,
+	// which are the ones added by the latest domain event of the instance
+	exportUniqueConstraintsStmt = `SELECT unique_type, unique_field, instance_id = '' FROM eventstore.unique_constraints WHERE instance_id = $1` +
+		` OR (instance_id = '' AND unique_type = '` + instance.UniqueInstanceDomain + `' AND unique_field IN (` +
+		`SELECT domain FROM (SELECT DISTINCT ON (payload->>'domain') payload->>'domain' AS domain, event_type FROM eventstore.events2` +
+		` WHERE instance_id = $1 AND aggregate_type = '` + string(instance.AggregateType) + `'` +
+		` AND event_type IN ('` + string(instance.InstanceDomainAddedEventType) + `', '` + string(instance.InstanceDomainRemovedEventType) + `')` +
+		` ORDER BY payload->>'domain', "position" DESC, in_tx_order DESC) AS domains WHERE event_type = '` + string(instance.InstanceDomainAddedEventType) + `'))`
+	exportAssetsStmt = `SELECT asset_type, resource_owner, name, content_type, data, updated_at FROM system.assets WHERE instance_id = $1`
+)
+
+type Exporter struct {
+	client        *database.DB
+	secret        reencrypt
+	keyDerivation *KeyDerivation
+}
+
+// NewExporter creates an exporter which encrypts all secrets of the exported events
+// with the transport key derived from the passphrase and a random salt
+func NewExporter(client *database.DB, keyStorage crypto.KeyStorage, passphrase string) (*Exporter, error) {
+	keyDerivation, err := newKeyDerivation()
+	if err != nil {
+		return nil, err
+	}
+	transport, err := transportKey(passphrase, keyDerivation)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := keyStorage.ReadKeys()
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{
+		client:        client,
+		secret:        toTransport(keys, transport),
+		keyDerivation: keyDerivation,
+	}, nil
+}
+
+// Export writes all events, unique constraints and assets of the instance to w.
+// All records are read in one transaction, so the bundle is a consistent snapshot of the instance.
+func (e *Exporter) Export(ctx context.Context, instanceID string, w io.Writer) (err error) {
+	writer, err := NewWriter(w, &Header{
+		Version:        Version,
+		ZitadelVersion: build.Version(),
+		InstanceID:     instanceID,
+		ExportedAt:     time.Now(),
+		KeyDerivation:  e.keyDerivation,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := writer.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	tx, err := e.client.BeginTx(ctx, &sql.TxOptions{ReadOnly: true, Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return zerrors.ThrowInternal(err, "BUNDL-Shoo7", "unable to begin transaction")
+	}
+	// nothing is written, so the transaction is never committed
+	defer func() {
+		rollbackErr := tx.Rollback()
+		logging.OnError(rollbackErr).Debug("unable to rollback")
+	}()
+
+	if err = exportEvents(ctx, tx, instanceID, e.secret, writer); err != nil {
+		return err
+	}
+	if err = exportUniqueConstraints(ctx, tx, instanceID, writer); err != nil {
+		return err
+	}
+	return exportAssets(ctx, tx, instanceID, writer)
+}
+
+func query(ctx context.Context, tx *sql.Tx, scan func(*sql.Rows) error, stmt string, args ...any) error {
+	rows, err := tx.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	return scan(rows)
+}
+
+func exportEvents(ctx context.Context, tx *sql.Tx, instanceID string, secret reencrypt, writer *Writer) error {
+	var count int
+	err := query(ctx, tx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			event := new(Event)
+			var payload []byte
+			err := rows.Scan(
+				&event.AggregateType,
+				&event.AggregateID,
+				&event.EventType,
+				&event.Sequence,
+				&event.Revision,
+				&event.CreatedAt,
+				&payload,
+				&event.Creator,
+				&event.Owner,
+				&event.Position,
+				&event.InTxOrder,
+			)
+			if err != nil {
+				return err
+			}
+			event.Payload, err = reencryptPayload(json.RawMessage(payload), secret)
+			if err != nil {
+				return err
+			}
+			if err = writer.Write(&Record{Event: event}); err != nil {
+				return err
+			}
+			count++
+		}
+		return rows.Err()
+	}, exportEventsStmt, instanceID)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "BUNDL-Jq7ei", "unable to export events")
+	}
+	if count == 0 {
+		return zerrors.ThrowNotFound(nil, "BUNDL-O1fmz", "Errors.Instance.NotFound")
+	}
+	return nil
+}
+
+func exportUniqueConstraints(ctx context.Context, tx *sql.Tx, instanceID string, writer *Writer) error {
+	err := query(ctx, tx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			constraint := new(UniqueConstraint)
+			if err := rows.Scan(&constraint.UniqueType, &constraint.UniqueField, &constraint.Global); err != nil {
+				return err
+			}
+			if err := writer.Write(&Record{UniqueConstraint: constraint}); err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	}, exportUniqueConstraintsStmt, instanceID)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "BUNDL-b0Wxm", "unable to export unique constraints")
+	}
+	return nil
+}
+
+func exportAssets(ctx context.Context, tx *sql.Tx, instanceID string, writer *Writer) error {
+	err := query(ctx, tx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			asset := new(Asset)
+			var updatedAt sql.NullTime
+			if err := rows.Scan(&asset.AssetType, &asset.ResourceOwner, &asset.Name, &asset.ContentType, &asset.Data, &updatedAt); err != nil {
+				return err
+			}
+			asset.UpdatedAt = updatedAt.Time
+			if err := writer.Write(&Record{Asset: asset}); err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	}, exportAssetsStmt, instanceID)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "BUNDL-x7Gha", "unable to export assets")
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package bundle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// the primary keys make the import idempotent, records which already exist are skipped
+	importEventStmt = `INSERT INTO eventstore.events2 (instance_id, aggregate_type, aggregate_id, event_type, "sequence", revision, created_at, payload, creator, "owner", "position", in_tx_order)` +
+		` VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT DO NOTHING`
+	importUniqueConstraintStmt = `INSERT INTO eventstore.unique_constraints (instance_id, unique_type, unique_field) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+	importAssetStmt            = `INSERT INTO system.assets (instance_id, asset_type, resource_owner, name, content_type, data, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7)` +
+		` ON CONFLICT (instance_id, resource_owner, name) DO UPDATE SET asset_type = EXCLUDED.asset_type, content_type = EXCLUDED.content_type, data = EXCLUDED.data, updated_at = EXCLUDED.updated_at`
+)
+
+type Importer struct {
+	client     *database.DB
+	keys       crypto.Keys
+	passphrase string
+	batchSize  int
+}
+
+// NewImporter creates an importer which encrypts the secrets of the imported events
+// with the keys of this installation.
+// The keys used by the exporting installation must exist with the same ids.
+func NewImporter(client *database.DB, keyStorage crypto.KeyStorage, passphrase string, batchSize int) (*Importer, error) {
+	if passphrase == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "BUNDL-Nw5bh", "Errors.Bundle.TransportKeyMissing")
+	}
+	keys, err := keyStorage.ReadKeys()
+	if err != nil {
+		return nil, err
+	}
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	return &Importer{
+		client:     client,
+		keys:       keys,
+		passphrase: passphrase,
+		batchSize:  batchSize,
+	}, nil
+}
+
+// Stats contains the amount of imported records
+type Stats struct {
+	Events            int
+	UniqueConstraints int
+	Assets            int
+}
+
+// Import reads the bundle from r and stores its records.
+// Each batch is stored in its own transaction, so a failed import can be repeated.
+func (i *Importer) Import(ctx context.Context, r io.Reader) (_ *Header, stats *Stats, err error) {
+	reader, err := NewReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		closeErr := reader.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+	// the transport key depends on the salt and parameters stored in the header of the bundle
+	transport, err := transportKey(i.passphrase, reader.Header.KeyDerivation)
+	if err != nil {
+		return reader.Header, nil, err
+	}
+	secret := fromTransport(i.keys, transport)
+
+	stats = new(Stats)
+	batch := make([]*Record, 0, i.batchSize)
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return reader.Header, stats, err
+		}
+		batch = append(batch, record)
+		if len(batch) < i.batchSize {
+			continue
+		}
+		if err = i.importBatch(ctx, reader.Header.InstanceID, secret, batch, stats); err != nil {
+			return reader.Header, stats, err
+		}
+		batch = batch[:0]
+	}
+	return reader.Header, stats, i.importBatch(ctx, reader.Header.InstanceID, secret, batch, stats)
+}
+
+func (i *Importer) importBatch(ctx context.Context, instanceID string, secret reencrypt, records []*Record, stats *Stats) (err error) {
+	if len(records) == 0 {
+		return nil
+	}
+	tx, err := i.client.BeginTx(ctx, nil)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "BUNDL-bC3sp", "unable to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			logging.OnError(rollbackErr).Debug("unable to rollback")
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	for _, record := range records {
+		switch {
+		case record.Event != nil:
+			err = i.importEvent(ctx, tx, instanceID, secret, record.Event)
+			stats.Events++
+		case record.UniqueConstraint != nil:
+			constraintInstanceID := instanceID
+			if record.UniqueConstraint.Global {
+				constraintInstanceID = ""
+			}
+			_, err = tx.ExecContext(ctx, importUniqueConstraintStmt, constraintInstanceID, record.UniqueConstraint.UniqueType, record.UniqueConstraint.UniqueField)
+			stats.UniqueConstraints++
+		case record.Asset != nil:
+			asset := record.Asset
+			_, err = tx.ExecContext(ctx, importAssetStmt, instanceID, asset.AssetType, asset.ResourceOwner, asset.Name, asset.ContentType, asset.Data, asset.UpdatedAt)
+			stats.Assets++
+		}
+		if err != nil {
+			return zerrors.ThrowInternal(err, "BUNDL-q2Oyd", "unable to import record")
+		}
+	}
+	return nil
+}
+
+func (i *Importer) importEvent(ctx context.Context, tx *sql.Tx, instanceID string, secret reencrypt, event *Event) error {
+	payload, err := reencryptPayload(event.Payload, secret)
+	if err != nil {
+		return err
+	}
+	var data []byte
+	if len(payload) > 0 {
+		data = payload
+	}
+	_, err = tx.ExecContext(ctx, importEventStmt,
+		instanceID,
+		event.AggregateType,
+		event.AggregateID,
+		event.EventType,
+		event.Sequence,
+		event.Revision,
+		event.CreatedAt,
+		data,
+		event.Creator,
+		event.Owner,
+		event.Position,
+		event.InTxOrder,
+	)
+	return err
+}
@@ -0,0 +1,213 @@
+package bundle
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// TransportKeyID is set as key id of secrets which are encrypted with the transport key
+const TransportKeyID = "bundle-transport"
+
+type cryptoValue struct {
+	CryptoType crypto.CryptoType
+	Algorithm  string
+	KeyID      string
+	Crypted    []byte
+	// SourceKeyID is the key id the secret was encrypted with before the export.
+	// It is used to encrypt the secret again on import.
+	SourceKeyID string `json:",omitempty"`
+}
+
+const (
+	// KeyDerivationArgon2id derives the transport key using argon2id
+	KeyDerivationArgon2id = "argon2id"
+
+	keyDerivationSaltLength = 16
+	keyDerivationKeyLength  = 32
+	// the defaults follow the recommendation of RFC 9106 for memory constrained environments
+	keyDerivationTime    uint32 = 3
+	keyDerivationMemory  uint32 = 64 * 1024
+	keyDerivationThreads uint8  = 4
+	// the bounds prevent bundles with weak parameters and
+	// bundles exhausting the resources of the importing installation
+	keyDerivationMinMemory uint32 = 19 * 1024
+	keyDerivationMaxMemory uint32 = 1024 * 1024
+	keyDerivationMaxTime   uint32 = 16
+)
+
+// KeyDerivation contains the parameters used to derive the transport key from the passphrase.
+// A random salt is generated for each export, so the same passphrase results in different keys.
+type KeyDerivation struct {
+	Algorithm string `json:"algorithm"`
+	Salt      []byte `json:"salt"`
+	Time      uint32 `json:"time"`
+	// Memory is defined in KiB
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+func newKeyDerivation() (*KeyDerivation, error) {
+	salt := make([]byte, keyDerivationSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, zerrors.ThrowInternal(err, "BUNDL-u3Rkd", "Errors.Internal")
+	}
+	return &KeyDerivation{
+		Algorithm: KeyDerivationArgon2id,
+		Salt:      salt,
+		Time:      keyDerivationTime,
+		Memory:    keyDerivationMemory,
+		Threads:   keyDerivationThreads,
+	}, nil
+}
+
+func (k *KeyDerivation) validate() error {
+	if k == nil ||
+		k.Algorithm != KeyDerivationArgon2id ||
+		len(k.Salt) < keyDerivationSaltLength ||
+		k.Time == 0 || k.Time > keyDerivationMaxTime ||
+		k.Memory < keyDerivationMinMemory || k.Memory > keyDerivationMaxMemory ||
+		k.Threads == 0 {
+		return zerrors.ThrowInvalidArgument(nil, "BUNDL-Wd9oe", "Errors.Bundle.KeyDerivationInvalid")
+	}
+	return nil
+}
+
+// transportKey derives an aes-256 key from the passphrase provided by the user
+func transportKey(passphrase string, derivation *KeyDerivation) (string, error) {
+	if passphrase == "" {
+		return "", zerrors.ThrowInvalidArgument(nil, "BUNDL-Nw5bh", "Errors.Bundle.TransportKeyMissing")
+	}
+	if err := derivation.validate(); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(passphrase), derivation.Salt, derivation.Time, derivation.Memory, derivation.Threads, keyDerivationKeyLength)
+	return string(key), nil
+}
+
+// reencrypt is called for each encrypted secret of a payload and returns the secret with the new encryption
+type reencrypt func(value *cryptoValue) (*cryptoValue, error)
+
+// toTransport decrypts secrets encrypted with the keys of the installation
+// and encrypts them with the transport key
+func toTransport(keys crypto.Keys, transport string) reencrypt {
+	return func(value *cryptoValue) (*cryptoValue, error) {
+		key, ok := keys[value.KeyID]
+		if !ok {
+			return nil, zerrors.ThrowInternalf(nil, "BUNDL-0zZtN", "encryption key %s not found", value.KeyID)
+		}
+		return switchKey(value, key, transport, TransportKeyID, value.KeyID)
+	}
+}
+
+// fromTransport decrypts secrets encrypted with the transport key
+// and encrypts them with the key of the installation with the id used before the export
+func fromTransport(keys crypto.Keys, transport string) reencrypt {
+	return func(value *cryptoValue) (*cryptoValue, error) {
+		if value.KeyID != TransportKeyID {
+			return value, nil
+		}
+		key, ok := keys[value.SourceKeyID]
+		if !ok {
+			return nil, zerrors.ThrowPreconditionFailedf(nil, "BUNDL-Tq2Sb", "encryption key %s not found, it must exist before the import", value.SourceKeyID)
+		}
+		return switchKey(value, transport, key, value.SourceKeyID, "")
+	}
+}
+
+func switchKey(value *cryptoValue, decryptionKey, encryptionKey, encryptionKeyID, sourceKeyID string) (*cryptoValue, error) {
+	decrypted, err := crypto.DecryptAES(value.Crypted, decryptionKey)
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "BUNDL-3cBvq", "Errors.Bundle.DecryptionFailed")
+	}
+	encrypted, err := crypto.EncryptAES(decrypted, encryptionKey)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "BUNDL-Hv8mZ", "Errors.Internal")
+	}
+	return &cryptoValue{
+		CryptoType:  value.CryptoType,
+		Algorithm:   value.Algorithm,
+		KeyID:       encryptionKeyID,
+		Crypted:     encrypted,
+		SourceKeyID: sourceKeyID,
+	}, nil
+}
+
+// reencryptPayload replaces all encrypted secrets ([crypto.CryptoValue]) in the json payload of an event
+func reencryptPayload(payload json.RawMessage, fn reencrypt) (json.RawMessage, error) {
+	if len(payload) == 0 || !bytes.Contains(payload, []byte(`"Crypted"`)) {
+		return payload, nil
+	}
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	// keep numbers as they are, large ids would lose precision as float64
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, zerrors.ThrowInternal(err, "BUNDL-c9Rkx", "unable to parse payload")
+	}
+	data, err := reencryptValue(data, fn)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(data)
+}
+
+func reencryptValue(data interface{}, fn reencrypt) (interface{}, error) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if value, ok := asEncryptedValue(v); ok {
+			reencrypted, err := fn(value)
+			if err != nil {
+				return nil, err
+			}
+			return reencrypted, nil
+		}
+		for key, field := range v {
+			reencrypted, err := reencryptValue(field, fn)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = reencrypted
+		}
+		return v, nil
+	case []interface{}:
+		for i, field := range v {
+			reencrypted, err := reencryptValue(field, fn)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = reencrypted
+		}
+		return v, nil
+	default:
+		return data, nil
+	}
+}
+
+// asEncryptedValue checks if the object is an encrypted [crypto.CryptoValue]
+// hashed values are not encrypted with a key and can be transferred as they are
+func asEncryptedValue(object map[string]interface{}) (*cryptoValue, bool) {
+	if _, ok := object["Crypted"]; !ok {
+		return nil, false
+	}
+	if _, ok := object["KeyID"]; !ok {
+		return nil, false
+	}
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return nil, false
+	}
+	value := new(cryptoValue)
+	if err = json.Unmarshal(encoded, value); err != nil {
+		return nil, false
+	}
+	if value.CryptoType != crypto.TypeEncryption || value.KeyID == "" || len(value.Crypted) == 0 {
+		return nil, false
+	}
+	return value, true
+}
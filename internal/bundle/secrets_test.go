@@ -0,0 +1,212 @@
+package bundle
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestReencryptPayload(t *testing.T) {
+	sourceKeys := crypto.Keys{"userKey": "01234567890123456789012345678901"}
+	targetKeys := crypto.Keys{"userKey": "abcdefghijabcdefghijabcdefghijab"}
+	keyDerivation, err := newKeyDerivation()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	transport, err := transportKey("passphrase", keyDerivation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	encrypted, err := crypto.EncryptAES([]byte("secret"), sourceKeys["userKey"])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"id":       json.Number("236118452394016516"),
+		"userName": "user",
+		"secret": &crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "aes",
+			KeyID:      "userKey",
+			Crypted:    encrypted,
+		},
+		"hash": &crypto.CryptoValue{
+			CryptoType: crypto.TypeHash,
+			Algorithm:  "bcrypt",
+			Crypted:    []byte("hash"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	exported, err := reencryptPayload(payload, toTransport(sourceKeys, transport))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	imported, err := reencryptPayload(exported, fromTransport(targetKeys, transport))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := struct {
+		ID       json.Number        `json:"id"`
+		UserName string             `json:"userName"`
+		Secret   crypto.CryptoValue `json:"secret"`
+		Hash     crypto.CryptoValue `json:"hash"`
+	}{}
+	if err = json.Unmarshal(imported, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != "236118452394016516" || got.UserName != "user" {
+		t.Errorf("unencrypted fields changed: %s", imported)
+	}
+	if got.Secret.KeyID != "userKey" {
+		t.Errorf("key id = %s, want userKey", got.Secret.KeyID)
+	}
+	decrypted, err := crypto.DecryptAES(got.Secret.Crypted, targetKeys["userKey"])
+	if err != nil || string(decrypted) != "secret" {
+		t.Errorf("secret not encrypted with target key: %s, %v", decrypted, err)
+	}
+	if string(got.Hash.Crypted) != "hash" {
+		t.Errorf("hash must not be changed, got %s", got.Hash.Crypted)
+	}
+}
+
+func TestReencryptPayload_missingKey(t *testing.T) {
+	keyDerivation, err := newKeyDerivation()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	transport, err := transportKey("passphrase", keyDerivation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	payload := []byte(`{"secret":{"CryptoType":0,"Algorithm":"aes","KeyID":"smtpKey","Crypted":"AAAAAAAAAAAAAAAAAAAAAA=="}}`)
+	if _, err = reencryptPayload(payload, toTransport(crypto.Keys{}, transport)); err == nil {
+		t.Error("expected error for missing key")
+	}
+}
+
+func TestTransportKey(t *testing.T) {
+	keyDerivation, err := newKeyDerivation()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	key, err := transportKey("passphrase", keyDerivation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(key) != keyDerivationKeyLength {
+		t.Errorf("key length = %d, want %d", len(key), keyDerivationKeyLength)
+	}
+	sameSalt, err := transportKey("passphrase", keyDerivation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sameSalt != key {
+		t.Error("same passphrase and salt must result in the same key")
+	}
+	otherDerivation, err := newKeyDerivation()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	otherSalt, err := transportKey("passphrase", otherDerivation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if otherSalt == key {
+		t.Error("different salts must result in different keys")
+	}
+}
+
+func TestTransportKey_invalid(t *testing.T) {
+	valid := func() *KeyDerivation {
+		return &KeyDerivation{
+			Algorithm: KeyDerivationArgon2id,
+			Salt:      make([]byte, keyDerivationSaltLength),
+			Time:      keyDerivationTime,
+			Memory:    keyDerivationMemory,
+			Threads:   keyDerivationThreads,
+		}
+	}
+	tests := []struct {
+		name       string
+		passphrase string
+		derivation func() *KeyDerivation
+	}{
+		{
+			name:       "missing passphrase",
+			derivation: valid,
+		},
+		{
+			name:       "missing key derivation",
+			passphrase: "passphrase",
+			derivation: func() *KeyDerivation { return nil },
+		},
+		{
+			name:       "unknown algorithm",
+			passphrase: "passphrase",
+			derivation: func() *KeyDerivation {
+				k := valid()
+				k.Algorithm = "sha256"
+				return k
+			},
+		},
+		{
+			name:       "short salt",
+			passphrase: "passphrase",
+			derivation: func() *KeyDerivation {
+				k := valid()
+				k.Salt = []byte("salt")
+				return k
+			},
+		},
+		{
+			name:       "too little memory",
+			passphrase: "passphrase",
+			derivation: func() *KeyDerivation {
+				k := valid()
+				k.Memory = 1024
+				return k
+			},
+		},
+		{
+			name:       "too much memory",
+			passphrase: "passphrase",
+			derivation: func() *KeyDerivation {
+				k := valid()
+				k.Memory = keyDerivationMaxMemory + 1
+				return k
+			},
+		},
+		{
+			name:       "no iterations",
+			passphrase: "passphrase",
+			derivation: func() *KeyDerivation {
+				k := valid()
+				k.Time = 0
+				return k
+			},
+		},
+		{
+			name:       "no threads",
+			passphrase: "passphrase",
+			derivation: func() *KeyDerivation {
+				k := valid()
+				k.Threads = 0
+				return k
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := transportKey(tt.passphrase, tt.derivation()); !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("expected invalid argument, got %v", err)
+			}
+		})
+	}
+}
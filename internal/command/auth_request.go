@@ -2,11 +2,14 @@ package command
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -27,6 +30,11 @@ type AuthRequest struct {
 	MaxAge        *time.Duration
 	LoginHint     *string
 	HintUserID    *string
+	// LevelOfAssurance is the lowest level requested by the client (acr_values),
+	// which must be reached by the session linked to the auth request.
+	LevelOfAssurance domain.LevelOfAssurance
+	// ConsentRequired is set if the client requires the consent of the user to the requested scopes.
+	ConsentRequired bool
 }
 
 type CurrentAuthRequest struct {
@@ -35,6 +43,8 @@ type CurrentAuthRequest struct {
 	UserID      string
 	AuthMethods []domain.UserAuthMethodType
 	AuthTime    time.Time
+	// FailureReason is set if the auth request failed
+	FailureReason domain.OIDCErrorReason
 }
 
 const IDPrefixV2 = "V2_"
@@ -69,6 +79,8 @@ func (c *Commands) AddAuthRequest(ctx context.Context, authRequest *AuthRequest)
 		authRequest.MaxAge,
 		authRequest.LoginHint,
 		authRequest.HintUserID,
+		authRequest.LevelOfAssurance,
+		authRequest.ConsentRequired,
 	))
 	if err != nil {
 		return nil, err
@@ -76,7 +88,11 @@ func (c *Commands) AddAuthRequest(ctx context.Context, authRequest *AuthRequest)
 	return authRequestWriteModelToCurrentAuthRequest(writeModel), nil
 }
 
-func (c *Commands) LinkSessionToAuthRequest(ctx context.Context, id, sessionID, sessionToken string, checkLoginClient bool) (*domain.ObjectDetails, *CurrentAuthRequest, error) {
+// LinkSessionToAuthRequest links the session to the auth request, after checking the authentication of the session.
+// If the client requires a consent (or asks for it using prompt=consent), which the user did not give for all requested scopes yet,
+// the login has to ask the user for it and pass consentGranted, which stores the consent for later auth requests.
+// Without consent, a precondition failed error is returned, or in case of prompt=none, the auth request fails with the reason consent_required.
+func (c *Commands) LinkSessionToAuthRequest(ctx context.Context, id, sessionID, sessionToken string, checkLoginClient, consentGranted bool) (*domain.ObjectDetails, *CurrentAuthRequest, error) {
 	writeModel, err := c.getAuthRequestWriteModel(ctx, id)
 	if err != nil {
 		return nil, nil, err
@@ -101,19 +117,64 @@ func (c *Commands) LinkSessionToAuthRequest(ctx context.Context, id, sessionID,
 	if err := c.sessionTokenVerifier(ctx, sessionToken, sessionWriteModel.AggregateID, sessionWriteModel.TokenID); err != nil {
 		return nil, nil, err
 	}
+	if err := writeModel.checkSessionAuthentication(sessionWriteModel); err != nil {
+		return nil, nil, err
+	}
+	authRequestAgg := &authrequest.NewAggregate(id, authz.GetInstance(ctx).InstanceID()).Aggregate
+	consentEvent, consentMissing, err := c.authRequestConsent(ctx, writeModel, sessionWriteModel, consentGranted)
+	if err != nil {
+		return nil, nil, err
+	}
+	if consentMissing {
+		if !slices.Contains(writeModel.Prompt, domain.PromptNone) {
+			return nil, nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Aer4s", "Errors.AuthRequest.ConsentRequired")
+		}
+		// the user must not be asked for consent with prompt=none
+		if err = c.pushAppendAndReduce(ctx, writeModel, authrequest.NewFailedEvent(ctx, authRequestAgg, domain.OIDCErrorReasonConsentRequired)); err != nil {
+			return nil, nil, err
+		}
+		return writeModelToObjectDetails(&writeModel.WriteModel), authRequestWriteModelToCurrentAuthRequest(writeModel), nil
+	}
 
-	if err := c.pushAppendAndReduce(ctx, writeModel, authrequest.NewSessionLinkedEvent(
-		ctx, &authrequest.NewAggregate(id, authz.GetInstance(ctx).InstanceID()).Aggregate,
-		sessionID,
-		sessionWriteModel.UserID,
-		sessionWriteModel.AuthenticationTime(),
-		sessionWriteModel.AuthMethodTypes(),
-	)); err != nil {
+	events := []eventstore.Command{
+		authrequest.NewSessionLinkedEvent(
+			ctx, authRequestAgg,
+			sessionID,
+			sessionWriteModel.UserID,
+			sessionWriteModel.AuthenticationTime(),
+			sessionWriteModel.AuthMethodTypes(),
+		),
+	}
+	if consentEvent != nil {
+		events = append(events, consentEvent)
+	}
+	if err := c.pushAppendAndReduce(ctx, writeModel, events...); err != nil {
 		return nil, nil, err
 	}
 	return writeModelToObjectDetails(&writeModel.WriteModel), authRequestWriteModelToCurrentAuthRequest(writeModel), nil
 }
 
+// authRequestConsent checks if the user consented to the client accessing the requested scopes,
+// in case the client requires it or explicitly asks for it (prompt=consent).
+// A consent granted in the login is returned as event to be stored with the linked session.
+func (c *Commands) authRequestConsent(ctx context.Context, writeModel *AuthRequestWriteModel, sessionWriteModel *SessionWriteModel, consentGranted bool) (_ eventstore.Command, missing bool, err error) {
+	consentPrompted := slices.Contains(writeModel.Prompt, domain.PromptConsent)
+	if !writeModel.ConsentRequired && !consentPrompted {
+		return nil, false, nil
+	}
+	consent, err := c.userConsentWriteModel(ctx, sessionWriteModel.UserID, sessionWriteModel.UserResourceOwner, writeModel.ClientID)
+	if err != nil {
+		return nil, false, err
+	}
+	if !consentGranted {
+		return nil, consentPrompted || !consent.Granted(writeModel.Scope), nil
+	}
+	if consent.Granted(writeModel.Scope) {
+		return nil, false, nil
+	}
+	return user.NewHumanConsentGrantedEvent(ctx, UserAggregateFromWriteModel(&consent.WriteModel), writeModel.ClientID, consent.withScopes(writeModel.Scope)), false, nil
+}
+
 func (c *Commands) FailAuthRequest(ctx context.Context, id string, reason domain.OIDCErrorReason) (*domain.ObjectDetails, *CurrentAuthRequest, error) {
 	writeModel, err := c.getAuthRequestWriteModel(ctx, id)
 	if err != nil {
@@ -185,11 +246,16 @@ func authRequestWriteModelToCurrentAuthRequest(writeModel *AuthRequestWriteModel
 			MaxAge:        writeModel.MaxAge,
 			LoginHint:     writeModel.LoginHint,
 			HintUserID:    writeModel.HintUserID,
+
+			LevelOfAssurance: writeModel.LevelOfAssurance,
+			ConsentRequired:  writeModel.ConsentRequired,
 		},
 		SessionID:   writeModel.SessionID,
 		UserID:      writeModel.UserID,
 		AuthMethods: writeModel.AuthMethods,
 		AuthTime:    writeModel.AuthTime,
+
+		FailureReason: writeModel.FailureReason,
 	}
 }
 
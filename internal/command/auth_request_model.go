@@ -15,6 +15,7 @@ type AuthRequestWriteModel struct {
 	eventstore.WriteModel
 	aggregate *eventstore.Aggregate
 
+	CreationDate     time.Time
 	LoginClient      string
 	ClientID         string
 	RedirectURI      string
@@ -29,11 +30,14 @@ type AuthRequestWriteModel struct {
 	MaxAge           *time.Duration
 	LoginHint        *string
 	HintUserID       *string
+	LevelOfAssurance domain.LevelOfAssurance
+	ConsentRequired  bool
 	SessionID        string
 	UserID           string
 	AuthTime         time.Time
 	AuthMethods      []domain.UserAuthMethodType
 	AuthRequestState domain.AuthRequestState
+	FailureReason    domain.OIDCErrorReason
 }
 
 func NewAuthRequestWriteModel(ctx context.Context, id string) *AuthRequestWriteModel {
@@ -49,6 +53,7 @@ func (m *AuthRequestWriteModel) Reduce() error {
 	for _, event := range m.Events {
 		switch e := event.(type) {
 		case *authrequest.AddedEvent:
+			m.CreationDate = e.CreationDate()
 			m.LoginClient = e.LoginClient
 			m.ClientID = e.ClientID
 			m.RedirectURI = e.RedirectURI
@@ -63,6 +68,8 @@ func (m *AuthRequestWriteModel) Reduce() error {
 			m.MaxAge = e.MaxAge
 			m.LoginHint = e.LoginHint
 			m.HintUserID = e.HintUserID
+			m.LevelOfAssurance = e.LevelOfAssurance
+			m.ConsentRequired = e.ConsentRequired
 			m.AuthRequestState = domain.AuthRequestStateAdded
 		case *authrequest.SessionLinkedEvent:
 			m.SessionID = e.SessionID
@@ -73,6 +80,7 @@ func (m *AuthRequestWriteModel) Reduce() error {
 			m.AuthRequestState = domain.AuthRequestStateCodeAdded
 		case *authrequest.FailedEvent:
 			m.AuthRequestState = domain.AuthRequestStateFailed
+			m.FailureReason = e.Reason
 		case *authrequest.CodeExchangedEvent:
 			m.AuthRequestState = domain.AuthRequestStateCodeExchanged
 		case *authrequest.SucceededEvent:
@@ -91,6 +99,18 @@ func (m *AuthRequestWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
+// checkSessionAuthentication checks that the authentication of the session satisfies
+// the max_age and the level of assurance (acr_values) requested by the client.
+func (m *AuthRequestWriteModel) checkSessionAuthentication(session *SessionWriteModel) error {
+	if m.MaxAge != nil && session.AuthenticationTime().Before(m.CreationDate.Add(-*m.MaxAge)) {
+		return zerrors.ThrowPreconditionFailed(nil, "AUTHR-Aeph8", "Errors.AuthRequest.MaxAgeExceeded")
+	}
+	if domain.LevelOfAssuranceFromAuthMethods(session.AuthMethodTypes()) < m.LevelOfAssurance {
+		return zerrors.ThrowPreconditionFailed(nil, "AUTHR-ohT3e", "Errors.AuthRequest.LevelOfAssuranceNotReached")
+	}
+	return nil
+}
+
 // CheckAuthenticated checks that the auth request exists, a session must have been linked
 // and in case of a Code Flow the code must have been exchanged
 func (m *AuthRequestWriteModel) CheckAuthenticated() error {
@@ -18,6 +18,7 @@ import (
 	"github.com/zitadel/zitadel/internal/id/mock"
 	"github.com/zitadel/zitadel/internal/repository/authrequest"
 	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -59,6 +60,8 @@ func TestCommands_AddAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								domain.LevelOfAssuranceNone,
+								false,
 							),
 						),
 					),
@@ -96,6 +99,8 @@ func TestCommands_AddAuthRequest(t *testing.T) {
 							gu.Ptr(time.Duration(0)),
 							gu.Ptr("loginHint"),
 							gu.Ptr("hintUserID"),
+							domain.LevelOfAssuranceNone,
+							false,
 						),
 					),
 				),
@@ -173,6 +178,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 		sessionID        string
 		sessionToken     string
 		checkLoginClient bool
+		consentGranted   bool
 	}
 	type res struct {
 		details *domain.ObjectDetails
@@ -223,6 +229,8 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								domain.LevelOfAssuranceNone,
+								false,
 							),
 						),
 						eventFromEventPusher(
@@ -263,6 +271,8 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								domain.LevelOfAssuranceNone,
+								false,
 							),
 						),
 					),
@@ -301,6 +311,8 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								domain.LevelOfAssuranceNone,
+								false,
 							),
 						),
 					),
@@ -338,6 +350,8 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								domain.LevelOfAssuranceNone,
+								false,
 							),
 						),
 					),
@@ -398,6 +412,8 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								domain.LevelOfAssuranceNone,
+								false,
 							),
 						),
 					),
@@ -447,6 +463,8 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								domain.LevelOfAssuranceNone,
+								false,
 							),
 						),
 					),
@@ -511,6 +529,132 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			"max age exceeded",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(
+							authrequest.NewAddedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate,
+								"loginClient",
+								"clientID",
+								"redirectURI",
+								"state",
+								"nonce",
+								[]string{"openid"},
+								[]string{"audience"},
+								domain.OIDCResponseTypeCode,
+								nil,
+								nil,
+								nil,
+								gu.Ptr(time.Duration(0)),
+								nil,
+								nil,
+								domain.LevelOfAssuranceNone,
+								false,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(mockCtx,
+								&session.NewAggregate("sessionID", "instance1").Aggregate,
+								&domain.UserAgent{
+									FingerprintID: gu.Ptr("fp1"),
+									IP:            net.ParseIP("1.2.3.4"),
+									Description:   gu.Ptr("firefox"),
+									Header:        http.Header{"foo": []string{"bar"}},
+								},
+							)),
+						eventFromEventPusher(
+							session.NewUserCheckedEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+								"userID", "org1", testNow),
+						),
+						eventFromEventPusher(
+							session.NewPasswordCheckedEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+								testNow),
+						),
+						eventFromEventPusherWithCreationDateNow(
+							session.NewLifetimeSetEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+								2*time.Minute),
+						),
+					),
+				),
+				tokenVerifier: newMockTokenVerifierValid(),
+			},
+			args{
+				ctx:          mockCtx,
+				id:           "V2_id",
+				sessionID:    "sessionID",
+				sessionToken: "token",
+			},
+			res{
+				wantErr: zerrors.ThrowPreconditionFailed(nil, "AUTHR-Aeph8", "Errors.AuthRequest.MaxAgeExceeded"),
+			},
+		},
+		{
+			"level of assurance not reached",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							authrequest.NewAddedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate,
+								"loginClient",
+								"clientID",
+								"redirectURI",
+								"state",
+								"nonce",
+								[]string{"openid"},
+								[]string{"audience"},
+								domain.OIDCResponseTypeCode,
+								nil,
+								nil,
+								nil,
+								nil,
+								nil,
+								nil,
+								domain.LevelOfAssuranceMFA,
+								false,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(mockCtx,
+								&session.NewAggregate("sessionID", "instance1").Aggregate,
+								&domain.UserAgent{
+									FingerprintID: gu.Ptr("fp1"),
+									IP:            net.ParseIP("1.2.3.4"),
+									Description:   gu.Ptr("firefox"),
+									Header:        http.Header{"foo": []string{"bar"}},
+								},
+							)),
+						eventFromEventPusher(
+							session.NewUserCheckedEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+								"userID", "org1", testNow),
+						),
+						eventFromEventPusher(
+							session.NewPasswordCheckedEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+								testNow),
+						),
+						eventFromEventPusherWithCreationDateNow(
+							session.NewLifetimeSetEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+								2*time.Minute),
+						),
+					),
+				),
+				tokenVerifier: newMockTokenVerifierValid(),
+			},
+			args{
+				ctx:          mockCtx,
+				id:           "V2_id",
+				sessionID:    "sessionID",
+				sessionToken: "token",
+			},
+			res{
+				wantErr: zerrors.ThrowPreconditionFailed(nil, "AUTHR-ohT3e", "Errors.AuthRequest.LevelOfAssuranceNotReached"),
+			},
+		},
 		{
 			"linked with login client check",
 			fields{
@@ -532,6 +676,8 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								domain.LevelOfAssuranceNone,
+								false,
 							),
 						),
 					),
@@ -604,7 +750,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 				eventstore:           tt.fields.eventstore,
 				sessionTokenVerifier: tt.fields.tokenVerifier,
 			}
-			details, got, err := c.LinkSessionToAuthRequest(tt.args.ctx, tt.args.id, tt.args.sessionID, tt.args.sessionToken, tt.args.checkLoginClient)
+			details, got, err := c.LinkSessionToAuthRequest(tt.args.ctx, tt.args.id, tt.args.sessionID, tt.args.sessionToken, tt.args.checkLoginClient, tt.args.consentGranted)
 			require.ErrorIs(t, err, tt.res.wantErr)
 			assert.Equal(t, tt.res.details, details)
 			if err == nil {
@@ -616,6 +762,166 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 	}
 }
 
+func TestCommands_LinkSessionToAuthRequest_consent(t *testing.T) {
+	mockCtx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+	authRequestAdded := func(prompt []domain.Prompt, consentRequired bool) eventstore.Event {
+		return eventFromEventPusher(
+			authrequest.NewAddedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate,
+				"loginClient",
+				"clientID",
+				"redirectURI",
+				"state",
+				"nonce",
+				[]string{"openid", "profile"},
+				[]string{"audience"},
+				domain.OIDCResponseTypeCode,
+				nil,
+				prompt,
+				nil,
+				nil,
+				nil,
+				nil,
+				domain.LevelOfAssuranceNone,
+				consentRequired,
+			),
+		)
+	}
+	sessionEvents := func() expect {
+		return expectFilter(
+			eventFromEventPusher(
+				session.NewAddedEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate, &domain.UserAgent{FingerprintID: gu.Ptr("fp1")}),
+			),
+			eventFromEventPusher(
+				session.NewUserCheckedEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate, "userID", "org1", testNow),
+			),
+			eventFromEventPusher(
+				session.NewPasswordCheckedEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate, testNow),
+			),
+			eventFromEventPusherWithCreationDateNow(
+				session.NewLifetimeSetEvent(mockCtx, &session.NewAggregate("sessionID", "instance1").Aggregate, 2*time.Minute),
+			),
+		)
+	}
+	sessionLinked := authrequest.NewSessionLinkedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate,
+		"sessionID",
+		"userID",
+		testNow,
+		[]domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+	)
+	consentGranted := func(scopes ...string) eventstore.Event {
+		return eventFromEventPusher(
+			user.NewHumanConsentGrantedEvent(mockCtx, &user.NewAggregate("userID", "org").Aggregate, "clientID", scopes),
+		)
+	}
+	tests := []struct {
+		name           string
+		eventstore     func(*testing.T) *eventstore.Eventstore
+		consentGranted bool
+		wantLinked     bool
+		wantFailure    domain.OIDCErrorReason
+		wantErr        error
+	}{
+		{
+			name: "consent not required, linked",
+			eventstore: expectEventstore(
+				expectFilter(authRequestAdded(nil, false)),
+				sessionEvents(),
+				expectPush(sessionLinked),
+			),
+			wantLinked: true,
+		},
+		{
+			name: "consent required, missing, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(authRequestAdded(nil, true)),
+				sessionEvents(),
+				expectFilter(consentHumanAddedEvent()),
+			),
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Aer4s", "Errors.AuthRequest.ConsentRequired"),
+		},
+		{
+			name: "consent required, missing scope, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(authRequestAdded(nil, true)),
+				sessionEvents(),
+				expectFilter(consentHumanAddedEvent(), consentGranted("openid")),
+			),
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Aer4s", "Errors.AuthRequest.ConsentRequired"),
+		},
+		{
+			name: "consent required, missing with prompt none, failed",
+			eventstore: expectEventstore(
+				expectFilter(authRequestAdded([]domain.Prompt{domain.PromptNone}, true)),
+				sessionEvents(),
+				expectFilter(consentHumanAddedEvent()),
+				expectPush(
+					authrequest.NewFailedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate, domain.OIDCErrorReasonConsentRequired),
+				),
+			),
+			wantFailure: domain.OIDCErrorReasonConsentRequired,
+		},
+		{
+			name: "consent required, stored, linked",
+			eventstore: expectEventstore(
+				expectFilter(authRequestAdded(nil, true)),
+				sessionEvents(),
+				expectFilter(consentHumanAddedEvent(), consentGranted("openid", "profile", "email")),
+				expectPush(sessionLinked),
+			),
+			wantLinked: true,
+		},
+		{
+			name: "consent required, granted in login, linked and stored",
+			eventstore: expectEventstore(
+				expectFilter(authRequestAdded(nil, true)),
+				sessionEvents(),
+				expectFilter(consentHumanAddedEvent(), consentGranted("openid", "email")),
+				expectPush(
+					sessionLinked,
+					user.NewHumanConsentGrantedEvent(mockCtx, &user.NewAggregate("userID", "org1").Aggregate, "clientID", []string{"openid", "email", "profile"}),
+				),
+			),
+			consentGranted: true,
+			wantLinked:     true,
+		},
+		{
+			name: "prompt consent, stored, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(authRequestAdded([]domain.Prompt{domain.PromptConsent}, false)),
+				sessionEvents(),
+				expectFilter(consentHumanAddedEvent(), consentGranted("openid", "profile")),
+			),
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Aer4s", "Errors.AuthRequest.ConsentRequired"),
+		},
+		{
+			name: "prompt consent, granted in login, linked",
+			eventstore: expectEventstore(
+				expectFilter(authRequestAdded([]domain.Prompt{domain.PromptConsent}, false)),
+				sessionEvents(),
+				expectFilter(consentHumanAddedEvent(), consentGranted("openid", "profile")),
+				expectPush(sessionLinked),
+			),
+			consentGranted: true,
+			wantLinked:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:           tt.eventstore(t),
+				sessionTokenVerifier: newMockTokenVerifierValid(),
+			}
+			_, got, err := c.LinkSessionToAuthRequest(mockCtx, "V2_id", "sessionID", "token", true, tt.consentGranted)
+			require.ErrorIs(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantLinked, got.SessionID == "sessionID")
+			assert.Equal(t, tt.wantFailure, got.FailureReason)
+		})
+	}
+}
+
 func TestCommands_FailAuthRequest(t *testing.T) {
 	mockCtx := authz.NewMockContext("instanceID", "orgID", "loginClient")
 	type fields struct {
@@ -674,6 +980,8 @@ func TestCommands_FailAuthRequest(t *testing.T) {
 								nil,
 								nil,
 								nil,
+								domain.LevelOfAssuranceNone,
+								false,
 							),
 						),
 					),
@@ -702,6 +1010,7 @@ func TestCommands_FailAuthRequest(t *testing.T) {
 						Audience:     []string{"audience"},
 						ResponseType: domain.OIDCResponseTypeCode,
 					},
+					FailureReason: domain.OIDCErrorReasonLoginRequired,
 				},
 			},
 		},
@@ -771,6 +1080,8 @@ func TestCommands_AddAuthRequestCode(t *testing.T) {
 								gu.Ptr(time.Duration(0)),
 								gu.Ptr("loginHint"),
 								gu.Ptr("hintUserID"),
+								domain.LevelOfAssuranceNone,
+								false,
 							),
 						),
 					),
@@ -807,6 +1118,8 @@ func TestCommands_AddAuthRequestCode(t *testing.T) {
 								gu.Ptr(time.Duration(0)),
 								gu.Ptr("loginHint"),
 								gu.Ptr("hintUserID"),
+								domain.LevelOfAssuranceNone,
+								false,
 							),
 						),
 						eventFromEventPusher(
@@ -898,6 +1211,8 @@ func TestCommands_ExchangeAuthCode(t *testing.T) {
 								gu.Ptr(time.Duration(0)),
 								gu.Ptr("loginHint"),
 								gu.Ptr("hintUserID"),
+								domain.LevelOfAssuranceNone,
+								false,
 							),
 						),
 					),
@@ -935,6 +1250,8 @@ func TestCommands_ExchangeAuthCode(t *testing.T) {
 								gu.Ptr(time.Duration(0)),
 								gu.Ptr("loginHint"),
 								gu.Ptr("hintUserID"),
+								domain.LevelOfAssuranceNone,
+								false,
 							),
 						),
 						eventFromEventPusher(
@@ -0,0 +1,43 @@
+package command
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// validateHTTPEndpoint checks that the endpoint of an http email or sms provider is an absolute http(s) url
+func validateHTTPEndpoint(endpoint string) error {
+	parsed, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return zerrors.ThrowInvalidArgument(err, "COMMAND-Vk2sp", "Errors.Invalid.Argument")
+	}
+	return nil
+}
+
+// encryptSigningKey encrypts the signing key of an http provider, an empty key is not encrypted
+func encryptSigningKey(signingKey string, alg crypto.EncryptionAlgorithm) (*crypto.CryptoValue, error) {
+	if signingKey == "" {
+		return nil, nil
+	}
+	return crypto.Encrypt([]byte(signingKey), alg)
+}
+
+// decryptOptionalString decrypts the value, an unset value results in an empty string
+func decryptOptionalString(value *crypto.CryptoValue, alg crypto.EncryptionAlgorithm) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	return crypto.DecryptString(value, alg)
+}
+
+func httpHeadersEqual(a, b http.Header) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
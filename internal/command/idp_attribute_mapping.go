@@ -0,0 +1,151 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetInstanceIDPAttributeMapping sets the mapping of the claims or attributes of users of the instance IDP
+// to the fields and metadata of the ZITADEL user.
+func (c *Commands) SetInstanceIDPAttributeMapping(ctx context.Context, idpID string, mapping *domain.IDPAttributeMapping) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if mapping == nil || !mapping.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ooP0u", "Errors.IDPConfig.AttributeMappingInvalid")
+	}
+	exists, err := ExistsInstanceIDP(ctx, c.eventstore.Filter, idpID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ahd7e", "Errors.IDPConfig.NotExisting")
+	}
+	writeModel, err := c.idpAttributeMappingWriteModel(ctx, idpID, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	eventMapping := attributeMappingToEvent(mapping)
+	if !writeModel.changed(eventMapping) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	err = c.pushAppendAndReduce(ctx, writeModel, instance.NewIDPAttributeMappingSetEvent(
+		ctx,
+		&instance.NewAggregate(instanceID).Aggregate,
+		idpID,
+		eventMapping,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// SetOrgIDPAttributeMapping sets the mapping of the claims or attributes of users of the organization IDP
+// to the fields and metadata of the ZITADEL user.
+func (c *Commands) SetOrgIDPAttributeMapping(ctx context.Context, resourceOwner, idpID string, mapping *domain.IDPAttributeMapping) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Eib6d", "Errors.ResourceOwnerMissing")
+	}
+	if mapping == nil || !mapping.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-aeN4t", "Errors.IDPConfig.AttributeMappingInvalid")
+	}
+	exists, err := ExistsOrgIDP(ctx, c.eventstore.Filter, idpID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-iePh8", "Errors.IDPConfig.NotExisting")
+	}
+	writeModel, err := c.idpAttributeMappingWriteModel(ctx, idpID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	eventMapping := attributeMappingToEvent(mapping)
+	if !writeModel.changed(eventMapping) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	err = c.pushAppendAndReduce(ctx, writeModel, org.NewIDPAttributeMappingSetEvent(
+		ctx,
+		&org.NewAggregate(resourceOwner).Aggregate,
+		idpID,
+		eventMapping,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// ldapProviderWithAdditionalAttributes creates the LDAP provider and additionally requests the attributes
+// used by the group and attribute mapping from the directory, since LDAP only returns the requested attributes.
+func (c *Commands) ldapProviderWithAdditionalAttributes(ctx context.Context, writeModel *AllIDPWriteModel, idpID, idpCallback string) (idp.Provider, error) {
+	provider, err := writeModel.ToProvider(idpCallback, c.idpConfigEncryption)
+	if err != nil {
+		return nil, err
+	}
+	ldapProvider, ok := provider.(*ldap.Provider)
+	if !ok {
+		return provider, nil
+	}
+	groupMapping, err := c.idpGroupMappingWriteModel(ctx, idpID, "")
+	if err != nil {
+		return nil, err
+	}
+	if groupMapping.GroupsAttribute != "" {
+		ldap.WithAdditionalAttributes(groupMapping.GroupsAttribute)(ldapProvider)
+	}
+	attributeMapping, err := c.idpAttributeMappingWriteModel(ctx, idpID, "")
+	if err != nil {
+		return nil, err
+	}
+	ldap.WithAdditionalAttributes(attributeMappingToDomain(attributeMapping.Mapping).Paths()...)(ldapProvider)
+	return ldapProvider, nil
+}
+
+// mapIDPUser applies the attribute mapping of the IDP to the information of the external user.
+func (c *Commands) mapIDPUser(ctx context.Context, idpID string, idpUser idp.User) (*domain.ExternalUser, error) {
+	writeModel, err := c.idpAttributeMappingWriteModel(ctx, idpID, "")
+	if err != nil {
+		return nil, err
+	}
+	return idpUserToExternalUser(idpID, idpUser, attributeMappingToDomain(writeModel.Mapping)), nil
+}
+
+func idpUserToExternalUser(idpID string, idpUser idp.User, mapping *domain.IDPAttributeMapping) *domain.ExternalUser {
+	externalUser := &domain.ExternalUser{
+		IDPConfigID:       idpID,
+		ExternalUserID:    idpUser.GetID(),
+		DisplayName:       idpUser.GetDisplayName(),
+		PreferredUsername: idpUser.GetPreferredUsername(),
+		FirstName:         idpUser.GetFirstName(),
+		LastName:          idpUser.GetLastName(),
+		NickName:          idpUser.GetNickname(),
+		Email:             idpUser.GetEmail(),
+		IsEmailVerified:   idpUser.IsEmailVerified(),
+		PreferredLanguage: idpUser.GetPreferredLanguage(),
+		Phone:             idpUser.GetPhone(),
+		IsPhoneVerified:   idpUser.IsPhoneVerified(),
+	}
+	mapping.Apply(externalUser, func(path string) []string {
+		return idp.Attribute(idpUser, path)
+	})
+	return externalUser
+}
+
+func (c *Commands) idpAttributeMappingWriteModel(ctx context.Context, idpID, resourceOwner string) (_ *IDPAttributeMappingWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewIDPAttributeMappingWriteModel(idpID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,149 @@
+package command
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// IDPAttributeMappingWriteModel contains the attribute mapping of an IDP,
+// which can either be defined on the instance or an organization.
+type IDPAttributeMappingWriteModel struct {
+	eventstore.WriteModel
+
+	ID      string
+	Mapping idp.AttributeMapping
+}
+
+func NewIDPAttributeMappingWriteModel(id, resourceOwner string) *IDPAttributeMappingWriteModel {
+	return &IDPAttributeMappingWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: resourceOwner,
+		},
+		ID: id,
+	}
+}
+
+func (wm *IDPAttributeMappingWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.IDPAttributeMappingSetEvent:
+			wm.WriteModel.AppendEvents(&e.AttributeMappingSetEvent)
+		case *org.IDPAttributeMappingSetEvent:
+			wm.WriteModel.AppendEvents(&e.AttributeMappingSetEvent)
+		case *instance.IDPRemovedEvent:
+			wm.WriteModel.AppendEvents(&e.RemovedEvent)
+		case *org.IDPRemovedEvent:
+			wm.WriteModel.AppendEvents(&e.RemovedEvent)
+		}
+	}
+}
+
+func (wm *IDPAttributeMappingWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.AttributeMappingSetEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.Mapping = e.AttributeMapping
+		case *idp.RemovedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.Mapping = idp.AttributeMapping{}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *IDPAttributeMappingWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPAttributeMappingSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPAttributeMappingSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+func (wm *IDPAttributeMappingWriteModel) changed(mapping idp.AttributeMapping) bool {
+	return wm.Mapping.FirstName != mapping.FirstName ||
+		wm.Mapping.LastName != mapping.LastName ||
+		wm.Mapping.DisplayName != mapping.DisplayName ||
+		wm.Mapping.NickName != mapping.NickName ||
+		wm.Mapping.PreferredUsername != mapping.PreferredUsername ||
+		wm.Mapping.Email != mapping.Email ||
+		wm.Mapping.EmailVerified != mapping.EmailVerified ||
+		wm.Mapping.Phone != mapping.Phone ||
+		wm.Mapping.PhoneVerified != mapping.PhoneVerified ||
+		wm.Mapping.PreferredLanguage != mapping.PreferredLanguage ||
+		!slices.Equal(wm.Mapping.Metadata, mapping.Metadata)
+}
+
+func attributeMappingToEvent(mapping *domain.IDPAttributeMapping) idp.AttributeMapping {
+	metadata := make([]idp.MetadataMapping, len(mapping.Metadata))
+	for i, entry := range mapping.Metadata {
+		metadata[i] = idp.MetadataMapping{
+			Key:        entry.Key,
+			Expression: entry.Expression,
+		}
+	}
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+	return idp.AttributeMapping{
+		FirstName:         mapping.FirstName,
+		LastName:          mapping.LastName,
+		DisplayName:       mapping.DisplayName,
+		NickName:          mapping.NickName,
+		PreferredUsername: mapping.PreferredUsername,
+		Email:             mapping.Email,
+		EmailVerified:     mapping.EmailVerified,
+		Phone:             mapping.Phone,
+		PhoneVerified:     mapping.PhoneVerified,
+		PreferredLanguage: mapping.PreferredLanguage,
+		Metadata:          metadata,
+	}
+}
+
+func attributeMappingToDomain(mapping idp.AttributeMapping) *domain.IDPAttributeMapping {
+	metadata := make([]*domain.IDPMetadataMapping, len(mapping.Metadata))
+	for i, entry := range mapping.Metadata {
+		metadata[i] = &domain.IDPMetadataMapping{
+			Key:        entry.Key,
+			Expression: entry.Expression,
+		}
+	}
+	return &domain.IDPAttributeMapping{
+		FirstName:         mapping.FirstName,
+		LastName:          mapping.LastName,
+		DisplayName:       mapping.DisplayName,
+		NickName:          mapping.NickName,
+		PreferredUsername: mapping.PreferredUsername,
+		Email:             mapping.Email,
+		EmailVerified:     mapping.EmailVerified,
+		Phone:             mapping.Phone,
+		PhoneVerified:     mapping.PhoneVerified,
+		PreferredLanguage: mapping.PreferredLanguage,
+		Metadata:          metadata,
+	}
+}
@@ -0,0 +1,261 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_SetInstanceIDPAttributeMapping(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx     context.Context
+		idpID   string
+		mapping *domain.IDPAttributeMapping
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid expression, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				mapping: &domain.IDPAttributeMapping{
+					DisplayName: "{given_name} {family_name",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				mapping: &domain.IDPAttributeMapping{
+					FirstName: "given_name",
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "set mapping, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent("idp1")),
+					),
+					expectFilter(),
+					expectPush(
+						instance.NewIDPAttributeMappingSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"idp1",
+							idp.AttributeMapping{
+								FirstName:   "given_name",
+								DisplayName: "{given_name} {family_name}",
+								Metadata: []idp.MetadataMapping{
+									{Key: "country", Expression: "address.country"},
+								},
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				mapping: &domain.IDPAttributeMapping{
+					FirstName:   "given_name",
+					DisplayName: "{given_name} {family_name}",
+					Metadata: []*domain.IDPMetadataMapping{
+						{Key: "country", Expression: "address.country"},
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "mapping unchanged, no push",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent("idp1")),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPAttributeMappingSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"idp1",
+								idp.AttributeMapping{
+									FirstName: "given_name",
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				mapping: &domain.IDPAttributeMapping{
+					FirstName: "given_name",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetInstanceIDPAttributeMapping(tt.args.ctx, tt.args.idpID, tt.args.mapping)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_SetOrgIDPAttributeMapping(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		idpID         string
+		mapping       *domain.IDPAttributeMapping
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing resource owner, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				idpID:   "idp1",
+				mapping: &domain.IDPAttributeMapping{},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp of other organization, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org2",
+				idpID:         "idp1",
+				mapping: &domain.IDPAttributeMapping{
+					Email: "mail",
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "set mapping, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(orgGitHubIDPAddedEvent("idp1", "org1")),
+					),
+					expectFilter(),
+					expectPush(
+						org.NewIDPAttributeMappingSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"idp1",
+							idp.AttributeMapping{
+								Email:         "mail",
+								EmailVerified: "mail_verified",
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				mapping: &domain.IDPAttributeMapping{
+					Email:         "mail",
+					EmailVerified: "mail_verified",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetOrgIDPAttributeMapping(tt.args.ctx, tt.args.resourceOwner, tt.args.idpID, tt.args.mapping)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
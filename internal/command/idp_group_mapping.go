@@ -0,0 +1,293 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetInstanceIDPGroupMapping sets the rules, which map groups of users of the instance IDP
+// to project roles and org memberships on every login.
+func (c *Commands) SetInstanceIDPGroupMapping(ctx context.Context, idpID string, mapping *domain.IDPGroupMapping) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	exists, err := ExistsInstanceIDP(ctx, c.eventstore.Filter, idpID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Aeb5u", "Errors.IDPConfig.NotExisting")
+	}
+	// an instance IDP might grant roles on projects of any organization
+	if err := c.checkIDPGroupMapping(ctx, mapping, ""); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.idpGroupMappingWriteModel(ctx, idpID, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	rules := groupMappingRulesToEvent(mapping.Rules)
+	if !writeModel.changed(mapping.GroupsAttribute, rules) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	err = c.pushAppendAndReduce(ctx, writeModel, instance.NewIDPGroupMappingSetEvent(
+		ctx,
+		&instance.NewAggregate(instanceID).Aggregate,
+		idpID,
+		mapping.GroupsAttribute,
+		rules,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// SetOrgIDPGroupMapping sets the rules, which map groups of users of the organization IDP
+// to project roles and org memberships on every login.
+func (c *Commands) SetOrgIDPGroupMapping(ctx context.Context, resourceOwner, idpID string, mapping *domain.IDPGroupMapping) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ohT6a", "Errors.ResourceOwnerMissing")
+	}
+	exists, err := ExistsOrgIDP(ctx, c.eventstore.Filter, idpID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Mee4o", "Errors.IDPConfig.NotExisting")
+	}
+	// an organization IDP must only grant roles on projects of the organization itself
+	if err := c.checkIDPGroupMapping(ctx, mapping, resourceOwner); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.idpGroupMappingWriteModel(ctx, idpID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	rules := groupMappingRulesToEvent(mapping.Rules)
+	if !writeModel.changed(mapping.GroupsAttribute, rules) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	err = c.pushAppendAndReduce(ctx, writeModel, org.NewIDPGroupMappingSetEvent(
+		ctx,
+		&org.NewAggregate(resourceOwner).Aggregate,
+		idpID,
+		mapping.GroupsAttribute,
+		rules,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) checkIDPGroupMapping(ctx context.Context, mapping *domain.IDPGroupMapping, projectResourceOwner string) error {
+	if mapping == nil || !mapping.IsValid() {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-ieN0b", "Errors.IDPConfig.GroupMappingInvalid")
+	}
+	if invalid := domain.CheckForInvalidRoles(mapping.OrgMemberRoles(), domain.OrgRolePrefix, c.zitadelRoles); len(invalid) > 0 {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Ra4ai", "Errors.Org.MemberInvalid")
+	}
+	for _, rule := range mapping.Rules {
+		if rule.ProjectID == "" {
+			continue
+		}
+		project := newIDPGroupMappingProjectReadModel(rule.ProjectID, projectResourceOwner)
+		if err := c.eventstore.FilterToQueryReducer(ctx, project); err != nil {
+			return err
+		}
+		if !project.exists() {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Eiph3", "Errors.Project.NotFound")
+		}
+		for _, key := range rule.RoleKeys {
+			if !slices.Contains(project.RoleKeys, key) {
+				return zerrors.ThrowPreconditionFailed(nil, "COMMAND-xo8Ae", "Errors.Project.Role.NotFound")
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Commands) idpGroupMappingWriteModel(ctx context.Context, idpID, resourceOwner string) (_ *IDPGroupMappingWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewIDPGroupMappingWriteModel(idpID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+// ApplyIDPGroupMapping applies the group mapping of the IDP to the user based on the groups provided by the idpUser:
+// roles of user grants and org memberships are added for matching groups and removed if they no longer match.
+// Only roles used in the mapping are touched, others (e.g. assigned manually) are kept.
+// User grants are only removed, if they were created by the mapping.
+// If the resourceOwner (organization) of the user is not known, it will be determined.
+func (c *Commands) ApplyIDPGroupMapping(ctx context.Context, idpID, userID, resourceOwner string, idpUser idp.User) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel, err := c.idpGroupMappingWriteModel(ctx, idpID, "")
+	if err != nil {
+		return err
+	}
+	mapping := writeModel.Mapping()
+	if len(mapping.Rules) == 0 {
+		return nil
+	}
+	if resourceOwner == "" {
+		user, err := c.userWriteModelByID(ctx, userID, "")
+		if err != nil {
+			return err
+		}
+		if !isUserStateExists(user.UserState) {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-ao9Ei", "Errors.User.NotFound")
+		}
+		resourceOwner = user.ResourceOwner
+	}
+	projectRoles, orgMemberRoles, manageOrgMember := mapping.Resolve(idp.Attribute(idpUser, mapping.GroupsAttribute))
+
+	cmds, err := c.userGrantsForGroupMapping(ctx, idpID, userID, mapping.ProjectRoleKeys(), projectRoles)
+	if err != nil {
+		return err
+	}
+	if manageOrgMember {
+		cmd, err := c.orgMemberForGroupMapping(ctx, resourceOwner, userID, mapping.OrgMemberRoles(), orgMemberRoles)
+		if err != nil {
+			return err
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	_, err = c.eventstore.Push(ctx, cmds...)
+	return err
+}
+
+// userGrantsForGroupMapping computes the (direct) user grants of the user on the projects of the mapping.
+// Roles, which are not managed by the mapping, are kept as they are.
+func (c *Commands) userGrantsForGroupMapping(ctx context.Context, idpID, userID string, managedRoles, projectRoles map[string][]string) ([]eventstore.Command, error) {
+	grantIDs := newUserGrantIDsReadModel(userID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, grantIDs); err != nil {
+		return nil, err
+	}
+	cmds := make([]eventstore.Command, 0, len(projectRoles))
+	handled := make(map[string]bool, len(projectRoles))
+	for _, grantID := range grantIDs.GrantIDs {
+		grant, err := c.userGrantWriteModelByID(ctx, grantID, "")
+		if err != nil {
+			return nil, err
+		}
+		if grant.State != domain.UserGrantStateActive && grant.State != domain.UserGrantStateInactive ||
+			grant.ProjectGrantID != "" {
+			continue
+		}
+		roleKeys, managed := projectRoles[grant.ProjectID]
+		if !managed || handled[grant.ProjectID] {
+			continue
+		}
+		handled[grant.ProjectID] = true
+		newRoleKeys := make([]string, 0, len(grant.RoleKeys)+len(roleKeys))
+		for _, key := range grant.RoleKeys {
+			if !slices.Contains(managedRoles[grant.ProjectID], key) {
+				newRoleKeys = append(newRoleKeys, key)
+			}
+		}
+		for _, key := range roleKeys {
+			if !slices.Contains(newRoleKeys, key) {
+				newRoleKeys = append(newRoleKeys, key)
+			}
+		}
+		aggregate := UserGrantAggregateFromWriteModel(&grant.WriteModel)
+		// nil roleKeys mean that no rule of the project matched
+		if roleKeys == nil && len(newRoleKeys) == 0 && grantIDs.managedByIDP(grantID) {
+			cmds = append(cmds, usergrant.NewUserGrantRemovedEvent(ctx, aggregate, grant.UserID, grant.ProjectID, grant.ProjectGrantID))
+			continue
+		}
+		if !sameRoles(grant.RoleKeys, newRoleKeys) {
+			cmds = append(cmds, usergrant.NewUserGrantChangedEvent(ctx, aggregate, newRoleKeys))
+		}
+	}
+	for projectID, roleKeys := range projectRoles {
+		if handled[projectID] || roleKeys == nil {
+			continue
+		}
+		project := newIDPGroupMappingProjectReadModel(projectID, "")
+		if err := c.eventstore.FilterToQueryReducer(ctx, project); err != nil {
+			return nil, err
+		}
+		// the project might have been removed since the mapping was set
+		if !project.exists() {
+			continue
+		}
+		grantID, err := c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+		aggregate := &usergrant.NewAggregate(grantID, project.ResourceOwner).Aggregate
+		cmds = append(cmds,
+			usergrant.NewUserGrantAddedEvent(ctx, aggregate, userID, projectID, "", roleKeys),
+			usergrant.NewUserGrantIDPManagedEvent(ctx, aggregate, userID, idpID),
+		)
+	}
+	return cmds, nil
+}
+
+// orgMemberForGroupMapping computes the membership of the user in its organization.
+// Roles, which are not managed by the mapping, are kept as they are.
+func (c *Commands) orgMemberForGroupMapping(ctx context.Context, orgID, userID string, managedRoles, roles []string) (eventstore.Command, error) {
+	member := NewOrgMemberWriteModel(orgID, userID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, member); err != nil {
+		return nil, err
+	}
+	exists := member.State == domain.MemberStateActive
+	newRoles := make([]string, 0, len(member.Roles)+len(roles))
+	if exists {
+		for _, role := range member.Roles {
+			if !slices.Contains(managedRoles, role) {
+				newRoles = append(newRoles, role)
+			}
+		}
+	}
+	for _, role := range roles {
+		if !slices.Contains(newRoles, role) {
+			newRoles = append(newRoles, role)
+		}
+	}
+	aggregate := &org.NewAggregate(orgID).Aggregate
+	switch {
+	case !exists && len(newRoles) == 0:
+		return nil, nil
+	case !exists:
+		return org.NewMemberAddedEvent(ctx, aggregate, userID, newRoles...), nil
+	case len(newRoles) == 0:
+		return org.NewMemberRemovedEvent(ctx, aggregate, userID), nil
+	case !sameRoles(member.Roles, newRoles):
+		return org.NewMemberChangedEvent(ctx, aggregate, userID, newRoles...), nil
+	}
+	return nil, nil
+}
+
+func sameRoles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, role := range a {
+		if !slices.Contains(b, role) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,244 @@
+package command
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+// IDPGroupMappingWriteModel contains the group mapping of an IDP,
+// which can either be defined on the instance or an organization.
+type IDPGroupMappingWriteModel struct {
+	eventstore.WriteModel
+
+	ID              string
+	GroupsAttribute string
+	Rules           []idp.GroupMappingRule
+}
+
+// NewIDPGroupMappingWriteModel creates the write model for the group mapping of the IDP.
+// The resourceOwner is optional, e.g. to retrieve the mapping of an IDP during the login,
+// where it is not known if it's an instance or organization IDP.
+func NewIDPGroupMappingWriteModel(id, resourceOwner string) *IDPGroupMappingWriteModel {
+	return &IDPGroupMappingWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: resourceOwner,
+		},
+		ID: id,
+	}
+}
+
+func (wm *IDPGroupMappingWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.IDPGroupMappingSetEvent:
+			wm.WriteModel.AppendEvents(&e.GroupMappingSetEvent)
+		case *org.IDPGroupMappingSetEvent:
+			wm.WriteModel.AppendEvents(&e.GroupMappingSetEvent)
+		case *instance.IDPRemovedEvent:
+			wm.WriteModel.AppendEvents(&e.RemovedEvent)
+		case *org.IDPRemovedEvent:
+			wm.WriteModel.AppendEvents(&e.RemovedEvent)
+		}
+	}
+}
+
+func (wm *IDPGroupMappingWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.GroupMappingSetEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.GroupsAttribute = e.GroupsAttribute
+			wm.Rules = e.Rules
+		case *idp.RemovedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.GroupsAttribute = ""
+			wm.Rules = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *IDPGroupMappingWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPGroupMappingSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPGroupMappingSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+func (wm *IDPGroupMappingWriteModel) Mapping() *domain.IDPGroupMapping {
+	return &domain.IDPGroupMapping{
+		GroupsAttribute: wm.GroupsAttribute,
+		Rules:           groupMappingRulesToDomain(wm.Rules),
+	}
+}
+
+func (wm *IDPGroupMappingWriteModel) changed(groupsAttribute string, rules []idp.GroupMappingRule) bool {
+	return wm.GroupsAttribute != groupsAttribute ||
+		!slices.EqualFunc(wm.Rules, rules, func(a, b idp.GroupMappingRule) bool {
+			return a.Group == b.Group &&
+				a.ProjectID == b.ProjectID &&
+				slices.Equal(a.RoleKeys, b.RoleKeys) &&
+				slices.Equal(a.OrgMemberRoles, b.OrgMemberRoles)
+		})
+}
+
+func groupMappingRulesToDomain(rules []idp.GroupMappingRule) []*domain.IDPGroupMappingRule {
+	domainRules := make([]*domain.IDPGroupMappingRule, len(rules))
+	for i, rule := range rules {
+		domainRules[i] = &domain.IDPGroupMappingRule{
+			Group:          rule.Group,
+			ProjectID:      rule.ProjectID,
+			RoleKeys:       rule.RoleKeys,
+			OrgMemberRoles: rule.OrgMemberRoles,
+		}
+	}
+	return domainRules
+}
+
+func groupMappingRulesToEvent(rules []*domain.IDPGroupMappingRule) []idp.GroupMappingRule {
+	eventRules := make([]idp.GroupMappingRule, len(rules))
+	for i, rule := range rules {
+		eventRules[i] = idp.GroupMappingRule{
+			Group:          rule.Group,
+			ProjectID:      rule.ProjectID,
+			RoleKeys:       rule.RoleKeys,
+			OrgMemberRoles: rule.OrgMemberRoles,
+		}
+	}
+	return eventRules
+}
+
+// idpGroupMappingProjectReadModel checks the existence of a project and its roles,
+// which are referenced in a group mapping.
+type idpGroupMappingProjectReadModel struct {
+	eventstore.WriteModel
+
+	RoleKeys []string
+	State    domain.ProjectState
+}
+
+func newIDPGroupMappingProjectReadModel(projectID, resourceOwner string) *idpGroupMappingProjectReadModel {
+	return &idpGroupMappingProjectReadModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (rm *idpGroupMappingProjectReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *project.ProjectAddedEvent:
+			rm.State = domain.ProjectStateActive
+		case *project.ProjectRemovedEvent:
+			rm.State = domain.ProjectStateRemoved
+		case *project.RoleAddedEvent:
+			rm.RoleKeys = append(rm.RoleKeys, e.Key)
+		case *project.RoleRemovedEvent:
+			rm.RoleKeys = slices.DeleteFunc(rm.RoleKeys, func(key string) bool {
+				return key == e.Key
+			})
+		}
+	}
+	return rm.WriteModel.Reduce()
+}
+
+func (rm *idpGroupMappingProjectReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			project.ProjectAddedType,
+			project.ProjectRemovedType,
+			project.RoleAddedType,
+			project.RoleRemovedType,
+		).
+		Builder()
+
+	if rm.ResourceOwner != "" {
+		query.ResourceOwner(rm.ResourceOwner)
+	}
+	return query
+}
+
+func (rm *idpGroupMappingProjectReadModel) exists() bool {
+	return rm.State != domain.ProjectStateUnspecified && rm.State != domain.ProjectStateRemoved
+}
+
+// userGrantIDsReadModel collects the ids of all user grants, which were ever added for the user,
+// and the ones created by the group mapping of an IDP.
+type userGrantIDsReadModel struct {
+	eventstore.WriteModel
+
+	UserID        string
+	GrantIDs      []string
+	IDPManagedIDs []string
+}
+
+func newUserGrantIDsReadModel(userID string) *userGrantIDsReadModel {
+	return &userGrantIDsReadModel{
+		UserID: userID,
+	}
+}
+
+func (rm *userGrantIDsReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *usergrant.UserGrantAddedEvent:
+			if e.UserID == rm.UserID {
+				rm.GrantIDs = append(rm.GrantIDs, e.Aggregate().ID)
+			}
+		case *usergrant.UserGrantIDPManagedEvent:
+			if e.UserID == rm.UserID {
+				rm.IDPManagedIDs = append(rm.IDPManagedIDs, e.Aggregate().ID)
+			}
+		}
+	}
+	return rm.WriteModel.Reduce()
+}
+
+func (rm *userGrantIDsReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(usergrant.AggregateType).
+		EventTypes(
+			usergrant.UserGrantAddedType,
+			usergrant.UserGrantIDPManagedType,
+		).
+		EventData(map[string]interface{}{"userId": rm.UserID}).
+		Builder()
+}
+
+func (rm *userGrantIDsReadModel) managedByIDP(grantID string) bool {
+	return slices.Contains(rm.IDPManagedIDs, grantID)
+}
@@ -0,0 +1,655 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_SetInstanceIDPGroupMapping(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx     context.Context
+		idpID   string
+		mapping *domain.IDPGroupMapping
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "idp not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				mapping: &domain.IDPGroupMapping{
+					GroupsAttribute: "groups",
+					Rules: []*domain.IDPGroupMappingRule{
+						{Group: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "invalid mapping, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent("idp1")),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				mapping: &domain.IDPGroupMapping{
+					Rules: []*domain.IDPGroupMappingRule{
+						{Group: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project not existing, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent("idp1")),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				mapping: &domain.IDPGroupMapping{
+					GroupsAttribute: "groups",
+					Rules: []*domain.IDPGroupMappingRule{
+						{Group: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "role not existing, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent("idp1")),
+					),
+					expectFilter(
+						eventFromEventPusher(projectAddedEvent("project1", "org1")),
+						eventFromEventPusher(projectRoleAddedEvent("project1", "org1", "viewer")),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				mapping: &domain.IDPGroupMapping{
+					GroupsAttribute: "groups",
+					Rules: []*domain.IDPGroupMappingRule{
+						{Group: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "invalid org member role, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent("idp1")),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				mapping: &domain.IDPGroupMapping{
+					GroupsAttribute: "groups",
+					Rules: []*domain.IDPGroupMappingRule{
+						{Group: "admins", OrgMemberRoles: []string{"IAM_OWNER"}},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "set mapping, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent("idp1")),
+					),
+					expectFilter(
+						eventFromEventPusher(projectAddedEvent("project1", "org1")),
+						eventFromEventPusher(projectRoleAddedEvent("project1", "org1", "admin")),
+					),
+					expectFilter(),
+					expectPush(
+						instance.NewIDPGroupMappingSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"idp1",
+							"groups",
+							[]idp.GroupMappingRule{
+								{Group: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}, OrgMemberRoles: []string{"ORG_OWNER"}},
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				mapping: &domain.IDPGroupMapping{
+					GroupsAttribute: "groups",
+					Rules: []*domain.IDPGroupMappingRule{
+						{Group: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}, OrgMemberRoles: []string{"ORG_OWNER"}},
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "mapping unchanged, no push",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent("idp1")),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPGroupMappingSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"idp1",
+								"groups",
+								[]idp.GroupMappingRule{
+									{Group: "admins", OrgMemberRoles: []string{"ORG_OWNER"}},
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				mapping: &domain.IDPGroupMapping{
+					GroupsAttribute: "groups",
+					Rules: []*domain.IDPGroupMappingRule{
+						{Group: "admins", OrgMemberRoles: []string{"ORG_OWNER"}},
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore(t),
+				zitadelRoles: []authz.RoleMapping{{Role: "ORG_OWNER"}},
+			}
+			got, err := c.SetInstanceIDPGroupMapping(tt.args.ctx, tt.args.idpID, tt.args.mapping)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_SetOrgIDPGroupMapping(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		idpID         string
+		mapping       *domain.IDPGroupMapping
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing resource owner, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project of other organization, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(orgGitHubIDPAddedEvent("idp1", "org1")),
+					),
+					// the project is filtered by the resource owner of the idp
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				mapping: &domain.IDPGroupMapping{
+					GroupsAttribute: "groups",
+					Rules: []*domain.IDPGroupMappingRule{
+						{Group: "admins", ProjectID: "project2", RoleKeys: []string{"admin"}},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set mapping, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(orgGitHubIDPAddedEvent("idp1", "org1")),
+					),
+					expectFilter(
+						eventFromEventPusher(projectAddedEvent("project1", "org1")),
+					),
+					expectFilter(),
+					expectPush(
+						org.NewIDPGroupMappingSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"idp1",
+							"groups",
+							[]idp.GroupMappingRule{
+								{Group: "users", ProjectID: "project1"},
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				mapping: &domain.IDPGroupMapping{
+					GroupsAttribute: "groups",
+					Rules: []*domain.IDPGroupMappingRule{
+						{Group: "users", ProjectID: "project1"},
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore(t),
+				zitadelRoles: []authz.RoleMapping{{Role: "ORG_OWNER"}},
+			}
+			got, err := c.SetOrgIDPGroupMapping(tt.args.ctx, tt.args.resourceOwner, tt.args.idpID, tt.args.mapping)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_ApplyIDPGroupMapping(t *testing.T) {
+	groupMappingSet := func(rules ...idp.GroupMappingRule) *instance.IDPGroupMappingSetEvent {
+		return instance.NewIDPGroupMappingSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+			"idp1",
+			"memberOf",
+			rules,
+		)
+	}
+	userGrantAdded := func(grantID, projectID string, roleKeys ...string) *usergrant.UserGrantAddedEvent {
+		return usergrant.NewUserGrantAddedEvent(context.Background(), &usergrant.NewAggregate(grantID, "org1").Aggregate,
+			"user1",
+			projectID,
+			"",
+			roleKeys,
+		)
+	}
+	userGrantIDPManaged := func(grantID string) *usergrant.UserGrantIDPManagedEvent {
+		return usergrant.NewUserGrantIDPManagedEvent(context.Background(), &usergrant.NewAggregate(grantID, "org1").Aggregate,
+			"user1",
+			"idp1",
+		)
+	}
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		resourceOwner string
+		groups        []string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		err    func(error) bool
+	}{
+		{
+			name: "no mapping, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				resourceOwner: "org1",
+				groups:        []string{"admins"},
+			},
+		},
+		{
+			name: "user not existing, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(groupMappingSet(idp.GroupMappingRule{Group: "admins", OrgMemberRoles: []string{"ORG_OWNER"}})),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				groups: []string{"admins"},
+			},
+			err: zerrors.IsPreconditionFailed,
+		},
+		{
+			name: "add user grant and org membership, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(groupMappingSet(
+							idp.GroupMappingRule{Group: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}, OrgMemberRoles: []string{"ORG_OWNER"}},
+						)),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"username", "firstname", "lastname", "", "", language.English, domain.GenderUnspecified, "email@test.ch", true,
+							),
+						),
+					),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(projectAddedEvent("project1", "org2")),
+					),
+					expectFilter(),
+					expectPush(
+						usergrant.NewUserGrantAddedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org2").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"admin"},
+						),
+						usergrant.NewUserGrantIDPManagedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org2").Aggregate,
+							"user1",
+							"idp1",
+						),
+						org.NewMemberAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"user1",
+							"ORG_OWNER",
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "grant1"),
+			},
+			args: args{
+				groups: []string{"admins"},
+			},
+		},
+		{
+			name: "change and remove user grants, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(groupMappingSet(
+							idp.GroupMappingRule{Group: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}},
+							idp.GroupMappingRule{Group: "users", ProjectID: "project1", RoleKeys: []string{"viewer"}},
+							idp.GroupMappingRule{Group: "developers", ProjectID: "project2"},
+						)),
+					),
+					expectFilter(
+						eventFromEventPusher(userGrantAdded("grant1", "project1", "viewer")),
+						eventFromEventPusher(userGrantAdded("grant2", "project2")),
+						eventFromEventPusher(userGrantIDPManaged("grant2")),
+						eventFromEventPusher(userGrantAdded("grant3", "project3", "other")),
+					),
+					expectFilter(
+						eventFromEventPusher(userGrantAdded("grant1", "project1", "viewer")),
+					),
+					expectFilter(
+						eventFromEventPusher(userGrantAdded("grant2", "project2")),
+					),
+					expectFilter(
+						eventFromEventPusher(userGrantAdded("grant3", "project3", "other")),
+					),
+					expectPush(
+						usergrant.NewUserGrantChangedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org1").Aggregate,
+							[]string{"admin", "viewer"},
+						),
+						usergrant.NewUserGrantRemovedEvent(context.Background(), &usergrant.NewAggregate("grant2", "org1").Aggregate,
+							"user1",
+							"project2",
+							"",
+						),
+					),
+				),
+			},
+			args: args{
+				resourceOwner: "org1",
+				groups:        []string{"admins", "users"},
+			},
+		},
+		{
+			name: "keep manually assigned roles and grants, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(groupMappingSet(
+							idp.GroupMappingRule{Group: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}},
+							idp.GroupMappingRule{Group: "admins", ProjectID: "project2", RoleKeys: []string{"admin"}},
+						)),
+					),
+					expectFilter(
+						eventFromEventPusher(userGrantAdded("grant1", "project1", "admin", "manual")),
+						eventFromEventPusher(userGrantAdded("grant2", "project2", "admin")),
+					),
+					expectFilter(
+						eventFromEventPusher(userGrantAdded("grant1", "project1", "admin", "manual")),
+					),
+					expectFilter(
+						eventFromEventPusher(userGrantAdded("grant2", "project2", "admin")),
+					),
+					expectPush(
+						usergrant.NewUserGrantChangedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org1").Aggregate,
+							[]string{"manual"},
+						),
+						usergrant.NewUserGrantChangedEvent(context.Background(), &usergrant.NewAggregate("grant2", "org1").Aggregate,
+							[]string{},
+						),
+					),
+				),
+			},
+			args: args{
+				resourceOwner: "org1",
+				groups:        []string{"users"},
+			},
+		},
+		{
+			name: "add mapped role to manually assigned grant, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(groupMappingSet(
+							idp.GroupMappingRule{Group: "admins", ProjectID: "project1", RoleKeys: []string{"admin"}},
+						)),
+					),
+					expectFilter(
+						eventFromEventPusher(userGrantAdded("grant1", "project1", "manual")),
+					),
+					expectFilter(
+						eventFromEventPusher(userGrantAdded("grant1", "project1", "manual")),
+					),
+					expectPush(
+						usergrant.NewUserGrantChangedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org1").Aggregate,
+							[]string{"manual", "admin"},
+						),
+					),
+				),
+			},
+			args: args{
+				resourceOwner: "org1",
+				groups:        []string{"admins"},
+			},
+		},
+		{
+			name: "remove managed org member roles only, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(groupMappingSet(idp.GroupMappingRule{Group: "admins", OrgMemberRoles: []string{"ORG_OWNER"}})),
+					),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMemberAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"user1",
+								"ORG_OWNER", "ORG_USER_MANAGER",
+							),
+						),
+					),
+					expectPush(
+						org.NewMemberChangedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"user1",
+							"ORG_USER_MANAGER",
+						),
+					),
+				),
+			},
+			args: args{
+				resourceOwner: "org1",
+				groups:        []string{"users"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			idpUser := &ldap.User{
+				ID:         "id",
+				Attributes: map[string][]string{"memberOf": tt.args.groups},
+			}
+			err := c.ApplyIDPGroupMapping(authz.WithInstanceID(context.Background(), "instance1"), "idp1", "user1", tt.args.resourceOwner, idpUser)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.err != nil && !tt.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func instanceGitHubIDPAddedEvent(id string) *instance.GitHubIDPAddedEvent {
+	return instance.NewGitHubIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+		id, "name", "clientID", nil, nil, idp.Options{},
+	)
+}
+
+func orgGitHubIDPAddedEvent(id, orgID string) *org.GitHubIDPAddedEvent {
+	return org.NewGitHubIDPAddedEvent(context.Background(), &org.NewAggregate(orgID).Aggregate,
+		id, "name", "clientID", nil, nil, idp.Options{},
+	)
+}
+
+func projectAddedEvent(projectID, resourceOwner string) *project.ProjectAddedEvent {
+	return project.NewProjectAddedEvent(context.Background(), &project.NewAggregate(projectID, resourceOwner).Aggregate,
+		"project", false, false, false, domain.PrivateLabelingSettingUnspecified,
+	)
+}
+
+func projectRoleAddedEvent(projectID, resourceOwner, key string) *project.RoleAddedEvent {
+	return project.NewRoleAddedEvent(context.Background(), &project.NewAggregate(projectID, resourceOwner).Aggregate,
+		key, key, "",
+	)
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idpintent"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetInstanceIDPInitiatedLogin enables or disables unsolicited responses (IdP-initiated login) of a SAML IDP of the instance.
+func (c *Commands) SetInstanceIDPInitiatedLogin(ctx context.Context, idpID string, login *domain.IDPInitiatedLogin) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if err := login.Validate(); err != nil {
+		return nil, err
+	}
+	return c.setIDPInitiatedLogin(ctx, idpID, instanceID, login, instance.NewIDPInitiatedLoginSetEvent(
+		ctx,
+		&instance.NewAggregate(instanceID).Aggregate,
+		idpID,
+		login.Enabled,
+		login.DefaultRedirectURI,
+	))
+}
+
+// SetOrgIDPInitiatedLogin enables or disables unsolicited responses (IdP-initiated login) of a SAML IDP of the organization.
+func (c *Commands) SetOrgIDPInitiatedLogin(ctx context.Context, resourceOwner, idpID string, login *domain.IDPInitiatedLogin) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-eiP5a", "Errors.ResourceOwnerMissing")
+	}
+	if err := login.Validate(); err != nil {
+		return nil, err
+	}
+	return c.setIDPInitiatedLogin(ctx, idpID, resourceOwner, login, org.NewIDPInitiatedLoginSetEvent(
+		ctx,
+		&org.NewAggregate(resourceOwner).Aggregate,
+		idpID,
+		login.Enabled,
+		login.DefaultRedirectURI,
+	))
+}
+
+func (c *Commands) setIDPInitiatedLogin(ctx context.Context, idpID, resourceOwner string, login *domain.IDPInitiatedLogin, event eventstore.Command) (*domain.ObjectDetails, error) {
+	writeModel := NewIDPInitiatedLoginWriteModel(idpID, resourceOwner)
+	err := c.setIDPSetting(ctx, idpID, resourceOwner, checkIDPInitiatedLoginIDPType, writeModel,
+		func() bool { return writeModel.changed(login) },
+		event,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func checkIDPInitiatedLoginIDPType(idpType domain.IDPType) error {
+	if idpType != domain.IDPTypeSAML {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-ahZ8e", "Errors.IDPConfig.IDPInitiatedLoginNotSupported")
+	}
+	return nil
+}
+
+// CreateIDPInitiatedSAMLIntent creates an intent for an unsolicited response (IdP-initiated login) of a SAML IDP,
+// which is handed to the redirectURI on success and failure.
+// The assertion can only be used for a single intent, which prevents replays of the response.
+func (c *Commands) CreateIDPInitiatedSAMLIntent(ctx context.Context, idpID, assertionID string, redirectURI *url.URL) (*IDPIntentWriteModel, error) {
+	if assertionID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Lae0e", "Errors.Intent.ResponseInvalid")
+	}
+	// the intent belongs to the organization (or instance) of the IDP, as there is no requesting organization
+	idpWriteModel := NewIDPTypeWriteModel(idpID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, idpWriteModel); err != nil {
+		return nil, err
+	}
+	if !idpWriteModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ohk3e", "Errors.IDPConfig.NotExisting")
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	writeModel := NewIDPIntentWriteModel(id, idpWriteModel.ResourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.prepareCreateIntent(writeModel, idpID, redirectURI.String(), redirectURI.String()))
+	if err != nil {
+		return nil, err
+	}
+	cmds = append(cmds, idpintent.NewSAMLUnsolicitedEvent(ctx, writeModel.aggregate, idpID, assertionID))
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
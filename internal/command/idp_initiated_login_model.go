@@ -0,0 +1,91 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// IDPInitiatedLoginWriteModel contains the IdP-initiated login setting of a SAML IDP,
+// which can either be defined on the instance or an organization.
+type IDPInitiatedLoginWriteModel struct {
+	eventstore.WriteModel
+
+	ID                 string
+	Enabled            bool
+	DefaultRedirectURI string
+}
+
+func NewIDPInitiatedLoginWriteModel(id, resourceOwner string) *IDPInitiatedLoginWriteModel {
+	return &IDPInitiatedLoginWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: resourceOwner,
+		},
+		ID: id,
+	}
+}
+
+func (wm *IDPInitiatedLoginWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.IDPInitiatedLoginSetEvent:
+			wm.WriteModel.AppendEvents(&e.IDPInitiatedLoginSetEvent)
+		case *org.IDPInitiatedLoginSetEvent:
+			wm.WriteModel.AppendEvents(&e.IDPInitiatedLoginSetEvent)
+		case *instance.IDPRemovedEvent:
+			wm.WriteModel.AppendEvents(&e.RemovedEvent)
+		case *org.IDPRemovedEvent:
+			wm.WriteModel.AppendEvents(&e.RemovedEvent)
+		}
+	}
+}
+
+func (wm *IDPInitiatedLoginWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.IDPInitiatedLoginSetEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.Enabled = e.Enabled
+			wm.DefaultRedirectURI = e.DefaultRedirectURI
+		case *idp.RemovedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.Enabled = false
+			wm.DefaultRedirectURI = ""
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *IDPInitiatedLoginWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPInitiatedLoginSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPInitiatedLoginSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+func (wm *IDPInitiatedLoginWriteModel) changed(login *domain.IDPInitiatedLogin) bool {
+	return wm.Enabled != login.Enabled || wm.DefaultRedirectURI != login.DefaultRedirectURI
+}
@@ -0,0 +1,276 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	rep_idp "github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_SetInstanceIDPInitiatedLogin(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		idpID string
+		login *domain.IDPInitiatedLogin
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid redirect uri, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				login: &domain.IDPInitiatedLogin{Enabled: true, DefaultRedirectURI: "/login"},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				login: &domain.IDPInitiatedLogin{Enabled: true, DefaultRedirectURI: "https://login.example.com"},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "github idp, precondition failed error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent("idp1")),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				login: &domain.IDPInitiatedLogin{Enabled: true, DefaultRedirectURI: "https://login.example.com"},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "enable, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent("idp1")),
+					),
+					expectFilter(),
+					expectPush(
+						instance.NewIDPInitiatedLoginSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"idp1",
+							true,
+							"https://login.example.com",
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				login: &domain.IDPInitiatedLogin{Enabled: true, DefaultRedirectURI: "https://login.example.com"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "unchanged, no push",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceSAMLIDPAddedEvent("idp1")),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPInitiatedLoginSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"idp1",
+								true,
+								"https://login.example.com",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				login: &domain.IDPInitiatedLogin{Enabled: true, DefaultRedirectURI: "https://login.example.com"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetInstanceIDPInitiatedLogin(tt.args.ctx, tt.args.idpID, tt.args.login)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_SetOrgIDPInitiatedLogin(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		idpID         string
+		login         *domain.IDPInitiatedLogin
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing resource owner, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				login: &domain.IDPInitiatedLogin{},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp of other organization, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(orgSAMLIDPAddedEvent("idp1", "org2")),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				login:         &domain.IDPInitiatedLogin{Enabled: true, DefaultRedirectURI: "https://login.example.com"},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "disable, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(orgSAMLIDPAddedEvent("idp1", "org1")),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewIDPInitiatedLoginSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"idp1",
+								true,
+								"https://login.example.com",
+							),
+						),
+					),
+					expectPush(
+						org.NewIDPInitiatedLoginSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"idp1",
+							false,
+							"",
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				login:         &domain.IDPInitiatedLogin{},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetOrgIDPInitiatedLogin(tt.args.ctx, tt.args.resourceOwner, tt.args.idpID, tt.args.login)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func instanceSAMLIDPAddedEvent(id string) *instance.SAMLIDPAddedEvent {
+	return instance.NewSAMLIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+		id, "name", []byte("metadata"), nil, nil, "", false, rep_idp.Options{},
+	)
+}
+
+func orgSAMLIDPAddedEvent(id, orgID string) *org.SAMLIDPAddedEvent {
+	return org.NewSAMLIDPAddedEvent(context.Background(), &org.NewAggregate(orgID).Aggregate,
+		id, "name", []byte("metadata"), nil, nil, "", false, rep_idp.Options{},
+	)
+}
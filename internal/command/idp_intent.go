@@ -84,6 +84,9 @@ func (c *Commands) GetProvider(ctx context.Context, idpID string, idpCallback st
 	if err != nil {
 		return nil, err
 	}
+	if writeModel.IDPType == domain.IDPTypeLDAP {
+		return c.ldapProviderWithAdditionalAttributes(ctx, writeModel, idpID, idpCallback)
+	}
 	if writeModel.IDPType != domain.IDPTypeSAML {
 		return writeModel.ToProvider(idpCallback, c.idpConfigEncryption)
 	}
@@ -164,10 +167,15 @@ func (c *Commands) SucceedIDPIntent(ctx context.Context, writeModel *IDPIntentWr
 	if err != nil {
 		return "", err
 	}
+	mappedUser, err := c.mappedIntentUser(ctx, writeModel.IDPID, idpUser)
+	if err != nil {
+		return "", err
+	}
 	cmd := idpintent.NewSucceededEvent(
 		ctx,
 		&idpintent.NewAggregate(writeModel.AggregateID, writeModel.ResourceOwner).Aggregate,
 		idpInfo,
+		mappedUser,
 		idpUser.GetID(),
 		idpUser.GetPreferredUsername(),
 		userID,
@@ -190,6 +198,10 @@ func (c *Commands) SucceedSAMLIDPIntent(ctx context.Context, writeModel *IDPInte
 	if err != nil {
 		return "", err
 	}
+	mappedUser, err := c.mappedIntentUser(ctx, writeModel.IDPID, idpUser)
+	if err != nil {
+		return "", err
+	}
 	assertionData, err := xml.Marshal(assertion)
 	if err != nil {
 		return "", err
@@ -202,6 +214,7 @@ func (c *Commands) SucceedSAMLIDPIntent(ctx context.Context, writeModel *IDPInte
 		ctx,
 		&idpintent.NewAggregate(writeModel.AggregateID, writeModel.ResourceOwner).Aggregate,
 		idpInfo,
+		mappedUser,
 		idpUser.GetID(),
 		idpUser.GetPreferredUsername(),
 		userID,
@@ -239,10 +252,15 @@ func (c *Commands) SucceedLDAPIDPIntent(ctx context.Context, writeModel *IDPInte
 	if err != nil {
 		return "", err
 	}
+	mappedUser, err := c.mappedIntentUser(ctx, writeModel.IDPID, idpUser)
+	if err != nil {
+		return "", err
+	}
 	cmd := idpintent.NewLDAPSucceededEvent(
 		ctx,
 		&idpintent.NewAggregate(writeModel.AggregateID, writeModel.ResourceOwner).Aggregate,
 		idpInfo,
+		mappedUser,
 		idpUser.GetID(),
 		idpUser.GetPreferredUsername(),
 		userID,
@@ -255,6 +273,36 @@ func (c *Commands) SucceedLDAPIDPIntent(ctx context.Context, writeModel *IDPInte
 	return token, nil
 }
 
+// mappedIntentUser returns the user of the identity provider with the attribute mapping of the IDP applied,
+// so it can be used to create or update the ZITADEL user
+func (c *Commands) mappedIntentUser(ctx context.Context, idpID string, idpUser idp.User) (*idpintent.MappedUser, error) {
+	externalUser, err := c.mapIDPUser(ctx, idpID, idpUser)
+	if err != nil {
+		return nil, err
+	}
+	mappedUser := &idpintent.MappedUser{
+		Username:      externalUser.PreferredUsername,
+		FirstName:     externalUser.FirstName,
+		LastName:      externalUser.LastName,
+		NickName:      externalUser.NickName,
+		DisplayName:   externalUser.DisplayName,
+		Email:         string(externalUser.Email),
+		EmailVerified: externalUser.IsEmailVerified,
+		Phone:         string(externalUser.Phone),
+		PhoneVerified: externalUser.IsPhoneVerified,
+	}
+	if !externalUser.PreferredLanguage.IsRoot() {
+		mappedUser.PreferredLanguage = externalUser.PreferredLanguage.String()
+	}
+	for _, metadata := range externalUser.Metadatas {
+		mappedUser.Metadata = append(mappedUser.Metadata, &idpintent.MappedMetadata{
+			Key:   metadata.Key,
+			Value: metadata.Value,
+		})
+	}
+	return mappedUser, nil
+}
+
 func (c *Commands) FailIDPIntent(ctx context.Context, writeModel *IDPIntentWriteModel, reason string) error {
 	cmd := idpintent.NewFailedEvent(
 		ctx,
@@ -276,24 +324,31 @@ func (c *Commands) GetIntentWriteModel(ctx context.Context, id, resourceOwner st
 
 // tokensForSucceededIDPIntent extracts the oidc.Tokens if available (and encrypts the access_token) for the succeeded event payload
 func tokensForSucceededIDPIntent(session idp.Session, encryptionAlg crypto.EncryptionAlgorithm) (*crypto.CryptoValue, string, error) {
-	var tokens *oidc.Tokens[*oidc.IDTokenClaims]
+	tokens := idpSessionTokens(session)
+	if tokens == nil {
+		return nil, "", nil
+	}
+	if tokens.Token == nil || tokens.AccessToken == "" {
+		return nil, tokens.IDToken, nil
+	}
+	accessToken, err := crypto.Encrypt([]byte(tokens.AccessToken), encryptionAlg)
+	return accessToken, tokens.IDToken, err
+}
+
+// idpSessionTokens returns the oidc.Tokens of the session, nil if the provider does not issue any
+func idpSessionTokens(session idp.Session) *oidc.Tokens[*oidc.IDTokenClaims] {
 	switch s := session.(type) {
 	case *oauth.Session:
-		tokens = s.Tokens
+		return s.Tokens
 	case *openid.Session:
-		tokens = s.Tokens
+		return s.Tokens
 	case *jwt.Session:
-		tokens = s.Tokens
+		return s.Tokens
 	case *azuread.Session:
-		tokens = s.Tokens
+		return s.Tokens
 	case *apple.Session:
-		tokens = s.Tokens
+		return s.Tokens
 	default:
-		return nil, "", nil
-	}
-	if tokens.Token == nil || tokens.AccessToken == "" {
-		return nil, tokens.IDToken, nil
+		return nil
 	}
-	accessToken, err := crypto.Encrypt([]byte(tokens.AccessToken), encryptionAlg)
-	return accessToken, tokens.IDToken, err
 }
@@ -664,12 +664,16 @@ func TestCommands_SucceedIDPIntent(t *testing.T) {
 			fields{
 				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 				eventstore: eventstoreExpect(t,
+					expectFilter(),
 					expectPush(
 						func() eventstore.Command {
 							event := idpintent.NewSucceededEvent(
 								context.Background(),
 								&idpintent.NewAggregate("id", "ro").Aggregate,
 								[]byte(`{"sub":"id","preferred_username":"username"}`),
+								&idpintent.MappedUser{
+									Username: "username",
+								},
 								"id",
 								"username",
 								"",
@@ -708,6 +712,87 @@ func TestCommands_SucceedIDPIntent(t *testing.T) {
 				token: "aWQ",
 			},
 		},
+		{
+			"push with attribute mapping",
+			fields{
+				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPAttributeMappingSetEvent(
+								context.Background(),
+								&instance.NewAggregate("instance").Aggregate,
+								"idp",
+								rep_idp.AttributeMapping{
+									FirstName:     "{given_name}",
+									Email:         "preferred_username",
+									EmailVerified: "email_verified",
+									Metadata: []rep_idp.MetadataMapping{
+										{Key: "country", Expression: "address.country"},
+									},
+								},
+							),
+						),
+					),
+					expectPush(
+						func() eventstore.Command {
+							event := idpintent.NewSucceededEvent(
+								context.Background(),
+								&idpintent.NewAggregate("id", "ro").Aggregate,
+								[]byte(`{"sub":"id","given_name":"given","preferred_username":"user@example.com","address":{"country":"CH"}}`),
+								&idpintent.MappedUser{
+									Username:  "user@example.com",
+									FirstName: "given",
+									Email:     "user@example.com",
+									Metadata: []*idpintent.MappedMetadata{
+										{Key: "country", Value: []byte("CH")},
+									},
+								},
+								"id",
+								"user@example.com",
+								"",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("accessToken"),
+								},
+								"",
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				writeModel: func() *IDPIntentWriteModel {
+					writeModel := NewIDPIntentWriteModel("id", "ro")
+					writeModel.IDPID = "idp"
+					return writeModel
+				}(),
+				idpSession: &openid.Session{
+					Tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+						Token: &oauth2.Token{
+							AccessToken: "accessToken",
+						},
+					},
+				},
+				idpUser: openid.NewUser(&oidc.UserInfo{
+					Subject: "id",
+					UserInfoProfile: oidc.UserInfoProfile{
+						GivenName:         "given",
+						PreferredUsername: "user@example.com",
+					},
+					Address: &oidc.UserInfoAddress{
+						Country: "CH",
+					},
+				}),
+			},
+			res{
+				token: "aWQ",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -766,11 +851,15 @@ func TestCommands_SucceedSAMLIDPIntent(t *testing.T) {
 			fields{
 				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 				eventstore: eventstoreExpect(t,
+					expectFilter(),
 					expectPush(
 						idpintent.NewSAMLSucceededEvent(
 							context.Background(),
 							&idpintent.NewAggregate("id", "ro").Aggregate,
 							[]byte(`{"sub":"id","preferred_username":"username"}`),
+							&idpintent.MappedUser{
+								Username: "username",
+							},
 							"id",
 							"username",
 							"",
@@ -804,11 +893,15 @@ func TestCommands_SucceedSAMLIDPIntent(t *testing.T) {
 			fields{
 				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 				eventstore: eventstoreExpect(t,
+					expectFilter(),
 					expectPush(
 						idpintent.NewSAMLSucceededEvent(
 							context.Background(),
 							&idpintent.NewAggregate("id", "ro").Aggregate,
 							[]byte(`{"sub":"id","preferred_username":"username"}`),
+							&idpintent.MappedUser{
+								Username: "username",
+							},
 							"id",
 							"username",
 							"user",
@@ -947,11 +1040,15 @@ func TestCommands_SucceedLDAPIDPIntent(t *testing.T) {
 			fields{
 				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 				eventstore: eventstoreExpect(t,
+					expectFilter(),
 					expectPush(
 						idpintent.NewLDAPSucceededEvent(
 							context.Background(),
 							&idpintent.NewAggregate("id", "ro").Aggregate,
 							[]byte(`{"id":"id","preferredUsername":"username","preferredLanguage":"und"}`),
+							&idpintent.MappedUser{
+								Username: "username",
+							},
 							"id",
 							"username",
 							"",
@@ -0,0 +1,360 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	repo_idp "github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ldapSyncNoUsersError is reported if the directory did not return any user at all,
+// which is most likely caused by a misconfiguration and must not deactivate all linked users.
+const ldapSyncNoUsersError = "no users found in the directory, deactivation of linked users skipped"
+
+// SetInstanceIDPLDAPSync sets the periodic synchronization of the users of the instance LDAP IDP.
+func (c *Commands) SetInstanceIDPLDAPSync(ctx context.Context, idpID string, sync *domain.IDPLDAPSync) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if err := checkIDPLDAPSync(sync); err != nil {
+		return nil, err
+	}
+	return c.setIDPLDAPSync(ctx, idpID, instanceID, sync, instance.NewIDPLDAPSyncSetEvent(
+		ctx,
+		&instance.NewAggregate(instanceID).Aggregate,
+		idpID,
+		ldapSyncToEvent(sync),
+	))
+}
+
+// SetOrgIDPLDAPSync sets the periodic synchronization of the users of the organization LDAP IDP.
+func (c *Commands) SetOrgIDPLDAPSync(ctx context.Context, resourceOwner, idpID string, sync *domain.IDPLDAPSync) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Jee0k", "Errors.ResourceOwnerMissing")
+	}
+	if err := checkIDPLDAPSync(sync); err != nil {
+		return nil, err
+	}
+	return c.setIDPLDAPSync(ctx, idpID, resourceOwner, sync, org.NewIDPLDAPSyncSetEvent(
+		ctx,
+		&org.NewAggregate(resourceOwner).Aggregate,
+		idpID,
+		ldapSyncToEvent(sync),
+	))
+}
+
+func (c *Commands) setIDPLDAPSync(ctx context.Context, idpID, resourceOwner string, sync *domain.IDPLDAPSync, event eventstore.Command) (*domain.ObjectDetails, error) {
+	writeModel := NewIDPLDAPSyncWriteModel(idpID, resourceOwner)
+	err := c.setIDPSetting(ctx, idpID, resourceOwner, checkLDAPIDPType, writeModel,
+		func() bool { return writeModel.Sync != ldapSyncToEvent(sync) },
+		event,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func checkIDPLDAPSync(sync *domain.IDPLDAPSync) error {
+	if sync == nil || !sync.IsValid() || sync.Filter != "" && !ldap.IsValidFilter(sync.Filter) {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-ahX3o", "Errors.IDPConfig.LDAPSyncInvalid")
+	}
+	return nil
+}
+
+func checkLDAPIDPType(idpType domain.IDPType) error {
+	if idpType != domain.IDPTypeLDAP {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohm1e", "Errors.IDPConfig.LDAPSyncNotSupported")
+	}
+	return nil
+}
+
+func (c *Commands) idpLDAPSyncWriteModel(ctx context.Context, idpID, resourceOwner string) (_ *IDPLDAPSyncWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewIDPLDAPSyncWriteModel(idpID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+// SyncLDAPIDP synchronizes the linked users of the LDAP IDP with the directory based on its synchronization settings
+// and records the result as run of the IDP. Failures of the directory search are reported in the run and not returned.
+// The resourceOwner is optional and ensures the IDP belongs to the organization.
+func (c *Commands) SyncLDAPIDP(ctx context.Context, idpID, resourceOwner string) (_ *domain.IDPLDAPSyncRun, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	idpWriteModel, err := IDPProviderWriteModel(ctx, c.eventstore.Filter, idpID)
+	if err != nil {
+		return nil, err
+	}
+	if resourceOwner != "" && idpWriteModel.ResourceOwner != resourceOwner {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Fai5e", "Errors.IDPConfig.NotExisting")
+	}
+	if idpWriteModel.IDPType != domain.IDPTypeLDAP {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Mai4s", "Errors.IDPConfig.LDAPSyncNotSupported")
+	}
+	settings, err := c.idpLDAPSyncWriteModel(ctx, idpID, idpWriteModel.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := c.ldapProviderWithAdditionalAttributes(ctx, idpWriteModel, idpID, "")
+	if err != nil {
+		return nil, err
+	}
+	ldapProvider, ok := provider.(*ldap.Provider)
+	if !ok {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-iu1Ie", "Errors.IDPConfig.LDAPSyncNotSupported")
+	}
+	attributeMapping, err := c.idpAttributeMappingWriteModel(ctx, idpID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	run := &domain.IDPLDAPSyncRun{
+		IDPID:     idpID,
+		StartedAt: time.Now(),
+	}
+	users, err := ldapProvider.SearchUsers(ctx, settings.Sync.SearchBase, settings.Sync.Filter)
+	if err != nil {
+		run.Error = err.Error()
+	} else if err = c.syncLDAPUsers(ctx, idpWriteModel, settings.Sync, attributeMappingToDomain(attributeMapping.Mapping), users, run); err != nil {
+		return nil, err
+	}
+
+	eventRun := repo_idp.LDAPSyncRun{
+		StartedAt:   run.StartedAt,
+		Users:       run.Users,
+		Created:     run.Created,
+		Updated:     run.Updated,
+		Deactivated: run.Deactivated,
+		Failed:      run.Failed,
+		Error:       run.Error,
+	}
+	var cmd eventstore.Command
+	if idpWriteModel.Instance {
+		cmd = instance.NewIDPLDAPSyncRunFinishedEvent(ctx, &instance.NewAggregate(idpWriteModel.ResourceOwner).Aggregate, idpID, eventRun)
+	} else {
+		cmd = org.NewIDPLDAPSyncRunFinishedEvent(ctx, &org.NewAggregate(idpWriteModel.ResourceOwner).Aggregate, idpID, eventRun)
+	}
+	events, err := c.eventstore.Push(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	run.FinishedAt = events[len(events)-1].CreatedAt()
+	return run, nil
+}
+
+type ldapSyncResult int
+
+const (
+	ldapSyncUnchanged ldapSyncResult = iota
+	ldapSyncCreated
+	ldapSyncUpdated
+	ldapSyncDeactivated
+)
+
+func (c *Commands) syncLDAPUsers(ctx context.Context, idpWriteModel *AllIDPWriteModel, settings repo_idp.LDAPSync, mapping *domain.IDPAttributeMapping, users []*ldap.User, run *domain.IDPLDAPSyncRun) error {
+	links := newIDPUserLinksReadModel(idpWriteModel.ID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, links); err != nil {
+		return err
+	}
+	// users of instance IDPs are created in the default organization
+	orgID := idpWriteModel.ResourceOwner
+	if idpWriteModel.Instance {
+		orgID = authz.GetInstance(ctx).DefaultOrganisationID()
+	}
+
+	run.Users = uint32(len(users))
+	found := make(map[string]bool, len(users))
+	for _, ldapUser := range users {
+		found[ldapUser.ID] = true
+		externalUser := idpUserToExternalUser(idpWriteModel.ID, ldapUser, mapping)
+		var (
+			result ldapSyncResult
+			exists bool
+			err    error
+		)
+		if userID, linked := links.Links[ldapUser.ID]; linked {
+			result, exists, err = c.syncLinkedLDAPUser(ctx, userID, externalUser, ldapUser.Disabled, settings)
+		}
+		// users removed in ZITADEL are handled as if they were never linked
+		if err == nil && !exists && settings.CreateUsers && !ldapUser.Disabled {
+			result, err = c.createLDAPUser(ctx, orgID, externalUser)
+		}
+		countLDAPSyncResult(run, result, err)
+		logging.WithFields("idp", idpWriteModel.ID, "external_user", ldapUser.ID).OnError(err).Warn("ldap sync of user failed")
+	}
+
+	if !settings.DeactivateUsers {
+		return nil
+	}
+	if len(users) == 0 && len(links.Links) > 0 {
+		run.Error = ldapSyncNoUsersError
+		return nil
+	}
+	for externalUserID, userID := range links.Links {
+		if found[externalUserID] {
+			continue
+		}
+		result, err := c.deactivateLDAPUser(ctx, userID)
+		countLDAPSyncResult(run, result, err)
+		logging.WithFields("idp", idpWriteModel.ID, "user", userID).OnError(err).Warn("ldap deprovisioning of user failed")
+	}
+	return nil
+}
+
+func countLDAPSyncResult(run *domain.IDPLDAPSyncRun, result ldapSyncResult, err error) {
+	if err != nil {
+		run.Failed++
+		return
+	}
+	switch result {
+	case ldapSyncCreated:
+		run.Created++
+	case ldapSyncUpdated:
+		run.Updated++
+	case ldapSyncDeactivated:
+		run.Deactivated++
+	case ldapSyncUnchanged:
+		// nothing to count
+	}
+}
+
+// syncLinkedLDAPUser deactivates the user if it was disabled in the directory or updates it with the values of the directory.
+// exists is false if the user was removed in the meantime.
+func (c *Commands) syncLinkedLDAPUser(ctx context.Context, userID string, externalUser *domain.ExternalUser, disabled bool, settings repo_idp.LDAPSync) (_ ldapSyncResult, exists bool, err error) {
+	if disabled {
+		if !settings.DeactivateUsers {
+			return ldapSyncUnchanged, true, nil
+		}
+		result, err := c.deactivateLDAPUser(ctx, userID)
+		return result, true, err
+	}
+	writeModel, err := c.userHumanWriteModel(ctx, userID, settings.UpdateUsers, settings.UpdateUsers, settings.UpdateUsers, false, false, false)
+	if err != nil {
+		return ldapSyncUnchanged, false, err
+	}
+	if !isUserStateExists(writeModel.UserState) {
+		return ldapSyncUnchanged, false, nil
+	}
+	if !settings.UpdateUsers {
+		return ldapSyncUnchanged, true, nil
+	}
+	cmds, err := changeUserProfile(ctx, nil, writeModel, ldapUserProfile(externalUser))
+	if err != nil {
+		return ldapSyncUnchanged, true, err
+	}
+	if externalUser.Email != "" {
+		cmds, _, err = c.changeUserEmail(ctx, cmds, writeModel, &Email{Address: externalUser.Email, Verified: externalUser.IsEmailVerified}, c.userEncryption)
+		if err != nil {
+			return ldapSyncUnchanged, true, err
+		}
+	}
+	if externalUser.Phone != "" {
+		cmds, _, err = c.changeUserPhone(ctx, cmds, writeModel, &Phone{Number: externalUser.Phone, Verified: externalUser.IsPhoneVerified}, c.userEncryption)
+		if err != nil {
+			return ldapSyncUnchanged, true, err
+		}
+	}
+	if len(cmds) == 0 {
+		return ldapSyncUnchanged, true, nil
+	}
+	if _, err = c.eventstore.Push(ctx, cmds...); err != nil {
+		return ldapSyncUnchanged, true, err
+	}
+	return ldapSyncUpdated, true, nil
+}
+
+// ldapUserProfile only returns the fields provided by the directory, so empty attributes don't reset the profile
+func ldapUserProfile(externalUser *domain.ExternalUser) *Profile {
+	profile := new(Profile)
+	if externalUser.FirstName != "" {
+		profile.FirstName = &externalUser.FirstName
+	}
+	if externalUser.LastName != "" {
+		profile.LastName = &externalUser.LastName
+	}
+	if externalUser.NickName != "" {
+		profile.NickName = &externalUser.NickName
+	}
+	if externalUser.DisplayName != "" {
+		profile.DisplayName = &externalUser.DisplayName
+	}
+	if !externalUser.PreferredLanguage.IsRoot() {
+		profile.PreferredLanguage = &externalUser.PreferredLanguage
+	}
+	return profile
+}
+
+// deactivateLDAPUser deactivates the active user.
+// Users in the initial state cannot be deactivated, but since they have no credentials yet,
+// they could only log in through the (now failing) LDAP IDP.
+func (c *Commands) deactivateLDAPUser(ctx context.Context, userID string) (ldapSyncResult, error) {
+	writeModel, err := c.userWriteModelByID(ctx, userID, "")
+	if err != nil {
+		return ldapSyncUnchanged, err
+	}
+	if !isUserStateExists(writeModel.UserState) ||
+		isUserStateInactive(writeModel.UserState) ||
+		isUserStateInitial(writeModel.UserState) {
+		return ldapSyncUnchanged, nil
+	}
+	_, err = c.eventstore.Push(ctx, user.NewUserDeactivatedEvent(ctx, UserAggregateFromWriteModel(&writeModel.WriteModel)))
+	if err != nil {
+		return ldapSyncUnchanged, err
+	}
+	return ldapSyncDeactivated, nil
+}
+
+func (c *Commands) createLDAPUser(ctx context.Context, orgID string, externalUser *domain.ExternalUser) (ldapSyncResult, error) {
+	username := externalUser.PreferredUsername
+	if username == "" {
+		username = string(externalUser.Email)
+	}
+	metadata := make([]*AddMetadataEntry, len(externalUser.Metadatas))
+	for i, entry := range externalUser.Metadatas {
+		metadata[i] = &AddMetadataEntry{
+			Key:   entry.Key,
+			Value: entry.Value,
+		}
+	}
+	human := &AddHuman{
+		Username:          username,
+		FirstName:         externalUser.FirstName,
+		LastName:          externalUser.LastName,
+		NickName:          externalUser.NickName,
+		DisplayName:       externalUser.DisplayName,
+		Email:             Email{Address: externalUser.Email, Verified: externalUser.IsEmailVerified},
+		Phone:             Phone{Number: externalUser.Phone, Verified: externalUser.IsPhoneVerified},
+		PreferredLanguage: externalUser.PreferredLanguage,
+		ExternalIDP:       true,
+		Metadata:          metadata,
+		Links: []*AddLink{{
+			IDPID:         externalUser.IDPConfigID,
+			DisplayName:   externalUser.PreferredUsername,
+			IDPExternalID: externalUser.ExternalUserID,
+		}},
+	}
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.AddHumanCommand(human, orgID, c.userPasswordHasher, c.userEncryption, false))
+	if err != nil {
+		return ldapSyncUnchanged, err
+	}
+	if _, err = c.eventstore.Push(ctx, cmds...); err != nil {
+		return ldapSyncUnchanged, err
+	}
+	return ldapSyncCreated, nil
+}
@@ -0,0 +1,149 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// IDPLDAPSyncWriteModel contains the synchronization settings of an LDAP IDP,
+// which can either be defined on the instance or an organization.
+type IDPLDAPSyncWriteModel struct {
+	eventstore.WriteModel
+
+	ID   string
+	Sync idp.LDAPSync
+}
+
+func NewIDPLDAPSyncWriteModel(id, resourceOwner string) *IDPLDAPSyncWriteModel {
+	return &IDPLDAPSyncWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: resourceOwner,
+		},
+		ID: id,
+	}
+}
+
+func (wm *IDPLDAPSyncWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.IDPLDAPSyncSetEvent:
+			wm.WriteModel.AppendEvents(&e.LDAPSyncSetEvent)
+		case *org.IDPLDAPSyncSetEvent:
+			wm.WriteModel.AppendEvents(&e.LDAPSyncSetEvent)
+		case *instance.IDPRemovedEvent:
+			wm.WriteModel.AppendEvents(&e.RemovedEvent)
+		case *org.IDPRemovedEvent:
+			wm.WriteModel.AppendEvents(&e.RemovedEvent)
+		}
+	}
+}
+
+func (wm *IDPLDAPSyncWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.LDAPSyncSetEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.Sync = e.LDAPSync
+		case *idp.RemovedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.Sync = idp.LDAPSync{}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *IDPLDAPSyncWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPLDAPSyncSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPLDAPSyncSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+func ldapSyncToEvent(sync *domain.IDPLDAPSync) idp.LDAPSync {
+	return idp.LDAPSync{
+		Enabled:         sync.Enabled,
+		Interval:        sync.Interval,
+		SearchBase:      sync.SearchBase,
+		Filter:          sync.Filter,
+		CreateUsers:     sync.CreateUsers,
+		UpdateUsers:     sync.UpdateUsers,
+		DeactivateUsers: sync.DeactivateUsers,
+	}
+}
+
+// idpUserLinksReadModel maps the external user IDs of an IDP to the IDs of the linked users
+type idpUserLinksReadModel struct {
+	eventstore.WriteModel
+
+	IDPID string
+	Links map[string]string
+}
+
+func newIDPUserLinksReadModel(idpID string) *idpUserLinksReadModel {
+	return &idpUserLinksReadModel{
+		IDPID: idpID,
+		Links: make(map[string]string),
+	}
+}
+
+func (rm *idpUserLinksReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *user.UserIDPLinkAddedEvent:
+			rm.Links[e.ExternalUserID] = e.Aggregate().ID
+		case *user.UserIDPLinkRemovedEvent:
+			rm.removeLink(e.ExternalUserID, e.Aggregate().ID)
+		case *user.UserIDPLinkCascadeRemovedEvent:
+			rm.removeLink(e.ExternalUserID, e.Aggregate().ID)
+		case *user.UserIDPExternalIDMigratedEvent:
+			rm.removeLink(e.PreviousID, e.Aggregate().ID)
+			rm.Links[e.NewID] = e.Aggregate().ID
+		}
+	}
+	return rm.WriteModel.Reduce()
+}
+
+func (rm *idpUserLinksReadModel) removeLink(externalUserID, userID string) {
+	if rm.Links[externalUserID] == userID {
+		delete(rm.Links, externalUserID)
+	}
+}
+
+func (rm *idpUserLinksReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		EventTypes(
+			user.UserIDPLinkAddedType,
+			user.UserIDPLinkRemovedType,
+			user.UserIDPLinkCascadeRemovedType,
+			user.UserIDPExternalIDMigratedType,
+		).
+		EventData(map[string]interface{}{"idpConfigId": rm.IDPID}).
+		Builder()
+}
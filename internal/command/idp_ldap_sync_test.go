@@ -0,0 +1,554 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_SetInstanceIDPLDAPSync(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		idpID string
+		sync  *domain.IDPLDAPSync
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "interval too short, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync: &domain.IDPLDAPSync{
+					Enabled:  true,
+					Interval: time.Minute,
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid filter, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync: &domain.IDPLDAPSync{
+					Enabled:  true,
+					Interval: time.Hour,
+					Filter:   "(memberOf=cn=zitadel",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync: &domain.IDPLDAPSync{
+					Enabled:  true,
+					Interval: time.Hour,
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no ldap idp, precondition failed error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent("idp1")),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync: &domain.IDPLDAPSync{
+					Enabled:  true,
+					Interval: time.Hour,
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set sync, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceLDAPIDPAddedEvent("idp1")),
+					),
+					expectFilter(),
+					expectPush(
+						instance.NewIDPLDAPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"idp1",
+							idp.LDAPSync{
+								Enabled:         true,
+								Interval:        time.Hour,
+								Filter:          "(memberOf=cn=zitadel,dc=example,dc=com)",
+								UpdateUsers:     true,
+								DeactivateUsers: true,
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync: &domain.IDPLDAPSync{
+					Enabled:         true,
+					Interval:        time.Hour,
+					Filter:          "(memberOf=cn=zitadel,dc=example,dc=com)",
+					UpdateUsers:     true,
+					DeactivateUsers: true,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "sync unchanged, no push",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceLDAPIDPAddedEvent("idp1")),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPLDAPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"idp1",
+								idp.LDAPSync{
+									Enabled:  true,
+									Interval: time.Hour,
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync: &domain.IDPLDAPSync{
+					Enabled:  true,
+					Interval: time.Hour,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetInstanceIDPLDAPSync(tt.args.ctx, tt.args.idpID, tt.args.sync)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_SetOrgIDPLDAPSync(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		idpID         string
+		sync          *domain.IDPLDAPSync
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resourceowner missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync:  &domain.IDPLDAPSync{},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp of other organization, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(orgLDAPIDPAddedEvent("idp1", "org2")),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				sync: &domain.IDPLDAPSync{
+					Enabled:  true,
+					Interval: time.Hour,
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "set sync, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(orgLDAPIDPAddedEvent("idp1", "org1")),
+					),
+					expectFilter(),
+					expectPush(
+						org.NewIDPLDAPSyncSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"idp1",
+							idp.LDAPSync{
+								Enabled:     true,
+								Interval:    time.Hour,
+								SearchBase:  "ou=people,dc=example,dc=com",
+								CreateUsers: true,
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				sync: &domain.IDPLDAPSync{
+					Enabled:     true,
+					Interval:    time.Hour,
+					SearchBase:  "ou=people,dc=example,dc=com",
+					CreateUsers: true,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetOrgIDPLDAPSync(tt.args.ctx, tt.args.resourceOwner, tt.args.idpID, tt.args.sync)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_syncLDAPUsers(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		settings idp.LDAPSync
+		users    []*ldap.User
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *domain.IDPLDAPSyncRun
+	}{
+		{
+			name: "disabled and missing users, deactivated",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(ldapUserLinkAddedEvent("user1", "ext1")),
+						eventFromEventPusher(ldapUserLinkAddedEvent("user2", "ext2")),
+					),
+					expectFilter(
+						eventFromEventPusher(ldapHumanAddedEvent("user1")),
+					),
+					expectPush(
+						user.NewUserDeactivatedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate),
+					),
+					expectFilter(
+						eventFromEventPusher(ldapHumanAddedEvent("user2")),
+					),
+					expectPush(
+						user.NewUserDeactivatedEvent(context.Background(), &user.NewAggregate("user2", "org1").Aggregate),
+					),
+				),
+			},
+			args: args{
+				settings: idp.LDAPSync{
+					DeactivateUsers: true,
+				},
+				users: []*ldap.User{
+					{ID: "ext1", Disabled: true},
+					{ID: "ext3"},
+				},
+			},
+			want: &domain.IDPLDAPSyncRun{
+				Users:       2,
+				Deactivated: 2,
+			},
+		},
+		{
+			name: "already inactive user, unchanged",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(ldapUserLinkAddedEvent("user1", "ext1")),
+					),
+					expectFilter(
+						eventFromEventPusher(ldapHumanAddedEvent("user1")),
+						eventFromEventPusher(
+							user.NewUserDeactivatedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate),
+						),
+					),
+				),
+			},
+			args: args{
+				settings: idp.LDAPSync{
+					DeactivateUsers: true,
+				},
+				users: []*ldap.User{
+					{ID: "ext1", Disabled: true},
+				},
+			},
+			want: &domain.IDPLDAPSyncRun{
+				Users: 1,
+			},
+		},
+		{
+			name: "no users in directory, deactivation skipped",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(ldapUserLinkAddedEvent("user1", "ext1")),
+					),
+				),
+			},
+			args: args{
+				settings: idp.LDAPSync{
+					DeactivateUsers: true,
+				},
+			},
+			want: &domain.IDPLDAPSyncRun{
+				Error: ldapSyncNoUsersError,
+			},
+		},
+		{
+			name: "deactivation disabled, unchanged",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(ldapUserLinkAddedEvent("user1", "ext1")),
+					),
+				),
+			},
+			args: args{
+				users: []*ldap.User{
+					{ID: "ext1", Disabled: true},
+				},
+			},
+			want: &domain.IDPLDAPSyncRun{
+				Users: 1,
+			},
+		},
+		{
+			name: "linked user changed, updated",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(ldapUserLinkAddedEvent("user1", "ext1")),
+					),
+					expectFilter(
+						eventFromEventPusher(ldapHumanAddedEvent("user1")),
+					),
+					expectPush(
+						func() eventstore.Command {
+							event, _ := user.NewHumanProfileChangedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								[]user.ProfileChanges{user.ChangeLastName("new lastname")},
+							)
+							return event
+						}(),
+						user.NewHumanEmailChangedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "new@test.ch"),
+						user.NewHumanEmailVerifiedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate),
+					),
+				),
+			},
+			args: args{
+				settings: idp.LDAPSync{
+					UpdateUsers: true,
+				},
+				users: []*ldap.User{
+					{ID: "ext1", FirstName: "firstname", LastName: "new lastname", Email: "new@test.ch", EmailVerified: true},
+				},
+			},
+			want: &domain.IDPLDAPSyncRun{
+				Users:   1,
+				Updated: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			run := new(domain.IDPLDAPSyncRun)
+			err := c.syncLDAPUsers(
+				authz.WithInstanceID(context.Background(), "instance1"),
+				&AllIDPWriteModel{ID: "idp1", ResourceOwner: "org1"},
+				tt.args.settings,
+				&domain.IDPAttributeMapping{},
+				tt.args.users,
+				run,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, run)
+		})
+	}
+}
+
+func instanceLDAPIDPAddedEvent(id string) *instance.LDAPIDPAddedEvent {
+	return instance.NewLDAPIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+		id,
+		"name",
+		[]string{"server"},
+		false,
+		"baseDN",
+		"dn",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("password"),
+		},
+		"user",
+		[]string{"object"},
+		[]string{"filter"},
+		time.Second*30,
+		idp.LDAPAttributes{},
+		idp.Options{},
+	)
+}
+
+func orgLDAPIDPAddedEvent(id, orgID string) *org.LDAPIDPAddedEvent {
+	return org.NewLDAPIDPAddedEvent(context.Background(), &org.NewAggregate(orgID).Aggregate,
+		id,
+		"name",
+		[]string{"server"},
+		false,
+		"baseDN",
+		"dn",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("password"),
+		},
+		"user",
+		[]string{"object"},
+		[]string{"filter"},
+		time.Second*30,
+		idp.LDAPAttributes{},
+		idp.Options{},
+	)
+}
+
+func ldapUserLinkAddedEvent(userID, externalUserID string) *user.UserIDPLinkAddedEvent {
+	return user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate(userID, "org1").Aggregate,
+		"idp1",
+		"display",
+		externalUserID,
+	)
+}
+
+func ldapHumanAddedEvent(userID string) *user.HumanAddedEvent {
+	return user.NewHumanAddedEvent(context.Background(),
+		&user.NewAggregate(userID, "org1").Aggregate,
+		"username",
+		"firstname",
+		"lastname",
+		"nickname",
+		"displayname",
+		language.German,
+		domain.GenderUnspecified,
+		"email@test.ch",
+		true,
+	)
+}
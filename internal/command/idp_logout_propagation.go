@@ -0,0 +1,263 @@
+package command
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/xml"
+	"time"
+
+	"github.com/crewjam/saml"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp"
+	saml2 "github.com/zitadel/zitadel/internal/idp/providers/saml"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetInstanceIDPLogoutPropagation enables or disables the termination of the user's session at the instance IDP on logout.
+func (c *Commands) SetInstanceIDPLogoutPropagation(ctx context.Context, idpID string, enabled bool) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	return c.setIDPLogoutPropagation(ctx, idpID, instanceID, enabled, instance.NewIDPLogoutPropagationSetEvent(
+		ctx,
+		&instance.NewAggregate(instanceID).Aggregate,
+		idpID,
+		enabled,
+	))
+}
+
+// SetOrgIDPLogoutPropagation enables or disables the termination of the user's session at the organization IDP on logout.
+func (c *Commands) SetOrgIDPLogoutPropagation(ctx context.Context, resourceOwner, idpID string, enabled bool) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-aeK2u", "Errors.ResourceOwnerMissing")
+	}
+	return c.setIDPLogoutPropagation(ctx, idpID, resourceOwner, enabled, org.NewIDPLogoutPropagationSetEvent(
+		ctx,
+		&org.NewAggregate(resourceOwner).Aggregate,
+		idpID,
+		enabled,
+	))
+}
+
+func (c *Commands) setIDPLogoutPropagation(ctx context.Context, idpID, resourceOwner string, enabled bool, event eventstore.Command) (*domain.ObjectDetails, error) {
+	writeModel := NewIDPLogoutPropagationWriteModel(idpID, resourceOwner)
+	err := c.setIDPSetting(ctx, idpID, resourceOwner, checkLogoutPropagationIDPType, writeModel,
+		func() bool { return writeModel.Enabled != enabled },
+		event,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func checkLogoutPropagationIDPType(idpType domain.IDPType) error {
+	if !idpType.SupportsLogoutPropagation() {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-woh2A", "Errors.IDPConfig.LogoutPropagationNotSupported")
+	}
+	return nil
+}
+
+func (c *Commands) idpLogoutPropagationWriteModel(ctx context.Context, idpID, resourceOwner string) (_ *IDPLogoutPropagationWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewIDPLogoutPropagationWriteModel(idpID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+// StoreUserIDPLogoutHint stores the hint identifying the session at the IDP (id_token or NameID) for the user agent,
+// if the logout propagation of the IDP is enabled.
+// Sessions without such a hint are ignored.
+func (c *Commands) StoreUserIDPLogoutHint(ctx context.Context, userID, resourceOwner, idpID, userAgentID string, session idp.Session) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	hint := idpSessionLogoutHint(session)
+	if hint == "" || userAgentID == "" {
+		return nil
+	}
+	propagation, err := c.idpLogoutPropagationWriteModel(ctx, idpID, "")
+	if err != nil || !propagation.Enabled {
+		return err
+	}
+	encryptedHint, err := crypto.Encrypt([]byte(hint), c.idpConfigEncryption)
+	if err != nil {
+		return err
+	}
+	_, err = c.eventstore.Push(ctx, user.NewUserIDPLogoutHintSetEvent(
+		ctx,
+		&user.NewAggregate(userID, resourceOwner).Aggregate,
+		idpID,
+		userAgentID,
+		encryptedHint,
+	))
+	return err
+}
+
+func idpSessionLogoutHint(session idp.Session) string {
+	if s, ok := session.(*saml2.Session); ok {
+		return samlAssertionNameID(s.Assertion)
+	}
+	if tokens := idpSessionTokens(session); tokens != nil {
+		return tokens.IDToken
+	}
+	return ""
+}
+
+func samlAssertionNameID(assertion *saml.Assertion) string {
+	if assertion == nil || assertion.Subject == nil || assertion.Subject.NameID == nil {
+		return ""
+	}
+	return assertion.Subject.NameID.Value
+}
+
+// idpLogoutStateLifetime limits the time the IDP has to terminate its session and send the user agent back
+const idpLogoutStateLifetime = time.Hour
+
+// UserAgentIDPLogoutURL returns the URL to terminate the session at the IDP one of the users last authenticated with on the user agent.
+// As the user agent can only be redirected to a single IDP, the first user with a stored hint is used.
+// An empty URL is returned if none of the users authenticated with an IDP with logout propagation.
+//
+// The IDP will redirect the user agent to the callbackURL (OIDC) or the SLO endpoint of the SAML service provider,
+// from where the user agent is sent to the redirectURI of the client, which is stored under a random state.
+// The function must therefore be called before the users are signed out.
+func (c *Commands) UserAgentIDPLogoutURL(ctx context.Context, userAgentID string, userIDs []string, clientID, redirectURI, callbackURL string, samlRootURL func(ctx context.Context, idpID string) string) (_ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	for _, userID := range userIDs {
+		writeModel := NewUserIDPLogoutHintWriteModel(userID, userAgentID, "")
+		if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+			return "", err
+		}
+		if writeModel.Hint == nil {
+			continue
+		}
+		hint, err := crypto.DecryptString(writeModel.Hint, c.idpConfigEncryption)
+		if err != nil {
+			return "", err
+		}
+		userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+		return c.idpLogoutURL(ctx, userAgg, writeModel.IDPConfigID, hint, clientID, redirectURI, callbackURL, samlRootURL)
+	}
+	return "", nil
+}
+
+// SessionIDPLogoutURL returns the URL to terminate the session at the IDP, which was used in the intent checked on the (v2) session.
+// The id_token or NameID of the intent is used as hint.
+// An empty URL is returned if no intent was checked or the IDP has no logout propagation.
+//
+// See [Commands.UserAgentIDPLogoutURL] for the callbackURL and redirectURI.
+func (c *Commands) SessionIDPLogoutURL(ctx context.Context, sessionID, clientID, redirectURI, callbackURL string, samlRootURL func(ctx context.Context, idpID string) string) (_ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	sessionWriteModel := NewSessionWriteModel(sessionID, authz.GetInstance(ctx).InstanceID())
+	if err := c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel); err != nil {
+		return "", err
+	}
+	if sessionWriteModel.IntentID == "" {
+		return "", nil
+	}
+	intentWriteModel, err := c.GetIntentWriteModel(ctx, sessionWriteModel.IntentID, "")
+	if err != nil {
+		return "", err
+	}
+	hint, err := c.idpIntentLogoutHint(intentWriteModel)
+	if err != nil || hint == "" {
+		return "", err
+	}
+	userAgg := &user.NewAggregate(sessionWriteModel.UserID, sessionWriteModel.UserResourceOwner).Aggregate
+	return c.idpLogoutURL(ctx, userAgg, intentWriteModel.IDPID, hint, clientID, redirectURI, callbackURL, samlRootURL)
+}
+
+func (c *Commands) idpIntentLogoutHint(writeModel *IDPIntentWriteModel) (string, error) {
+	if writeModel.Assertion == nil {
+		return writeModel.IDPIDToken, nil
+	}
+	data, err := crypto.Decrypt(writeModel.Assertion, c.idpConfigEncryption)
+	if err != nil {
+		return "", err
+	}
+	assertion := new(saml.Assertion)
+	if err := xml.Unmarshal(data, assertion); err != nil {
+		return "", zerrors.ThrowInternal(err, "COMMAND-Quai4", "Errors.Intent.ResponseInvalid")
+	}
+	return samlAssertionNameID(assertion), nil
+}
+
+func (c *Commands) idpLogoutURL(ctx context.Context, userAgg *eventstore.Aggregate, idpID, hint, clientID, redirectURI, callbackURL string, samlRootURL func(ctx context.Context, idpID string) string) (string, error) {
+	propagation, err := c.idpLogoutPropagationWriteModel(ctx, idpID, "")
+	if err != nil || !propagation.Enabled {
+		return "", err
+	}
+	provider, err := c.GetProvider(ctx, idpID, "", samlRootURL(ctx, idpID))
+	if err != nil {
+		return "", err
+	}
+	logoutProvider, ok := provider.(idp.ProviderSupportsLogout)
+	if !ok {
+		return "", zerrors.ThrowPreconditionFailed(nil, "COMMAND-ahC7i", "Errors.IDPConfig.LogoutPropagationNotSupported")
+	}
+	state, err := idpLogoutState()
+	if err != nil {
+		return "", err
+	}
+	_, err = c.eventstore.Push(ctx, user.NewUserIDPLogoutStartedEvent(ctx, userAgg, state, idpID, clientID, redirectURI))
+	if err != nil {
+		return "", err
+	}
+	return logoutProvider.LogoutURL(ctx, hint, callbackURL, state)
+}
+
+func idpLogoutState() (string, error) {
+	state := make([]byte, 32)
+	if _, err := rand.Read(state); err != nil {
+		return "", zerrors.ThrowInternal(err, "COMMAND-Wah2e", "Errors.Internal")
+	}
+	return base64.RawURLEncoding.EncodeToString(state), nil
+}
+
+// IDPLogoutRedirect is the destination of the user agent after the IDP terminated its session.
+// The RedirectURI must be checked against the (post logout) redirect uris of the client, if the ClientID is set.
+type IDPLogoutRedirect struct {
+	ClientID    string
+	RedirectURI string
+}
+
+// FinishIDPLogout returns the redirect stored under the state of the logout at the IDP.
+// Each state can only be used once and expires after [idpLogoutStateLifetime].
+func (c *Commands) FinishIDPLogout(ctx context.Context, state string) (_ *IDPLogoutRedirect, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if state == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ieB0a", "Errors.IDPConfig.LogoutStateInvalid")
+	}
+	writeModel := NewUserIDPLogoutStateWriteModel(state)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if writeModel.RedirectURI == "" || writeModel.Finished || writeModel.StartedAt.Add(idpLogoutStateLifetime).Before(time.Now()) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ohj5a", "Errors.IDPConfig.LogoutStateInvalid")
+	}
+	_, err = c.eventstore.Push(ctx, user.NewUserIDPLogoutFinishedEvent(ctx, UserAggregateFromWriteModel(&writeModel.WriteModel), state))
+	if err != nil {
+		return nil, err
+	}
+	return &IDPLogoutRedirect{
+		ClientID:    writeModel.ClientID,
+		RedirectURI: writeModel.RedirectURI,
+	}, nil
+}
@@ -0,0 +1,202 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// IDPLogoutPropagationWriteModel contains the logout propagation setting of an IDP,
+// which can either be defined on the instance or an organization.
+type IDPLogoutPropagationWriteModel struct {
+	eventstore.WriteModel
+
+	ID      string
+	Enabled bool
+}
+
+func NewIDPLogoutPropagationWriteModel(id, resourceOwner string) *IDPLogoutPropagationWriteModel {
+	return &IDPLogoutPropagationWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: resourceOwner,
+		},
+		ID: id,
+	}
+}
+
+func (wm *IDPLogoutPropagationWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.IDPLogoutPropagationSetEvent:
+			wm.WriteModel.AppendEvents(&e.LogoutPropagationSetEvent)
+		case *org.IDPLogoutPropagationSetEvent:
+			wm.WriteModel.AppendEvents(&e.LogoutPropagationSetEvent)
+		case *instance.IDPRemovedEvent:
+			wm.WriteModel.AppendEvents(&e.RemovedEvent)
+		case *org.IDPRemovedEvent:
+			wm.WriteModel.AppendEvents(&e.RemovedEvent)
+		}
+	}
+}
+
+func (wm *IDPLogoutPropagationWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.LogoutPropagationSetEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.Enabled = e.Enabled
+		case *idp.RemovedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.Enabled = false
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *IDPLogoutPropagationWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPLogoutPropagationSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPLogoutPropagationSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// UserIDPLogoutHintWriteModel contains the logout hint of the IDP the user last authenticated with on the user agent.
+// The hint is discarded as soon as the user signs out of the user agent.
+type UserIDPLogoutHintWriteModel struct {
+	eventstore.WriteModel
+
+	UserAgentID string
+	IDPConfigID string
+	Hint        *crypto.CryptoValue
+}
+
+func NewUserIDPLogoutHintWriteModel(userID, userAgentID, resourceOwner string) *UserIDPLogoutHintWriteModel {
+	return &UserIDPLogoutHintWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		UserAgentID: userAgentID,
+	}
+}
+
+func (wm *UserIDPLogoutHintWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.UserIDPLogoutHintSetEvent:
+			if e.UserAgentID != wm.UserAgentID {
+				continue
+			}
+			wm.IDPConfigID = e.IDPConfigID
+			wm.Hint = e.Hint
+		case *user.HumanSignedOutEvent:
+			if e.UserAgentID != wm.UserAgentID {
+				continue
+			}
+			wm.removeHint()
+		case *user.UserRemovedEvent:
+			wm.removeHint()
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserIDPLogoutHintWriteModel) removeHint() {
+	wm.IDPConfigID = ""
+	wm.Hint = nil
+}
+
+func (wm *UserIDPLogoutHintWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.UserIDPLogoutHintSetType,
+			user.HumanSignedOutType,
+			user.UserRemovedType,
+		).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// UserIDPLogoutStateWriteModel contains the redirect uri of a logout at the IDP stored under a random state.
+type UserIDPLogoutStateWriteModel struct {
+	eventstore.WriteModel
+
+	StateID     string
+	IDPConfigID string
+	ClientID    string
+	RedirectURI string
+	StartedAt   time.Time
+	Finished    bool
+}
+
+func NewUserIDPLogoutStateWriteModel(stateID string) *UserIDPLogoutStateWriteModel {
+	return &UserIDPLogoutStateWriteModel{
+		StateID: stateID,
+	}
+}
+
+func (wm *UserIDPLogoutStateWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.UserIDPLogoutStartedEvent:
+			if e.StateID != wm.StateID {
+				continue
+			}
+			wm.IDPConfigID = e.IDPConfigID
+			wm.ClientID = e.ClientID
+			wm.RedirectURI = e.RedirectURI
+			wm.StartedAt = e.CreatedAt()
+		case *user.UserIDPLogoutFinishedEvent:
+			if e.StateID != wm.StateID {
+				continue
+			}
+			wm.Finished = true
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserIDPLogoutStateWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		EventTypes(
+			user.UserIDPLogoutStartedType,
+			user.UserIDPLogoutFinishedType,
+		).
+		EventData(map[string]interface{}{"stateId": wm.StateID}).
+		Builder()
+}
@@ -0,0 +1,475 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	rep_idp "github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/idpintent"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_SetInstanceIDPLogoutPropagation(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx     context.Context
+		idpID   string
+		enabled bool
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "idp not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				idpID:   "idp1",
+				enabled: true,
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "github idp, precondition failed error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent("idp1")),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				idpID:   "idp1",
+				enabled: true,
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "enable, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceOIDCIDPAddedEvent("idp1")),
+					),
+					expectFilter(),
+					expectPush(
+						instance.NewIDPLogoutPropagationSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"idp1",
+							true,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				idpID:   "idp1",
+				enabled: true,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "unchanged, no push",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceOIDCIDPAddedEvent("idp1")),
+					),
+					expectFilter(
+						eventFromEventPusher(instanceLogoutPropagationSetEvent("idp1")),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				idpID:   "idp1",
+				enabled: true,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetInstanceIDPLogoutPropagation(tt.args.ctx, tt.args.idpID, tt.args.enabled)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_StoreUserIDPLogoutHint(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userAgentID string
+		session     idp.Session
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+	}{
+		{
+			name: "no hint, ignored",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userAgentID: "agent1",
+				session:     &ldap.Session{},
+			},
+		},
+		{
+			name: "no user agent, ignored",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				session: oidcSessionWithIDToken("idToken"),
+			},
+		},
+		{
+			name: "logout propagation disabled, ignored",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				userAgentID: "agent1",
+				session:     oidcSessionWithIDToken("idToken"),
+			},
+		},
+		{
+			name: "stored",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceLogoutPropagationSetEvent("idp1")),
+					),
+					expectPush(
+						userIDPLogoutHintSetEvent("agent1", "idToken"),
+					),
+				),
+			},
+			args: args{
+				userAgentID: "agent1",
+				session:     oidcSessionWithIDToken("idToken"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore(t),
+				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			err := c.StoreUserIDPLogoutHint(authz.WithInstanceID(context.Background(), "instance1"), "user1", "org1", "idp1", tt.args.userAgentID, tt.args.session)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCommands_UserAgentIDPLogoutURL(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "no hint, empty",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+		},
+		{
+			name: "signed out, empty",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(userIDPLogoutHintSetEvent("agent1", "idToken")),
+						eventFromEventPusher(user.NewHumanSignedOutEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "agent1")),
+					),
+				),
+			},
+		},
+		{
+			name: "hint of other user agent, empty",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(userIDPLogoutHintSetEvent("agent2", "idToken")),
+					),
+				),
+			},
+		},
+		{
+			name: "logout propagation disabled, empty",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(userIDPLogoutHintSetEvent("agent1", "idToken")),
+					),
+					expectFilter(
+						eventFromEventPusher(instanceLogoutPropagationSetEvent("idp1")),
+						eventFromEventPusher(instance.NewIDPRemovedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "idp1")),
+					),
+				),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore(t),
+				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := c.UserAgentIDPLogoutURL(
+				authz.WithInstanceID(context.Background(), "instance1"),
+				"agent1",
+				[]string{"user1"},
+				"client1",
+				"https://example.com/logged-out",
+				"https://zitadel.cloud/idps/logout/callback",
+				func(context.Context, string) string { return "https://zitadel.cloud/idps/idp1/" },
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommands_SessionIDPLogoutURL(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "no intent checked, empty",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(session.NewAddedEvent(context.Background(), &session.NewAggregate("session1", "instance1").Aggregate, nil)),
+					),
+				),
+			},
+		},
+		{
+			name: "logout propagation disabled, empty",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(session.NewAddedEvent(context.Background(), &session.NewAggregate("session1", "instance1").Aggregate, nil)),
+						eventFromEventPusher(session.NewIntentCheckedEvent(context.Background(), &session.NewAggregate("session1", "instance1").Aggregate, testNow, "intent1")),
+					),
+					expectFilter(
+						eventFromEventPusher(idpintent.NewStartedEvent(context.Background(), &idpintent.NewAggregate("intent1", "instance1").Aggregate, nil, nil, "idp1")),
+						eventFromEventPusher(idpintent.NewSucceededEvent(context.Background(), &idpintent.NewAggregate("intent1", "instance1").Aggregate, nil, nil, "ext1", "username", "user1", nil, "idToken")),
+					),
+					expectFilter(),
+				),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore(t),
+				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := c.SessionIDPLogoutURL(
+				authz.WithInstanceID(context.Background(), "instance1"),
+				"session1",
+				"client1",
+				"https://example.com/logged-out",
+				"https://zitadel.cloud/idps/logout/callback",
+				func(context.Context, string) string { return "https://zitadel.cloud/idps/idp1/" },
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommands_FinishIDPLogout(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		state   string
+		want    *IDPLogoutRedirect
+		wantErr func(error) bool
+	}{
+		{
+			name: "missing state, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "unknown state, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			state:   "state1",
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "state already used, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(userIDPLogoutStartedEvent("state1")),
+						eventFromEventPusherWithCreationDateNow(user.NewUserIDPLogoutFinishedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "state1")),
+					),
+				),
+			},
+			state:   "state1",
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "state expired, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						expiredUserIDPLogoutStartedEvent("state1"),
+					),
+				),
+			},
+			state:   "state1",
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(userIDPLogoutStartedEvent("state1")),
+					),
+					expectPush(
+						user.NewUserIDPLogoutFinishedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "state1"),
+					),
+				),
+			},
+			state: "state1",
+			want: &IDPLogoutRedirect{
+				ClientID:    "client1",
+				RedirectURI: "https://example.com/logged-out",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.FinishIDPLogout(authz.WithInstanceID(context.Background(), "instance1"), tt.state)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "got wrong err: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func instanceOIDCIDPAddedEvent(id string) *instance.OIDCIDPAddedEvent {
+	return instance.NewOIDCIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+		id, "name", "https://issuer.com", "clientID", nil, []string{"openid"}, false, rep_idp.Options{},
+	)
+}
+
+func instanceLogoutPropagationSetEvent(id string) *instance.IDPLogoutPropagationSetEvent {
+	return instance.NewIDPLogoutPropagationSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+		id, true,
+	)
+}
+
+func userIDPLogoutHintSetEvent(userAgentID, hint string) *user.UserIDPLogoutHintSetEvent {
+	return user.NewUserIDPLogoutHintSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+		"idp1", userAgentID, mockEncryptedValue(hint),
+	)
+}
+
+func oidcSessionWithIDToken(idToken string) *openid.Session {
+	return &openid.Session{
+		Tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+			IDToken: idToken,
+		},
+	}
+}
+
+func userIDPLogoutStartedEvent(stateID string) *user.UserIDPLogoutStartedEvent {
+	return user.NewUserIDPLogoutStartedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+		stateID, "idp1", "client1", "https://example.com/logged-out",
+	)
+}
+
+func expiredUserIDPLogoutStartedEvent(stateID string) *repository.Event {
+	e := eventFromEventPusher(userIDPLogoutStartedEvent(stateID))
+	e.CreationDate = time.Now().Add(-2 * idpLogoutStateLifetime)
+	return e
+}
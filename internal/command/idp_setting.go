@@ -0,0 +1,48 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// setIDPSetting sets a setting of an IDP (e.g. its token vault), which is stored on the instance or organization of the IDP.
+// The IDP must exist on the resourceOwner and its type must be accepted by checkType.
+// The event is only pushed if changed reports a difference to the setting reduced into the writeModel.
+func (c *Commands) setIDPSetting(
+	ctx context.Context,
+	idpID, resourceOwner string,
+	checkType func(domain.IDPType) error,
+	writeModel eventstore.QueryReducer,
+	changed func() bool,
+	event eventstore.Command,
+) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if err = c.checkIDPType(ctx, idpID, resourceOwner, checkType); err != nil {
+		return err
+	}
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return err
+	}
+	if !changed() {
+		return nil
+	}
+	return c.pushAppendAndReduce(ctx, writeModel, event)
+}
+
+// checkIDPType checks that the IDP exists on the resourceOwner (instance or organization) and its type is accepted by checkType
+func (c *Commands) checkIDPType(ctx context.Context, idpID, resourceOwner string, checkType func(domain.IDPType) error) error {
+	writeModel := NewIDPTypeWriteModel(idpID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return err
+	}
+	if !writeModel.State.Exists() || writeModel.ResourceOwner != resourceOwner {
+		return zerrors.ThrowNotFound(nil, "COMMAND-eiT5u", "Errors.IDPConfig.NotExisting")
+	}
+	return checkType(writeModel.Type)
+}
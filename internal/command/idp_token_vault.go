@@ -0,0 +1,245 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// idpLinkTokensExpiryDelta is subtracted from the expiry of a stored access token,
+// so that a returned token does not expire right before it's used.
+const idpLinkTokensExpiryDelta = 10 * time.Second
+
+// SetInstanceIDPTokenVault enables or disables the storage of the tokens issued by the instance IDP on the links of its users.
+func (c *Commands) SetInstanceIDPTokenVault(ctx context.Context, idpID string, enabled bool) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	return c.setIDPTokenVault(ctx, idpID, instanceID, enabled, instance.NewIDPTokenVaultSetEvent(
+		ctx,
+		&instance.NewAggregate(instanceID).Aggregate,
+		idpID,
+		enabled,
+	))
+}
+
+// SetOrgIDPTokenVault enables or disables the storage of the tokens issued by the organization IDP on the links of its users.
+func (c *Commands) SetOrgIDPTokenVault(ctx context.Context, resourceOwner, idpID string, enabled bool) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Uo0ph", "Errors.ResourceOwnerMissing")
+	}
+	return c.setIDPTokenVault(ctx, idpID, resourceOwner, enabled, org.NewIDPTokenVaultSetEvent(
+		ctx,
+		&org.NewAggregate(resourceOwner).Aggregate,
+		idpID,
+		enabled,
+	))
+}
+
+func (c *Commands) setIDPTokenVault(ctx context.Context, idpID, resourceOwner string, enabled bool, event eventstore.Command) (*domain.ObjectDetails, error) {
+	writeModel := NewIDPTokenVaultWriteModel(idpID, resourceOwner)
+	err := c.setIDPSetting(ctx, idpID, resourceOwner, checkTokenVaultIDPType, writeModel,
+		func() bool { return writeModel.Enabled != enabled },
+		event,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func checkTokenVaultIDPType(idpType domain.IDPType) error {
+	if !idpType.SupportsTokenVault() {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-gai0O", "Errors.IDPConfig.TokenVaultNotSupported")
+	}
+	return nil
+}
+
+func (c *Commands) idpTokenVaultWriteModel(ctx context.Context, idpID, resourceOwner string) (_ *IDPTokenVaultWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewIDPTokenVaultWriteModel(idpID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) userIDPLinkTokensWriteModel(ctx context.Context, userID, idpID, externalUserID, resourceOwner string) (_ *UserIDPLinkTokensWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewUserIDPLinkTokensWriteModel(userID, idpID, externalUserID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+// StoreUserIDPLinkTokens stores the tokens of the session on the IDP link of the user, if the token vault of the IDP is enabled.
+// Sessions without an access token and users not (yet) linked to the IDP are ignored.
+func (c *Commands) StoreUserIDPLinkTokens(ctx context.Context, userID, resourceOwner, idpID, externalUserID string, session idp.Session) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	tokens := idpSessionTokens(session)
+	if tokens == nil || tokens.Token == nil || tokens.AccessToken == "" {
+		return nil
+	}
+	vault, err := c.idpTokenVaultWriteModel(ctx, idpID, "")
+	if err != nil || !vault.Enabled {
+		return err
+	}
+	writeModel, err := c.userIDPLinkTokensWriteModel(ctx, userID, idpID, externalUserID, resourceOwner)
+	if err != nil || writeModel.State != domain.UserIDPLinkStateActive {
+		return err
+	}
+	return c.pushUserIDPLinkTokens(ctx, writeModel, tokens)
+}
+
+// pushUserIDPLinkTokens encrypts and stores the tokens on the IDP link.
+// Providers do not necessarily return a refresh or id token on every authentication or refresh,
+// in which case the previously stored one is kept.
+func (c *Commands) pushUserIDPLinkTokens(ctx context.Context, writeModel *UserIDPLinkTokensWriteModel, tokens *oidc.Tokens[*oidc.IDTokenClaims]) error {
+	accessToken, err := crypto.Encrypt([]byte(tokens.AccessToken), c.idpConfigEncryption)
+	if err != nil {
+		return err
+	}
+	refreshToken := writeModel.RefreshToken
+	if tokens.RefreshToken != "" {
+		refreshToken, err = crypto.Encrypt([]byte(tokens.RefreshToken), c.idpConfigEncryption)
+		if err != nil {
+			return err
+		}
+	}
+	idToken := writeModel.IDToken
+	if tokens.IDToken != "" {
+		idToken, err = crypto.Encrypt([]byte(tokens.IDToken), c.idpConfigEncryption)
+		if err != nil {
+			return err
+		}
+	}
+	return c.pushAppendAndReduce(ctx, writeModel, user.NewUserIDPLinkTokensSetEvent(
+		ctx,
+		UserAggregateFromWriteModel(&writeModel.WriteModel),
+		writeModel.IDPConfigID,
+		writeModel.ExternalUserID,
+		accessToken,
+		refreshToken,
+		idToken,
+		tokens.TokenType,
+		tokens.Expiry,
+	))
+}
+
+// UserIDPLinkTokens returns the stored tokens of the IDP link of the user.
+// An expired access token is renewed using the stored refresh token, if the IDP supports it.
+func (c *Commands) UserIDPLinkTokens(ctx context.Context, userID, resourceOwner, idpID, externalUserID string) (_ *domain.IDPLinkTokens, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || idpID == "" || externalUserID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ohC9u", "Errors.IDMissing")
+	}
+	vault, err := c.idpTokenVaultWriteModel(ctx, idpID, "")
+	if err != nil {
+		return nil, err
+	}
+	writeModel, err := c.userIDPLinkTokensWriteModel(ctx, userID, idpID, externalUserID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !vault.Enabled || !writeModel.hasTokens() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ua5ie", "Errors.User.ExternalIDP.TokensNotFound")
+	}
+	if !writeModel.Expiry.IsZero() && time.Now().Add(idpLinkTokensExpiryDelta).After(writeModel.Expiry) {
+		if err = c.refreshUserIDPLinkTokens(ctx, writeModel); err != nil {
+			return nil, err
+		}
+	}
+	return c.userIDPLinkTokensToDomain(writeModel)
+}
+
+func (c *Commands) refreshUserIDPLinkTokens(ctx context.Context, writeModel *UserIDPLinkTokensWriteModel) error {
+	if writeModel.RefreshToken == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Xoo7i", "Errors.User.ExternalIDP.TokensExpired")
+	}
+	refreshToken, err := crypto.DecryptString(writeModel.RefreshToken, c.idpConfigEncryption)
+	if err != nil {
+		return err
+	}
+	provider, err := c.GetProvider(ctx, writeModel.IDPConfigID, "", "")
+	if err != nil {
+		return err
+	}
+	refresher, ok := provider.(idp.ProviderSupportsRefresh)
+	if !ok {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieF4c", "Errors.User.ExternalIDP.TokensExpired")
+	}
+	tokens, err := refresher.RefreshTokens(ctx, refreshToken)
+	if err != nil {
+		return zerrors.ThrowPreconditionFailed(err, "COMMAND-Ahf3o", "Errors.User.ExternalIDP.TokensExpired")
+	}
+	return c.pushUserIDPLinkTokens(ctx, writeModel, tokens)
+}
+
+func (c *Commands) userIDPLinkTokensToDomain(writeModel *UserIDPLinkTokensWriteModel) (*domain.IDPLinkTokens, error) {
+	accessToken, err := crypto.DecryptString(writeModel.AccessToken, c.idpConfigEncryption)
+	if err != nil {
+		return nil, err
+	}
+	var idToken string
+	if writeModel.IDToken != nil {
+		idToken, err = crypto.DecryptString(writeModel.IDToken, c.idpConfigEncryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &domain.IDPLinkTokens{
+		IDPID:          writeModel.IDPConfigID,
+		ExternalUserID: writeModel.ExternalUserID,
+		AccessToken:    accessToken,
+		TokenType:      writeModel.TokenType,
+		Expiry:         writeModel.Expiry,
+		IDToken:        idToken,
+	}, nil
+}
+
+// RemoveUserIDPLinkTokens removes the stored tokens of the IDP link of the user.
+// The link itself is kept and new tokens will be stored on the next authentication of the user.
+func (c *Commands) RemoveUserIDPLinkTokens(ctx context.Context, userID, resourceOwner, idpID, externalUserID string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || idpID == "" || externalUserID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Aix0u", "Errors.IDMissing")
+	}
+	writeModel, err := c.userIDPLinkTokensWriteModel(ctx, userID, idpID, externalUserID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.hasTokens() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-ooZ8e", "Errors.User.ExternalIDP.TokensNotFound")
+	}
+	err = c.pushAppendAndReduce(ctx, writeModel, user.NewUserIDPLinkTokensRemovedEvent(
+		ctx,
+		UserAggregateFromWriteModel(&writeModel.WriteModel),
+		writeModel.IDPConfigID,
+		writeModel.ExternalUserID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
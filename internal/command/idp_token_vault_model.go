@@ -0,0 +1,197 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// IDPTokenVaultWriteModel contains the token vault setting of an IDP,
+// which can either be defined on the instance or an organization.
+type IDPTokenVaultWriteModel struct {
+	eventstore.WriteModel
+
+	ID      string
+	Enabled bool
+}
+
+func NewIDPTokenVaultWriteModel(id, resourceOwner string) *IDPTokenVaultWriteModel {
+	return &IDPTokenVaultWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: resourceOwner,
+		},
+		ID: id,
+	}
+}
+
+func (wm *IDPTokenVaultWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.IDPTokenVaultSetEvent:
+			wm.WriteModel.AppendEvents(&e.TokenVaultSetEvent)
+		case *org.IDPTokenVaultSetEvent:
+			wm.WriteModel.AppendEvents(&e.TokenVaultSetEvent)
+		case *instance.IDPRemovedEvent:
+			wm.WriteModel.AppendEvents(&e.RemovedEvent)
+		case *org.IDPRemovedEvent:
+			wm.WriteModel.AppendEvents(&e.RemovedEvent)
+		}
+	}
+}
+
+func (wm *IDPTokenVaultWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.TokenVaultSetEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.Enabled = e.Enabled
+		case *idp.RemovedEvent:
+			if e.ID != wm.ID {
+				continue
+			}
+			wm.Enabled = false
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *IDPTokenVaultWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPTokenVaultSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPTokenVaultSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// UserIDPLinkTokensWriteModel contains the stored tokens of an IDP link of a user.
+// The tokens are discarded as soon as the link or the user is removed.
+type UserIDPLinkTokensWriteModel struct {
+	eventstore.WriteModel
+
+	IDPConfigID    string
+	ExternalUserID string
+	State          domain.UserIDPLinkState
+
+	AccessToken  *crypto.CryptoValue
+	RefreshToken *crypto.CryptoValue
+	IDToken      *crypto.CryptoValue
+	TokenType    string
+	Expiry       time.Time
+}
+
+func NewUserIDPLinkTokensWriteModel(userID, idpConfigID, externalUserID, resourceOwner string) *UserIDPLinkTokensWriteModel {
+	return &UserIDPLinkTokensWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		IDPConfigID:    idpConfigID,
+		ExternalUserID: externalUserID,
+	}
+}
+
+func (wm *UserIDPLinkTokensWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.UserIDPLinkAddedEvent:
+			if e.IDPConfigID != wm.IDPConfigID || e.ExternalUserID != wm.ExternalUserID {
+				continue
+			}
+			wm.State = domain.UserIDPLinkStateActive
+			wm.removeTokens()
+		case *user.UserIDPExternalIDMigratedEvent:
+			if e.IDPConfigID != wm.IDPConfigID || e.PreviousID != wm.ExternalUserID {
+				continue
+			}
+			wm.ExternalUserID = e.NewID
+		case *user.UserIDPLinkRemovedEvent:
+			if e.IDPConfigID != wm.IDPConfigID || e.ExternalUserID != wm.ExternalUserID {
+				continue
+			}
+			wm.State = domain.UserIDPLinkStateRemoved
+			wm.removeTokens()
+		case *user.UserIDPLinkCascadeRemovedEvent:
+			if e.IDPConfigID != wm.IDPConfigID || e.ExternalUserID != wm.ExternalUserID {
+				continue
+			}
+			wm.State = domain.UserIDPLinkStateRemoved
+			wm.removeTokens()
+		case *user.UserIDPLinkTokensSetEvent:
+			if e.IDPConfigID != wm.IDPConfigID || e.ExternalUserID != wm.ExternalUserID {
+				continue
+			}
+			wm.AccessToken = e.AccessToken
+			wm.RefreshToken = e.RefreshToken
+			wm.IDToken = e.IDToken
+			wm.TokenType = e.TokenType
+			wm.Expiry = e.Expiry
+		case *user.UserIDPLinkTokensRemovedEvent:
+			if e.IDPConfigID != wm.IDPConfigID || e.ExternalUserID != wm.ExternalUserID {
+				continue
+			}
+			wm.removeTokens()
+		case *user.UserRemovedEvent:
+			wm.State = domain.UserIDPLinkStateRemoved
+			wm.removeTokens()
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserIDPLinkTokensWriteModel) removeTokens() {
+	wm.AccessToken = nil
+	wm.RefreshToken = nil
+	wm.IDToken = nil
+	wm.TokenType = ""
+	wm.Expiry = time.Time{}
+}
+
+func (wm *UserIDPLinkTokensWriteModel) hasTokens() bool {
+	return wm.State == domain.UserIDPLinkStateActive && wm.AccessToken != nil
+}
+
+func (wm *UserIDPLinkTokensWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.UserIDPLinkAddedType,
+			user.UserIDPExternalIDMigratedType,
+			user.UserIDPLinkRemovedType,
+			user.UserIDPLinkCascadeRemovedType,
+			user.UserIDPLinkTokensSetType,
+			user.UserIDPLinkTokensRemovedType,
+			user.UserRemovedType,
+		).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
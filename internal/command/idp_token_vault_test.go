@@ -0,0 +1,448 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/oauth2"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_SetInstanceIDPTokenVault(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx     context.Context
+		idpID   string
+		enabled bool
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "idp not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				idpID:   "idp1",
+				enabled: true,
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "ldap idp, precondition failed error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceLDAPIDPAddedEvent("idp1")),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				idpID:   "idp1",
+				enabled: true,
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "enable, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent("idp1")),
+					),
+					expectFilter(),
+					expectPush(
+						instance.NewIDPTokenVaultSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"idp1",
+							true,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				idpID:   "idp1",
+				enabled: true,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "unchanged, no push",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceGitHubIDPAddedEvent("idp1")),
+					),
+					expectFilter(
+						eventFromEventPusher(instanceTokenVaultSetEvent("idp1")),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				idpID:   "idp1",
+				enabled: true,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetInstanceIDPTokenVault(tt.args.ctx, tt.args.idpID, tt.args.enabled)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_StoreUserIDPLinkTokens(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).UTC()
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		session idp.Session
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+	}{
+		{
+			name: "no oauth session, ignored",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				session: &ldap.Session{},
+			},
+		},
+		{
+			name: "token vault disabled, ignored",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				session: oauthSessionWithTokens("access", "", expiry),
+			},
+		},
+		{
+			name: "user not linked, ignored",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceTokenVaultSetEvent("idp1")),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				session: oauthSessionWithTokens("access", "", expiry),
+			},
+		},
+		{
+			name: "stored, previous refresh token kept",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceTokenVaultSetEvent("idp1")),
+					),
+					expectFilter(
+						eventFromEventPusher(userIDPLinkAddedEvent("idp1", "ext1")),
+						eventFromEventPusher(userIDPLinkTokensSetEvent("old", "refresh", time.Time{})),
+					),
+					expectPush(
+						userIDPLinkTokensSetEvent("access", "refresh", expiry),
+					),
+				),
+			},
+			args: args{
+				session: oauthSessionWithTokens("access", "", expiry),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore(t),
+				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			err := c.StoreUserIDPLinkTokens(authz.WithInstanceID(context.Background(), "instance1"), "user1", "org1", "idp1", "ext1", tt.args.session)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCommands_UserIDPLinkTokens(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).UTC()
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type res struct {
+		want *domain.IDPLinkTokens
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "token vault disabled, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(userIDPLinkAddedEvent("idp1", "ext1")),
+						eventFromEventPusher(userIDPLinkTokensSetEvent("access", "", expiry)),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "link removed, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceTokenVaultSetEvent("idp1")),
+					),
+					expectFilter(
+						eventFromEventPusher(userIDPLinkAddedEvent("idp1", "ext1")),
+						eventFromEventPusher(userIDPLinkTokensSetEvent("access", "", expiry)),
+						eventFromEventPusher(
+							user.NewUserIDPLinkRemovedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp1", "ext1",
+							),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "expired without refresh token, precondition failed error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceTokenVaultSetEvent("idp1")),
+					),
+					expectFilter(
+						eventFromEventPusher(userIDPLinkAddedEvent("idp1", "ext1")),
+						eventFromEventPusher(userIDPLinkTokensSetEvent("access", "", time.Now().Add(-time.Minute))),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "valid tokens, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(instanceTokenVaultSetEvent("idp1")),
+					),
+					expectFilter(
+						eventFromEventPusher(userIDPLinkAddedEvent("idp1", "ext1")),
+						eventFromEventPusher(userIDPLinkTokensSetEvent("access", "refresh", expiry)),
+					),
+				),
+			},
+			res: res{
+				want: &domain.IDPLinkTokens{
+					IDPID:          "idp1",
+					ExternalUserID: "ext1",
+					AccessToken:    "access",
+					TokenType:      oidc.BearerToken,
+					Expiry:         expiry,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore(t),
+				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := c.UserIDPLinkTokens(authz.WithInstanceID(context.Background(), "instance1"), "user1", "org1", "idp1", "ext1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveUserIDPLinkTokens(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "no tokens, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(userIDPLinkAddedEvent("idp1", "ext1")),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "remove, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(userIDPLinkAddedEvent("idp1", "ext1")),
+						eventFromEventPusher(userIDPLinkTokensSetEvent("access", "refresh", time.Time{})),
+					),
+					expectPush(
+						user.NewUserIDPLinkTokensRemovedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+							"idp1", "ext1",
+						),
+					),
+				),
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.RemoveUserIDPLinkTokens(authz.WithInstanceID(context.Background(), "instance1"), "user1", "org1", "idp1", "ext1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func instanceTokenVaultSetEvent(id string) *instance.IDPTokenVaultSetEvent {
+	return instance.NewIDPTokenVaultSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+		id, true,
+	)
+}
+
+func userIDPLinkAddedEvent(idpID, externalUserID string) *user.UserIDPLinkAddedEvent {
+	return user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+		idpID, "name", externalUserID,
+	)
+}
+
+func userIDPLinkTokensSetEvent(accessToken, refreshToken string, expiry time.Time) *user.UserIDPLinkTokensSetEvent {
+	return user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+		"idp1", "ext1",
+		mockEncryptedValue(accessToken),
+		mockEncryptedValue(refreshToken),
+		nil,
+		oidc.BearerToken,
+		expiry,
+	)
+}
+
+// mockEncryptedValue returns the value as encrypted by [crypto.CreateMockEncryptionAlg]
+func mockEncryptedValue(value string) *crypto.CryptoValue {
+	if value == "" {
+		return nil
+	}
+	return &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte(value),
+	}
+}
+
+func oauthSessionWithTokens(accessToken, refreshToken string, expiry time.Time) *oauth.Session {
+	return &oauth.Session{
+		Tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+			Token: &oauth2.Token{
+				AccessToken:  accessToken,
+				RefreshToken: refreshToken,
+				TokenType:    oidc.BearerToken,
+				Expiry:       expiry,
+			},
+		},
+	}
+}
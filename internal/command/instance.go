@@ -43,76 +43,116 @@ type InstanceSetup struct {
 	DefaultLanguage          language.Tag
 	Org                      InstanceOrgSetup
 	SecretGenerators         *SecretGenerators
-	PasswordComplexityPolicy struct {
-		MinLength    uint64
-		HasLowercase bool
-		HasUppercase bool
-		HasNumber    bool
-		HasSymbol    bool
-	}
-	PasswordAgePolicy struct {
-		ExpireWarnDays uint64
-		MaxAgeDays     uint64
-	}
-	DomainPolicy struct {
-		UserLoginMustBeDomain                  bool
-		ValidateOrgDomains                     bool
-		SMTPSenderAddressMatchesInstanceDomain bool
-	}
-	LoginPolicy struct {
-		AllowUsernamePassword      bool
-		AllowRegister              bool
-		AllowExternalIDP           bool
-		ForceMFA                   bool
-		ForceMFALocalOnly          bool
-		HidePasswordReset          bool
-		IgnoreUnknownUsername      bool
-		AllowDomainDiscovery       bool
-		DisableLoginWithEmail      bool
-		DisableLoginWithPhone      bool
-		PasswordlessType           domain.PasswordlessType
-		DefaultRedirectURI         string
-		PasswordCheckLifetime      time.Duration
-		ExternalLoginCheckLifetime time.Duration
-		MfaInitSkipLifetime        time.Duration
-		SecondFactorCheckLifetime  time.Duration
-		MultiFactorCheckLifetime   time.Duration
-	}
-	NotificationPolicy struct {
-		PasswordChange bool
-	}
-	PrivacyPolicy struct {
-		TOSLink      string
-		PrivacyLink  string
-		HelpLink     string
-		SupportEmail domain.EmailAddress
-	}
-	LabelPolicy struct {
-		PrimaryColor        string
-		BackgroundColor     string
-		WarnColor           string
-		FontColor           string
-		PrimaryColorDark    string
-		BackgroundColorDark string
-		WarnColorDark       string
-		FontColorDark       string
-		HideLoginNameSuffix bool
-		ErrorMsgPopup       bool
-		DisableWatermark    bool
-		ThemeMode           domain.LabelPolicyThemeMode
-	}
-	LockoutPolicy struct {
-		MaxAttempts              uint64
-		ShouldShowLockoutFailure bool
-	}
-	EmailTemplate     []byte
-	MessageTexts      []*domain.CustomMessageText
-	SMTPConfiguration *smtp.Config
-	OIDCSettings      *OIDCSettings
-	Quotas            *SetQuotas
-	Features          map[domain.Feature]any
-	Limits            *SetLimits
-	Restrictions      *SetRestrictions
+	PasswordComplexityPolicy InstancePasswordComplexityPolicy
+	PasswordAgePolicy        InstancePasswordAgePolicy
+	DomainPolicy             InstanceDomainPolicy
+	LoginPolicy              InstanceLoginPolicy
+	NotificationPolicy       InstanceNotificationPolicy
+	PrivacyPolicy            InstancePrivacyPolicy
+	LabelPolicy              InstanceLabelPolicy
+	LockoutPolicy            InstanceLockoutPolicy
+	EmailTemplate            []byte
+	MessageTexts             []*domain.CustomMessageText
+	SMTPConfiguration        *smtp.Config
+	OIDCSettings             *OIDCSettings
+	Quotas                   *SetQuotas
+	Features                 map[domain.Feature]any
+	Limits                   *SetLimits
+	Restrictions             *SetRestrictions
+	// Template overwrites the settings and adds its resources to the default organisation
+	Template *InstanceTemplate
+}
+
+type InstancePasswordComplexityPolicy struct {
+	MinLength    uint64
+	HasLowercase bool
+	HasUppercase bool
+	HasNumber    bool
+	HasSymbol    bool
+}
+
+type InstancePasswordAgePolicy struct {
+	ExpireWarnDays uint64
+	MaxAgeDays     uint64
+}
+
+type InstanceDomainPolicy struct {
+	UserLoginMustBeDomain                  bool
+	ValidateOrgDomains                     bool
+	SMTPSenderAddressMatchesInstanceDomain bool
+}
+
+type InstanceLoginPolicy struct {
+	AllowUsernamePassword      bool
+	AllowRegister              bool
+	AllowExternalIDP           bool
+	ForceMFA                   bool
+	ForceMFALocalOnly          bool
+	HidePasswordReset          bool
+	IgnoreUnknownUsername      bool
+	AllowDomainDiscovery       bool
+	DisableLoginWithEmail      bool
+	DisableLoginWithPhone      bool
+	AllowMagicLink             bool
+	AllowPhoneOTPLogin         bool
+	PasswordlessType           domain.PasswordlessType
+	DefaultRedirectURI         string
+	PasswordCheckLifetime      time.Duration
+	ExternalLoginCheckLifetime time.Duration
+	MfaInitSkipLifetime        time.Duration
+	SecondFactorCheckLifetime  time.Duration
+	MultiFactorCheckLifetime   time.Duration
+}
+
+type InstanceNotificationPolicy struct {
+	PasswordChange         bool
+	UnknownUserAgentSignIn bool
+	MFAAdded               bool
+	MFARemoved             bool
+	EmailChanged           bool
+	PhoneChanged           bool
+	AccountLocked          bool
+	MachineCredentialAdded bool
+}
+
+func (p *InstanceNotificationPolicy) toDomain() *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		PasswordChange:         p.PasswordChange,
+		UnknownUserAgentSignIn: p.UnknownUserAgentSignIn,
+		MFAAdded:               p.MFAAdded,
+		MFARemoved:             p.MFARemoved,
+		EmailChanged:           p.EmailChanged,
+		PhoneChanged:           p.PhoneChanged,
+		AccountLocked:          p.AccountLocked,
+		MachineCredentialAdded: p.MachineCredentialAdded,
+	}
+}
+
+type InstancePrivacyPolicy struct {
+	TOSLink      string
+	PrivacyLink  string
+	HelpLink     string
+	SupportEmail domain.EmailAddress
+}
+
+type InstanceLabelPolicy struct {
+	PrimaryColor        string
+	BackgroundColor     string
+	WarnColor           string
+	FontColor           string
+	PrimaryColorDark    string
+	BackgroundColorDark string
+	WarnColorDark       string
+	FontColorDark       string
+	HideLoginNameSuffix bool
+	ErrorMsgPopup       bool
+	DisableWatermark    bool
+	ThemeMode           domain.LabelPolicyThemeMode
+}
+
+type InstanceLockoutPolicy struct {
+	MaxAttempts              uint64
+	ShouldShowLockoutFailure bool
 }
 
 type OIDCSettings struct {
@@ -137,6 +177,8 @@ type SecretGenerators struct {
 	DomainVerification       *crypto.GeneratorConfig
 	OTPSMS                   *crypto.GeneratorConfig
 	OTPEmail                 *crypto.GeneratorConfig
+	MagicLinkCode            *crypto.GeneratorConfig
+	EmailChangeRevertCode    *crypto.GeneratorConfig
 }
 
 type ZitadelConfig struct {
@@ -203,6 +245,9 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 	if err = setup.generateIDs(c.idGenerator); err != nil {
 		return "", "", nil, nil, err
 	}
+	if setup.Template != nil {
+		setup.Template.applySettings(setup)
+	}
 	ctx = authz.WithConsole(ctx, setup.zitadel.projectID, setup.zitadel.consoleAppID)
 
 	instanceAgg := instance.NewAggregate(instanceID)
@@ -223,6 +268,8 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeVerifyDomain, setup.SecretGenerators.DomainVerification),
 		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeOTPSMS, setup.SecretGenerators.OTPSMS),
 		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeOTPEmail, setup.SecretGenerators.OTPEmail),
+		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeMagicLinkCode, setup.SecretGenerators.MagicLinkCode),
+		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeEmailChangeRevertCode, setup.SecretGenerators.EmailChangeRevertCode),
 
 		prepareAddDefaultPasswordComplexityPolicy(
 			instanceAgg,
@@ -255,6 +302,8 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 			setup.LoginPolicy.AllowDomainDiscovery,
 			setup.LoginPolicy.DisableLoginWithEmail,
 			setup.LoginPolicy.DisableLoginWithPhone,
+			setup.LoginPolicy.AllowMagicLink,
+			setup.LoginPolicy.AllowPhoneOTPLogin,
 			setup.LoginPolicy.PasswordlessType,
 			setup.LoginPolicy.DefaultRedirectURI,
 			setup.LoginPolicy.PasswordCheckLifetime,
@@ -272,7 +321,7 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 		prepareAddMultiFactorToDefaultLoginPolicy(instanceAgg, domain.MultiFactorTypeU2FWithPIN),
 
 		prepareAddDefaultPrivacyPolicy(instanceAgg, setup.PrivacyPolicy.TOSLink, setup.PrivacyPolicy.PrivacyLink, setup.PrivacyPolicy.HelpLink, setup.PrivacyPolicy.SupportEmail),
-		prepareAddDefaultNotificationPolicy(instanceAgg, setup.NotificationPolicy.PasswordChange),
+		prepareAddDefaultNotificationPolicy(instanceAgg, setup.NotificationPolicy.toDomain()),
 		prepareAddDefaultLockoutPolicy(instanceAgg, setup.LockoutPolicy.MaxAttempts, setup.LockoutPolicy.ShouldShowLockoutFailure),
 
 		prepareAddDefaultLabelPolicy(
@@ -290,10 +339,13 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 			setup.LabelPolicy.DisableWatermark,
 			setup.LabelPolicy.ThemeMode,
 		),
+	}
+	setupTemplateLabelPolicyAssets(c, &validations, setup.Template, instanceAgg)
+	validations = append(validations,
 		prepareActivateDefaultLabelPolicy(instanceAgg),
 
 		prepareAddDefaultEmailTemplate(instanceAgg, setup.EmailTemplate),
-	}
+	)
 	if err := setupQuotas(c, &validations, setup.Quotas, instanceID); err != nil {
 		return "", "", nil, nil, err
 	}
@@ -307,6 +359,9 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 		return "", "", nil, nil, err
 	}
 	setupMinimalInterfaces(c, &validations, instanceAgg, projectAgg, orgAgg, userID, setup.zitadel)
+	if err := setupTemplate(c, &validations, setup.Template, instanceAgg, orgAgg, userID); err != nil {
+		return "", "", nil, nil, err
+	}
 	if err := setupGeneratedDomain(ctx, c, &validations, instanceAgg, setup.InstanceName); err != nil {
 		return "", "", nil, nil, err
 	}
@@ -403,9 +458,12 @@ func setupSMTPSettings(commands *Commands, validations *[]preparation.Validation
 	if smtpConfig == nil {
 		return
 	}
+	// the configuration of the setup uses the id of the instance and is therefore active right away
 	*validations = append(*validations,
 		commands.prepareAddSMTPConfig(
 			instanceAgg,
+			instanceAgg.ID,
+			"default",
 			smtpConfig.From,
 			smtpConfig.FromName,
 			smtpConfig.ReplyToAddress,
@@ -413,6 +471,7 @@ func setupSMTPSettings(commands *Commands, validations *[]preparation.Validation
 			smtpConfig.SMTP.User,
 			[]byte(smtpConfig.SMTP.Password),
 			smtpConfig.Tls,
+			smtpConfigConnection(&smtpConfig.SMTP),
 		),
 	)
 }
@@ -42,6 +42,8 @@ func writeModelToLoginPolicy(wm *LoginPolicyWriteModel) *domain.LoginPolicy {
 		MFAInitSkipLifetime:        wm.MFAInitSkipLifetime,
 		SecondFactorCheckLifetime:  wm.SecondFactorCheckLifetime,
 		MultiFactorCheckLifetime:   wm.MultiFactorCheckLifetime,
+		AllowMagicLink:             wm.AllowMagicLink,
+		AllowPhoneOTPLogin:         wm.AllowPhoneOTPLogin,
 	}
 }
 
@@ -70,7 +70,9 @@ func (wm *InstanceLoginPolicyWriteModel) NewChangedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowMagicLink,
+	allowPhoneOTPLogin bool,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
@@ -132,6 +134,12 @@ func (wm *InstanceLoginPolicyWriteModel) NewChangedEvent(
 	if wm.DisableLoginWithPhone != disableLoginWithPhone {
 		changes = append(changes, policy.ChangeDisableLoginWithPhone(disableLoginWithPhone))
 	}
+	if wm.AllowMagicLink != allowMagicLink {
+		changes = append(changes, policy.ChangeAllowMagicLink(allowMagicLink))
+	}
+	if wm.AllowPhoneOTPLogin != allowPhoneOTPLogin {
+		changes = append(changes, policy.ChangeAllowPhoneOTPLogin(allowPhoneOTPLogin))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
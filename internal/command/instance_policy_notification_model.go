@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/policy"
@@ -54,12 +55,33 @@ func (wm *InstanceNotificationPolicyWriteModel) Query() *eventstore.SearchQueryB
 func (wm *InstanceNotificationPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	notificationPolicy *domain.NotificationPolicy,
 ) (*instance.NotificationPolicyChangedEvent, bool) {
 
 	changes := make([]policy.NotificationPolicyChanges, 0)
-	if wm.PasswordChange != passwordChange {
-		changes = append(changes, policy.ChangePasswordChange(passwordChange))
+	if wm.PasswordChange != notificationPolicy.PasswordChange {
+		changes = append(changes, policy.ChangePasswordChange(notificationPolicy.PasswordChange))
+	}
+	if wm.UnknownUserAgentSignIn != notificationPolicy.UnknownUserAgentSignIn {
+		changes = append(changes, policy.ChangeUnknownUserAgentSignIn(notificationPolicy.UnknownUserAgentSignIn))
+	}
+	if wm.MFAAdded != notificationPolicy.MFAAdded {
+		changes = append(changes, policy.ChangeMFAAdded(notificationPolicy.MFAAdded))
+	}
+	if wm.MFARemoved != notificationPolicy.MFARemoved {
+		changes = append(changes, policy.ChangeMFARemoved(notificationPolicy.MFARemoved))
+	}
+	if wm.EmailChanged != notificationPolicy.EmailChanged {
+		changes = append(changes, policy.ChangeEmailChanged(notificationPolicy.EmailChanged))
+	}
+	if wm.PhoneChanged != notificationPolicy.PhoneChanged {
+		changes = append(changes, policy.ChangePhoneChanged(notificationPolicy.PhoneChanged))
+	}
+	if wm.AccountLocked != notificationPolicy.AccountLocked {
+		changes = append(changes, policy.ChangeAccountLocked(notificationPolicy.AccountLocked))
+	}
+	if wm.MachineCredentialAdded != notificationPolicy.MachineCredentialAdded {
+		changes = append(changes, policy.ChangeMachineCredentialAdded(notificationPolicy.MachineCredentialAdded))
 	}
 	if len(changes) == 0 {
 		return nil, false
@@ -18,9 +18,9 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		resourceOwner  string
-		passwordChange bool
+		ctx           context.Context
+		resourceOwner string
+		policy        *domain.NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -42,15 +42,22 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				policy:        &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsErrorAlreadyExists,
@@ -66,14 +73,21 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 						instance.NewNotificationPolicyAddedEvent(context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
 							true,
+							false,
+							false,
+							false,
+							false,
+							false,
+							false,
+							false,
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				policy:        &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -91,14 +105,21 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 						instance.NewNotificationPolicyAddedEvent(context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
 							true,
+							false,
+							false,
+							false,
+							false,
+							false,
+							false,
+							false,
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				policy:        &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -112,7 +133,7 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange)
+			got, err := r.AddDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.policy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -131,9 +152,9 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		resourceOwner  string
-		passwordChange bool
+		ctx           context.Context
+		resourceOwner string
+		policy        *domain.NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -154,9 +175,9 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				policy:        &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsNotFound,
@@ -172,15 +193,22 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				policy:        &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsPreconditionFailed,
@@ -196,6 +224,13 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
@@ -207,9 +242,60 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				policy:        &domain.NotificationPolicy{PasswordChange: true},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "change security notifications, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewNotificationPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+							),
+						),
+					),
+					expectPush(
+						func() *instance.NotificationPolicyChangedEvent {
+							event, _ := instance.NewNotificationPolicyChangedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								[]policy.NotificationPolicyChanges{
+									policy.ChangeUnknownUserAgentSignIn(true),
+									policy.ChangeMFARemoved(true),
+									policy.ChangeAccountLocked(true),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				policy: &domain.NotificationPolicy{
+					PasswordChange:         true,
+					UnknownUserAgentSignIn: true,
+					MFARemoved:             true,
+					AccountLocked:          true,
+				},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -223,7 +309,7 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange)
+			got, err := r.ChangeDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.policy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
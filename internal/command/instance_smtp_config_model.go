@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -12,6 +13,8 @@ import (
 type InstanceSMTPConfigWriteModel struct {
 	eventstore.WriteModel
 
+	ID             string
+	Description    string
 	SenderAddress  string
 	SenderName     string
 	ReplyToAddress string
@@ -19,6 +22,8 @@ type InstanceSMTPConfigWriteModel struct {
 	Host           string
 	User           string
 	Password       *crypto.CryptoValue
+	Connection     *instance.SMTPConfigConnection
+	HTTP           *HTTPConfig
 	State          domain.SMTPConfigState
 
 	domain                                 string
@@ -26,12 +31,13 @@ type InstanceSMTPConfigWriteModel struct {
 	smtpSenderAddressMatchesInstanceDomain bool
 }
 
-func NewInstanceSMTPConfigWriteModel(instanceID, domain string) *InstanceSMTPConfigWriteModel {
+func NewInstanceSMTPConfigWriteModel(instanceID, id, domain string) *InstanceSMTPConfigWriteModel {
 	return &InstanceSMTPConfigWriteModel{
 		WriteModel: eventstore.WriteModel{
 			AggregateID:   instanceID,
 			ResourceOwner: instanceID,
 		},
+		ID:     id,
 		domain: domain,
 	}
 }
@@ -49,6 +55,18 @@ func (wm *InstanceSMTPConfigWriteModel) AppendEvents(events ...eventstore.Event)
 				continue
 			}
 			wm.WriteModel.AppendEvents(e)
+		case *instance.SMTPConfigAddedEvent,
+			*instance.SMTPConfigChangedEvent,
+			*instance.SMTPConfigPasswordChangedEvent,
+			*instance.SMTPConfigRemovedEvent,
+			*instance.SMTPConfigHTTPAddedEvent,
+			*instance.SMTPConfigHTTPChangedEvent,
+			*instance.SMTPConfigActivatedEvent,
+			*instance.SMTPConfigDeactivatedEvent:
+			if smtpConfigEventID(e) != wm.ID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
 		default:
 			wm.WriteModel.AppendEvents(e)
 		}
@@ -60,6 +78,7 @@ func (wm *InstanceSMTPConfigWriteModel) Reduce() error {
 	for _, event := range wm.Events {
 		switch e := event.(type) {
 		case *instance.SMTPConfigAddedEvent:
+			wm.Description = e.Description
 			wm.TLS = e.TLS
 			wm.SenderAddress = e.SenderAddress
 			wm.SenderName = e.SenderName
@@ -67,8 +86,12 @@ func (wm *InstanceSMTPConfigWriteModel) Reduce() error {
 			wm.Host = e.Host
 			wm.User = e.User
 			wm.Password = e.Password
-			wm.State = domain.SMTPConfigStateActive
+			wm.Connection = e.Connection
+			wm.State = smtpConfigAddedState(e.ID)
 		case *instance.SMTPConfigChangedEvent:
+			if e.Description != nil {
+				wm.Description = *e.Description
+			}
 			if e.TLS != nil {
 				wm.TLS = *e.TLS
 			}
@@ -87,8 +110,18 @@ func (wm *InstanceSMTPConfigWriteModel) Reduce() error {
 			if e.User != nil {
 				wm.User = *e.User
 			}
+			if e.Connection != nil {
+				wm.Connection = reduceSMTPConfigConnection(e.Connection)
+			}
+		case *instance.SMTPConfigPasswordChangedEvent:
+			wm.Password = e.Password
+		case *instance.SMTPConfigActivatedEvent:
+			wm.State = domain.SMTPConfigStateActive
+		case *instance.SMTPConfigDeactivatedEvent:
+			wm.State = domain.SMTPConfigStateInactive
 		case *instance.SMTPConfigRemovedEvent:
 			wm.State = domain.SMTPConfigStateRemoved
+			wm.Description = ""
 			wm.TLS = false
 			wm.SenderName = ""
 			wm.SenderAddress = ""
@@ -96,6 +129,29 @@ func (wm *InstanceSMTPConfigWriteModel) Reduce() error {
 			wm.Host = ""
 			wm.User = ""
 			wm.Password = nil
+			wm.Connection = nil
+			wm.HTTP = nil
+		case *instance.SMTPConfigHTTPAddedEvent:
+			wm.Description = e.Description
+			wm.HTTP = &HTTPConfig{
+				Endpoint:   e.Endpoint,
+				Headers:    e.Headers,
+				SigningKey: e.SigningKey,
+			}
+			wm.State = smtpConfigAddedState(e.ID)
+		case *instance.SMTPConfigHTTPChangedEvent:
+			if e.Description != nil {
+				wm.Description = *e.Description
+			}
+			if e.Endpoint != nil {
+				wm.HTTP.Endpoint = *e.Endpoint
+			}
+			if e.Headers != nil {
+				wm.HTTP.Headers = *e.Headers
+			}
+			if e.SigningKey != nil {
+				wm.HTTP.SigningKey = e.SigningKey
+			}
 		case *instance.DomainAddedEvent:
 			wm.domainState = domain.InstanceDomainStateActive
 		case *instance.DomainRemovedEvent:
@@ -122,6 +178,10 @@ func (wm *InstanceSMTPConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.SMTPConfigRemovedEventType,
 			instance.SMTPConfigChangedEventType,
 			instance.SMTPConfigPasswordChangedEventType,
+			instance.SMTPConfigHTTPAddedEventType,
+			instance.SMTPConfigHTTPChangedEventType,
+			instance.SMTPConfigActivatedEventType,
+			instance.SMTPConfigDeactivatedEventType,
 			instance.InstanceDomainAddedEventType,
 			instance.InstanceDomainRemovedEventType,
 			instance.DomainPolicyAddedEventType,
@@ -129,10 +189,13 @@ func (wm *InstanceSMTPConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
-func (wm *InstanceSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, tls bool, fromAddress, fromName, replyToAddress, smtpHost, smtpUser string) (*instance.SMTPConfigChangedEvent, bool, error) {
+func (wm *InstanceSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, description string, tls bool, fromAddress, fromName, replyToAddress, smtpHost, smtpUser string, connection *instance.SMTPConfigConnection) (*instance.SMTPConfigChangedEvent, bool, error) {
 	changes := make([]instance.SMTPConfigChanges, 0)
 	var err error
 
+	if wm.Description != description {
+		changes = append(changes, instance.ChangeSMTPConfigDescription(description))
+	}
 	if wm.TLS != tls {
 		changes = append(changes, instance.ChangeSMTPConfigTLS(tls))
 	}
@@ -151,13 +214,161 @@ func (wm *InstanceSMTPConfigWriteModel) NewChangedEvent(ctx context.Context, agg
 	if wm.User != smtpUser {
 		changes = append(changes, instance.ChangeSMTPConfigSMTPUser(smtpUser))
 	}
+	if !wm.Connection.Equal(connection) {
+		changes = append(changes, instance.ChangeSMTPConfigConnection(smtpConfigConnectionOrDefault(connection)))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
 	}
-	changeEvent, err := instance.NewSMTPConfigChangeEvent(ctx, aggregate, changes)
+	changeEvent, err := instance.NewSMTPConfigChangeEvent(ctx, aggregate, wm.eventID(), changes)
 	if err != nil {
 		return nil, false, err
 	}
 	return changeEvent, true, nil
 }
+
+func (wm *InstanceSMTPConfigWriteModel) NewHTTPChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, description, endpoint string, headers http.Header, signingKey *crypto.CryptoValue) (*instance.SMTPConfigHTTPChangedEvent, bool, error) {
+	changes := make([]instance.SMTPConfigHTTPChanges, 0)
+	if wm.Description != description {
+		changes = append(changes, instance.ChangeSMTPConfigHTTPDescription(description))
+	}
+	if wm.HTTP.Endpoint != endpoint {
+		changes = append(changes, instance.ChangeSMTPConfigHTTPEndpoint(endpoint))
+	}
+	if !httpHeadersEqual(wm.HTTP.Headers, headers) {
+		changes = append(changes, instance.ChangeSMTPConfigHTTPHeaders(headers))
+	}
+	if signingKey != nil {
+		changes = append(changes, instance.ChangeSMTPConfigHTTPSigningKey(signingKey))
+	}
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewSMTPConfigHTTPChangedEvent(ctx, aggregate, wm.eventID(), changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
+
+// reduceSMTPConfigConnection maps the connection of a changed event,
+// where the defaults are stored as empty connection
+func reduceSMTPConfigConnection(connection *instance.SMTPConfigConnection) *instance.SMTPConfigConnection {
+	if connection.Equal(&instance.SMTPConfigConnection{}) {
+		return nil
+	}
+	return connection
+}
+
+func smtpConfigConnectionOrDefault(connection *instance.SMTPConfigConnection) instance.SMTPConfigConnection {
+	if connection == nil {
+		return instance.SMTPConfigConnection{}
+	}
+	return *connection
+}
+
+// eventID returns the id set on new events of the configuration,
+// which is empty for the configuration created before multiple configurations were possible
+func (wm *InstanceSMTPConfigWriteModel) eventID() string {
+	return smtpConfigIDForEvent(wm.AggregateID, wm.ID)
+}
+
+// smtpConfigIDForEvent is the inverse of smtpConfigEventID
+func smtpConfigIDForEvent(instanceID, id string) string {
+	if id == instanceID {
+		return ""
+	}
+	return id
+}
+
+// InstanceSMTPConfigActiveWriteModel keeps track of the active SMTP configuration of the instance
+type InstanceSMTPConfigActiveWriteModel struct {
+	eventstore.WriteModel
+
+	ActiveID string
+}
+
+func NewInstanceSMTPConfigActiveWriteModel(instanceID string) *InstanceSMTPConfigActiveWriteModel {
+	return &InstanceSMTPConfigActiveWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   instanceID,
+			ResourceOwner: instanceID,
+		},
+	}
+}
+
+func (wm *InstanceSMTPConfigActiveWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.SMTPConfigAddedEvent:
+			if smtpConfigAddedState(e.ID) == domain.SMTPConfigStateActive {
+				wm.ActiveID = smtpConfigEventID(e)
+			}
+		case *instance.SMTPConfigHTTPAddedEvent:
+			if smtpConfigAddedState(e.ID) == domain.SMTPConfigStateActive {
+				wm.ActiveID = smtpConfigEventID(e)
+			}
+		case *instance.SMTPConfigActivatedEvent:
+			wm.ActiveID = smtpConfigEventID(e)
+		case *instance.SMTPConfigDeactivatedEvent, *instance.SMTPConfigRemovedEvent:
+			if smtpConfigEventID(e) == wm.ActiveID {
+				wm.ActiveID = ""
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceSMTPConfigActiveWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.SMTPConfigAddedEventType,
+			instance.SMTPConfigHTTPAddedEventType,
+			instance.SMTPConfigActivatedEventType,
+			instance.SMTPConfigDeactivatedEventType,
+			instance.SMTPConfigRemovedEventType).
+		Builder()
+}
+
+// smtpConfigEventID returns the id of the SMTP configuration the event belongs to,
+// events of the configuration created before multiple configurations were possible
+// have no id and belong to the configuration with the id of the instance
+func smtpConfigEventID(event eventstore.Event) string {
+	var id string
+	switch e := event.(type) {
+	case *instance.SMTPConfigAddedEvent:
+		id = e.ID
+	case *instance.SMTPConfigChangedEvent:
+		id = e.ID
+	case *instance.SMTPConfigPasswordChangedEvent:
+		id = e.ID
+	case *instance.SMTPConfigRemovedEvent:
+		id = e.ID
+	case *instance.SMTPConfigHTTPAddedEvent:
+		id = e.ID
+	case *instance.SMTPConfigHTTPChangedEvent:
+		id = e.ID
+	case *instance.SMTPConfigActivatedEvent:
+		id = e.ID
+	case *instance.SMTPConfigDeactivatedEvent:
+		id = e.ID
+	}
+	if id == "" {
+		return event.Aggregate().ResourceOwner
+	}
+	return id
+}
+
+// smtpConfigAddedState returns the state of a newly added SMTP configuration,
+// the configuration created before multiple configurations were possible was always active
+func smtpConfigAddedState(id string) domain.SMTPConfigState {
+	if id == "" {
+		return domain.SMTPConfigStateActive
+	}
+	return domain.SMTPConfigStateInactive
+}
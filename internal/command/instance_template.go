@@ -0,0 +1,477 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/zitadel/saml/pkg/provider/xml"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// InstanceTemplate contains the settings and resources of an instance which are copied to a new instance.
+// The IDs in the template are only used to reference the resources within the template,
+// new IDs, client ids and client secrets are generated during the set up of the new instance.
+// The resources are created in the default organisation of the new instance.
+type InstanceTemplate struct {
+	DefaultLanguage          language.Tag
+	PasswordComplexityPolicy *InstancePasswordComplexityPolicy
+	PasswordAgePolicy        *InstancePasswordAgePolicy
+	DomainPolicy             *InstanceDomainPolicy
+	LoginPolicy              *InstanceLoginPolicy
+	NotificationPolicy       *InstanceNotificationPolicy
+	PrivacyPolicy            *InstancePrivacyPolicy
+	LabelPolicy              *InstanceLabelPolicy
+	LabelPolicyAssets        []*TemplateLabelPolicyAsset
+	LockoutPolicy            *InstanceLockoutPolicy
+	MessageTexts             []*domain.CustomMessageText
+	LoginTexts               []*TemplateLoginText
+	OIDCSettings             *OIDCSettings
+
+	Projects []*TemplateProject
+	Actions  []*TemplateAction
+	Flows    []*TemplateFlow
+
+	// oidcApps and apiApps are the apps created during the set up of the new instance
+	oidcApps []*addOIDCApp
+	apiApps  []*addAPIApp
+}
+
+type TemplateLabelPolicyAssetType string
+
+const (
+	TemplateLabelPolicyLogo     TemplateLabelPolicyAssetType = "logo"
+	TemplateLabelPolicyLogoDark TemplateLabelPolicyAssetType = "logo_dark"
+	TemplateLabelPolicyIcon     TemplateLabelPolicyAssetType = "icon"
+	TemplateLabelPolicyIconDark TemplateLabelPolicyAssetType = "icon_dark"
+	TemplateLabelPolicyFont     TemplateLabelPolicyAssetType = "font"
+)
+
+// TemplateLabelPolicyAsset is an asset (logo, icon or font) of the label policy,
+// the content is copied to the static storage of the new instance
+type TemplateLabelPolicyAsset struct {
+	Type        TemplateLabelPolicyAssetType
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// TemplateLoginText is a custom text of the login
+type TemplateLoginText struct {
+	Language language.Tag
+	Key      string
+	Text     string
+}
+
+// TemplateAppSecret contains the generated client id and secret of an app of the template
+type TemplateAppSecret struct {
+	ProjectID    string
+	AppID        string
+	Name         string
+	ClientID     string
+	ClientSecret string
+}
+
+type TemplateProject struct {
+	ID                     string
+	Name                   string
+	ProjectRoleAssertion   bool
+	ProjectRoleCheck       bool
+	HasProjectCheck        bool
+	PrivateLabelingSetting domain.PrivateLabelingSetting
+	Roles                  []*TemplateProjectRole
+	OIDCApps               []*TemplateOIDCApp
+	APIApps                []*TemplateAPIApp
+	SAMLApps               []*TemplateSAMLApp
+}
+
+type TemplateProjectRole struct {
+	Key         string
+	DisplayName string
+	Group       string
+}
+
+type TemplateOIDCApp struct {
+	Name                        string
+	Version                     domain.OIDCVersion
+	RedirectUris                []string
+	ResponseTypes               []domain.OIDCResponseType
+	GrantTypes                  []domain.OIDCGrantType
+	ApplicationType             domain.OIDCApplicationType
+	AuthMethodType              domain.OIDCAuthMethodType
+	PostLogoutRedirectUris      []string
+	DevMode                     bool
+	AccessTokenType             domain.OIDCTokenType
+	AccessTokenRoleAssertion    bool
+	IDTokenRoleAssertion        bool
+	IDTokenUserinfoAssertion    bool
+	ClockSkew                   time.Duration
+	AdditionalOrigins           []string
+	SkipSuccessPageForNativeApp bool
+	ConsentRequired             bool
+}
+
+type TemplateAPIApp struct {
+	Name           string
+	AuthMethodType domain.APIAuthMethodType
+}
+
+// TemplateSAMLApp contains the metadata of the service provider,
+// which is used as is, even if it was originally read from the MetadataURL
+type TemplateSAMLApp struct {
+	Name        string
+	Metadata    []byte
+	MetadataURL string
+}
+
+type TemplateAction struct {
+	ID            string
+	Name          string
+	Script        string
+	Timeout       time.Duration
+	AllowedToFail bool
+}
+
+type TemplateFlow struct {
+	FlowType    domain.FlowType
+	TriggerType domain.TriggerType
+	// ActionIDs are the IDs of the [TemplateAction]s of the template
+	ActionIDs []string
+}
+
+// AppSecrets returns the client ids and secrets generated for the confidential apps during the set up of the new instance
+func (t *InstanceTemplate) AppSecrets() []*TemplateAppSecret {
+	secrets := make([]*TemplateAppSecret, 0, len(t.oidcApps)+len(t.apiApps))
+	for _, app := range t.oidcApps {
+		if app.ClientSecretPlain != "" {
+			secrets = append(secrets, &TemplateAppSecret{
+				ProjectID:    app.Aggregate.ID,
+				AppID:        app.ID,
+				Name:         app.Name,
+				ClientID:     app.ClientID,
+				ClientSecret: app.ClientSecretPlain,
+			})
+		}
+	}
+	for _, app := range t.apiApps {
+		if app.ClientSecretPlain != "" {
+			secrets = append(secrets, &TemplateAppSecret{
+				ProjectID:    app.Aggregate.ID,
+				AppID:        app.ID,
+				Name:         app.Name,
+				ClientID:     app.ClientID,
+				ClientSecret: app.ClientSecretPlain,
+			})
+		}
+	}
+	return secrets
+}
+
+// applySettings overwrites the settings of the setup with the ones defined in the template
+func (t *InstanceTemplate) applySettings(setup *InstanceSetup) {
+	if !t.DefaultLanguage.IsRoot() {
+		setup.DefaultLanguage = t.DefaultLanguage
+	}
+	if t.PasswordComplexityPolicy != nil {
+		setup.PasswordComplexityPolicy = *t.PasswordComplexityPolicy
+	}
+	if t.PasswordAgePolicy != nil {
+		setup.PasswordAgePolicy = *t.PasswordAgePolicy
+	}
+	if t.DomainPolicy != nil {
+		setup.DomainPolicy = *t.DomainPolicy
+	}
+	if t.LoginPolicy != nil {
+		setup.LoginPolicy = *t.LoginPolicy
+	}
+	if t.NotificationPolicy != nil {
+		setup.NotificationPolicy = *t.NotificationPolicy
+	}
+	if t.PrivacyPolicy != nil {
+		setup.PrivacyPolicy = *t.PrivacyPolicy
+	}
+	if t.LabelPolicy != nil {
+		setup.LabelPolicy = *t.LabelPolicy
+	}
+	if t.LockoutPolicy != nil {
+		setup.LockoutPolicy = *t.LockoutPolicy
+	}
+	if len(t.MessageTexts) > 0 {
+		setup.MessageTexts = t.MessageTexts
+	}
+	if t.OIDCSettings != nil {
+		setup.OIDCSettings = t.OIDCSettings
+	}
+}
+
+// setupTemplateLabelPolicyAssets prepares the commands which copy the assets of the label policy of the template,
+// they must be added before the label policy is activated
+func setupTemplateLabelPolicyAssets(commands *Commands, validations *[]preparation.Validation, template *InstanceTemplate, instanceAgg *instance.Aggregate) {
+	if template == nil {
+		return
+	}
+	for _, asset := range template.LabelPolicyAssets {
+		*validations = append(*validations, commands.prepareAddTemplateLabelPolicyAsset(instanceAgg, asset))
+	}
+}
+
+// setupTemplate prepares the commands which create the login texts of the template
+// and its resources in the default organisation
+func setupTemplate(commands *Commands, validations *[]preparation.Validation, template *InstanceTemplate, instanceAgg *instance.Aggregate, orgAgg *org.Aggregate, userID string) error {
+	if template == nil {
+		return nil
+	}
+	for _, text := range template.LoginTexts {
+		*validations = append(*validations, prepareSetTemplateLoginText(instanceAgg, text))
+	}
+	template.oidcApps = make([]*addOIDCApp, 0)
+	template.apiApps = make([]*addAPIApp, 0)
+	actionIDs := make(map[string]string, len(template.Actions))
+	for _, templateAction := range template.Actions {
+		id, err := commands.idGenerator.Next()
+		if err != nil {
+			return err
+		}
+		actionIDs[templateAction.ID] = id
+		*validations = append(*validations, prepareAddTemplateAction(action.NewAggregate(id, orgAgg.ID), templateAction))
+	}
+	for _, flow := range template.Flows {
+		ids := make([]string, 0, len(flow.ActionIDs))
+		for _, templateID := range flow.ActionIDs {
+			id, ok := actionIDs[templateID]
+			if !ok {
+				return zerrors.ThrowInvalidArgument(nil, "INSTANCE-Tq3kE", "Errors.Flow.ActionIDsNotExist")
+			}
+			ids = append(ids, id)
+		}
+		*validations = append(*validations, prepareSetTemplateTriggerActions(orgAgg, flow, ids))
+	}
+	for _, templateProject := range template.Projects {
+		if err := setupTemplateProject(commands, validations, template, templateProject, orgAgg.ID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setupTemplateProject(commands *Commands, validations *[]preparation.Validation, template *InstanceTemplate, templateProject *TemplateProject, orgID, userID string) error {
+	projectID, err := commands.idGenerator.Next()
+	if err != nil {
+		return err
+	}
+	projectAgg := project.NewAggregate(projectID, orgID)
+	*validations = append(*validations,
+		AddProjectCommand(projectAgg,
+			templateProject.Name,
+			userID,
+			templateProject.ProjectRoleAssertion,
+			templateProject.ProjectRoleCheck,
+			templateProject.HasProjectCheck,
+			templateProject.PrivateLabelingSetting,
+		),
+	)
+	for _, role := range templateProject.Roles {
+		*validations = append(*validations, prepareAddTemplateProjectRole(projectAgg, role))
+	}
+	for _, app := range templateProject.OIDCApps {
+		appID, err := commands.idGenerator.Next()
+		if err != nil {
+			return err
+		}
+		oidcApp := &addOIDCApp{
+			AddApp: AddApp{
+				Aggregate: *projectAgg,
+				ID:        appID,
+				Name:      app.Name,
+			},
+			Version:                     app.Version,
+			RedirectUris:                app.RedirectUris,
+			ResponseTypes:               app.ResponseTypes,
+			GrantTypes:                  app.GrantTypes,
+			ApplicationType:             app.ApplicationType,
+			AuthMethodType:              app.AuthMethodType,
+			PostLogoutRedirectUris:      app.PostLogoutRedirectUris,
+			DevMode:                     app.DevMode,
+			AccessTokenType:             app.AccessTokenType,
+			AccessTokenRoleAssertion:    app.AccessTokenRoleAssertion,
+			IDTokenRoleAssertion:        app.IDTokenRoleAssertion,
+			IDTokenUserinfoAssertion:    app.IDTokenUserinfoAssertion,
+			ClockSkew:                   app.ClockSkew,
+			AdditionalOrigins:           app.AdditionalOrigins,
+			SkipSuccessPageForNativeApp: app.SkipSuccessPageForNativeApp,
+			ConsentRequired:             app.ConsentRequired,
+		}
+		template.oidcApps = append(template.oidcApps, oidcApp)
+		*validations = append(*validations, commands.AddOIDCAppCommand(oidcApp, commands.codeAlg))
+	}
+	for _, app := range templateProject.APIApps {
+		appID, err := commands.idGenerator.Next()
+		if err != nil {
+			return err
+		}
+		apiApp := &addAPIApp{
+			AddApp: AddApp{
+				Aggregate: *projectAgg,
+				ID:        appID,
+				Name:      app.Name,
+			},
+			AuthMethodType: app.AuthMethodType,
+		}
+		template.apiApps = append(template.apiApps, apiApp)
+		*validations = append(*validations, commands.AddAPIAppCommand(apiApp, commands.codeAlg))
+	}
+	for _, app := range templateProject.SAMLApps {
+		appID, err := commands.idGenerator.Next()
+		if err != nil {
+			return err
+		}
+		*validations = append(*validations, prepareAddTemplateSAMLApp(projectAgg, appID, app))
+	}
+	return nil
+}
+
+func prepareAddTemplateSAMLApp(projectAgg *project.Aggregate, appID string, app *TemplateSAMLApp) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if app.Name = strings.TrimSpace(app.Name); app.Name == "" || len(app.Metadata) == 0 {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-Ooy3a", "Errors.Project.App.Invalid")
+		}
+		entity, err := xml.ParseMetadataXmlIntoStruct(app.Metadata)
+		if err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "INSTANCE-ieL0o", "Errors.Project.App.SAMLMetadataFormat")
+		}
+		return func(ctx context.Context, _ preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			return []eventstore.Command{
+				project.NewApplicationAddedEvent(ctx, &projectAgg.Aggregate, appID, app.Name),
+				project.NewSAMLConfigAddedEvent(ctx, &projectAgg.Aggregate, appID, string(entity.EntityID), app.Metadata, app.MetadataURL),
+			}, nil
+		}, nil
+	}
+}
+
+func prepareAddTemplateProjectRole(projectAgg *project.Aggregate, role *TemplateProjectRole) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if role.Key = strings.TrimSpace(role.Key); role.Key == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-Hr8wM", "Errors.Project.Role.Invalid")
+		}
+		return func(ctx context.Context, _ preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			return []eventstore.Command{
+				project.NewRoleAddedEvent(ctx, &projectAgg.Aggregate, role.Key, role.DisplayName, role.Group),
+			}, nil
+		}, nil
+	}
+}
+
+func prepareAddTemplateAction(actionAgg *action.Aggregate, templateAction *TemplateAction) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		addAction := &domain.Action{
+			Name:          templateAction.Name,
+			Script:        templateAction.Script,
+			Timeout:       templateAction.Timeout,
+			AllowedToFail: templateAction.AllowedToFail,
+		}
+		if !addAction.IsValid() {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-a0Rdk", "Errors.Action.Invalid")
+		}
+		return func(ctx context.Context, _ preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			return []eventstore.Command{
+				action.NewAddedEvent(ctx, &actionAgg.Aggregate, addAction.Name, addAction.Script, addAction.Timeout, addAction.AllowedToFail),
+			}, nil
+		}, nil
+	}
+}
+
+func prepareSetTemplateTriggerActions(orgAgg *org.Aggregate, flow *TemplateFlow, actionIDs []string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if !flow.FlowType.Valid() || !flow.TriggerType.Valid() {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-Wf2Zp", "Errors.Flow.FlowTypeMissing")
+		}
+		if !flow.FlowType.HasTrigger(flow.TriggerType) {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-L4nbc", "Errors.Flow.WrongTriggerType")
+		}
+		return func(ctx context.Context, _ preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			return []eventstore.Command{
+				org.NewTriggerActionsSetEvent(ctx, &orgAgg.Aggregate, flow.FlowType, flow.TriggerType, actionIDs),
+			}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) prepareAddTemplateLabelPolicyAsset(instanceAgg *instance.Aggregate, asset *TemplateLabelPolicyAsset) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if asset.Name == "" || len(asset.Data) == 0 {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-Vb3xk", "Errors.Instance.Template.Invalid")
+		}
+		var addedEvent func(ctx context.Context, name string) eventstore.Command
+		switch asset.Type {
+		case TemplateLabelPolicyLogo:
+			addedEvent = func(ctx context.Context, name string) eventstore.Command {
+				return instance.NewLabelPolicyLogoAddedEvent(ctx, &instanceAgg.Aggregate, name)
+			}
+		case TemplateLabelPolicyLogoDark:
+			addedEvent = func(ctx context.Context, name string) eventstore.Command {
+				return instance.NewLabelPolicyLogoDarkAddedEvent(ctx, &instanceAgg.Aggregate, name)
+			}
+		case TemplateLabelPolicyIcon:
+			addedEvent = func(ctx context.Context, name string) eventstore.Command {
+				return instance.NewLabelPolicyIconAddedEvent(ctx, &instanceAgg.Aggregate, name)
+			}
+		case TemplateLabelPolicyIconDark:
+			addedEvent = func(ctx context.Context, name string) eventstore.Command {
+				return instance.NewLabelPolicyIconDarkAddedEvent(ctx, &instanceAgg.Aggregate, name)
+			}
+		case TemplateLabelPolicyFont:
+			addedEvent = func(ctx context.Context, name string) eventstore.Command {
+				return instance.NewLabelPolicyFontAddedEvent(ctx, &instanceAgg.Aggregate, name)
+			}
+		default:
+			return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-Jm4sd", "Errors.Instance.Template.Invalid")
+		}
+		return func(ctx context.Context, _ preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			uploaded, err := c.uploadAsset(ctx, &AssetUpload{
+				ResourceOwner: instanceAgg.ID,
+				ObjectName:    asset.Name,
+				ContentType:   asset.ContentType,
+				ObjectType:    static.ObjectTypeStyling,
+				File:          bytes.NewReader(asset.Data),
+				Size:          int64(len(asset.Data)),
+			})
+			if err != nil {
+				return nil, zerrors.ThrowInternal(err, "INSTANCE-Rk2oa", "Errors.Assets.Object.PutFailed")
+			}
+			return []eventstore.Command{
+				addedEvent(ctx, uploaded.Name),
+			}, nil
+		}, nil
+	}
+}
+
+func prepareSetTemplateLoginText(instanceAgg *instance.Aggregate, text *TemplateLoginText) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if text.Key == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-Ug7ns", "Errors.CustomText.Invalid")
+		}
+		if err := domain.LanguageIsDefined(text.Language); err != nil {
+			return nil, err
+		}
+		if err := domain.LanguagesAreSupported(i18n.SupportedLanguages(), text.Language); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, _ preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			return []eventstore.Command{
+				instance.NewCustomTextSetEvent(ctx, &instanceAgg.Aggregate, domain.LoginCustomText, text.Key, text.Text, text.Language),
+			}, nil
+		}, nil
+	}
+}
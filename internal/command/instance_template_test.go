@@ -0,0 +1,266 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/static"
+	static_mock "github.com/zitadel/zitadel/internal/static/mock"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestInstanceTemplate_applySettings(t *testing.T) {
+	setup := &InstanceSetup{
+		DefaultLanguage: language.English,
+		LockoutPolicy: InstanceLockoutPolicy{
+			MaxAttempts: 5,
+		},
+		PasswordAgePolicy: InstancePasswordAgePolicy{
+			MaxAgeDays: 30,
+		},
+	}
+	template := &InstanceTemplate{
+		DefaultLanguage: language.German,
+		LockoutPolicy: &InstanceLockoutPolicy{
+			MaxAttempts:              3,
+			ShouldShowLockoutFailure: true,
+		},
+	}
+	template.applySettings(setup)
+
+	assert.Equal(t, language.German, setup.DefaultLanguage)
+	assert.Equal(t, InstanceLockoutPolicy{MaxAttempts: 3, ShouldShowLockoutFailure: true}, setup.LockoutPolicy)
+	assert.Equal(t, InstancePasswordAgePolicy{MaxAgeDays: 30}, setup.PasswordAgePolicy)
+}
+
+func Test_setupTemplate(t *testing.T) {
+	type args struct {
+		idGenerator id.Generator
+		template    *InstanceTemplate
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantValidations int
+		wantErr         func(error) bool
+	}{
+		{
+			name: "no template",
+			args: args{
+				idGenerator: mock.NewIDGeneratorExpectIDs(t),
+			},
+		},
+		{
+			name: "unknown action in flow",
+			args: args{
+				idGenerator: mock.NewIDGeneratorExpectIDs(t, "action1"),
+				template: &InstanceTemplate{
+					Actions: []*TemplateAction{{ID: "template1", Name: "action", Script: "function action(ctx, api) {}"}},
+					Flows: []*TemplateFlow{{
+						FlowType:    domain.FlowTypeExternalAuthentication,
+						TriggerType: domain.TriggerTypePostAuthentication,
+						ActionIDs:   []string{"unknown"},
+					}},
+				},
+			},
+			wantValidations: 1,
+			wantErr:         zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "login text without language",
+			args: args{
+				idGenerator: mock.NewIDGeneratorExpectIDs(t),
+				template: &InstanceTemplate{
+					LoginTexts: []*TemplateLoginText{{Key: "Login.Title", Text: "title"}},
+				},
+			},
+			wantValidations: 1,
+		},
+		{
+			name: "actions, flows and projects",
+			args: args{
+				idGenerator: mock.NewIDGeneratorExpectIDs(t, "action1", "project1", "app1", "app2", "app3"),
+				template: &InstanceTemplate{
+					LoginTexts: []*TemplateLoginText{{Language: language.English, Key: "Login.Title", Text: "title"}},
+					Actions:    []*TemplateAction{{ID: "template1", Name: "action", Script: "function action(ctx, api) {}"}},
+					Flows: []*TemplateFlow{{
+						FlowType:    domain.FlowTypeExternalAuthentication,
+						TriggerType: domain.TriggerTypePostAuthentication,
+						ActionIDs:   []string{"template1"},
+					}},
+					Projects: []*TemplateProject{{
+						ID:       "template2",
+						Name:     "project",
+						Roles:    []*TemplateProjectRole{{Key: "role"}},
+						OIDCApps: []*TemplateOIDCApp{{Name: "oidc"}},
+						APIApps:  []*TemplateAPIApp{{Name: "api"}},
+						SAMLApps: []*TemplateSAMLApp{{Name: "saml", Metadata: testMetadata}},
+					}},
+				},
+			},
+			// login text, action, flow, project, role, oidc app, api app, saml app
+			wantValidations: 8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				idGenerator: tt.args.idGenerator,
+			}
+			validations := make([]preparation.Validation, 0)
+			err := setupTemplate(c, &validations, tt.args.template, instance.NewAggregate("instance1"), org.NewAggregate("org1"), "user1")
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else if !tt.wantErr(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			assert.Len(t, validations, tt.wantValidations)
+		})
+	}
+}
+
+func TestInstanceTemplate_AppSecrets(t *testing.T) {
+	template := &InstanceTemplate{
+		oidcApps: []*addOIDCApp{
+			{AddApp: AddApp{Aggregate: *projectAgg("project1"), ID: "app1", Name: "public"}, ClientID: "client1"},
+			{AddApp: AddApp{Aggregate: *projectAgg("project1"), ID: "app2", Name: "confidential"}, ClientID: "client2", ClientSecretPlain: "secret2"},
+		},
+		apiApps: []*addAPIApp{
+			{AddApp: AddApp{Aggregate: *projectAgg("project2"), ID: "app3", Name: "api"}, ClientID: "client3", ClientSecretPlain: "secret3"},
+		},
+	}
+	assert.Equal(t, []*TemplateAppSecret{
+		{ProjectID: "project1", AppID: "app2", Name: "confidential", ClientID: "client2", ClientSecret: "secret2"},
+		{ProjectID: "project2", AppID: "app3", Name: "api", ClientID: "client3", ClientSecret: "secret3"},
+	}, template.AppSecrets())
+}
+
+func Test_prepareAddTemplateSAMLApp(t *testing.T) {
+	tests := []struct {
+		name    string
+		app     *TemplateSAMLApp
+		want    []eventstore.Command
+		wantErr func(error) bool
+	}{
+		{
+			name:    "metadata missing",
+			app:     &TemplateSAMLApp{Name: "saml", MetadataURL: "https://test.com/saml/metadata"},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "invalid metadata",
+			app:     &TemplateSAMLApp{Name: "saml", Metadata: []byte("invalid")},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "metadata, ok",
+			app:  &TemplateSAMLApp{Name: "saml", Metadata: testMetadata, MetadataURL: "https://test.com/saml/metadata"},
+			want: []eventstore.Command{
+				project.NewApplicationAddedEvent(context.Background(), &projectAgg("project1").Aggregate, "app1", "saml"),
+				project.NewSAMLConfigAddedEvent(context.Background(), &projectAgg("project1").Aggregate, "app1", "https://test.com/saml/metadata", testMetadata, "https://test.com/saml/metadata"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := authz.WithInstanceID(context.Background(), "instance1")
+			cmds, err := preparation.PrepareCommands(ctx, nil, prepareAddTemplateSAMLApp(projectAgg("project1"), "app1", tt.app))
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, cmds, len(tt.want))
+			for i, cmd := range cmds {
+				assert.Equal(t, tt.want[i].Type(), cmd.Type())
+				assert.Equal(t, tt.want[i].Payload(), cmd.Payload())
+			}
+		})
+	}
+}
+
+func TestCommands_prepareAddTemplateLabelPolicyAsset(t *testing.T) {
+	instanceAgg := instance.NewAggregate("instance1")
+	type args struct {
+		storage static.Storage
+		asset   *TemplateLabelPolicyAsset
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []eventstore.Command
+		wantErr func(error) bool
+	}{
+		{
+			name: "unknown type",
+			args: args{
+				asset: &TemplateLabelPolicyAsset{Type: "unknown", Name: "logo", Data: []byte("logo")},
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "empty asset",
+			args: args{
+				asset: &TemplateLabelPolicyAsset{Type: TemplateLabelPolicyLogo, Name: "logo"},
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "upload failed",
+			args: args{
+				storage: static_mock.NewStorage(t).ExpectPutObjectError(),
+				asset:   &TemplateLabelPolicyAsset{Type: TemplateLabelPolicyFont, Name: "font", ContentType: "font/ttf", Data: []byte("font")},
+			},
+			wantErr: zerrors.IsInternal,
+		},
+		{
+			name: "font, ok",
+			args: args{
+				storage: static_mock.NewStorage(t).ExpectPutObject(),
+				asset:   &TemplateLabelPolicyAsset{Type: TemplateLabelPolicyFont, Name: "font", ContentType: "font/ttf", Data: []byte("font")},
+			},
+			want: []eventstore.Command{
+				instance.NewLabelPolicyFontAddedEvent(context.Background(), &instanceAgg.Aggregate, "font"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				static: tt.args.storage,
+			}
+			ctx := authz.WithInstanceID(context.Background(), "instance1")
+			cmds, err := preparation.PrepareCommands(ctx, nil, c.prepareAddTemplateLabelPolicyAsset(instanceAgg, tt.args.asset))
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, cmds, len(tt.want))
+			for i, cmd := range cmds {
+				assert.Equal(t, tt.want[i].Type(), cmd.Type())
+				assert.Equal(t, tt.want[i].Payload(), cmd.Payload())
+			}
+		})
+	}
+}
+
+func projectAgg(id string) *project.Aggregate {
+	return project.NewAggregate(id, "org1")
+}
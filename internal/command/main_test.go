@@ -25,6 +25,7 @@ import (
 	iam_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	key_repo "github.com/zitadel/zitadel/internal/repository/keypair"
 	"github.com/zitadel/zitadel/internal/repository/limits"
+	"github.com/zitadel/zitadel/internal/repository/notification"
 	"github.com/zitadel/zitadel/internal/repository/oidcsession"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
@@ -63,6 +64,7 @@ func eventstoreExpect(t *testing.T, expects ...expect) *eventstore.Eventstore {
 	quota_repo.RegisterEventMappers(es)
 	limits.RegisterEventMappers(es)
 	restrictions.RegisterEventMappers(es)
+	notification.RegisterEventMappers(es)
 	feature.RegisterEventMappers(es)
 	deviceauth.RegisterEventMappers(es)
 	return es
@@ -0,0 +1,117 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// RequestNotification stores the message in the notification outbox and returns the id of the notification.
+// The message is encrypted, as it might contain codes or links.
+// If no resourceOwner is provided, the notification belongs to the instance.
+func (c *Commands) RequestNotification(
+	ctx context.Context,
+	resourceOwner string,
+	channel domain.NotificationType,
+	recipient string,
+	message channels.Message,
+) (_ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "COMMAND-Ld9nw", "Errors.Internal")
+	}
+	encryptedMessage, err := crypto.Encrypt(payload, c.userEncryption)
+	if err != nil {
+		return "", err
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", err
+	}
+	var (
+		triggeringAggregateID string
+		triggeringEventType   eventstore.EventType
+	)
+	if triggeringEvent := message.GetTriggeringEvent(); triggeringEvent != nil {
+		triggeringEventType = triggeringEvent.Type()
+		if triggeringEvent.Aggregate() != nil {
+			triggeringAggregateID = triggeringEvent.Aggregate().ID
+		}
+	}
+	_, err = c.eventstore.Push(ctx, notification.NewRequestedEvent(
+		ctx,
+		notificationAggregate(ctx, id, resourceOwner),
+		channel,
+		recipient,
+		triggeringAggregateID,
+		triggeringEventType,
+		encryptedMessage,
+	))
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// NotificationSent marks the notification as delivered
+func (c *Commands) NotificationSent(ctx context.Context, id, resourceOwner string) error {
+	_, err := c.eventstore.Push(ctx, notification.NewSentEvent(ctx, notificationAggregate(ctx, id, resourceOwner)))
+	return err
+}
+
+// NotificationRetryRequested schedules the next attempt to send the notification after a failed delivery
+func (c *Commands) NotificationRetryRequested(ctx context.Context, id, resourceOwner string, sendErr error, notBefore time.Time) error {
+	_, err := c.eventstore.Push(ctx, notification.NewRetryRequestedEvent(ctx, notificationAggregate(ctx, id, resourceOwner), sendErr, notBefore))
+	return err
+}
+
+// NotificationFailed marks the notification as failed after the last attempt to deliver it
+func (c *Commands) NotificationFailed(ctx context.Context, id, resourceOwner string, sendErr error) error {
+	_, err := c.eventstore.Push(ctx, notification.NewFailedEvent(ctx, notificationAggregate(ctx, id, resourceOwner), sendErr))
+	return err
+}
+
+// ResendNotification schedules a sent or failed notification to be delivered again
+func (c *Commands) ResendNotification(ctx context.Context, id string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Rb2mf", "Errors.IDMissing")
+	}
+	writeModel := NewNotificationWriteModel(id, "")
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Pw0ej", "Errors.Notification.NotFound")
+	}
+	if !writeModel.State.Resendable() {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Yk3ms", "Errors.Notification.NotResendable")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, notification.NewResendRequestedEvent(ctx, NotificationAggregateFromWriteModel(&writeModel.WriteModel)))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func notificationAggregate(ctx context.Context, id, resourceOwner string) *eventstore.Aggregate {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if resourceOwner == "" {
+		resourceOwner = instanceID
+	}
+	return &notification.NewAggregate(id, instanceID, resourceOwner).Aggregate
+}
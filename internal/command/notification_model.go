@@ -0,0 +1,67 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+)
+
+type NotificationWriteModel struct {
+	eventstore.WriteModel
+
+	State    domain.NotificationState
+	Channel  domain.NotificationType
+	Attempts uint16
+}
+
+func NewNotificationWriteModel(id, resourceOwner string) *NotificationWriteModel {
+	return &NotificationWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *NotificationWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *notification.RequestedEvent:
+			wm.State = domain.NotificationStatePending
+			wm.Channel = e.Channel
+		case *notification.SentEvent:
+			wm.State = domain.NotificationStateSent
+			wm.Attempts++
+		case *notification.RetryRequestedEvent:
+			wm.State = domain.NotificationStateRetrying
+			wm.Attempts++
+		case *notification.FailedEvent:
+			wm.State = domain.NotificationStateFailed
+			wm.Attempts++
+		case *notification.ResendRequestedEvent:
+			wm.State = domain.NotificationStateRetrying
+			wm.Attempts = 0
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *NotificationWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(notification.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			notification.RequestedType,
+			notification.SentType,
+			notification.RetryRequestedType,
+			notification.FailedType,
+			notification.ResendRequestedType,
+		).
+		Builder()
+}
+
+func NotificationAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, notification.AggregateType, notification.AggregateVersion)
+}
@@ -0,0 +1,292 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_RequestNotification(t *testing.T) {
+	type fields struct {
+		eventstore     func(t *testing.T) *eventstore.Eventstore
+		idGenerator    id.Generator
+		userEncryption crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		channel       domain.NotificationType
+		recipient     string
+		message       channels.Message
+	}
+	type res struct {
+		want string
+		err  func(error) bool
+	}
+	triggeringEvent := user.NewHumanInitialCodeSentEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate)
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "email requested, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectPush(
+						notification.NewRequestedEvent(context.Background(),
+							&notification.NewAggregate("notification1", "INSTANCE", "org1").Aggregate,
+							domain.NotificationTypeEmail,
+							"user@example.com",
+							"user1",
+							user.HumanInitialCodeSentType,
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte(`{"recipients":["user@example.com"],"subject":"subject","content":"content"}`),
+							},
+						),
+					),
+				),
+				idGenerator:    id_mock.NewIDGeneratorExpectIDs(t, "notification1"),
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "INSTANCE"),
+				resourceOwner: "org1",
+				channel:       domain.NotificationTypeEmail,
+				recipient:     "user@example.com",
+				message: &messages.Email{
+					Recipients:      []string{"user@example.com"},
+					Subject:         "subject",
+					Content:         "content",
+					TriggeringEvent: triggeringEvent,
+				},
+			},
+			res: res{
+				want: "notification1",
+			},
+		},
+		{
+			name: "sms requested for instance, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectPush(
+						notification.NewRequestedEvent(context.Background(),
+							&notification.NewAggregate("notification1", "INSTANCE", "INSTANCE").Aggregate,
+							domain.NotificationTypeSms,
+							"+41791234567",
+							"",
+							"",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte(`{"recipientPhoneNumber":"+41791234567","content":"content"}`),
+							},
+						),
+					),
+				),
+				idGenerator:    id_mock.NewIDGeneratorExpectIDs(t, "notification1"),
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:       authz.WithInstanceID(context.Background(), "INSTANCE"),
+				channel:   domain.NotificationTypeSms,
+				recipient: "+41791234567",
+				message: &messages.SMS{
+					RecipientPhoneNumber: "+41791234567",
+					Content:              "content",
+				},
+			},
+			res: res{
+				want: "notification1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				idGenerator:    tt.fields.idGenerator,
+				userEncryption: tt.fields.userEncryption,
+			}
+			got, err := c.RequestNotification(tt.args.ctx, tt.args.resourceOwner, tt.args.channel, tt.args.recipient, tt.args.message)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_NotificationRetryRequested(t *testing.T) {
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &Commands{
+		eventstore: expectEventstore(
+			expectPush(
+				notification.NewRetryRequestedEvent(context.Background(),
+					&notification.NewAggregate("notification1", "INSTANCE", "org1").Aggregate,
+					errors.New("connection refused"),
+					notBefore,
+				),
+			),
+		)(t),
+	}
+	err := c.NotificationRetryRequested(authz.WithInstanceID(context.Background(), "INSTANCE"), "notification1", "org1", errors.New("connection refused"), notBefore)
+	assert.NoError(t, err)
+}
+
+func TestCommands_ResendNotification(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	requested := func() eventstore.Event {
+		return eventFromEventPusherWithInstanceID("INSTANCE",
+			notification.NewRequestedEvent(context.Background(),
+				&notification.NewAggregate("notification1", "INSTANCE", "org1").Aggregate,
+				domain.NotificationTypeEmail,
+				"user@example.com",
+				"user1",
+				user.HumanInitialCodeAddedType,
+				&crypto.CryptoValue{},
+			),
+		)
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "id missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "not found, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "notification1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "retrying, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						requested(),
+						eventFromEventPusherWithInstanceID("INSTANCE",
+							notification.NewRetryRequestedEvent(context.Background(),
+								&notification.NewAggregate("notification1", "INSTANCE", "org1").Aggregate,
+								errors.New("connection refused"),
+								time.Now(),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "notification1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "failed, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						requested(),
+						eventFromEventPusherWithInstanceID("INSTANCE",
+							notification.NewFailedEvent(context.Background(),
+								&notification.NewAggregate("notification1", "INSTANCE", "org1").Aggregate,
+								errors.New("connection refused"),
+							),
+						),
+					),
+					expectPush(
+						notification.NewResendRequestedEvent(context.Background(),
+							&notification.NewAggregate("notification1", "INSTANCE", "org1").Aggregate,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "notification1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.ResendNotification(tt.args.ctx, tt.args.id)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
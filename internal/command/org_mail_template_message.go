@@ -0,0 +1,137 @@
+package command
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/zitadel/logging"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetOrgMailTemplateMessage validates and uploads the template as preview,
+// it is only used for notifications after it was activated
+func (c *Commands) SetOrgMailTemplateMessage(ctx context.Context, resourceOwner string, message *domain.MailTemplateMessage) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Ahw3e", "Errors.ResourceOwnerMissing")
+	}
+	if !message.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Ohz4e", "Errors.Org.MailTemplateMessage.Invalid")
+	}
+	if err := templates.ValidateTemplate(string(message.Template)); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "ORG-ieG5a", "Errors.Org.MailTemplateMessage.TemplateInvalid")
+	}
+	existing, err := c.orgMailTemplateMessageWriteModelByID(ctx, resourceOwner, message.MessageType, message.Language)
+	if err != nil {
+		return nil, err
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	asset, err := c.uploadAsset(ctx, &AssetUpload{
+		ResourceOwner: resourceOwner,
+		ObjectName:    domain.MailTemplateMessagePath(message.MessageType, message.Language, id),
+		ContentType:   "text/html",
+		ObjectType:    static.ObjectTypeMailTemplate,
+		File:          bytes.NewReader(message.Template),
+		Size:          int64(len(message.Template)),
+	})
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ORG-Vae2i", "Errors.Assets.Object.PutFailed")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewMailTemplateMessageSetEvent(ctx, orgAgg, message.MessageType, message.Language, asset.Name))
+	if err != nil {
+		return nil, err
+	}
+	// the replaced preview is not referenced anymore if it was never activated
+	if existing.StoreKey != "" && existing.StoreKey != existing.ActiveStoreKey {
+		c.removeMailTemplateAsset(ctx, resourceOwner, existing.StoreKey)
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) ActivateOrgMailTemplateMessage(ctx context.Context, resourceOwner, messageType string, lang language.Tag) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Quai3", "Errors.ResourceOwnerMissing")
+	}
+	existing, err := c.orgMailTemplateMessageWriteModelByID(ctx, resourceOwner, messageType, lang)
+	if err != nil {
+		return nil, err
+	}
+	if existing.State != domain.PolicyStateActive {
+		return nil, zerrors.ThrowNotFound(nil, "ORG-Xei7o", "Errors.Org.MailTemplateMessage.NotFound")
+	}
+	if existing.StoreKey == existing.ActiveStoreKey {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "ORG-uu0Ie", "Errors.Org.MailTemplateMessage.NotChanged")
+	}
+	previousActive := existing.ActiveStoreKey
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewMailTemplateMessageActivatedEvent(ctx, orgAgg, messageType, lang, existing.StoreKey))
+	if err != nil {
+		return nil, err
+	}
+	if previousActive != "" {
+		c.removeMailTemplateAsset(ctx, resourceOwner, previousActive)
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// RemoveOrgMailTemplateMessage removes the preview and active template,
+// the notifications fall back to the mail template policy
+func (c *Commands) RemoveOrgMailTemplateMessage(ctx context.Context, resourceOwner, messageType string, lang language.Tag) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Ees1u", "Errors.ResourceOwnerMissing")
+	}
+	existing, err := c.orgMailTemplateMessageWriteModelByID(ctx, resourceOwner, messageType, lang)
+	if err != nil {
+		return nil, err
+	}
+	if existing.State != domain.PolicyStateActive {
+		return nil, zerrors.ThrowNotFound(nil, "ORG-aiT6e", "Errors.Org.MailTemplateMessage.NotFound")
+	}
+	storeKey, activeStoreKey := existing.StoreKey, existing.ActiveStoreKey
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewMailTemplateMessageRemovedEvent(ctx, orgAgg, messageType, lang))
+	if err != nil {
+		return nil, err
+	}
+	c.removeMailTemplateAsset(ctx, resourceOwner, storeKey)
+	if activeStoreKey != "" && activeStoreKey != storeKey {
+		c.removeMailTemplateAsset(ctx, resourceOwner, activeStoreKey)
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// removeMailTemplateAsset only logs failures, as the template isn't referenced by the events anymore
+func (c *Commands) removeMailTemplateAsset(ctx context.Context, resourceOwner, storeKey string) {
+	err := c.removeAsset(ctx, resourceOwner, storeKey)
+	logging.WithFields("resourceOwner", resourceOwner, "storeKey", storeKey).OnError(err).Warn("unable to remove mail template asset")
+}
+
+func (c *Commands) orgMailTemplateMessageWriteModelByID(ctx context.Context, orgID, messageType string, lang language.Tag) (*OrgMailTemplateMessageWriteModel, error) {
+	writeModel := NewOrgMailTemplateMessageWriteModel(orgID, messageType, lang)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
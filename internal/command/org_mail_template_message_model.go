@@ -0,0 +1,83 @@
+package command
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgMailTemplateMessageWriteModel struct {
+	eventstore.WriteModel
+
+	MessageType    string
+	Language       language.Tag
+	StoreKey       string
+	ActiveStoreKey string
+	State          domain.PolicyState
+}
+
+func NewOrgMailTemplateMessageWriteModel(orgID, messageType string, lang language.Tag) *OrgMailTemplateMessageWriteModel {
+	return &OrgMailTemplateMessageWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   orgID,
+			ResourceOwner: orgID,
+		},
+		MessageType: messageType,
+		Language:    lang,
+	}
+}
+
+func (wm *OrgMailTemplateMessageWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.MailTemplateMessageSetEvent:
+			if e.MessageType != wm.MessageType || e.Language != wm.Language {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *org.MailTemplateMessageActivatedEvent:
+			if e.MessageType != wm.MessageType || e.Language != wm.Language {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *org.MailTemplateMessageRemovedEvent:
+			if e.MessageType != wm.MessageType || e.Language != wm.Language {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgMailTemplateMessageWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.MailTemplateMessageSetEvent:
+			wm.StoreKey = e.StoreKey
+			wm.State = domain.PolicyStateActive
+		case *org.MailTemplateMessageActivatedEvent:
+			wm.ActiveStoreKey = e.StoreKey
+		case *org.MailTemplateMessageRemovedEvent:
+			wm.StoreKey = ""
+			wm.ActiveStoreKey = ""
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgMailTemplateMessageWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.MailTemplateMessageSetEventType,
+			org.MailTemplateMessageActivatedEventType,
+			org.MailTemplateMessageRemovedEventType,
+		).
+		Builder()
+}
@@ -0,0 +1,499 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/static/mock"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetOrgMailTemplateMessage(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+		storage     static.Storage
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		message       *domain.MailTemplateMessage
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resource owner missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: context.Background(),
+				message: &domain.MailTemplateMessage{
+					MessageType: domain.InitCodeMessageType,
+					Language:    language.English,
+					Template:    []byte("<p>{{.Text}}</p>"),
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "sms message type, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				message: &domain.MailTemplateMessage{
+					MessageType: domain.VerifySMSOTPMessageType,
+					Language:    language.English,
+					Template:    []byte("<p>{{.Text}}</p>"),
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "template not parsable, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				message: &domain.MailTemplateMessage{
+					MessageType: domain.InitCodeMessageType,
+					Language:    language.English,
+					Template:    []byte("<p>{{.Text</p>"),
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "template with unknown field, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				message: &domain.MailTemplateMessage{
+					MessageType: domain.InitCodeMessageType,
+					Language:    language.English,
+					Template:    []byte("<p>{{.Unknown}}</p>"),
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "upload failed, internal error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				storage:     mock.NewStorage(t).ExpectPutObjectError(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				message: &domain.MailTemplateMessage{
+					MessageType: domain.InitCodeMessageType,
+					Language:    language.English,
+					Template:    []byte("<p>{{.Text}}</p>"),
+				},
+			},
+			res: res{
+				err: zerrors.IsInternal,
+			},
+		},
+		{
+			name: "template set, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectPush(
+						org.NewMailTemplateMessageSetEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							domain.InitCodeMessageType,
+							language.English,
+							"policy/mail/template/InitCode/en-id1",
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				storage:     mock.NewStorage(t).ExpectPutObject(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				message: &domain.MailTemplateMessage{
+					MessageType: domain.InitCodeMessageType,
+					Language:    language.English,
+					Template:    []byte("<p>{{.Text}}</p>"),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "preview replaced, previous preview removed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailTemplateMessageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								language.English,
+								"policy/mail/template/InitCode/en-id0",
+							),
+						),
+					),
+					expectPush(
+						org.NewMailTemplateMessageSetEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							domain.InitCodeMessageType,
+							language.English,
+							"policy/mail/template/InitCode/en-id1",
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+				storage:     mock.NewStorage(t).ExpectPutObject().ExpectRemoveObjectNoError(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				message: &domain.MailTemplateMessage{
+					MessageType: domain.InitCodeMessageType,
+					Language:    language.English,
+					Template:    []byte("<p>{{.Text}}</p>"),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+				static:      tt.fields.storage,
+			}
+			got, err := r.SetOrgMailTemplateMessage(tt.args.ctx, tt.args.resourceOwner, tt.args.message)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ActivateOrgMailTemplateMessage(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		storage    static.Storage
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		messageType   string
+		lang          language.Tag
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resource owner missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:         context.Background(),
+				messageType: domain.InitCodeMessageType,
+				lang:        language.English,
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "template not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				messageType:   domain.InitCodeMessageType,
+				lang:          language.English,
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "preview already active, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailTemplateMessageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								language.English,
+								"key1",
+							),
+						),
+						eventFromEventPusher(
+							org.NewMailTemplateMessageActivatedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								language.English,
+								"key1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				messageType:   domain.InitCodeMessageType,
+				lang:          language.English,
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "preview activated, previous active template removed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailTemplateMessageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								language.English,
+								"key1",
+							),
+						),
+						eventFromEventPusher(
+							org.NewMailTemplateMessageActivatedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								language.English,
+								"key1",
+							),
+						),
+						eventFromEventPusher(
+							org.NewMailTemplateMessageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								language.English,
+								"key2",
+							),
+						),
+					),
+					expectPush(
+						org.NewMailTemplateMessageActivatedEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							domain.InitCodeMessageType,
+							language.English,
+							"key2",
+						),
+					),
+				),
+				storage: mock.NewStorage(t).ExpectRemoveObjectNoError(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				messageType:   domain.InitCodeMessageType,
+				lang:          language.English,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+				static:     tt.fields.storage,
+			}
+			got, err := r.ActivateOrgMailTemplateMessage(tt.args.ctx, tt.args.resourceOwner, tt.args.messageType, tt.args.lang)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveOrgMailTemplateMessage(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		storage    static.Storage
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		messageType   string
+		lang          language.Tag
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "template not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailTemplateMessageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								language.German,
+								"key1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				messageType:   domain.InitCodeMessageType,
+				lang:          language.English,
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "template removed, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMailTemplateMessageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								language.English,
+								"key1",
+							),
+						),
+						eventFromEventPusher(
+							org.NewMailTemplateMessageActivatedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								language.English,
+								"key1",
+							),
+						),
+					),
+					expectPush(
+						org.NewMailTemplateMessageRemovedEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							domain.InitCodeMessageType,
+							language.English,
+						),
+					),
+				),
+				storage: mock.NewStorage(t).ExpectRemoveObjectNoError(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				messageType:   domain.InitCodeMessageType,
+				lang:          language.English,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+				static:     tt.fields.storage,
+			}
+			got, err := r.RemoveOrgMailTemplateMessage(tt.args.ctx, tt.args.resourceOwner, tt.args.messageType, tt.args.lang)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetOrgNotificationProviders lets the organization send its notifications with the referenced
+// SMTP and SMS configurations of the instance instead of the active ones.
+// An empty id keeps the active configuration of the instance for the respective channel.
+// As the configurations belong to the instance, assigning them requires the permission to write the instance.
+func (c *Commands) SetOrgNotificationProviders(ctx context.Context, orgID, smtpConfigID, smsConfigID string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Nq8vd", "Errors.ResourceOwnerMissing")
+	}
+	if smtpConfigID == "" && smsConfigID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Nq8ve", "Errors.Org.NotificationProviders.Invalid")
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if err := c.checkPermission(ctx, domain.PermissionIAMWrite, instanceID, instanceID); err != nil {
+		return nil, err
+	}
+	if err := c.checkOrgExists(ctx, orgID); err != nil {
+		return nil, err
+	}
+	if smtpConfigID != "" {
+		smtpConfig, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, smtpConfigID, "")
+		if err != nil {
+			return nil, err
+		}
+		if !smtpConfig.State.Exists() {
+			return nil, zerrors.ThrowPreconditionFailed(nil, "ORG-Ls2mf", "Errors.SMTPConfig.NotFound")
+		}
+	}
+	if smsConfigID != "" {
+		smsConfig, err := c.getSMSConfig(ctx, instanceID, smsConfigID)
+		if err != nil {
+			return nil, err
+		}
+		if !smsConfig.State.Exists() {
+			return nil, zerrors.ThrowPreconditionFailed(nil, "ORG-Ls2mg", "Errors.SMSConfig.NotFound")
+		}
+	}
+	writeModel, err := c.getOrgNotificationProvidersWriteModel(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.IsSet && writeModel.SMTPConfigID == smtpConfigID && writeModel.SMSConfigID == smsConfigID {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "ORG-Ls2mh", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewNotificationProvidersSetEvent(
+		ctx,
+		OrgAggregateFromWriteModel(&writeModel.WriteModel),
+		smtpConfigID,
+		smsConfigID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveOrgNotificationProviders lets the organization use the active configurations of the instance again
+func (c *Commands) RemoveOrgNotificationProviders(ctx context.Context, orgID string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Yt5mw", "Errors.ResourceOwnerMissing")
+	}
+	writeModel, err := c.getOrgNotificationProvidersWriteModel(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.IsSet {
+		return nil, zerrors.ThrowNotFound(nil, "ORG-Yt5mx", "Errors.Org.NotificationProviders.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewNotificationProvidersRemovedEvent(
+		ctx,
+		OrgAggregateFromWriteModel(&writeModel.WriteModel),
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) getOrgNotificationProvidersWriteModel(ctx context.Context, orgID string) (*OrgNotificationProvidersWriteModel, error) {
+	writeModel := NewOrgNotificationProvidersWriteModel(orgID)
+	err := c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgNotificationProvidersWriteModel struct {
+	eventstore.WriteModel
+
+	SMTPConfigID string
+	SMSConfigID  string
+	IsSet        bool
+}
+
+func NewOrgNotificationProvidersWriteModel(orgID string) *OrgNotificationProvidersWriteModel {
+	return &OrgNotificationProvidersWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   orgID,
+			ResourceOwner: orgID,
+		},
+	}
+}
+
+func (wm *OrgNotificationProvidersWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.NotificationProvidersSetEvent:
+			wm.SMTPConfigID = e.SMTPConfigID
+			wm.SMSConfigID = e.SMSConfigID
+			wm.IsSet = true
+		case *org.NotificationProvidersRemovedEvent:
+			wm.SMTPConfigID = ""
+			wm.SMSConfigID = ""
+			wm.IsSet = false
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgNotificationProvidersWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.NotificationProvidersSetEventType,
+			org.NotificationProvidersRemovedEventType).
+		Builder()
+}
@@ -72,7 +72,9 @@ func (wm *OrgLoginPolicyWriteModel) NewChangedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowMagicLink,
+	allowPhoneOTPLogin bool,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
@@ -134,6 +136,12 @@ func (wm *OrgLoginPolicyWriteModel) NewChangedEvent(
 	if wm.DisableLoginWithPhone != disableLoginWithPhone {
 		changes = append(changes, policy.ChangeDisableLoginWithPhone(disableLoginWithPhone))
 	}
+	if wm.AllowMagicLink != allowMagicLink {
+		changes = append(changes, policy.ChangeAllowMagicLink(allowMagicLink))
+	}
+	if wm.AllowPhoneOTPLogin != allowPhoneOTPLogin {
+		changes = append(changes, policy.ChangeAllowPhoneOTPLogin(allowPhoneOTPLogin))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
@@ -62,6 +62,8 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 								true,
 								false,
 								false,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -117,6 +119,8 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 							true,
 							true,
 							true,
+							false,
+							false,
 							domain.PasswordlessTypeAllowed,
 							"https://example.com/redirect",
 							time.Hour*1,
@@ -211,6 +215,8 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 							true,
 							true,
 							true,
+							false,
+							false,
 							domain.PasswordlessTypeAllowed,
 							"https://example.com/redirect",
 							time.Hour*1,
@@ -334,6 +340,8 @@ func TestCommandSide_AddLoginPolicy(t *testing.T) {
 							true,
 							true,
 							true,
+							false,
+							false,
 							domain.PasswordlessTypeAllowed,
 							"https://example.com/redirect",
 							time.Hour*1,
@@ -472,6 +480,8 @@ func TestCommandSide_ChangeLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -530,6 +540,8 @@ func TestCommandSide_ChangeLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -679,6 +691,8 @@ func TestCommandSide_RemoveLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -819,6 +833,8 @@ func TestCommandSide_AddIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -864,6 +880,8 @@ func TestCommandSide_AddIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -929,6 +947,8 @@ func TestCommandSide_AddIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1092,6 +1112,8 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1137,6 +1159,8 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1194,6 +1218,8 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -1254,6 +1280,8 @@ func TestCommandSide_RemoveIDPProviderLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
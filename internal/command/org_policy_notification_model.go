@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/repository/policy"
@@ -55,12 +56,33 @@ func (wm *OrgNotificationPolicyWriteModel) Query() *eventstore.SearchQueryBuilde
 func (wm *OrgNotificationPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	notificationPolicy *domain.NotificationPolicy,
 ) (*org.NotificationPolicyChangedEvent, bool) {
 
 	changes := make([]policy.NotificationPolicyChanges, 0)
-	if wm.PasswordChange != passwordChange {
-		changes = append(changes, policy.ChangePasswordChange(passwordChange))
+	if wm.PasswordChange != notificationPolicy.PasswordChange {
+		changes = append(changes, policy.ChangePasswordChange(notificationPolicy.PasswordChange))
+	}
+	if wm.UnknownUserAgentSignIn != notificationPolicy.UnknownUserAgentSignIn {
+		changes = append(changes, policy.ChangeUnknownUserAgentSignIn(notificationPolicy.UnknownUserAgentSignIn))
+	}
+	if wm.MFAAdded != notificationPolicy.MFAAdded {
+		changes = append(changes, policy.ChangeMFAAdded(notificationPolicy.MFAAdded))
+	}
+	if wm.MFARemoved != notificationPolicy.MFARemoved {
+		changes = append(changes, policy.ChangeMFARemoved(notificationPolicy.MFARemoved))
+	}
+	if wm.EmailChanged != notificationPolicy.EmailChanged {
+		changes = append(changes, policy.ChangeEmailChanged(notificationPolicy.EmailChanged))
+	}
+	if wm.PhoneChanged != notificationPolicy.PhoneChanged {
+		changes = append(changes, policy.ChangePhoneChanged(notificationPolicy.PhoneChanged))
+	}
+	if wm.AccountLocked != notificationPolicy.AccountLocked {
+		changes = append(changes, policy.ChangeAccountLocked(notificationPolicy.AccountLocked))
+	}
+	if wm.MachineCredentialAdded != notificationPolicy.MachineCredentialAdded {
+		changes = append(changes, policy.ChangeMachineCredentialAdded(notificationPolicy.MachineCredentialAdded))
 	}
 	if len(changes) == 0 {
 		return nil, false
@@ -18,9 +18,9 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		orgID          string
-		passwordChange bool
+		ctx    context.Context
+		orgID  string
+		policy *domain.NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -40,9 +40,9 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "",
-				passwordChange: true,
+				ctx:    context.Background(),
+				orgID:  "",
+				policy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsErrorInvalidArgument,
@@ -58,15 +58,22 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:    context.Background(),
+				orgID:  "org1",
+				policy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsErrorAlreadyExists,
@@ -82,14 +89,21 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 						org.NewNotificationPolicyAddedEvent(context.Background(),
 							&org.NewAggregate("org1").Aggregate,
 							true,
+							false,
+							false,
+							false,
+							false,
+							false,
+							false,
+							false,
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:    context.Background(),
+				orgID:  "org1",
+				policy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -107,14 +121,21 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 						org.NewNotificationPolicyAddedEvent(context.Background(),
 							&org.NewAggregate("org1").Aggregate,
 							false,
+							false,
+							false,
+							false,
+							false,
+							false,
+							false,
+							false,
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: false,
+				ctx:    context.Background(),
+				orgID:  "org1",
+				policy: &domain.NotificationPolicy{PasswordChange: false},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -128,7 +149,7 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange)
+			got, err := r.AddNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.policy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -147,9 +168,9 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		orgID          string
-		passwordChange bool
+		ctx    context.Context
+		orgID  string
+		policy *domain.NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -169,8 +190,8 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				passwordChange: true,
+				ctx:    context.Background(),
+				policy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsErrorInvalidArgument,
@@ -185,9 +206,9 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:    context.Background(),
+				orgID:  "org1",
+				policy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsNotFound,
@@ -203,15 +224,22 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:    context.Background(),
+				orgID:  "org1",
+				policy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsPreconditionFailed,
@@ -227,6 +255,13 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
@@ -236,9 +271,9 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: false,
+				ctx:    context.Background(),
+				orgID:  "org1",
+				policy: &domain.NotificationPolicy{PasswordChange: false},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -252,7 +287,7 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange)
+			got, err := r.ChangeNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.policy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -324,6 +359,13 @@ func TestCommandSide_RemoveNotificationPolicy(t *testing.T) {
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 							),
 						),
 					),
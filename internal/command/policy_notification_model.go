@@ -9,8 +9,15 @@ import (
 type NotificationPolicyWriteModel struct {
 	eventstore.WriteModel
 
-	PasswordChange bool
-	State          domain.PolicyState
+	PasswordChange         bool
+	UnknownUserAgentSignIn bool
+	MFAAdded               bool
+	MFARemoved             bool
+	EmailChanged           bool
+	PhoneChanged           bool
+	AccountLocked          bool
+	MachineCredentialAdded bool
+	State                  domain.PolicyState
 }
 
 func (wm *NotificationPolicyWriteModel) Reduce() error {
@@ -18,11 +25,39 @@ func (wm *NotificationPolicyWriteModel) Reduce() error {
 		switch e := event.(type) {
 		case *policy.NotificationPolicyAddedEvent:
 			wm.PasswordChange = e.PasswordChange
+			wm.UnknownUserAgentSignIn = e.UnknownUserAgentSignIn
+			wm.MFAAdded = e.MFAAdded
+			wm.MFARemoved = e.MFARemoved
+			wm.EmailChanged = e.EmailChanged
+			wm.PhoneChanged = e.PhoneChanged
+			wm.AccountLocked = e.AccountLocked
+			wm.MachineCredentialAdded = e.MachineCredentialAdded
 			wm.State = domain.PolicyStateActive
 		case *policy.NotificationPolicyChangedEvent:
 			if e.PasswordChange != nil {
 				wm.PasswordChange = *e.PasswordChange
 			}
+			if e.UnknownUserAgentSignIn != nil {
+				wm.UnknownUserAgentSignIn = *e.UnknownUserAgentSignIn
+			}
+			if e.MFAAdded != nil {
+				wm.MFAAdded = *e.MFAAdded
+			}
+			if e.MFARemoved != nil {
+				wm.MFARemoved = *e.MFARemoved
+			}
+			if e.EmailChanged != nil {
+				wm.EmailChanged = *e.EmailChanged
+			}
+			if e.PhoneChanged != nil {
+				wm.PhoneChanged = *e.PhoneChanged
+			}
+			if e.AccountLocked != nil {
+				wm.AccountLocked = *e.AccountLocked
+			}
+			if e.MachineCredentialAdded != nil {
+				wm.MachineCredentialAdded = *e.MachineCredentialAdded
+			}
 		case *policy.NotificationPolicyRemovedEvent:
 			wm.State = domain.PolicyStateRemoved
 		}
@@ -34,6 +34,7 @@ type addOIDCApp struct {
 	ClockSkew                   time.Duration
 	AdditionalOrigins           []string
 	SkipSuccessPageForNativeApp bool
+	ConsentRequired             bool
 
 	ClientID          string
 	ClientSecret      *crypto.CryptoValue
@@ -112,6 +113,7 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 					app.ClockSkew,
 					app.AdditionalOrigins,
 					app.SkipSuccessPageForNativeApp,
+					app.ConsentRequired,
 				),
 			}, nil
 		}, nil
@@ -196,6 +198,7 @@ func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain
 		oidcApp.ClockSkew,
 		oidcApp.AdditionalOrigins,
 		oidcApp.SkipNativeAppSuccessPage,
+		oidcApp.ConsentRequired,
 	))
 
 	addedApplication.AppID = oidcApp.AppID
@@ -248,6 +251,7 @@ func (c *Commands) ChangeOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		oidc.ClockSkew,
 		oidc.AdditionalOrigins,
 		oidc.SkipNativeAppSuccessPage,
+		oidc.ConsentRequired,
 	)
 	if err != nil {
 		return nil, err
@@ -169,6 +169,7 @@ func TestAddOIDCApp(t *testing.T) {
 						0,
 						nil,
 						false,
+						false,
 					),
 				},
 			},
@@ -323,6 +324,7 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							time.Second*1,
 							[]string{"https://sub.test.ch"},
 							true,
+							false,
 						),
 					),
 				),
@@ -557,6 +559,7 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								false,
 							),
 						),
 					),
@@ -631,6 +634,7 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								false,
 							),
 						),
 					),
@@ -828,6 +832,7 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								false,
+								false,
 							),
 						),
 					),
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetApplicationTLSClientAuth sets the client certificate of an OIDC or API application authenticating with mutual TLS (RFC 8705):
+// the subject DN of the certificate issued by a trusted CA for tls_client_auth,
+// respectively the (PEM encoded) certificate for self_signed_tls_client_auth.
+func (c *Commands) SetApplicationTLSClientAuth(ctx context.Context, projectID, appID, resourceOwner string, tlsClientAuth *domain.ApplicationTLSClientAuth) (*domain.ObjectDetails, error) {
+	if projectID == "" || appID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Aeph6", "Errors.IDMissing")
+	}
+	writeModel := NewApplicationTLSClientAuthWriteModel(projectID, appID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Uo2ie", "Errors.Project.App.NotFound")
+	}
+	if !writeModel.TLSClientAuthAllowed {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Jae3u", "Errors.Project.App.AuthMethodNoTLSClientAuth")
+	}
+	var (
+		subjectDN   string
+		certificate []byte
+	)
+	if writeModel.SelfSigned {
+		if _, err := domain.ParseX509SelfSignedCertificate(tlsClientAuth.Certificate); err != nil {
+			return nil, err
+		}
+		certificate = tlsClientAuth.Certificate
+	} else {
+		subjectDN = strings.TrimSpace(tlsClientAuth.SubjectDN)
+		if subjectDN == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ieS4u", "Errors.Project.App.TLSClientAuth.SubjectDNMissing")
+		}
+	}
+	if !writeModel.changed(subjectDN, certificate) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ree9a", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx,
+		project.NewApplicationTLSClientAuthSetEvent(
+			ctx,
+			ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+			appID,
+			subjectDN,
+			certificate,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
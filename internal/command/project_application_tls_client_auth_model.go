@@ -0,0 +1,132 @@
+package command
+
+import (
+	"bytes"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type ApplicationTLSClientAuthWriteModel struct {
+	eventstore.WriteModel
+
+	AppID       string
+	SubjectDN   string
+	Certificate []byte
+
+	State domain.AppState
+	// TLSClientAuthAllowed is set if the application authenticates with tls_client_auth or self_signed_tls_client_auth
+	TLSClientAuthAllowed bool
+	// SelfSigned is set if the application authenticates with self_signed_tls_client_auth
+	SelfSigned bool
+}
+
+func NewApplicationTLSClientAuthWriteModel(projectID, appID, resourceOwner string) *ApplicationTLSClientAuthWriteModel {
+	return &ApplicationTLSClientAuthWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		AppID: appID,
+	}
+}
+
+func (wm *ApplicationTLSClientAuthWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.ApplicationRemovedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.OIDCConfigAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.OIDCConfigChangedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.APIConfigAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.APIConfigChangedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationTLSClientAuthSetEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *ApplicationTLSClientAuthWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.OIDCConfigAddedEvent:
+			wm.State = domain.AppStateActive
+			wm.setOIDCAuthMethod(e.AuthMethodType)
+		case *project.OIDCConfigChangedEvent:
+			if e.AuthMethodType != nil {
+				wm.setOIDCAuthMethod(*e.AuthMethodType)
+			}
+		case *project.APIConfigAddedEvent:
+			wm.State = domain.AppStateActive
+			wm.setAPIAuthMethod(e.AuthMethodType)
+		case *project.APIConfigChangedEvent:
+			if e.AuthMethodType != nil {
+				wm.setAPIAuthMethod(*e.AuthMethodType)
+			}
+		case *project.ApplicationTLSClientAuthSetEvent:
+			wm.SubjectDN = e.SubjectDN
+			wm.Certificate = e.Certificate
+		case *project.ApplicationRemovedEvent:
+			wm.State = domain.AppStateRemoved
+		case *project.ProjectRemovedEvent:
+			wm.State = domain.AppStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *ApplicationTLSClientAuthWriteModel) setOIDCAuthMethod(authMethod domain.OIDCAuthMethodType) {
+	wm.TLSClientAuthAllowed = authMethod.IsTLSClientAuth()
+	wm.SelfSigned = authMethod == domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth
+}
+
+func (wm *ApplicationTLSClientAuthWriteModel) setAPIAuthMethod(authMethod domain.APIAuthMethodType) {
+	wm.TLSClientAuthAllowed = authMethod.IsTLSClientAuth()
+	wm.SelfSigned = authMethod == domain.APIAuthMethodTypeSelfSignedTLSClientAuth
+}
+
+func (wm *ApplicationTLSClientAuthWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.ApplicationRemovedType,
+			project.OIDCConfigAddedType,
+			project.OIDCConfigChangedType,
+			project.APIConfigAddedType,
+			project.APIConfigChangedType,
+			project.ApplicationTLSClientAuthSetType,
+			project.ProjectRemovedType).
+		Builder()
+}
+
+func (wm *ApplicationTLSClientAuthWriteModel) changed(subjectDN string, certificate []byte) bool {
+	return wm.SubjectDN != subjectDN || !bytes.Equal(wm.Certificate, certificate)
+}
@@ -0,0 +1,230 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_SetApplicationTLSClientAuth(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	cert := newTestX509CA(t, "client")
+	apiConfigAdded := func(authMethod domain.APIAuthMethodType) eventstore.Event {
+		return eventFromEventPusher(
+			project.NewAPIConfigAddedEvent(ctx,
+				&project.NewAggregate("project1", "org1").Aggregate,
+				"app1",
+				"client1@project",
+				nil,
+				authMethod,
+			),
+		)
+	}
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		projectID     string
+		appID         string
+		tlsClientAuth *domain.ApplicationTLSClientAuth
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing app id, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				projectID:     "project1",
+				tlsClientAuth: &domain.ApplicationTLSClientAuth{SubjectDN: "CN=client"},
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Aeph6", "Errors.IDMissing"),
+			},
+		},
+		{
+			name: "app not found, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				appID:         "app1",
+				tlsClientAuth: &domain.ApplicationTLSClientAuth{SubjectDN: "CN=client"},
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Uo2ie", "Errors.Project.App.NotFound"),
+			},
+		},
+		{
+			name: "auth method without mutual tls, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						apiConfigAdded(domain.APIAuthMethodTypePrivateKeyJWT),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				appID:         "app1",
+				tlsClientAuth: &domain.ApplicationTLSClientAuth{SubjectDN: "CN=client"},
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Jae3u", "Errors.Project.App.AuthMethodNoTLSClientAuth"),
+			},
+		},
+		{
+			name: "missing subject dn, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						apiConfigAdded(domain.APIAuthMethodTypeTLSClientAuth),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				appID:         "app1",
+				tlsClientAuth: &domain.ApplicationTLSClientAuth{SubjectDN: " "},
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-ieS4u", "Errors.Project.App.TLSClientAuth.SubjectDNMissing"),
+			},
+		},
+		{
+			name: "invalid self-signed certificate, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						apiConfigAdded(domain.APIAuthMethodTypeSelfSignedTLSClientAuth),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				appID:         "app1",
+				tlsClientAuth: &domain.ApplicationTLSClientAuth{Certificate: []byte("certificate")},
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Iek2o", "Errors.Project.App.TLSClientAuth.CertificateInvalid"),
+			},
+		},
+		{
+			name: "unchanged, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						apiConfigAdded(domain.APIAuthMethodTypeTLSClientAuth),
+						eventFromEventPusher(
+							project.NewApplicationTLSClientAuthSetEvent(ctx,
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"CN=client",
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				appID:         "app1",
+				tlsClientAuth: &domain.ApplicationTLSClientAuth{SubjectDN: "CN=client"},
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ree9a", "Errors.NoChangesFound"),
+			},
+		},
+		{
+			name: "subject dn, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						apiConfigAdded(domain.APIAuthMethodTypeTLSClientAuth),
+					),
+					expectPush(
+						project.NewApplicationTLSClientAuthSetEvent(ctx,
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"CN=client,O=ZITADEL",
+							nil,
+						),
+					),
+				),
+			},
+			args: args{
+				projectID: "project1",
+				appID:     "app1",
+				tlsClientAuth: &domain.ApplicationTLSClientAuth{
+					SubjectDN:   " CN=client,O=ZITADEL ",
+					Certificate: cert.pem,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "self-signed certificate, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						apiConfigAdded(domain.APIAuthMethodTypeSelfSignedTLSClientAuth),
+					),
+					expectPush(
+						project.NewApplicationTLSClientAuthSetEvent(ctx,
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"",
+							cert.pem,
+						),
+					),
+				),
+			},
+			args: args{
+				projectID: "project1",
+				appID:     "app1",
+				tlsClientAuth: &domain.ApplicationTLSClientAuth{
+					SubjectDN:   "CN=client",
+					Certificate: cert.pem,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetApplicationTLSClientAuth(ctx, tt.args.projectID, tt.args.appID, "org1", tt.args.tlsClientAuth)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
@@ -18,6 +18,7 @@ type QuotaUnit string
 const (
 	QuotaRequestsAllAuthenticated QuotaUnit = "requests.all.authenticated"
 	QuotaActionsAllRunsSeconds    QuotaUnit = "actions.all.runs.seconds"
+	QuotaNotificationsAllSent     QuotaUnit = "notifications.all.sent"
 )
 
 func (q QuotaUnit) Enum() quota.Unit {
@@ -26,11 +27,35 @@ func (q QuotaUnit) Enum() quota.Unit {
 		return quota.RequestsAllAuthenticated
 	case QuotaActionsAllRunsSeconds:
 		return quota.ActionsAllRunsSeconds
+	case QuotaNotificationsAllSent:
+		return quota.NotificationsAllSent
 	default:
 		return quota.Unimplemented
 	}
 }
 
+type QuotaNotificationChannel string
+
+const (
+	QuotaNotificationChannelWebhook QuotaNotificationChannel = "webhook"
+	QuotaNotificationChannelEmail   QuotaNotificationChannel = "email"
+	QuotaNotificationChannelEvent   QuotaNotificationChannel = "event"
+)
+
+// Enum returns the channel of the notification, an empty channel defaults to webhook
+func (c QuotaNotificationChannel) Enum() (quota.NotificationChannel, bool) {
+	switch c {
+	case QuotaNotificationChannelWebhook, "":
+		return quota.NotificationChannelWebhook, true
+	case QuotaNotificationChannelEmail:
+		return quota.NotificationChannelEmail, true
+	case QuotaNotificationChannelEvent:
+		return quota.NotificationChannelEvent, true
+	default:
+		return 0, false
+	}
+}
+
 // AddQuota returns and error if the quota already exists.
 // AddQuota is deprecated. Use SetQuota instead.
 func (c *Commands) AddQuota(
@@ -127,9 +152,11 @@ func (c *Commands) getQuotaWriteModel(ctx context.Context, instanceId, resourceO
 }
 
 type QuotaNotification struct {
-	Percent uint16
-	Repeat  bool
-	CallURL string
+	Percent    uint16
+	Repeat     bool
+	CallURL    string
+	Channel    QuotaNotificationChannel
+	Recipients []string
 }
 
 // SetQuota configures a quota and activates it if it isn't active already
@@ -145,12 +172,28 @@ type SetQuota struct {
 type QuotaNotifications []*QuotaNotification
 
 func (q *QuotaNotification) validate() error {
-	u, err := url.Parse(q.CallURL)
-	if err != nil {
-		return zerrors.ThrowInvalidArgument(err, "QUOTA-bZ0Fj", "Errors.Quota.Invalid.CallURL")
+	channel, ok := q.Channel.Enum()
+	if !ok {
+		return zerrors.ThrowInvalidArgument(nil, "QUOTA-Eiv3a", "Errors.Quota.Invalid.Channel")
 	}
-	if !u.IsAbs() || u.Host == "" {
-		return zerrors.ThrowInvalidArgument(nil, "QUOTA-HAYmN", "Errors.Quota.Invalid.CallURL")
+	switch channel {
+	case quota.NotificationChannelWebhook:
+		u, err := url.Parse(q.CallURL)
+		if err != nil {
+			return zerrors.ThrowInvalidArgument(err, "QUOTA-bZ0Fj", "Errors.Quota.Invalid.CallURL")
+		}
+		if !u.IsAbs() || u.Host == "" {
+			return zerrors.ThrowInvalidArgument(nil, "QUOTA-HAYmN", "Errors.Quota.Invalid.CallURL")
+		}
+	case quota.NotificationChannelEmail:
+		if len(q.Recipients) == 0 {
+			return zerrors.ThrowInvalidArgument(nil, "QUOTA-ooB4u", "Errors.Quota.Invalid.Recipients")
+		}
+		for _, recipient := range q.Recipients {
+			if err := domain.EmailAddress(recipient).Validate(); err != nil {
+				return zerrors.ThrowInvalidArgument(err, "QUOTA-Ua8ie", "Errors.Quota.Invalid.Recipients")
+			}
+		}
 	}
 	if q.Percent < 1 {
 		return zerrors.ThrowInvalidArgument(nil, "QUOTA-pBfjq", "Errors.Quota.Invalid.Percent")
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"slices"
@@ -136,7 +137,9 @@ func (wm *quotaWriteModel) NewChanges(
 	for i, notification := range setEventNotifications {
 		if notification.CallURL != wm.notifications[i].CallURL ||
 			notification.Percent != wm.notifications[i].Percent ||
-			notification.Repeat != wm.notifications[i].Repeat {
+			notification.Repeat != wm.notifications[i].Repeat ||
+			notification.Channel != wm.notifications[i].Channel ||
+			!slices.Equal(notification.Recipients, wm.notifications[i].Recipients) {
 			changes = append(changes, quota.ChangeNotifications(setEventNotifications))
 			return changes, nil
 		}
@@ -151,10 +154,14 @@ func (q QuotaNotifications) newSetEventNotifications(idGenerator id.Generator) (
 	}
 	notifications := make([]*quota.SetEventNotification, len(q))
 	for idx, notification := range q {
+		// the channel is already validated
+		channel, _ := notification.Channel.Enum()
 		notifications[idx] = &quota.SetEventNotification{
-			Percent: notification.Percent,
-			Repeat:  notification.Repeat,
-			CallURL: notification.CallURL,
+			Percent:    notification.Percent,
+			Repeat:     notification.Repeat,
+			CallURL:    notification.CallURL,
+			Channel:    channel,
+			Recipients: notification.Recipients,
 		}
 		notifications[idx].ID, err = idGenerator.Next()
 		if err != nil {
@@ -171,14 +178,25 @@ func sortSetEventNotifications(notifications []*quota.SetEventNotification) (err
 			err = zerrors.ThrowInternal(errors.New("sorting slices of *quota.SetEventNotification with nil pointers is not supported"), "QUOTA-8YXPk", "Errors.Internal")
 			return 0
 		}
-		if i.Percent == j.Percent && i.CallURL == j.CallURL && i.Repeat == j.Repeat {
+		if i.Percent == j.Percent && i.CallURL == j.CallURL && i.Repeat == j.Repeat &&
+			i.Channel == j.Channel && slices.Equal(i.Recipients, j.Recipients) {
 			// TODO: translate
 			err = zerrors.ThrowInternal(fmt.Errorf("%+v", i), "QUOTA-Pty2n", "Errors.Quota.Notifications.Duplicate")
 			return 0
 		}
-		if i.Percent < j.Percent ||
-			i.Percent == j.Percent && i.CallURL < j.CallURL ||
-			i.Percent == j.Percent && i.CallURL == j.CallURL && !i.Repeat && j.Repeat {
+		if i.Percent != j.Percent {
+			return cmp.Compare(i.Percent, j.Percent)
+		}
+		if i.Channel != j.Channel {
+			return cmp.Compare(i.Channel, j.Channel)
+		}
+		if i.CallURL != j.CallURL {
+			return cmp.Compare(i.CallURL, j.CallURL)
+		}
+		if recipients := slices.Compare(i.Recipients, j.Recipients); recipients != 0 {
+			return recipients
+		}
+		if !i.Repeat && j.Repeat {
 			return -1
 		}
 		return +1
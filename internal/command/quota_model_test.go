@@ -204,6 +204,77 @@ func TestQuotaWriteModel_NewChanges(t *testing.T) {
 			}},
 			idGenerator: id_mock.NewIDGenerator(t),
 		},
+	}, {
+		name: "change notification recipients",
+		fields: fields{
+			amount:        5,
+			from:          time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			resetInterval: time.Hour,
+			limit:         true,
+			notifications: []*quota.SetEventNotification{{
+				ID:         "notification1",
+				Percent:    10,
+				Repeat:     true,
+				Channel:    quota.NotificationChannelEmail,
+				Recipients: []string{"ops@zitadel.com"},
+			}},
+		},
+		args: args{
+			amount:        5,
+			from:          time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			resetInterval: time.Hour,
+			limit:         true,
+			notifications: []*QuotaNotification{{
+				Percent:    10,
+				Repeat:     true,
+				Channel:    QuotaNotificationChannelEmail,
+				Recipients: []string{"ops@zitadel.com", "billing@zitadel.com"},
+			}},
+			idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "notification2"),
+		},
+		wantChanges: 1,
+		wantEvent: quota.SetEvent{Notifications: &[]*quota.SetEventNotification{{
+			ID:         "notification2",
+			Percent:    10,
+			Repeat:     true,
+			Channel:    quota.NotificationChannelEmail,
+			Recipients: []string{"ops@zitadel.com", "billing@zitadel.com"},
+		}}},
+	}, {
+		name: "notifications with same percent on different channels",
+		fields: fields{
+			amount:        5,
+			from:          time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			resetInterval: time.Hour,
+			limit:         true,
+			notifications: []*quota.SetEventNotification{{
+				ID:      "notification1",
+				Percent: 10,
+				Repeat:  true,
+				Channel: quota.NotificationChannelEvent,
+			}, {
+				ID:      "notification2",
+				Percent: 10,
+				Repeat:  true,
+				CallURL: "https://call.url",
+			}},
+		},
+		args: args{
+			amount:        5,
+			from:          time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			resetInterval: time.Hour,
+			limit:         true,
+			notifications: []*QuotaNotification{{
+				Percent: 10,
+				Repeat:  true,
+				CallURL: "https://call.url",
+			}, {
+				Percent: 10,
+				Repeat:  true,
+				Channel: QuotaNotificationChannelEvent,
+			}},
+			idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "newnotification1", "newnotification2"),
+		},
 	}, {
 		name: "don't change notification order",
 		fields: fields{
@@ -56,6 +56,8 @@ func TestQuotaReport_ReportQuotaUsage(t *testing.T) {
 								QuotaRequestsAllAuthenticated.Enum(),
 								"id",
 								"url",
+								quota.NotificationChannelWebhook,
+								nil,
 								time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
 								1000,
 								200,
@@ -73,6 +75,8 @@ func TestQuotaReport_ReportQuotaUsage(t *testing.T) {
 						QuotaRequestsAllAuthenticated.Enum(),
 						"id",
 						"url",
+						quota.NotificationChannelWebhook,
+						nil,
 						time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
 						1000,
 						250,
@@ -93,6 +97,8 @@ func TestQuotaReport_ReportQuotaUsage(t *testing.T) {
 							QuotaRequestsAllAuthenticated.Enum(),
 							"id",
 							"url",
+							quota.NotificationChannelWebhook,
+							nil,
 							time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
 							1000,
 							250,
@@ -109,6 +115,8 @@ func TestQuotaReport_ReportQuotaUsage(t *testing.T) {
 						QuotaRequestsAllAuthenticated.Enum(),
 						"id",
 						"url",
+						quota.NotificationChannelWebhook,
+						nil,
 						time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
 						1000,
 						250,
@@ -131,6 +139,8 @@ func TestQuotaReport_ReportQuotaUsage(t *testing.T) {
 								QuotaRequestsAllAuthenticated.Enum(),
 								"id2",
 								"url",
+								quota.NotificationChannelWebhook,
+								nil,
 								time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
 								1000,
 								250,
@@ -144,6 +154,8 @@ func TestQuotaReport_ReportQuotaUsage(t *testing.T) {
 							QuotaRequestsAllAuthenticated.Enum(),
 							"id1",
 							"url",
+							quota.NotificationChannelWebhook,
+							nil,
 							time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
 							1000,
 							250,
@@ -153,6 +165,8 @@ func TestQuotaReport_ReportQuotaUsage(t *testing.T) {
 							QuotaRequestsAllAuthenticated.Enum(),
 							"id3",
 							"url",
+							quota.NotificationChannelWebhook,
+							nil,
 							time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
 							1000,
 							250,
@@ -169,6 +183,8 @@ func TestQuotaReport_ReportQuotaUsage(t *testing.T) {
 						QuotaRequestsAllAuthenticated.Enum(),
 						"id1",
 						"url",
+						quota.NotificationChannelWebhook,
+						nil,
 						time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
 						1000,
 						250,
@@ -179,6 +195,8 @@ func TestQuotaReport_ReportQuotaUsage(t *testing.T) {
 						QuotaRequestsAllAuthenticated.Enum(),
 						"id2",
 						"url",
+						quota.NotificationChannelWebhook,
+						nil,
 						time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
 						1000,
 						250,
@@ -189,6 +207,8 @@ func TestQuotaReport_ReportQuotaUsage(t *testing.T) {
 						QuotaRequestsAllAuthenticated.Enum(),
 						"id3",
 						"url",
+						quota.NotificationChannelWebhook,
+						nil,
 						time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
 						1000,
 						250,
@@ -247,6 +267,8 @@ func TestQuotaReport_UsageNotificationSent(t *testing.T) {
 								QuotaRequestsAllAuthenticated.Enum(),
 								"id1",
 								"url",
+								quota.NotificationChannelWebhook,
+								nil,
 								time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
 								1000,
 								250,
@@ -264,6 +286,8 @@ func TestQuotaReport_UsageNotificationSent(t *testing.T) {
 					QuotaRequestsAllAuthenticated.Enum(),
 					"id1",
 					"url",
+					quota.NotificationChannelWebhook,
+					nil,
 					time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
 					1000,
 					250,
@@ -769,6 +769,74 @@ func TestQuota_QuotaNotification_validate(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			name: "notification unknown channel",
+			args: args{
+				quotaNotification: &QuotaNotification{
+					Percent: 20,
+					Channel: "sms",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "QUOTA-Eiv3a", ""))
+				},
+			},
+		},
+		{
+			name: "email notification without recipients",
+			args: args{
+				quotaNotification: &QuotaNotification{
+					Percent: 20,
+					Channel: QuotaNotificationChannelEmail,
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "QUOTA-ooB4u", ""))
+				},
+			},
+		},
+		{
+			name: "email notification invalid recipient",
+			args: args{
+				quotaNotification: &QuotaNotification{
+					Percent:    20,
+					Channel:    QuotaNotificationChannelEmail,
+					Recipients: []string{"ops@zitadel.com", "billing"},
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "QUOTA-Ua8ie", ""))
+				},
+			},
+		},
+		{
+			name: "email notification, ok",
+			args: args{
+				quotaNotification: &QuotaNotification{
+					Percent:    20,
+					Channel:    QuotaNotificationChannelEmail,
+					Recipients: []string{"ops@zitadel.com"},
+				},
+			},
+			res: res{
+				err: nil,
+			},
+		},
+		{
+			name: "event notification, ok",
+			args: args{
+				quotaNotification: &QuotaNotification{
+					Percent: 20,
+					Channel: QuotaNotificationChannelEvent,
+				},
+			},
+			res: res{
+				err: nil,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
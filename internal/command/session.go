@@ -3,6 +3,8 @@ package command
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/base64"
 	"fmt"
 	"time"
@@ -37,6 +39,7 @@ type SessionCommands struct {
 	otpAlg      crypto.EncryptionAlgorithm
 	createCode  cryptoCodeWithDefaultFunc
 	createToken func(sessionID string) (id string, token string, err error)
+	verifyX509  func(ctx context.Context, userID, resourceOwner string, certificates []*x509.Certificate, now time.Time) (*x509Verification, error)
 	now         func() time.Time
 }
 
@@ -51,6 +54,7 @@ func (c *Commands) NewSessionCommands(cmds []SessionCommand, session *SessionWri
 		otpAlg:            c.userEncryption,
 		createCode:        c.newCodeWithDefault,
 		createToken:       c.sessionTokenCreator,
+		verifyX509:        c.verifyUserX509Certificate,
 		now:               time.Now,
 	}
 }
@@ -130,7 +134,51 @@ func CheckIntent(intentID, token string) SessionCommand {
 				return zerrors.ThrowPreconditionFailed(nil, "COMMAND-O8xk3w", "Errors.Intent.OtherUser")
 			}
 		}
-		cmd.IntentChecked(ctx, cmd.now())
+		cmd.IntentChecked(ctx, cmd.now(), intentID)
+		return nil
+	}
+}
+
+// x509ChallengeExpiry limits the time to sign the challenge of a session with the client certificate
+const x509ChallengeExpiry = 5 * time.Minute
+
+// CreateX509Challenge creates a random challenge, which must be signed with the private key of the client certificate
+// for the [CheckX509] of a later request.
+func CreateX509Challenge(dst *string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		challenge := make([]byte, 32)
+		if _, err := rand.Read(challenge); err != nil {
+			return zerrors.ThrowInternal(err, "COMMAND-Eit2o", "Errors.Internal")
+		}
+		*dst = base64.RawURLEncoding.EncodeToString(challenge)
+		cmd.X509Challenged(ctx, *dst, x509ChallengeExpiry)
+		return nil
+	}
+}
+
+// CheckX509 defines a check of the client certificate (followed by optional intermediates) to be executed for a session update.
+// As certificates are public, the signature of the X.509 challenge of the session proves the possession of the private key.
+func CheckX509(certificates []*x509.Certificate, signature []byte) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		if cmd.sessionWriteModel.UserID == "" {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohch3", "Errors.User.UserIDMissing")
+		}
+		now := cmd.now()
+		challenge := cmd.sessionWriteModel.X509Challenge
+		if challenge == nil || now.After(challenge.CreationDate.Add(challenge.Expiry)) {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ra7ae", "Errors.Session.X509.NoChallenge")
+		}
+		if len(certificates) == 0 {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-Zoo4e", "Errors.User.X509.CertificateMissing")
+		}
+		if err := domain.VerifyX509Signature(certificates[0], []byte(challenge.Challenge), signature); err != nil {
+			return err
+		}
+		verification, err := cmd.verifyX509(ctx, cmd.sessionWriteModel.UserID, cmd.sessionWriteModel.UserResourceOwner, certificates, now)
+		if err != nil {
+			return err
+		}
+		cmd.X509Checked(ctx, now, verification.CAID, verification.SerialNumber)
 		return nil
 	}
 }
@@ -169,6 +217,10 @@ func (s *SessionCommands) Exec(ctx context.Context) error {
 
 func (s *SessionCommands) Start(ctx context.Context, userAgent *domain.UserAgent) {
 	s.eventCommands = append(s.eventCommands, session.NewAddedEvent(ctx, s.sessionWriteModel.aggregate, userAgent))
+	// set the fingerprint so challenges can be bound to the user agent
+	if userAgent != nil && userAgent.FingerprintID != nil {
+		s.sessionWriteModel.FingerprintID = *userAgent.FingerprintID
+	}
 }
 
 func (s *SessionCommands) UserChecked(ctx context.Context, userID, resourceOwner string, checkedAt time.Time) error {
@@ -183,8 +235,8 @@ func (s *SessionCommands) PasswordChecked(ctx context.Context, checkedAt time.Ti
 	s.eventCommands = append(s.eventCommands, session.NewPasswordCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
 }
 
-func (s *SessionCommands) IntentChecked(ctx context.Context, checkedAt time.Time) {
-	s.eventCommands = append(s.eventCommands, session.NewIntentCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
+func (s *SessionCommands) IntentChecked(ctx context.Context, checkedAt time.Time, intentID string) {
+	s.eventCommands = append(s.eventCommands, session.NewIntentCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt, intentID))
 }
 
 func (s *SessionCommands) WebAuthNChallenged(ctx context.Context, challenge string, allowedCrentialIDs [][]byte, userVerification domain.UserVerificationRequirement, rpid string) {
@@ -210,8 +262,8 @@ func (s *SessionCommands) TOTPChecked(ctx context.Context, checkedAt time.Time)
 	s.eventCommands = append(s.eventCommands, session.NewTOTPCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
 }
 
-func (s *SessionCommands) OTPSMSChallenged(ctx context.Context, code *crypto.CryptoValue, expiry time.Duration, returnCode bool) {
-	s.eventCommands = append(s.eventCommands, session.NewOTPSMSChallengedEvent(ctx, s.sessionWriteModel.aggregate, code, expiry, returnCode))
+func (s *SessionCommands) OTPSMSChallenged(ctx context.Context, code *crypto.CryptoValue, expiry time.Duration, returnCode bool, channel domain.PhoneChannel) {
+	s.eventCommands = append(s.eventCommands, session.NewOTPSMSChallengedEvent(ctx, s.sessionWriteModel.aggregate, code, expiry, returnCode, channel))
 }
 
 func (s *SessionCommands) OTPSMSChecked(ctx context.Context, checkedAt time.Time) {
@@ -226,6 +278,22 @@ func (s *SessionCommands) OTPEmailChecked(ctx context.Context, checkedAt time.Ti
 	s.eventCommands = append(s.eventCommands, session.NewOTPEmailCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
 }
 
+func (s *SessionCommands) MagicLinkChallenged(ctx context.Context, code *crypto.CryptoValue, expiry time.Duration, returnCode bool, urlTmpl, fingerprintID string) {
+	s.eventCommands = append(s.eventCommands, session.NewMagicLinkChallengedEvent(ctx, s.sessionWriteModel.aggregate, code, expiry, returnCode, urlTmpl, fingerprintID))
+}
+
+func (s *SessionCommands) MagicLinkChecked(ctx context.Context, checkedAt time.Time) {
+	s.eventCommands = append(s.eventCommands, session.NewMagicLinkCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
+}
+
+func (s *SessionCommands) X509Challenged(ctx context.Context, challenge string, expiry time.Duration) {
+	s.eventCommands = append(s.eventCommands, session.NewX509ChallengedEvent(ctx, s.sessionWriteModel.aggregate, challenge, expiry))
+}
+
+func (s *SessionCommands) X509Checked(ctx context.Context, checkedAt time.Time, caID, serialNumber string) {
+	s.eventCommands = append(s.eventCommands, session.NewX509CheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt, caID, serialNumber))
+}
+
 func (s *SessionCommands) SetToken(ctx context.Context, tokenID string) {
 	// trigger activity log for session for user
 	activity.Trigger(ctx, s.sessionWriteModel.UserResourceOwner, s.sessionWriteModel.UserID, activity.SessionAPI)
@@ -0,0 +1,110 @@
+package command
+
+import (
+	"context"
+	"io"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func (c *Commands) CreateMagicLinkChallengeURLTemplate(urlTmpl string) (SessionCommand, error) {
+	if err := domain.RenderMagicLinkURLTemplate(io.Discard, urlTmpl, "code", "sessionID", "userID", "loginName", "displayName", language.English); err != nil {
+		return nil, err
+	}
+	return c.createMagicLinkChallenge(false, urlTmpl, nil), nil
+}
+
+func (c *Commands) CreateMagicLinkChallengeReturnCode(dst *string) SessionCommand {
+	return c.createMagicLinkChallenge(true, "", dst)
+}
+
+func (c *Commands) CreateMagicLinkChallenge() SessionCommand {
+	return c.createMagicLinkChallenge(false, "", nil)
+}
+
+// createMagicLinkChallenge creates a single use code, which will be sent as link to the verified email address of the user.
+// The challenge is bound to the fingerprint of the user agent the session was created with,
+// so a link opened on another device (e.g. from a phishing mail) cannot be used to check the session.
+func (c *Commands) createMagicLinkChallenge(returnCode bool, urlTmpl string, dst *string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) error {
+		if cmd.sessionWriteModel.UserID == "" {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-aeZ3u", "Errors.User.UserIDMissing")
+		}
+		if cmd.sessionWriteModel.FingerprintID == "" {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieG5a", "Errors.User.MagicLink.UserAgentMissing")
+		}
+		policy, err := c.getOrgLoginPolicy(ctx, cmd.sessionWriteModel.UserResourceOwner)
+		if err != nil {
+			return err
+		}
+		if !policy.AllowMagicLink {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohx4e", "Errors.User.MagicLink.NotAllowed")
+		}
+		writeModel := NewHumanEmailWriteModel(cmd.sessionWriteModel.UserID, "")
+		if err := cmd.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+			return err
+		}
+		if !writeModel.UserState.Exists() {
+			return zerrors.ThrowNotFound(nil, "COMMAND-Ci5ai", "Errors.User.NotFound")
+		}
+		if !writeModel.IsEmailVerified {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-ooZ8u", "Errors.User.Email.NotVerified")
+		}
+		code, err := cmd.createCode(ctx, cmd.eventstore.Filter, domain.SecretGeneratorTypeMagicLinkCode, cmd.otpAlg, c.defaultSecretGenerators.MagicLinkCode)
+		if err != nil {
+			return err
+		}
+		if returnCode {
+			*dst = code.Plain
+		}
+		cmd.MagicLinkChallenged(ctx, code.Crypted, code.Expiry, returnCode, urlTmpl, cmd.sessionWriteModel.FingerprintID)
+		return nil
+	}
+}
+
+func (c *Commands) MagicLinkSent(ctx context.Context, sessionID, resourceOwner string) error {
+	sessionWriteModel := NewSessionWriteModel(sessionID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel)
+	if err != nil {
+		return err
+	}
+	if sessionWriteModel.MagicLinkChallenge == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Eing4", "Errors.User.Code.NotFound")
+	}
+	return c.pushAppendAndReduce(ctx, sessionWriteModel,
+		session.NewMagicLinkSentEvent(ctx, &session.NewAggregate(sessionID, sessionWriteModel.ResourceOwner).Aggregate),
+	)
+}
+
+// CheckMagicLink defines a check of the code of a magic link to be executed for a session update.
+// The fingerprintID of the user agent opening the link must match the one the challenge was requested from.
+// The code can only be used once.
+func CheckMagicLink(code, fingerprintID string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) (err error) {
+		if cmd.sessionWriteModel.UserID == "" {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ahr3e", "Errors.User.UserIDMissing")
+		}
+		challenge := cmd.sessionWriteModel.MagicLinkChallenge
+		if challenge == nil {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ue7ei", "Errors.User.Code.NotFound")
+		}
+		// challenges are only issued for sessions with a fingerprint, an unbound challenge is never accepted
+		if challenge.FingerprintID == "" {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohg4x", "Errors.User.MagicLink.UserAgentMissing")
+		}
+		if challenge.FingerprintID != fingerprintID {
+			return zerrors.ThrowPermissionDenied(nil, "COMMAND-Iech8", "Errors.User.MagicLink.UserAgentMismatch")
+		}
+		err = crypto.VerifyCodeWithAlgorithm(challenge.CreationDate, challenge.Expiry, challenge.Code, code, cmd.otpAlg)
+		if err != nil {
+			return err
+		}
+		cmd.MagicLinkChecked(ctx, cmd.now())
+		return nil
+	}
+}
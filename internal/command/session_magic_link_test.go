@@ -0,0 +1,616 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func magicLinkLoginPolicyEvent(allowMagicLink bool) eventstore.Event {
+	return eventFromEventPusher(
+		org.NewLoginPolicyAddedEvent(context.Background(),
+			&org.NewAggregate("org").Aggregate,
+			true,
+			false,
+			false,
+			false,
+			false,
+			false,
+			false,
+			false,
+			false,
+			false,
+			allowMagicLink,
+			false,
+			domain.PasswordlessTypeNotAllowed,
+			"",
+			time.Hour*1,
+			time.Hour*2,
+			time.Hour*3,
+			time.Hour*4,
+			time.Hour*5,
+		),
+	)
+}
+
+func magicLinkHumanAddedEvent() eventstore.Event {
+	return eventFromEventPusher(
+		user.NewHumanAddedEvent(context.Background(),
+			&user.NewAggregate("userID", "org").Aggregate,
+			"username",
+			"firstname",
+			"lastname",
+			"nickname",
+			"displayname",
+			language.German,
+			domain.GenderUnspecified,
+			"email@test.ch",
+			true,
+		),
+	)
+}
+
+func TestCommands_CreateMagicLinkChallengeURLTemplate(t *testing.T) {
+	type fields struct {
+		userID        string
+		fingerprintID string
+		eventstore    func(*testing.T) *eventstore.Eventstore
+		createCode    cryptoCodeWithDefaultFunc
+	}
+	type args struct {
+		urlTmpl string
+	}
+	type res struct {
+		templateError error
+		err           error
+		commands      []eventstore.Command
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid template, precondition error",
+			args: args{
+				urlTmpl: "https://example.com/magiclink?code={{.InvalidField}}",
+			},
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			res: res{
+				templateError: zerrors.ThrowInvalidArgument(nil, "DOMAIN-ieYa7", "Errors.User.InvalidURLTemplate"),
+			},
+		},
+		{
+			name: "userID missing, precondition error",
+			args: args{
+				urlTmpl: "https://example.com/magiclink?sessionID={{.SessionID}}&code={{.Code}}",
+			},
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-aeZ3u", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "fingerprint missing, precondition error",
+			args: args{
+				urlTmpl: "https://example.com/magiclink?sessionID={{.SessionID}}&code={{.Code}}",
+			},
+			fields: fields{
+				userID:     "userID",
+				eventstore: expectEventstore(),
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieG5a", "Errors.User.MagicLink.UserAgentMissing"),
+			},
+		},
+		{
+			name: "magic link not allowed, precondition error",
+			args: args{
+				urlTmpl: "https://example.com/magiclink?sessionID={{.SessionID}}&code={{.Code}}",
+			},
+			fields: fields{
+				userID:        "userID",
+				fingerprintID: "fingerprintID",
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkLoginPolicyEvent(false),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohx4e", "Errors.User.MagicLink.NotAllowed"),
+			},
+		},
+		{
+			name: "email not verified, precondition error",
+			args: args{
+				urlTmpl: "https://example.com/magiclink?sessionID={{.SessionID}}&code={{.Code}}",
+			},
+			fields: fields{
+				userID:        "userID",
+				fingerprintID: "fingerprintID",
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkLoginPolicyEvent(true),
+					),
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-ooZ8u", "Errors.User.Email.NotVerified"),
+			},
+		},
+		{
+			name: "generate code",
+			args: args{
+				urlTmpl: "https://example.com/magiclink?sessionID={{.SessionID}}&code={{.Code}}",
+			},
+			fields: fields{
+				userID:        "userID",
+				fingerprintID: "fingerprintID",
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkLoginPolicyEvent(true),
+					),
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(context.Background(), &user.NewAggregate("userID", "org").Aggregate),
+						),
+					),
+				),
+				createCode: mockCodeWithDefault("1234567", 10*time.Minute),
+			},
+			res: res{
+				commands: []eventstore.Command{
+					session.NewMagicLinkChallengedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
+						&crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("1234567"),
+						},
+						10*time.Minute,
+						false,
+						"https://example.com/magiclink?sessionID={{.SessionID}}&code={{.Code}}",
+						"fingerprintID",
+					),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := tt.fields.eventstore(t)
+			c := &Commands{
+				eventstore: es,
+				// config will not be actively used for the test (is only for default),
+				// but not providing it would result in a nil pointer
+				defaultSecretGenerators: &SecretGenerators{
+					MagicLinkCode: emptyConfig,
+				},
+			}
+
+			cmd, err := c.CreateMagicLinkChallengeURLTemplate(tt.args.urlTmpl)
+			assert.ErrorIs(t, err, tt.res.templateError)
+			if tt.res.templateError != nil {
+				return
+			}
+
+			sessionModel := &SessionWriteModel{
+				UserID:            tt.fields.userID,
+				UserResourceOwner: "org",
+				UserCheckedAt:     testNow,
+				State:             domain.SessionStateActive,
+				FingerprintID:     tt.fields.fingerprintID,
+				aggregate:         &session.NewAggregate("sessionID", "instanceID").Aggregate,
+			}
+			cmds := &SessionCommands{
+				sessionCommands:   []SessionCommand{cmd},
+				sessionWriteModel: sessionModel,
+				eventstore:        es,
+				createCode:        tt.fields.createCode,
+				now:               time.Now,
+			}
+
+			err = cmd(context.Background(), cmds)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.commands, cmds.eventCommands)
+		})
+	}
+}
+
+func TestCommands_CreateMagicLinkChallengeReturnCode(t *testing.T) {
+	type fields struct {
+		userID        string
+		fingerprintID string
+		eventstore    func(*testing.T) *eventstore.Eventstore
+		createCode    cryptoCodeWithDefaultFunc
+	}
+	type res struct {
+		err        error
+		returnCode string
+		commands   []eventstore.Command
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "userID missing, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-aeZ3u", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "fingerprint missing, precondition error",
+			fields: fields{
+				userID:     "userID",
+				eventstore: expectEventstore(),
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieG5a", "Errors.User.MagicLink.UserAgentMissing"),
+			},
+		},
+		{
+			name: "generate code",
+			fields: fields{
+				userID:        "userID",
+				fingerprintID: "fingerprintID",
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkLoginPolicyEvent(true),
+					),
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(context.Background(), &user.NewAggregate("userID", "org").Aggregate),
+						),
+					),
+				),
+				createCode: mockCodeWithDefault("1234567", 10*time.Minute),
+			},
+			res: res{
+				returnCode: "1234567",
+				commands: []eventstore.Command{
+					session.NewMagicLinkChallengedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
+						&crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("1234567"),
+						},
+						10*time.Minute,
+						true,
+						"",
+						"fingerprintID",
+					),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := tt.fields.eventstore(t)
+			c := &Commands{
+				eventstore: es,
+				// config will not be actively used for the test (is only for default),
+				// but not providing it would result in a nil pointer
+				defaultSecretGenerators: &SecretGenerators{
+					MagicLinkCode: emptyConfig,
+				},
+			}
+			var dst string
+			cmd := c.CreateMagicLinkChallengeReturnCode(&dst)
+
+			sessionModel := &SessionWriteModel{
+				UserID:            tt.fields.userID,
+				UserResourceOwner: "org",
+				UserCheckedAt:     testNow,
+				State:             domain.SessionStateActive,
+				FingerprintID:     tt.fields.fingerprintID,
+				aggregate:         &session.NewAggregate("sessionID", "instanceID").Aggregate,
+			}
+			cmds := &SessionCommands{
+				sessionCommands:   []SessionCommand{cmd},
+				sessionWriteModel: sessionModel,
+				eventstore:        es,
+				createCode:        tt.fields.createCode,
+				now:               time.Now,
+			}
+
+			err := cmd(context.Background(), cmds)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.returnCode, dst)
+			assert.Equal(t, tt.res.commands, cmds.eventCommands)
+		})
+	}
+}
+
+func TestCommands_MagicLinkSent(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		sessionID     string
+		resourceOwner string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "not challenged, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				sessionID:     "sessionID",
+				resourceOwner: "instanceID",
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Eing4", "Errors.User.Code.NotFound"),
+		},
+		{
+			name: "challenged and sent",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							session.NewMagicLinkChallengedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("1234567"),
+								},
+								10*time.Minute,
+								false,
+								"",
+								"fingerprintID",
+							),
+						),
+					),
+					expectPush(
+						session.NewMagicLinkSentEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				sessionID:     "sessionID",
+				resourceOwner: "instanceID",
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			err := c.MagicLinkSent(tt.args.ctx, tt.args.sessionID, tt.args.resourceOwner)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCheckMagicLink(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+		userID     string
+		challenge  *MagicLinkChallenge
+		otpAlg     crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		code          string
+		fingerprintID string
+	}
+	type res struct {
+		err      error
+		commands []eventstore.Command
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing userID",
+			fields: fields{
+				eventstore: expectEventstore(),
+				userID:     "",
+			},
+			args: args{
+				code: "code",
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ahr3e", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "missing challenge",
+			fields: fields{
+				eventstore: expectEventstore(),
+				userID:     "userID",
+				challenge:  nil,
+			},
+			args: args{
+				code: "code",
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ue7ei", "Errors.User.Code.NotFound"),
+			},
+		},
+		{
+			name: "challenge not bound to user agent, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(),
+				userID:     "userID",
+				challenge: &MagicLinkChallenge{
+					OTPCode: OTPCode{
+						Code: &crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("code"),
+						},
+						Expiry:       10 * time.Minute,
+						CreationDate: testNow,
+					},
+				},
+				otpAlg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				code:          "code",
+				fingerprintID: "fingerprintID",
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohg4x", "Errors.User.MagicLink.UserAgentMissing"),
+			},
+		},
+		{
+			name: "other user agent, permission denied",
+			fields: fields{
+				eventstore: expectEventstore(),
+				userID:     "userID",
+				challenge: &MagicLinkChallenge{
+					OTPCode: OTPCode{
+						Code: &crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("code"),
+						},
+						Expiry:       10 * time.Minute,
+						CreationDate: testNow,
+					},
+					FingerprintID: "fingerprintID",
+				},
+				otpAlg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				code:          "code",
+				fingerprintID: "otherFingerprintID",
+			},
+			res: res{
+				err: zerrors.ThrowPermissionDenied(nil, "COMMAND-Iech8", "Errors.User.MagicLink.UserAgentMismatch"),
+			},
+		},
+		{
+			name: "invalid code",
+			fields: fields{
+				eventstore: expectEventstore(),
+				userID:     "userID",
+				challenge: &MagicLinkChallenge{
+					OTPCode: OTPCode{
+						Code: &crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("code"),
+						},
+						Expiry:       10 * time.Minute,
+						CreationDate: testNow.Add(-20 * time.Minute),
+					},
+					FingerprintID: "fingerprintID",
+				},
+				otpAlg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				code:          "code",
+				fingerprintID: "fingerprintID",
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "CODE-QvUQ4P", "Errors.User.Code.Expired"),
+			},
+		},
+		{
+			name: "check ok",
+			fields: fields{
+				eventstore: expectEventstore(),
+				userID:     "userID",
+				challenge: &MagicLinkChallenge{
+					OTPCode: OTPCode{
+						Code: &crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("code"),
+						},
+						Expiry:       10 * time.Minute,
+						CreationDate: testNow,
+					},
+					FingerprintID: "fingerprintID",
+				},
+				otpAlg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				code:          "code",
+				fingerprintID: "fingerprintID",
+			},
+			res: res{
+				commands: []eventstore.Command{
+					session.NewMagicLinkCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
+						testNow,
+					),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := CheckMagicLink(tt.args.code, tt.args.fingerprintID)
+
+			sessionModel := &SessionWriteModel{
+				UserID:             tt.fields.userID,
+				UserCheckedAt:      testNow,
+				State:              domain.SessionStateActive,
+				MagicLinkChallenge: tt.fields.challenge,
+				aggregate:          &session.NewAggregate("sessionID", "instanceID").Aggregate,
+			}
+			cmds := &SessionCommands{
+				sessionCommands:   []SessionCommand{cmd},
+				sessionWriteModel: sessionModel,
+				eventstore:        tt.fields.eventstore(t),
+				otpAlg:            tt.fields.otpAlg,
+				now: func() time.Time {
+					return testNow
+				},
+			}
+
+			err := cmd(context.Background(), cmds)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.commands, cmds.eventCommands)
+		})
+	}
+}
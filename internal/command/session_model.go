@@ -23,6 +23,21 @@ type OTPCode struct {
 	CreationDate time.Time
 }
 
+// MagicLinkChallenge is the single use code of a magic link,
+// bound to the fingerprint of the user agent which requested it (if known)
+type MagicLinkChallenge struct {
+	OTPCode
+	FingerprintID string
+}
+
+// X509Challenge must be signed with the private key of the client certificate
+// to prove its possession when checked through the session API
+type X509Challenge struct {
+	Challenge    string
+	Expiry       time.Duration
+	CreationDate time.Time
+}
+
 func (p *WebAuthNChallengeModel) WebAuthNLogin(human *domain.Human, credentialAssertionData []byte) *domain.WebAuthNLogin {
 	return &domain.WebAuthNLogin{
 		ObjectRoot:              human.ObjectRoot,
@@ -43,18 +58,24 @@ type SessionWriteModel struct {
 	UserCheckedAt        time.Time
 	PasswordCheckedAt    time.Time
 	IntentCheckedAt      time.Time
+	IntentID             string
 	WebAuthNCheckedAt    time.Time
 	TOTPCheckedAt        time.Time
 	OTPSMSCheckedAt      time.Time
 	OTPEmailCheckedAt    time.Time
+	MagicLinkCheckedAt   time.Time
+	X509CheckedAt        time.Time
 	WebAuthNUserVerified bool
 	Metadata             map[string][]byte
 	State                domain.SessionState
+	FingerprintID        string
 	Expiration           time.Time
 
 	WebAuthNChallenge     *WebAuthNChallengeModel
 	OTPSMSCodeChallenge   *OTPCode
 	OTPEmailCodeChallenge *OTPCode
+	MagicLinkChallenge    *MagicLinkChallenge
+	X509Challenge         *X509Challenge
 
 	aggregate *eventstore.Aggregate
 }
@@ -94,6 +115,14 @@ func (wm *SessionWriteModel) Reduce() error {
 			wm.reduceOTPEmailChallenged(e)
 		case *session.OTPEmailCheckedEvent:
 			wm.reduceOTPEmailChecked(e)
+		case *session.MagicLinkChallengedEvent:
+			wm.reduceMagicLinkChallenged(e)
+		case *session.MagicLinkCheckedEvent:
+			wm.reduceMagicLinkChecked(e)
+		case *session.X509ChallengedEvent:
+			wm.reduceX509Challenged(e)
+		case *session.X509CheckedEvent:
+			wm.reduceX509Checked(e)
 		case *session.TokenSetEvent:
 			wm.reduceTokenSet(e)
 		case *session.LifetimeSetEvent:
@@ -122,6 +151,10 @@ func (wm *SessionWriteModel) Query() *eventstore.SearchQueryBuilder {
 			session.OTPSMSCheckedType,
 			session.OTPEmailChallengedType,
 			session.OTPEmailCheckedType,
+			session.MagicLinkChallengedType,
+			session.MagicLinkCheckedType,
+			session.X509ChallengedType,
+			session.X509CheckedType,
 			session.TokenSetType,
 			session.MetadataSetType,
 			session.LifetimeSetType,
@@ -137,6 +170,9 @@ func (wm *SessionWriteModel) Query() *eventstore.SearchQueryBuilder {
 
 func (wm *SessionWriteModel) reduceAdded(e *session.AddedEvent) {
 	wm.State = domain.SessionStateActive
+	if e.UserAgent != nil && e.UserAgent.FingerprintID != nil {
+		wm.FingerprintID = *e.UserAgent.FingerprintID
+	}
 }
 
 func (wm *SessionWriteModel) reduceUserChecked(e *session.UserCheckedEvent) {
@@ -151,6 +187,7 @@ func (wm *SessionWriteModel) reducePasswordChecked(e *session.PasswordCheckedEve
 
 func (wm *SessionWriteModel) reduceIntentChecked(e *session.IntentCheckedEvent) {
 	wm.IntentCheckedAt = e.CheckedAt
+	wm.IntentID = e.IntentID
 }
 
 func (wm *SessionWriteModel) reduceWebAuthNChallenged(e *session.WebAuthNChallengedEvent) {
@@ -198,6 +235,35 @@ func (wm *SessionWriteModel) reduceOTPEmailChecked(e *session.OTPEmailCheckedEve
 	wm.OTPEmailCheckedAt = e.CheckedAt
 }
 
+func (wm *SessionWriteModel) reduceMagicLinkChallenged(e *session.MagicLinkChallengedEvent) {
+	wm.MagicLinkChallenge = &MagicLinkChallenge{
+		OTPCode: OTPCode{
+			Code:         e.Code,
+			Expiry:       e.Expiry,
+			CreationDate: e.CreationDate(),
+		},
+		FingerprintID: e.FingerprintID,
+	}
+}
+
+func (wm *SessionWriteModel) reduceMagicLinkChecked(e *session.MagicLinkCheckedEvent) {
+	wm.MagicLinkChallenge = nil
+	wm.MagicLinkCheckedAt = e.CheckedAt
+}
+
+func (wm *SessionWriteModel) reduceX509Challenged(e *session.X509ChallengedEvent) {
+	wm.X509Challenge = &X509Challenge{
+		Challenge:    e.Challenge,
+		Expiry:       e.Expiry,
+		CreationDate: e.CreationDate(),
+	}
+}
+
+func (wm *SessionWriteModel) reduceX509Checked(e *session.X509CheckedEvent) {
+	wm.X509Challenge = nil
+	wm.X509CheckedAt = e.CheckedAt
+}
+
 func (wm *SessionWriteModel) reduceTokenSet(e *session.TokenSetEvent) {
 	wm.TokenID = e.TokenID
 }
@@ -220,6 +286,8 @@ func (wm *SessionWriteModel) AuthenticationTime() time.Time {
 		wm.IntentCheckedAt,
 		wm.OTPSMSCheckedAt,
 		wm.OTPEmailCheckedAt,
+		wm.MagicLinkCheckedAt,
+		wm.X509CheckedAt,
 	} {
 		if check.After(authTime) {
 			authTime = check
@@ -253,6 +321,12 @@ func (wm *SessionWriteModel) AuthMethodTypes() []domain.UserAuthMethodType {
 	if !wm.OTPEmailCheckedAt.IsZero() {
 		types = append(types, domain.UserAuthMethodTypeOTPEmail)
 	}
+	if !wm.MagicLinkCheckedAt.IsZero() {
+		types = append(types, domain.UserAuthMethodTypeMagicLink)
+	}
+	if !wm.X509CheckedAt.IsZero() {
+		types = append(types, domain.UserAuthMethodTypeX509)
+	}
 	return types
 }
 
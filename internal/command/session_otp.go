@@ -13,14 +13,17 @@ import (
 )
 
 func (c *Commands) CreateOTPSMSChallengeReturnCode(dst *string) SessionCommand {
-	return c.createOTPSMSChallenge(true, dst)
+	return c.createOTPSMSChallenge(true, domain.PhoneChannelSMS, dst)
 }
 
-func (c *Commands) CreateOTPSMSChallenge() SessionCommand {
-	return c.createOTPSMSChallenge(false, nil)
+func (c *Commands) CreateOTPSMSChallenge(channel domain.PhoneChannel) SessionCommand {
+	return c.createOTPSMSChallenge(false, channel, nil)
 }
 
-func (c *Commands) createOTPSMSChallenge(returnCode bool, dst *string) SessionCommand {
+// createOTPSMSChallenge creates a code, which will be sent to the phone of the user over the requested channel.
+// Besides being used as second factor (OTP SMS), the code can be used as primary factor,
+// if the login policy allows phone number login and the phone number of the user is verified.
+func (c *Commands) createOTPSMSChallenge(returnCode bool, channel domain.PhoneChannel, dst *string) SessionCommand {
 	return func(ctx context.Context, cmd *SessionCommands) error {
 		if cmd.sessionWriteModel.UserID == "" {
 			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-JKL3g", "Errors.User.UserIDMissing")
@@ -29,8 +32,17 @@ func (c *Commands) createOTPSMSChallenge(returnCode bool, dst *string) SessionCo
 		if err := cmd.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
 			return err
 		}
+		if !channel.Valid() {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-ohr5E", "Errors.User.Phone.ChannelInvalid")
+		}
 		if !writeModel.OTPAdded() {
-			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-BJ2g3", "Errors.User.MFA.OTP.NotReady")
+			allowed, err := c.phoneOTPLoginAllowed(ctx, cmd.sessionWriteModel.UserID, cmd.sessionWriteModel.UserResourceOwner)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return zerrors.ThrowPreconditionFailed(nil, "COMMAND-BJ2g3", "Errors.User.MFA.OTP.NotReady")
+			}
 		}
 		code, err := cmd.createCode(ctx, cmd.eventstore.Filter, domain.SecretGeneratorTypeOTPSMS, cmd.otpAlg, c.defaultSecretGenerators.OTPSMS)
 		if err != nil {
@@ -39,11 +51,28 @@ func (c *Commands) createOTPSMSChallenge(returnCode bool, dst *string) SessionCo
 		if returnCode {
 			*dst = code.Plain
 		}
-		cmd.OTPSMSChallenged(ctx, code.Crypted, code.Expiry, returnCode)
+		cmd.OTPSMSChallenged(ctx, code.Crypted, code.Expiry, returnCode, channel)
 		return nil
 	}
 }
 
+// phoneOTPLoginAllowed checks if the login policy allows the login with a one time code sent to the phone
+// and the phone number of the user is verified.
+func (c *Commands) phoneOTPLoginAllowed(ctx context.Context, userID, resourceOwner string) (bool, error) {
+	policy, err := c.getOrgLoginPolicy(ctx, resourceOwner)
+	if err != nil {
+		return false, err
+	}
+	if !policy.AllowPhoneOTPLogin {
+		return false, nil
+	}
+	phoneWriteModel := NewHumanPhoneWriteModel(userID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, phoneWriteModel); err != nil {
+		return false, err
+	}
+	return phoneWriteModel.IsPhoneVerified, nil
+}
+
 func (c *Commands) OTPSMSSent(ctx context.Context, sessionID, resourceOwner string) error {
 	sessionWriteModel := NewSessionWriteModel(sessionID, resourceOwner)
 	err := c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel)
@@ -11,11 +11,39 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/repository/session"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
+func phoneOTPLoginPolicyEvent(allowPhoneOTPLogin bool) eventstore.Event {
+	return eventFromEventPusher(
+		org.NewLoginPolicyAddedEvent(context.Background(),
+			&org.NewAggregate("org").Aggregate,
+			true,
+			false,
+			false,
+			false,
+			false,
+			false,
+			false,
+			false,
+			false,
+			false,
+			false,
+			allowPhoneOTPLogin,
+			domain.PasswordlessTypeNotAllowed,
+			"",
+			time.Hour*1,
+			time.Hour*2,
+			time.Hour*3,
+			time.Hour*4,
+			time.Hour*5,
+		),
+	)
+}
+
 func TestCommands_CreateOTPSMSChallengeReturnCode(t *testing.T) {
 	type fields struct {
 		userID     string
@@ -48,6 +76,9 @@ func TestCommands_CreateOTPSMSChallengeReturnCode(t *testing.T) {
 				userID: "userID",
 				eventstore: expectEventstore(
 					expectFilter(),
+					expectFilter(
+						phoneOTPLoginPolicyEvent(false),
+					),
 				),
 			},
 			res: res{
@@ -79,6 +110,7 @@ func TestCommands_CreateOTPSMSChallengeReturnCode(t *testing.T) {
 						},
 						5*time.Minute,
 						true,
+						domain.PhoneChannelSMS,
 					),
 				},
 			},
@@ -86,7 +118,9 @@ func TestCommands_CreateOTPSMSChallengeReturnCode(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			es := tt.fields.eventstore(t)
 			c := &Commands{
+				eventstore: es,
 				// config will not be actively used for the test (is only for default),
 				// but not providing it would result in a nil pointer
 				defaultSecretGenerators: &SecretGenerators{
@@ -97,15 +131,16 @@ func TestCommands_CreateOTPSMSChallengeReturnCode(t *testing.T) {
 			cmd := c.CreateOTPSMSChallengeReturnCode(&dst)
 
 			sessionModel := &SessionWriteModel{
-				UserID:        tt.fields.userID,
-				UserCheckedAt: testNow,
-				State:         domain.SessionStateActive,
-				aggregate:     &session.NewAggregate("sessionID", "instanceID").Aggregate,
+				UserID:            tt.fields.userID,
+				UserResourceOwner: "org",
+				UserCheckedAt:     testNow,
+				State:             domain.SessionStateActive,
+				aggregate:         &session.NewAggregate("sessionID", "instanceID").Aggregate,
 			}
 			cmds := &SessionCommands{
 				sessionCommands:   []SessionCommand{cmd},
 				sessionWriteModel: sessionModel,
-				eventstore:        tt.fields.eventstore(t),
+				eventstore:        es,
 				createCode:        tt.fields.createCode,
 				now:               time.Now,
 			}
@@ -123,6 +158,7 @@ func TestCommands_CreateOTPSMSChallenge(t *testing.T) {
 		userID     string
 		eventstore func(*testing.T) *eventstore.Eventstore
 		createCode cryptoCodeWithDefaultFunc
+		channel    domain.PhoneChannel
 	}
 	type res struct {
 		err      error
@@ -149,6 +185,9 @@ func TestCommands_CreateOTPSMSChallenge(t *testing.T) {
 				userID: "userID",
 				eventstore: expectEventstore(
 					expectFilter(),
+					expectFilter(
+						phoneOTPLoginPolicyEvent(false),
+					),
 				),
 			},
 			res: res{
@@ -179,6 +218,79 @@ func TestCommands_CreateOTPSMSChallenge(t *testing.T) {
 						},
 						5*time.Minute,
 						false,
+						domain.PhoneChannelSMS,
+					),
+				},
+			},
+		},
+		{
+			name: "invalid channel, invalid argument error",
+			fields: fields{
+				userID: "userID",
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				channel: domain.PhoneChannel(99),
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-ohr5E", "Errors.User.Phone.ChannelInvalid"),
+			},
+		},
+		{
+			name: "phone otp login, phone not verified, precondition error",
+			fields: fields{
+				userID: "userID",
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(
+						phoneOTPLoginPolicyEvent(true),
+					),
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewHumanPhoneChangedEvent(context.Background(), &user.NewAggregate("userID", "org").Aggregate, "+41791234567"),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-BJ2g3", "Errors.User.MFA.OTP.NotReady"),
+			},
+		},
+		{
+			name: "phone otp login, generate code for whatsapp",
+			fields: fields{
+				userID: "userID",
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(
+						phoneOTPLoginPolicyEvent(true),
+					),
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewHumanPhoneChangedEvent(context.Background(), &user.NewAggregate("userID", "org").Aggregate, "+41791234567"),
+						),
+						eventFromEventPusher(
+							user.NewHumanPhoneVerifiedEvent(context.Background(), &user.NewAggregate("userID", "org").Aggregate),
+						),
+					),
+				),
+				createCode: mockCodeWithDefault("1234567", 5*time.Minute),
+				channel:    domain.PhoneChannelWhatsApp,
+			},
+			res: res{
+				commands: []eventstore.Command{
+					session.NewOTPSMSChallengedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
+						&crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("1234567"),
+						},
+						5*time.Minute,
+						false,
+						domain.PhoneChannelWhatsApp,
 					),
 				},
 			},
@@ -186,7 +298,9 @@ func TestCommands_CreateOTPSMSChallenge(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			es := tt.fields.eventstore(t)
 			c := &Commands{
+				eventstore: es,
 				// config will not be actively used for the test (is only for default),
 				// but not providing it would result in a nil pointer
 				defaultSecretGenerators: &SecretGenerators{
@@ -194,18 +308,19 @@ func TestCommands_CreateOTPSMSChallenge(t *testing.T) {
 				},
 			}
 
-			cmd := c.CreateOTPSMSChallenge()
+			cmd := c.CreateOTPSMSChallenge(tt.fields.channel)
 
 			sessionModel := &SessionWriteModel{
-				UserID:        tt.fields.userID,
-				UserCheckedAt: testNow,
-				State:         domain.SessionStateActive,
-				aggregate:     &session.NewAggregate("sessionID", "instanceID").Aggregate,
+				UserID:            tt.fields.userID,
+				UserResourceOwner: "org",
+				UserCheckedAt:     testNow,
+				State:             domain.SessionStateActive,
+				aggregate:         &session.NewAggregate("sessionID", "instanceID").Aggregate,
 			}
 			cmds := &SessionCommands{
 				sessionCommands:   []SessionCommand{cmd},
 				sessionWriteModel: sessionModel,
-				eventstore:        tt.fields.eventstore(t),
+				eventstore:        es,
 				createCode:        tt.fields.createCode,
 				now:               time.Now,
 			}
@@ -261,6 +376,7 @@ func TestCommands_OTPSMSSent(t *testing.T) {
 								},
 								5*time.Minute,
 								false,
+								domain.PhoneChannelSMS,
 							),
 						),
 					),
@@ -2,10 +2,15 @@ package command
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+	"github.com/zitadel/zitadel/internal/notification/messages"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -110,6 +115,87 @@ func (c *Commands) ChangeSMSConfigTwilioToken(ctx context.Context, instanceID, i
 	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
 }
 
+func (c *Commands) AddSMSConfigHTTP(ctx context.Context, instanceID string, config *webhook.Config) (string, *domain.ObjectDetails, error) {
+	if err := validateHTTPEndpoint(config.CallURL); err != nil {
+		return "", nil, err
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return "", nil, err
+	}
+	signingKey, err := encryptSigningKey(config.SigningKey, c.smsEncryption)
+	if err != nil {
+		return "", nil, err
+	}
+
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMSConfigHTTPAddedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.CallURL,
+		config.Headers,
+		signingKey))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+// ChangeSMSConfigHTTP changes the endpoint and headers of the provider,
+// the signing key is only changed if a new one is provided
+func (c *Commands) ChangeSMSConfigHTTP(ctx context.Context, instanceID, id string, config *webhook.Config) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Fh2qk", "Errors.IDMissing")
+	}
+	if err := validateHTTPEndpoint(config.CallURL); err != nil {
+		return nil, err
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.HTTP == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ue8zv", "Errors.SMSConfig.NotFound")
+	}
+	signingKey, err := encryptSigningKey(config.SigningKey, c.smsEncryption)
+	if err != nil {
+		return nil, err
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+
+	changedEvent, hasChanged, err := smsConfigWriteModel.NewHTTPChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.CallURL,
+		config.Headers,
+		signingKey)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gq7ys", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
 func (c *Commands) ActivateSMSConfig(ctx context.Context, instanceID, id string) (*domain.ObjectDetails, error) {
 	if id == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-dn93n", "Errors.IDMissing")
@@ -125,11 +211,19 @@ func (c *Commands) ActivateSMSConfig(ctx context.Context, instanceID, id string)
 	if smsConfigWriteModel.State == domain.SMSConfigStateActive {
 		return nil, zerrors.ThrowNotFound(nil, "COMMAND-sn9we", "Errors.SMSConfig.AlreadyActive")
 	}
+	activeWriteModel := NewIAMSMSConfigActiveWriteModel(instanceID)
+	err = c.eventstore.FilterToQueryReducer(ctx, activeWriteModel)
+	if err != nil {
+		return nil, err
+	}
 	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
-	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMSConfigTwilioActivatedEvent(
-		ctx,
-		iamAgg,
-		id))
+	// only one provider can be active, so the currently active one is deactivated
+	cmds := make([]eventstore.Command, 0, 2)
+	if activeWriteModel.ActiveID != "" {
+		cmds = append(cmds, instance.NewSMSConfigDeactivatedEvent(ctx, iamAgg, activeWriteModel.ActiveID))
+	}
+	cmds = append(cmds, instance.NewSMSConfigTwilioActivatedEvent(ctx, iamAgg, id))
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
 	if err != nil {
 		return nil, err
 	}
@@ -196,6 +290,62 @@ func (c *Commands) RemoveSMSConfig(ctx context.Context, instanceID, id string) (
 	}
 	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
 }
+
+// TestSMSConfig sends a test message to the phone number with the stored provider, regardless of its state
+func (c *Commands) TestSMSConfig(ctx context.Context, instanceID, id, phone string) error {
+	if id == "" {
+		return zerrors.ThrowInvalidArgument(nil, "SMS-Kd8wq", "Errors.IDMissing")
+	}
+	if phone == "" {
+		return zerrors.ThrowInvalidArgument(nil, "SMS-Lo2xn", "Errors.SMSConfig.TestPhoneMissing")
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return err
+	}
+	if !smsConfigWriteModel.State.Exists() {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Pq3vm", "Errors.SMSConfig.NotFound")
+	}
+	message := &messages.SMS{
+		RecipientPhoneNumber: phone,
+		Content:              "This is a test message to verify the configuration of your SMS provider.",
+	}
+	var channel channels.NotificationChannel
+	switch {
+	case smsConfigWriteModel.Twilio != nil:
+		token, err := crypto.DecryptString(smsConfigWriteModel.Twilio.Token, c.smsEncryption)
+		if err != nil {
+			return err
+		}
+		message.SenderPhoneNumber = smsConfigWriteModel.Twilio.SenderNumber
+		channel = twilio.InitChannel(twilio.Config{
+			SID:          smsConfigWriteModel.Twilio.SID,
+			Token:        token,
+			SenderNumber: smsConfigWriteModel.Twilio.SenderNumber,
+		})
+	case smsConfigWriteModel.HTTP != nil:
+		signingKey, err := decryptOptionalString(smsConfigWriteModel.HTTP.SigningKey, c.smsEncryption)
+		if err != nil {
+			return err
+		}
+		channel, err = webhook.InitChannel(ctx, webhook.Config{
+			CallURL:    smsConfigWriteModel.HTTP.Endpoint,
+			Method:     http.MethodPost,
+			Headers:    smsConfigWriteModel.HTTP.Headers,
+			SigningKey: signingKey,
+		})
+		if err != nil {
+			return err
+		}
+	default:
+		return zerrors.ThrowNotFound(nil, "COMMAND-Pq3vn", "Errors.SMSConfig.NotFound")
+	}
+	if err = channel.HandleMessage(message); err != nil {
+		return zerrors.ThrowPreconditionFailed(err, "COMMAND-Wm2ko", "Errors.SMSConfig.TestFailed")
+	}
+	return nil
+}
+
 func (c *Commands) getSMSConfig(ctx context.Context, instanceID, id string) (_ *IAMSMSConfigWriteModel, err error) {
 	writeModel := NewIAMSMSConfigWriteModel(instanceID, id)
 	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"net"
+	"net/http"
 	"strings"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -10,32 +11,35 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+	"github.com/zitadel/zitadel/internal/notification/messages"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
-func (c *Commands) AddSMTPConfig(ctx context.Context, config *smtp.Config) (*domain.ObjectDetails, error) {
+func (c *Commands) AddSMTPConfig(ctx context.Context, description string, config *smtp.Config) (string, *domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareAddSMTPConfig(instanceAgg, config.From, config.FromName, config.ReplyToAddress, config.SMTP.Host, config.SMTP.User, []byte(config.SMTP.Password), config.Tls)
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	validation := c.prepareAddSMTPConfig(instanceAgg, id, description, config.From, config.FromName, config.ReplyToAddress, config.SMTP.Host, config.SMTP.User, []byte(config.SMTP.Password), config.Tls, smtpConfigConnection(&config.SMTP))
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 	events, err := c.eventstore.Push(ctx, cmds...)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
-	return &domain.ObjectDetails{
-		Sequence:      events[len(events)-1].Sequence(),
-		EventDate:     events[len(events)-1].CreatedAt(),
-		ResourceOwner: events[len(events)-1].Aggregate().InstanceID,
-	}, nil
+	return id, pushedEventsToObjectDetails(events), nil
 }
 
-func (c *Commands) ChangeSMTPConfig(ctx context.Context, config *smtp.Config) (*domain.ObjectDetails, error) {
+func (c *Commands) ChangeSMTPConfig(ctx context.Context, id, description string, config *smtp.Config) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareChangeSMTPConfig(instanceAgg, config.From, config.FromName, config.ReplyToAddress, config.SMTP.Host, config.SMTP.User, config.Tls)
+	validation := c.prepareChangeSMTPConfig(instanceAgg, id, description, config.From, config.FromName, config.ReplyToAddress, config.SMTP.Host, config.SMTP.User, config.Tls, smtpConfigConnection(&config.SMTP))
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
 		return nil, err
@@ -44,20 +48,19 @@ func (c *Commands) ChangeSMTPConfig(ctx context.Context, config *smtp.Config) (*
 	if err != nil {
 		return nil, err
 	}
-	return &domain.ObjectDetails{
-		Sequence:      events[len(events)-1].Sequence(),
-		EventDate:     events[len(events)-1].CreatedAt(),
-		ResourceOwner: events[len(events)-1].Aggregate().InstanceID,
-	}, nil
+	return pushedEventsToObjectDetails(events), nil
 }
 
-func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, password string) (*domain.ObjectDetails, error) {
+func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, id, password string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-4n8fs", "Errors.IDMissing")
+	}
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	smtpConfigWriteModel, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, "")
+	smtpConfigWriteModel, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, id, "")
 	if err != nil {
 		return nil, err
 	}
-	if smtpConfigWriteModel.State != domain.SMTPConfigStateActive {
+	if !smtpConfigWriteModel.State.Exists() || smtpConfigWriteModel.HTTP != nil {
 		return nil, zerrors.ThrowNotFound(nil, "COMMAND-3n9ls", "Errors.SMTPConfig.NotFound")
 	}
 	var smtpPassword *crypto.CryptoValue
@@ -70,20 +73,81 @@ func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, password string
 	events, err := c.eventstore.Push(ctx, instance.NewSMTPConfigPasswordChangedEvent(
 		ctx,
 		&instanceAgg.Aggregate,
+		smtpConfigWriteModel.eventID(),
 		smtpPassword))
 	if err != nil {
 		return nil, err
 	}
-	return &domain.ObjectDetails{
-		Sequence:      events[len(events)-1].Sequence(),
-		EventDate:     events[len(events)-1].CreatedAt(),
-		ResourceOwner: events[len(events)-1].Aggregate().InstanceID,
-	}, nil
+	return pushedEventsToObjectDetails(events), nil
+}
+
+func (c *Commands) RemoveSMTPConfig(ctx context.Context, id string) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	validation := c.prepareRemoveSMTPConfig(instanceAgg, id)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
+	if err != nil {
+		return nil, err
+	}
+	events, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(events), nil
+}
+
+func (c *Commands) AddSMTPConfigHTTP(ctx context.Context, description string, config *webhook.Config) (string, *domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	validation := c.prepareAddSMTPConfigHTTP(instanceAgg, id, description, config.CallURL, config.Headers, config.SigningKey)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
+	if err != nil {
+		return "", nil, err
+	}
+	events, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, pushedEventsToObjectDetails(events), nil
+}
+
+// ChangeSMTPConfigHTTP changes the endpoint and headers of the http email provider,
+// the signing key is only changed if a new one is provided
+func (c *Commands) ChangeSMTPConfigHTTP(ctx context.Context, id, description string, config *webhook.Config) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	validation := c.prepareChangeSMTPConfigHTTP(instanceAgg, id, description, config.CallURL, config.Headers, config.SigningKey)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
+	if err != nil {
+		return nil, err
+	}
+	events, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(events), nil
+}
+
+// ActivateSMTPConfig activates the configuration and deactivates the previously active one,
+// as only one configuration of the instance can be used at a time
+func (c *Commands) ActivateSMTPConfig(ctx context.Context, id string) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	validation := c.prepareActivateSMTPConfig(instanceAgg, id)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
+	if err != nil {
+		return nil, err
+	}
+	events, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(events), nil
 }
 
-func (c *Commands) RemoveSMTPConfig(ctx context.Context) (*domain.ObjectDetails, error) {
+func (c *Commands) DeactivateSMTPConfig(ctx context.Context, id string) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareRemoveSMTPConfig(instanceAgg)
+	validation := c.prepareDeactivateSMTPConfig(instanceAgg, id)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
 		return nil, err
@@ -92,14 +156,173 @@ func (c *Commands) RemoveSMTPConfig(ctx context.Context) (*domain.ObjectDetails,
 	if err != nil {
 		return nil, err
 	}
-	return &domain.ObjectDetails{
-		Sequence:      events[len(events)-1].Sequence(),
-		EventDate:     events[len(events)-1].CreatedAt(),
-		ResourceOwner: events[len(events)-1].Aggregate().InstanceID,
-	}, nil
+	return pushedEventsToObjectDetails(events), nil
+}
+
+// TestSMTPConfig sends a test email with the provided configuration before it is stored or activated.
+// If an id is provided and the password is empty, the stored password of the configuration is used.
+func (c *Commands) TestSMTPConfig(ctx context.Context, id, email string, config *smtp.Config) error {
+	if email == "" {
+		return zerrors.ThrowInvalidArgument(nil, "SMTP-p9uy", "Errors.SMTPConfig.TestEmailNotFound")
+	}
+	if err := validateSMTPConfigConnection(smtpConfigConnection(&config.SMTP), config.SMTP.User, config.Tls); err != nil {
+		return err
+	}
+	if id != "" && config.SMTP.Password == "" {
+		smtpConfigWriteModel, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, id, "")
+		if err != nil {
+			return err
+		}
+		if !smtpConfigWriteModel.State.Exists() || smtpConfigWriteModel.HTTP != nil {
+			return zerrors.ThrowNotFound(nil, "SMTP-p9cc", "Errors.SMTPConfig.NotFound")
+		}
+		if smtpConfigWriteModel.Password != nil {
+			config.SMTP.Password, err = crypto.DecryptString(smtpConfigWriteModel.Password, c.smtpEncryption)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	channel, err := smtp.InitChannel(config)
+	if err != nil {
+		return zerrors.ThrowPreconditionFailed(err, "SMTP-m0fd2", "Errors.SMTPConfig.TestFailed")
+	}
+	return sendTestEmail(channel, email)
+}
+
+// TestSMTPConfigByID sends a test email with the stored configuration, regardless of its state
+func (c *Commands) TestSMTPConfigByID(ctx context.Context, id, email string) error {
+	if id == "" {
+		return zerrors.ThrowInvalidArgument(nil, "SMTP-99oki", "Errors.IDMissing")
+	}
+	if email == "" {
+		return zerrors.ThrowInvalidArgument(nil, "SMTP-99yth", "Errors.SMTPConfig.TestEmailNotFound")
+	}
+	smtpConfigWriteModel, err := getSMTPConfigWriteModel(ctx, c.eventstore.Filter, id, "")
+	if err != nil {
+		return err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return zerrors.ThrowNotFound(nil, "SMTP-99klw", "Errors.SMTPConfig.NotFound")
+	}
+	if smtpConfigWriteModel.HTTP != nil {
+		signingKey, err := decryptOptionalString(smtpConfigWriteModel.HTTP.SigningKey, c.smtpEncryption)
+		if err != nil {
+			return err
+		}
+		channel, err := webhook.InitChannel(ctx, webhook.Config{
+			CallURL:    smtpConfigWriteModel.HTTP.Endpoint,
+			Method:     http.MethodPost,
+			Headers:    smtpConfigWriteModel.HTTP.Headers,
+			SigningKey: signingKey,
+		})
+		if err != nil {
+			return err
+		}
+		return sendTestEmail(channel, email)
+	}
+	password, err := decryptOptionalString(smtpConfigWriteModel.Password, c.smtpEncryption)
+	if err != nil {
+		return err
+	}
+	channel, err := smtp.InitChannel(&smtp.Config{
+		Tls:            smtpConfigWriteModel.TLS,
+		From:           smtpConfigWriteModel.SenderAddress,
+		FromName:       smtpConfigWriteModel.SenderName,
+		ReplyToAddress: smtpConfigWriteModel.ReplyToAddress,
+		SMTP: smtpConfigConnectionToSMTP(smtpConfigWriteModel.Connection, smtp.SMTP{
+			Host:     smtpConfigWriteModel.Host,
+			User:     smtpConfigWriteModel.User,
+			Password: password,
+		}),
+	})
+	if err != nil {
+		return zerrors.ThrowPreconditionFailed(err, "SMTP-9wq3d", "Errors.SMTPConfig.TestFailed")
+	}
+	return sendTestEmail(channel, email)
+}
+
+func sendTestEmail(channel channels.NotificationChannel, email string) error {
+	err := channel.HandleMessage(&messages.Email{
+		Recipients: []string{email},
+		Subject:    "Test email",
+		Content:    "This is a test email to verify the configuration of your email provider.",
+	})
+	if err != nil {
+		return zerrors.ThrowPreconditionFailed(err, "SMTP-Ks9oe", "Errors.SMTPConfig.TestFailed")
+	}
+	return nil
+}
+
+// smtpConfigConnection maps the settings of the connection, nil is returned if the defaults are used
+func smtpConfigConnection(config *smtp.SMTP) *instance.SMTPConfigConnection {
+	if config.AuthMechanism == domain.SMTPAuthMechanismUnspecified &&
+		config.TLSMode == domain.SMTPTLSModeUnspecified &&
+		config.Timeout == 0 &&
+		config.XOAuth2 == nil {
+		return nil
+	}
+	connection := &instance.SMTPConfigConnection{
+		AuthMechanism: config.AuthMechanism,
+		TLSMode:       config.TLSMode,
+		Timeout:       config.Timeout,
+	}
+	if config.XOAuth2 != nil {
+		connection.XOAuth2 = &instance.SMTPConfigXOAuth2{
+			TokenEndpoint: strings.TrimSpace(config.XOAuth2.TokenEndpoint),
+			ClientID:      strings.TrimSpace(config.XOAuth2.ClientID),
+			Scopes:        config.XOAuth2.Scopes,
+		}
+	}
+	return connection
+}
+
+func smtpConfigConnectionToSMTP(connection *instance.SMTPConfigConnection, config smtp.SMTP) smtp.SMTP {
+	if connection == nil {
+		return config
+	}
+	config.AuthMechanism = connection.AuthMechanism
+	config.TLSMode = connection.TLSMode
+	config.Timeout = connection.Timeout
+	if connection.XOAuth2 != nil {
+		config.XOAuth2 = &smtp.XOAuth2{
+			TokenEndpoint: connection.XOAuth2.TokenEndpoint,
+			ClientID:      connection.XOAuth2.ClientID,
+			Scopes:        connection.XOAuth2.Scopes,
+		}
+	}
+	return config
+}
+
+// validateSMTPConfigConnection checks the settings of the connection,
+// the client for the access token is required for and only allowed with the XOAUTH2 mechanism,
+// which also requires an encrypted connection
+func validateSMTPConfigConnection(connection *instance.SMTPConfigConnection, user string, tls bool) error {
+	if connection == nil {
+		return nil
+	}
+	if !connection.AuthMechanism.Valid() || !connection.TLSMode.Valid() || connection.Timeout < 0 {
+		return zerrors.ThrowInvalidArgument(nil, "INST-Vq3ob", "Errors.SMTPConfig.ConnectionInvalid")
+	}
+	if connection.AuthMechanism != domain.SMTPAuthMechanismXOAuth2 {
+		if connection.XOAuth2 != nil {
+			return zerrors.ThrowInvalidArgument(nil, "INST-Vq3oc", "Errors.SMTPConfig.XOAuth2Invalid")
+		}
+		return nil
+	}
+	if user == "" || connection.XOAuth2 == nil || connection.XOAuth2.ClientID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "INST-Vq3od", "Errors.SMTPConfig.XOAuth2Invalid")
+	}
+	if err := validateHTTPEndpoint(connection.XOAuth2.TokenEndpoint); err != nil {
+		return zerrors.ThrowInvalidArgument(err, "INST-Vq3oe", "Errors.SMTPConfig.XOAuth2Invalid")
+	}
+	if connection.TLSMode == domain.SMTPTLSModeNone || (connection.TLSMode == domain.SMTPTLSModeUnspecified && !tls) {
+		return zerrors.ThrowInvalidArgument(nil, "INST-Vq3of", "Errors.SMTPConfig.XOAuth2TLSRequired")
+	}
+	return nil
 }
 
-func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, from, name, replyTo, hostAndPort, user string, password []byte, tls bool) preparation.Validation {
+func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, id, description, from, name, replyTo, hostAndPort, user string, password []byte, tls bool, connection *instance.SMTPConfigConnection) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		if from = strings.TrimSpace(from); from == "" {
 			return nil, zerrors.ThrowInvalidArgument(nil, "INST-mruNY", "Errors.Invalid.Argument")
@@ -111,14 +334,17 @@ func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, from, name, reply
 		if _, _, err := net.SplitHostPort(hostAndPort); err != nil {
 			return nil, zerrors.ThrowInvalidArgument(nil, "INST-9JdRe", "Errors.Invalid.Argument")
 		}
+		if err := validateSMTPConfigConnection(connection, user, tls); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			fromSplitted := strings.Split(from, "@")
 			senderDomain := fromSplitted[len(fromSplitted)-1]
-			writeModel, err := getSMTPConfigWriteModel(ctx, filter, senderDomain)
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, senderDomain)
 			if err != nil {
 				return nil, err
 			}
-			if writeModel.State == domain.SMTPConfigStateActive {
+			if writeModel.State.Exists() {
 				return nil, zerrors.ThrowAlreadyExists(nil, "INST-W3VS2", "Errors.SMTPConfig.AlreadyExists")
 			}
 			err = checkSenderAddress(writeModel)
@@ -136,6 +362,8 @@ func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, from, name, reply
 				instance.NewSMTPConfigAddedEvent(
 					ctx,
 					&a.Aggregate,
+					smtpConfigIDForEvent(a.ID, id),
+					description,
 					tls,
 					from,
 					name,
@@ -143,14 +371,18 @@ func (c *Commands) prepareAddSMTPConfig(a *instance.Aggregate, from, name, reply
 					hostAndPort,
 					user,
 					smtpPassword,
+					connection,
 				),
 			}, nil
 		}, nil
 	}
 }
 
-func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, replyTo, hostAndPort, user string, tls bool) preparation.Validation {
+func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, id, description, from, name, replyTo, hostAndPort, user string, tls bool, connection *instance.SMTPConfigConnection) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
+		if id == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-x8vo9", "Errors.IDMissing")
+		}
 		if from = strings.TrimSpace(from); from == "" {
 			return nil, zerrors.ThrowInvalidArgument(nil, "INST-ASv2d", "Errors.Invalid.Argument")
 		}
@@ -160,14 +392,17 @@ func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, re
 		if _, _, err := net.SplitHostPort(hostAndPort); err != nil {
 			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Kv875", "Errors.Invalid.Argument")
 		}
+		if err := validateSMTPConfigConnection(connection, user, tls); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			fromSplitted := strings.Split(from, "@")
 			senderDomain := fromSplitted[len(fromSplitted)-1]
-			writeModel, err := getSMTPConfigWriteModel(ctx, filter, senderDomain)
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, senderDomain)
 			if err != nil {
 				return nil, err
 			}
-			if writeModel.State != domain.SMTPConfigStateActive {
+			if !writeModel.State.Exists() || writeModel.HTTP != nil {
 				return nil, zerrors.ThrowNotFound(nil, "INST-Svq1a", "Errors.SMTPConfig.NotFound")
 			}
 			err = checkSenderAddress(writeModel)
@@ -177,12 +412,14 @@ func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, re
 			changedEvent, hasChanged, err := writeModel.NewChangedEvent(
 				ctx,
 				&a.Aggregate,
+				description,
 				tls,
 				from,
 				name,
 				replyTo,
 				hostAndPort,
 				user,
+				connection,
 			)
 			if err != nil {
 				return nil, err
@@ -197,18 +434,151 @@ func (c *Commands) prepareChangeSMTPConfig(a *instance.Aggregate, from, name, re
 	}
 }
 
-func (c *Commands) prepareRemoveSMTPConfig(a *instance.Aggregate) preparation.Validation {
+func (c *Commands) prepareAddSMTPConfigHTTP(a *instance.Aggregate, id, description, endpoint string, headers http.Header, signingKey string) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
+		if err := validateHTTPEndpoint(endpoint); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
-			writeModel, err := getSMTPConfigWriteModel(ctx, filter, "")
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, "")
+			if err != nil {
+				return nil, err
+			}
+			if writeModel.State.Exists() {
+				return nil, zerrors.ThrowAlreadyExists(nil, "INST-Jc8bd", "Errors.SMTPConfig.AlreadyExists")
+			}
+			key, err := encryptSigningKey(signingKey, c.smtpEncryption)
+			if err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				instance.NewSMTPConfigHTTPAddedEvent(
+					ctx,
+					&a.Aggregate,
+					smtpConfigIDForEvent(a.ID, id),
+					description,
+					endpoint,
+					headers,
+					key,
+				),
+			}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) prepareChangeSMTPConfigHTTP(a *instance.Aggregate, id, description, endpoint string, headers http.Header, signingKey string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if id == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Bq9ce", "Errors.IDMissing")
+		}
+		if err := validateHTTPEndpoint(endpoint); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, "")
+			if err != nil {
+				return nil, err
+			}
+			if !writeModel.State.Exists() || writeModel.HTTP == nil {
+				return nil, zerrors.ThrowNotFound(nil, "INST-Ns1ke", "Errors.SMTPConfig.NotFound")
+			}
+			key, err := encryptSigningKey(signingKey, c.smtpEncryption)
+			if err != nil {
+				return nil, err
+			}
+			changedEvent, hasChanged, err := writeModel.NewHTTPChangedEvent(
+				ctx,
+				&a.Aggregate,
+				description,
+				endpoint,
+				headers,
+				key,
+			)
 			if err != nil {
 				return nil, err
 			}
-			if writeModel.State != domain.SMTPConfigStateActive {
+			if !hasChanged {
+				return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Zr4ki", "Errors.NoChangesFound")
+			}
+			return []eventstore.Command{
+				changedEvent,
+			}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) prepareRemoveSMTPConfig(a *instance.Aggregate, id string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if id == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Ge8kd", "Errors.IDMissing")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, "")
+			if err != nil {
+				return nil, err
+			}
+			if !writeModel.State.Exists() {
 				return nil, zerrors.ThrowNotFound(nil, "INST-Sfefg", "Errors.SMTPConfig.NotFound")
 			}
 			return []eventstore.Command{
-				instance.NewSMTPConfigRemovedEvent(ctx, &a.Aggregate),
+				instance.NewSMTPConfigRemovedEvent(ctx, &a.Aggregate, writeModel.eventID()),
+			}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) prepareActivateSMTPConfig(a *instance.Aggregate, id string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if id == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Fq2mx", "Errors.IDMissing")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, "")
+			if err != nil {
+				return nil, err
+			}
+			if !writeModel.State.Exists() {
+				return nil, zerrors.ThrowNotFound(nil, "INST-Ks8dr", "Errors.SMTPConfig.NotFound")
+			}
+			if writeModel.State == domain.SMTPConfigStateActive {
+				return nil, zerrors.ThrowPreconditionFailed(nil, "INST-Wv2ap", "Errors.SMTPConfig.AlreadyActive")
+			}
+			activeWriteModel := NewInstanceSMTPConfigActiveWriteModel(a.ID)
+			events, err := filter(ctx, activeWriteModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			activeWriteModel.AppendEvents(events...)
+			if err = activeWriteModel.Reduce(); err != nil {
+				return nil, err
+			}
+			cmds := make([]eventstore.Command, 0, 2)
+			if activeWriteModel.ActiveID != "" {
+				cmds = append(cmds, instance.NewSMTPConfigDeactivatedEvent(ctx, &a.Aggregate, smtpConfigIDForEvent(a.ID, activeWriteModel.ActiveID)))
+			}
+			return append(cmds, instance.NewSMTPConfigActivatedEvent(ctx, &a.Aggregate, writeModel.eventID())), nil
+		}, nil
+	}
+}
+
+func (c *Commands) prepareDeactivateSMTPConfig(a *instance.Aggregate, id string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if id == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Rz6mu", "Errors.IDMissing")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel, err := getSMTPConfigWriteModel(ctx, filter, id, "")
+			if err != nil {
+				return nil, err
+			}
+			if !writeModel.State.Exists() {
+				return nil, zerrors.ThrowNotFound(nil, "INST-Hb3pe", "Errors.SMTPConfig.NotFound")
+			}
+			if writeModel.State == domain.SMTPConfigStateInactive {
+				return nil, zerrors.ThrowPreconditionFailed(nil, "INST-Mn4kr", "Errors.SMTPConfig.AlreadyDeactivated")
+			}
+			return []eventstore.Command{
+				instance.NewSMTPConfigDeactivatedEvent(ctx, &a.Aggregate, writeModel.eventID()),
 			}, nil
 		}, nil
 	}
@@ -224,8 +594,8 @@ func checkSenderAddress(writeModel *InstanceSMTPConfigWriteModel) error {
 	return nil
 }
 
-func getSMTPConfigWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer, domain string) (_ *InstanceSMTPConfigWriteModel, err error) {
-	writeModel := NewInstanceSMTPConfigWriteModel(authz.GetInstance(ctx).InstanceID(), domain)
+func getSMTPConfigWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer, id, domain string) (_ *InstanceSMTPConfigWriteModel, err error) {
+	writeModel := NewInstanceSMTPConfigWriteModel(authz.GetInstance(ctx).InstanceID(), id, domain)
 	events, err := filter(ctx, writeModel.Query())
 	if err != nil {
 		return nil, err
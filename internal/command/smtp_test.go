@@ -2,7 +2,9 @@ package command
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -11,22 +13,28 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 func TestCommandSide_AddSMTPConfig(t *testing.T) {
 	type fields struct {
-		eventstore *eventstore.Eventstore
-		alg        crypto.EncryptionAlgorithm
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+		alg         crypto.EncryptionAlgorithm
 	}
 	type args struct {
-		ctx  context.Context
-		smtp *smtp.Config
+		ctx         context.Context
+		description string
+		smtp        *smtp.Config
 	}
 	type res struct {
 		want *domain.ObjectDetails
+		id   string
 		err  func(error) bool
 	}
 	tests := []struct {
@@ -38,6 +46,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		{
 			name: "smtp config, custom domain not existing",
 			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
 				eventstore: eventstoreExpect(
 					t,
 					expectFilter(
@@ -53,7 +62,8 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -72,6 +82,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		{
 			name: "smtp config, error already exists",
 			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
 				eventstore: eventstoreExpect(
 					t,
 					expectFilter(
@@ -91,6 +102,8 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 						eventFromEventPusher(
 							instance.NewSMTPConfigAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
 								true,
 								"from@domain.ch",
 								"name",
@@ -98,13 +111,15 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								nil,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:            true,
 					From:           "from@domain.ch",
@@ -124,6 +139,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		{
 			name: "add smtp config, ok",
 			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
 				eventstore: eventstoreExpect(
 					t,
 					expectFilter(
@@ -145,6 +161,8 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 						instance.NewSMTPConfigAddedEvent(
 							context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
+							"configid",
+							"test",
 							true,
 							"from@domain.ch",
 							"name",
@@ -157,13 +175,15 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 								KeyID:      "id",
 								Crypted:    []byte("password"),
 							},
+							nil,
 						),
 					),
 				),
 				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -179,11 +199,179 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				want: &domain.ObjectDetails{
 					ResourceOwner: "INSTANCE",
 				},
+				id: "configid",
+			},
+		},
+		{
+			name: "add smtp config with xoauth2, ok",
+			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewDomainAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"domain.ch",
+								false,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewDomainPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								true, true, false,
+							),
+						),
+					),
+					expectPush(
+						instance.NewSMTPConfigAddedEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"configid",
+							"test",
+							true,
+							"from@domain.ch",
+							"name",
+							"",
+							"host:587",
+							"user",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("secret"),
+							},
+							&instance.SMTPConfigConnection{
+								AuthMechanism: domain.SMTPAuthMechanismXOAuth2,
+								TLSMode:       domain.SMTPTLSModeStartTLS,
+								Timeout:       time.Minute,
+								XOAuth2: &instance.SMTPConfigXOAuth2{
+									TokenEndpoint: "https://login.example.com/token",
+									ClientID:      "client",
+									Scopes:        []string{"https://outlook.office365.com/.default"},
+								},
+							},
+						),
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
+				smtp: &smtp.Config{
+					Tls:      true,
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host:          "host:587",
+						User:          "user",
+						Password:      "secret",
+						AuthMechanism: domain.SMTPAuthMechanismXOAuth2,
+						TLSMode:       domain.SMTPTLSModeStartTLS,
+						Timeout:       time.Minute,
+						XOAuth2: &smtp.XOAuth2{
+							TokenEndpoint: "https://login.example.com/token",
+							ClientID:      "client",
+							Scopes:        []string{"https://outlook.office365.com/.default"},
+						},
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+				id: "configid",
+			},
+		},
+		{
+			name: "smtp config, xoauth2 client missing",
+			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+				eventstore:  eventstoreExpect(t),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
+				smtp: &smtp.Config{
+					Tls:      true,
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host:          "host:587",
+						User:          "user",
+						Password:      "secret",
+						AuthMechanism: domain.SMTPAuthMechanismXOAuth2,
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "smtp config, xoauth2 client without mechanism",
+			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+				eventstore:  eventstoreExpect(t),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
+				smtp: &smtp.Config{
+					Tls:      true,
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host:     "host:587",
+						User:     "user",
+						Password: "secret",
+						XOAuth2: &smtp.XOAuth2{
+							TokenEndpoint: "https://login.example.com/token",
+							ClientID:      "client",
+						},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "smtp config, xoauth2 without tls",
+			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+				eventstore:  eventstoreExpect(t),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
+				smtp: &smtp.Config{
+					Tls:      true,
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host:          "host:587",
+						User:          "user",
+						Password:      "secret",
+						AuthMechanism: domain.SMTPAuthMechanismXOAuth2,
+						TLSMode:       domain.SMTPTLSModeNone,
+						XOAuth2: &smtp.XOAuth2{
+							TokenEndpoint: "https://login.example.com/token",
+							ClientID:      "client",
+						},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
 			},
 		},
 		{
 			name: "add smtp config with reply to address, ok",
 			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
 				eventstore: eventstoreExpect(
 					t,
 					expectFilter(
@@ -205,6 +393,8 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 						instance.NewSMTPConfigAddedEvent(
 							context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
+							"configid",
+							"test",
 							true,
 							"from@domain.ch",
 							"name",
@@ -217,13 +407,15 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 								KeyID:      "id",
 								Crypted:    []byte("password"),
 							},
+							nil,
 						),
 					),
 				),
 				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:            true,
 					From:           "from@domain.ch",
@@ -240,15 +432,18 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				want: &domain.ObjectDetails{
 					ResourceOwner: "INSTANCE",
 				},
+				id: "configid",
 			},
 		},
 		{
 			name: "smtp config, port is missing",
 			fields: fields{
-				eventstore: eventstoreExpect(t),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+				eventstore:  eventstoreExpect(t),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -267,10 +462,12 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		{
 			name: "smtp config, host is empty",
 			fields: fields{
-				eventstore: eventstoreExpect(t),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+				eventstore:  eventstoreExpect(t),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -289,6 +486,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		{
 			name: "add smtp config, ipv6 works",
 			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
 				eventstore: eventstoreExpect(
 					t,
 					expectFilter(
@@ -310,6 +508,8 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 						instance.NewSMTPConfigAddedEvent(
 							context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
+							"configid",
+							"test",
 							true,
 							"from@domain.ch",
 							"name",
@@ -322,13 +522,15 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 								KeyID:      "id",
 								Crypted:    []byte("password"),
 							},
+							nil,
 						),
 					),
 				),
 				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -344,6 +546,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				want: &domain.ObjectDetails{
 					ResourceOwner: "INSTANCE",
 				},
+				id: "configid",
 			},
 		},
 	}
@@ -351,9 +554,10 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
 				eventstore:     tt.fields.eventstore,
+				idGenerator:    tt.fields.idGenerator,
 				smtpEncryption: tt.fields.alg,
 			}
-			got, err := r.AddSMTPConfig(tt.args.ctx, tt.args.smtp)
+			id, got, err := r.AddSMTPConfig(tt.args.ctx, tt.args.description, tt.args.smtp)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -361,6 +565,7 @@ func TestCommandSide_AddSMTPConfig(t *testing.T) {
 				t.Errorf("got wrong err: %v ", err)
 			}
 			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, id)
 				assert.Equal(t, tt.res.want, got)
 			}
 		})
@@ -372,8 +577,10 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx  context.Context
-		smtp *smtp.Config
+		ctx         context.Context
+		id          string
+		description string
+		smtp        *smtp.Config
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -393,8 +600,10 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:  authz.WithInstanceID(context.Background(), "INSTANCE"),
-				smtp: &smtp.Config{},
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:          "configid",
+				description: "test",
+				smtp:        &smtp.Config{},
 			},
 			res: res{
 				err: zerrors.IsErrorInvalidArgument,
@@ -409,7 +618,9 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:          "configid",
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -447,6 +658,8 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
 								true,
 								"from@domain.ch",
 								"name",
@@ -454,13 +667,16 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								nil,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:          "configid",
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@wrongdomain.ch",
@@ -498,6 +714,8 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
 								true,
 								"from@domain.ch",
 								"name",
@@ -505,13 +723,16 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								nil,
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:          "configid",
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -549,6 +770,8 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
 								true,
 								"from@domain.ch",
 								"name",
@@ -556,6 +779,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								nil,
 							),
 						),
 					),
@@ -573,7 +797,9 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:          "configid",
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:            false,
 					From:           "from2@domain.ch",
@@ -591,13 +817,92 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "smtp config change connection, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewDomainAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"domain.ch",
+								false,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewDomainPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								true, true, true,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
+								true,
+								"from@domain.ch",
+								"name",
+								"",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								&instance.SMTPConfigConnection{
+									AuthMechanism: domain.SMTPAuthMechanismLogin,
+								},
+							),
+						),
+					),
+					expectPush(
+						func() eventstore.Command {
+							event, _ := instance.NewSMTPConfigChangeEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								[]instance.SMTPConfigChanges{
+									instance.ChangeSMTPConfigConnection(instance.SMTPConfigConnection{
+										AuthMechanism: domain.SMTPAuthMechanismCRAMMD5,
+										TLSMode:       domain.SMTPTLSModeImplicit,
+									}),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:          "configid",
+				description: "test",
+				smtp: &smtp.Config{
+					Tls:      true,
+					From:     "from@domain.ch",
+					FromName: "name",
+					SMTP: smtp.SMTP{
+						Host:          "host:587",
+						User:          "user",
+						AuthMechanism: domain.SMTPAuthMechanismCRAMMD5,
+						TLSMode:       domain.SMTPTLSModeImplicit,
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
 		{
 			name: "smtp config, port is missing",
 			fields: fields{
 				eventstore: eventstoreExpect(t),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:          "configid",
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -619,7 +924,9 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 				eventstore: eventstoreExpect(t),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:          "configid",
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:      true,
 					From:     "from@domain.ch",
@@ -658,6 +965,8 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
 								true,
 								"from@domain.ch",
 								"name",
@@ -665,6 +974,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								nil,
 							),
 						),
 					),
@@ -682,7 +992,9 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:          "configid",
+				description: "test",
 				smtp: &smtp.Config{
 					Tls:            false,
 					From:           "from2@domain.ch",
@@ -706,7 +1018,7 @@ func TestCommandSide_ChangeSMTPConfig(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeSMTPConfig(tt.args.ctx, tt.args.smtp)
+			got, err := r.ChangeSMTPConfig(tt.args.ctx, tt.args.id, tt.args.description, tt.args.smtp)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -727,6 +1039,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 	}
 	type args struct {
 		ctx      context.Context
+		id       string
 		password string
 	}
 	type res struct {
@@ -749,6 +1062,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 			},
 			args: args{
 				ctx:      context.Background(),
+				id:       "configid",
 				password: "",
 			},
 			res: res{
@@ -765,6 +1079,8 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
 								true,
 								"from",
 								"name",
@@ -772,6 +1088,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								nil,
 							),
 						),
 					),
@@ -779,6 +1096,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 						instance.NewSMTPConfigPasswordChangedEvent(
 							context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
+							"configid",
 							&crypto.CryptoValue{
 								CryptoType: crypto.TypeEncryption,
 								Algorithm:  "enc",
@@ -792,6 +1110,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 			},
 			args: args{
 				ctx:      authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:       "configid",
 				password: "password",
 			},
 			res: res{
@@ -807,7 +1126,7 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 				eventstore:     tt.fields.eventstore,
 				smtpEncryption: tt.fields.alg,
 			}
-			got, err := r.ChangeSMTPConfigPassword(tt.args.ctx, tt.args.password)
+			got, err := r.ChangeSMTPConfigPassword(tt.args.ctx, tt.args.id, tt.args.password)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -828,6 +1147,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 	}
 	type args struct {
 		ctx context.Context
+		id  string
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -849,6 +1169,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 			},
 			args: args{
 				ctx: context.Background(),
+				id:  "configid",
 			},
 			res: res{
 				err: zerrors.IsNotFound,
@@ -864,6 +1185,8 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 							instance.NewSMTPConfigAddedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
 								true,
 								"from",
 								"name",
@@ -871,6 +1194,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 								"host:587",
 								"user",
 								&crypto.CryptoValue{},
+								nil,
 							),
 						),
 					),
@@ -878,12 +1202,14 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 						instance.NewSMTPConfigRemovedEvent(
 							context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
+							"configid",
 						),
 					),
 				),
 			},
 			args: args{
 				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "configid",
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -898,7 +1224,7 @@ func TestCommandSide_RemoveSMTPConfig(t *testing.T) {
 				eventstore:     tt.fields.eventstore,
 				smtpEncryption: tt.fields.alg,
 			}
-			got, err := r.RemoveSMTPConfig(tt.args.ctx)
+			got, err := r.RemoveSMTPConfig(tt.args.ctx, tt.args.id)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -923,7 +1249,580 @@ func newSMTPConfigChangedEvent(ctx context.Context, tls bool, fromAddress, fromN
 	}
 	event, _ := instance.NewSMTPConfigChangeEvent(ctx,
 		&instance.NewAggregate("INSTANCE").Aggregate,
+		"configid",
 		changes,
 	)
 	return event
 }
+
+func TestCommandSide_AddSMTPConfigHTTP(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+		alg         crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx         context.Context
+		description string
+		http        *webhook.Config
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		id   string
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid endpoint, error",
+			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+				eventstore:  eventstoreExpect(t),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
+				http: &webhook.Config{
+					CallURL: "endpoint",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "smtp config existing, error already exists",
+			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
+								true,
+								"from@domain.ch",
+								"name",
+								"",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
+				http: &webhook.Config{
+					CallURL: "https://mail.example.com/send",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "add http config, ok",
+			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						instance.NewSMTPConfigHTTPAddedEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"configid",
+							"test",
+							"https://mail.example.com/send",
+							http.Header{"Authorization": {"Bearer token"}},
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("key"),
+							},
+						),
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				description: "test",
+				http: &webhook.Config{
+					CallURL:    "https://mail.example.com/send",
+					Headers:    http.Header{"Authorization": {"Bearer token"}},
+					SigningKey: "key",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+				id: "configid",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:     tt.fields.eventstore,
+				idGenerator:    tt.fields.idGenerator,
+				smtpEncryption: tt.fields.alg,
+			}
+			id, got, err := r.AddSMTPConfigHTTP(tt.args.ctx, tt.args.description, tt.args.http)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, id)
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeSMTPConfigHTTP(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+		alg        crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx         context.Context
+		id          string
+		description string
+		http        *webhook.Config
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "smtp config, error not found",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
+								true,
+								"from@domain.ch",
+								"name",
+								"",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:          "configid",
+				description: "test",
+				http: &webhook.Config{
+					CallURL: "https://mail.example.com/send",
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigHTTPAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
+								"https://mail.example.com/send",
+								nil,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:          "configid",
+				description: "test",
+				http: &webhook.Config{
+					CallURL: "https://mail.example.com/send",
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "change http config, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigHTTPAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
+								"https://mail.example.com/send",
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						newSMTPConfigHTTPChangedEvent(
+							context.Background(),
+							"https://mail.example.com/v2/send",
+							http.Header{"Authorization": {"Bearer token"}},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:         authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:          "configid",
+				description: "test",
+				http: &webhook.Config{
+					CallURL: "https://mail.example.com/v2/send",
+					Headers: http.Header{"Authorization": {"Bearer token"}},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:     tt.fields.eventstore,
+				smtpEncryption: tt.fields.alg,
+			}
+			got, err := r.ChangeSMTPConfigHTTP(tt.args.ctx, tt.args.id, tt.args.description, tt.args.http)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newSMTPConfigHTTPChangedEvent(ctx context.Context, endpoint string, headers http.Header) *instance.SMTPConfigHTTPChangedEvent {
+	event, _ := instance.NewSMTPConfigHTTPChangedEvent(ctx,
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		"configid",
+		[]instance.SMTPConfigHTTPChanges{
+			instance.ChangeSMTPConfigHTTPEndpoint(endpoint),
+			instance.ChangeSMTPConfigHTTPHeaders(headers),
+		},
+	)
+	return event
+}
+
+func TestCommandSide_ActivateSMTPConfig(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "id empty, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "smtp config not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "configid",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "smtp config already active, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigHTTPAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
+								"https://mail.example.com/send",
+								nil,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewSMTPConfigActivatedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "configid",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "activate smtp config, legacy config deactivated, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigHTTPAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
+								"https://mail.example.com/send",
+								nil,
+								nil,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"",
+								"",
+								true,
+								"from@domain.ch",
+								"name",
+								"",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewSMTPConfigHTTPAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
+								"https://mail.example.com/send",
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						instance.NewSMTPConfigDeactivatedEvent(context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"",
+						),
+						instance.NewSMTPConfigActivatedEvent(context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"configid",
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "configid",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ActivateSMTPConfig(tt.args.ctx, tt.args.id)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_DeactivateSMTPConfig(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "smtp config inactive, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigHTTPAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"configid",
+								"test",
+								"https://mail.example.com/send",
+								nil,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "configid",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "deactivate legacy smtp config, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"",
+								"",
+								true,
+								"from@domain.ch",
+								"name",
+								"",
+								"host:587",
+								"user",
+								&crypto.CryptoValue{},
+								nil,
+							),
+						),
+					),
+					expectPush(
+						instance.NewSMTPConfigDeactivatedEvent(context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"",
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				id:  "INSTANCE",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.DeactivateSMTPConfig(tt.args.ctx, tt.args.id)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
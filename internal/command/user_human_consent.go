@@ -0,0 +1,92 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// GrantUserConsent stores the consent of the user for the client to access the provided scopes on their behalf.
+// Scopes granted to the client before are kept, so the consent only grows until it is revoked.
+func (c *Commands) GrantUserConsent(ctx context.Context, userID, resourceOwner, clientID string, scopes []string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || clientID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Eel2i", "Errors.IDMissing")
+	}
+	if len(scopes) == 0 {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-phai5", "Errors.User.Consent.ScopesMissing")
+	}
+	writeModel, err := c.userConsentWriteModel(ctx, userID, resourceOwner, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.UserState != domain.UserStateActive {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-ooGh4", "Errors.User.NotFound")
+	}
+	if writeModel.Granted(scopes) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanConsentGrantedEvent(ctx, userAgg, clientID, writeModel.withScopes(scopes)))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RevokeUserConsent revokes the consent the user gave to the client.
+// All refresh tokens issued to the client for the user are revoked as well,
+// so the client will have to ask for consent again to get new ones.
+func (c *Commands) RevokeUserConsent(ctx context.Context, userID, resourceOwner, clientID string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || clientID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Thoh3", "Errors.IDMissing")
+	}
+	writeModel, err := c.userConsentWriteModel(ctx, userID, resourceOwner, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.UserState != domain.UserStateActive || len(writeModel.Scopes) == 0 {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-ieX2a", "Errors.User.Consent.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	events := []eventstore.Command{
+		user.NewHumanConsentRevokedEvent(ctx, userAgg, clientID),
+	}
+	for _, tokenID := range writeModel.refreshTokenIDs() {
+		events = append(events, user.NewHumanRefreshTokenRemovedEvent(ctx, userAgg, tokenID))
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) userConsentWriteModel(ctx context.Context, userID, resourceOwner, clientID string) (writeModel *HumanConsentWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanConsentWriteModel(userID, resourceOwner, clientID)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,143 @@
+package command
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanConsentWriteModel struct {
+	eventstore.WriteModel
+
+	ClientID  string
+	Scopes    []string
+	UserState domain.UserState
+
+	// RefreshTokens maps the ids of the active refresh tokens issued to the client to their user agent
+	RefreshTokens map[string]string
+}
+
+func NewHumanConsentWriteModel(userID, resourceOwner, clientID string) *HumanConsentWriteModel {
+	return &HumanConsentWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		ClientID:      clientID,
+		RefreshTokens: make(map[string]string),
+	}
+}
+
+func (wm *HumanConsentWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *user.HumanConsentGrantedEvent:
+			if e.ClientID != wm.ClientID {
+				continue
+			}
+		case *user.HumanConsentRevokedEvent:
+			if e.ClientID != wm.ClientID {
+				continue
+			}
+		case *user.HumanRefreshTokenAddedEvent:
+			if e.ClientID != wm.ClientID {
+				continue
+			}
+		}
+		wm.WriteModel.AppendEvents(event)
+	}
+}
+
+func (wm *HumanConsentWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent,
+			*user.HumanRegisteredEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.UserRemovedEvent:
+			wm.UserState = domain.UserStateDeleted
+		case *user.HumanConsentGrantedEvent:
+			wm.Scopes = e.Scopes
+		case *user.HumanConsentRevokedEvent:
+			wm.Scopes = nil
+		case *user.HumanRefreshTokenAddedEvent:
+			wm.RefreshTokens[e.TokenID] = e.UserAgentID
+		case *user.HumanRefreshTokenRemovedEvent:
+			delete(wm.RefreshTokens, e.TokenID)
+		case *user.HumanSignedOutEvent:
+			for tokenID, userAgentID := range wm.RefreshTokens {
+				if userAgentID == e.UserAgentID {
+					delete(wm.RefreshTokens, tokenID)
+				}
+			}
+		case *user.UserLockedEvent,
+			*user.UserDeactivatedEvent:
+			// refresh tokens are invalidated and cannot be used anymore after a reactivation
+			clear(wm.RefreshTokens)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanConsentWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.UserV1AddedType,
+			user.HumanAddedType,
+			user.UserV1RegisteredType,
+			user.HumanRegisteredType,
+			user.UserRemovedType,
+			user.HumanConsentGrantedType,
+			user.HumanConsentRevokedType,
+			user.HumanRefreshTokenAddedType,
+			user.HumanRefreshTokenRemovedType,
+			user.HumanSignedOutType,
+			user.UserLockedType,
+			user.UserDeactivatedType,
+		).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// Granted returns true if the user consented to the client accessing at least the provided scopes.
+func (wm *HumanConsentWriteModel) Granted(scopes []string) bool {
+	if len(wm.Scopes) == 0 {
+		return false
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(wm.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// withScopes returns the scopes already granted extended by the provided ones
+func (wm *HumanConsentWriteModel) withScopes(scopes []string) []string {
+	granted := slices.Clone(wm.Scopes)
+	for _, scope := range scopes {
+		if !slices.Contains(granted, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	return granted
+}
+
+// refreshTokenIDs returns the ids of the active refresh tokens issued to the client in a stable order.
+func (wm *HumanConsentWriteModel) refreshTokenIDs() []string {
+	ids := make([]string, 0, len(wm.RefreshTokens))
+	for tokenID := range wm.RefreshTokens {
+		ids = append(ids, tokenID)
+	}
+	slices.Sort(ids)
+	return ids
+}
@@ -0,0 +1,347 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func consentHumanAddedEvent() eventstore.Event {
+	return eventFromEventPusher(
+		user.NewHumanAddedEvent(context.Background(),
+			&user.NewAggregate("userID", "org").Aggregate,
+			"username",
+			"firstname",
+			"lastname",
+			"nickname",
+			"displayname",
+			language.German,
+			domain.GenderUnspecified,
+			"email@test.ch",
+			true,
+		),
+	)
+}
+
+func consentRefreshTokenAddedEvent(tokenID, clientID, userAgentID string) eventstore.Event {
+	return eventFromEventPusher(
+		user.NewHumanRefreshTokenAddedEvent(context.Background(),
+			&user.NewAggregate("userID", "org").Aggregate,
+			tokenID,
+			clientID,
+			userAgentID,
+			"de",
+			[]string{clientID},
+			[]string{"openid", "offline_access"},
+			[]string{"password"},
+			time.Now(),
+			time.Hour,
+			10*time.Hour,
+		),
+	)
+}
+
+func TestCommandSide_GrantUserConsent(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userID        string
+		resourceOwner string
+		clientID      string
+		scopes        []string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "clientID missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				scopes:        []string{"openid"},
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Eel2i", "Errors.IDMissing"),
+			},
+		},
+		{
+			name: "scopes missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				clientID:      "clientID",
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-phai5", "Errors.User.Consent.ScopesMissing"),
+			},
+		},
+		{
+			name: "user not found, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				clientID:      "clientID",
+				scopes:        []string{"openid"},
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-ooGh4", "Errors.User.NotFound"),
+			},
+		},
+		{
+			name: "scopes already granted, no push",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						consentHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewHumanConsentGrantedEvent(context.Background(),
+								&user.NewAggregate("userID", "org").Aggregate,
+								"clientID",
+								[]string{"openid", "profile"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				clientID:      "clientID",
+				scopes:        []string{"profile"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org",
+				},
+			},
+		},
+		{
+			name: "first consent, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						consentHumanAddedEvent(),
+					),
+					expectPush(
+						user.NewHumanConsentGrantedEvent(context.Background(),
+							&user.NewAggregate("userID", "org").Aggregate,
+							"clientID",
+							[]string{"openid", "profile"},
+						),
+					),
+				),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				clientID:      "clientID",
+				scopes:        []string{"openid", "profile"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org",
+				},
+			},
+		},
+		{
+			name: "additional scopes, merged with granted scopes",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						consentHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewHumanConsentGrantedEvent(context.Background(),
+								&user.NewAggregate("userID", "org").Aggregate,
+								"clientID",
+								[]string{"openid", "profile"},
+							),
+						),
+					),
+					expectPush(
+						user.NewHumanConsentGrantedEvent(context.Background(),
+							&user.NewAggregate("userID", "org").Aggregate,
+							"clientID",
+							[]string{"openid", "profile", "email"},
+						),
+					),
+				),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				clientID:      "clientID",
+				scopes:        []string{"openid", "email"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.GrantUserConsent(context.Background(), tt.args.userID, tt.args.resourceOwner, tt.args.clientID, tt.args.scopes)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
+
+func TestCommandSide_RevokeUserConsent(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userID        string
+		resourceOwner string
+		clientID      string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "clientID missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Thoh3", "Errors.IDMissing"),
+			},
+		},
+		{
+			name: "no consent, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						consentHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewHumanConsentGrantedEvent(context.Background(),
+								&user.NewAggregate("userID", "org").Aggregate,
+								"clientID",
+								[]string{"openid"},
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanConsentRevokedEvent(context.Background(),
+								&user.NewAggregate("userID", "org").Aggregate,
+								"clientID",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				clientID:      "clientID",
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-ieX2a", "Errors.User.Consent.NotFound"),
+			},
+		},
+		{
+			name: "revoke, refresh tokens of client revoked",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						consentHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewHumanConsentGrantedEvent(context.Background(),
+								&user.NewAggregate("userID", "org").Aggregate,
+								"clientID",
+								[]string{"openid", "offline_access"},
+							),
+						),
+						consentRefreshTokenAddedEvent("tokenID2", "clientID", "agentID"),
+						consentRefreshTokenAddedEvent("tokenID1", "clientID", "agentID"),
+						consentRefreshTokenAddedEvent("tokenID3", "clientID", "agentID2"),
+						eventFromEventPusher(
+							user.NewHumanRefreshTokenRemovedEvent(context.Background(),
+								&user.NewAggregate("userID", "org").Aggregate,
+								"tokenID2",
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanSignedOutEvent(context.Background(),
+								&user.NewAggregate("userID", "org").Aggregate,
+								"agentID2",
+							),
+						),
+					),
+					expectPush(
+						user.NewHumanConsentRevokedEvent(context.Background(),
+							&user.NewAggregate("userID", "org").Aggregate,
+							"clientID",
+						),
+						user.NewHumanRefreshTokenRemovedEvent(context.Background(),
+							&user.NewAggregate("userID", "org").Aggregate,
+							"tokenID1",
+						),
+					),
+				),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				clientID:      "clientID",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.RevokeUserConsent(context.Background(), tt.args.userID, tt.args.resourceOwner, tt.args.clientID)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
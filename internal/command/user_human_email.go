@@ -40,7 +40,19 @@ func (c *Commands) ChangeHumanEmail(ctx context.Context, email *domain.Email, em
 	}
 
 	events := make([]eventstore.Command, 0)
-	if hasChanged {
+	switch {
+	case hasChanged && existingEmail.IsEmailVerified && !email.IsEmailVerified:
+		// the verified email address stays active until the new one is verified
+		revertCodeGenerator, err := c.emailChangeRevertCodeGenerator(ctx)
+		if err != nil {
+			return nil, err
+		}
+		changeRequestedEvent, err := newEmailChangeRequestedEvent(ctx, userAgg, email.EmailAddress, revertCodeGenerator)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, changeRequestedEvent)
+	case hasChanged:
 		events = append(events, changedEvent)
 	}
 	if email.IsEmailVerified {
@@ -83,7 +95,12 @@ func (c *Commands) VerifyHumanEmail(ctx context.Context, userID, code, resourceo
 	userAgg := UserAggregateFromWriteModel(&existingCode.WriteModel)
 	err = crypto.VerifyCode(existingCode.CodeCreationDate, existingCode.CodeExpiry, existingCode.Code, code, emailCodeGenerator)
 	if err == nil {
-		pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanEmailVerifiedEvent(ctx, userAgg))
+		events := make([]eventstore.Command, 0, 2)
+		if existingCode.ChangePending() {
+			events = append(events, user.NewHumanEmailChangedEvent(ctx, userAgg, existingCode.PendingEmail))
+		}
+		events = append(events, user.NewHumanEmailVerifiedEvent(ctx, userAgg))
+		pushedEvents, err := c.eventstore.Push(ctx, events...)
 		if err != nil {
 			return nil, err
 		}
@@ -114,7 +131,7 @@ func (c *Commands) CreateHumanEmailVerificationCode(ctx context.Context, userID,
 	if existingEmail.UserState == domain.UserStateInitial {
 		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-E3fbw", "Errors.User.NotInitialised")
 	}
-	if existingEmail.IsEmailVerified {
+	if existingEmail.IsEmailVerified && !existingEmail.ChangePending() {
 		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-3M9ds", "Errors.User.Email.AlreadyVerified")
 	}
 	userAgg := UserAggregateFromWriteModel(&existingEmail.WriteModel)
@@ -0,0 +1,99 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func (c *Commands) emailChangeRevertCodeGenerator(ctx context.Context) (crypto.Generator, error) {
+	config, err := secretGeneratorConfigWithDefault(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeEmailChangeRevertCode, c.defaultSecretGenerators.EmailChangeRevertCode)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.NewEncryptionGenerator(*config, c.userEncryption), nil
+}
+
+// newEmailChangeRequestedEvent creates the event for a pending email change including a revert code,
+// which will be sent to the current email address of the user.
+// The current address stays active until the new one is verified.
+func newEmailChangeRequestedEvent(ctx context.Context, userAgg *eventstore.Aggregate, email domain.EmailAddress, gen crypto.Generator) (*user.HumanEmailChangeRequestedEvent, error) {
+	value, _, err := crypto.NewCode(gen)
+	if err != nil {
+		return nil, err
+	}
+	return user.NewHumanEmailChangeRequestedEvent(ctx, userAgg, email, value, gen.Expiry()), nil
+}
+
+// RevertHumanEmailChange reverts the change of the email address with the code sent to the previous address.
+// A pending change is discarded, an already verified change is rolled back to the previous (verified) address.
+func (c *Commands) RevertHumanEmailChange(ctx context.Context, userID, resourceOwner, code string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-aiL6o", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ahm4e", "Errors.User.Code.Empty")
+	}
+	existingEmail, err := c.emailWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingEmail.UserState.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-ieV3u", "Errors.User.NotFound")
+	}
+	if existingEmail.RevertCode == nil {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohy8a", "Errors.User.Email.ChangeNotRevertible")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingEmail.WriteModel)
+	err = crypto.VerifyCodeWithAlgorithm(existingEmail.RevertCodeCreationDate, existingEmail.RevertCodeExpiry, existingEmail.RevertCode, code, c.userEncryption)
+	if err != nil {
+		_, pushErr := c.eventstore.Push(ctx, user.NewHumanEmailChangeRevertCheckFailedEvent(ctx, userAgg))
+		logging.WithFields("userID", userID).OnError(pushErr).Error("email change revert failure check push failed")
+		return nil, err
+	}
+	events := []eventstore.Command{
+		user.NewHumanEmailChangeRevertedEvent(ctx, userAgg, existingEmail.RevertEmail),
+	}
+	// the new email address was already verified and applied
+	if existingEmail.Email != existingEmail.RevertEmail {
+		events = append(events,
+			user.NewHumanEmailChangedEvent(ctx, userAgg, existingEmail.RevertEmail),
+			user.NewHumanEmailVerifiedEvent(ctx, userAgg),
+		)
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingEmail, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingEmail.WriteModel), nil
+}
+
+func (c *Commands) HumanEmailChangeRevertCodeSent(ctx context.Context, userID, resourceOwner string) (err error) {
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Chee1", "Errors.User.UserIDMissing")
+	}
+	existingEmail, err := c.emailWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !existingEmail.UserState.Exists() {
+		return zerrors.ThrowNotFound(nil, "COMMAND-ooP0e", "Errors.User.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingEmail.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanEmailChangeRevertCodeSentEvent(ctx, userAgg))
+	return err
+}
@@ -0,0 +1,354 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func emailChangeHumanAddedEvents() []eventstore.Event {
+	return []eventstore.Event{
+		eventFromEventPusher(
+			user.NewHumanAddedEvent(context.Background(),
+				&user.NewAggregate("user1", "org1").Aggregate,
+				"username",
+				"firstname",
+				"lastname",
+				"nickname",
+				"displayname",
+				language.German,
+				domain.GenderUnspecified,
+				"email@test.ch",
+				true,
+			),
+		),
+		eventFromEventPusher(
+			user.NewHumanEmailVerifiedEvent(context.Background(),
+				&user.NewAggregate("user1", "org1").Aggregate,
+			),
+		),
+	}
+}
+
+func emailChangeRequestedEvent() eventstore.Event {
+	return eventFromEventPusherWithCreationDateNow(
+		user.NewHumanEmailChangeRequestedEvent(context.Background(),
+			&user.NewAggregate("user1", "org1").Aggregate,
+			"email-changed@test.ch",
+			&crypto.CryptoValue{
+				CryptoType: crypto.TypeEncryption,
+				Algorithm:  "enc",
+				KeyID:      "id",
+				Crypted:    []byte("revert"),
+			},
+			72*time.Hour,
+		),
+	)
+}
+
+func TestCommandSide_RevertHumanEmailChange(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userID string
+		code   string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userID missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				code: "revert",
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-aiL6o", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "code missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID: "user1",
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ahm4e", "Errors.User.Code.Empty"),
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID: "user1",
+				code:   "revert",
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-ieV3u", "Errors.User.NotFound"),
+			},
+		},
+		{
+			name: "no change requested, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						emailChangeHumanAddedEvents()...,
+					),
+				),
+			},
+			args: args{
+				userID: "user1",
+				code:   "revert",
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohy8a", "Errors.User.Email.ChangeNotRevertible"),
+			},
+		},
+		{
+			name: "already reverted, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						append(emailChangeHumanAddedEvents(),
+							emailChangeRequestedEvent(),
+							eventFromEventPusher(
+								user.NewHumanEmailChangeRevertedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"email@test.ch",
+								),
+							),
+						)...,
+					),
+				),
+			},
+			args: args{
+				userID: "user1",
+				code:   "revert",
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohy8a", "Errors.User.Email.ChangeNotRevertible"),
+			},
+		},
+		{
+			name: "invalid code, check failed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						append(emailChangeHumanAddedEvents(),
+							emailChangeRequestedEvent(),
+						)...,
+					),
+					expectPush(
+						user.NewHumanEmailChangeRevertCheckFailedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+						),
+					),
+				),
+			},
+			args: args{
+				userID: "user1",
+				code:   "wrong",
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "CODE-woT0xc", "Errors.User.Code.Invalid"),
+			},
+		},
+		{
+			name: "pending change, reverted",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						append(emailChangeHumanAddedEvents(),
+							emailChangeRequestedEvent(),
+						)...,
+					),
+					expectPush(
+						user.NewHumanEmailChangeRevertedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"email@test.ch",
+						),
+					),
+				),
+			},
+			args: args{
+				userID: "user1",
+				code:   "revert",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "verified change, rolled back",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						append(emailChangeHumanAddedEvents(),
+							emailChangeRequestedEvent(),
+							eventFromEventPusher(
+								user.NewHumanEmailChangedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"email-changed@test.ch",
+								),
+							),
+							eventFromEventPusher(
+								user.NewHumanEmailVerifiedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+								),
+							),
+						)...,
+					),
+					expectPush(
+						user.NewHumanEmailChangeRevertedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"email@test.ch",
+						),
+						user.NewHumanEmailChangedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"email@test.ch",
+						),
+						user.NewHumanEmailVerifiedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+						),
+					),
+				),
+			},
+			args: args{
+				userID: "user1",
+				code:   "revert",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "changed again, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						append(emailChangeHumanAddedEvents(),
+							emailChangeRequestedEvent(),
+							eventFromEventPusher(
+								user.NewHumanEmailChangedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"email-changed@test.ch",
+								),
+							),
+							eventFromEventPusher(
+								user.NewHumanEmailChangedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"email-other@test.ch",
+								),
+							),
+						)...,
+					),
+				),
+			},
+			args: args{
+				userID: "user1",
+				code:   "revert",
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohy8a", "Errors.User.Email.ChangeNotRevertible"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := c.RevertHumanEmailChange(context.Background(), tt.args.userID, "org1", tt.args.code)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.want, got)
+		})
+	}
+}
+
+func TestCommandSide_HumanEmailChangeRevertCodeSent(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		userID string
+		err    error
+	}{
+		{
+			name: "userID missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Chee1", "Errors.User.UserIDMissing"),
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			userID: "user1",
+			err:    zerrors.ThrowNotFound(nil, "COMMAND-ooP0e", "Errors.User.NotFound"),
+		},
+		{
+			name: "code sent",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						append(emailChangeHumanAddedEvents(),
+							emailChangeRequestedEvent(),
+						)...,
+					),
+					expectPush(
+						user.NewHumanEmailChangeRevertCodeSentEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+						),
+					),
+				),
+			},
+			userID: "user1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			err := c.HumanEmailChangeRevertCodeSent(context.Background(), tt.userID, "org1")
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
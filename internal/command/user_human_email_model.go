@@ -20,6 +20,14 @@ type HumanEmailWriteModel struct {
 	CodeCreationDate time.Time
 	CodeExpiry       time.Duration
 
+	// PendingEmail is the requested email address, which replaces Email as soon as it's verified
+	PendingEmail domain.EmailAddress
+	// RevertEmail is the email address before the change was requested, which is restored if the change is reverted
+	RevertEmail            domain.EmailAddress
+	RevertCode             *crypto.CryptoValue
+	RevertCodeCreationDate time.Time
+	RevertCodeExpiry       time.Duration
+
 	UserState domain.UserState
 }
 
@@ -46,9 +54,25 @@ func (wm *HumanEmailWriteModel) Reduce() error {
 		case *user.HumanInitializedCheckSucceededEvent:
 			wm.UserState = domain.UserStateActive
 		case *user.HumanEmailChangedEvent:
+			// the revert code stays valid, if the pending email address was applied
+			if e.EmailAddress != wm.PendingEmail {
+				wm.RevertCode = nil
+			}
 			wm.Email = e.EmailAddress
 			wm.IsEmailVerified = false
 			wm.Code = nil
+			wm.PendingEmail = ""
+		case *user.HumanEmailChangeRequestedEvent:
+			wm.PendingEmail = e.EmailAddress
+			wm.RevertEmail = wm.Email
+			wm.RevertCode = e.RevertCode
+			wm.RevertCodeCreationDate = e.CreationDate()
+			wm.RevertCodeExpiry = e.RevertExpiry
+			wm.Code = nil
+		case *user.HumanEmailChangeRevertedEvent:
+			wm.PendingEmail = ""
+			wm.RevertCode = nil
+			wm.Code = nil
 		case *user.HumanEmailCodeAddedEvent:
 			wm.Code = e.Code
 			wm.CodeCreationDate = e.CreationDate()
@@ -82,6 +106,8 @@ func (wm *HumanEmailWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.HumanEmailCodeAddedType,
 			user.UserV1EmailVerifiedType,
 			user.HumanEmailVerifiedType,
+			user.HumanEmailChangeRequestedType,
+			user.HumanEmailChangeRevertedType,
 			user.UserRemovedType).
 		Builder()
 
@@ -101,3 +127,8 @@ func (wm *HumanEmailWriteModel) NewChangedEvent(
 	}
 	return user.NewHumanEmailChangedEvent(ctx, aggregate, email), true
 }
+
+// ChangePending returns if a change of the email address waits for the verification of the new address
+func (wm *HumanEmailWriteModel) ChangePending() bool {
+	return wm.PendingEmail != ""
+}
@@ -0,0 +1,120 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// HumanRequestMagicLink creates a single use code, which will be sent as link to the verified email address of the user (during login).
+// The code is bound to the user agent of the auth request and can only be checked from there.
+func (c *Commands) HumanRequestMagicLink(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Phai4", "Errors.User.UserIDMissing")
+	}
+	if authRequest == nil || authRequest.AgentID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-ieS3o", "Errors.User.MagicLink.UserAgentMismatch")
+	}
+	writeModel, err := c.magicLinkWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !writeModel.UserState.Exists() {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Uo5ie", "Errors.User.NotFound")
+	}
+	policy, err := c.getOrgLoginPolicy(ctx, writeModel.ResourceOwner)
+	if err != nil {
+		return err
+	}
+	if !policy.AllowMagicLink {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-oow7U", "Errors.User.MagicLink.NotAllowed")
+	}
+	if !writeModel.IsEmailVerified {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gei9a", "Errors.User.Email.NotVerified")
+	}
+	config, err := secretGeneratorConfigWithDefault(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeMagicLinkCode, c.defaultSecretGenerators.MagicLinkCode)
+	if err != nil {
+		return err
+	}
+	gen := crypto.NewEncryptionGenerator(*config, c.userEncryption)
+	value, _, err := crypto.NewCode(gen)
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanMagicLinkCodeAddedEvent(ctx, userAgg, value, gen.Expiry(), authRequestDomainToAuthRequestInfo(authRequest)))
+	return err
+}
+
+func (c *Commands) HumanMagicLinkCodeSent(ctx context.Context, userID, resourceOwner string) (err error) {
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-eiL8a", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.magicLinkWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if writeModel.MagicLinkCode == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-ahG4o", "Errors.User.Code.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanMagicLinkCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+// HumanCheckMagicLink checks the code of a magic link (during login).
+// The link must be opened from the same user agent it was requested from. The code can only be checked once.
+func (c *Commands) HumanCheckMagicLink(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Zoo3e", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-hee6J", "Errors.User.Code.Empty")
+	}
+	writeModel, err := c.magicLinkWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !writeModel.UserState.Exists() {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Aiph1", "Errors.User.NotFound")
+	}
+	if writeModel.MagicLinkCode == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Quoh3", "Errors.User.Code.NotFound")
+	}
+	if authRequest == nil || authRequest.AgentID == "" || authRequest.AgentID != writeModel.UserAgentID {
+		return zerrors.ThrowPermissionDenied(nil, "COMMAND-oth2W", "Errors.User.MagicLink.UserAgentMismatch")
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	err = crypto.VerifyCodeWithAlgorithm(writeModel.MagicLinkCodeCreationDate, writeModel.MagicLinkCodeExpiry, writeModel.MagicLinkCode, code, c.userEncryption)
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanMagicLinkCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	_, pushErr := c.eventstore.Push(ctx, user.NewHumanMagicLinkCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	logging.WithFields("userID", userID).OnError(pushErr).Error("magic link failure check push failed")
+	return err
+}
+
+func (c *Commands) magicLinkWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanMagicLinkWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanMagicLinkWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanMagicLinkWriteModel struct {
+	*HumanEmailWriteModel
+
+	MagicLinkCode             *crypto.CryptoValue
+	MagicLinkCodeCreationDate time.Time
+	MagicLinkCodeExpiry       time.Duration
+	UserAgentID               string
+}
+
+func NewHumanMagicLinkWriteModel(userID, resourceOwner string) *HumanMagicLinkWriteModel {
+	return &HumanMagicLinkWriteModel{
+		HumanEmailWriteModel: NewHumanEmailWriteModel(userID, resourceOwner),
+	}
+}
+
+func (wm *HumanMagicLinkWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanMagicLinkCodeAddedEvent:
+			wm.MagicLinkCode = e.Code
+			wm.MagicLinkCodeCreationDate = e.CreationDate()
+			wm.MagicLinkCodeExpiry = e.Expiry
+			wm.UserAgentID = ""
+			if e.AuthRequestInfo != nil {
+				wm.UserAgentID = e.AuthRequestInfo.UserAgentID
+			}
+		case *user.HumanMagicLinkCheckSucceededEvent,
+			*user.HumanMagicLinkCheckFailedEvent,
+			*user.HumanEmailChangedEvent:
+			// the code can only be used once and is bound to the address it was sent to
+			wm.MagicLinkCode = nil
+		}
+	}
+	return wm.HumanEmailWriteModel.Reduce()
+}
+
+func (wm *HumanMagicLinkWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.UserV1AddedType,
+			user.HumanAddedType,
+			user.UserV1RegisteredType,
+			user.HumanRegisteredType,
+			user.UserV1InitialCodeAddedType,
+			user.HumanInitialCodeAddedType,
+			user.UserV1InitializedCheckSucceededType,
+			user.HumanInitializedCheckSucceededType,
+			user.UserV1EmailChangedType,
+			user.HumanEmailChangedType,
+			user.UserV1EmailVerifiedType,
+			user.HumanEmailVerifiedType,
+			user.HumanMagicLinkCodeAddedType,
+			user.HumanMagicLinkCheckSucceededType,
+			user.HumanMagicLinkCheckFailedType,
+			user.UserRemovedType,
+		).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
@@ -0,0 +1,341 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_HumanRequestMagicLink(t *testing.T) {
+	type fields struct {
+		eventstore     func(*testing.T) *eventstore.Eventstore
+		userEncryption crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		userID        string
+		resourceOwner string
+		authRequest   *domain.AuthRequest
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		err    error
+	}{
+		{
+			name: "userID missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				resourceOwner: "org",
+			},
+			err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Phai4", "Errors.User.UserIDMissing"),
+		},
+		{
+			name: "user agent missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				authRequest:   &domain.AuthRequest{ID: "authRequestID"},
+			},
+			err: zerrors.ThrowInvalidArgument(nil, "COMMAND-ieS3o", "Errors.User.MagicLink.UserAgentMismatch"),
+		},
+		{
+			name: "user not found, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				authRequest:   &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowNotFound(nil, "COMMAND-Uo5ie", "Errors.User.NotFound"),
+		},
+		{
+			name: "magic link not allowed, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+					),
+					expectFilter(
+						magicLinkLoginPolicyEvent(false),
+					),
+				),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				authRequest:   &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-oow7U", "Errors.User.MagicLink.NotAllowed"),
+		},
+		{
+			name: "email not verified, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+					),
+					expectFilter(
+						magicLinkLoginPolicyEvent(true),
+					),
+				),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				authRequest:   &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gei9a", "Errors.User.Email.NotVerified"),
+		},
+		{
+			name: "code added",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(context.Background(),
+								&user.NewAggregate("userID", "org").Aggregate,
+							),
+						),
+					),
+					expectFilter(
+						magicLinkLoginPolicyEvent(true),
+					),
+					expectFilter(),
+					expectPush(
+						user.NewHumanMagicLinkCodeAddedEvent(context.Background(),
+							&user.NewAggregate("userID", "org").Aggregate,
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("12345678"),
+							},
+							time.Hour,
+							&user.AuthRequestInfo{
+								ID:          "authRequestID",
+								UserAgentID: "userAgentID",
+							},
+						),
+					),
+				),
+				userEncryption: crypto.CreateMockEncryptionAlgWithCode(gomock.NewController(t), "12345678"),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				authRequest:   &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				userEncryption: tt.fields.userEncryption,
+				defaultSecretGenerators: &SecretGenerators{
+					MagicLinkCode: &crypto.GeneratorConfig{
+						Length:              8,
+						Expiry:              time.Hour,
+						IncludeLowerLetters: false,
+						IncludeUpperLetters: false,
+						IncludeDigits:       true,
+						IncludeSymbols:      false,
+					},
+				},
+			}
+			err := c.HumanRequestMagicLink(context.Background(), tt.args.userID, tt.args.resourceOwner, tt.args.authRequest)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCommandSide_HumanCheckMagicLink(t *testing.T) {
+	codeAdded := func(userAgentID string) eventstore.Event {
+		return eventFromEventPusherWithCreationDateNow(
+			user.NewHumanMagicLinkCodeAddedEvent(context.Background(),
+				&user.NewAggregate("userID", "org").Aggregate,
+				&crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    []byte("code"),
+				},
+				time.Hour,
+				&user.AuthRequestInfo{
+					ID:          "authRequestID",
+					UserAgentID: userAgentID,
+				},
+			),
+		)
+	}
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userID      string
+		code        string
+		authRequest *domain.AuthRequest
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		err    error
+	}{
+		{
+			name: "userID missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{},
+			err:  zerrors.ThrowInvalidArgument(nil, "COMMAND-Zoo3e", "Errors.User.UserIDMissing"),
+		},
+		{
+			name: "code missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID: "userID",
+			},
+			err: zerrors.ThrowInvalidArgument(nil, "COMMAND-hee6J", "Errors.User.Code.Empty"),
+		},
+		{
+			name: "code not found, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+					),
+				),
+			},
+			args: args{
+				userID:      "userID",
+				code:        "code",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Quoh3", "Errors.User.Code.NotFound"),
+		},
+		{
+			name: "code already used, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+						codeAdded("userAgentID"),
+						eventFromEventPusher(
+							user.NewHumanMagicLinkCheckSucceededEvent(context.Background(),
+								&user.NewAggregate("userID", "org").Aggregate,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				userID:      "userID",
+				code:        "code",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Quoh3", "Errors.User.Code.NotFound"),
+		},
+		{
+			name: "other user agent, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+						codeAdded("userAgentID"),
+					),
+				),
+			},
+			args: args{
+				userID:      "userID",
+				code:        "code",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "otherUserAgentID"},
+			},
+			err: zerrors.ThrowPermissionDenied(nil, "COMMAND-oth2W", "Errors.User.MagicLink.UserAgentMismatch"),
+		},
+		{
+			name: "invalid code, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+						codeAdded("userAgentID"),
+					),
+					expectPush(
+						user.NewHumanMagicLinkCheckFailedEvent(context.Background(),
+							&user.NewAggregate("userID", "org").Aggregate,
+							&user.AuthRequestInfo{
+								ID:          "authRequestID",
+								UserAgentID: "userAgentID",
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				userID:      "userID",
+				code:        "wrong",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowInvalidArgument(nil, "CODE-woT0xc", "Errors.User.Code.Invalid"),
+		},
+		{
+			name: "code ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+						codeAdded("userAgentID"),
+					),
+					expectPush(
+						user.NewHumanMagicLinkCheckSucceededEvent(context.Background(),
+							&user.NewAggregate("userID", "org").Aggregate,
+							&user.AuthRequestInfo{
+								ID:          "authRequestID",
+								UserAgentID: "userAgentID",
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				userID:      "userID",
+				code:        "code",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			err := c.HumanCheckMagicLink(context.Background(), tt.args.userID, tt.args.code, "org", tt.args.authRequest)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package command
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// RegisterHumanWithPhone registers a human with a phone number, but without email address and password (phone-first sign-up).
+// The user signs in with one time codes sent to the phone, the first successful check verifies the phone number.
+// If no username is provided, the phone number is used.
+func (c *Commands) RegisterHumanWithPhone(ctx context.Context, orgID string, human *domain.Human) (_ *domain.Human, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Aeb4u", "Errors.ResourceOwnerMissing")
+	}
+	if human == nil || human.Phone == nil {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ro6ai", "Errors.User.Phone.Empty")
+	}
+	if err := human.Phone.Normalize(); err != nil {
+		return nil, err
+	}
+	if human.Username = strings.TrimSpace(human.Username); human.Username == "" {
+		human.Username = string(human.PhoneNumber)
+	}
+	if err := human.Profile.Validate(); err != nil {
+		return nil, err
+	}
+	domainPolicy, err := c.getOrgDomainPolicy(ctx, orgID)
+	if err != nil {
+		return nil, zerrors.ThrowPreconditionFailed(err, "COMMAND-ohf3E", "Errors.Org.DomainPolicy.NotFound")
+	}
+	loginPolicy, err := c.getOrgLoginPolicy(ctx, orgID)
+	if err != nil {
+		return nil, zerrors.ThrowPreconditionFailed(err, "COMMAND-iej9T", "Errors.Org.LoginPolicy.NotFound")
+	}
+	if !loginPolicy.AllowRegister || !loginPolicy.AllowPhoneOTPLogin {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Fie2e", "Errors.Org.LoginPolicy.RegistrationNotAllowed")
+	}
+	// the phone number is verified by the first phone login and no other credentials are set
+	human.Phone.IsPhoneVerified = false
+	human.Email = new(domain.Email)
+	human.Password = nil
+	human.HashedPassword = ""
+
+	if human.AggregateID == "" {
+		human.AggregateID, err = c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+	human.EnsureDisplayName()
+
+	registeredHuman := NewHumanWriteModel(human.AggregateID, orgID)
+	userAgg := UserAggregateFromWriteModel(&registeredHuman.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, createRegisterHumanEvent(ctx, userAgg, human, domainPolicy.UserLoginMustBeDomain))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(registeredHuman, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToHuman(registeredHuman), nil
+}
+
+// HumanRequestPhoneLogin creates a one time code, which will be sent to the phone of the user over the requested channel (during login).
+// The code is bound to the user agent of the auth request and can only be checked from there.
+func (c *Commands) HumanRequestPhoneLogin(ctx context.Context, userID, resourceOwner string, channel domain.PhoneChannel, authRequest *domain.AuthRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Eeth4", "Errors.User.UserIDMissing")
+	}
+	if !channel.Valid() {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-gu3Ai", "Errors.User.Phone.ChannelInvalid")
+	}
+	if authRequest == nil || authRequest.AgentID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Vai8u", "Errors.User.PhoneLogin.UserAgentMismatch")
+	}
+	writeModel, err := c.phoneLoginWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !writeModel.UserState.Exists() {
+		return zerrors.ThrowNotFound(nil, "COMMAND-ahL7i", "Errors.User.NotFound")
+	}
+	policy, err := c.getOrgLoginPolicy(ctx, writeModel.ResourceOwner)
+	if err != nil {
+		return err
+	}
+	if !policy.AllowPhoneOTPLogin {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Thoo0", "Errors.User.PhoneLogin.NotAllowed")
+	}
+	if !writeModel.PhoneLoginPossible() {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieT1a", "Errors.User.Phone.NotVerified")
+	}
+	config, err := secretGeneratorConfigWithDefault(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeOTPSMS, c.defaultSecretGenerators.OTPSMS)
+	if err != nil {
+		return err
+	}
+	gen := crypto.NewEncryptionGenerator(*config, c.userEncryption)
+	value, _, err := crypto.NewCode(gen)
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanPhoneLoginCodeAddedEvent(ctx, userAgg, value, gen.Expiry(), channel, authRequestDomainToAuthRequestInfo(authRequest)))
+	return err
+}
+
+func (c *Commands) HumanPhoneLoginCodeSent(ctx context.Context, userID, resourceOwner string) (err error) {
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-ooN7e", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.phoneLoginWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if writeModel.PhoneLoginCode == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohl5u", "Errors.User.Code.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanPhoneLoginCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+// HumanCheckPhoneLogin checks the one time code sent to the phone of the user (during login).
+// The code must be checked from the same user agent it was requested from and can only be checked once.
+// A successful check verifies the phone number, if it was not verified yet (phone-first sign-up).
+func (c *Commands) HumanCheckPhoneLogin(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Kei0u", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-zo8Ah", "Errors.User.Code.Empty")
+	}
+	writeModel, err := c.phoneLoginWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !writeModel.UserState.Exists() {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Uu9ee", "Errors.User.NotFound")
+	}
+	if writeModel.PhoneLoginCode == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-eeR0o", "Errors.User.Code.NotFound")
+	}
+	if authRequest == nil || authRequest.AgentID == "" || authRequest.AgentID != writeModel.UserAgentID {
+		return zerrors.ThrowPermissionDenied(nil, "COMMAND-ya6Ie", "Errors.User.PhoneLogin.UserAgentMismatch")
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	err = crypto.VerifyCodeWithAlgorithm(writeModel.PhoneLoginCodeCreationDate, writeModel.PhoneLoginCodeExpiry, writeModel.PhoneLoginCode, code, c.userEncryption)
+	if err == nil {
+		events := []eventstore.Command{
+			user.NewHumanPhoneLoginCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)),
+		}
+		if !writeModel.IsPhoneVerified {
+			events = append(events, user.NewHumanPhoneVerifiedEvent(ctx, userAgg))
+		}
+		_, err = c.eventstore.Push(ctx, events...)
+		return err
+	}
+	_, pushErr := c.eventstore.Push(ctx, user.NewHumanPhoneLoginCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	logging.WithFields("userID", userID).OnError(pushErr).Error("phone login failure check push failed")
+	return err
+}
+
+func (c *Commands) phoneLoginWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanPhoneLoginWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanPhoneLoginWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
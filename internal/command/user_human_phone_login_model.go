@@ -0,0 +1,100 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanPhoneLoginWriteModel struct {
+	*HumanPhoneWriteModel
+
+	Email domain.EmailAddress
+
+	PhoneLoginCode             *crypto.CryptoValue
+	PhoneLoginCodeCreationDate time.Time
+	PhoneLoginCodeExpiry       time.Duration
+	UserAgentID                string
+}
+
+func NewHumanPhoneLoginWriteModel(userID, resourceOwner string) *HumanPhoneLoginWriteModel {
+	return &HumanPhoneLoginWriteModel{
+		HumanPhoneWriteModel: NewHumanPhoneWriteModel(userID, resourceOwner),
+	}
+}
+
+func (wm *HumanPhoneLoginWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent:
+			wm.Email = e.EmailAddress
+		case *user.HumanRegisteredEvent:
+			wm.Email = e.EmailAddress
+		case *user.HumanEmailChangedEvent:
+			wm.Email = e.EmailAddress
+		case *user.HumanPhoneLoginCodeAddedEvent:
+			wm.PhoneLoginCode = e.Code
+			wm.PhoneLoginCodeCreationDate = e.CreationDate()
+			wm.PhoneLoginCodeExpiry = e.Expiry
+			wm.UserAgentID = ""
+			if e.AuthRequestInfo != nil {
+				wm.UserAgentID = e.AuthRequestInfo.UserAgentID
+			}
+		case *user.HumanPhoneLoginCheckSucceededEvent,
+			*user.HumanPhoneLoginCheckFailedEvent,
+			*user.HumanPhoneChangedEvent,
+			*user.HumanPhoneRemovedEvent:
+			// the code can only be used once and is bound to the number it was sent to
+			wm.PhoneLoginCode = nil
+		}
+	}
+	return wm.HumanPhoneWriteModel.Reduce()
+}
+
+// PhoneLoginPossible returns if a code can be sent to the phone of the user.
+// The phone number must be verified, except for users without an email address (phone-first sign-up),
+// where the successful check of the code verifies the phone number.
+func (wm *HumanPhoneLoginWriteModel) PhoneLoginPossible() bool {
+	if wm.State != domain.PhoneStateActive {
+		return false
+	}
+	return wm.IsPhoneVerified || wm.Email == ""
+}
+
+func (wm *HumanPhoneLoginWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.UserV1AddedType,
+			user.HumanAddedType,
+			user.UserV1RegisteredType,
+			user.HumanRegisteredType,
+			user.UserV1InitialCodeAddedType,
+			user.HumanInitialCodeAddedType,
+			user.UserV1InitializedCheckSucceededType,
+			user.HumanInitializedCheckSucceededType,
+			user.UserV1EmailChangedType,
+			user.HumanEmailChangedType,
+			user.UserV1PhoneChangedType,
+			user.HumanPhoneChangedType,
+			user.UserV1PhoneVerifiedType,
+			user.HumanPhoneVerifiedType,
+			user.UserV1PhoneRemovedType,
+			user.HumanPhoneRemovedType,
+			user.HumanPhoneLoginCodeAddedType,
+			user.HumanPhoneLoginCheckSucceededType,
+			user.HumanPhoneLoginCheckFailedType,
+			user.UserRemovedType,
+		).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
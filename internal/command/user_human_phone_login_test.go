@@ -0,0 +1,733 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func phoneLoginHumanAddedEvent(email domain.EmailAddress) eventstore.Event {
+	return eventFromEventPusher(
+		user.NewHumanAddedEvent(context.Background(),
+			&user.NewAggregate("userID", "org").Aggregate,
+			"username",
+			"firstname",
+			"lastname",
+			"nickname",
+			"displayname",
+			language.German,
+			domain.GenderUnspecified,
+			email,
+			true,
+		),
+	)
+}
+
+func phoneLoginPhoneChangedEvent() eventstore.Event {
+	return eventFromEventPusher(
+		user.NewHumanPhoneChangedEvent(context.Background(),
+			&user.NewAggregate("userID", "org").Aggregate,
+			"+41791234567",
+		),
+	)
+}
+
+func TestCommandSide_HumanRequestPhoneLogin(t *testing.T) {
+	type fields struct {
+		eventstore     func(*testing.T) *eventstore.Eventstore
+		userEncryption crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		userID        string
+		resourceOwner string
+		channel       domain.PhoneChannel
+		authRequest   *domain.AuthRequest
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		err    error
+	}{
+		{
+			name: "userID missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				resourceOwner: "org",
+			},
+			err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Eeth4", "Errors.User.UserIDMissing"),
+		},
+		{
+			name: "invalid channel, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				channel:       domain.PhoneChannel(99),
+				authRequest:   &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowInvalidArgument(nil, "COMMAND-gu3Ai", "Errors.User.Phone.ChannelInvalid"),
+		},
+		{
+			name: "user agent missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				authRequest:   &domain.AuthRequest{ID: "authRequestID"},
+			},
+			err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Vai8u", "Errors.User.PhoneLogin.UserAgentMismatch"),
+		},
+		{
+			name: "user not found, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				authRequest:   &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowNotFound(nil, "COMMAND-ahL7i", "Errors.User.NotFound"),
+		},
+		{
+			name: "phone login not allowed, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						phoneLoginHumanAddedEvent("email@test.ch"),
+						phoneLoginPhoneChangedEvent(),
+					),
+					expectFilter(
+						phoneOTPLoginPolicyEvent(false),
+					),
+				),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				authRequest:   &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Thoo0", "Errors.User.PhoneLogin.NotAllowed"),
+		},
+		{
+			name: "phone not verified, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						phoneLoginHumanAddedEvent("email@test.ch"),
+						phoneLoginPhoneChangedEvent(),
+					),
+					expectFilter(
+						phoneOTPLoginPolicyEvent(true),
+					),
+				),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				authRequest:   &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieT1a", "Errors.User.Phone.NotVerified"),
+		},
+		{
+			name: "no phone, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						phoneLoginHumanAddedEvent(""),
+					),
+					expectFilter(
+						phoneOTPLoginPolicyEvent(true),
+					),
+				),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				authRequest:   &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieT1a", "Errors.User.Phone.NotVerified"),
+		},
+		{
+			name: "code added, verified phone",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						phoneLoginHumanAddedEvent("email@test.ch"),
+						phoneLoginPhoneChangedEvent(),
+						eventFromEventPusher(
+							user.NewHumanPhoneVerifiedEvent(context.Background(),
+								&user.NewAggregate("userID", "org").Aggregate,
+							),
+						),
+					),
+					expectFilter(
+						phoneOTPLoginPolicyEvent(true),
+					),
+					expectFilter(),
+					expectPush(
+						user.NewHumanPhoneLoginCodeAddedEvent(context.Background(),
+							&user.NewAggregate("userID", "org").Aggregate,
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("123456"),
+							},
+							time.Minute*5,
+							domain.PhoneChannelWhatsApp,
+							&user.AuthRequestInfo{
+								ID:          "authRequestID",
+								UserAgentID: "userAgentID",
+							},
+						),
+					),
+				),
+				userEncryption: crypto.CreateMockEncryptionAlgWithCode(gomock.NewController(t), "123456"),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				channel:       domain.PhoneChannelWhatsApp,
+				authRequest:   &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+		},
+		{
+			name: "code added, unverified phone of user without email (phone-first sign-up)",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						phoneLoginHumanAddedEvent(""),
+						phoneLoginPhoneChangedEvent(),
+					),
+					expectFilter(
+						phoneOTPLoginPolicyEvent(true),
+					),
+					expectFilter(),
+					expectPush(
+						user.NewHumanPhoneLoginCodeAddedEvent(context.Background(),
+							&user.NewAggregate("userID", "org").Aggregate,
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("123456"),
+							},
+							time.Minute*5,
+							domain.PhoneChannelSMS,
+							&user.AuthRequestInfo{
+								ID:          "authRequestID",
+								UserAgentID: "userAgentID",
+							},
+						),
+					),
+				),
+				userEncryption: crypto.CreateMockEncryptionAlgWithCode(gomock.NewController(t), "123456"),
+			},
+			args: args{
+				userID:        "userID",
+				resourceOwner: "org",
+				channel:       domain.PhoneChannelSMS,
+				authRequest:   &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				userEncryption: tt.fields.userEncryption,
+				defaultSecretGenerators: &SecretGenerators{
+					OTPSMS: &crypto.GeneratorConfig{
+						Length:              6,
+						Expiry:              time.Minute * 5,
+						IncludeLowerLetters: false,
+						IncludeUpperLetters: false,
+						IncludeDigits:       true,
+						IncludeSymbols:      false,
+					},
+				},
+			}
+			err := c.HumanRequestPhoneLogin(context.Background(), tt.args.userID, tt.args.resourceOwner, tt.args.channel, tt.args.authRequest)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCommandSide_HumanCheckPhoneLogin(t *testing.T) {
+	codeAdded := func(userAgentID string) eventstore.Event {
+		return eventFromEventPusherWithCreationDateNow(
+			user.NewHumanPhoneLoginCodeAddedEvent(context.Background(),
+				&user.NewAggregate("userID", "org").Aggregate,
+				&crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    []byte("code"),
+				},
+				time.Hour,
+				domain.PhoneChannelSMS,
+				&user.AuthRequestInfo{
+					ID:          "authRequestID",
+					UserAgentID: userAgentID,
+				},
+			),
+		)
+	}
+	phoneVerified := eventFromEventPusher(
+		user.NewHumanPhoneVerifiedEvent(context.Background(),
+			&user.NewAggregate("userID", "org").Aggregate,
+		),
+	)
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userID      string
+		code        string
+		authRequest *domain.AuthRequest
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		err    error
+	}{
+		{
+			name: "userID missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{},
+			err:  zerrors.ThrowInvalidArgument(nil, "COMMAND-Kei0u", "Errors.User.UserIDMissing"),
+		},
+		{
+			name: "code missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID: "userID",
+			},
+			err: zerrors.ThrowInvalidArgument(nil, "COMMAND-zo8Ah", "Errors.User.Code.Empty"),
+		},
+		{
+			name: "code not found, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						phoneLoginHumanAddedEvent("email@test.ch"),
+						phoneLoginPhoneChangedEvent(),
+						phoneVerified,
+					),
+				),
+			},
+			args: args{
+				userID:      "userID",
+				code:        "code",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-eeR0o", "Errors.User.Code.NotFound"),
+		},
+		{
+			name: "phone changed after code was sent, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						phoneLoginHumanAddedEvent("email@test.ch"),
+						phoneLoginPhoneChangedEvent(),
+						phoneVerified,
+						codeAdded("userAgentID"),
+						phoneLoginPhoneChangedEvent(),
+					),
+				),
+			},
+			args: args{
+				userID:      "userID",
+				code:        "code",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-eeR0o", "Errors.User.Code.NotFound"),
+		},
+		{
+			name: "other user agent, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						phoneLoginHumanAddedEvent("email@test.ch"),
+						phoneLoginPhoneChangedEvent(),
+						phoneVerified,
+						codeAdded("userAgentID"),
+					),
+				),
+			},
+			args: args{
+				userID:      "userID",
+				code:        "code",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "otherUserAgentID"},
+			},
+			err: zerrors.ThrowPermissionDenied(nil, "COMMAND-ya6Ie", "Errors.User.PhoneLogin.UserAgentMismatch"),
+		},
+		{
+			name: "invalid code, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						phoneLoginHumanAddedEvent("email@test.ch"),
+						phoneLoginPhoneChangedEvent(),
+						phoneVerified,
+						codeAdded("userAgentID"),
+					),
+					expectPush(
+						user.NewHumanPhoneLoginCheckFailedEvent(context.Background(),
+							&user.NewAggregate("userID", "org").Aggregate,
+							&user.AuthRequestInfo{
+								ID:          "authRequestID",
+								UserAgentID: "userAgentID",
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				userID:      "userID",
+				code:        "wrong",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+			err: zerrors.ThrowInvalidArgument(nil, "CODE-woT0xc", "Errors.User.Code.Invalid"),
+		},
+		{
+			name: "code ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						phoneLoginHumanAddedEvent("email@test.ch"),
+						phoneLoginPhoneChangedEvent(),
+						phoneVerified,
+						codeAdded("userAgentID"),
+					),
+					expectPush(
+						user.NewHumanPhoneLoginCheckSucceededEvent(context.Background(),
+							&user.NewAggregate("userID", "org").Aggregate,
+							&user.AuthRequestInfo{
+								ID:          "authRequestID",
+								UserAgentID: "userAgentID",
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				userID:      "userID",
+				code:        "code",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+		},
+		{
+			name: "code ok, phone verified (phone-first sign-up)",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						phoneLoginHumanAddedEvent(""),
+						phoneLoginPhoneChangedEvent(),
+						codeAdded("userAgentID"),
+					),
+					expectPush(
+						user.NewHumanPhoneLoginCheckSucceededEvent(context.Background(),
+							&user.NewAggregate("userID", "org").Aggregate,
+							&user.AuthRequestInfo{
+								ID:          "authRequestID",
+								UserAgentID: "userAgentID",
+							},
+						),
+						user.NewHumanPhoneVerifiedEvent(context.Background(),
+							&user.NewAggregate("userID", "org").Aggregate,
+						),
+					),
+				),
+			},
+			args: args{
+				userID:      "userID",
+				code:        "code",
+				authRequest: &domain.AuthRequest{ID: "authRequestID", AgentID: "userAgentID"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			err := c.HumanCheckPhoneLogin(context.Background(), tt.args.userID, tt.args.code, "org", tt.args.authRequest)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCommandSide_RegisterHumanWithPhone(t *testing.T) {
+	domainPolicy := eventFromEventPusher(
+		org.NewDomainPolicyAddedEvent(context.Background(),
+			&org.NewAggregate("org").Aggregate,
+			false,
+			true,
+			true,
+		),
+	)
+	loginPolicy := func(allowRegister, allowPhoneOTPLogin bool) eventstore.Event {
+		return eventFromEventPusher(
+			org.NewLoginPolicyAddedEvent(context.Background(),
+				&org.NewAggregate("org").Aggregate,
+				false,
+				allowRegister,
+				false,
+				false,
+				false,
+				false,
+				false,
+				false,
+				false,
+				false,
+				false,
+				allowPhoneOTPLogin,
+				domain.PasswordlessTypeNotAllowed,
+				"",
+				time.Hour*1,
+				time.Hour*2,
+				time.Hour*3,
+				time.Hour*4,
+				time.Hour*5,
+			),
+		)
+	}
+	registeredEvent := func(username string) *user.HumanRegisteredEvent {
+		event := user.NewHumanRegisteredEvent(context.Background(),
+			&user.NewAggregate("user1", "org").Aggregate,
+			username,
+			"firstname",
+			"lastname",
+			"",
+			"firstname lastname",
+			language.German,
+			domain.GenderUnspecified,
+			"",
+			false,
+		)
+		event.AddPhoneData("+41791234567")
+		return event
+	}
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		orgID string
+		human *domain.Human
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *domain.Human
+		err    func(error) bool
+	}{
+		{
+			name: "orgID missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				human: &domain.Human{},
+			},
+			err: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "phone missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				orgID: "org",
+				human: &domain.Human{
+					Profile: &domain.Profile{FirstName: "firstname", LastName: "lastname"},
+				},
+			},
+			err: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "phone invalid, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				orgID: "org",
+				human: &domain.Human{
+					Profile: &domain.Profile{FirstName: "firstname", LastName: "lastname"},
+					Phone:   &domain.Phone{PhoneNumber: "invalid"},
+				},
+			},
+			err: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "phone login not allowed, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(domainPolicy),
+					expectFilter(loginPolicy(true, false)),
+				),
+			},
+			args: args{
+				orgID: "org",
+				human: &domain.Human{
+					Profile: &domain.Profile{FirstName: "firstname", LastName: "lastname"},
+					Phone:   &domain.Phone{PhoneNumber: "+41791234567"},
+				},
+			},
+			err: zerrors.IsPreconditionFailed,
+		},
+		{
+			name: "registration not allowed, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(domainPolicy),
+					expectFilter(loginPolicy(false, true)),
+				),
+			},
+			args: args{
+				orgID: "org",
+				human: &domain.Human{
+					Profile: &domain.Profile{FirstName: "firstname", LastName: "lastname"},
+					Phone:   &domain.Phone{PhoneNumber: "+41791234567"},
+				},
+			},
+			err: zerrors.IsPreconditionFailed,
+		},
+		{
+			name: "registered, phone number as username",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(domainPolicy),
+					expectFilter(loginPolicy(true, true)),
+					expectPush(
+						registeredEvent("+41791234567"),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "user1"),
+			},
+			args: args{
+				orgID: "org",
+				human: &domain.Human{
+					Profile: &domain.Profile{
+						FirstName:         "firstname",
+						LastName:          "lastname",
+						PreferredLanguage: language.German,
+					},
+					Phone: &domain.Phone{PhoneNumber: "079 123 45 67"},
+					Email: &domain.Email{EmailAddress: "ignored@test.ch", IsEmailVerified: true},
+					Password: &domain.Password{
+						SecretString: "ignored",
+					},
+				},
+			},
+			want: &domain.Human{
+				ObjectRoot: models.ObjectRoot{
+					AggregateID:   "user1",
+					ResourceOwner: "org",
+				},
+				Username: "+41791234567",
+				State:    domain.UserStateActive,
+				Profile: &domain.Profile{
+					FirstName:         "firstname",
+					LastName:          "lastname",
+					DisplayName:       "firstname lastname",
+					PreferredLanguage: language.German,
+				},
+				Email: &domain.Email{},
+				Phone: &domain.Phone{
+					PhoneNumber: "+41791234567",
+				},
+			},
+		},
+		{
+			name: "registered with username",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(domainPolicy),
+					expectFilter(loginPolicy(true, true)),
+					expectPush(
+						registeredEvent("username"),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "user1"),
+			},
+			args: args{
+				orgID: "org",
+				human: &domain.Human{
+					Username: " username ",
+					Profile: &domain.Profile{
+						FirstName:         "firstname",
+						LastName:          "lastname",
+						PreferredLanguage: language.German,
+					},
+					Phone: &domain.Phone{PhoneNumber: "+41791234567"},
+				},
+			},
+			want: &domain.Human{
+				ObjectRoot: models.ObjectRoot{
+					AggregateID:   "user1",
+					ResourceOwner: "org",
+				},
+				Username: "username",
+				State:    domain.UserStateActive,
+				Profile: &domain.Profile{
+					FirstName:         "firstname",
+					LastName:          "lastname",
+					DisplayName:       "firstname lastname",
+					PreferredLanguage: language.German,
+				},
+				Email: &domain.Email{},
+				Phone: &domain.Phone{
+					PhoneNumber: "+41791234567",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := c.RegisterHumanWithPhone(context.Background(), tt.args.orgID, tt.args.human)
+			if tt.err != nil {
+				assert.True(t, tt.err(err), "got wrong err: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
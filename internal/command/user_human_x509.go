@@ -0,0 +1,146 @@
+package command
+
+import (
+	"context"
+	"crypto/x509"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// x509Verification identifies the client certificate of a successful X.509 authentication
+type x509Verification struct {
+	CAID         string
+	SerialNumber string
+}
+
+// HumanCheckX509 checks the client certificate (followed by optional intermediates) of the user (during login).
+// See [Commands.verifyHumanX509Certificate] for the requirements of the certificate.
+func (c *Commands) HumanCheckX509(ctx context.Context, userID, resourceOwner string, certificates []*x509.Certificate, authRequest *domain.AuthRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Ung7o", "Errors.User.UserIDMissing")
+	}
+	human, err := c.getHumanWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !human.UserState.Exists() {
+		return zerrors.ThrowNotFound(nil, "COMMAND-iNg9e", "Errors.User.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&human.WriteModel)
+	verification, err := c.verifyHumanX509Certificate(ctx, human, certificates, time.Now())
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanX509CheckSucceededEvent(ctx, userAgg, verification.CAID, verification.SerialNumber, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	_, pushErr := c.eventstore.Push(ctx, user.NewHumanX509CheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	logging.WithFields("userID", userID).OnError(pushErr).Error("x509 failure check push failed")
+	return err
+}
+
+// verifyUserX509Certificate verifies the client certificate for the user of a session,
+// see [Commands.verifyHumanX509Certificate].
+func (c *Commands) verifyUserX509Certificate(ctx context.Context, userID, resourceOwner string, certificates []*x509.Certificate, now time.Time) (_ *x509Verification, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	human, err := c.getHumanWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !human.UserState.Exists() {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Lah5u", "Errors.User.NotFound")
+	}
+	return c.verifyHumanX509Certificate(ctx, human, certificates, now)
+}
+
+// verifyHumanX509Certificate verifies that the client certificate
+//   - is valid for client authentication and issued (directly or through the passed intermediates)
+//     by a trusted CA of the organization of the user or the instance
+//   - is not revoked, neither are the intermediates, if the CA has certificate revocation lists
+//   - identifies the user, as the attribute mapped by the CA matches the username or verified email address of the user
+func (c *Commands) verifyHumanX509Certificate(ctx context.Context, human *HumanWriteModel, certificates []*x509.Certificate, now time.Time) (*x509Verification, error) {
+	if len(certificates) == 0 {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ieX4a", "Errors.User.X509.CertificateMissing")
+	}
+	cas, err := c.trustedX509CAs(ctx, human.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if len(cas) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Qua9o", "Errors.User.X509.NotConfigured")
+	}
+	leaf := certificates[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range certificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	// the error of a CA, which issued the certificate, is more specific than not being trusted at all
+	var issuerErr error
+	for _, ca := range cas {
+		issued, err := verifyX509CertificateWithCA(ca, leaf, intermediates, now)
+		if !issued {
+			continue
+		}
+		if err != nil {
+			issuerErr = err
+			continue
+		}
+		if !x509CertificateIdentifiesHuman(ca.UserMapping, leaf, human) {
+			issuerErr = zerrors.ThrowPermissionDenied(nil, "COMMAND-Aeg3i", "Errors.User.X509.UserMismatch")
+			continue
+		}
+		return &x509Verification{
+			CAID:         ca.CAID,
+			SerialNumber: leaf.SerialNumber.Text(16),
+		}, nil
+	}
+	if issuerErr != nil {
+		return nil, issuerErr
+	}
+	return nil, zerrors.ThrowPermissionDenied(nil, "COMMAND-eiS0u", "Errors.User.X509.NotTrusted")
+}
+
+// trustedX509CAs returns the CAs of the organization followed by the ones of the instance
+func (c *Commands) trustedX509CAs(ctx context.Context, orgID string) (_ []*X509CAWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	orgCAs := NewX509CAsWriteModel(orgID)
+	if err = c.eventstore.FilterToQueryReducer(ctx, orgCAs); err != nil {
+		return nil, err
+	}
+	instanceCAs := NewX509CAsWriteModel(authz.GetInstance(ctx).InstanceID())
+	if err = c.eventstore.FilterToQueryReducer(ctx, instanceCAs); err != nil {
+		return nil, err
+	}
+	return append(orgCAs.CAs, instanceCAs.CAs...), nil
+}
+
+// verifyX509CertificateWithCA returns if the certificate was issued by the CA
+// and an error if it was revoked or the revocation could not be checked.
+func verifyX509CertificateWithCA(ca *X509CAWriteModel, leaf *x509.Certificate, intermediates *x509.CertPool, now time.Time) (bool, error) {
+	return (&domain.X509CA{
+		ID:          ca.CAID,
+		Certificate: ca.Certificate,
+		CRL:         ca.CRL,
+	}).VerifyClientCertificate(leaf, intermediates, now)
+}
+
+func x509CertificateIdentifiesHuman(mapping domain.X509UserMapping, cert *x509.Certificate, human *HumanWriteModel) bool {
+	return slices.ContainsFunc(mapping.UserIdentifiers(cert), func(identifier string) bool {
+		return strings.EqualFold(identifier, human.UserName) ||
+			(human.IsEmailVerified && strings.EqualFold(identifier, string(human.Email)))
+	})
+}
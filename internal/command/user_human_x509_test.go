@@ -0,0 +1,455 @@
+package command
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func x509CAAddedEvent(ca *testX509CA, crl []byte, mapping domain.X509UserMapping) eventstore.Event {
+	return eventFromEventPusher(
+		org.NewX509CAAddedEvent(context.Background(),
+			&org.NewAggregate("org").Aggregate,
+			"ca1",
+			"ca",
+			ca.pem,
+			crl,
+			mapping,
+		),
+	)
+}
+
+func TestCommands_HumanCheckX509(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	ca := newTestX509CA(t, "ca")
+	otherCA := newTestX509CA(t, "other")
+	byUsername := ca.clientCertificate(t, 2, &x509.Certificate{Subject: pkix.Name{CommonName: "username"}})
+	byEmail := ca.clientCertificate(t, 3, &x509.Certificate{EmailAddresses: []string{"email@test.ch"}})
+	otherUser := ca.clientCertificate(t, 4, &x509.Certificate{Subject: pkix.Name{CommonName: "other"}})
+	untrusted := otherCA.clientCertificate(t, 2, &x509.Certificate{Subject: pkix.Name{CommonName: "username"}})
+	failedEvent := user.NewHumanX509CheckFailedEvent(ctx, &user.NewAggregate("userID", "org").Aggregate, nil)
+
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userID       string
+		certificates []*x509.Certificate
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		err    error
+	}{
+		{
+			name: "missing userID, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				certificates: []*x509.Certificate{byUsername},
+			},
+			err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ung7o", "Errors.User.UserIDMissing"),
+		},
+		{
+			name: "user not found, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID:       "userID",
+				certificates: []*x509.Certificate{byUsername},
+			},
+			err: zerrors.ThrowNotFound(nil, "COMMAND-iNg9e", "Errors.User.NotFound"),
+		},
+		{
+			name: "no ca configured, precondition failed error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+					),
+					expectFilter(),
+					expectFilter(),
+					expectPush(failedEvent),
+				),
+			},
+			args: args{
+				userID:       "userID",
+				certificates: []*x509.Certificate{byUsername},
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Qua9o", "Errors.User.X509.NotConfigured"),
+		},
+		{
+			name: "issued by other ca, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+					),
+					expectFilter(
+						x509CAAddedEvent(ca, nil, domain.X509UserMappingSubjectCommonName),
+					),
+					expectFilter(),
+					expectPush(failedEvent),
+				),
+			},
+			args: args{
+				userID:       "userID",
+				certificates: []*x509.Certificate{untrusted},
+			},
+			err: zerrors.ThrowPermissionDenied(nil, "COMMAND-eiS0u", "Errors.User.X509.NotTrusted"),
+		},
+		{
+			name: "revoked, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+					),
+					expectFilter(
+						x509CAAddedEvent(ca, ca.crl(t, time.Now().Add(time.Hour), 2), domain.X509UserMappingSubjectCommonName),
+					),
+					expectFilter(),
+					expectPush(failedEvent),
+				),
+			},
+			args: args{
+				userID:       "userID",
+				certificates: []*x509.Certificate{byUsername},
+			},
+			err: zerrors.ThrowPermissionDenied(nil, "DOMAIN-Yei7o", "Errors.User.X509.Revoked"),
+		},
+		{
+			name: "crl expired, precondition failed error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+					),
+					expectFilter(
+						x509CAAddedEvent(ca, ca.crl(t, time.Now().Add(-time.Minute)), domain.X509UserMappingSubjectCommonName),
+					),
+					expectFilter(),
+					expectPush(failedEvent),
+				),
+			},
+			args: args{
+				userID:       "userID",
+				certificates: []*x509.Certificate{byUsername},
+			},
+			err: zerrors.ThrowPreconditionFailed(nil, "DOMAIN-ahT5e", "Errors.User.X509.CRLExpired"),
+		},
+		{
+			name: "other user, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+					),
+					expectFilter(
+						x509CAAddedEvent(ca, nil, domain.X509UserMappingSubjectCommonName),
+					),
+					expectFilter(),
+					expectPush(failedEvent),
+				),
+			},
+			args: args{
+				userID:       "userID",
+				certificates: []*x509.Certificate{otherUser},
+			},
+			err: zerrors.ThrowPermissionDenied(nil, "COMMAND-Aeg3i", "Errors.User.X509.UserMismatch"),
+		},
+		{
+			name: "email not verified, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+					),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewX509CAAddedEvent(ctx,
+								&instance.NewAggregate("instance1").Aggregate,
+								"ca1",
+								"ca",
+								ca.pem,
+								nil,
+								domain.X509UserMappingSANEmail,
+							),
+						),
+					),
+					expectPush(failedEvent),
+				),
+			},
+			args: args{
+				userID:       "userID",
+				certificates: []*x509.Certificate{byEmail},
+			},
+			err: zerrors.ThrowPermissionDenied(nil, "COMMAND-Aeg3i", "Errors.User.X509.UserMismatch"),
+		},
+		{
+			name: "username, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+					),
+					expectFilter(
+						x509CAAddedEvent(ca, ca.crl(t, time.Now().Add(time.Hour), 4), domain.X509UserMappingSubjectCommonName),
+					),
+					expectFilter(),
+					expectPush(
+						user.NewHumanX509CheckSucceededEvent(ctx, &user.NewAggregate("userID", "org").Aggregate, "ca1", "2", nil),
+					),
+				),
+			},
+			args: args{
+				userID:       "userID",
+				certificates: []*x509.Certificate{byUsername},
+			},
+		},
+		{
+			name: "verified email of instance ca, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						magicLinkHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(ctx, &user.NewAggregate("userID", "org").Aggregate),
+						),
+					),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewX509CAAddedEvent(ctx,
+								&instance.NewAggregate("instance1").Aggregate,
+								"ca1",
+								"ca",
+								ca.pem,
+								nil,
+								domain.X509UserMappingSANEmail,
+							),
+						),
+					),
+					expectPush(
+						user.NewHumanX509CheckSucceededEvent(ctx, &user.NewAggregate("userID", "org").Aggregate, "ca1", "3", nil),
+					),
+				),
+			},
+			args: args{
+				userID:       "userID",
+				certificates: []*x509.Certificate{byEmail},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			err := c.HumanCheckX509(ctx, tt.args.userID, "org", tt.args.certificates, nil)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCheckX509(t *testing.T) {
+	ca := newTestX509CA(t, "ca")
+	cert, key := ca.clientCertificateWithKey(t, 2, &x509.Certificate{Subject: pkix.Name{CommonName: "user"}})
+	challenge := &X509Challenge{Challenge: "challenge", Expiry: time.Minute, CreationDate: testNow.Add(-time.Second)}
+	digest := sha256.Sum256([]byte(challenge.Challenge))
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	type fields struct {
+		userID     string
+		challenge  *X509Challenge
+		verifyX509 func(ctx context.Context, userID, resourceOwner string, certificates []*x509.Certificate, now time.Time) (*x509Verification, error)
+	}
+	type args struct {
+		certificates []*x509.Certificate
+		signature    []byte
+	}
+	type res struct {
+		err      error
+		commands []eventstore.Command
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing userID",
+			fields: fields{
+				userID: "",
+			},
+			args: args{
+				certificates: []*x509.Certificate{cert},
+				signature:    signature,
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohch3", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "missing challenge",
+			fields: fields{
+				userID: "userID",
+			},
+			args: args{
+				certificates: []*x509.Certificate{cert},
+				signature:    signature,
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ra7ae", "Errors.Session.X509.NoChallenge"),
+			},
+		},
+		{
+			name: "expired challenge",
+			fields: fields{
+				userID:    "userID",
+				challenge: &X509Challenge{Challenge: "challenge", Expiry: time.Minute, CreationDate: testNow.Add(-time.Hour)},
+			},
+			args: args{
+				certificates: []*x509.Certificate{cert},
+				signature:    signature,
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ra7ae", "Errors.Session.X509.NoChallenge"),
+			},
+		},
+		{
+			name: "missing certificate",
+			fields: fields{
+				userID:    "userID",
+				challenge: challenge,
+			},
+			args: args{
+				signature: signature,
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Zoo4e", "Errors.User.X509.CertificateMissing"),
+			},
+		},
+		{
+			name: "certificate without signature of challenge",
+			fields: fields{
+				userID:    "userID",
+				challenge: challenge,
+			},
+			args: args{
+				certificates: []*x509.Certificate{cert},
+				signature:    []byte("signature"),
+			},
+			res: res{
+				err: zerrors.ThrowPermissionDenied(nil, "DOMAIN-Eex5u", "Errors.User.X509.SignatureInvalid"),
+			},
+		},
+		{
+			name: "verification failed",
+			fields: fields{
+				userID:    "userID",
+				challenge: challenge,
+				verifyX509: func(context.Context, string, string, []*x509.Certificate, time.Time) (*x509Verification, error) {
+					return nil, zerrors.ThrowPermissionDenied(nil, "COMMAND-eiS0u", "Errors.User.X509.NotTrusted")
+				},
+			},
+			args: args{
+				certificates: []*x509.Certificate{cert},
+				signature:    signature,
+			},
+			res: res{
+				err: zerrors.ThrowPermissionDenied(nil, "COMMAND-eiS0u", "Errors.User.X509.NotTrusted"),
+			},
+		},
+		{
+			name: "check ok",
+			fields: fields{
+				userID:    "userID",
+				challenge: challenge,
+				verifyX509: func(context.Context, string, string, []*x509.Certificate, time.Time) (*x509Verification, error) {
+					return &x509Verification{CAID: "ca1", SerialNumber: "2"}, nil
+				},
+			},
+			args: args{
+				certificates: []*x509.Certificate{cert},
+				signature:    signature,
+			},
+			res: res{
+				commands: []eventstore.Command{
+					session.NewX509CheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
+						testNow,
+						"ca1",
+						"2",
+					),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := CheckX509(tt.args.certificates, tt.args.signature)
+
+			sessionModel := &SessionWriteModel{
+				UserID:        tt.fields.userID,
+				UserCheckedAt: testNow,
+				State:         domain.SessionStateActive,
+				X509Challenge: tt.fields.challenge,
+				aggregate:     &session.NewAggregate("sessionID", "instanceID").Aggregate,
+			}
+			cmds := &SessionCommands{
+				sessionCommands:   []SessionCommand{cmd},
+				sessionWriteModel: sessionModel,
+				verifyX509:        tt.fields.verifyX509,
+				now: func() time.Time {
+					return testNow
+				},
+			}
+
+			err := cmd(context.Background(), cmds)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.commands, cmds.eventCommands)
+		})
+	}
+}
+
+func TestCreateX509Challenge(t *testing.T) {
+	var challenge string
+	cmds := &SessionCommands{
+		sessionWriteModel: &SessionWriteModel{
+			aggregate: &session.NewAggregate("sessionID", "instanceID").Aggregate,
+		},
+	}
+	err := CreateX509Challenge(&challenge)(context.Background(), cmds)
+	require.NoError(t, err)
+	assert.Len(t, challenge, 43)
+	assert.Equal(t, []eventstore.Command{
+		session.NewX509ChallengedEvent(context.Background(), &session.NewAggregate("sessionID", "instanceID").Aggregate,
+			challenge,
+			5*time.Minute,
+		),
+	}, cmds.eventCommands)
+}
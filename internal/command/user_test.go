@@ -1500,7 +1500,7 @@ func TestCommandSide_AddUserToken(t *testing.T) {
 				eventstore:  tt.fields.eventstore,
 				idGenerator: tt.fields.idGenerator,
 			}
-			got, err := r.AddUserToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.audience, tt.args.scopes, tt.args.lifetime)
+			got, err := r.AddUserToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.audience, tt.args.scopes, nil, tt.args.lifetime, "")
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -1565,7 +1565,9 @@ func TestCommands_RevokeAccessToken(t *testing.T) {
 								"refreshTokenID",
 								[]string{"clientID"},
 								[]string{"openid"},
+								nil,
 								time.Now(),
+								"",
 							),
 						),
 					),
@@ -1597,7 +1599,9 @@ func TestCommands_RevokeAccessToken(t *testing.T) {
 								"refreshTokenID",
 								[]string{"clientID"},
 								[]string{"openid"},
+								nil,
 								time.Now().Add(5*time.Hour),
+								"",
 							),
 						),
 					),
@@ -1744,6 +1748,159 @@ func TestCommandSide_UserDomainClaimedSent(t *testing.T) {
 	}
 }
 
+func TestCommandSide_SecurityNotificationSent(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx                     context.Context
+		userID                  string
+		resourceOwner           string
+		messageType             string
+		triggeringAggregateID   string
+		triggeringEventSequence uint64
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				messageType:   domain.AccountLockedMessageType,
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				messageType:   domain.AccountLockedMessageType,
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "notification sent, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						user.NewSecurityNotificationSentEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							domain.AccountLockedMessageType,
+							"",
+							5,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:                     context.Background(),
+				userID:                  "user1",
+				resourceOwner:           "org1",
+				messageType:             domain.AccountLockedMessageType,
+				triggeringEventSequence: 5,
+			},
+			res: res{},
+		},
+		{
+			name: "notification of session event sent, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						user.NewSecurityNotificationSentEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							domain.UnknownUserAgentSignInMessageType,
+							"session1",
+							3,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:                     context.Background(),
+				userID:                  "user1",
+				resourceOwner:           "org1",
+				messageType:             domain.UnknownUserAgentSignInMessageType,
+				triggeringAggregateID:   "session1",
+				triggeringEventSequence: 3,
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := r.SecurityNotificationSent(tt.args.ctx, tt.args.resourceOwner, tt.args.userID, tt.args.messageType, tt.args.triggeringAggregateID, tt.args.triggeringEventSequence)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
 func TestExistsUser(t *testing.T) {
 	type args struct {
 		filter        preparation.FilterToQueryReducer
@@ -84,7 +84,17 @@ func (c *Commands) changeUserEmailWithGeneratorEvents(ctx context.Context, userI
 			return nil, err
 		}
 	}
-	if err = cmd.Change(ctx, domain.EmailAddress(email)); err != nil {
+	if cmd.model.IsEmailVerified {
+		// the verified email address stays active until the new one is verified
+		var revertCodeGen crypto.Generator
+		if revertCodeGen, err = c.emailChangeRevertCodeGenerator(ctx); err != nil {
+			return nil, err
+		}
+		err = cmd.RequestChange(ctx, domain.EmailAddress(email), revertCodeGen)
+	} else {
+		err = cmd.Change(ctx, domain.EmailAddress(email))
+	}
+	if err != nil {
 		return nil, err
 	}
 	if err = cmd.AddGeneratedCode(ctx, gen, urlTmpl, returnCode); err != nil {
@@ -167,6 +177,23 @@ func (c *UserEmailEvents) Change(ctx context.Context, email domain.EmailAddress)
 	return nil
 }
 
+// RequestChange requests the change to a new email address, which replaces the current address as soon as it's verified.
+// The generated revert code is sent to the current address.
+func (c *UserEmailEvents) RequestChange(ctx context.Context, email domain.EmailAddress, revertCodeGen crypto.Generator) error {
+	if err := email.Validate(); err != nil {
+		return err
+	}
+	if c.model.Email == email {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-aeW9o", "Errors.User.Email.NotChanged")
+	}
+	event, err := newEmailChangeRequestedEvent(ctx, c.aggregate, email, revertCodeGen)
+	if err != nil {
+		return err
+	}
+	c.events = append(c.events, event)
+	return nil
+}
+
 // SetVerified sets the email address to verified.
 func (c *UserEmailEvents) SetVerified(ctx context.Context) {
 	c.events = append(c.events, user.NewHumanEmailVerifiedEvent(ctx, c.aggregate))
@@ -206,6 +233,9 @@ func (c *UserEmailEvents) VerifyCode(ctx context.Context, code string, gen crypt
 
 	err := crypto.VerifyCode(c.model.CodeCreationDate, c.model.CodeExpiry, c.model.Code, code, gen)
 	if err == nil {
+		if c.model.ChangePending() {
+			c.events = append(c.events, user.NewHumanEmailChangedEvent(ctx, c.aggregate, c.model.PendingEmail))
+		}
 		c.events = append(c.events, user.NewHumanEmailVerifiedEvent(ctx, c.aggregate))
 		return nil
 	}
@@ -0,0 +1,195 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AddX509CA is a certificate authority trusted to issue client certificates (X.509 authentication).
+// The Certificate is PEM encoded, the optional CRL either DER encoded or the PEM encoded CRLs of the CA and its intermediate CAs.
+type AddX509CA struct {
+	Name        string
+	Certificate []byte
+	CRL         []byte
+	UserMapping domain.X509UserMapping
+}
+
+func (ca *AddX509CA) validate() error {
+	if ca.Name == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-uGh3a", "Errors.X509CA.NameMissing")
+	}
+	if !ca.UserMapping.Valid() {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Ahg2u", "Errors.X509CA.UserMappingInvalid")
+	}
+	cert, err := domain.ParseX509CACertificate(ca.Certificate)
+	if err != nil {
+		return err
+	}
+	if len(ca.CRL) == 0 {
+		return nil
+	}
+	_, err = domain.ParseX509CRLs(ca.CRL, cert)
+	return err
+}
+
+// AddInstanceX509CA adds a certificate authority trusted for the users of all organizations of the instance.
+func (c *Commands) AddInstanceX509CA(ctx context.Context, ca *AddX509CA) (string, *domain.ObjectDetails, error) {
+	if err := ca.validate(); err != nil {
+		return "", nil, err
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	writeModel, err := c.x509CAWriteModel(ctx, id, instanceID)
+	if err != nil {
+		return "", nil, err
+	}
+	err = c.pushAppendAndReduce(ctx, writeModel, instance.NewX509CAAddedEvent(
+		ctx,
+		&instance.NewAggregate(instanceID).Aggregate,
+		id,
+		ca.Name,
+		ca.Certificate,
+		ca.CRL,
+		ca.UserMapping,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// AddOrgX509CA adds a certificate authority trusted for the users of the organization.
+func (c *Commands) AddOrgX509CA(ctx context.Context, resourceOwner string, ca *AddX509CA) (string, *domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return "", nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ooH7e", "Errors.ResourceOwnerMissing")
+	}
+	if err := ca.validate(); err != nil {
+		return "", nil, err
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	writeModel, err := c.x509CAWriteModel(ctx, id, resourceOwner)
+	if err != nil {
+		return "", nil, err
+	}
+	err = c.pushAppendAndReduce(ctx, writeModel, org.NewX509CAAddedEvent(
+		ctx,
+		&org.NewAggregate(resourceOwner).Aggregate,
+		id,
+		ca.Name,
+		ca.Certificate,
+		ca.CRL,
+		ca.UserMapping,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// SetInstanceX509CACRL replaces the certificate revocation list of the instance CA.
+// An empty crl disables the revocation check.
+func (c *Commands) SetInstanceX509CACRL(ctx context.Context, id string, crl []byte) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	return c.setX509CACRL(ctx, id, instanceID, crl, func(ctx context.Context) eventstore.Command {
+		return instance.NewX509CACRLSetEvent(ctx, &instance.NewAggregate(instanceID).Aggregate, id, crl)
+	})
+}
+
+// SetOrgX509CACRL replaces the certificate revocation list of the organization CA.
+// An empty crl disables the revocation check.
+func (c *Commands) SetOrgX509CACRL(ctx context.Context, resourceOwner, id string, crl []byte) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ohv1e", "Errors.ResourceOwnerMissing")
+	}
+	return c.setX509CACRL(ctx, id, resourceOwner, crl, func(ctx context.Context) eventstore.Command {
+		return org.NewX509CACRLSetEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, id, crl)
+	})
+}
+
+func (c *Commands) setX509CACRL(ctx context.Context, id, resourceOwner string, crl []byte, setEvent func(ctx context.Context) eventstore.Command) (*domain.ObjectDetails, error) {
+	writeModel, err := c.existingX509CAWriteModel(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if len(crl) > 0 {
+		cert, err := domain.ParseX509CACertificate(writeModel.Certificate)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = domain.ParseX509CRLs(crl, cert); err != nil {
+			return nil, err
+		}
+	}
+	if err = c.pushAppendAndReduce(ctx, writeModel, setEvent(ctx)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveInstanceX509CA removes the instance CA, certificates issued by it are no longer accepted.
+func (c *Commands) RemoveInstanceX509CA(ctx context.Context, id string) (*domain.ObjectDetails, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	writeModel, err := c.existingX509CAWriteModel(ctx, id, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	err = c.pushAppendAndReduce(ctx, writeModel, instance.NewX509CARemovedEvent(ctx, &instance.NewAggregate(instanceID).Aggregate, id))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveOrgX509CA removes the organization CA, certificates issued by it are no longer accepted.
+func (c *Commands) RemoveOrgX509CA(ctx context.Context, resourceOwner, id string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Aej0o", "Errors.ResourceOwnerMissing")
+	}
+	writeModel, err := c.existingX509CAWriteModel(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	err = c.pushAppendAndReduce(ctx, writeModel, org.NewX509CARemovedEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, id))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) existingX509CAWriteModel(ctx context.Context, id, resourceOwner string) (*X509CAWriteModel, error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Eiz2i", "Errors.IDMissing")
+	}
+	writeModel, err := c.x509CAWriteModel(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-xoo0U", "Errors.X509CA.NotFound")
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) x509CAWriteModel(ctx context.Context, id, resourceOwner string) (_ *X509CAWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewX509CAWriteModel(id, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
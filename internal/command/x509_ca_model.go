@@ -0,0 +1,154 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/x509ca"
+)
+
+// X509CAWriteModel contains a trusted certificate authority of the instance or an organization.
+type X509CAWriteModel struct {
+	eventstore.WriteModel
+
+	CAID        string
+	Name        string
+	Certificate []byte
+	CRL         []byte
+	UserMapping domain.X509UserMapping
+	State       domain.X509CAState
+}
+
+func NewX509CAWriteModel(caID, resourceOwner string) *X509CAWriteModel {
+	return &X509CAWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: resourceOwner,
+		},
+		CAID: caID,
+	}
+}
+
+func (wm *X509CAWriteModel) AppendEvents(events ...eventstore.Event) {
+	wm.WriteModel.AppendEvents(unwrapX509CAEvents(events)...)
+}
+
+func (wm *X509CAWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		wm.reduce(event)
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *X509CAWriteModel) reduce(event eventstore.Event) {
+	switch e := event.(type) {
+	case *x509ca.AddedEvent:
+		if e.ID != wm.CAID {
+			return
+		}
+		wm.Name = e.Name
+		wm.Certificate = e.Certificate
+		wm.CRL = e.CRL
+		wm.UserMapping = e.UserMapping
+		wm.State = domain.X509CAStateActive
+	case *x509ca.CRLSetEvent:
+		if e.ID != wm.CAID {
+			return
+		}
+		wm.CRL = e.CRL
+	case *x509ca.RemovedEvent:
+		if e.ID != wm.CAID {
+			return
+		}
+		wm.State = domain.X509CAStateRemoved
+	}
+}
+
+func (wm *X509CAWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return x509CAsQuery(wm.ResourceOwner, map[string]interface{}{"id": wm.CAID})
+}
+
+// X509CAsWriteModel contains all trusted certificate authorities of the instance or an organization.
+type X509CAsWriteModel struct {
+	eventstore.WriteModel
+
+	CAs []*X509CAWriteModel
+}
+
+func NewX509CAsWriteModel(resourceOwner string) *X509CAsWriteModel {
+	return &X509CAsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *X509CAsWriteModel) AppendEvents(events ...eventstore.Event) {
+	wm.WriteModel.AppendEvents(unwrapX509CAEvents(events)...)
+}
+
+func (wm *X509CAsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		if e, ok := event.(*x509ca.AddedEvent); ok {
+			wm.CAs = append(wm.CAs, NewX509CAWriteModel(e.ID, wm.ResourceOwner))
+		}
+		for _, ca := range wm.CAs {
+			ca.reduce(event)
+		}
+	}
+	cas := wm.CAs[:0]
+	for _, ca := range wm.CAs {
+		if ca.State.Exists() {
+			cas = append(cas, ca)
+		}
+	}
+	wm.CAs = cas
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *X509CAsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return x509CAsQuery(wm.ResourceOwner, nil)
+}
+
+func unwrapX509CAEvents(events []eventstore.Event) []eventstore.Event {
+	unwrapped := make([]eventstore.Event, 0, len(events))
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.X509CAAddedEvent:
+			unwrapped = append(unwrapped, &e.AddedEvent)
+		case *org.X509CAAddedEvent:
+			unwrapped = append(unwrapped, &e.AddedEvent)
+		case *instance.X509CACRLSetEvent:
+			unwrapped = append(unwrapped, &e.CRLSetEvent)
+		case *org.X509CACRLSetEvent:
+			unwrapped = append(unwrapped, &e.CRLSetEvent)
+		case *instance.X509CARemovedEvent:
+			unwrapped = append(unwrapped, &e.RemovedEvent)
+		case *org.X509CARemovedEvent:
+			unwrapped = append(unwrapped, &e.RemovedEvent)
+		}
+	}
+	return unwrapped
+}
+
+func x509CAsQuery(resourceOwner string, eventData map[string]interface{}) *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(resourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.X509CAAddedEventType,
+			instance.X509CACRLSetEventType,
+			instance.X509CARemovedEventType,
+		).
+		EventData(eventData).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.X509CAAddedEventType,
+			org.X509CACRLSetEventType,
+			org.X509CARemovedEventType,
+		).
+		EventData(eventData).
+		Builder()
+}
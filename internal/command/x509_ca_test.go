@@ -0,0 +1,492 @@
+package command
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type testX509CA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestX509CA(t *testing.T, name string) *testX509CA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testX509CA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+func (ca *testX509CA) clientCertificate(t *testing.T, serial int64, template *x509.Certificate) *x509.Certificate {
+	cert, _ := ca.clientCertificateWithKey(t, serial, template)
+	return cert
+}
+
+func (ca *testX509CA) clientCertificateWithKey(t *testing.T, serial int64, template *x509.Certificate) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template.SerialNumber = big.NewInt(serial)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func (ca *testX509CA) crl(t *testing.T, nextUpdate time.Time, revoked ...int64) []byte {
+	entries := make([]x509.RevocationListEntry, len(revoked))
+	for i, serial := range revoked {
+		entries[i] = x509.RevocationListEntry{SerialNumber: big.NewInt(serial), RevocationTime: time.Now().Add(-time.Minute)}
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(1),
+		ThisUpdate:                time.Now().Add(-time.Hour),
+		NextUpdate:                nextUpdate,
+		RevokedCertificateEntries: entries,
+	}, ca.cert, ca.key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+func TestCommands_AddInstanceX509CA(t *testing.T) {
+	ca := newTestX509CA(t, "ca")
+	otherCA := newTestX509CA(t, "other")
+	leaf := ca.clientCertificate(t, 2, &x509.Certificate{Subject: pkix.Name{CommonName: "user"}})
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ca *AddX509CA
+	}
+	type res struct {
+		id  string
+		err error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing name, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ca: &AddX509CA{
+					Certificate: ca.pem,
+					UserMapping: domain.X509UserMappingSANUPN,
+				},
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-uGh3a", "Errors.X509CA.NameMissing"),
+			},
+		},
+		{
+			name: "missing user mapping, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ca: &AddX509CA{
+					Name:        "ca",
+					Certificate: ca.pem,
+				},
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ahg2u", "Errors.X509CA.UserMappingInvalid"),
+			},
+		},
+		{
+			name: "no ca certificate, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ca: &AddX509CA{
+					Name:        "ca",
+					Certificate: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw}),
+					UserMapping: domain.X509UserMappingSANUPN,
+				},
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "DOMAIN-aiP0e", "Errors.X509CA.CertificateInvalid"),
+			},
+		},
+		{
+			name: "crl of other ca, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ca: &AddX509CA{
+					Name:        "ca",
+					Certificate: ca.pem,
+					CRL:         otherCA.crl(t, time.Now().Add(time.Hour)),
+					UserMapping: domain.X509UserMappingSANUPN,
+				},
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohj4a", "Errors.X509CA.CRLInvalid"),
+			},
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						instance.NewX509CAAddedEvent(ctx,
+							&instance.NewAggregate("instance1").Aggregate,
+							"ca1",
+							"ca",
+							ca.pem,
+							nil,
+							domain.X509UserMappingSANUPN,
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "ca1"),
+			},
+			args: args{
+				ca: &AddX509CA{
+					Name:        "ca",
+					Certificate: ca.pem,
+					UserMapping: domain.X509UserMappingSANUPN,
+				},
+			},
+			res: res{
+				id: "ca1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			id, _, err := c.AddInstanceX509CA(ctx, tt.args.ca)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.id, id)
+		})
+	}
+}
+
+func TestCommands_AddOrgX509CA(t *testing.T) {
+	ca := newTestX509CA(t, "ca")
+	crl := ca.crl(t, time.Now().Add(time.Hour), 3)
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		resourceOwner string
+		ca            *AddX509CA
+	}
+	type res struct {
+		id  string
+		err error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing resource owner, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ca: &AddX509CA{
+					Name:        "ca",
+					Certificate: ca.pem,
+					UserMapping: domain.X509UserMappingSANEmail,
+				},
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-ooH7e", "Errors.ResourceOwnerMissing"),
+			},
+		},
+		{
+			name: "ok with crl",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						org.NewX509CAAddedEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							"ca1",
+							"ca",
+							ca.pem,
+							crl,
+							domain.X509UserMappingSANEmail,
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "ca1"),
+			},
+			args: args{
+				resourceOwner: "org1",
+				ca: &AddX509CA{
+					Name:        "ca",
+					Certificate: ca.pem,
+					CRL:         crl,
+					UserMapping: domain.X509UserMappingSANEmail,
+				},
+			},
+			res: res{
+				id: "ca1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			id, _, err := c.AddOrgX509CA(context.Background(), tt.args.resourceOwner, tt.args.ca)
+			assert.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.id, id)
+		})
+	}
+}
+
+func TestCommands_SetOrgX509CACRL(t *testing.T) {
+	ca := newTestX509CA(t, "ca")
+	otherCA := newTestX509CA(t, "other")
+	crl := ca.crl(t, time.Now().Add(time.Hour), 3)
+	caAdded := eventFromEventPusher(
+		org.NewX509CAAddedEvent(context.Background(),
+			&org.NewAggregate("org1").Aggregate,
+			"ca1",
+			"ca",
+			ca.pem,
+			nil,
+			domain.X509UserMappingSANUPN,
+		),
+	)
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		id  string
+		crl []byte
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		err    error
+	}{
+		{
+			name: "not found, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				id:  "ca1",
+				crl: crl,
+			},
+			err: zerrors.ThrowNotFound(nil, "COMMAND-xoo0U", "Errors.X509CA.NotFound"),
+		},
+		{
+			name: "removed, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						caAdded,
+						eventFromEventPusher(
+							org.NewX509CARemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "ca1"),
+						),
+					),
+				),
+			},
+			args: args{
+				id:  "ca1",
+				crl: crl,
+			},
+			err: zerrors.ThrowNotFound(nil, "COMMAND-xoo0U", "Errors.X509CA.NotFound"),
+		},
+		{
+			name: "crl of other ca, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						caAdded,
+					),
+				),
+			},
+			args: args{
+				id:  "ca1",
+				crl: otherCA.crl(t, time.Now().Add(time.Hour)),
+			},
+			err: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohj4a", "Errors.X509CA.CRLInvalid"),
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						caAdded,
+					),
+					expectPush(
+						org.NewX509CACRLSetEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							"ca1",
+							crl,
+						),
+					),
+				),
+			},
+			args: args{
+				id:  "ca1",
+				crl: crl,
+			},
+		},
+		{
+			name: "remove crl, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						caAdded,
+					),
+					expectPush(
+						org.NewX509CACRLSetEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							"ca1",
+							nil,
+						),
+					),
+				),
+			},
+			args: args{
+				id: "ca1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			_, err := c.SetOrgX509CACRL(context.Background(), "org1", tt.args.id, tt.args.crl)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCommands_RemoveInstanceX509CA(t *testing.T) {
+	ca := newTestX509CA(t, "ca")
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		id     string
+		err    error
+	}{
+		{
+			name: "missing id, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Eiz2i", "Errors.IDMissing"),
+		},
+		{
+			name: "not found, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			id:  "ca1",
+			err: zerrors.ThrowNotFound(nil, "COMMAND-xoo0U", "Errors.X509CA.NotFound"),
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewX509CAAddedEvent(ctx,
+								&instance.NewAggregate("instance1").Aggregate,
+								"ca1",
+								"ca",
+								ca.pem,
+								nil,
+								domain.X509UserMappingSANUPN,
+							),
+						),
+					),
+					expectPush(
+						instance.NewX509CARemovedEvent(ctx,
+							&instance.NewAggregate("instance1").Aggregate,
+							"ca1",
+						),
+					),
+				),
+			},
+			id: "ca1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			_, err := c.RemoveInstanceX509CA(ctx, tt.id)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -25,6 +25,11 @@ type TLS struct {
 	Key []byte
 	//Certificate for the TLS connection (CertPath will this overwrite, if specified)
 	Cert []byte
+	//If enabled, clients are asked for a certificate during the handshake,
+	//which is used for the authentication of OIDC clients with mutual TLS.
+	//The certificate is optional and not verified during the handshake,
+	//this is done during the authentication of the client.
+	RequestClientCert bool
 }
 
 func (t *TLS) Config() (_ *tls.Config, err error) {
@@ -50,7 +55,11 @@ func (t *TLS) Config() (_ *tls.Config, err error) {
 	if err != nil {
 		return nil, err
 	}
-	return &tls.Config{
+	config := &tls.Config{
 		Certificates: []tls.Certificate{tlsCert},
-	}, nil
+	}
+	if t.RequestClientCert {
+		config.ClientAuth = tls.RequestClientCert
+	}
+	return config, nil
 }
@@ -31,8 +31,17 @@ type APIAuthMethodType int32
 const (
 	APIAuthMethodTypeBasic APIAuthMethodType = iota
 	APIAuthMethodTypePrivateKeyJWT
+	// APIAuthMethodTypeTLSClientAuth authenticates the client with a certificate issued by a trusted CA (RFC 8705)
+	APIAuthMethodTypeTLSClientAuth
+	// APIAuthMethodTypeSelfSignedTLSClientAuth authenticates the client with a registered self-signed certificate (RFC 8705)
+	APIAuthMethodTypeSelfSignedTLSClientAuth
 )
 
+// IsTLSClientAuth returns if the client authenticates with a client certificate (mutual TLS).
+func (t APIAuthMethodType) IsTLSClientAuth() bool {
+	return t == APIAuthMethodTypeTLSClientAuth || t == APIAuthMethodTypeSelfSignedTLSClientAuth
+}
+
 func (a *APIApp) IsValid() bool {
 	return a.AppName != ""
 }
@@ -50,7 +59,7 @@ func (a *APIApp) requiresClientSecret() bool {
 }
 
 func (a *APIApp) GenerateClientSecretIfNeeded(generator crypto.Generator) (secret string, err error) {
-	if a.AuthMethodType == APIAuthMethodTypePrivateKeyJWT {
+	if !a.requiresClientSecret() {
 		return "", nil
 	}
 	a.ClientSecret, secret, err = NewClientSecret(generator)
@@ -46,6 +46,7 @@ type OIDCApp struct {
 	ClockSkew                time.Duration
 	AdditionalOrigins        []string
 	SkipNativeAppSuccessPage bool
+	ConsentRequired          bool
 
 	State AppState
 }
@@ -108,8 +109,17 @@ const (
 	OIDCAuthMethodTypePost
 	OIDCAuthMethodTypeNone
 	OIDCAuthMethodTypePrivateKeyJWT
+	// OIDCAuthMethodTypeTLSClientAuth authenticates the client with a certificate issued by a trusted CA (RFC 8705)
+	OIDCAuthMethodTypeTLSClientAuth
+	// OIDCAuthMethodTypeSelfSignedTLSClientAuth authenticates the client with a registered self-signed certificate (RFC 8705)
+	OIDCAuthMethodTypeSelfSignedTLSClientAuth
 )
 
+// IsTLSClientAuth returns if the client authenticates with a client certificate (mutual TLS).
+func (t OIDCAuthMethodType) IsTLSClientAuth() bool {
+	return t == OIDCAuthMethodTypeTLSClientAuth || t == OIDCAuthMethodTypeSelfSignedTLSClientAuth
+}
+
 type Compliance struct {
 	NoneCompliant bool
 	Problems      []string
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/x509"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ApplicationTLSClientAuth defines the client certificate of an application,
+// which authenticates with mutual TLS (RFC 8705).
+type ApplicationTLSClientAuth struct {
+	// SubjectDN is the expected subject (RFC 2253 string representation) of the certificate
+	// issued by a trusted CA of the organization or instance (tls_client_auth)
+	SubjectDN string
+	// Certificate is the PEM encoded certificate registered for self_signed_tls_client_auth
+	Certificate []byte
+}
+
+// ParseX509SelfSignedCertificate parses the PEM encoded certificate registered for self_signed_tls_client_auth.
+func ParseX509SelfSignedCertificate(data []byte) (*x509.Certificate, error) {
+	certs, err := parseX509PEM(data)
+	if err != nil || len(certs) != 1 {
+		return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-Iek2o", "Errors.Project.App.TLSClientAuth.CertificateInvalid")
+	}
+	return certs[0], nil
+}
+
+// MatchesSubjectDN checks the subject of a certificate issued by a trusted CA (tls_client_auth).
+func (a *ApplicationTLSClientAuth) MatchesSubjectDN(cert *x509.Certificate) bool {
+	return a.SubjectDN != "" && strings.EqualFold(strings.TrimSpace(a.SubjectDN), cert.Subject.String())
+}
+
+// VerifySelfSigned checks that the certificate is the registered one and currently valid (self_signed_tls_client_auth).
+func (a *ApplicationTLSClientAuth) VerifySelfSigned(cert *x509.Certificate, now time.Time) error {
+	registered, err := ParseX509SelfSignedCertificate(a.Certificate)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(registered.Raw, cert.Raw) {
+		return zerrors.ThrowPermissionDenied(nil, "DOMAIN-ooP4i", "Errors.Project.App.TLSClientAuth.CertificateMismatch")
+	}
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return zerrors.ThrowPermissionDenied(nil, "DOMAIN-Tha8u", "Errors.Project.App.TLSClientAuth.CertificateExpired")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestApplicationTLSClientAuth_MatchesSubjectDN(t *testing.T) {
+	cert, _ := testX509Certificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "client", Organization: []string{"ZITADEL"}}})
+	tests := []struct {
+		name      string
+		subjectDN string
+		want      bool
+	}{
+		{
+			name:      "empty",
+			subjectDN: "",
+			want:      false,
+		},
+		{
+			name:      "other subject",
+			subjectDN: "CN=other,O=ZITADEL",
+			want:      false,
+		},
+		{
+			name:      "matching subject",
+			subjectDN: "cn=client,o=zitadel",
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &ApplicationTLSClientAuth{SubjectDN: tt.subjectDN}
+			assert.Equal(t, tt.want, a.MatchesSubjectDN(cert))
+		})
+	}
+}
+
+func TestApplicationTLSClientAuth_VerifySelfSigned(t *testing.T) {
+	cert, der := testX509Certificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "client"}})
+	other, _ := testX509Certificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "client"}})
+	registered := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	tests := []struct {
+		name        string
+		certificate []byte
+		cert        *x509.Certificate
+		now         time.Time
+		wantErr     error
+	}{
+		{
+			name:        "no registered certificate",
+			certificate: nil,
+			cert:        cert,
+			now:         time.Now(),
+			wantErr:     zerrors.ThrowInvalidArgument(nil, "DOMAIN-Iek2o", "Errors.Project.App.TLSClientAuth.CertificateInvalid"),
+		},
+		{
+			name:        "other certificate",
+			certificate: registered,
+			cert:        other,
+			now:         time.Now(),
+			wantErr:     zerrors.ThrowPermissionDenied(nil, "DOMAIN-ooP4i", "Errors.Project.App.TLSClientAuth.CertificateMismatch"),
+		},
+		{
+			name:        "expired certificate",
+			certificate: registered,
+			cert:        cert,
+			now:         time.Now().Add(2 * time.Hour),
+			wantErr:     zerrors.ThrowPermissionDenied(nil, "DOMAIN-Tha8u", "Errors.Project.App.TLSClientAuth.CertificateExpired"),
+		},
+		{
+			name:        "registered certificate",
+			certificate: registered,
+			cert:        cert,
+			now:         time.Now(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &ApplicationTLSClientAuth{Certificate: tt.certificate}
+			assert.ErrorIs(t, a.VerifySelfSigned(tt.cert, tt.now), tt.wantErr)
+		})
+	}
+}
@@ -19,6 +19,8 @@ const (
 	LabelPolicyLogoPath = labelPolicyLogoPrefix
 	LabelPolicyIconPath = labelPolicyIconPrefix
 	LabelPolicyFontPath = labelPolicyFontPrefix
+
+	MailTemplatePrefix = policyPrefix + "/mail/template"
 )
 
 type AssetInfo struct {
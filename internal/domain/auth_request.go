@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"slices"
 	"strings"
 	"time"
 
@@ -26,7 +27,6 @@ type AuthRequest struct {
 	InstanceID    string
 	Request       Request
 
-	levelOfAssurance         LevelOfAssurance
 	UserID                   string
 	UserName                 string
 	LoginName                string
@@ -44,6 +44,10 @@ type AuthRequest struct {
 	LinkingUsers             []*ExternalUser
 	PossibleSteps            []NextStep `json:"-"`
 	PasswordVerified         bool
+	MagicLinkVerified        bool
+	X509Verified             bool
+	PhoneLoginVerified       bool
+	ConsentGranted           bool
 	MFAsVerified             []MFAType
 	Audience                 []string
 	AuthTime                 time.Time
@@ -94,10 +98,19 @@ func IsPrompt(prompt []Prompt, requestedPrompt Prompt) bool {
 	return false
 }
 
+// LevelOfAssurance describes the strength of an authentication
+// based on the factors the user authenticated with.
+// The levels are ordered, so a higher level always satisfies a lower one.
 type LevelOfAssurance int
 
 const (
 	LevelOfAssuranceNone LevelOfAssurance = iota
+	// LevelOfAssurancePassword is reached by any single factor (e.g. password, magic link or external IDP)
+	LevelOfAssurancePassword
+	// LevelOfAssuranceMFA is reached by multiple factors (e.g. password and OTP) or a passkey
+	LevelOfAssuranceMFA
+	// LevelOfAssurancePhishingResistant is reached by multiple factors including a WebAuthN authenticator (U2F or passkey)
+	LevelOfAssurancePhishingResistant
 )
 
 type MFAType int
@@ -110,6 +123,23 @@ const (
 	MFATypeOTPEmail
 )
 
+// IsPhishingResistant returns true for the WebAuthN based factors,
+// which are bound to the origin and can therefore not be phished.
+func (m MFAType) IsPhishingResistant() bool {
+	return m == MFATypeU2F || m == MFATypeU2FUserVerification
+}
+
+// PhishingResistantMFATypes filters the provided types for the ones which are phishing resistant.
+func PhishingResistantMFATypes(types []MFAType) []MFAType {
+	filtered := make([]MFAType, 0, len(types))
+	for _, mfaType := range types {
+		if mfaType.IsPhishingResistant() {
+			filtered = append(filtered, mfaType)
+		}
+	}
+	return filtered
+}
+
 func (m MFAType) UserAuthMethodType() UserAuthMethodType {
 	switch m {
 	case MFATypeTOTP:
@@ -165,6 +195,10 @@ func (a *AuthRequest) WithCurrentInfo(info *BrowserInfo) *AuthRequest {
 }
 
 func (a *AuthRequest) SetUserInfo(userID, userName, loginName, displayName, avatar, userOrgID string) {
+	// a consent given in this request is only valid for the user who gave it
+	if a.UserID != userID {
+		a.ConsentGranted = false
+	}
 	a.UserID = userID
 	a.UserName = userName
 	a.LoginName = loginName
@@ -180,9 +214,27 @@ func (a *AuthRequest) SetOrgInformation(id, name, primaryDomain string, requeste
 	a.RequestedOrgDomain = requestedByDomain
 }
 
+// MFALevel returns the MFA level needed to reach the level of assurance requested by the client.
+// If no (or only a single factor) level was requested, -1 is returned and the login policy decides.
 func (a *AuthRequest) MFALevel() MFALevel {
+	if a.RequestedLevelOfAssurance() >= LevelOfAssuranceMFA {
+		return MFALevelSecondFactor
+	}
 	return -1
-	//PLANNED: check a.PossibleLOAs (and Prompt Login?)
+}
+
+// RequestedLevelOfAssurance returns the lowest of the levels requested by the client (acr_values),
+// since reaching any of them satisfies the request.
+func (a *AuthRequest) RequestedLevelOfAssurance() LevelOfAssurance {
+	if len(a.PossibleLOAs) == 0 {
+		return LevelOfAssuranceNone
+	}
+	return slices.Min(a.PossibleLOAs)
+}
+
+// LevelOfAssurance returns the level reached by the factors verified in this request.
+func (a *AuthRequest) LevelOfAssurance() LevelOfAssurance {
+	return LevelOfAssuranceFromAuthMethods(a.UserAuthMethodTypes())
 }
 
 func (a *AuthRequest) AppendAudIfNotExisting(aud string) {
@@ -246,6 +298,15 @@ func (a *AuthRequest) UserAuthMethodTypes() []UserAuthMethodType {
 	if a.PasswordVerified {
 		list = append(list, UserAuthMethodTypePassword)
 	}
+	if a.MagicLinkVerified {
+		list = append(list, UserAuthMethodTypeMagicLink)
+	}
+	if a.PhoneLoginVerified {
+		list = append(list, UserAuthMethodTypeOTPSMS)
+	}
+	if a.X509Verified {
+		list = append(list, UserAuthMethodTypeX509)
+	}
 	for _, mfa := range a.MFAsVerified {
 		list = append(list, mfa.UserAuthMethodType())
 	}
@@ -106,3 +106,118 @@ func TestAuthRequest_UserAuthMethodTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthRequest_MFALevel(t *testing.T) {
+	tests := []struct {
+		name          string
+		possibleLOAs  []LevelOfAssurance
+		wantRequested LevelOfAssurance
+		wantMFALevel  MFALevel
+	}{
+		{
+			name:          "nothing requested",
+			possibleLOAs:  nil,
+			wantRequested: LevelOfAssuranceNone,
+			wantMFALevel:  -1,
+		},
+		{
+			name:          "password",
+			possibleLOAs:  []LevelOfAssurance{LevelOfAssurancePassword},
+			wantRequested: LevelOfAssurancePassword,
+			wantMFALevel:  -1,
+		},
+		{
+			name:          "mfa",
+			possibleLOAs:  []LevelOfAssurance{LevelOfAssuranceMFA},
+			wantRequested: LevelOfAssuranceMFA,
+			wantMFALevel:  MFALevelSecondFactor,
+		},
+		{
+			name:          "lowest of multiple",
+			possibleLOAs:  []LevelOfAssurance{LevelOfAssurancePhishingResistant, LevelOfAssuranceMFA},
+			wantRequested: LevelOfAssuranceMFA,
+			wantMFALevel:  MFALevelSecondFactor,
+		},
+		{
+			name:          "phishing resistant",
+			possibleLOAs:  []LevelOfAssurance{LevelOfAssurancePhishingResistant},
+			wantRequested: LevelOfAssurancePhishingResistant,
+			wantMFALevel:  MFALevelSecondFactor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AuthRequest{
+				PossibleLOAs: tt.possibleLOAs,
+			}
+			assert.Equal(t, tt.wantRequested, a.RequestedLevelOfAssurance())
+			assert.Equal(t, tt.wantMFALevel, a.MFALevel())
+		})
+	}
+}
+
+func TestAuthRequest_LevelOfAssurance(t *testing.T) {
+	type fields struct {
+		PasswordVerified  bool
+		MagicLinkVerified bool
+		MFAsVerified      []MFAType
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   LevelOfAssurance
+	}{
+		{
+			name:   "no auth methods",
+			fields: fields{},
+			want:   LevelOfAssuranceNone,
+		},
+		{
+			name: "password",
+			fields: fields{
+				PasswordVerified: true,
+			},
+			want: LevelOfAssurancePassword,
+		},
+		{
+			name: "magic link",
+			fields: fields{
+				MagicLinkVerified: true,
+			},
+			want: LevelOfAssurancePassword,
+		},
+		{
+			name: "password and otp",
+			fields: fields{
+				PasswordVerified: true,
+				MFAsVerified:     []MFAType{MFATypeTOTP},
+			},
+			want: LevelOfAssuranceMFA,
+		},
+		{
+			name: "password and u2f",
+			fields: fields{
+				PasswordVerified: true,
+				MFAsVerified:     []MFAType{MFATypeU2F},
+			},
+			want: LevelOfAssurancePhishingResistant,
+		},
+		{
+			name: "passwordless",
+			fields: fields{
+				MFAsVerified: []MFAType{MFATypeU2FUserVerification},
+			},
+			want: LevelOfAssurancePhishingResistant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AuthRequest{
+				PasswordVerified:  tt.fields.PasswordVerified,
+				MagicLinkVerified: tt.fields.MagicLinkVerified,
+				MFAsVerified:      tt.fields.MFAsVerified,
+			}
+			assert.Equal(t, tt.want, a.LevelOfAssurance())
+		})
+	}
+}
@@ -17,6 +17,16 @@ const (
 	DomainClaimedMessageType            = "DomainClaimed"
 	PasswordlessRegistrationMessageType = "PasswordlessRegistration"
 	PasswordChangeMessageType           = "PasswordChange"
+	UnknownUserAgentSignInMessageType   = "UnknownUserAgentSignIn"
+	MFAAddedMessageType                 = "MFAAdded"
+	MFARemovedMessageType               = "MFARemoved"
+	EmailChangedMessageType             = "EmailChanged"
+	PhoneChangedMessageType             = "PhoneChanged"
+	AccountLockedMessageType            = "AccountLocked"
+	MachineCredentialAddedMessageType   = "MachineCredentialAdded"
+	MagicLinkMessageType                = "MagicLink"
+	EmailChangeRequestedMessageType     = "EmailChangeRequested"
+	QuotaNotificationMessageType        = "QuotaNotification"
 	MessageTitle                        = "Title"
 	MessagePreHeader                    = "PreHeader"
 	MessageSubject                      = "Subject"
@@ -34,6 +44,15 @@ type MessageTexts struct {
 	DomainClaimed            CustomMessageText
 	PasswordlessRegistration CustomMessageText
 	PasswordChange           CustomMessageText
+	UnknownUserAgentSignIn   CustomMessageText
+	MFAAdded                 CustomMessageText
+	MFARemoved               CustomMessageText
+	EmailChanged             CustomMessageText
+	PhoneChanged             CustomMessageText
+	AccountLocked            CustomMessageText
+	MachineCredentialAdded   CustomMessageText
+	MagicLink                CustomMessageText
+	EmailChangeRequested     CustomMessageText
 }
 
 type CustomMessageText struct {
@@ -71,5 +90,14 @@ func IsMessageTextType(textType string) bool {
 		textType == VerifyEmailOTPMessageType ||
 		textType == DomainClaimedMessageType ||
 		textType == PasswordlessRegistrationMessageType ||
-		textType == PasswordChangeMessageType
+		textType == PasswordChangeMessageType ||
+		textType == UnknownUserAgentSignInMessageType ||
+		textType == MFAAddedMessageType ||
+		textType == MFARemovedMessageType ||
+		textType == EmailChangedMessageType ||
+		textType == PhoneChangedMessageType ||
+		textType == AccountLockedMessageType ||
+		textType == MachineCredentialAddedMessageType ||
+		textType == MagicLinkMessageType ||
+		textType == EmailChangeRequestedMessageType
 }
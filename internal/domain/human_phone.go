@@ -82,3 +82,26 @@ func (s PhoneState) Valid() bool {
 func (s PhoneState) Exists() bool {
 	return s == PhoneStateActive
 }
+
+// PhoneChannel defines over which channel a message (e.g. a one time code) is delivered to a phone number.
+type PhoneChannel int32
+
+const (
+	PhoneChannelSMS PhoneChannel = iota
+	PhoneChannelWhatsApp
+
+	phoneChannelCount
+)
+
+func (c PhoneChannel) Valid() bool {
+	return c >= 0 && c < phoneChannelCount
+}
+
+func (c PhoneChannel) String() string {
+	switch c {
+	case PhoneChannelWhatsApp:
+		return "whatsapp"
+	default:
+		return "sms"
+	}
+}
@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// IDPAttributeMapping declares how the claims or attributes of a federated user
+// are mapped to the ZITADEL user, overriding the provider specific default mapping.
+//
+// Every field contains an expression, which is either a path to the claim (e.g. `given_name` or `address.country`)
+// or a template with paths in curly brackets (e.g. `{given_name} {family_name}`).
+// Empty expressions keep the value of the default mapping.
+type IDPAttributeMapping struct {
+	FirstName         string
+	LastName          string
+	DisplayName       string
+	NickName          string
+	PreferredUsername string
+	Email             string
+	EmailVerified     string
+	Phone             string
+	PhoneVerified     string
+	PreferredLanguage string
+	Metadata          []*IDPMetadataMapping
+}
+
+// IDPMetadataMapping maps the result of the Expression to the metadata Key of the user.
+type IDPMetadataMapping struct {
+	Key        string
+	Expression string
+}
+
+// AttributeLookup returns the values of the claim or attribute found at the path.
+type AttributeLookup func(path string) []string
+
+func (m *IDPAttributeMapping) IsValid() bool {
+	for _, expression := range m.expressions() {
+		if !validAttributeExpression(expression, true) {
+			return false
+		}
+	}
+	keys := make(map[string]struct{}, len(m.Metadata))
+	for _, metadata := range m.Metadata {
+		if metadata.Key == "" || !validAttributeExpression(metadata.Expression, false) {
+			return false
+		}
+		if _, ok := keys[metadata.Key]; ok {
+			return false
+		}
+		keys[metadata.Key] = struct{}{}
+	}
+	return true
+}
+
+// Paths returns all (distinct) paths referenced by the mapping,
+// e.g. to request the corresponding attributes from an LDAP directory.
+func (m *IDPAttributeMapping) Paths() []string {
+	paths := make([]string, 0)
+	add := func(expression string) {
+		for _, path := range attributeExpressionPaths(expression) {
+			if !slices.Contains(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+	for _, expression := range m.expressions() {
+		add(expression)
+	}
+	for _, metadata := range m.Metadata {
+		add(metadata.Expression)
+	}
+	return paths
+}
+
+// Apply overrides the fields of the user with the results of the mapped expressions.
+// Fields with an empty expression or an empty result are kept.
+func (m *IDPAttributeMapping) Apply(user *ExternalUser, lookup AttributeLookup) {
+	setString := func(field *string, expression string) {
+		if value := evaluateAttributeExpression(expression, lookup); value != "" {
+			*field = value
+		}
+	}
+	setBool := func(field *bool, expression string) {
+		if value, err := strconv.ParseBool(evaluateAttributeExpression(expression, lookup)); err == nil {
+			*field = value
+		}
+	}
+	setString(&user.FirstName, m.FirstName)
+	setString(&user.LastName, m.LastName)
+	setString(&user.DisplayName, m.DisplayName)
+	setString(&user.NickName, m.NickName)
+	setString(&user.PreferredUsername, m.PreferredUsername)
+	if email := evaluateAttributeExpression(m.Email, lookup); email != "" {
+		user.Email = EmailAddress(email)
+	}
+	setBool(&user.IsEmailVerified, m.EmailVerified)
+	if phone := evaluateAttributeExpression(m.Phone, lookup); phone != "" {
+		user.Phone = PhoneNumber(phone)
+	}
+	setBool(&user.IsPhoneVerified, m.PhoneVerified)
+	if lang := evaluateAttributeExpression(m.PreferredLanguage, lookup); lang != "" {
+		if tag, err := language.Parse(lang); err == nil {
+			user.PreferredLanguage = tag
+		}
+	}
+	for _, metadata := range m.Metadata {
+		value := evaluateAttributeExpression(metadata.Expression, lookup)
+		if value == "" {
+			continue
+		}
+		user.Metadatas = setMetadata(user.Metadatas, metadata.Key, []byte(value))
+	}
+}
+
+func (m *IDPAttributeMapping) expressions() []string {
+	return []string{
+		m.FirstName,
+		m.LastName,
+		m.DisplayName,
+		m.NickName,
+		m.PreferredUsername,
+		m.Email,
+		m.EmailVerified,
+		m.Phone,
+		m.PhoneVerified,
+		m.PreferredLanguage,
+	}
+}
+
+func setMetadata(metadata []*Metadata, key string, value []byte) []*Metadata {
+	for _, entry := range metadata {
+		if entry.Key == key {
+			entry.Value = value
+			return metadata
+		}
+	}
+	return append(metadata, &Metadata{Key: key, Value: value})
+}
+
+// validAttributeExpression checks the syntax of the expression:
+// it must either be a path or a template with balanced curly brackets around non-empty paths.
+func validAttributeExpression(expression string, emptyAllowed bool) bool {
+	if expression == "" {
+		return emptyAllowed
+	}
+	if !strings.ContainsAny(expression, "{}") {
+		return validAttributePath(expression)
+	}
+	rest := expression
+	for rest != "" {
+		start := strings.IndexAny(rest, "{}")
+		if start < 0 {
+			return true
+		}
+		if rest[start] == '}' {
+			return false
+		}
+		end := strings.IndexAny(rest[start+1:], "{}")
+		if end < 0 || rest[start+1+end] == '{' {
+			return false
+		}
+		if !validAttributePath(rest[start+1 : start+1+end]) {
+			return false
+		}
+		rest = rest[start+1+end+1:]
+	}
+	return true
+}
+
+func validAttributePath(path string) bool {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return false
+	}
+	for _, segment := range strings.Split(path, ".") {
+		if segment == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// attributeExpressionPaths returns the paths of a (valid) expression
+func attributeExpressionPaths(expression string) []string {
+	if expression == "" {
+		return nil
+	}
+	if !strings.Contains(expression, "{") {
+		return []string{strings.TrimSpace(expression)}
+	}
+	paths := make([]string, 0, strings.Count(expression, "{"))
+	rest := expression
+	for {
+		start := strings.Index(rest, "{")
+		end := strings.Index(rest, "}")
+		if start < 0 || end < start {
+			return paths
+		}
+		paths = append(paths, strings.TrimSpace(rest[start+1:end]))
+		rest = rest[end+1:]
+	}
+}
+
+// evaluateAttributeExpression returns the first value of the path
+// or the template, where every path was replaced by its first value.
+func evaluateAttributeExpression(expression string, lookup AttributeLookup) string {
+	if expression == "" {
+		return ""
+	}
+	first := func(path string) string {
+		values := lookup(strings.TrimSpace(path))
+		if len(values) == 0 {
+			return ""
+		}
+		return values[0]
+	}
+	if !strings.Contains(expression, "{") {
+		return first(expression)
+	}
+	var result strings.Builder
+	var found bool
+	rest := expression
+	for {
+		start := strings.Index(rest, "{")
+		end := strings.Index(rest, "}")
+		if start < 0 || end < start {
+			result.WriteString(rest)
+			break
+		}
+		value := first(rest[start+1 : end])
+		found = found || value != ""
+		result.WriteString(rest[:start])
+		result.WriteString(value)
+		rest = rest[end+1:]
+	}
+	// a template without any value (e.g. only the separator between first and last name) is considered empty
+	if !found {
+		return ""
+	}
+	return strings.TrimSpace(result.String())
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestIDPAttributeMapping_IsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping *IDPAttributeMapping
+		want    bool
+	}{
+		{
+			name:    "empty mapping",
+			mapping: &IDPAttributeMapping{},
+			want:    true,
+		},
+		{
+			name: "paths and templates",
+			mapping: &IDPAttributeMapping{
+				FirstName:   "given_name",
+				DisplayName: "{given_name} {family_name}",
+				Email:       "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+				Metadata: []*IDPMetadataMapping{
+					{Key: "country", Expression: "address.country"},
+				},
+			},
+			want: true,
+		},
+		{
+			name:    "empty path segment",
+			mapping: &IDPAttributeMapping{FirstName: "address..country"},
+			want:    false,
+		},
+		{
+			name:    "unclosed bracket",
+			mapping: &IDPAttributeMapping{DisplayName: "{given_name} {family_name"},
+			want:    false,
+		},
+		{
+			name:    "nested brackets",
+			mapping: &IDPAttributeMapping{DisplayName: "{given_{name}}"},
+			want:    false,
+		},
+		{
+			name:    "empty placeholder",
+			mapping: &IDPAttributeMapping{DisplayName: "{} {family_name}"},
+			want:    false,
+		},
+		{
+			name: "metadata without expression",
+			mapping: &IDPAttributeMapping{
+				Metadata: []*IDPMetadataMapping{{Key: "country"}},
+			},
+			want: false,
+		},
+		{
+			name: "duplicate metadata key",
+			mapping: &IDPAttributeMapping{
+				Metadata: []*IDPMetadataMapping{
+					{Key: "country", Expression: "country"},
+					{Key: "country", Expression: "address.country"},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.mapping.IsValid())
+		})
+	}
+}
+
+func TestIDPAttributeMapping_Paths(t *testing.T) {
+	mapping := &IDPAttributeMapping{
+		FirstName:   "given_name",
+		DisplayName: "{given_name} { family_name }",
+		Metadata: []*IDPMetadataMapping{
+			{Key: "country", Expression: "address.country"},
+		},
+	}
+	assert.Equal(t, []string{"given_name", "family_name", "address.country"}, mapping.Paths())
+}
+
+func TestIDPAttributeMapping_Apply(t *testing.T) {
+	attributes := map[string][]string{
+		"given_name":      {"Gigi"},
+		"family_name":     {"Giraffe"},
+		"mail":            {"gigi@example.com"},
+		"mail_verified":   {"true"},
+		"locale":          {"de-CH"},
+		"address.country": {"CH"},
+		"groups":          {"admins", "users"},
+	}
+	lookup := func(path string) []string {
+		return attributes[path]
+	}
+	tests := []struct {
+		name    string
+		mapping *IDPAttributeMapping
+		user    *ExternalUser
+		want    *ExternalUser
+	}{
+		{
+			name:    "empty mapping keeps user",
+			mapping: &IDPAttributeMapping{},
+			user:    &ExternalUser{FirstName: "first", Email: "email@example.com"},
+			want:    &ExternalUser{FirstName: "first", Email: "email@example.com"},
+		},
+		{
+			name: "all fields mapped",
+			mapping: &IDPAttributeMapping{
+				FirstName:         "given_name",
+				LastName:          "family_name",
+				DisplayName:       "{given_name} {family_name}",
+				PreferredUsername: "mail",
+				Email:             "mail",
+				EmailVerified:     "mail_verified",
+				PreferredLanguage: "locale",
+				Metadata: []*IDPMetadataMapping{
+					{Key: "country", Expression: "address.country"},
+					{Key: "group", Expression: "groups"},
+				},
+			},
+			user: &ExternalUser{
+				FirstName: "first",
+				Metadatas: []*Metadata{{Key: "country", Value: []byte("US")}},
+			},
+			want: &ExternalUser{
+				FirstName:         "Gigi",
+				LastName:          "Giraffe",
+				DisplayName:       "Gigi Giraffe",
+				PreferredUsername: "gigi@example.com",
+				Email:             "gigi@example.com",
+				IsEmailVerified:   true,
+				PreferredLanguage: language.Make("de-CH"),
+				Metadatas: []*Metadata{
+					{Key: "country", Value: []byte("CH")},
+					{Key: "group", Value: []byte("admins")},
+				},
+			},
+		},
+		{
+			name: "missing values keep user",
+			mapping: &IDPAttributeMapping{
+				FirstName:     "first_name",
+				DisplayName:   "{first_name} {last_name}",
+				PhoneVerified: "phone_verified",
+			},
+			user: &ExternalUser{FirstName: "first", DisplayName: "display", IsPhoneVerified: true},
+			want: &ExternalUser{FirstName: "first", DisplayName: "display", IsPhoneVerified: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mapping.Apply(tt.user, lookup)
+			assert.Equal(t, tt.want, tt.user)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"slices"
+)
+
+// IDPGroupMapping declares how the group memberships of a federated user
+// are mapped to authorizations (project role user grants and org memberships) on every login.
+//
+// Every project referenced in a rule is managed by the mapping:
+// the user grant of the user on the project is added, changed or removed to match the roles of all matching rules.
+// The same applies to the membership in the organization of the user, if any rule defines member roles.
+type IDPGroupMapping struct {
+	// GroupsAttribute is the attribute or claim of the federated user, which contains the groups
+	// e.g. `memberOf` for LDAP or `groups` for OIDC and Azure AD
+	GroupsAttribute string
+	Rules           []*IDPGroupMappingRule
+}
+
+type IDPGroupMappingRule struct {
+	// Group is the name (or distinguished name) of the group in the external identity provider
+	Group          string
+	ProjectID      string
+	RoleKeys       []string
+	OrgMemberRoles []string
+}
+
+func (m *IDPGroupMapping) IsValid() bool {
+	if len(m.Rules) == 0 {
+		return true
+	}
+	if m.GroupsAttribute == "" {
+		return false
+	}
+	for _, rule := range m.Rules {
+		if !rule.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *IDPGroupMappingRule) IsValid() bool {
+	if r.Group == "" {
+		return false
+	}
+	if r.ProjectID == "" && len(r.RoleKeys) > 0 {
+		return false
+	}
+	return r.ProjectID != "" || len(r.OrgMemberRoles) > 0
+}
+
+// ProjectIDs returns all (distinct) projects, which are managed by the mapping
+func (m *IDPGroupMapping) ProjectIDs() []string {
+	projectIDs := make([]string, 0, len(m.Rules))
+	for _, rule := range m.Rules {
+		if rule.ProjectID != "" && !slices.Contains(projectIDs, rule.ProjectID) {
+			projectIDs = append(projectIDs, rule.ProjectID)
+		}
+	}
+	return projectIDs
+}
+
+// ProjectRoleKeys returns all (distinct) role keys per project, which are managed by the mapping
+func (m *IDPGroupMapping) ProjectRoleKeys() map[string][]string {
+	roleKeys := make(map[string][]string, len(m.Rules))
+	for _, rule := range m.Rules {
+		if rule.ProjectID == "" {
+			continue
+		}
+		roleKeys[rule.ProjectID] = appendMissing(roleKeys[rule.ProjectID], rule.RoleKeys...)
+	}
+	return roleKeys
+}
+
+// OrgMemberRoles returns all (distinct) org member roles used in the mapping
+func (m *IDPGroupMapping) OrgMemberRoles() []string {
+	roles := make([]string, 0)
+	for _, rule := range m.Rules {
+		roles = appendMissing(roles, rule.OrgMemberRoles...)
+	}
+	return roles
+}
+
+// Resolve returns the project roles (per managed project) and the org member roles, which result
+// from the provided groups of the user.
+// Managed projects without any matching rule are returned with nil roles, so their user grant can be removed.
+// If the org membership is not managed by the mapping, manageOrgMember is false.
+func (m *IDPGroupMapping) Resolve(groups []string) (projectRoles map[string][]string, orgMemberRoles []string, manageOrgMember bool) {
+	projectRoles = make(map[string][]string, len(m.Rules))
+	for _, projectID := range m.ProjectIDs() {
+		projectRoles[projectID] = nil
+	}
+	orgMemberRoles = []string{}
+	for _, rule := range m.Rules {
+		if len(rule.OrgMemberRoles) > 0 {
+			manageOrgMember = true
+		}
+		if !slices.Contains(groups, rule.Group) {
+			continue
+		}
+		if rule.ProjectID != "" {
+			roles := projectRoles[rule.ProjectID]
+			if roles == nil {
+				roles = []string{}
+			}
+			projectRoles[rule.ProjectID] = appendMissing(roles, rule.RoleKeys...)
+		}
+		orgMemberRoles = appendMissing(orgMemberRoles, rule.OrgMemberRoles...)
+	}
+	return projectRoles, orgMemberRoles, manageOrgMember
+}
+
+func appendMissing(list []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
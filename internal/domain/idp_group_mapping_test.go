@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDPGroupMapping_IsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping *IDPGroupMapping
+		want    bool
+	}{
+		{
+			"no rules, valid",
+			&IDPGroupMapping{},
+			true,
+		},
+		{
+			"groups attribute missing, invalid",
+			&IDPGroupMapping{
+				Rules: []*IDPGroupMappingRule{{Group: "admins", ProjectID: "project"}},
+			},
+			false,
+		},
+		{
+			"group missing, invalid",
+			&IDPGroupMapping{
+				GroupsAttribute: "groups",
+				Rules:           []*IDPGroupMappingRule{{ProjectID: "project"}},
+			},
+			false,
+		},
+		{
+			"roles without project, invalid",
+			&IDPGroupMapping{
+				GroupsAttribute: "groups",
+				Rules:           []*IDPGroupMappingRule{{Group: "admins", RoleKeys: []string{"admin"}}},
+			},
+			false,
+		},
+		{
+			"neither project nor org member roles, invalid",
+			&IDPGroupMapping{
+				GroupsAttribute: "groups",
+				Rules:           []*IDPGroupMappingRule{{Group: "admins"}},
+			},
+			false,
+		},
+		{
+			"project and org member roles, valid",
+			&IDPGroupMapping{
+				GroupsAttribute: "groups",
+				Rules: []*IDPGroupMappingRule{
+					{Group: "admins", ProjectID: "project", RoleKeys: []string{"admin"}},
+					{Group: "admins", OrgMemberRoles: []string{"ORG_OWNER"}},
+				},
+			},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.mapping.IsValid())
+		})
+	}
+}
+
+func TestIDPGroupMapping_Resolve(t *testing.T) {
+	mapping := &IDPGroupMapping{
+		GroupsAttribute: "groups",
+		Rules: []*IDPGroupMappingRule{
+			{Group: "admins", ProjectID: "project1", RoleKeys: []string{"admin", "user"}},
+			{Group: "users", ProjectID: "project1", RoleKeys: []string{"user"}},
+			{Group: "users", ProjectID: "project2"},
+			{Group: "owners", OrgMemberRoles: []string{"ORG_OWNER"}},
+		},
+	}
+	type res struct {
+		projectRoles    map[string][]string
+		orgMemberRoles  []string
+		manageOrgMember bool
+	}
+	tests := []struct {
+		name    string
+		mapping *IDPGroupMapping
+		groups  []string
+		res     res
+	}{
+		{
+			"no rules",
+			&IDPGroupMapping{},
+			[]string{"admins"},
+			res{
+				projectRoles:   map[string][]string{},
+				orgMemberRoles: []string{},
+			},
+		},
+		{
+			"no groups, all managed removed",
+			mapping,
+			nil,
+			res{
+				projectRoles:    map[string][]string{"project1": nil, "project2": nil},
+				orgMemberRoles:  []string{},
+				manageOrgMember: true,
+			},
+		},
+		{
+			"multiple groups, roles merged",
+			mapping,
+			[]string{"admins", "users", "other"},
+			res{
+				projectRoles:    map[string][]string{"project1": {"admin", "user"}, "project2": {}},
+				orgMemberRoles:  []string{},
+				manageOrgMember: true,
+			},
+		},
+		{
+			"org member",
+			mapping,
+			[]string{"owners"},
+			res{
+				projectRoles:    map[string][]string{"project1": nil, "project2": nil},
+				orgMemberRoles:  []string{"ORG_OWNER"},
+				manageOrgMember: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectRoles, orgMemberRoles, manageOrgMember := tt.mapping.Resolve(tt.groups)
+			assert.Equal(t, tt.res.projectRoles, projectRoles)
+			assert.Equal(t, tt.res.orgMemberRoles, orgMemberRoles)
+			assert.Equal(t, tt.res.manageOrgMember, manageOrgMember)
+		})
+	}
+}
+
+func TestIDPGroupMapping_ProjectRoleKeys(t *testing.T) {
+	mapping := &IDPGroupMapping{
+		GroupsAttribute: "groups",
+		Rules: []*IDPGroupMappingRule{
+			{Group: "admins", ProjectID: "project1", RoleKeys: []string{"admin", "user"}},
+			{Group: "users", ProjectID: "project1", RoleKeys: []string{"user"}},
+			{Group: "users", ProjectID: "project2"},
+			{Group: "owners", OrgMemberRoles: []string{"ORG_OWNER"}},
+		},
+	}
+	assert.Equal(t, map[string][]string{"project1": {"admin", "user"}, "project2": nil}, mapping.ProjectRoleKeys())
+}
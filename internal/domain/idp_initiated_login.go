@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"net/url"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// IDPInitiatedLogin defines if unsolicited responses of a SAML IDP (IdP-initiated login) are accepted
+// and where the user is sent to with the resulting intent.
+type IDPInitiatedLogin struct {
+	Enabled bool
+	// DefaultRedirectURI receives the intent, if the RelayState of the response is not a valid target
+	DefaultRedirectURI string
+}
+
+// Validate checks that an enabled IdP-initiated login has an absolute http(s) default redirect uri.
+func (l *IDPInitiatedLogin) Validate() error {
+	if !l.Enabled {
+		return nil
+	}
+	redirectURI, err := url.Parse(l.DefaultRedirectURI)
+	if err != nil || !redirectURI.IsAbs() || (redirectURI.Scheme != "https" && redirectURI.Scheme != "http") || redirectURI.Host == "" {
+		return zerrors.ThrowInvalidArgument(err, "DOMAIN-Ohy2a", "Errors.IDPConfig.IDPInitiatedLoginRedirectURIInvalid")
+	}
+	return nil
+}
+
+// RedirectURI returns the target of an unsolicited response.
+// The RelayState is used if it's a path or a URL of the same origin as the default redirect uri,
+// otherwise the user is sent to the default redirect uri, which prevents open redirects.
+func (l *IDPInitiatedLogin) RedirectURI(relayState string) (*url.URL, error) {
+	defaultRedirectURI, err := url.Parse(l.DefaultRedirectURI)
+	if err != nil {
+		return nil, zerrors.ThrowPreconditionFailed(err, "DOMAIN-Eek4f", "Errors.IDPConfig.IDPInitiatedLoginRedirectURIInvalid")
+	}
+	if relayState == "" {
+		return defaultRedirectURI, nil
+	}
+	target, err := url.Parse(relayState)
+	if err != nil {
+		return defaultRedirectURI, nil
+	}
+	target = defaultRedirectURI.ResolveReference(target)
+	if target.Scheme != defaultRedirectURI.Scheme || target.Host != defaultRedirectURI.Host {
+		return defaultRedirectURI, nil
+	}
+	return target, nil
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestIDPInitiatedLogin_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		login   *IDPInitiatedLogin
+		wantErr error
+	}{
+		{
+			name:  "disabled",
+			login: &IDPInitiatedLogin{},
+		},
+		{
+			name:    "relative redirect uri",
+			login:   &IDPInitiatedLogin{Enabled: true, DefaultRedirectURI: "/login"},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohy2a", "Errors.IDPConfig.IDPInitiatedLoginRedirectURIInvalid"),
+		},
+		{
+			name:    "other scheme",
+			login:   &IDPInitiatedLogin{Enabled: true, DefaultRedirectURI: "javascript://login.example.com"},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohy2a", "Errors.IDPConfig.IDPInitiatedLoginRedirectURIInvalid"),
+		},
+		{
+			name:  "valid redirect uri",
+			login: &IDPInitiatedLogin{Enabled: true, DefaultRedirectURI: "https://login.example.com/idp/saml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.login.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestIDPInitiatedLogin_RedirectURI(t *testing.T) {
+	login := &IDPInitiatedLogin{Enabled: true, DefaultRedirectURI: "https://login.example.com/idp/saml"}
+	tests := []struct {
+		name       string
+		relayState string
+		want       string
+	}{
+		{
+			name:       "empty relay state",
+			relayState: "",
+			want:       "https://login.example.com/idp/saml",
+		},
+		{
+			name:       "path",
+			relayState: "/app?tenant=1",
+			want:       "https://login.example.com/app?tenant=1",
+		},
+		{
+			name:       "same origin",
+			relayState: "https://login.example.com/app",
+			want:       "https://login.example.com/app",
+		},
+		{
+			name:       "other origin",
+			relayState: "https://evil.example.com/app",
+			want:       "https://login.example.com/idp/saml",
+		},
+		{
+			name:       "protocol relative other origin",
+			relayState: "//evil.example.com/app",
+			want:       "https://login.example.com/idp/saml",
+		},
+		{
+			name:       "invalid relay state",
+			relayState: "%zz",
+			want:       "https://login.example.com/idp/saml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := login.RedirectURI(tt.relayState)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+)
+
+// MinIDPLDAPSyncInterval prevents the directory from being searched too often
+const MinIDPLDAPSyncInterval = 5 * time.Minute
+
+// IDPLDAPSync defines the periodic synchronization of the users of an LDAP IDP.
+//
+// Every run searches all users below the SearchBase (the base DN of the IDP if empty),
+// which match the user object classes of the IDP and the optional Filter.
+type IDPLDAPSync struct {
+	// Enabled schedules a run every Interval
+	Enabled    bool
+	Interval   time.Duration
+	SearchBase string
+	Filter     string
+	// CreateUsers creates and links users found in the directory, which are not linked yet
+	CreateUsers bool
+	// UpdateUsers updates the profile, email and phone of linked users
+	UpdateUsers bool
+	// DeactivateUsers deactivates linked users, which are disabled in or removed from the directory
+	DeactivateUsers bool
+}
+
+func (s *IDPLDAPSync) IsValid() bool {
+	return !s.Enabled || s.Interval >= MinIDPLDAPSyncInterval
+}
+
+// IDPLDAPSyncRun reports the result of a synchronization of an LDAP IDP.
+// Users which could not be created, updated or deactivated are counted as Failed,
+// Error is set if the run could not be completed at all.
+type IDPLDAPSyncRun struct {
+	IDPID       string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	Users       uint32
+	Created     uint32
+	Updated     uint32
+	Deactivated uint32
+	Failed      uint32
+	Error       string
+}
@@ -0,0 +1,25 @@
+package domain
+
+// SupportsLogoutPropagation returns if the session of the user at the IDP can be terminated on logout,
+// either with an RP-initiated logout (OIDC end_session_endpoint) or a SAML single logout.
+func (t IDPType) SupportsLogoutPropagation() bool {
+	switch t {
+	case IDPTypeOIDC,
+		IDPTypeSAML:
+		return true
+	case IDPTypeUnspecified,
+		IDPTypeOAuth,
+		IDPTypeLDAP,
+		IDPTypeJWT,
+		IDPTypeAzureAD,
+		IDPTypeGitHub,
+		IDPTypeGitHubEnterprise,
+		IDPTypeGitLab,
+		IDPTypeGitLabSelfHosted,
+		IDPTypeGoogle,
+		IDPTypeApple:
+		return false
+	default:
+		return false
+	}
+}
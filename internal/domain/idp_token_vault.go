@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+)
+
+// IDPLinkTokens are the tokens issued by an IDP for a linked external user,
+// which are stored in the token vault of the IDP.
+type IDPLinkTokens struct {
+	IDPID          string
+	ExternalUserID string
+	AccessToken    string
+	TokenType      string
+	// Expiry of the access token, zero if unknown
+	Expiry  time.Time
+	IDToken string
+}
+
+// SupportsTokenVault returns if the IDP issues OAuth tokens, which can be stored in the token vault.
+func (t IDPType) SupportsTokenVault() bool {
+	switch t {
+	case IDPTypeOIDC,
+		IDPTypeOAuth,
+		IDPTypeAzureAD,
+		IDPTypeGitHub,
+		IDPTypeGitHubEnterprise,
+		IDPTypeGitLab,
+		IDPTypeGitLabSelfHosted,
+		IDPTypeGoogle,
+		IDPTypeApple:
+		return true
+	case IDPTypeUnspecified,
+		IDPTypeJWT,
+		IDPTypeLDAP,
+		IDPTypeSAML:
+		return false
+	default:
+		return false
+	}
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// MailTemplateMessage is a complete email template of an organization,
+// which replaces the mail template policy for a message type and language
+type MailTemplateMessage struct {
+	models.ObjectRoot
+
+	MessageType string
+	Language    language.Tag
+	Template    []byte
+}
+
+func (m *MailTemplateMessage) IsValid() bool {
+	return IsMailTemplateMessageType(m.MessageType) && m.Language != language.Und && len(m.Template) > 0
+}
+
+// IsMailTemplateMessageType checks if the message type is sent by email,
+// message types which are only sent by SMS can't have a mail template
+func IsMailTemplateMessageType(messageType string) bool {
+	return IsMessageTextType(messageType) &&
+		messageType != VerifyPhoneMessageType &&
+		messageType != VerifySMSOTPMessageType &&
+		messageType != PhoneChangedMessageType
+}
+
+func MailTemplateMessagePath(messageType string, lang language.Tag, id string) string {
+	return MailTemplatePrefix + "/" + messageType + "/" + lang.String() + "-" + id
+}
@@ -29,6 +29,8 @@ const (
 	NextStepProjectRequired
 	NextStepRedirectToExternalIDP
 	NextStepLoginSucceeded
+	NextStepPhoneLogin
+	NextStepConsent
 )
 
 type LoginStep struct{}
@@ -95,6 +97,14 @@ func (s *PasswordStep) Type() NextStepType {
 	return NextStepPassword
 }
 
+// PhoneLoginStep is returned for users without password and email address (phone-first sign-up),
+// which sign in with a one time code sent to their phone.
+type PhoneLoginStep struct{}
+
+func (s *PhoneLoginStep) Type() NextStepType {
+	return NextStepPhoneLogin
+}
+
 type ExternalLoginStep struct {
 	SelectedIDPConfigID string
 }
@@ -164,6 +174,14 @@ func (s *LinkUsersStep) Type() NextStepType {
 	return NextStepLinkUsers
 }
 
+type ConsentStep struct {
+	Scopes []string
+}
+
+func (s *ConsentStep) Type() NextStepType {
+	return NextStepConsent
+}
+
 type GrantRequiredStep struct{}
 
 func (s *GrantRequiredStep) Type() NextStepType {
@@ -31,3 +31,25 @@ const (
 
 	notificationProviderTypeCount
 )
+
+// NotificationState is the delivery state of a message in the notification outbox
+type NotificationState int32
+
+const (
+	NotificationStateUnspecified NotificationState = iota
+	NotificationStatePending
+	NotificationStateSent
+	NotificationStateRetrying
+	NotificationStateFailed
+
+	notificationStateCount
+)
+
+func (s NotificationState) Exists() bool {
+	return s != NotificationStateUnspecified
+}
+
+// Resendable reports if the message was already handled and can be sent again
+func (s NotificationState) Resendable() bool {
+	return s == NotificationStateSent || s == NotificationStateFailed
+}
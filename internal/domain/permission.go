@@ -32,4 +32,5 @@ const (
 	PermissionUserDelete    = "user.delete"
 	PermissionSessionWrite  = "session.write"
 	PermissionSessionDelete = "session.delete"
+	PermissionIAMWrite      = "iam.write"
 )
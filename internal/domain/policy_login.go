@@ -31,6 +31,8 @@ type LoginPolicy struct {
 	MultiFactorCheckLifetime   time.Duration
 	DisableLoginWithEmail      bool
 	DisableLoginWithPhone      bool
+	AllowMagicLink             bool
+	AllowPhoneOTPLogin         bool
 }
 
 func ValidateDefaultRedirectURI(rawURL string) bool {
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// NotificationPolicy defines which security notifications are sent to the users
+type NotificationPolicy struct {
+	models.ObjectRoot
+
+	Default bool
+
+	PasswordChange         bool
+	UnknownUserAgentSignIn bool
+	MFAAdded               bool
+	MFARemoved             bool
+	EmailChanged           bool
+	PhoneChanged           bool
+	AccountLocked          bool
+	MachineCredentialAdded bool
+}
@@ -13,6 +13,8 @@ const (
 	SecretGeneratorTypeAppSecret
 	SecretGeneratorTypeOTPSMS
 	SecretGeneratorTypeOTPEmail
+	SecretGeneratorTypeMagicLinkCode
+	SecretGeneratorTypeEmailChangeRevertCode
 
 	secretGeneratorTypeCount
 )
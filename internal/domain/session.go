@@ -33,3 +33,25 @@ func RenderOTPEmailURLTemplate(w io.Writer, tmpl, code, userID, loginName, displ
 		PreferredLanguage: preferredLanguage,
 	})
 }
+
+type MagicLinkURLData struct {
+	Code              string
+	SessionID         string
+	UserID            string
+	LoginName         string
+	DisplayName       string
+	PreferredLanguage language.Tag
+}
+
+// RenderMagicLinkURLTemplate parses and renders tmpl.
+// code, sessionID, userID, (preferred) loginName, displayName and preferredLanguage are passed into the [MagicLinkURLData].
+func RenderMagicLinkURLTemplate(w io.Writer, tmpl, code, sessionID, userID, loginName, displayName string, preferredLanguage language.Tag) error {
+	return renderURLTemplate(w, tmpl, &MagicLinkURLData{
+		Code:              code,
+		SessionID:         sessionID,
+		UserID:            userID,
+		LoginName:         loginName,
+		DisplayName:       displayName,
+		PreferredLanguage: preferredLanguage,
+	})
+}
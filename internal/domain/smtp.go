@@ -5,5 +5,44 @@ type SMTPConfigState int32
 const (
 	SMTPConfigStateUnspecified SMTPConfigState = iota
 	SMTPConfigStateActive
+	SMTPConfigStateInactive
 	SMTPConfigStateRemoved
 )
+
+func (s SMTPConfigState) Exists() bool {
+	return s != SMTPConfigStateUnspecified && s != SMTPConfigStateRemoved
+}
+
+// SMTPAuthMechanism is the SASL mechanism used to authenticate against the SMTP server
+type SMTPAuthMechanism int32
+
+const (
+	// SMTPAuthMechanismUnspecified is handled as SMTPAuthMechanismPlain
+	SMTPAuthMechanismUnspecified SMTPAuthMechanism = iota
+	SMTPAuthMechanismPlain
+	SMTPAuthMechanismLogin
+	SMTPAuthMechanismCRAMMD5
+	// SMTPAuthMechanismXOAuth2 authenticates with an access token,
+	// which is requested with the client credentials grant
+	SMTPAuthMechanismXOAuth2
+)
+
+func (m SMTPAuthMechanism) Valid() bool {
+	return m >= SMTPAuthMechanismUnspecified && m <= SMTPAuthMechanismXOAuth2
+}
+
+// SMTPTLSMode defines how the connection to the SMTP server is secured
+type SMTPTLSMode int32
+
+const (
+	// SMTPTLSModeUnspecified uses implicit TLS with a fallback to STARTTLS if TLS is required,
+	// otherwise an unencrypted connection
+	SMTPTLSModeUnspecified SMTPTLSMode = iota
+	SMTPTLSModeNone
+	SMTPTLSModeStartTLS
+	SMTPTLSModeImplicit
+)
+
+func (m SMTPTLSMode) Valid() bool {
+	return m >= SMTPTLSModeUnspecified && m <= SMTPTLSModeImplicit
+}
@@ -42,23 +42,34 @@ const (
 	UserAuthMethodTypeIDP
 	UserAuthMethodTypeOTPSMS
 	UserAuthMethodTypeOTPEmail
+	UserAuthMethodTypeMagicLink
+	UserAuthMethodTypeX509
 	userAuthMethodTypeCount
 )
 
 // HasMFA checks whether the user authenticated with multiple auth factors.
 // This can either be true if the list contains a [UserAuthMethodType] which by itself is MFA (e.g. [UserAuthMethodTypePasswordless])
-// or if multiple factors were used (e.g. [UserAuthMethodTypePassword] and [UserAuthMethodTypeU2F])
+// or if multiple factors were used (e.g. [UserAuthMethodTypePassword] and [UserAuthMethodTypeU2F]).
+// Methods proving the possession of the same email address ([UserAuthMethodTypeOTPEmail] and [UserAuthMethodTypeMagicLink])
+// only count as one factor.
 func HasMFA(methods []UserAuthMethodType) bool {
 	var factors int
+	var emailPossession bool
 	for _, method := range methods {
 		switch method {
 		case UserAuthMethodTypePasswordless:
 			return true
+		case UserAuthMethodTypeOTPEmail,
+			UserAuthMethodTypeMagicLink:
+			if !emailPossession {
+				emailPossession = true
+				factors++
+			}
 		case UserAuthMethodTypePassword,
 			UserAuthMethodTypeU2F,
 			UserAuthMethodTypeTOTP,
 			UserAuthMethodTypeOTPSMS,
-			UserAuthMethodTypeOTPEmail,
+			UserAuthMethodTypeX509,
 			UserAuthMethodTypeIDP:
 			factors++
 		case UserAuthMethodTypeUnspecified,
@@ -69,6 +80,53 @@ func HasMFA(methods []UserAuthMethodType) bool {
 	return factors > 1
 }
 
+// LevelOfAssuranceFromAuthMethods returns the [LevelOfAssurance] reached by the provided auth methods.
+// Multiple factors are phishing resistant if at least one of them is a WebAuthN authenticator
+// (e.g. [UserAuthMethodTypePassword] and [UserAuthMethodTypeU2F] or [UserAuthMethodTypePasswordless]).
+// As in [HasMFA], email based methods only count as one factor.
+func LevelOfAssuranceFromAuthMethods(methods []UserAuthMethodType) LevelOfAssurance {
+	var (
+		factors         int
+		webAuthN        bool
+		emailPossession bool
+	)
+	for _, method := range methods {
+		switch method {
+		case UserAuthMethodTypePasswordless:
+			factors += 2
+			webAuthN = true
+		case UserAuthMethodTypeU2F:
+			factors++
+			webAuthN = true
+		case UserAuthMethodTypeOTPEmail,
+			UserAuthMethodTypeMagicLink:
+			if !emailPossession {
+				emailPossession = true
+				factors++
+			}
+		case UserAuthMethodTypePassword,
+			UserAuthMethodTypeTOTP,
+			UserAuthMethodTypeOTPSMS,
+			UserAuthMethodTypeX509,
+			UserAuthMethodTypeIDP:
+			factors++
+		case UserAuthMethodTypeUnspecified,
+			userAuthMethodTypeCount:
+			// ignore
+		}
+	}
+	switch {
+	case factors == 0:
+		return LevelOfAssuranceNone
+	case factors == 1:
+		return LevelOfAssurancePassword
+	case webAuthN:
+		return LevelOfAssurancePhishingResistant
+	default:
+		return LevelOfAssuranceMFA
+	}
+}
+
 // RequiresMFA checks whether the user requires to authenticate with multiple auth factors based on the LoginPolicy and the authentication type.
 // Internal authentication will require MFA if either option is activated.
 // External authentication will only require MFA if it's forced generally and not local only.
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasMFA(t *testing.T) {
+	tests := []struct {
+		name    string
+		methods []UserAuthMethodType
+		want    bool
+	}{
+		{
+			name: "no methods",
+		},
+		{
+			name:    "password",
+			methods: []UserAuthMethodType{UserAuthMethodTypePassword},
+		},
+		{
+			name:    "passwordless",
+			methods: []UserAuthMethodType{UserAuthMethodTypePasswordless},
+			want:    true,
+		},
+		{
+			name:    "password and totp",
+			methods: []UserAuthMethodType{UserAuthMethodTypePassword, UserAuthMethodTypeTOTP},
+			want:    true,
+		},
+		{
+			name:    "magic link and otp email",
+			methods: []UserAuthMethodType{UserAuthMethodTypeMagicLink, UserAuthMethodTypeOTPEmail},
+		},
+		{
+			name:    "magic link and otp sms",
+			methods: []UserAuthMethodType{UserAuthMethodTypeMagicLink, UserAuthMethodTypeOTPSMS},
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, HasMFA(tt.methods))
+		})
+	}
+}
+
+func TestLevelOfAssuranceFromAuthMethods(t *testing.T) {
+	tests := []struct {
+		name    string
+		methods []UserAuthMethodType
+		want    LevelOfAssurance
+	}{
+		{
+			name: "no methods",
+			want: LevelOfAssuranceNone,
+		},
+		{
+			name:    "password",
+			methods: []UserAuthMethodType{UserAuthMethodTypePassword},
+			want:    LevelOfAssurancePassword,
+		},
+		{
+			name:    "password and totp",
+			methods: []UserAuthMethodType{UserAuthMethodTypePassword, UserAuthMethodTypeTOTP},
+			want:    LevelOfAssuranceMFA,
+		},
+		{
+			name:    "password and u2f",
+			methods: []UserAuthMethodType{UserAuthMethodTypePassword, UserAuthMethodTypeU2F},
+			want:    LevelOfAssurancePhishingResistant,
+		},
+		{
+			name:    "passwordless",
+			methods: []UserAuthMethodType{UserAuthMethodTypePasswordless},
+			want:    LevelOfAssurancePhishingResistant,
+		},
+		{
+			name:    "magic link and otp email",
+			methods: []UserAuthMethodType{UserAuthMethodTypeMagicLink, UserAuthMethodTypeOTPEmail},
+			want:    LevelOfAssurancePassword,
+		},
+		{
+			name:    "magic link, otp email and password",
+			methods: []UserAuthMethodType{UserAuthMethodTypeMagicLink, UserAuthMethodTypeOTPEmail, UserAuthMethodTypePassword},
+			want:    LevelOfAssuranceMFA,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LevelOfAssuranceFromAuthMethods(tt.methods))
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// X509UserMapping defines which attribute of a client certificate identifies the user.
+// The value of the attribute is matched against the username and the verified email address of the user.
+type X509UserMapping int32
+
+const (
+	X509UserMappingUnspecified X509UserMapping = iota
+	// X509UserMappingSubjectCommonName uses the common name (CN) of the subject
+	X509UserMappingSubjectCommonName
+	// X509UserMappingSubjectEmail uses the email address (1.2.840.113549.1.9.1) of the subject
+	X509UserMappingSubjectEmail
+	// X509UserMappingSANEmail uses the email addresses (rfc822Name) of the subject alternative names
+	X509UserMappingSANEmail
+	// X509UserMappingSANUPN uses the user principal name (1.3.6.1.4.1.311.20.2.3) of the subject alternative names,
+	// which is used by PIV / CAC smart cards and Active Directory
+	X509UserMappingSANUPN
+	x509UserMappingCount
+)
+
+func (m X509UserMapping) Valid() bool {
+	return m > X509UserMappingUnspecified && m < x509UserMappingCount
+}
+
+var (
+	oidSubjectEmail   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}
+	oidSubjectAltName = asn1.ObjectIdentifier{2, 5, 29, 17}
+	oidUPN            = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 3}
+)
+
+// UserIdentifiers returns the values of the mapped attribute of the certificate.
+func (m X509UserMapping) UserIdentifiers(cert *x509.Certificate) []string {
+	switch m {
+	case X509UserMappingSubjectCommonName:
+		if cert.Subject.CommonName == "" {
+			return nil
+		}
+		return []string{cert.Subject.CommonName}
+	case X509UserMappingSubjectEmail:
+		var emails []string
+		for _, name := range cert.Subject.Names {
+			if email, ok := name.Value.(string); ok && name.Type.Equal(oidSubjectEmail) {
+				emails = append(emails, email)
+			}
+		}
+		return emails
+	case X509UserMappingSANEmail:
+		return cert.EmailAddresses
+	case X509UserMappingSANUPN:
+		return x509UPNs(cert)
+	case X509UserMappingUnspecified, x509UserMappingCount:
+		return nil
+	default:
+		return nil
+	}
+}
+
+// x509UPNs returns the user principal names of the otherName entries of the subject alternative names,
+// which are not parsed by the standard library.
+func x509UPNs(cert *x509.Certificate) []string {
+	var upns []string
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidSubjectAltName) {
+			continue
+		}
+		var names []asn1.RawValue
+		if _, err := asn1.Unmarshal(ext.Value, &names); err != nil {
+			return nil
+		}
+		for _, name := range names {
+			// otherName [0] IMPLICIT SEQUENCE { type-id OBJECT IDENTIFIER, value [0] EXPLICIT ANY }
+			if name.Class != asn1.ClassContextSpecific || name.Tag != 0 {
+				continue
+			}
+			var otherName struct {
+				TypeID asn1.ObjectIdentifier
+				Value  asn1.RawValue `asn1:"explicit,tag:0"`
+			}
+			if _, err := asn1.UnmarshalWithParams(name.FullBytes, &otherName, "tag:0"); err != nil || !otherName.TypeID.Equal(oidUPN) {
+				continue
+			}
+			var upn string
+			if _, err := asn1.Unmarshal(otherName.Value.Bytes, &upn); err == nil && upn != "" {
+				upns = append(upns, upn)
+			}
+		}
+	}
+	return upns
+}
+
+type X509CAState int32
+
+const (
+	X509CAStateUnspecified X509CAState = iota
+	X509CAStateActive
+	X509CAStateRemoved
+)
+
+func (s X509CAState) Exists() bool {
+	return s == X509CAStateActive
+}
+
+// X509CA is a certificate authority trusted to issue client certificates of the users of the instance or an organization.
+// If certificate revocation lists are set, every certificate of the chain is checked against the CRL of its issuer,
+// so the CRLs of intermediate CAs issuing client certificates must be included.
+type X509CA struct {
+	ID          string
+	Name        string
+	Certificate []byte
+	CRL         []byte
+	UserMapping X509UserMapping
+}
+
+// VerifyClientCertificate returns if the certificate was issued by the CA (directly or through the intermediates)
+// and an error if it or an intermediate was revoked or the revocation could not be checked.
+func (ca *X509CA) VerifyClientCertificate(leaf *x509.Certificate, intermediates *x509.CertPool, now time.Time) (bool, error) {
+	caCert, err := ParseX509CACertificate(ca.Certificate)
+	if err != nil {
+		return false, err
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	chains, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return false, nil
+	}
+	if len(ca.CRL) == 0 {
+		return true, nil
+	}
+	crls, err := ParseX509CRLs(ca.CRL, caCert)
+	if err != nil {
+		return true, err
+	}
+	for _, chain := range chains {
+		// the last certificate of the chain is the CA itself, all others are checked against the CRL of their issuer
+		for i := 0; i < len(chain)-1; i++ {
+			if err = checkX509Revocation(chain[i], chain[i+1], crls, now); err != nil {
+				return true, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// checkX509Revocation checks the certificate against the most recent CRL signed by its issuer.
+// The certificate is rejected if there is no such CRL, as its revocation could not be checked.
+func checkX509Revocation(cert, issuer *x509.Certificate, crls []*x509.RevocationList, now time.Time) error {
+	var crl *x509.RevocationList
+	for _, c := range crls {
+		if !bytes.Equal(c.RawIssuer, issuer.RawSubject) || c.CheckSignatureFrom(issuer) != nil {
+			continue
+		}
+		if crl == nil || c.ThisUpdate.After(crl.ThisUpdate) {
+			crl = c
+		}
+	}
+	if crl == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "DOMAIN-Wai3e", "Errors.User.X509.CRLMissing")
+	}
+	// an outdated CRL might not contain recently revoked certificates
+	if !crl.NextUpdate.IsZero() && now.After(crl.NextUpdate) {
+		return zerrors.ThrowPreconditionFailed(nil, "DOMAIN-ahT5e", "Errors.User.X509.CRLExpired")
+	}
+	for _, revoked := range crl.RevokedCertificateEntries {
+		if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+			return zerrors.ThrowPermissionDenied(nil, "DOMAIN-Yei7o", "Errors.User.X509.Revoked")
+		}
+	}
+	return nil
+}
+
+// X509CertificateThumbprint returns the base64url encoded SHA-256 hash of the DER encoded certificate,
+// as used for the `x5t#S256` confirmation method of certificate-bound access tokens (RFC 8705).
+func X509CertificateThumbprint(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// VerifyX509CertificateBinding checks that a certificate-bound access token (thumbprint)
+// is presented with the client certificate it is bound to (RFC 8705).
+// Tokens without a thumbprint are not bound and always valid.
+func VerifyX509CertificateBinding(certificates []*x509.Certificate, thumbprint string) error {
+	if thumbprint == "" {
+		return nil
+	}
+	if len(certificates) == 0 || X509CertificateThumbprint(certificates[0]) != thumbprint {
+		return zerrors.ThrowPermissionDenied(nil, "DOMAIN-ahx4O", "token is bound to another client certificate")
+	}
+	return nil
+}
+
+// VerifyX509Signature checks that the data was signed with the private key of the certificate,
+// which proves the possession of the key for certificates not presented in a TLS handshake.
+// RSA keys must sign with PKCS #1 v1.5 or PSS (salt length of the hash) and ECDSA keys with ASN.1 encoded signatures, both over SHA-256.
+func VerifyX509Signature(cert *x509.Certificate, data, signature []byte) error {
+	var algorithms []x509.SignatureAlgorithm
+	switch cert.PublicKeyAlgorithm {
+	case x509.RSA:
+		algorithms = []x509.SignatureAlgorithm{x509.SHA256WithRSA, x509.SHA256WithRSAPSS}
+	case x509.ECDSA:
+		algorithms = []x509.SignatureAlgorithm{x509.ECDSAWithSHA256}
+	case x509.Ed25519:
+		algorithms = []x509.SignatureAlgorithm{x509.PureEd25519}
+	case x509.UnknownPublicKeyAlgorithm, x509.DSA:
+	}
+	for _, algorithm := range algorithms {
+		if cert.CheckSignature(algorithm, data, signature) == nil {
+			return nil
+		}
+	}
+	return zerrors.ThrowPermissionDenied(nil, "DOMAIN-Eex5u", "Errors.User.X509.SignatureInvalid")
+}
+
+// ParseX509CACertificate parses the PEM encoded certificate of a CA.
+func ParseX509CACertificate(data []byte) (*x509.Certificate, error) {
+	certs, err := parseX509PEM(data)
+	if err != nil || len(certs) != 1 {
+		return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-Ohx3a", "Errors.X509CA.CertificateInvalid")
+	}
+	if !certs[0].IsCA {
+		return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-aiP0e", "Errors.X509CA.CertificateInvalid")
+	}
+	return certs[0], nil
+}
+
+// ParseX509CRLs parses a DER encoded certificate revocation list or one or more PEM encoded ones.
+// The CRL of the CA must be included and signed by it. CRLs of other issuers belong to intermediate CAs,
+// their signature is checked against the intermediates of the client certificate on verification.
+func ParseX509CRLs(data []byte, ca *x509.Certificate) ([]*x509.RevocationList, error) {
+	ders := [][]byte{data}
+	if block, rest := pem.Decode(data); block != nil {
+		ders = nil
+		for block != nil {
+			ders = append(ders, block.Bytes)
+			block, rest = pem.Decode(rest)
+		}
+	}
+	crls := make([]*x509.RevocationList, 0, len(ders))
+	var caCRL bool
+	for _, der := range ders {
+		crl, err := x509.ParseRevocationList(der)
+		if err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-Jei5o", "Errors.X509CA.CRLInvalid")
+		}
+		if bytes.Equal(crl.RawIssuer, ca.RawSubject) {
+			if err = crl.CheckSignatureFrom(ca); err != nil {
+				return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-ree4U", "Errors.X509CA.CRLInvalid")
+			}
+			caCRL = true
+		}
+		crls = append(crls, crl)
+	}
+	if !caCRL {
+		return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohj4a", "Errors.X509CA.CRLInvalid")
+	}
+	return crls, nil
+}
+
+// ParseX509ClientCertificates parses the client certificate (followed by optional intermediates)
+// as forwarded by a TLS terminating proxy. Supported are:
+//   - (URL encoded) PEM, e.g. NGINX `$ssl_client_escaped_cert` or AWS ALB `X-Amzn-Mtls-Clientcert`
+//   - comma separated base64 encoded DER, e.g. Traefik `X-Forwarded-Tls-Client-Cert`
+//   - the `Cert` and `Chain` elements of Envoy's `X-Forwarded-Client-Cert`
+func ParseX509ClientCertificates(value string) ([]*x509.Certificate, error) {
+	value = strings.TrimSpace(value)
+	if cert, chain, ok := parseXFCC(value); ok {
+		value = cert + chain
+	}
+	if value == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-Cei7o", "Errors.User.X509.CertificateMissing")
+	}
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		value = unescaped
+	}
+	if strings.Contains(value, "-----BEGIN") {
+		certs, err := parseX509PEM([]byte(value))
+		if err != nil || len(certs) == 0 {
+			return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-lee2F", "Errors.User.X509.CertificateInvalid")
+		}
+		return certs, nil
+	}
+	parts := strings.Split(value, ",")
+	certs := make([]*x509.Certificate, 0, len(parts))
+	for _, part := range parts {
+		der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(part))
+		if err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-Ko0ai", "Errors.User.X509.CertificateInvalid")
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-ahR4i", "Errors.User.X509.CertificateInvalid")
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// parseXFCC returns the (URL encoded) Cert and Chain of the first element of an X-Forwarded-Client-Cert header.
+func parseXFCC(value string) (cert, chain string, ok bool) {
+	element, _, _ := strings.Cut(value, ",")
+	for _, pair := range strings.Split(element, ";") {
+		key, val, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "cert":
+			cert = strings.Trim(strings.TrimSpace(val), `"`)
+			ok = true
+		case "chain":
+			chain = strings.Trim(strings.TrimSpace(val), `"`)
+		}
+	}
+	return cert, chain, ok
+}
+
+func parseX509PEM(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+}
@@ -0,0 +1,486 @@
+package domain
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func testX509Certificate(t *testing.T, template *x509.Certificate) (*x509.Certificate, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template.SerialNumber = big.NewInt(1)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, der
+}
+
+func testUPNExtension(t *testing.T, upn string) pkix.Extension {
+	value, err := asn1.MarshalWithParams(upn, "utf8")
+	require.NoError(t, err)
+	otherName, err := asn1.MarshalWithParams(struct {
+		TypeID asn1.ObjectIdentifier
+		Value  asn1.RawValue
+	}{
+		TypeID: oidUPN,
+		Value:  asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: value},
+	}, "tag:0")
+	require.NoError(t, err)
+	san, err := asn1.Marshal([]asn1.RawValue{{FullBytes: otherName}})
+	require.NoError(t, err)
+	return pkix.Extension{Id: oidSubjectAltName, Value: san}
+}
+
+func TestX509UserMapping_UserIdentifiers(t *testing.T) {
+	cert, _ := testX509Certificate(t, &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName: "DOE.JOHN.1234567890",
+			ExtraNames: []pkix.AttributeTypeAndValue{
+				{Type: oidSubjectEmail, Value: "subject@example.com"},
+			},
+		},
+		ExtraExtensions: []pkix.Extension{
+			testUPNExtension(t, "1234567890@mil"),
+		},
+	})
+	certWithSAN, _ := testX509Certificate(t, &x509.Certificate{
+		EmailAddresses: []string{"john@example.com", "doe@example.com"},
+	})
+	tests := []struct {
+		name    string
+		mapping X509UserMapping
+		cert    *x509.Certificate
+		want    []string
+	}{
+		{
+			name:    "unspecified",
+			mapping: X509UserMappingUnspecified,
+			cert:    cert,
+			want:    nil,
+		},
+		{
+			name:    "subject common name",
+			mapping: X509UserMappingSubjectCommonName,
+			cert:    cert,
+			want:    []string{"DOE.JOHN.1234567890"},
+		},
+		{
+			name:    "subject email",
+			mapping: X509UserMappingSubjectEmail,
+			cert:    cert,
+			want:    []string{"subject@example.com"},
+		},
+		{
+			name:    "san email",
+			mapping: X509UserMappingSANEmail,
+			cert:    certWithSAN,
+			want:    []string{"john@example.com", "doe@example.com"},
+		},
+		{
+			name:    "san upn",
+			mapping: X509UserMappingSANUPN,
+			cert:    cert,
+			want:    []string{"1234567890@mil"},
+		},
+		{
+			name:    "san upn missing",
+			mapping: X509UserMappingSANUPN,
+			cert:    certWithSAN,
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.mapping.UserIdentifiers(tt.cert))
+		})
+	}
+}
+
+func TestParseX509ClientCertificates(t *testing.T) {
+	cert, der := testX509Certificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "client"}})
+	intermediate, intermediateDER := testX509Certificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "intermediate"}})
+	pemCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	pemIntermediate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: intermediateDER}))
+
+	tests := []struct {
+		name    string
+		value   string
+		want    []*x509.Certificate
+		wantErr func(error) bool
+	}{
+		{
+			name:    "empty",
+			value:   "",
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "invalid",
+			value:   "invalid",
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:  "pem",
+			value: pemCert,
+			want:  []*x509.Certificate{cert},
+		},
+		{
+			name:  "url encoded pem with chain",
+			value: url.PathEscape(pemCert + pemIntermediate),
+			want:  []*x509.Certificate{cert, intermediate},
+		},
+		{
+			name:  "base64 der",
+			value: base64.StdEncoding.EncodeToString(der) + "," + base64.StdEncoding.EncodeToString(intermediateDER),
+			want:  []*x509.Certificate{cert, intermediate},
+		},
+		{
+			name:  "xfcc",
+			value: `By=spiffe://cluster.local/ns/default/sa/zitadel;Hash=abc;Cert="` + url.PathEscape(pemCert) + `";Chain="` + url.PathEscape(pemIntermediate) + `"`,
+			want:  []*x509.Certificate{cert, intermediate},
+		},
+		{
+			name:    "xfcc without cert",
+			value:   `By=spiffe://cluster.local/ns/default/sa/zitadel;Cert=""`,
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseX509ClientCertificates(tt.value)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type testX509Issuer struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestX509RootCA(t *testing.T, name string) *testX509Issuer {
+	return (*testX509Issuer)(nil).issue(t, 1, name, true)
+}
+
+// issue creates a certificate signed by the issuer (or self-signed if the issuer is nil).
+func (issuer *testX509Issuer) issue(t *testing.T, serial int64, name string, isCA bool) *testX509Issuer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+	parent, parentKey := template, key
+	if issuer != nil {
+		parent, parentKey = issuer.cert, issuer.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testX509Issuer{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (issuer *testX509Issuer) crl(t *testing.T, nextUpdate time.Time, revoked ...int64) []byte {
+	entries := make([]x509.RevocationListEntry, len(revoked))
+	for i, serial := range revoked {
+		entries[i] = x509.RevocationListEntry{SerialNumber: big.NewInt(serial), RevocationTime: time.Now().Add(-time.Minute)}
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(1),
+		ThisUpdate:                time.Now().Add(-time.Hour),
+		NextUpdate:                nextUpdate,
+		RevokedCertificateEntries: entries,
+	}, issuer.cert, issuer.key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+func TestX509CA_VerifyClientCertificate(t *testing.T) {
+	root := newTestX509RootCA(t, "root")
+	intermediate := root.issue(t, 2, "intermediate", true)
+	leaf := intermediate.issue(t, 3, "user", false)
+	other := newTestX509RootCA(t, "other")
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(intermediate.cert)
+	nextUpdate := time.Now().Add(time.Hour)
+	concat := func(crls ...[]byte) []byte {
+		var data []byte
+		for _, crl := range crls {
+			data = append(data, crl...)
+		}
+		return data
+	}
+	tests := []struct {
+		name       string
+		crl        []byte
+		leaf       *x509.Certificate
+		wantIssued bool
+		wantErr    error
+	}{
+		{
+			name:       "not issued by ca",
+			leaf:       other.issue(t, 3, "user", false).cert,
+			wantIssued: false,
+		},
+		{
+			name:       "no crl",
+			leaf:       leaf.cert,
+			wantIssued: true,
+		},
+		{
+			name:       "crl of intermediate missing",
+			crl:        root.crl(t, nextUpdate),
+			leaf:       leaf.cert,
+			wantIssued: true,
+			wantErr:    zerrors.ThrowPreconditionFailed(nil, "DOMAIN-Wai3e", "Errors.User.X509.CRLMissing"),
+		},
+		{
+			name:       "intermediate revoked",
+			crl:        concat(root.crl(t, nextUpdate, 2), intermediate.crl(t, nextUpdate)),
+			leaf:       leaf.cert,
+			wantIssued: true,
+			wantErr:    zerrors.ThrowPermissionDenied(nil, "DOMAIN-Yei7o", "Errors.User.X509.Revoked"),
+		},
+		{
+			name:       "leaf issued by intermediate revoked",
+			crl:        concat(root.crl(t, nextUpdate), intermediate.crl(t, nextUpdate, 3)),
+			leaf:       leaf.cert,
+			wantIssued: true,
+			wantErr:    zerrors.ThrowPermissionDenied(nil, "DOMAIN-Yei7o", "Errors.User.X509.Revoked"),
+		},
+		{
+			name:       "crl of intermediate expired",
+			crl:        concat(root.crl(t, nextUpdate), intermediate.crl(t, time.Now().Add(-time.Minute))),
+			leaf:       leaf.cert,
+			wantIssued: true,
+			wantErr:    zerrors.ThrowPreconditionFailed(nil, "DOMAIN-ahT5e", "Errors.User.X509.CRLExpired"),
+		},
+		{
+			name:       "not revoked",
+			crl:        concat(root.crl(t, nextUpdate, 4), intermediate.crl(t, nextUpdate, 4)),
+			leaf:       leaf.cert,
+			wantIssued: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := &X509CA{Certificate: root.pem, CRL: tt.crl}
+			issued, err := ca.VerifyClientCertificate(tt.leaf, intermediates, time.Now())
+			assert.Equal(t, tt.wantIssued, issued)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseX509CRLs(t *testing.T) {
+	ca := newTestX509RootCA(t, "ca")
+	intermediate := ca.issue(t, 2, "intermediate", true)
+	other := newTestX509RootCA(t, "other")
+	nextUpdate := time.Now().Add(time.Hour)
+	caCRL := ca.crl(t, nextUpdate)
+	der, _ := pem.Decode(caCRL)
+	tests := []struct {
+		name    string
+		data    []byte
+		want    int
+		wantErr error
+	}{
+		{
+			name:    "invalid",
+			data:    []byte("crl"),
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Jei5o", "Errors.X509CA.CRLInvalid"),
+		},
+		{
+			name:    "crl of ca missing",
+			data:    intermediate.crl(t, nextUpdate),
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohj4a", "Errors.X509CA.CRLInvalid"),
+		},
+		{
+			name: "der",
+			data: der.Bytes,
+			want: 1,
+		},
+		{
+			name: "pem of ca and intermediate",
+			data: append(caCRL, intermediate.crl(t, nextUpdate)...),
+			want: 2,
+		},
+		{
+			name:    "pem of other ca",
+			data:    other.crl(t, nextUpdate),
+			wantErr: zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohj4a", "Errors.X509CA.CRLInvalid"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseX509CRLs(tt.data, ca.cert)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Len(t, got, tt.want)
+		})
+	}
+}
+
+func TestVerifyX509Signature(t *testing.T) {
+	data := []byte("challenge")
+	digest := sha256.Sum256(data)
+	certificate := func(t *testing.T, pub, priv any) *x509.Certificate {
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "user"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return cert
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaCert := certificate(t, &rsaKey.PublicKey, rsaKey)
+	pkcs1Signature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	pssSignature, err := rsa.SignPSS(rand.Reader, rsaKey, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecCert := certificate(t, &ecKey.PublicKey, ecKey)
+	ecSignature, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+	require.NoError(t, err)
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edCert := certificate(t, edPublic, edKey)
+	tests := []struct {
+		name      string
+		cert      *x509.Certificate
+		data      []byte
+		signature []byte
+		wantErr   error
+	}{
+		{
+			name:      "rsa pkcs1",
+			cert:      rsaCert,
+			data:      data,
+			signature: pkcs1Signature,
+		},
+		{
+			name:      "rsa pss",
+			cert:      rsaCert,
+			data:      data,
+			signature: pssSignature,
+		},
+		{
+			name:      "ecdsa",
+			cert:      ecCert,
+			data:      data,
+			signature: ecSignature,
+		},
+		{
+			name:      "ed25519",
+			cert:      edCert,
+			data:      data,
+			signature: ed25519.Sign(edKey, data),
+		},
+		{
+			name:      "signature of other key",
+			cert:      rsaCert,
+			data:      data,
+			signature: ecSignature,
+			wantErr:   zerrors.ThrowPermissionDenied(nil, "DOMAIN-Eex5u", "Errors.User.X509.SignatureInvalid"),
+		},
+		{
+			name:      "other data",
+			cert:      ecCert,
+			data:      []byte("other"),
+			signature: ecSignature,
+			wantErr:   zerrors.ThrowPermissionDenied(nil, "DOMAIN-Eex5u", "Errors.User.X509.SignatureInvalid"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, VerifyX509Signature(tt.cert, tt.data, tt.signature), tt.wantErr)
+		})
+	}
+}
+
+func TestVerifyX509CertificateBinding(t *testing.T) {
+	cert, _ := testX509Certificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "client"}})
+	otherCert, _ := testX509Certificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "other"}})
+	tests := []struct {
+		name         string
+		certificates []*x509.Certificate
+		thumbprint   string
+		wantErr      bool
+	}{
+		{
+			name: "not bound",
+		},
+		{
+			name:         "not bound, certificate",
+			certificates: []*x509.Certificate{cert},
+		},
+		{
+			name:       "bound, certificate missing",
+			thumbprint: X509CertificateThumbprint(cert),
+			wantErr:    true,
+		},
+		{
+			name:         "bound, other certificate",
+			certificates: []*x509.Certificate{otherCert, cert},
+			thumbprint:   X509CertificateThumbprint(cert),
+			wantErr:      true,
+		},
+		{
+			name:         "bound, certificate",
+			certificates: []*x509.Certificate{cert},
+			thumbprint:   X509CertificateThumbprint(cert),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyX509CertificateBinding(tt.certificates, tt.thumbprint)
+			if tt.wantErr {
+				assert.True(t, zerrors.IsPermissionDenied(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
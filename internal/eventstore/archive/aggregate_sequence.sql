@@ -0,0 +1 @@
+SELECT MAX("sequence") FROM eventstore.events2 WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3
\ No newline at end of file
@@ -0,0 +1,244 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed expired_aggregates.sql
+	expiredAggregatesStmt string
+	//go:embed aggregate_sequence.sql
+	aggregateSequenceStmt string
+	//go:embed archive_aggregate.sql
+	archiveAggregateStmt string
+	//go:embed delete_aggregate.sql
+	deleteAggregateStmt string
+	//go:embed delete_unique_constraint.sql
+	deleteUniqueConstraintStmt string
+	//go:embed archive_events.sql
+	archiveEventsStmt string
+)
+
+// Archiver moves events which are not needed anymore from eventstore.events2 to eventstore.events2_archive.
+// Only events already processed by all projections of an instance are moved.
+type Archiver struct {
+	config *Config
+	client *database.DB
+	es     *eventstore.Eventstore
+	now    func() time.Time
+}
+
+func NewArchiver(config *Config, client *database.DB, es *eventstore.Eventstore) *Archiver {
+	return &Archiver{
+		config: config,
+		client: client,
+		es:     es,
+		now:    time.Now,
+	}
+}
+
+// Start runs the archival in the configured interval until ctx is done
+func (a *Archiver) Start(ctx context.Context) {
+	go a.schedule(ctx)
+}
+
+func (a *Archiver) schedule(ctx context.Context) {
+	t := time.NewTimer(0)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			err := a.Archive(ctx)
+			logging.OnError(err).Warn("archival of events failed")
+			t.Reset(a.config.Interval)
+		}
+	}
+}
+
+// Archive moves the events of expired aggregates and old events of the configured types to the archive
+func (a *Archiver) Archive(ctx context.Context) error {
+	if len(a.config.AggregateTypes) > 0 {
+		if err := a.archiveAggregates(ctx); err != nil {
+			return err
+		}
+	}
+	if len(a.config.EventTypes) > 0 {
+		return a.archiveEvents(ctx)
+	}
+	return nil
+}
+
+type aggregate struct {
+	instanceID    string
+	aggregateType eventstore.AggregateType
+	aggregateID   string
+	sequence      uint64
+}
+
+func (a *Archiver) archiveAggregates(ctx context.Context) error {
+	aggregates, err := a.expiredAggregates(ctx)
+	if err != nil {
+		return err
+	}
+	var archived int
+	for _, agg := range aggregates {
+		ok, err := a.archiveAggregate(ctx, agg)
+		if err != nil {
+			logging.WithFields("instance", agg.instanceID, "aggregate_type", agg.aggregateType, "aggregate_id", agg.aggregateID).WithError(err).Warn("unable to archive aggregate")
+			continue
+		}
+		if ok {
+			archived++
+		}
+	}
+	logging.WithFields("count", archived).Info("aggregates archived")
+	return nil
+}
+
+func (a *Archiver) expiredAggregates(ctx context.Context) ([]*aggregate, error) {
+	aggregates := make([]*aggregate, 0, a.config.BulkLimit)
+	err := a.client.QueryContext(ctx,
+		func(rows *sql.Rows) error {
+			for rows.Next() {
+				agg := new(aggregate)
+				if err := rows.Scan(&agg.instanceID, &agg.aggregateType, &agg.aggregateID, &agg.sequence); err != nil {
+					return err
+				}
+				aggregates = append(aggregates, agg)
+			}
+			return rows.Err()
+		},
+		expiredAggregatesStmt,
+		database.TextArray[string](a.config.AggregateTypes),
+		a.now().Add(-a.config.RetentionPeriod),
+		a.config.BulkLimit,
+	)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHI-Wm3Kx", "Errors.Internal")
+	}
+	return aggregates, nil
+}
+
+// archiveAggregate moves all events of the aggregate to the archive and removes the unique constraints it still holds.
+// If the aggregate changed since it was selected it is not archived.
+func (a *Archiver) archiveAggregate(ctx context.Context, agg *aggregate) (_ bool, err error) {
+	events, err := a.es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(agg.instanceID).
+		OrderAsc().
+		AddQuery().
+		AggregateTypes(agg.aggregateType).
+		AggregateIDs(agg.aggregateID).
+		Builder(),
+	)
+	if err != nil {
+		return false, err
+	}
+	if len(events) == 0 || events[len(events)-1].Sequence() != agg.sequence {
+		return false, nil
+	}
+
+	tx, err := a.client.BeginTx(ctx, nil)
+	if err != nil {
+		return false, zerrors.ThrowInternal(err, "ARCHI-Bx9sE", "Errors.Internal")
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			logging.OnError(rollbackErr).Debug("unable to rollback tx")
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	var sequence uint64
+	if err = tx.QueryRowContext(ctx, aggregateSequenceStmt, agg.instanceID, agg.aggregateType, agg.aggregateID).Scan(&sequence); err != nil {
+		return false, zerrors.ThrowInternal(err, "ARCHI-Lq8fT", "Errors.Internal")
+	}
+	if sequence != agg.sequence {
+		return false, nil
+	}
+	if _, err = tx.ExecContext(ctx, archiveAggregateStmt, agg.instanceID, agg.aggregateType, agg.aggregateID); err != nil {
+		return false, zerrors.ThrowInternal(err, "ARCHI-y7Hd2", "Errors.Internal")
+	}
+	for _, constraint := range uniqueConstraints(events) {
+		if _, err = tx.ExecContext(ctx, deleteUniqueConstraintStmt, agg.instanceID, constraint.UniqueType, constraint.UniqueField); err != nil {
+			return false, zerrors.ThrowInternal(err, "ARCHI-e3Rnq", "Errors.Internal")
+		}
+	}
+	if _, err = tx.ExecContext(ctx, deleteAggregateStmt, agg.instanceID, agg.aggregateType, agg.aggregateID); err != nil {
+		return false, zerrors.ThrowInternal(err, "ARCHI-Ka2vP", "Errors.Internal")
+	}
+	return true, nil
+}
+
+type uniqueConstraintsProvider interface {
+	UniqueConstraints() []*eventstore.UniqueConstraint
+}
+
+// uniqueConstraints returns the unique constraints which were added by the events and not removed afterwards
+func uniqueConstraints(events []eventstore.Event) []*eventstore.UniqueConstraint {
+	constraints := make(map[string]*eventstore.UniqueConstraint)
+	keys := make([]string, 0)
+	for _, event := range events {
+		provider, ok := event.(uniqueConstraintsProvider)
+		if !ok {
+			continue
+		}
+		for _, constraint := range provider.UniqueConstraints() {
+			key := constraint.UniqueType + ":" + strings.ToLower(constraint.UniqueField)
+			switch constraint.Action {
+			case eventstore.UniqueConstraintAdd:
+				if _, ok := constraints[key]; !ok {
+					keys = append(keys, key)
+				}
+				constraints[key] = &eventstore.UniqueConstraint{
+					UniqueType:  constraint.UniqueType,
+					UniqueField: strings.ToLower(constraint.UniqueField),
+					Action:      eventstore.UniqueConstraintAdd,
+				}
+			case eventstore.UniqueConstraintRemove:
+				delete(constraints, key)
+			case eventstore.UniqueConstraintInstanceRemove:
+				clear(constraints)
+			}
+		}
+	}
+	remaining := make([]*eventstore.UniqueConstraint, 0, len(constraints))
+	for _, key := range keys {
+		if constraint, ok := constraints[key]; ok {
+			remaining = append(remaining, constraint)
+			// prevents duplicates if the constraint was added multiple times
+			delete(constraints, key)
+		}
+	}
+	return remaining
+}
+
+func (a *Archiver) archiveEvents(ctx context.Context) error {
+	result, err := a.client.ExecContext(ctx, archiveEventsStmt,
+		database.TextArray[string](a.config.EventTypes),
+		a.now().Add(-a.config.EventRetentionPeriod),
+		a.config.BulkLimit,
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "ARCHI-Vn4oE", "Errors.Internal")
+	}
+	archived, err := result.RowsAffected()
+	if err != nil {
+		return zerrors.ThrowInternal(err, "ARCHI-Z0cWp", "Errors.Internal")
+	}
+	logging.WithFields("count", archived).Info("events archived")
+	return nil
+}
@@ -0,0 +1,33 @@
+INSERT INTO eventstore.events2_archive (
+    instance_id
+    , aggregate_type
+    , aggregate_id
+    , event_type
+    , "sequence"
+    , revision
+    , created_at
+    , payload
+    , creator
+    , "owner"
+    , "position"
+    , in_tx_order
+) SELECT
+    instance_id
+    , aggregate_type
+    , aggregate_id
+    , event_type
+    , "sequence"
+    , revision
+    , created_at
+    , payload
+    , creator
+    , "owner"
+    , "position"
+    , in_tx_order
+FROM
+    eventstore.events2
+WHERE
+    instance_id = $1
+    AND aggregate_type = $2
+    AND aggregate_id = $3
+ON CONFLICT DO NOTHING
\ No newline at end of file
@@ -0,0 +1,53 @@
+-- moves events of the given types which are older than the retention
+-- and already processed by all projections of the instance
+-- the latest event of an aggregate is never moved because the sequence of the aggregate is based on it
+WITH archived AS (
+    DELETE FROM eventstore.events2
+    WHERE (instance_id, aggregate_type, aggregate_id, "sequence") IN (
+        SELECT
+            e.instance_id
+            , e.aggregate_type
+            , e.aggregate_id
+            , e."sequence"
+        FROM
+            eventstore.events2 e
+        WHERE
+            e.event_type = ANY($1)
+            AND e.created_at < $2
+            AND e."position" < (
+                SELECT MIN(s."position") FROM projections.current_states s WHERE s.instance_id = e.instance_id
+            )
+            AND e."sequence" < (
+                SELECT MAX(l."sequence") FROM eventstore.events2 l WHERE l.instance_id = e.instance_id AND l.aggregate_type = e.aggregate_type AND l.aggregate_id = e.aggregate_id
+            )
+        LIMIT $3
+    )
+    RETURNING
+        instance_id
+        , aggregate_type
+        , aggregate_id
+        , event_type
+        , "sequence"
+        , revision
+        , created_at
+        , payload
+        , creator
+        , "owner"
+        , "position"
+        , in_tx_order
+)
+INSERT INTO eventstore.events2_archive (
+    instance_id
+    , aggregate_type
+    , aggregate_id
+    , event_type
+    , "sequence"
+    , revision
+    , created_at
+    , payload
+    , creator
+    , "owner"
+    , "position"
+    , in_tx_order
+) SELECT * FROM archived
+ON CONFLICT DO NOTHING
\ No newline at end of file
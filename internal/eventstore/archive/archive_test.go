@@ -0,0 +1,134 @@
+package archive
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+type testEvent struct {
+	eventstore.Event
+	constraints []*eventstore.UniqueConstraint
+}
+
+func (e *testEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return e.constraints
+}
+
+func Test_uniqueConstraints(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []eventstore.Event
+		want   []*eventstore.UniqueConstraint
+	}{
+		{
+			name:   "no events",
+			events: nil,
+			want:   []*eventstore.UniqueConstraint{},
+		},
+		{
+			name: "added",
+			events: []eventstore.Event{
+				&testEvent{constraints: []*eventstore.UniqueConstraint{
+					eventstore.NewAddEventUniqueConstraint("type", "Field", "error"),
+				}},
+			},
+			want: []*eventstore.UniqueConstraint{
+				{UniqueType: "type", UniqueField: "field", Action: eventstore.UniqueConstraintAdd},
+			},
+		},
+		{
+			name: "added and removed",
+			events: []eventstore.Event{
+				&testEvent{constraints: []*eventstore.UniqueConstraint{
+					eventstore.NewAddEventUniqueConstraint("type", "field", "error"),
+					eventstore.NewAddEventUniqueConstraint("type", "other", "error"),
+				}},
+				&testEvent{constraints: []*eventstore.UniqueConstraint{
+					eventstore.NewRemoveUniqueConstraint("type", "Field"),
+				}},
+			},
+			want: []*eventstore.UniqueConstraint{
+				{UniqueType: "type", UniqueField: "other", Action: eventstore.UniqueConstraintAdd},
+			},
+		},
+		{
+			name: "removed and added again",
+			events: []eventstore.Event{
+				&testEvent{constraints: []*eventstore.UniqueConstraint{
+					eventstore.NewAddEventUniqueConstraint("type", "field", "error"),
+				}},
+				&testEvent{constraints: []*eventstore.UniqueConstraint{
+					eventstore.NewRemoveUniqueConstraint("type", "field"),
+				}},
+				&testEvent{constraints: []*eventstore.UniqueConstraint{
+					eventstore.NewAddEventUniqueConstraint("type", "field", "error"),
+				}},
+			},
+			want: []*eventstore.UniqueConstraint{
+				{UniqueType: "type", UniqueField: "field", Action: eventstore.UniqueConstraintAdd},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := uniqueConstraints(tt.events); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("uniqueConstraints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArchiver_archiveEvents(t *testing.T) {
+	now := time.Now()
+	config := &Config{
+		BulkLimit:            100,
+		EventRetentionPeriod: time.Hour,
+		EventTypes:           []string{"quota.notified"},
+	}
+	tests := []struct {
+		name    string
+		mock    *mock.SQLMock
+		wantErr bool
+	}{
+		{
+			name: "exec fails",
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(archiveEventsStmt,
+					mock.WithExecArgs(database.TextArray[string]{"quota.notified"}, now.Add(-time.Hour), uint16(100)),
+					mock.WithExecErr(errors.New("exec failed")),
+				),
+			),
+			wantErr: true,
+		},
+		{
+			name: "archived",
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(archiveEventsStmt,
+					mock.WithExecArgs(database.TextArray[string]{"quota.notified"}, now.Add(-time.Hour), uint16(100)),
+					mock.WithExecRowsAffected(driver.RowsAffected(5)),
+				),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Archiver{
+				config: config,
+				client: &database.DB{DB: tt.mock.DB},
+				now:    func() time.Time { return now },
+			}
+			if err := a.archiveEvents(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("archiveEvents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			tt.mock.Assert(t)
+		})
+	}
+}
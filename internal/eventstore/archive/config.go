@@ -0,0 +1,25 @@
+package archive
+
+import (
+	"time"
+)
+
+type Config struct {
+	Enabled bool
+	// Interval defines how often the archival job runs
+	Interval time.Duration
+	// BulkLimit limits the amount of aggregates and events moved per run
+	BulkLimit uint16
+	// RetentionPeriod defines how long the last event of an aggregate must be ago
+	// before the whole aggregate is archived.
+	// It must be longer than the lifetime of the aggregates (e.g. the refresh token expiration of oidc sessions)
+	RetentionPeriod time.Duration
+	// AggregateTypes are the types of the ephemeral aggregates which are archived as a whole
+	AggregateTypes []string
+	// EventRetentionPeriod defines how old events of EventTypes must be before they are archived.
+	// It must be longer than the longest period of the quotas
+	EventRetentionPeriod time.Duration
+	// EventTypes are the types of high volume events which are archived
+	// except the latest event of an aggregate
+	EventTypes []string
+}
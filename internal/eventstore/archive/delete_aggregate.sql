@@ -0,0 +1 @@
+DELETE FROM eventstore.events2 WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3
\ No newline at end of file
@@ -0,0 +1 @@
+DELETE FROM eventstore.unique_constraints WHERE instance_id = $1 AND unique_type = $2 AND unique_field = $3
\ No newline at end of file
@@ -0,0 +1,21 @@
+-- selects aggregates whose last event is older than the retention
+-- and already processed by all projections of the instance
+SELECT
+    e.instance_id
+    , e.aggregate_type
+    , e.aggregate_id
+    , MAX(e."sequence")
+FROM
+    eventstore.events2 e
+WHERE
+    e.aggregate_type = ANY($1)
+GROUP BY
+    e.instance_id
+    , e.aggregate_type
+    , e.aggregate_id
+HAVING
+    MAX(e.created_at) < $2
+    AND MAX(e."position") < (
+        SELECT MIN(s."position") FROM projections.current_states s WHERE s.instance_id = e.instance_id
+    )
+LIMIT $3
\ No newline at end of file
@@ -1,19 +1,34 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
 	_ "embed"
 	"time"
 
+	"github.com/zitadel/logging"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/metrics"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
+const (
+	// MaxFailureCountReachedCounter counts the events which were skipped by a projection
+	// because the configured MaxFailureCount was reached
+	MaxFailureCountReachedCounter            = "projection_events_max_failure_count_reached"
+	maxFailureCountReachedCounterDescription = "Events skipped by projections because the max failure count was reached"
+)
+
 var (
 	//go:embed failed_event_set.sql
 	setFailedEventStmt string
 	//go:embed failed_event_get_count.sql
 	failureCountStmt string
+	//go:embed failed_event_delete.sql
+	deleteFailedEventStmt string
 )
 
 type failure struct {
@@ -47,8 +62,13 @@ func failureFromStatement(statement *Statement, err error) *failure {
 	}
 }
 
+func registerFailureMetrics() {
+	err := metrics.RegisterCounter(MaxFailureCountReachedCounter, maxFailureCountReachedCounterDescription)
+	logging.WithFields("metric", MaxFailureCountReachedCounter).OnError(err).Panic("unable to register counter")
+}
+
 func (h *Handler) handleFailedStmt(tx *sql.Tx, f *failure) (shouldContinue bool) {
-	failureCount, err := h.failureCount(tx, f)
+	failureCount, skipped, err := h.failureCount(tx, f)
 	if err != nil {
 		h.logFailure(f).WithError(err).Warn("unable to get failure count")
 		return false
@@ -57,10 +77,27 @@ func (h *Handler) handleFailedStmt(tx *sql.Tx, f *failure) (shouldContinue bool)
 	err = h.setFailureCount(tx, failureCount, f)
 	h.logFailure(f).OnError(err).Warn("unable to update failure count")
 
+	if skipped {
+		h.logFailure(f).Info("event was marked as skipped")
+		return true
+	}
+	if failureCount == h.maxFailureCount {
+		h.reportMaxFailureCountReached(f)
+	}
 	return failureCount >= h.maxFailureCount
 }
 
-func (h *Handler) failureCount(tx *sql.Tx, f *failure) (count uint8, err error) {
+// reportMaxFailureCountReached is called once per failed event, as soon as the projection skips it
+func (h *Handler) reportMaxFailureCountReached(f *failure) {
+	h.logFailure(f).WithField("failure_count", h.maxFailureCount).WithError(f.err).Error("max failure count reached, event is skipped")
+	err := metrics.AddCount(context.Background(), MaxFailureCountReachedCounter, 1, map[string]attribute.Value{
+		"projection": attribute.StringValue(h.projection.Name()),
+		"instance":   attribute.StringValue(f.instance),
+	})
+	h.logFailure(f).OnError(err).Warn("unable to increment max failure count metric")
+}
+
+func (h *Handler) failureCount(tx *sql.Tx, f *failure) (count uint8, skipped bool, err error) {
 	row := tx.QueryRow(failureCountStmt,
 		h.projection.Name(),
 		f.instance,
@@ -69,12 +106,12 @@ func (h *Handler) failureCount(tx *sql.Tx, f *failure) (count uint8, err error)
 		f.sequence,
 	)
 	if err = row.Err(); err != nil {
-		return 0, zerrors.ThrowInternal(err, "CRDB-Unnex", "unable to update failure count")
+		return 0, false, zerrors.ThrowInternal(err, "CRDB-Unnex", "unable to update failure count")
 	}
-	if err = row.Scan(&count); err != nil {
-		return 0, zerrors.ThrowInternal(err, "CRDB-RwSMV", "unable to scan count")
+	if err = row.Scan(&count, &skipped); err != nil {
+		return 0, false, zerrors.ThrowInternal(err, "CRDB-RwSMV", "unable to scan count")
 	}
-	return count, nil
+	return count, skipped, nil
 }
 
 func (h *Handler) setFailureCount(tx *sql.Tx, count uint8, f *failure) error {
@@ -93,3 +130,101 @@ func (h *Handler) setFailureCount(tx *sql.Tx, count uint8, f *failure) error {
 	}
 	return nil
 }
+
+func (h *Handler) removeFailure(tx *sql.Tx, f *failure) error {
+	_, err := tx.Exec(deleteFailedEventStmt,
+		h.projection.Name(),
+		f.instance,
+		f.aggregateType,
+		f.aggregateID,
+		f.sequence,
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "CRDB-Pp3Ex", "remove failure failed")
+	}
+	return nil
+}
+
+// RetryFailedEvent reduces and executes a single failed event of the instance in ctx again.
+// The event must already be passed by the projection, either because the max failure count was reached
+// or because it was skipped. On success the failure is removed,
+// otherwise the failure count and error are updated.
+func (h *Handler) RetryFailedEvent(ctx context.Context, aggregateType eventstore.AggregateType, aggregateID string, sequence uint64) (err error) {
+	event, err := h.failedEvent(ctx, aggregateType, aggregateID, sequence)
+	if err != nil {
+		return err
+	}
+
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V2-3NQdu", "Errors.Internal")
+	}
+	// retryErr is returned after the updated failure is committed
+	var retryErr error
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			h.log().OnError(rollbackErr).Debug("unable to rollback tx")
+			return
+		}
+		if err = tx.Commit(); err == nil {
+			err = retryErr
+		}
+	}()
+
+	// locks the current state so the projection is not updated concurrently
+	currentState, err := h.currentState(ctx, tx, &triggerConfig{awaitRunning: true})
+	if err != nil {
+		return err
+	}
+	if event.Position() > currentState.position {
+		return zerrors.ThrowPreconditionFailed(nil, "V2-Ow7eL", "Errors.FailedEvent.NotProcessed")
+	}
+
+	f := failureFromEvent(event, nil)
+	statement, err := h.reduce(event)
+	if err == nil && statement.Execute != nil {
+		err = statement.Execute(tx, h.projection.Name())
+	}
+	if err != nil {
+		h.logEvent(event).WithError(err).Warn("retry of failed event failed")
+		f.err = err
+		count, _, countErr := h.failureCount(tx, f)
+		if countErr != nil {
+			return countErr
+		}
+		if countErr = h.setFailureCount(tx, count+1, f); countErr != nil {
+			return countErr
+		}
+		retryErr = zerrors.ThrowInternal(err, "V2-bH3wq", "Errors.FailedEvent.RetryFailed")
+		return nil
+	}
+	return h.removeFailure(tx, f)
+}
+
+func (h *Handler) failedEvent(ctx context.Context, aggregateType eventstore.AggregateType, aggregateID string, sequence uint64) (eventstore.Event, error) {
+	events, err := h.es.Filter(ctx, FailedEventQuery(ctx, aggregateType, aggregateID, sequence))
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 || events[0].Sequence() != sequence {
+		return nil, zerrors.ThrowNotFound(nil, "V2-Uo1Lk", "Errors.FailedEvent.EventNotFound")
+	}
+	return events[0], nil
+}
+
+// FailedEventQuery returns the query to load the event of a failure of the instance in ctx
+func FailedEventQuery(ctx context.Context, aggregateType eventstore.AggregateType, aggregateID string, sequence uint64) *eventstore.SearchQueryBuilder {
+	builder := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(authz.GetInstance(ctx).InstanceID()).
+		OrderAsc().
+		Limit(1)
+	if sequence > 0 {
+		builder = builder.SequenceGreater(sequence - 1)
+	}
+	return builder.
+		AddQuery().
+		AggregateTypes(aggregateType).
+		AggregateIDs(aggregateID).
+		Builder()
+}
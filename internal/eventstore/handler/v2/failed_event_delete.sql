@@ -0,0 +1,8 @@
+DELETE FROM projections.failed_events2
+WHERE
+    projection_name = $1
+    AND instance_id = $2
+    AND aggregate_type = $3
+    AND aggregate_id = $4
+    AND failed_sequence = $5
+;
\ No newline at end of file
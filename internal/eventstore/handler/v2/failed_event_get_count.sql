@@ -1,6 +1,7 @@
 WITH failures AS (
     SELECT 
-        failure_count 
+        failure_count
+        , skipped
     FROM 
         projections.failed_events2
     WHERE 
@@ -9,4 +10,6 @@ WITH failures AS (
         AND aggregate_type = $3
         AND aggregate_id = $4
         AND failed_sequence = $5
-) SELECT COALESCE((SELECT failure_count FROM failures), 0) AS failure_count
\ No newline at end of file
+) SELECT 
+    COALESCE((SELECT failure_count FROM failures), 0) AS failure_count
+    , COALESCE((SELECT skipped FROM failures), FALSE) AS skipped
\ No newline at end of file
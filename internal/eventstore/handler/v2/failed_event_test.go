@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database/mock"
+)
+
+func TestHandler_handleFailedStmt(t *testing.T) {
+	failedErr := errors.New("failed")
+	testTime := time.Now()
+	type fields struct {
+		projection      Projection
+		maxFailureCount uint8
+		mock            *mock.SQLMock
+	}
+	type args struct {
+		failure *failure
+	}
+	tests := []struct {
+		name               string
+		fields             fields
+		args               args
+		wantShouldContinue bool
+	}{
+		{
+			name: "count query fails",
+			fields: fields{
+				projection: &projection{
+					name: "projection",
+				},
+				maxFailureCount: 5,
+				mock: mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExpectQuery(failureCountStmt,
+						mock.WithQueryArgs("projection", "instance", "aggregate type", "aggregate id", uint64(42)),
+						mock.WithQueryErr(errors.New("query failed")),
+					),
+				),
+			},
+			args: args{
+				failure: &failure{
+					sequence:      42,
+					instance:      "instance",
+					aggregateID:   "aggregate id",
+					aggregateType: "aggregate type",
+					eventDate:     testTime,
+					err:           failedErr,
+				},
+			},
+			wantShouldContinue: false,
+		},
+		{
+			name: "max failure count not reached",
+			fields: fields{
+				projection: &projection{
+					name: "projection",
+				},
+				maxFailureCount: 5,
+				mock: mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExpectQuery(failureCountStmt,
+						mock.WithQueryArgs("projection", "instance", "aggregate type", "aggregate id", uint64(42)),
+						mock.WithQueryResult(
+							[]string{"failure_count", "skipped"},
+							[][]driver.Value{{uint8(1), false}},
+						),
+					),
+					mock.ExcpectExec(setFailedEventStmt,
+						mock.WithExecArgs("projection", "instance", "aggregate type", "aggregate id", testTime, uint64(42), uint8(2), "failed"),
+						mock.WithExecRowsAffected(1),
+					),
+				),
+			},
+			args: args{
+				failure: &failure{
+					sequence:      42,
+					instance:      "instance",
+					aggregateID:   "aggregate id",
+					aggregateType: "aggregate type",
+					eventDate:     testTime,
+					err:           failedErr,
+				},
+			},
+			wantShouldContinue: false,
+		},
+		{
+			name: "max failure count reached",
+			fields: fields{
+				projection: &projection{
+					name: "projection",
+				},
+				maxFailureCount: 5,
+				mock: mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExpectQuery(failureCountStmt,
+						mock.WithQueryArgs("projection", "instance", "aggregate type", "aggregate id", uint64(42)),
+						mock.WithQueryResult(
+							[]string{"failure_count", "skipped"},
+							[][]driver.Value{{uint8(4), false}},
+						),
+					),
+					mock.ExcpectExec(setFailedEventStmt,
+						mock.WithExecArgs("projection", "instance", "aggregate type", "aggregate id", testTime, uint64(42), uint8(5), "failed"),
+						mock.WithExecRowsAffected(1),
+					),
+				),
+			},
+			args: args{
+				failure: &failure{
+					sequence:      42,
+					instance:      "instance",
+					aggregateID:   "aggregate id",
+					aggregateType: "aggregate type",
+					eventDate:     testTime,
+					err:           failedErr,
+				},
+			},
+			wantShouldContinue: true,
+		},
+		{
+			name: "skipped",
+			fields: fields{
+				projection: &projection{
+					name: "projection",
+				},
+				maxFailureCount: 5,
+				mock: mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExpectQuery(failureCountStmt,
+						mock.WithQueryArgs("projection", "instance", "aggregate type", "aggregate id", uint64(42)),
+						mock.WithQueryResult(
+							[]string{"failure_count", "skipped"},
+							[][]driver.Value{{uint8(1), true}},
+						),
+					),
+					mock.ExcpectExec(setFailedEventStmt,
+						mock.WithExecArgs("projection", "instance", "aggregate type", "aggregate id", testTime, uint64(42), uint8(2), "failed"),
+						mock.WithExecRowsAffected(1),
+					),
+				),
+			},
+			args: args{
+				failure: &failure{
+					sequence:      42,
+					instance:      "instance",
+					aggregateID:   "aggregate id",
+					aggregateType: "aggregate type",
+					eventDate:     testTime,
+					err:           failedErr,
+				},
+			},
+			wantShouldContinue: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				projection:      tt.fields.projection,
+				maxFailureCount: tt.fields.maxFailureCount,
+			}
+
+			tx, err := tt.fields.mock.DB.BeginTx(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("unable to begin transaction: %v", err)
+			}
+
+			if gotShouldContinue := h.handleFailedStmt(tx, tt.args.failure); gotShouldContinue != tt.wantShouldContinue {
+				t.Errorf("Handler.handleFailedStmt() = %v, want %v", gotShouldContinue, tt.wantShouldContinue)
+			}
+			tt.fields.mock.Assert(t)
+		})
+	}
+}
@@ -83,6 +83,7 @@ func NewHandler(
 		}
 		aggregates[reducer.Aggregate] = eventTypes
 	}
+	registerFailureMetrics()
 
 	handler := &Handler{
 		projection:             projection,
@@ -334,6 +334,17 @@ func NewArrayAppendCol(column string, value interface{}) Column {
 	}
 }
 
+// NewIncrementCol adds the value to the current value of the column
+func NewIncrementCol(column string, value interface{}) Column {
+	return Column{
+		Name:  column,
+		Value: value,
+		ParameterOpt: func(placeholder string) string {
+			return column + " + " + placeholder
+		},
+	}
+}
+
 func NewArrayRemoveCol(column string, value interface{}) Column {
 	return Column{
 		Name:  column,
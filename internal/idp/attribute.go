@@ -0,0 +1,86 @@
+package idp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UserWithAttributes is an optional extension to the [User] interface.
+// It can be implemented to provide further attributes or claims of the federated user,
+// which are not part of the profile (e.g. the group memberships), so they can be mapped declaratively.
+type UserWithAttributes interface {
+	GetAttribute(name string) []string
+}
+
+// Attribute returns the values of the attribute or claim of the federated user.
+// It returns nil if the [User] does not implement [UserWithAttributes].
+func Attribute(user User, name string) []string {
+	if name == "" {
+		return nil
+	}
+	attributes, ok := user.(UserWithAttributes)
+	if !ok {
+		return nil
+	}
+	return attributes.GetAttribute(name)
+}
+
+// AttributeValues converts a (JSON) claim value into a list of strings.
+// Single values are returned as list with one entry, arrays are converted element wise.
+func AttributeValues(value any) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, element := range v {
+			values = append(values, AttributeValues(element)...)
+		}
+		return values
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case int:
+		return []string{strconv.Itoa(v)}
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+// ClaimValues returns the values of the claim found at the path.
+// The path is either the name of the claim or a dot separated path into nested objects (e.g. `address.country`).
+func ClaimValues(claims map[string]any, path string) []string {
+	if value, ok := claims[path]; ok {
+		return AttributeValues(value)
+	}
+	var current any = claims
+	for _, segment := range strings.Split(path, ".") {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = object[segment]
+	}
+	return AttributeValues(current)
+}
+
+// ClaimsMap converts the (JSON) representation of the claims (e.g. the userinfo) into a map,
+// so that standard and additional claims can be accessed by their name.
+func ClaimsMap(claims any) map[string]any {
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return nil
+	}
+	claimsMap := make(map[string]any)
+	if err := json.Unmarshal(data, &claimsMap); err != nil {
+		return nil
+	}
+	return claimsMap
+}
@@ -0,0 +1,93 @@
+package idp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributeValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  []string
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+		},
+		{
+			"string",
+			"admins",
+			[]string{"admins"},
+		},
+		{
+			"string list",
+			[]string{"admins", "users"},
+			[]string{"admins", "users"},
+		},
+		{
+			"json array",
+			[]any{"admins", float64(1), true},
+			[]string{"admins", "1", "true"},
+		},
+		{
+			"number",
+			float64(1.5),
+			[]string{"1.5"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AttributeValues(tt.value))
+		})
+	}
+}
+
+func TestClaimValues(t *testing.T) {
+	claims := map[string]any{
+		"given_name": "Gigi",
+		"groups":     []any{"admins", "users"},
+		"address": map[string]any{
+			"country": "CH",
+		},
+		"http://schemas.xmlsoap.org/claims/email": "gigi@example.com",
+	}
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{
+			"claim",
+			"given_name",
+			[]string{"Gigi"},
+		},
+		{
+			"list claim",
+			"groups",
+			[]string{"admins", "users"},
+		},
+		{
+			"nested claim",
+			"address.country",
+			[]string{"CH"},
+		},
+		{
+			"claim name with dots",
+			"http://schemas.xmlsoap.org/claims/email",
+			[]string{"gigi@example.com"},
+		},
+		{
+			"missing nested claim",
+			"given_name.country",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClaimValues(claims, tt.path))
+		})
+	}
+}
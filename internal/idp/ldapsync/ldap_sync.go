@@ -0,0 +1,113 @@
+package ldapsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	LDAPSyncProjectionTable = "projections.ldap_sync"
+	// SyncUserID is the editor of the changes made by the synchronization
+	SyncUserID = "LDAP-SYNC"
+)
+
+type Commands interface {
+	SyncLDAPIDP(ctx context.Context, idpID, resourceOwner string) (*domain.IDPLDAPSyncRun, error)
+}
+
+type Queries interface {
+	InstanceByID(ctx context.Context) (authz.Instance, error)
+	DueIDPLDAPSyncs(ctx context.Context, now time.Time) ([]string, error)
+}
+
+type ldapSync struct {
+	commands Commands
+	queries  Queries
+	now      func() time.Time
+}
+
+// Start runs the synchronization of all LDAP IDPs, which are due, every RequeueEvery of the handler
+func Start(ctx context.Context, customConfig projection.CustomConfig, commands Commands, queries Queries) {
+	NewLDAPSync(ctx, projection.ApplyCustomConfig(customConfig), commands, queries).Start(ctx)
+}
+
+func NewLDAPSync(
+	ctx context.Context,
+	handlerCfg handler.Config,
+	commands Commands,
+	queries Queries,
+) *handler.Handler {
+	sync := &ldapSync{
+		commands: commands,
+		queries:  queries,
+		now:      time.Now,
+	}
+	handlerCfg.TriggerWithoutEvents = sync.syncDueIDPs
+	return handler.NewHandler(ctx, &handlerCfg, sync)
+}
+
+func (s *ldapSync) Name() string {
+	return LDAPSyncProjectionTable
+}
+
+func (s *ldapSync) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{{
+		Aggregate: pseudo.AggregateType,
+		EventReducers: []handler.EventReducer{{
+			Event:  pseudo.ScheduledEventType,
+			Reduce: s.syncDueIDPs,
+		}},
+	}}
+}
+
+func (s *ldapSync) syncDueIDPs(event eventstore.Event) (*handler.Statement, error) {
+	scheduledEvent, ok := event.(*pseudo.ScheduledEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-ohB7u", "reduce.wrong.event.type %s", event.Type())
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		for _, instanceID := range scheduledEvent.InstanceIDs {
+			// a failing instance must not prevent the synchronization of the others
+			err := s.syncInstance(instanceID)
+			logging.WithFields("instance", instanceID).OnError(err).Warn("ldap sync failed")
+		}
+		return nil
+	}), nil
+}
+
+func (s *ldapSync) syncInstance(instanceID string) error {
+	ctx := authz.WithInstanceID(call.WithTimestamp(context.Background()), instanceID)
+	// the complete instance is needed, as users of instance IDPs are created in its default organization
+	instance, err := s.queries.InstanceByID(ctx)
+	if err != nil {
+		return err
+	}
+	ctx = authz.SetCtxData(authz.WithInstance(ctx, instance), authz.CtxData{UserID: SyncUserID})
+	idpIDs, err := s.queries.DueIDPLDAPSyncs(ctx, s.now())
+	if err != nil {
+		return err
+	}
+	for _, idpID := range idpIDs {
+		run, err := s.commands.SyncLDAPIDP(ctx, idpID, "")
+		if err != nil {
+			logging.WithFields("instance", instanceID, "idp", idpID).WithError(err).Warn("ldap sync of idp failed")
+			continue
+		}
+		if run.Error != "" || run.Failed > 0 {
+			logging.WithFields("instance", instanceID, "idp", idpID, "failed", run.Failed, "error", run.Error).Warn("ldap sync of idp incomplete")
+		}
+	}
+	return nil
+}
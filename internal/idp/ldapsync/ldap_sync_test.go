@@ -0,0 +1,109 @@
+package ldapsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+type syncCall struct {
+	instanceID string
+	idpID      string
+	userID     string
+}
+
+type testCommands struct {
+	calls   []syncCall
+	failing map[string]bool
+}
+
+func (c *testCommands) SyncLDAPIDP(ctx context.Context, idpID, _ string) (*domain.IDPLDAPSyncRun, error) {
+	c.calls = append(c.calls, syncCall{
+		instanceID: authz.GetInstance(ctx).InstanceID(),
+		idpID:      idpID,
+		userID:     authz.GetCtxData(ctx).UserID,
+	})
+	if c.failing[idpID] {
+		return nil, errors.New("sync failed")
+	}
+	return &domain.IDPLDAPSyncRun{IDPID: idpID}, nil
+}
+
+type testQueries struct {
+	now  time.Time
+	due  map[string][]string
+	fail bool
+}
+
+func (q *testQueries) InstanceByID(ctx context.Context) (authz.Instance, error) {
+	if q.fail {
+		return nil, errors.New("instance not found")
+	}
+	return authz.GetInstance(ctx), nil
+}
+
+func (q *testQueries) DueIDPLDAPSyncs(ctx context.Context, now time.Time) ([]string, error) {
+	if !now.Equal(q.now) {
+		return nil, errors.New("unexpected time")
+	}
+	return q.due[authz.GetInstance(ctx).InstanceID()], nil
+}
+
+func Test_ldapSync_syncInstance(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		commands   *testCommands
+		queries    *testQueries
+		wantErr    bool
+		wantCalled []syncCall
+	}{
+		{
+			name:     "instance not found, error",
+			commands: &testCommands{},
+			queries:  &testQueries{now: now, fail: true},
+			wantErr:  true,
+		},
+		{
+			name:     "no due idps",
+			commands: &testCommands{},
+			queries:  &testQueries{now: now},
+		},
+		{
+			name: "failing idp, other idps synced",
+			commands: &testCommands{
+				failing: map[string]bool{"idp1": true},
+			},
+			queries: &testQueries{
+				now: now,
+				due: map[string][]string{"instance1": {"idp1", "idp2"}},
+			},
+			wantCalled: []syncCall{
+				{instanceID: "instance1", idpID: "idp1", userID: SyncUserID},
+				{instanceID: "instance1", idpID: "idp2", userID: SyncUserID},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &ldapSync{
+				commands: tt.commands,
+				queries:  tt.queries,
+				now:      func() time.Time { return now },
+			}
+			err := s.syncInstance("instance1")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCalled, tt.commands.calls)
+		})
+	}
+}
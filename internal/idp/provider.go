@@ -3,6 +3,7 @@ package idp
 import (
 	"context"
 
+	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/domain"
@@ -18,6 +19,19 @@ type Provider interface {
 	IsAutoUpdate() bool
 }
 
+// ProviderSupportsRefresh is an optional extension to the Provider interface.
+// It can be implemented by providers issuing refresh tokens, so that stored tokens of a federated user can be renewed.
+type ProviderSupportsRefresh interface {
+	RefreshTokens(ctx context.Context, refreshToken string) (*oidc.Tokens[*oidc.IDTokenClaims], error)
+}
+
+// ProviderSupportsLogout is an optional extension to the Provider interface.
+// It can be implemented by providers, which allow to terminate the session of the user at the provider.
+// The hint identifies the session, e.g. the id_token (OIDC) or NameID (SAML) received on authentication.
+type ProviderSupportsLogout interface {
+	LogoutURL(ctx context.Context, hint, redirectURI, state string) (string, error)
+}
+
 // User contains the information of a federated user.
 type User interface {
 	GetID() string
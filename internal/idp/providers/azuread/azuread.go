@@ -157,6 +157,9 @@ type User struct {
 	LastName          string               `json:"surname"`
 	UserPrincipalName string               `json:"userPrincipalName"`
 	isEmailVerified   bool
+	// idTokenClaims are the claims of the id_token (e.g. `groups`),
+	// which are not returned by the user endpoint
+	idTokenClaims map[string]any
 }
 
 // GetID is an implementation of the [idp.User] interface.
@@ -235,3 +238,9 @@ func (u *User) GetProfile() string {
 func (u *User) GetAvatarURL() string {
 	return ""
 }
+
+// GetAttribute is an implementation of the [idp.UserWithAttributes] interface.
+// It returns the values of the claims of the id_token, e.g. `groups`.
+func (u *User) GetAttribute(name string) []string {
+	return idp.ClaimValues(u.idTokenClaims, name)
+}
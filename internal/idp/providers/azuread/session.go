@@ -1,11 +1,13 @@
 package azuread
 
 import (
+	"context"
 	"net/http"
 
 	httphelper "github.com/zitadel/oidc/v3/pkg/http"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 
+	"github.com/zitadel/zitadel/internal/idp"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 )
 
@@ -27,3 +29,23 @@ func (s *Session) RetrievePreviousID() (string, error) {
 	}
 	return userinfo.Subject, nil
 }
+
+// FetchUser extends the [oauth.Session] implementation by providing the claims of the id_token
+// (e.g. `groups`) as attributes of the [User], since they are not returned by the user endpoint.
+// The id_token was received directly from the token endpoint, so its signature is not verified again.
+func (s *Session) FetchUser(ctx context.Context) (idp.User, error) {
+	user, err := s.Session.FetchUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	azureUser, ok := user.(*User)
+	if !ok || s.Tokens == nil || s.Tokens.IDToken == "" {
+		return user, nil
+	}
+	claims := make(map[string]any)
+	if _, err = oidc.ParseToken(s.Tokens.IDToken, &claims); err != nil {
+		return nil, err
+	}
+	azureUser.idTokenClaims = claims
+	return azureUser, nil
+}
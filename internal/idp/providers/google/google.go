@@ -45,3 +45,9 @@ type User struct {
 func (u *User) GetPreferredUsername() string {
 	return string(u.GetEmail())
 }
+
+// GetAttribute implements the [idp.UserWithAttributes] interface
+// by returning the additional claims of the wrapped [idp.User].
+func (u *User) GetAttribute(name string) []string {
+	return idp.Attribute(u.User, name)
+}
@@ -138,3 +138,13 @@ func (u *User) GetAvatarURL() string {
 func (u *User) GetProfile() string {
 	return u.Profile
 }
+
+// GetAttribute is an implementation of the [idp.UserWithAttributes] interface.
+// It returns the values of standard and additional claims (e.g. `groups`) of the token,
+// nested values can be accessed by a dot separated path.
+func (u *User) GetAttribute(name string) []string {
+	if u.IDTokenClaims == nil {
+		return nil
+	}
+	return idp.ClaimValues(idp.ClaimsMap(u.IDTokenClaims), name)
+}
@@ -0,0 +1,118 @@
+package ldap
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// searchPagingSize is the number of entries requested per page when searching all users,
+	// it must not exceed the size limit of the directory (e.g. MaxPageSize of 1000 in Active Directory)
+	searchPagingSize = 500
+
+	// userAccountControlAttribute contains the flags of an Active Directory account
+	userAccountControlAttribute = "userAccountControl"
+	// userAccountControlDisabled is the ACCOUNTDISABLE flag of the userAccountControlAttribute
+	userAccountControlDisabled = 0x2
+)
+
+// SearchUsers returns all users of the directory below the searchBase (base DN of the provider if empty),
+// which match the user object classes of the provider and the optional filter (e.g. `(memberOf=cn=zitadel,dc=example,dc=com)`).
+// Entries without a value for the ID attribute are ignored, as they cannot be linked.
+func (p *Provider) SearchUsers(_ context.Context, searchBase, filter string) (users []*User, err error) {
+	if searchBase == "" {
+		searchBase = p.baseDN
+	}
+	for _, server := range p.servers {
+		users, err = p.searchUsers(server, searchBase, filter)
+		if err == nil {
+			return users, nil
+		}
+	}
+	return nil, err
+}
+
+func (p *Provider) searchUsers(server, searchBase, filter string) ([]*User, error) {
+	conn, err := getConnection(server, p.startTLS, p.timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.Bind(p.bindDN, p.bindPassword); err != nil {
+		return nil, err
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		searchBase,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(p.timeout.Seconds()), false,
+		usersSearchQuery(p.userObjectClasses, filter),
+		append(p.getNecessaryAttributes(), userAccountControlAttribute),
+		nil,
+	)
+	result, err := conn.SearchWithPaging(searchRequest, searchPagingSize)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*User, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		user, err := mapLDAPEntryToUser(
+			entry,
+			p.idAttribute,
+			p.firstNameAttribute,
+			p.lastNameAttribute,
+			p.displayNameAttribute,
+			p.nickNameAttribute,
+			p.preferredUsernameAttribute,
+			p.emailAttribute,
+			p.emailVerifiedAttribute,
+			p.phoneAttribute,
+			p.phoneVerifiedAttribute,
+			p.preferredLanguageAttribute,
+			p.avatarURLAttribute,
+			p.profileAttribute,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if user.ID == "" {
+			continue
+		}
+		user.Attributes = mapLDAPEntryAttributes(entry, p.additionalAttributes)
+		user.Disabled = isDisabled(entry)
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// IsValidFilter checks if the filter can be used to search the directory
+func IsValidFilter(filter string) bool {
+	_, err := ldap.CompileFilter(filter)
+	return err == nil
+}
+
+func usersSearchQuery(objectClasses []string, filter string) string {
+	queries := make([]string, 0, len(objectClasses)+1)
+	for _, class := range objectClasses {
+		queries = append(queries, objectClassesToSearchQuery([]string{class}))
+	}
+	if filter != "" {
+		queries = append(queries, filter)
+	}
+	if len(queries) == 0 {
+		return "(objectClass=*)"
+	}
+	return queriesAndToSearchQuery(queries...)
+}
+
+// isDisabled checks the ACCOUNTDISABLE flag of Active Directory accounts
+func isDisabled(entry *ldap.Entry) bool {
+	value := entry.GetAttributeValue(userAccountControlAttribute)
+	if value == "" {
+		return false
+	}
+	flags, err := strconv.ParseInt(value, 10, 64)
+	return err == nil && flags&userAccountControlDisabled != 0
+}
@@ -0,0 +1,84 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvider_usersSearchQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		objectClasses []string
+		filter        string
+		want          string
+	}{
+		{
+			name: "zero",
+			want: "(objectClass=*)",
+		},
+		{
+			name:          "one object class",
+			objectClasses: []string{"user"},
+			want:          "(objectClass=user)",
+		},
+		{
+			name:          "object classes",
+			objectClasses: []string{"user", "person"},
+			want:          "(&(objectClass=user)(objectClass=person))",
+		},
+		{
+			name:   "filter",
+			filter: "(memberOf=cn=zitadel,dc=example,dc=com)",
+			want:   "(memberOf=cn=zitadel,dc=example,dc=com)",
+		},
+		{
+			name:          "object classes and filter",
+			objectClasses: []string{"user", "person"},
+			filter:        "(memberOf=cn=zitadel,dc=example,dc=com)",
+			want:          "(&(objectClass=user)(objectClass=person)(memberOf=cn=zitadel,dc=example,dc=com))",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := usersSearchQuery(tt.objectClasses, tt.filter)
+			assert.Equal(t, tt.want, query)
+			assert.True(t, IsValidFilter(query))
+		})
+	}
+}
+
+func TestProvider_isDisabled(t *testing.T) {
+	tests := []struct {
+		name  string
+		value []string
+		want  bool
+	}{
+		{
+			name: "no userAccountControl",
+			want: false,
+		},
+		{
+			name:  "normal account",
+			value: []string{"512"},
+			want:  false,
+		},
+		{
+			name:  "disabled account",
+			value: []string{"514"},
+			want:  true,
+		},
+		{
+			name:  "invalid value",
+			value: []string{"disabled"},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := ldap.NewEntry("cn=user,dc=example,dc=com", map[string][]string{userAccountControlAttribute: tt.value})
+			assert.Equal(t, tt.want, isDisabled(entry))
+		})
+	}
+}
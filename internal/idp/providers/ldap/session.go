@@ -61,7 +61,7 @@ func (s *Session) FetchUser(_ context.Context) (_ idp.User, err error) {
 	}
 	s.Entry = user
 
-	return mapLDAPEntryToUser(
+	ldapUser, err := mapLDAPEntryToUser(
 		user,
 		s.Provider.idAttribute,
 		s.Provider.firstNameAttribute,
@@ -77,6 +77,11 @@ func (s *Session) FetchUser(_ context.Context) (_ idp.User, err error) {
 		s.Provider.avatarURLAttribute,
 		s.Provider.profileAttribute,
 	)
+	if err != nil {
+		return nil, err
+	}
+	ldapUser.Attributes = mapLDAPEntryAttributes(user, s.Provider.additionalAttributes)
+	return ldapUser, nil
 }
 
 func tryBind(
@@ -227,6 +232,19 @@ func userFiltersToSearchQuery(filters []string, username string) string {
 	return searchQuery
 }
 
+func mapLDAPEntryAttributes(user *ldap.Entry, attributes []string) map[string][]string {
+	if len(attributes) == 0 {
+		return nil
+	}
+	values := make(map[string][]string, len(attributes))
+	for _, attribute := range attributes {
+		if value := user.GetAttributeValues(attribute); len(value) > 0 {
+			values[attribute] = value
+		}
+	}
+	return values
+}
+
 func mapLDAPEntryToUser(
 	user *ldap.Entry,
 	idAttribute,
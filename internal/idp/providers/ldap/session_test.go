@@ -398,3 +398,35 @@ func TestProvider_mapLDAPEntryToUser(t *testing.T) {
 		})
 	}
 }
+
+func TestProvider_mapLDAPEntryAttributes(t *testing.T) {
+	entry := &ldap.Entry{
+		Attributes: []*ldap.EntryAttribute{
+			{Name: "id", Values: []string{"id"}},
+			{Name: "memberOf", Values: []string{"cn=admins,dc=example,dc=com", "cn=users,dc=example,dc=com"}},
+		},
+	}
+	tests := []struct {
+		name       string
+		attributes []string
+		want       map[string][]string
+	}{
+		{
+			name:       "no additional attributes",
+			attributes: nil,
+			want:       nil,
+		},
+		{
+			name:       "missing attribute ignored",
+			attributes: []string{"memberOf", "department"},
+			want: map[string][]string{
+				"memberOf": {"cn=admins,dc=example,dc=com", "cn=users,dc=example,dc=com"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mapLDAPEntryAttributes(entry, tt.attributes))
+		})
+	}
+}
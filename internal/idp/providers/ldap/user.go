@@ -20,6 +20,10 @@ type User struct {
 	PreferredLanguage language.Tag        `json:"preferredLanguage,omitempty"`
 	AvatarURL         string              `json:"avatarURL,omitempty"`
 	Profile           string              `json:"profile,omitempty"`
+	// Attributes contains the values of the additional attributes requested from the directory (e.g. memberOf)
+	Attributes map[string][]string `json:"attributes,omitempty"`
+	// Disabled is set for users disabled in the directory, it's only provided by [Provider.SearchUsers]
+	Disabled bool `json:"disabled,omitempty"`
 }
 
 func NewUser(
@@ -51,6 +55,8 @@ func NewUser(
 		preferredLanguage,
 		avatarURL,
 		profile,
+		nil,
+		false,
 	}
 }
 
@@ -93,3 +99,9 @@ func (u *User) GetAvatarURL() string {
 func (u *User) GetProfile() string {
 	return u.Profile
 }
+
+// GetAttribute is an implementation of the [idp.UserWithAttributes] interface.
+// It returns the values of the additional attributes (see [WithAdditionalAttributes]).
+func (u *User) GetAttribute(name string) []string {
+	return u.Attributes[name]
+}
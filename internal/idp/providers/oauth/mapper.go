@@ -108,3 +108,9 @@ func (u *UserMapper) GetAvatarURL() string {
 func (u *UserMapper) GetProfile() string {
 	return ""
 }
+
+// GetAttribute is an implementation of the [idp.UserWithAttributes] interface.
+// It returns the values of the `RawInfo`, nested values can be accessed by a dot separated path.
+func (u *UserMapper) GetAttribute(name string) []string {
+	return idp.ClaimValues(u.RawInfo, name)
+}
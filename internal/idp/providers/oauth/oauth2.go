@@ -10,7 +10,10 @@ import (
 	"github.com/zitadel/zitadel/internal/idp"
 )
 
-var _ idp.Provider = (*Provider)(nil)
+var (
+	_ idp.Provider                = (*Provider)(nil)
+	_ idp.ProviderSupportsRefresh = (*Provider)(nil)
+)
 
 // Provider is the [idp.Provider] implementation for a generic OAuth 2.0 provider
 type Provider struct {
@@ -109,6 +112,12 @@ func loginHint(hint string) rp.AuthURLOpt {
 	}
 }
 
+// RefreshTokens implements the [idp.ProviderSupportsRefresh] interface.
+// It uses the refresh_token grant to retrieve new tokens from the token endpoint.
+func (p *Provider) RefreshTokens(ctx context.Context, refreshToken string) (*oidc.Tokens[*oidc.IDTokenClaims], error) {
+	return rp.RefreshTokens[*oidc.IDTokenClaims](ctx, p.RelyingParty, refreshToken, "", "")
+}
+
 // IsLinkingAllowed implements the [idp.Provider] interface.
 func (p *Provider) IsLinkingAllowed() bool {
 	return p.isLinkingAllowed
@@ -2,6 +2,8 @@ package oidc
 
 import (
 	"context"
+	"errors"
+	"net/url"
 
 	"github.com/zitadel/oidc/v3/pkg/client/rp"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
@@ -10,7 +12,13 @@ import (
 	"github.com/zitadel/zitadel/internal/idp"
 )
 
-var _ idp.Provider = (*Provider)(nil)
+var (
+	_ idp.Provider                = (*Provider)(nil)
+	_ idp.ProviderSupportsRefresh = (*Provider)(nil)
+	_ idp.ProviderSupportsLogout  = (*Provider)(nil)
+)
+
+var ErrEndSessionNotSupported = errors.New("no end_session_endpoint provided")
 
 // Provider is the [idp.Provider] implementation for a generic OIDC provider
 type Provider struct {
@@ -160,6 +168,37 @@ func loginHint(hint string) rp.AuthURLOpt {
 	}
 }
 
+// RefreshTokens implements the [idp.ProviderSupportsRefresh] interface.
+// It uses the refresh_token grant to retrieve new tokens from the token endpoint.
+func (p *Provider) RefreshTokens(ctx context.Context, refreshToken string) (*oidc.Tokens[*oidc.IDTokenClaims], error) {
+	return rp.RefreshTokens[*oidc.IDTokenClaims](ctx, p.RelyingParty, refreshToken, "", "")
+}
+
+// LogoutURL implements the [idp.ProviderSupportsLogout] interface.
+// It returns the URL of the end_session_endpoint (RP-initiated logout) with the id_token as id_token_hint.
+// The user agent needs to be redirected to the URL, so that the provider can terminate its session.
+func (p *Provider) LogoutURL(_ context.Context, idToken, redirectURI, state string) (string, error) {
+	endpoint := p.RelyingParty.GetEndSessionEndpoint()
+	if endpoint == "" {
+		return "", ErrEndSessionNotSupported
+	}
+	logoutURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	query := logoutURL.Query()
+	query.Set("id_token_hint", idToken)
+	query.Set("client_id", p.RelyingParty.OAuthConfig().ClientID)
+	if redirectURI != "" {
+		query.Set("post_logout_redirect_uri", redirectURI)
+	}
+	if state != "" {
+		query.Set("state", state)
+	}
+	logoutURL.RawQuery = query.Encode()
+	return logoutURL.String(), nil
+}
+
 // IsLinkingAllowed implements the [idp.Provider] interface.
 func (p *Provider) IsLinkingAllowed() bool {
 	return p.isLinkingAllowed
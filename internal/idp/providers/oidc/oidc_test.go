@@ -191,3 +191,94 @@ func TestProvider_Options(t *testing.T) {
 		})
 	}
 }
+
+func TestProvider_LogoutURL(t *testing.T) {
+	type fields struct {
+		issuer   string
+		httpMock func(issuer string)
+	}
+	type args struct {
+		idToken     string
+		redirectURI string
+		state       string
+	}
+	type want struct {
+		url string
+		err error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   want
+	}{
+		{
+			name: "end session not supported",
+			fields: fields{
+				issuer: "https://issuer.com",
+				httpMock: func(issuer string) {
+					gock.New(issuer).
+						Get(oidc.DiscoveryEndpoint).
+						Reply(200).
+						JSON(&oidc.DiscoveryConfiguration{
+							Issuer:                issuer,
+							AuthorizationEndpoint: issuer + "/authorize",
+							TokenEndpoint:         issuer + "/token",
+							UserinfoEndpoint:      issuer + "/userinfo",
+						})
+				},
+			},
+			args: args{
+				idToken: "idToken",
+			},
+			want: want{
+				err: ErrEndSessionNotSupported,
+			},
+		},
+		{
+			name: "successful logout url",
+			fields: fields{
+				issuer: "https://issuer.com",
+				httpMock: func(issuer string) {
+					gock.New(issuer).
+						Get(oidc.DiscoveryEndpoint).
+						Reply(200).
+						JSON(&oidc.DiscoveryConfiguration{
+							Issuer:                issuer,
+							AuthorizationEndpoint: issuer + "/authorize",
+							TokenEndpoint:         issuer + "/token",
+							UserinfoEndpoint:      issuer + "/userinfo",
+							EndSessionEndpoint:    issuer + "/end_session",
+						})
+				},
+			},
+			args: args{
+				idToken:     "idToken",
+				redirectURI: "https://zitadel.cloud/idps/logout/callback",
+				state:       "state",
+			},
+			want: want{
+				url: "https://issuer.com/end_session?client_id=clientID&id_token_hint=idToken&post_logout_redirect_uri=https%3A%2F%2Fzitadel.cloud%2Fidps%2Flogout%2Fcallback&state=state",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			tt.fields.httpMock(tt.fields.issuer)
+			a := assert.New(t)
+			r := require.New(t)
+
+			provider, err := New("oidc", tt.fields.issuer, "clientID", "clientSecret", "redirectURI", []string{"openid"}, DefaultMapper)
+			r.NoError(err)
+
+			got, err := provider.LogoutURL(context.Background(), tt.args.idToken, tt.args.redirectURI, tt.args.state)
+			if tt.want.err != nil {
+				a.ErrorIs(err, tt.want.err)
+				return
+			}
+			r.NoError(err)
+			a.Equal(tt.want.url, got)
+		})
+	}
+}
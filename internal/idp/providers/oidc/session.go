@@ -121,3 +121,13 @@ func (u *User) GetAvatarURL() string {
 func (u *User) GetProfile() string {
 	return u.Profile
 }
+
+// GetAttribute is an implementation of the [idp.UserWithAttributes] interface.
+// It returns the values of standard and additional claims (e.g. `groups`) of the userinfo,
+// nested values can be accessed by a dot separated path.
+func (u *User) GetAttribute(name string) []string {
+	if u.UserInfo == nil {
+		return nil
+	}
+	return idp.ClaimValues(idp.ClaimsMap(u.UserInfo), name)
+}
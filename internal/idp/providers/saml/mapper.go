@@ -88,3 +88,9 @@ func (u *UserMapper) GetAvatarURL() string {
 func (u *UserMapper) GetProfile() string {
 	return ""
 }
+
+// GetAttribute is an implementation of the [idp.UserWithAttributes] interface.
+// It returns the values of the SAML attribute.
+func (u *UserMapper) GetAttribute(name string) []string {
+	return u.Attributes[name]
+}
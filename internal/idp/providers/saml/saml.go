@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/xml"
+	"errors"
 	"net/url"
 
 	"github.com/crewjam/saml"
@@ -15,7 +16,12 @@ import (
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
-var _ idp.Provider = (*Provider)(nil)
+var (
+	_ idp.Provider               = (*Provider)(nil)
+	_ idp.ProviderSupportsLogout = (*Provider)(nil)
+)
+
+var ErrSLONotSupported = errors.New("no SingleLogoutService with HTTP-Redirect binding provided")
 
 // Provider is the [idp.Provider] implementation for a generic SAML provider
 type Provider struct {
@@ -173,3 +179,21 @@ func (p *Provider) BeginAuth(ctx context.Context, state string, _ ...idp.Paramet
 		state:           state,
 	}, nil
 }
+
+// LogoutURL implements the [idp.ProviderSupportsLogout] interface.
+// It returns the URL of the SingleLogoutService (HTTP-Redirect binding) of the provider with a LogoutRequest for the NameID.
+// The provider will send the LogoutResponse to the SLO endpoint of the service provider metadata, so the redirectURI is ignored.
+func (p *Provider) LogoutURL(_ context.Context, nameID, _, relayState string) (string, error) {
+	sp, err := p.GetSP()
+	if err != nil {
+		return "", err
+	}
+	if sp.ServiceProvider.GetSLOBindingLocation(saml.HTTPRedirectBinding) == "" {
+		return "", ErrSLONotSupported
+	}
+	logoutURL, err := sp.ServiceProvider.MakeRedirectLogoutRequest(nameID, relayState)
+	if err != nil {
+		return "", err
+	}
+	return logoutURL.String(), nil
+}
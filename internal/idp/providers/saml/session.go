@@ -3,6 +3,7 @@ package saml
 import (
 	"bytes"
 	"context"
+	"errors"
 	"net/http"
 	"net/url"
 
@@ -22,6 +23,8 @@ type Session struct {
 
 	RequestID string
 	Request   *http.Request
+	// IDPInitiated accepts an unsolicited response, which was not requested by an intent (IdP-initiated login)
+	IDPInitiated bool
 
 	Assertion *saml.Assertion
 }
@@ -47,11 +50,15 @@ func (s *Session) GetAuth(ctx context.Context) (string, bool) {
 
 // FetchUser implements the [idp.Session] interface.
 func (s *Session) FetchUser(ctx context.Context) (user idp.User, err error) {
-	if s.RequestID == "" || s.Request == nil {
+	if s.Request == nil || (s.RequestID == "" && !s.IDPInitiated) {
 		return nil, zerrors.ThrowInvalidArgument(nil, "SAML-d09hy0wkex", "Errors.Intent.ResponseInvalid")
 	}
 
-	s.Assertion, err = s.ServiceProvider.ServiceProvider.ParseResponse(s.Request, []string{s.RequestID})
+	if s.IDPInitiated {
+		s.Assertion, err = s.parseUnsolicitedResponse()
+	} else {
+		s.Assertion, err = s.ServiceProvider.ServiceProvider.ParseResponse(s.Request, []string{s.RequestID})
+	}
 	if err != nil {
 		return nil, zerrors.ThrowInvalidArgument(err, "SAML-nuo0vphhh9", "Errors.Intent.ResponseInvalid")
 	}
@@ -70,6 +77,26 @@ func (s *Session) FetchUser(ctx context.Context) (user idp.User, err error) {
 	return userMapper, nil
 }
 
+// parseUnsolicitedResponse parses and validates a response without a request ID.
+// Only the parsing of this response allows IdP-initiated responses, the service provider itself still rejects them.
+// Responses to a request (with InResponseTo) are rejected, as they were not intended for an IdP-initiated login.
+func (s *Session) parseUnsolicitedResponse() (*saml.Assertion, error) {
+	serviceProvider := s.ServiceProvider.ServiceProvider
+	serviceProvider.AllowIDPInitiated = true
+	assertion, err := serviceProvider.ParseResponse(s.Request, nil)
+	if err != nil {
+		return nil, err
+	}
+	if assertion.Subject != nil {
+		for _, confirmation := range assertion.Subject.SubjectConfirmations {
+			if confirmation.SubjectConfirmationData != nil && confirmation.SubjectConfirmationData.InResponseTo != "" {
+				return nil, errors.New("unsolicited response must not be in response to a request")
+			}
+		}
+	}
+	return assertion, nil
+}
+
 type TempResponseWriter struct {
 	header  http.Header
 	content *bytes.Buffer
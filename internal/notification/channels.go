@@ -5,8 +5,10 @@ import (
 
 	"github.com/zitadel/logging"
 
-	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
-	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/email"
+	"github.com/zitadel/zitadel/internal/notification/channels/sms"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
 	"github.com/zitadel/zitadel/internal/notification/senders"
@@ -19,6 +21,8 @@ var _ types.ChannelChains = (*channels)(nil)
 type counters struct {
 	success deliveryMetrics
 	failed  deliveryMetrics
+	retried deliveryMetrics
+	expired deliveryMetrics
 }
 
 type deliveryMetrics struct {
@@ -30,9 +34,11 @@ type deliveryMetrics struct {
 type channels struct {
 	q        *handlers.NotificationQueries
 	counters counters
+	// quota is nil if the usage of notifications isn't limited
+	quota senders.UsageQuota
 }
 
-func newChannels(q *handlers.NotificationQueries) *channels {
+func newChannels(q *handlers.NotificationQueries, commands *command.Commands, quotaEnabled bool) *channels {
 	c := &channels{
 		q: q,
 		counters: counters{
@@ -46,6 +52,14 @@ func newChannels(q *handlers.NotificationQueries) *channels {
 				sms:   "failed_deliveries_sms",
 				json:  "failed_deliveries_json",
 			},
+			retried: deliveryMetrics{
+				email: "retried_deliveries_email",
+				sms:   "retried_deliveries_sms",
+			},
+			expired: deliveryMetrics{
+				email: "expired_deliveries_email",
+				sms:   "expired_deliveries_sms",
+			},
 		},
 	}
 	registerCounter(c.counters.success.email, "Successfully delivered emails")
@@ -54,44 +68,72 @@ func newChannels(q *handlers.NotificationQueries) *channels {
 	registerCounter(c.counters.failed.sms, "Failed SMS deliveries")
 	registerCounter(c.counters.success.json, "Successfully delivered JSON messages")
 	registerCounter(c.counters.failed.json, "Failed JSON message deliveries")
+	registerCounter(c.counters.retried.email, "Email deliveries scheduled for retry")
+	registerCounter(c.counters.retried.sms, "SMS deliveries scheduled for retry")
+	registerCounter(c.counters.expired.email, "Emails not delivered after the maximum number of attempts")
+	registerCounter(c.counters.expired.sms, "SMS not delivered after the maximum number of attempts")
+	if quotaEnabled {
+		c.quota = &notificationsQuota{queries: q, commands: commands}
+	}
 	return c
 }
 
+// limitByQuota enforces the notifications quota of the instance on the chain, if quotas are enabled
+func (c *channels) limitByQuota(ctx context.Context, chain *senders.Chain) *senders.Chain {
+	if c.quota == nil {
+		return chain
+	}
+	return senders.LimitByQuota(ctx, chain, c.quota)
+}
+
+func (c *channels) outboxMetrics() handlers.NotificationMetrics {
+	return handlers.NotificationMetrics{
+		Retried: map[domain.NotificationType]string{
+			domain.NotificationTypeEmail: c.counters.retried.email,
+			domain.NotificationTypeSms:   c.counters.retried.sms,
+		},
+		Failed: map[domain.NotificationType]string{
+			domain.NotificationTypeEmail: c.counters.expired.email,
+			domain.NotificationTypeSms:   c.counters.expired.sms,
+		},
+	}
+}
+
 func registerCounter(counter, desc string) {
 	err := metrics.RegisterCounter(counter, desc)
 	logging.WithFields("metric", counter).OnError(err).Panic("unable to register counter")
 }
 
-func (c *channels) Email(ctx context.Context) (*senders.Chain, *smtp.Config, error) {
-	smtpCfg, err := c.q.GetSMTPConfig(ctx)
+func (c *channels) Email(ctx context.Context) (*senders.Chain, *email.Config, error) {
+	emailCfg, err := c.q.GetActiveEmailConfig(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 	chain, err := senders.EmailChannels(
 		ctx,
-		smtpCfg,
+		emailCfg,
 		c.q.GetFileSystemProvider,
 		c.q.GetLogProvider,
 		c.counters.success.email,
 		c.counters.failed.email,
 	)
-	return chain, smtpCfg, err
+	return c.limitByQuota(ctx, chain), emailCfg, err
 }
 
-func (c *channels) SMS(ctx context.Context) (*senders.Chain, *twilio.Config, error) {
-	twilioCfg, err := c.q.GetTwilioConfig(ctx)
+func (c *channels) SMS(ctx context.Context) (*senders.Chain, *sms.Config, error) {
+	smsCfg, err := c.q.GetActiveSMSConfig(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 	chain, err := senders.SMSChannels(
 		ctx,
-		twilioCfg,
+		smsCfg,
 		c.q.GetFileSystemProvider,
 		c.q.GetLogProvider,
 		c.counters.success.sms,
 		c.counters.failed.sms,
 	)
-	return chain, twilioCfg, err
+	return c.limitByQuota(ctx, chain), smsCfg, err
 }
 
 func (c *channels) Webhook(ctx context.Context, cfg webhook.Config) (*senders.Chain, error) {
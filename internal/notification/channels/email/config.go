@@ -0,0 +1,13 @@
+package email
+
+import (
+	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+)
+
+// Config is the email provider of an instance,
+// either SMTPConfig or WebhookConfig is set
+type Config struct {
+	SMTPConfig    *smtp.Config
+	WebhookConfig *webhook.Config
+}
@@ -34,3 +34,9 @@ func addCount(ctx context.Context, metricName string, message channels.Message,
 	addCountErr := metrics.AddCount(ctx, metricName, 1, labels)
 	logging.WithFields("name", metricName, "labels", labels).OnError(addCountErr).Error("incrementing counter metric failed")
 }
+
+// CountMessage increments the counter metricName for the message,
+// it's used for counting events which are not visible to the channel itself, e.g. scheduled retries
+func CountMessage(ctx context.Context, metricName string, message channels.Message, err error) {
+	addCount(ctx, metricName, message, err)
+}
@@ -0,0 +1,13 @@
+package sms
+
+import (
+	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+)
+
+// Config is the active sms provider of an instance,
+// either TwilioConfig or WebhookConfig is set
+type Config struct {
+	TwilioConfig  *twilio.Config
+	WebhookConfig *webhook.Config
+}
@@ -0,0 +1,117 @@
+package smtp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginAuth_Next(t *testing.T) {
+	tests := []struct {
+		name       string
+		fromServer string
+		more       bool
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "username",
+			fromServer: "Username:",
+			more:       true,
+			want:       "user",
+		},
+		{
+			name:       "password",
+			fromServer: "Password:",
+			more:       true,
+			want:       "password",
+		},
+		{
+			name: "done",
+			more: false,
+		},
+		{
+			name:       "unknown challenge",
+			fromServer: "Unknown:",
+			more:       true,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoginAuth("user", "password").Next([]byte(tt.fromServer), tt.more)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestXOAuth2Auth_Start(t *testing.T) {
+	mechanism, initial, err := XOAuth2Auth("user@example.com", "token").Start(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "XOAUTH2", mechanism)
+	assert.Equal(t, "user=user@example.com\x01auth=Bearer token\x01\x01", string(initial))
+}
+
+func Test_xoauth2Token(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	config := &XOAuth2{
+		TokenEndpoint: server.URL,
+		ClientID:      "client",
+		Scopes:        []string{"smtp"},
+	}
+	for i := 0; i < 2; i++ {
+		token, err := xoauth2Token(config, "secret", time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, "access-token", token)
+	}
+	assert.Equal(t, 1, requests, "token must be reused until expired")
+}
+
+func Test_xoauth2TokenSources_get(t *testing.T) {
+	config := &XOAuth2{
+		TokenEndpoint: "https://login.example.com/token",
+		ClientID:      "client",
+	}
+	now := time.Now()
+	sources := &xoauth2TokenSources{sources: make(map[string]*xoauth2TokenSource)}
+
+	source := sources.get(config, "secret", 0, now)
+	assert.Same(t, source, sources.get(config, "secret", 0, now), "source must be reused")
+	assert.NotSame(t, source, sources.get(config, "changed", 0, now), "source must be replaced if the secret changes")
+	assert.Len(t, sources.sources, 1)
+
+	sources.get(&XOAuth2{TokenEndpoint: config.TokenEndpoint, ClientID: "other"}, "secret", 0, now)
+	assert.Len(t, sources.sources, 2)
+
+	sources.get(config, "changed", 0, now.Add(xoauth2IdleTimeout+time.Minute))
+	assert.Len(t, sources.sources, 1, "unused source must be removed")
+
+	sources.get(config, "changed", 0, now.Add(2*xoauth2IdleTimeout+time.Minute))
+	assert.Len(t, sources.sources, 1, "source in use must be kept")
+}
+
+func Test_xoauth2Token_timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	_, err := xoauth2Token(&XOAuth2{TokenEndpoint: server.URL, ClientID: "timeout"}, "secret", 10*time.Millisecond)
+	assert.Error(t, err)
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"net"
 	"net/smtp"
+	"time"
 
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/messages"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -90,10 +92,19 @@ func (smtpConfig SMTP) connectToSMTP(tlsRequired bool) (client *smtp.Client, err
 		return nil, zerrors.ThrowInternal(err, "EMAIL-spR56", "could not split host and port for connect to smtp")
 	}
 
-	if !tlsRequired {
-		client, err = smtpConfig.getSMPTClient()
-	} else {
-		client, err = smtpConfig.getSMPTClientWithTls(host)
+	switch smtpConfig.TLSMode {
+	case domain.SMTPTLSModeNone:
+		client, err = smtpConfig.getSMPTClient(host)
+	case domain.SMTPTLSModeStartTLS:
+		client, err = smtpConfig.getSMPTClientWithStartTls(host, true)
+	case domain.SMTPTLSModeImplicit:
+		client, err = smtpConfig.getSMPTClientWithImplicitTls(host)
+	default:
+		if !tlsRequired {
+			client, err = smtpConfig.getSMPTClient(host)
+		} else {
+			client, err = smtpConfig.getSMPTClientWithTls(host)
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -101,47 +112,83 @@ func (smtpConfig SMTP) connectToSMTP(tlsRequired bool) (client *smtp.Client, err
 
 	err = smtpConfig.smtpAuth(client, host)
 	if err != nil {
+		client.Close()
 		return nil, err
 	}
 	return client, nil
 }
 
-func (smtpConfig SMTP) getSMPTClient() (*smtp.Client, error) {
-	client, err := smtp.Dial(smtpConfig.Host)
+func (smtpConfig SMTP) dialer() *net.Dialer {
+	return &net.Dialer{Timeout: smtpConfig.Timeout}
+}
+
+// setDeadline limits the whole session with the server to the configured timeout
+func (smtpConfig SMTP) setDeadline(conn net.Conn) error {
+	if smtpConfig.Timeout <= 0 {
+		return nil
+	}
+	return conn.SetDeadline(time.Now().Add(smtpConfig.Timeout))
+}
+
+func (smtpConfig SMTP) newClient(conn net.Conn, host string) (*smtp.Client, error) {
+	if err := smtpConfig.setDeadline(conn); err != nil {
+		conn.Close()
+		return nil, zerrors.ThrowInternal(err, "EMAIL-Hw2k1", "could not set smtp timeout")
+	}
+	client, err := smtp.NewClient(conn, host)
 	if err != nil {
-		return nil, zerrors.ThrowInternal(err, "EMAIL-skwos", "could not make smtp dial")
+		conn.Close()
+		return nil, zerrors.ThrowInternal(err, "EMAIL-skwi4", "could not create smtp client")
 	}
 	return client, nil
 }
 
+func (smtpConfig SMTP) getSMPTClient(host string) (*smtp.Client, error) {
+	conn, err := smtpConfig.dialer().Dial("tcp", smtpConfig.Host)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EMAIL-skwos", "could not make smtp dial")
+	}
+	return smtpConfig.newClient(conn, host)
+}
+
 func (smtpConfig SMTP) getSMPTClientWithTls(host string) (*smtp.Client, error) {
-	conn, err := tls.Dial("tcp", smtpConfig.Host, &tls.Config{})
+	conn, err := tls.DialWithDialer(smtpConfig.dialer(), "tcp", smtpConfig.Host, &tls.Config{})
 
 	if errors.As(err, &tls.RecordHeaderError{}) {
 		logging.Log("MAIN-xKIzT").OnError(err).Warn("could not connect using normal tls. trying starttls instead...")
-		return smtpConfig.getSMPTClientWithStartTls(host)
+		return smtpConfig.getSMPTClientWithStartTls(host, false)
 	}
 
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "EMAIL-sl39s", "could not make tls dial")
 	}
+	return smtpConfig.newClient(conn, host)
+}
 
-	client, err := smtp.NewClient(conn, host)
+func (smtpConfig SMTP) getSMPTClientWithImplicitTls(host string) (*smtp.Client, error) {
+	conn, err := tls.DialWithDialer(smtpConfig.dialer(), "tcp", smtpConfig.Host, &tls.Config{ServerName: host})
 	if err != nil {
-		return nil, zerrors.ThrowInternal(err, "EMAIL-skwi4", "could not create smtp client")
+		return nil, zerrors.ThrowInternal(err, "EMAIL-Ls0cm", "could not make tls dial")
 	}
-	return client, err
+	return smtpConfig.newClient(conn, host)
 }
 
-func (smtpConfig SMTP) getSMPTClientWithStartTls(host string) (*smtp.Client, error) {
-	client, err := smtpConfig.getSMPTClient()
+// getSMPTClientWithStartTls upgrades the connection with STARTTLS,
+// if it is required the server has to announce the extension
+func (smtpConfig SMTP) getSMPTClientWithStartTls(host string, required bool) (*smtp.Client, error) {
+	client, err := smtpConfig.getSMPTClient(host)
 	if err != nil {
 		return nil, err
 	}
+	if ok, _ := client.Extension("STARTTLS"); !ok && required {
+		client.Close()
+		return nil, zerrors.ThrowInternal(nil, "EMAIL-Ps8qm", "smtp server does not support starttls")
+	}
 
 	if err := client.StartTLS(&tls.Config{
 		ServerName: host,
 	}); err != nil {
+		client.Close()
 		return nil, zerrors.ThrowInternal(err, "EMAIL-guvsQ", "could not start tls")
 	}
 	return client, nil
@@ -151,13 +198,36 @@ func (smtpConfig SMTP) smtpAuth(client *smtp.Client, host string) error {
 	if !smtpConfig.HasAuth() {
 		return nil
 	}
-	// Auth
-	auth := unencryptedAuth{
-		smtp.PlainAuth("", smtpConfig.User, smtpConfig.Password, host),
+	// the access token must not be sent unencrypted
+	if _, ok := client.TLSConnectionState(); !ok && smtpConfig.AuthMechanism == domain.SMTPAuthMechanismXOAuth2 {
+		return zerrors.ThrowInternal(nil, "EMAIL-Xo2tl", "xoauth2 requires a tls connection")
 	}
-	err := client.Auth(auth)
+	auth, err := smtpConfig.auth(host)
+	if err != nil {
+		return err
+	}
+	err = client.Auth(auth)
 	if err != nil {
 		return zerrors.ThrowInternalf(err, "EMAIL-s9kfs", "could not add smtp auth for user %s", smtpConfig.User)
 	}
 	return nil
 }
+
+func (smtpConfig SMTP) auth(host string) (smtp.Auth, error) {
+	switch smtpConfig.AuthMechanism {
+	case domain.SMTPAuthMechanismLogin:
+		return LoginAuth(smtpConfig.User, smtpConfig.Password), nil
+	case domain.SMTPAuthMechanismCRAMMD5:
+		return smtp.CRAMMD5Auth(smtpConfig.User, smtpConfig.Password), nil
+	case domain.SMTPAuthMechanismXOAuth2:
+		token, err := xoauth2Token(smtpConfig.XOAuth2, smtpConfig.Password, smtpConfig.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		return XOAuth2Auth(smtpConfig.User, token), nil
+	default:
+		return unencryptedAuth{
+			smtp.PlainAuth("", smtpConfig.User, smtpConfig.Password, host),
+		}, nil
+	}
+}
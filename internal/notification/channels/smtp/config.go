@@ -1,5 +1,11 @@
 package smtp
 
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
 type Config struct {
 	SMTP           SMTP
 	Tls            bool
@@ -12,8 +18,26 @@ type SMTP struct {
 	Host     string
 	User     string
 	Password string
+	// AuthMechanism defaults to PLAIN,
+	// for XOAUTH2 the Password is the secret of the client
+	AuthMechanism domain.SMTPAuthMechanism
+	XOAuth2       *XOAuth2
+	// TLSMode overrides the Tls flag of the [Config] if specified
+	TLSMode domain.SMTPTLSMode
+	// Timeout limits the connection and the whole delivery of a message, no limit is set if zero
+	Timeout time.Duration
+}
+
+// XOAuth2 are the settings of the client used to request the access token for the XOAUTH2 authentication
+type XOAuth2 struct {
+	TokenEndpoint string
+	ClientID      string
+	Scopes        []string
 }
 
 func (smtp *SMTP) HasAuth() bool {
+	if smtp.AuthMechanism == domain.SMTPAuthMechanismXOAuth2 {
+		return smtp.User != "" && smtp.XOAuth2 != nil
+	}
 	return smtp.User != "" && smtp.Password != ""
 }
@@ -0,0 +1,35 @@
+package smtp
+
+import (
+	"net/smtp"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type loginAuth struct {
+	username, password string
+}
+
+// LoginAuth returns an Auth that implements the non-standardised LOGIN mechanism,
+// which is still required by some providers instead of PLAIN
+func LoginAuth(username, password string) smtp.Auth {
+	return &loginAuth{username: username, password: password}
+}
+
+func (a *loginAuth) Start(_ *smtp.ServerInfo) (string, []byte, error) {
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch string(fromServer) {
+	case "Username:", "User Name\x00":
+		return []byte(a.username), nil
+	case "Password:", "Password\x00":
+		return []byte(a.password), nil
+	default:
+		return nil, zerrors.ThrowInternalf(nil, "EMAIL-Kd93n", "unexpected server challenge %q", fromServer)
+	}
+}
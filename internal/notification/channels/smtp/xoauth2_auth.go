@@ -0,0 +1,109 @@
+package smtp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// xoauth2DefaultTimeout limits the token requests if no timeout is configured for the SMTP connection
+	xoauth2DefaultTimeout = 30 * time.Second
+	// xoauth2IdleTimeout is the time after which unused token sources are removed,
+	// e.g. because the configuration was changed or removed
+	xoauth2IdleTimeout = time.Hour
+)
+
+type xoauth2Auth struct {
+	username, token string
+}
+
+// XOAuth2Auth returns an Auth that implements the XOAUTH2 mechanism,
+// which authenticates the user with an OAuth 2.0 access token
+func XOAuth2Auth(username, token string) smtp.Auth {
+	return &xoauth2Auth{username: username, token: token}
+}
+
+func (a *xoauth2Auth) Start(_ *smtp.ServerInfo) (string, []byte, error) {
+	return "XOAUTH2", []byte("user=" + a.username + "\x01auth=Bearer " + a.token + "\x01\x01"), nil
+}
+
+func (a *xoauth2Auth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if more {
+		// the server sends an error as JSON challenge and expects an empty response
+		return []byte{}, nil
+	}
+	return nil, nil
+}
+
+// tokenSources caches the token sources of the clients,
+// so the access tokens are reused until they are expired and refreshed afterwards
+var tokenSources = &xoauth2TokenSources{sources: make(map[string]*xoauth2TokenSource)}
+
+type xoauth2TokenSources struct {
+	mu      sync.Mutex
+	sources map[string]*xoauth2TokenSource
+}
+
+type xoauth2TokenSource struct {
+	oauth2.TokenSource
+	// settings are the settings of the client which are not part of the key,
+	// the source is replaced if they change
+	settings string
+	lastUsed time.Time
+}
+
+func xoauth2Token(config *XOAuth2, clientSecret string, timeout time.Duration) (string, error) {
+	source := tokenSources.get(config, clientSecret, timeout, time.Now())
+	token, err := source.Token()
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "EMAIL-Ow3nd", "could not get access token for xoauth2")
+	}
+	return token.AccessToken, nil
+}
+
+// get returns the cached token source of the client or creates a new one if the settings changed.
+// Sources which were not used for the [xoauth2IdleTimeout] are removed.
+func (s *xoauth2TokenSources) get(config *XOAuth2, clientSecret string, timeout time.Duration, now time.Time) oauth2.TokenSource {
+	if timeout <= 0 {
+		timeout = xoauth2DefaultTimeout
+	}
+	key := strings.Join([]string{config.TokenEndpoint, config.ClientID, strings.Join(config.Scopes, " ")}, "|")
+	secretHash := sha256.Sum256([]byte(clientSecret))
+	settings := hex.EncodeToString(secretHash[:]) + "|" + timeout.String()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, source := range s.sources {
+		if now.Sub(source.lastUsed) > xoauth2IdleTimeout {
+			delete(s.sources, k)
+		}
+	}
+	source, ok := s.sources[key]
+	if !ok || source.settings != settings {
+		// the context is used for all token requests of the source
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: timeout})
+		source = &xoauth2TokenSource{
+			TokenSource: (&clientcredentials.Config{
+				ClientID:     config.ClientID,
+				ClientSecret: clientSecret,
+				TokenURL:     config.TokenEndpoint,
+				Scopes:       config.Scopes,
+			}).TokenSource(ctx),
+			settings: settings,
+		}
+		s.sources[key] = source
+	}
+	source.lastUsed = now
+	return source
+}
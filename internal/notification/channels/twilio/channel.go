@@ -4,11 +4,14 @@ import (
 	"github.com/kevinburke/twilio-go"
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/messages"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
+const whatsAppPrefix = "whatsapp:"
+
 func InitChannel(config Config) channels.NotificationChannel {
 	client := twilio.NewClient(config.SID, config.Token, nil)
 
@@ -23,7 +26,12 @@ func InitChannel(config Config) channels.NotificationChannel {
 		if err != nil {
 			return err
 		}
-		m, err := client.Messages.SendMessage(twilioMsg.SenderPhoneNumber, twilioMsg.RecipientPhoneNumber, content, nil)
+		from, to := twilioMsg.SenderPhoneNumber, twilioMsg.RecipientPhoneNumber
+		if twilioMsg.Channel == domain.PhoneChannelWhatsApp.String() {
+			// twilio routes the message over whatsapp if both numbers are prefixed with the channel
+			from, to = whatsAppPrefix+from, whatsAppPrefix+to
+		}
+		m, err := client.Messages.SendMessage(from, to, content, nil)
 		if err != nil {
 			return zerrors.ThrowInternal(err, "TWILI-osk3S", "could not send message")
 		}
@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -23,11 +24,7 @@ func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel,
 	return channels.HandleMessageFunc(func(message channels.Message) error {
 		requestCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 		defer cancel()
-		msg, ok := message.(*messages.JSON)
-		if !ok {
-			return zerrors.ThrowInternal(nil, "WEBH-K686U", "message is not JSON")
-		}
-		payload, err := msg.GetContent()
+		payload, err := messagePayload(message)
 		if err != nil {
 			return err
 		}
@@ -36,9 +33,12 @@ func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel,
 			return err
 		}
 		if cfg.Headers != nil {
-			req.Header = cfg.Headers
+			req.Header = cfg.Headers.Clone()
 		}
 		req.Header.Set("Content-Type", "application/json")
+		if cfg.SigningKey != "" {
+			req.Header.Set(SignatureHeader, Sign([]byte(payload), cfg.SigningKey, time.Now()))
+		}
 		resp, err := http.DefaultClient.Do(req)
 		if err != nil {
 			return err
@@ -53,3 +53,20 @@ func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel,
 		return nil
 	}), nil
 }
+
+// messagePayload returns the JSON of the message,
+// rendered emails and sms are serialized as a whole
+func messagePayload(message channels.Message) (string, error) {
+	switch msg := message.(type) {
+	case *messages.JSON:
+		return msg.GetContent()
+	case *messages.Email, *messages.SMS:
+		payload, err := json.Marshal(msg)
+		if err != nil {
+			return "", zerrors.ThrowInternal(err, "WEBH-Ju4ef", "unable to serialize message")
+		}
+		return string(payload), nil
+	default:
+		return "", zerrors.ThrowInternal(nil, "WEBH-K686U", "message is not JSON")
+	}
+}
@@ -9,6 +9,8 @@ type Config struct {
 	CallURL string
 	Method  string
 	Headers http.Header
+	// SigningKey is used to sign the payload of the request, the signature is sent in the [SignatureHeader]
+	SigningKey string
 }
 
 func (w *Config) Validate() error {
@@ -0,0 +1,23 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+const SignatureHeader = "ZITADEL-Signature"
+
+// Sign computes the value of the [SignatureHeader] of a payload.
+// The value consists of the unix timestamp and the hex encoded HMAC-SHA256 of the timestamp and payload separated by a dot:
+// t=<timestamp>,v1=<hmac(key, timestamp + "." + payload)>
+func Sign(payload []byte, key string, timestamp time.Time) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	type args struct {
+		payload   []byte
+		key       string
+		timestamp time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "signed",
+			args: args{
+				payload:   []byte(`{"content":"message"}`),
+				key:       "key",
+				timestamp: time.Unix(1700000000, 0),
+			},
+			want: "t=1700000000,v1=6b6284410f28a2e12ed02739144be622f00bc4cf605d0341e27d8124658fb75f",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.args.payload, tt.args.key, tt.args.timestamp); got != tt.want {
+				t.Errorf("Sign() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
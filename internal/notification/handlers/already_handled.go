@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
 )
 
 func (n *NotificationQueries) IsAlreadyHandled(ctx context.Context, event eventstore.Event, data map[string]interface{}, aggregateType eventstore.AggregateType, eventTypes ...eventstore.EventType) (bool, error) {
@@ -24,3 +25,33 @@ func (n *NotificationQueries) IsAlreadyHandled(ctx context.Context, event events
 	}
 	return len(events) > 0, nil
 }
+
+// IsSecurityNotificationSent checks if the user was already notified about the event.
+// The triggeringAggregateID must be set, if the event is not an event of the user (e.g. of a session).
+func (n *NotificationQueries) IsSecurityNotificationSent(ctx context.Context, event eventstore.Event, userID, triggeringAggregateID, messageType string) (bool, error) {
+	data := map[string]interface{}{
+		"messageType":             messageType,
+		"triggeringEventSequence": event.Sequence(),
+	}
+	if triggeringAggregateID == "" {
+		return n.IsAlreadyHandled(ctx, event, data, user.AggregateType, user.UserSecurityNotificationSentType)
+	}
+	// the sequence of the user can't be compared with the one of the triggering aggregate
+	data["triggeringAggregateId"] = triggeringAggregateID
+	events, err := n.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			Limit(1).
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(userID).
+			EventTypes(user.UserSecurityNotificationSentType).
+			EventData(data).
+			Builder(),
+	)
+	if err != nil {
+		return false, err
+	}
+	return len(events) > 0, nil
+}
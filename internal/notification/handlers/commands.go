@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"context"
+	"time"
 
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/repository/milestone"
 	"github.com/zitadel/zitadel/internal/repository/quota"
 )
@@ -15,10 +18,19 @@ type Commands interface {
 	HumanOTPEmailCodeSent(ctx context.Context, userID, resourceOwner string) error
 	OTPSMSSent(ctx context.Context, sessionID, resourceOwner string) error
 	OTPEmailSent(ctx context.Context, sessionID, resourceOwner string) error
+	MagicLinkSent(ctx context.Context, sessionID, resourceOwner string) error
+	HumanMagicLinkCodeSent(ctx context.Context, userID, resourceOwner string) error
+	HumanPhoneLoginCodeSent(ctx context.Context, userID, resourceOwner string) error
+	HumanEmailChangeRevertCodeSent(ctx context.Context, userID, resourceOwner string) error
 	UserDomainClaimedSent(ctx context.Context, orgID, userID string) error
 	HumanPasswordlessInitCodeSent(ctx context.Context, userID, resourceOwner, codeID string) error
 	PasswordChangeSent(ctx context.Context, orgID, userID string) error
+	SecurityNotificationSent(ctx context.Context, orgID, userID, messageType, triggeringAggregateID string, triggeringEventSequence uint64) error
 	HumanPhoneVerificationCodeSent(ctx context.Context, orgID, userID string) error
 	UsageNotificationSent(ctx context.Context, dueEvent *quota.NotificationDueEvent) error
 	MilestonePushed(ctx context.Context, msType milestone.Type, endpoints []string, primaryDomain string) error
+	RequestNotification(ctx context.Context, resourceOwner string, channel domain.NotificationType, recipient string, message channels.Message) (string, error)
+	NotificationSent(ctx context.Context, id, resourceOwner string) error
+	NotificationRetryRequested(ctx context.Context, id, resourceOwner string, sendErr error, notBefore time.Time) error
+	NotificationFailed(ctx context.Context, id, resourceOwner string, sendErr error) error
 }
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/notification/channels/email"
+	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// GetActiveEmailConfig reads the email provider config of the organization in the context if one is set,
+// otherwise the active iam email provider config, which is either an SMTP or an HTTP provider
+func (n *NotificationQueries) GetActiveEmailConfig(ctx context.Context) (*email.Config, error) {
+	config, err := n.emailConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if config.HTTPConfig != nil {
+		signingKey, err := decryptSigningKey(config.HTTPConfig.SigningKey, n.SMTPPasswordCrypto)
+		if err != nil {
+			return nil, err
+		}
+		return &email.Config{
+			WebhookConfig: &webhook.Config{
+				CallURL:    config.HTTPConfig.Endpoint,
+				Method:     http.MethodPost,
+				Headers:    config.HTTPConfig.Headers,
+				SigningKey: signingKey,
+			},
+		}, nil
+	}
+	password, err := crypto.DecryptString(config.Password, n.SMTPPasswordCrypto)
+	if err != nil {
+		return nil, err
+	}
+	return &email.Config{
+		SMTPConfig: &smtp.Config{
+			From:           config.SenderAddress,
+			FromName:       config.SenderName,
+			ReplyToAddress: config.ReplyToAddress,
+			Tls:            config.TLS,
+			SMTP: smtp.SMTP{
+				Host:          config.Host,
+				User:          config.User,
+				Password:      password,
+				AuthMechanism: config.AuthMechanism,
+				XOAuth2:       smtpXOAuth2(config.XOAuth2),
+				TLSMode:       config.TLSMode,
+				Timeout:       config.Timeout,
+			},
+		},
+	}, nil
+}
+
+func smtpXOAuth2(config *query.SMTPXOAuth2) *smtp.XOAuth2 {
+	if config == nil {
+		return nil
+	}
+	return &smtp.XOAuth2{
+		TokenEndpoint: config.TokenEndpoint,
+		ClientID:      config.ClientID,
+		Scopes:        config.Scopes,
+	}
+}
+
+func (n *NotificationQueries) emailConfig(ctx context.Context) (*query.SMTPConfig, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	if orgID := authz.GetCtxData(ctx).OrgID; orgID != "" {
+		providers, err := n.OrgNotificationProviders(ctx, orgID)
+		if err != nil && !zerrors.IsNotFound(err) {
+			return nil, err
+		}
+		if providers != nil && providers.SMTPConfigID != "" {
+			config, err := n.SMTPConfigByID(ctx, instanceID, providers.SMTPConfigID)
+			if err == nil {
+				return config, nil
+			}
+			if !zerrors.IsNotFound(err) {
+				return nil, err
+			}
+			logging.WithFields("org", orgID, "smtp_config", providers.SMTPConfigID).Warn("smtp config of organization not found, active config is used")
+		}
+	}
+	return n.SMTPConfigActive(ctx, instanceID)
+}
+
+func decryptSigningKey(signingKey *crypto.CryptoValue, alg crypto.EncryptionAlgorithm) (string, error) {
+	if signingKey == nil {
+		return "", nil
+	}
+	return crypto.DecryptString(signingKey, alg)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/sms"
+	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// GetActiveSMSConfig reads the SMS provider config of the organization in the context if one is set,
+// otherwise the active iam SMS provider config, which is either a Twilio or an HTTP provider
+func (n *NotificationQueries) GetActiveSMSConfig(ctx context.Context) (*sms.Config, error) {
+	config, err := n.smsConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if config.TwilioConfig != nil {
+		token, err := crypto.DecryptString(config.TwilioConfig.Token, n.SMSTokenCrypto)
+		if err != nil {
+			return nil, err
+		}
+		return &sms.Config{
+			TwilioConfig: &twilio.Config{
+				SID:          config.TwilioConfig.SID,
+				Token:        token,
+				SenderNumber: config.TwilioConfig.SenderNumber,
+			},
+		}, nil
+	}
+	if config.HTTPConfig != nil {
+		signingKey, err := decryptSigningKey(config.HTTPConfig.SigningKey, n.SMSTokenCrypto)
+		if err != nil {
+			return nil, err
+		}
+		return &sms.Config{
+			WebhookConfig: &webhook.Config{
+				CallURL:    config.HTTPConfig.Endpoint,
+				Method:     http.MethodPost,
+				Headers:    config.HTTPConfig.Headers,
+				SigningKey: signingKey,
+			},
+		}, nil
+	}
+	return nil, zerrors.ThrowNotFound(nil, "HANDLER-8nfow", "Errors.SMS.Twilio.NotFound")
+}
+
+func (n *NotificationQueries) smsConfig(ctx context.Context) (*query.SMSConfig, error) {
+	if orgID := authz.GetCtxData(ctx).OrgID; orgID != "" {
+		providers, err := n.OrgNotificationProviders(ctx, orgID)
+		if err != nil && !zerrors.IsNotFound(err) {
+			return nil, err
+		}
+		if providers != nil && providers.SMSConfigID != "" {
+			config, err := n.SMSProviderConfigByID(ctx, providers.SMSConfigID)
+			if err == nil {
+				return config, nil
+			}
+			if !zerrors.IsNotFound(err) {
+				return nil, err
+			}
+			logging.WithFields("org", orgID, "sms_config", providers.SMSConfigID).Warn("sms config of organization not found, active config is used")
+		}
+	}
+	active, err := query.NewSMSProviderStateQuery(domain.SMSConfigStateActive)
+	if err != nil {
+		return nil, err
+	}
+	return n.SMSProviderConfig(ctx, active)
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// MailTemplate returns the activated template of the organization for the message type
+// in the preferred language of the user or else in the default language of the instance,
+// if there is none the template of the mail template policy is returned
+func (n *NotificationQueries) MailTemplate(ctx context.Context, orgID, messageType string, user *query.NotifyUser) (string, error) {
+	messages, err := n.MailTemplateMessagesByOrg(ctx, orgID, messageType)
+	if err != nil {
+		return "", err
+	}
+	storeKey := activeMailTemplateStoreKey(messages.Messages, user.PreferredLanguage)
+	if storeKey == "" && len(messages.Messages) > 0 {
+		storeKey = activeMailTemplateStoreKey(messages.Messages, n.GetDefaultLanguage(ctx))
+	}
+	if storeKey != "" {
+		template, _, err := n.static.GetObject(ctx, authz.GetInstance(ctx).InstanceID(), orgID, storeKey)
+		if err != nil {
+			return "", err
+		}
+		return string(template), nil
+	}
+	template, err := n.MailTemplateByOrg(ctx, orgID, false)
+	if err != nil {
+		return "", err
+	}
+	return string(template.Template), nil
+}
+
+func activeMailTemplateStoreKey(messages []*query.MailTemplateMessage, lang language.Tag) string {
+	for _, message := range messages {
+		if message.Language == lang {
+			return message.ActiveStoreKey
+		}
+	}
+	return ""
+}
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -package mock -destination ./mock/commands.mock.go github.com/zitadel/zitadel/internal/notification/handlers Commands
+//	mockgen -package mock -destination ./internal/notification/handlers/mock/commands.mock.go github.com/zitadel/zitadel/internal/notification/handlers Commands
 //
 // Package mock is a generated GoMock package.
 package mock
@@ -11,7 +11,10 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	domain "github.com/zitadel/zitadel/internal/domain"
+	channels "github.com/zitadel/zitadel/internal/notification/channels"
 	milestone "github.com/zitadel/zitadel/internal/repository/milestone"
 	quota "github.com/zitadel/zitadel/internal/repository/quota"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +43,20 @@ func (m *MockCommands) EXPECT() *MockCommandsMockRecorder {
 	return m.recorder
 }
 
+// HumanEmailChangeRevertCodeSent mocks base method.
+func (m *MockCommands) HumanEmailChangeRevertCodeSent(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HumanEmailChangeRevertCodeSent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HumanEmailChangeRevertCodeSent indicates an expected call of HumanEmailChangeRevertCodeSent.
+func (mr *MockCommandsMockRecorder) HumanEmailChangeRevertCodeSent(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HumanEmailChangeRevertCodeSent", reflect.TypeOf((*MockCommands)(nil).HumanEmailChangeRevertCodeSent), arg0, arg1, arg2)
+}
+
 // HumanEmailVerificationCodeSent mocks base method.
 func (m *MockCommands) HumanEmailVerificationCodeSent(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -68,6 +85,20 @@ func (mr *MockCommandsMockRecorder) HumanInitCodeSent(arg0, arg1, arg2 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HumanInitCodeSent", reflect.TypeOf((*MockCommands)(nil).HumanInitCodeSent), arg0, arg1, arg2)
 }
 
+// HumanMagicLinkCodeSent mocks base method.
+func (m *MockCommands) HumanMagicLinkCodeSent(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HumanMagicLinkCodeSent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HumanMagicLinkCodeSent indicates an expected call of HumanMagicLinkCodeSent.
+func (mr *MockCommandsMockRecorder) HumanMagicLinkCodeSent(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HumanMagicLinkCodeSent", reflect.TypeOf((*MockCommands)(nil).HumanMagicLinkCodeSent), arg0, arg1, arg2)
+}
+
 // HumanOTPEmailCodeSent mocks base method.
 func (m *MockCommands) HumanOTPEmailCodeSent(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -110,6 +141,20 @@ func (mr *MockCommandsMockRecorder) HumanPasswordlessInitCodeSent(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HumanPasswordlessInitCodeSent", reflect.TypeOf((*MockCommands)(nil).HumanPasswordlessInitCodeSent), arg0, arg1, arg2, arg3)
 }
 
+// HumanPhoneLoginCodeSent mocks base method.
+func (m *MockCommands) HumanPhoneLoginCodeSent(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HumanPhoneLoginCodeSent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HumanPhoneLoginCodeSent indicates an expected call of HumanPhoneLoginCodeSent.
+func (mr *MockCommandsMockRecorder) HumanPhoneLoginCodeSent(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HumanPhoneLoginCodeSent", reflect.TypeOf((*MockCommands)(nil).HumanPhoneLoginCodeSent), arg0, arg1, arg2)
+}
+
 // HumanPhoneVerificationCodeSent mocks base method.
 func (m *MockCommands) HumanPhoneVerificationCodeSent(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -124,6 +169,20 @@ func (mr *MockCommandsMockRecorder) HumanPhoneVerificationCodeSent(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HumanPhoneVerificationCodeSent", reflect.TypeOf((*MockCommands)(nil).HumanPhoneVerificationCodeSent), arg0, arg1, arg2)
 }
 
+// MagicLinkSent mocks base method.
+func (m *MockCommands) MagicLinkSent(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MagicLinkSent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MagicLinkSent indicates an expected call of MagicLinkSent.
+func (mr *MockCommandsMockRecorder) MagicLinkSent(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MagicLinkSent", reflect.TypeOf((*MockCommands)(nil).MagicLinkSent), arg0, arg1, arg2)
+}
+
 // MilestonePushed mocks base method.
 func (m *MockCommands) MilestonePushed(arg0 context.Context, arg1 milestone.Type, arg2 []string, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -138,6 +197,48 @@ func (mr *MockCommandsMockRecorder) MilestonePushed(arg0, arg1, arg2, arg3 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MilestonePushed", reflect.TypeOf((*MockCommands)(nil).MilestonePushed), arg0, arg1, arg2, arg3)
 }
 
+// NotificationFailed mocks base method.
+func (m *MockCommands) NotificationFailed(arg0 context.Context, arg1, arg2 string, arg3 error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotificationFailed", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotificationFailed indicates an expected call of NotificationFailed.
+func (mr *MockCommandsMockRecorder) NotificationFailed(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationFailed", reflect.TypeOf((*MockCommands)(nil).NotificationFailed), arg0, arg1, arg2, arg3)
+}
+
+// NotificationRetryRequested mocks base method.
+func (m *MockCommands) NotificationRetryRequested(arg0 context.Context, arg1, arg2 string, arg3 error, arg4 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotificationRetryRequested", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotificationRetryRequested indicates an expected call of NotificationRetryRequested.
+func (mr *MockCommandsMockRecorder) NotificationRetryRequested(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationRetryRequested", reflect.TypeOf((*MockCommands)(nil).NotificationRetryRequested), arg0, arg1, arg2, arg3, arg4)
+}
+
+// NotificationSent mocks base method.
+func (m *MockCommands) NotificationSent(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotificationSent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotificationSent indicates an expected call of NotificationSent.
+func (mr *MockCommandsMockRecorder) NotificationSent(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationSent", reflect.TypeOf((*MockCommands)(nil).NotificationSent), arg0, arg1, arg2)
+}
+
 // OTPEmailSent mocks base method.
 func (m *MockCommands) OTPEmailSent(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -194,6 +295,35 @@ func (mr *MockCommandsMockRecorder) PasswordCodeSent(arg0, arg1, arg2 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordCodeSent", reflect.TypeOf((*MockCommands)(nil).PasswordCodeSent), arg0, arg1, arg2)
 }
 
+// RequestNotification mocks base method.
+func (m *MockCommands) RequestNotification(arg0 context.Context, arg1 string, arg2 domain.NotificationType, arg3 string, arg4 channels.Message) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestNotification", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestNotification indicates an expected call of RequestNotification.
+func (mr *MockCommandsMockRecorder) RequestNotification(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestNotification", reflect.TypeOf((*MockCommands)(nil).RequestNotification), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SecurityNotificationSent mocks base method.
+func (m *MockCommands) SecurityNotificationSent(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecurityNotificationSent", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecurityNotificationSent indicates an expected call of SecurityNotificationSent.
+func (mr *MockCommandsMockRecorder) SecurityNotificationSent(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityNotificationSent", reflect.TypeOf((*MockCommands)(nil).SecurityNotificationSent), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // UsageNotificationSent mocks base method.
 func (m *MockCommands) UsageNotificationSent(arg0 context.Context, arg1 *quota.NotificationDueEvent) error {
 	m.ctrl.T.Helper()
@@ -11,9 +11,11 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/zitadel/zitadel/internal/domain"
 	query "github.com/zitadel/zitadel/internal/query"
+	quota "github.com/zitadel/zitadel/internal/repository/quota"
 	gomock "go.uber.org/mock/gomock"
 	language "golang.org/x/text/language"
 )
@@ -71,6 +73,21 @@ func (mr *MockQueriesMockRecorder) CustomTextListByTemplate(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CustomTextListByTemplate", reflect.TypeOf((*MockQueries)(nil).CustomTextListByTemplate), arg0, arg1, arg2, arg3)
 }
 
+// DueNotifications mocks base method.
+func (m *MockQueries) DueNotifications(arg0 context.Context, arg1, arg2 time.Time, arg3 uint64) (*query.Notifications, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DueNotifications", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*query.Notifications)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DueNotifications indicates an expected call of DueNotifications.
+func (mr *MockQueriesMockRecorder) DueNotifications(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DueNotifications", reflect.TypeOf((*MockQueries)(nil).DueNotifications), arg0, arg1, arg2, arg3)
+}
+
 // GetDefaultLanguage mocks base method.
 func (m *MockQueries) GetDefaultLanguage(arg0 context.Context) language.Tag {
 	m.ctrl.T.Helper()
@@ -85,6 +102,21 @@ func (mr *MockQueriesMockRecorder) GetDefaultLanguage(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultLanguage", reflect.TypeOf((*MockQueries)(nil).GetDefaultLanguage), arg0)
 }
 
+// GetDueQuotaNotifications mocks base method.
+func (m *MockQueries) GetDueQuotaNotifications(arg0 context.Context, arg1 string, arg2 quota.Unit, arg3 *query.Quota, arg4 time.Time, arg5 uint64) ([]*quota.NotificationDueEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueQuotaNotifications", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]*quota.NotificationDueEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueQuotaNotifications indicates an expected call of GetDueQuotaNotifications.
+func (mr *MockQueriesMockRecorder) GetDueQuotaNotifications(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueQuotaNotifications", reflect.TypeOf((*MockQueries)(nil).GetDueQuotaNotifications), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // GetInstanceRestrictions mocks base method.
 func (m *MockQueries) GetInstanceRestrictions(arg0 context.Context) (query.Restrictions, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +147,36 @@ func (mr *MockQueriesMockRecorder) GetNotifyUserByID(arg0, arg1, arg2 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifyUserByID", reflect.TypeOf((*MockQueries)(nil).GetNotifyUserByID), arg0, arg1, arg2)
 }
 
+// GetQuota mocks base method.
+func (m *MockQueries) GetQuota(arg0 context.Context, arg1 string, arg2 quota.Unit) (*query.Quota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuota", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*query.Quota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuota indicates an expected call of GetQuota.
+func (mr *MockQueriesMockRecorder) GetQuota(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuota", reflect.TypeOf((*MockQueries)(nil).GetQuota), arg0, arg1, arg2)
+}
+
+// GetRemainingQuotaUsage mocks base method.
+func (m *MockQueries) GetRemainingQuotaUsage(arg0 context.Context, arg1 string, arg2 quota.Unit) (*uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemainingQuotaUsage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemainingQuotaUsage indicates an expected call of GetRemainingQuotaUsage.
+func (mr *MockQueriesMockRecorder) GetRemainingQuotaUsage(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemainingQuotaUsage", reflect.TypeOf((*MockQueries)(nil).GetRemainingQuotaUsage), arg0, arg1, arg2)
+}
+
 // MailTemplateByOrg mocks base method.
 func (m *MockQueries) MailTemplateByOrg(arg0 context.Context, arg1 string, arg2 bool) (*query.MailTemplate, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +192,21 @@ func (mr *MockQueriesMockRecorder) MailTemplateByOrg(arg0, arg1, arg2 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MailTemplateByOrg", reflect.TypeOf((*MockQueries)(nil).MailTemplateByOrg), arg0, arg1, arg2)
 }
 
+// MailTemplateMessagesByOrg mocks base method.
+func (m *MockQueries) MailTemplateMessagesByOrg(arg0 context.Context, arg1, arg2 string) (*query.MailTemplateMessages, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MailTemplateMessagesByOrg", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*query.MailTemplateMessages)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MailTemplateMessagesByOrg indicates an expected call of MailTemplateMessagesByOrg.
+func (mr *MockQueriesMockRecorder) MailTemplateMessagesByOrg(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MailTemplateMessagesByOrg", reflect.TypeOf((*MockQueries)(nil).MailTemplateMessagesByOrg), arg0, arg1, arg2)
+}
+
 // NotificationPolicyByOrg mocks base method.
 func (m *MockQueries) NotificationPolicyByOrg(arg0 context.Context, arg1 bool, arg2 string, arg3 bool) (*query.NotificationPolicy, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +237,21 @@ func (mr *MockQueriesMockRecorder) NotificationProviderByIDAndType(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationProviderByIDAndType", reflect.TypeOf((*MockQueries)(nil).NotificationProviderByIDAndType), arg0, arg1, arg2)
 }
 
+// OrgNotificationProviders mocks base method.
+func (m *MockQueries) OrgNotificationProviders(arg0 context.Context, arg1 string) (*query.OrgNotificationProviders, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrgNotificationProviders", arg0, arg1)
+	ret0, _ := ret[0].(*query.OrgNotificationProviders)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrgNotificationProviders indicates an expected call of OrgNotificationProviders.
+func (mr *MockQueriesMockRecorder) OrgNotificationProviders(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrgNotificationProviders", reflect.TypeOf((*MockQueries)(nil).OrgNotificationProviders), arg0, arg1)
+}
+
 // SMSProviderConfig mocks base method.
 func (m *MockQueries) SMSProviderConfig(arg0 context.Context, arg1 ...query.SearchQuery) (*query.SMSConfig, error) {
 	m.ctrl.T.Helper()
@@ -180,19 +272,49 @@ func (mr *MockQueriesMockRecorder) SMSProviderConfig(arg0 any, arg1 ...any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMSProviderConfig", reflect.TypeOf((*MockQueries)(nil).SMSProviderConfig), varargs...)
 }
 
-// SMTPConfigByAggregateID mocks base method.
-func (m *MockQueries) SMTPConfigByAggregateID(arg0 context.Context, arg1 string) (*query.SMTPConfig, error) {
+// SMSProviderConfigByID mocks base method.
+func (m *MockQueries) SMSProviderConfigByID(arg0 context.Context, arg1 string) (*query.SMSConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SMSProviderConfigByID", arg0, arg1)
+	ret0, _ := ret[0].(*query.SMSConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SMSProviderConfigByID indicates an expected call of SMSProviderConfigByID.
+func (mr *MockQueriesMockRecorder) SMSProviderConfigByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMSProviderConfigByID", reflect.TypeOf((*MockQueries)(nil).SMSProviderConfigByID), arg0, arg1)
+}
+
+// SMTPConfigActive mocks base method.
+func (m *MockQueries) SMTPConfigActive(arg0 context.Context, arg1 string) (*query.SMTPConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SMTPConfigActive", arg0, arg1)
+	ret0, _ := ret[0].(*query.SMTPConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SMTPConfigActive indicates an expected call of SMTPConfigActive.
+func (mr *MockQueriesMockRecorder) SMTPConfigActive(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMTPConfigActive", reflect.TypeOf((*MockQueries)(nil).SMTPConfigActive), arg0, arg1)
+}
+
+// SMTPConfigByID mocks base method.
+func (m *MockQueries) SMTPConfigByID(arg0 context.Context, arg1, arg2 string) (*query.SMTPConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SMTPConfigByAggregateID", arg0, arg1)
+	ret := m.ctrl.Call(m, "SMTPConfigByID", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*query.SMTPConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SMTPConfigByAggregateID indicates an expected call of SMTPConfigByAggregateID.
-func (mr *MockQueriesMockRecorder) SMTPConfigByAggregateID(arg0, arg1 any) *gomock.Call {
+// SMTPConfigByID indicates an expected call of SMTPConfigByID.
+func (mr *MockQueriesMockRecorder) SMTPConfigByID(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMTPConfigByAggregateID", reflect.TypeOf((*MockQueries)(nil).SMTPConfigByAggregateID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMTPConfigByID", reflect.TypeOf((*MockQueries)(nil).SMTPConfigByID), arg0, arg1, arg2)
 }
 
 // SearchInstanceDomains mocks base method.
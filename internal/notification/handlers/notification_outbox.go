@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/channels/email"
+	"github.com/zitadel/zitadel/internal/notification/channels/instrumenting"
+	"github.com/zitadel/zitadel/internal/notification/channels/sms"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/notification/senders"
+	"github.com/zitadel/zitadel/internal/notification/types"
+)
+
+type NotificationWorkerConfig struct {
+	// MaxAttempts is the number of delivery attempts before a notification is marked as failed
+	MaxAttempts uint16
+	// MinRetryDelay is the delay before the first retry
+	MinRetryDelay time.Duration
+	// MaxRetryDelay caps the exponentially growing delay between two retries
+	MaxRetryDelay time.Duration
+	// RetryDelayFactor is applied to the delay after every failed attempt
+	RetryDelayFactor float64
+	// PendingTimeout is the time after which a notification, which was neither sent nor scheduled for retry, is sent again
+	PendingTimeout time.Duration
+	// BulkLimit is the maximum number of notifications sent per instance and run
+	BulkLimit uint64
+}
+
+// retryDelay returns the delay after the given (failed) attempt
+func (c *NotificationWorkerConfig) retryDelay(attempt uint16) time.Duration {
+	delay := float64(c.MinRetryDelay) * math.Pow(c.RetryDelayFactor, float64(attempt-1))
+	if delay > float64(c.MaxRetryDelay) {
+		return c.MaxRetryDelay
+	}
+	return time.Duration(delay)
+}
+
+// NotificationMetrics contains the names of the counters per channel
+// for deliveries which are retried or failed finally
+type NotificationMetrics struct {
+	Retried map[domain.NotificationType]string
+	Failed  map[domain.NotificationType]string
+}
+
+// notificationOutbox records each email and SMS in the notification outbox
+// and schedules a retry with exponential backoff if the delivery fails
+type notificationOutbox struct {
+	config   NotificationWorkerConfig
+	commands Commands
+	metrics  NotificationMetrics
+	now      func() time.Time
+}
+
+func newNotificationOutbox(config NotificationWorkerConfig, commands Commands, metrics NotificationMetrics) *notificationOutbox {
+	return &notificationOutbox{
+		config:   config,
+		commands: commands,
+		metrics:  metrics,
+		now:      time.Now,
+	}
+}
+
+// channel returns a notification channel which requests the notification in the outbox
+// and does the first delivery attempt using chain
+func (o *notificationOutbox) channel(ctx context.Context, channel domain.NotificationType, chain *senders.Chain) channels.NotificationChannel {
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		resourceOwner := authz.GetCtxData(ctx).OrgID
+		id, err := o.commands.RequestNotification(ctx, resourceOwner, channel, messageRecipient(message), message)
+		if err != nil {
+			return err
+		}
+		err = o.deliver(ctx, id, resourceOwner, channel, 1, chain, message)
+		// the notification is stored in the outbox and will be sent again by the worker
+		logging.WithFields("notification", id).OnError(err).Warn("unable to record notification delivery")
+		return nil
+	})
+}
+
+// deliver sends the message using chain and records the result of the attempt
+func (o *notificationOutbox) deliver(ctx context.Context, id, resourceOwner string, channel domain.NotificationType, attempt uint16, chain *senders.Chain, message channels.Message) error {
+	return o.recordAttempt(ctx, id, resourceOwner, channel, attempt, message, chain.HandleMessage(message))
+}
+
+// recordAttempt marks the notification as sent if sendErr is nil,
+// otherwise a retry is scheduled until the maximum number of attempts is reached
+func (o *notificationOutbox) recordAttempt(ctx context.Context, id, resourceOwner string, channel domain.NotificationType, attempt uint16, message channels.Message, sendErr error) error {
+	if sendErr == nil {
+		return o.commands.NotificationSent(ctx, id, resourceOwner)
+	}
+	if attempt >= o.config.MaxAttempts {
+		instrumenting.CountMessage(ctx, o.metrics.Failed[channel], message, sendErr)
+		return o.commands.NotificationFailed(ctx, id, resourceOwner, sendErr)
+	}
+	instrumenting.CountMessage(ctx, o.metrics.Retried[channel], message, sendErr)
+	return o.commands.NotificationRetryRequested(ctx, id, resourceOwner, sendErr, o.now().Add(o.config.retryDelay(attempt)))
+}
+
+func messageRecipient(message channels.Message) string {
+	switch m := message.(type) {
+	case *messages.Email:
+		return strings.Join(m.Recipients, ", ")
+	case *messages.SMS:
+		return m.RecipientPhoneNumber
+	default:
+		return ""
+	}
+}
+
+var _ types.ChannelChains = (*outboxChains)(nil)
+
+// outboxChains passes emails and SMS through the notification outbox,
+// so failed deliveries are retried by the notification worker instead of the projection
+type outboxChains struct {
+	types.ChannelChains
+	outbox *notificationOutbox
+}
+
+func (c *outboxChains) Email(ctx context.Context) (*senders.Chain, *email.Config, error) {
+	chain, config, err := c.ChannelChains.Email(ctx)
+	if chain == nil || chain.Len() == 0 {
+		return chain, config, err
+	}
+	return senders.ChainChannels(c.outbox.channel(ctx, domain.NotificationTypeEmail, chain)), config, err
+}
+
+func (c *outboxChains) SMS(ctx context.Context) (*senders.Chain, *sms.Config, error) {
+	chain, config, err := c.ChannelChains.SMS(ctx)
+	if chain == nil || chain.Len() == 0 {
+		return chain, config, err
+	}
+	return senders.ChainChannels(c.outbox.channel(ctx, domain.NotificationTypeSms, chain)), config, err
+}
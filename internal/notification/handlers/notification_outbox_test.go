@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/handlers/mock"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+func TestNotificationWorkerConfig_retryDelay(t *testing.T) {
+	config := &NotificationWorkerConfig{
+		MinRetryDelay:    time.Second,
+		MaxRetryDelay:    10 * time.Second,
+		RetryDelayFactor: 2,
+	}
+	tests := []struct {
+		attempt uint16
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 4, want: 8 * time.Second},
+		{attempt: 5, want: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, config.retryDelay(tt.attempt))
+	}
+}
+
+func Test_notificationOutbox_recordAttempt(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sendErr := errors.New("connection refused")
+	message := &messages.Email{
+		Recipients:      []string{"user@example.com"},
+		TriggeringEvent: &eventstore.BaseEvent{EventType: "user.human.initialization.code.added"},
+	}
+	tests := []struct {
+		name    string
+		attempt uint16
+		sendErr error
+		expect  func(commands *mock.MockCommandsMockRecorder)
+	}{
+		{
+			name:    "sent",
+			attempt: 1,
+			expect: func(commands *mock.MockCommandsMockRecorder) {
+				commands.NotificationSent(gomock.Any(), "notification1", "org1").Return(nil)
+			},
+		},
+		{
+			name:    "retry",
+			attempt: 2,
+			sendErr: sendErr,
+			expect: func(commands *mock.MockCommandsMockRecorder) {
+				commands.NotificationRetryRequested(gomock.Any(), "notification1", "org1", sendErr, now.Add(2*time.Second)).Return(nil)
+			},
+		},
+		{
+			name:    "max attempts reached, failed",
+			attempt: 3,
+			sendErr: sendErr,
+			expect: func(commands *mock.MockCommandsMockRecorder) {
+				commands.NotificationFailed(gomock.Any(), "notification1", "org1", sendErr).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands := mock.NewMockCommands(gomock.NewController(t))
+			tt.expect(commands.EXPECT())
+			outbox := newNotificationOutbox(
+				NotificationWorkerConfig{
+					MaxAttempts:      3,
+					MinRetryDelay:    time.Second,
+					MaxRetryDelay:    time.Minute,
+					RetryDelayFactor: 2,
+				},
+				commands,
+				NotificationMetrics{},
+			)
+			outbox.now = func() time.Time { return now }
+			err := outbox.recordAttempt(authz.WithInstanceID(context.Background(), "instance1"), "notification1", "org1", domain.NotificationTypeEmail, tt.attempt, message, tt.sendErr)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/notification/senders"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/pseudo"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	NotificationWorkerProjectionTable = "projections.notification_worker"
+)
+
+type notificationWorker struct {
+	outbox   *notificationOutbox
+	queries  *NotificationQueries
+	channels types.ChannelChains
+}
+
+// NewNotificationWorker returns a handler which periodically sends the due notifications of the outbox
+// and a [types.ChannelChains] which records the notifications sent through it in the outbox
+func NewNotificationWorker(
+	ctx context.Context,
+	workerCfg NotificationWorkerConfig,
+	handlerCfg handler.Config,
+	commands Commands,
+	queries *NotificationQueries,
+	channels types.ChannelChains,
+	metrics NotificationMetrics,
+) (*handler.Handler, types.ChannelChains) {
+	worker := &notificationWorker{
+		outbox:   newNotificationOutbox(workerCfg, commands, metrics),
+		queries:  queries,
+		channels: channels,
+	}
+	handlerCfg.TriggerWithoutEvents = worker.sendDueNotifications
+	chains := &outboxChains{
+		ChannelChains: channels,
+		outbox:        worker.outbox,
+	}
+	return handler.NewHandler(ctx, &handlerCfg, worker), chains
+}
+
+func (w *notificationWorker) Name() string {
+	return NotificationWorkerProjectionTable
+}
+
+func (w *notificationWorker) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{{
+		Aggregate: pseudo.AggregateType,
+		EventReducers: []handler.EventReducer{{
+			Event:  pseudo.ScheduledEventType,
+			Reduce: w.sendDueNotifications,
+		}},
+	}}
+}
+
+func (w *notificationWorker) sendDueNotifications(event eventstore.Event) (*handler.Statement, error) {
+	scheduledEvent, ok := event.(*pseudo.ScheduledEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Wo3Sk", "reduce.wrong.event.type %s", event.Type())
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		for _, instanceID := range scheduledEvent.InstanceIDs {
+			ctx := authz.WithInstanceID(call.WithTimestamp(context.Background()), instanceID)
+			now := w.outbox.now()
+			notifications, err := w.queries.DueNotifications(ctx, now, now.Add(-w.outbox.config.PendingTimeout), w.outbox.config.BulkLimit)
+			if err != nil {
+				return err
+			}
+			for _, notification := range notifications.Notifications {
+				err = w.sendNotification(ctx, notification)
+				logging.WithFields("instance", instanceID, "notification", notification.ID).OnError(err).Warn("sending notification failed")
+			}
+		}
+		return nil
+	}), nil
+}
+
+func (w *notificationWorker) sendNotification(ctx context.Context, notification *query.Notification) error {
+	ctx = authz.SetCtxData(ctx, authz.CtxData{UserID: NotifyUserID, OrgID: notification.ResourceOwner})
+	message, err := w.message(ctx, notification)
+	if err != nil {
+		return err
+	}
+	chain, err := w.chain(ctx, notification.Channel)
+	if err != nil {
+		return w.outbox.recordAttempt(ctx, notification.ID, notification.ResourceOwner, notification.Channel, notification.Attempts+1, message, err)
+	}
+	return w.outbox.deliver(ctx, notification.ID, notification.ResourceOwner, notification.Channel, notification.Attempts+1, chain, message)
+}
+
+// message decrypts the stored message of the notification
+func (w *notificationWorker) message(ctx context.Context, notification *query.Notification) (channels.Message, error) {
+	decrypted, err := crypto.Decrypt(notification.Message, w.queries.UserDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	triggeringEvent := &eventstore.BaseEvent{
+		EventType: notification.TriggeringEventType,
+		Agg: &eventstore.Aggregate{
+			ID:            notification.TriggeringAggregateID,
+			ResourceOwner: notification.ResourceOwner,
+			InstanceID:    authz.GetInstance(ctx).InstanceID(),
+		},
+	}
+	switch notification.Channel {
+	case domain.NotificationTypeEmail:
+		message := &messages.Email{TriggeringEvent: triggeringEvent}
+		if err = json.Unmarshal(decrypted, message); err != nil {
+			return nil, zerrors.ThrowInternal(err, "HANDL-Ue4ai", "Errors.Internal")
+		}
+		return message, nil
+	case domain.NotificationTypeSms:
+		message := &messages.SMS{TriggeringEvent: triggeringEvent}
+		if err = json.Unmarshal(decrypted, message); err != nil {
+			return nil, zerrors.ThrowInternal(err, "HANDL-Oov2a", "Errors.Internal")
+		}
+		return message, nil
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "HANDL-ai3Ph", "Errors.Notification.Channels.NotPresent")
+	}
+}
+
+// chain returns the currently active channels of the organization or instance
+func (w *notificationWorker) chain(ctx context.Context, channel domain.NotificationType) (chain *senders.Chain, err error) {
+	switch channel {
+	case domain.NotificationTypeEmail:
+		chain, _, err = w.channels.Email(ctx)
+	case domain.NotificationTypeSms:
+		chain, _, err = w.channels.SMS(ctx)
+		logging.OnError(err).Error("could not create sms channel")
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if chain == nil || chain.Len() == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "HANDL-ieM2u", "Errors.Notification.Channels.NotPresent")
+	}
+	return chain, nil
+}
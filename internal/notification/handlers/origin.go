@@ -33,6 +33,12 @@ func (n *NotificationQueries) Origin(ctx context.Context, e eventstore.Event) (c
 		}
 		return enrichCtx(ctx, originURL.Hostname(), origin), nil
 	}
+	return n.PrimaryDomainOrigin(ctx)
+}
+
+// PrimaryDomainOrigin sets the primary domain of the instance as origin,
+// used for notifications about events which were not triggered by a request with an origin
+func (n *NotificationQueries) PrimaryDomainOrigin(ctx context.Context) (context.Context, error) {
 	primary, err := query.NewInstanceDomainPrimarySearchQuery(true)
 	if err != nil {
 		return ctx, err
@@ -2,17 +2,22 @@ package handlers
 
 import (
 	"context"
+	"time"
+
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+	"github.com/zitadel/zitadel/internal/static"
 )
 
 type Queries interface {
 	ActiveLabelPolicyByOrg(ctx context.Context, orgID string, withOwnerRemoved bool) (*query.LabelPolicy, error)
 	MailTemplateByOrg(ctx context.Context, orgID string, withOwnerRemoved bool) (*query.MailTemplate, error)
+	MailTemplateMessagesByOrg(ctx context.Context, orgID, messageType string) (*query.MailTemplateMessages, error)
 	GetNotifyUserByID(ctx context.Context, shouldTriggered bool, userID string) (*query.NotifyUser, error)
 	CustomTextListByTemplate(ctx context.Context, aggregateID, template string, withOwnerRemoved bool) (*query.CustomTexts, error)
 	SearchInstanceDomains(ctx context.Context, queries *query.InstanceDomainSearchQueries) (*query.InstanceDomains, error)
@@ -21,9 +26,16 @@ type Queries interface {
 	SearchMilestones(ctx context.Context, instanceIDs []string, queries *query.MilestonesSearchQueries) (*query.Milestones, error)
 	NotificationProviderByIDAndType(ctx context.Context, aggID string, providerType domain.NotificationProviderType) (*query.DebugNotificationProvider, error)
 	SMSProviderConfig(ctx context.Context, queries ...query.SearchQuery) (*query.SMSConfig, error)
-	SMTPConfigByAggregateID(ctx context.Context, aggregateID string) (*query.SMTPConfig, error)
+	SMSProviderConfigByID(ctx context.Context, id string) (*query.SMSConfig, error)
+	SMTPConfigActive(ctx context.Context, instanceID string) (*query.SMTPConfig, error)
+	SMTPConfigByID(ctx context.Context, instanceID, id string) (*query.SMTPConfig, error)
+	OrgNotificationProviders(ctx context.Context, orgID string) (*query.OrgNotificationProviders, error)
 	GetDefaultLanguage(ctx context.Context) language.Tag
 	GetInstanceRestrictions(ctx context.Context) (restrictions query.Restrictions, err error)
+	DueNotifications(ctx context.Context, now, pendingBefore time.Time, limit uint64) (*query.Notifications, error)
+	GetQuota(ctx context.Context, instanceID string, unit quota.Unit) (*query.Quota, error)
+	GetRemainingQuotaUsage(ctx context.Context, instanceID string, unit quota.Unit) (remaining *uint64, err error)
+	GetDueQuotaNotifications(ctx context.Context, instanceID string, unit quota.Unit, qu *query.Quota, periodStart time.Time, usedAbs uint64) ([]*quota.NotificationDueEvent, error)
 }
 
 type NotificationQueries struct {
@@ -33,6 +45,7 @@ type NotificationQueries struct {
 	externalPort       uint16
 	externalSecure     bool
 	fileSystemPath     string
+	static             static.Storage
 	UserDataCrypto     crypto.EncryptionAlgorithm
 	SMTPPasswordCrypto crypto.EncryptionAlgorithm
 	SMSTokenCrypto     crypto.EncryptionAlgorithm
@@ -45,6 +58,7 @@ func NewNotificationQueries(
 	externalPort uint16,
 	externalSecure bool,
 	fileSystemPath string,
+	static static.Storage,
 	userDataCrypto crypto.EncryptionAlgorithm,
 	smtpPasswordCrypto crypto.EncryptionAlgorithm,
 	smsTokenCrypto crypto.EncryptionAlgorithm,
@@ -56,6 +70,7 @@ func NewNotificationQueries(
 		externalPort:       externalPort,
 		externalSecure:     externalSecure,
 		fileSystemPath:     fileSystemPath,
+		static:             static,
 		UserDataCrypto:     userDataCrypto,
 		SMTPPasswordCrypto: smtpPasswordCrypto,
 		SMSTokenCrypto:     smsTokenCrypto,
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
@@ -72,10 +73,38 @@ func (u *quotaNotifier) reduceNotificationDue(event eventstore.Event) (*handler.
 		if alreadyHandled {
 			return nil
 		}
-		err = types.SendJSON(ctx, webhook.Config{CallURL: e.CallURL, Method: http.MethodPost}, u.channels, e, e).WithoutTemplate()
+		switch e.Channel {
+		case quota.NotificationChannelWebhook:
+			err = types.SendJSON(ctx, webhook.Config{CallURL: e.CallURL, Method: http.MethodPost}, u.channels, e, e).WithoutTemplate()
+		case quota.NotificationChannelEmail:
+			err = u.sendEmail(ctx, e)
+		case quota.NotificationChannelEvent:
+			// the notified event is the notification on the event stream of the instance
+		}
 		if err != nil {
 			return err
 		}
 		return u.commands.UsageNotificationSent(ctx, e)
 	}), nil
 }
+
+func (u *quotaNotifier) sendEmail(ctx context.Context, e *quota.NotificationDueEvent) error {
+	instanceID := e.Aggregate().InstanceID
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, instanceID, false)
+	if err != nil {
+		return err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, instanceID, domain.QuotaNotificationMessageType)
+	if err != nil {
+		return err
+	}
+	template, err := u.queries.MailTemplateByOrg(ctx, instanceID, false)
+	if err != nil {
+		return err
+	}
+	ctx, err = u.queries.PrimaryDomainOrigin(ctx)
+	if err != nil {
+		return err
+	}
+	return types.SendQuotaNotification(ctx, u.channels, string(template.Template), translator, u.queries.GetDefaultLanguage(ctx).String(), colors, e)
+}
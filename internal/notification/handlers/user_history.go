@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+var signInSucceededEventTypes = []eventstore.EventType{
+	user.UserV1PasswordCheckSucceededType,
+	user.HumanPasswordCheckSucceededType,
+	user.UserIDPLoginCheckSucceededType,
+	user.HumanPasswordlessTokenCheckSucceededType,
+	user.HumanMagicLinkCheckSucceededType,
+	user.HumanPhoneLoginCheckSucceededType,
+	user.HumanX509CheckSucceededType,
+}
+
+// sessionCheckedEventTypes are the successful checks of the session API
+var sessionCheckedEventTypes = []eventstore.EventType{
+	session.PasswordCheckedType,
+	session.IntentCheckedType,
+	session.WebAuthNCheckedType,
+	session.TOTPCheckedType,
+	session.OTPSMSCheckedType,
+	session.OTPEmailCheckedType,
+	session.MagicLinkCheckedType,
+	session.X509CheckedType,
+}
+
+// IsUnknownUserAgent checks if the user signed in before the event, but never with the given user agent.
+// Sign-ins through the login UI and the session API are both considered.
+func (n *NotificationQueries) IsUnknownUserAgent(ctx context.Context, event eventstore.Event, userID, userAgentID string) (bool, error) {
+	known, err := n.hasPreviousSignIns(ctx, event, userID, userAgentID)
+	if err != nil || known {
+		return false, err
+	}
+	// the first sign-in of a user is not reported
+	return n.hasPreviousSignIns(ctx, event, userID, "")
+}
+
+func (n *NotificationQueries) hasPreviousSignIns(ctx context.Context, event eventstore.Event, userID, userAgentID string) (bool, error) {
+	var data map[string]interface{}
+	if userAgentID != "" {
+		data = map[string]interface{}{"userAgentID": userAgentID}
+	}
+	known, err := n.hasPreviousUserEvents(ctx, event, userID, data, signInSucceededEventTypes...)
+	if err != nil || known {
+		return known, err
+	}
+	return n.hasPreviousSessionSignIns(ctx, event, userID, userAgentID)
+}
+
+// hasPreviousSessionSignIns checks if the user was successfully checked in a session (of the user agent) before the event
+func (n *NotificationQueries) hasPreviousSessionSignIns(ctx context.Context, event eventstore.Event, userID, userAgentID string) (bool, error) {
+	sessionIDs, err := n.previousSessionIDs(ctx, event, session.UserCheckedType, map[string]interface{}{"userID": userID})
+	if err != nil || len(sessionIDs) == 0 {
+		return false, err
+	}
+	if userAgentID != "" {
+		sessionIDs, err = n.previousSessionIDs(ctx, event, session.AddedType, map[string]interface{}{"user_agent": map[string]interface{}{"fingerprint_id": userAgentID}}, sessionIDs...)
+		if err != nil || len(sessionIDs) == 0 {
+			return false, err
+		}
+	}
+	events, err := n.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			CreationDateBefore(event.CreatedAt()).
+			Limit(1).
+			AddQuery().
+			AggregateTypes(session.AggregateType).
+			AggregateIDs(sessionIDs...).
+			EventTypes(sessionCheckedEventTypes...).
+			Builder(),
+	)
+	if err != nil {
+		return false, err
+	}
+	return len(events) > 0, nil
+}
+
+func (n *NotificationQueries) previousSessionIDs(ctx context.Context, event eventstore.Event, eventType eventstore.EventType, data map[string]interface{}, sessionIDs ...string) ([]string, error) {
+	events, err := n.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			CreationDateBefore(event.CreatedAt()).
+			AddQuery().
+			AggregateTypes(session.AggregateType).
+			AggregateIDs(sessionIDs...).
+			EventTypes(eventType).
+			EventData(data).
+			Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(events))
+	for _, e := range events {
+		if !slices.Contains(ids, e.Aggregate().ID) {
+			ids = append(ids, e.Aggregate().ID)
+		}
+	}
+	return ids, nil
+}
+
+// SessionSignIn describes the successful check of a session through the session API
+type SessionSignIn struct {
+	UserID        string
+	ResourceOwner string
+	UserAgent     *domain.UserAgent
+	// First is true, if no other check of the session succeeded before
+	First bool
+}
+
+// SessionSignIn returns the user and user agent of the session of the (checked) event
+func (n *NotificationQueries) SessionSignIn(ctx context.Context, event eventstore.Event) (*SessionSignIn, error) {
+	events, err := n.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			OrderAsc().
+			AddQuery().
+			AggregateTypes(session.AggregateType).
+			AggregateIDs(event.Aggregate().ID).
+			EventTypes(append([]eventstore.EventType{session.AddedType, session.UserCheckedType}, sessionCheckedEventTypes...)...).
+			Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	signIn := &SessionSignIn{First: true}
+	for _, e := range events {
+		if e.Sequence() >= event.Sequence() {
+			break
+		}
+		switch e := e.(type) {
+		case *session.AddedEvent:
+			signIn.UserAgent = e.UserAgent
+		case *session.UserCheckedEvent:
+			signIn.UserID = e.UserID
+			signIn.ResourceOwner = e.UserResourceOwner
+		default:
+			signIn.First = false
+		}
+	}
+	return signIn, nil
+}
+
+func (n *NotificationQueries) hasPreviousUserEvents(ctx context.Context, event eventstore.Event, userID string, data map[string]interface{}, eventTypes ...eventstore.EventType) (bool, error) {
+	events, err := n.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			CreationDateBefore(event.CreatedAt()).
+			Limit(1).
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(userID).
+			EventTypes(eventTypes...).
+			EventData(data).
+			Builder(),
+	)
+	if err != nil {
+		return false, err
+	}
+	return len(events) > 0, nil
+}
+
+// PreviousEmail returns the email address the user had before the event
+func (n *NotificationQueries) PreviousEmail(ctx context.Context, event eventstore.Event) (domain.EmailAddress, error) {
+	events, err := n.previousUserEvents(ctx, event,
+		user.UserV1AddedType,
+		user.UserV1RegisteredType,
+		user.UserV1EmailChangedType,
+		user.HumanAddedType,
+		user.HumanRegisteredType,
+		user.HumanEmailChangedType,
+	)
+	if err != nil {
+		return "", err
+	}
+	var email domain.EmailAddress
+	for _, event := range events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent:
+			email = e.EmailAddress
+		case *user.HumanRegisteredEvent:
+			email = e.EmailAddress
+		case *user.HumanEmailChangedEvent:
+			email = e.EmailAddress
+		}
+	}
+	return email, nil
+}
+
+// PendingEmail returns the requested email address of a pending change at the time of the event
+func (n *NotificationQueries) PendingEmail(ctx context.Context, event eventstore.Event) (domain.EmailAddress, error) {
+	events, err := n.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			OrderAsc().
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(event.Aggregate().ID).
+			EventTypes(
+				user.UserV1EmailChangedType,
+				user.HumanEmailChangedType,
+				user.HumanEmailChangeRequestedType,
+				user.HumanEmailChangeRevertedType,
+			).
+			Builder(),
+	)
+	if err != nil {
+		return "", err
+	}
+	var pendingEmail domain.EmailAddress
+	for _, e := range events {
+		// the change is requested in the same transaction as the code is added,
+		// so the events can't be filtered by their creation date
+		if e.Sequence() > event.Sequence() {
+			break
+		}
+		switch e := e.(type) {
+		case *user.HumanEmailChangeRequestedEvent:
+			pendingEmail = e.EmailAddress
+		case *user.HumanEmailChangedEvent, *user.HumanEmailChangeRevertedEvent:
+			pendingEmail = ""
+		}
+	}
+	return pendingEmail, nil
+}
+
+// PreviousPhone returns the phone number the user had before the event
+func (n *NotificationQueries) PreviousPhone(ctx context.Context, event eventstore.Event) (domain.PhoneNumber, error) {
+	events, err := n.previousUserEvents(ctx, event,
+		user.UserV1AddedType,
+		user.UserV1RegisteredType,
+		user.UserV1PhoneChangedType,
+		user.UserV1PhoneRemovedType,
+		user.HumanAddedType,
+		user.HumanRegisteredType,
+		user.HumanPhoneChangedType,
+		user.HumanPhoneRemovedType,
+	)
+	if err != nil {
+		return "", err
+	}
+	var phone domain.PhoneNumber
+	for _, event := range events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent:
+			phone = e.PhoneNumber
+		case *user.HumanRegisteredEvent:
+			phone = e.PhoneNumber
+		case *user.HumanPhoneChangedEvent:
+			phone = e.PhoneNumber
+		case *user.HumanPhoneRemovedEvent:
+			phone = ""
+		}
+	}
+	return phone, nil
+}
+
+func (n *NotificationQueries) previousUserEvents(ctx context.Context, event eventstore.Event, eventTypes ...eventstore.EventType) ([]eventstore.Event, error) {
+	return n.es.Filter(
+		ctx,
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(event.Aggregate().InstanceID).
+			OrderAsc().
+			CreationDateBefore(event.CreatedAt()).
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(event.Aggregate().ID).
+			EventTypes(eventTypes...).
+			Builder(),
+	)
+}
@@ -23,10 +23,11 @@ const (
 )
 
 type userNotifier struct {
-	commands     Commands
-	queries      *NotificationQueries
-	channels     types.ChannelChains
-	otpEmailTmpl string
+	commands      Commands
+	queries       *NotificationQueries
+	channels      types.ChannelChains
+	otpEmailTmpl  string
+	magicLinkTmpl string
 }
 
 func NewUserNotifier(
@@ -36,12 +37,14 @@ func NewUserNotifier(
 	queries *NotificationQueries,
 	channels types.ChannelChains,
 	otpEmailTmpl string,
+	magicLinkTmpl string,
 ) *handler.Handler {
 	return handler.NewHandler(ctx, &config, &userNotifier{
-		commands:     commands,
-		queries:      queries,
-		otpEmailTmpl: otpEmailTmpl,
-		channels:     channels,
+		commands:      commands,
+		queries:       queries,
+		otpEmailTmpl:  otpEmailTmpl,
+		magicLinkTmpl: magicLinkTmpl,
+		channels:      channels,
 	})
 }
 
@@ -78,6 +81,10 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanPasswordCodeAddedType,
 					Reduce: u.reducePasswordCodeAdded,
 				},
+				{
+					Event:  user.HumanEmailChangeRequestedType,
+					Reduce: u.reduceEmailChangeRequested,
+				},
 				{
 					Event:  user.UserDomainClaimedType,
 					Reduce: u.reduceDomainClaimed,
@@ -106,6 +113,98 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanOTPEmailCodeAddedType,
 					Reduce: u.reduceOTPEmailCodeAdded,
 				},
+				{
+					Event:  user.HumanMagicLinkCodeAddedType,
+					Reduce: u.reduceMagicLinkCodeAdded,
+				},
+				{
+					Event:  user.HumanPhoneLoginCodeAddedType,
+					Reduce: u.reducePhoneLoginCodeAdded,
+				},
+				{
+					Event:  user.HumanPasswordCheckSucceededType,
+					Reduce: u.reduceSignInSucceeded,
+				},
+				{
+					Event:  user.UserIDPLoginCheckSucceededType,
+					Reduce: u.reduceSignInSucceeded,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenCheckSucceededType,
+					Reduce: u.reduceSignInSucceeded,
+				},
+				{
+					Event:  user.HumanMagicLinkCheckSucceededType,
+					Reduce: u.reduceSignInSucceeded,
+				},
+				{
+					Event:  user.HumanPhoneLoginCheckSucceededType,
+					Reduce: u.reduceSignInSucceeded,
+				},
+				{
+					Event:  user.HumanX509CheckSucceededType,
+					Reduce: u.reduceSignInSucceeded,
+				},
+				{
+					Event:  user.HumanMFAOTPVerifiedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanU2FTokenVerifiedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenVerifiedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanOTPSMSAddedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanOTPEmailAddedType,
+					Reduce: u.reduceMFAAdded,
+				},
+				{
+					Event:  user.HumanMFAOTPRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanU2FTokenRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanOTPSMSRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanOTPEmailRemovedType,
+					Reduce: u.reduceMFARemoved,
+				},
+				{
+					Event:  user.HumanEmailChangedType,
+					Reduce: u.reduceEmailChanged,
+				},
+				{
+					Event:  user.HumanPhoneChangedType,
+					Reduce: u.reducePhoneChanged,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: u.reduceUserLocked,
+				},
+				{
+					Event:  user.MachineKeyAddedEventType,
+					Reduce: u.reduceMachineCredentialAdded,
+				},
+				{
+					Event:  user.PersonalAccessTokenAddedType,
+					Reduce: u.reduceMachineCredentialAdded,
+				},
 			},
 		},
 		{
@@ -119,6 +218,42 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 					Event:  session.OTPEmailChallengedType,
 					Reduce: u.reduceSessionOTPEmailChallenged,
 				},
+				{
+					Event:  session.MagicLinkChallengedType,
+					Reduce: u.reduceSessionMagicLinkChallenged,
+				},
+				{
+					Event:  session.PasswordCheckedType,
+					Reduce: u.reduceSessionCheckSucceeded,
+				},
+				{
+					Event:  session.IntentCheckedType,
+					Reduce: u.reduceSessionCheckSucceeded,
+				},
+				{
+					Event:  session.WebAuthNCheckedType,
+					Reduce: u.reduceSessionCheckSucceeded,
+				},
+				{
+					Event:  session.TOTPCheckedType,
+					Reduce: u.reduceSessionCheckSucceeded,
+				},
+				{
+					Event:  session.OTPSMSCheckedType,
+					Reduce: u.reduceSessionCheckSucceeded,
+				},
+				{
+					Event:  session.OTPEmailCheckedType,
+					Reduce: u.reduceSessionCheckSucceeded,
+				},
+				{
+					Event:  session.MagicLinkCheckedType,
+					Reduce: u.reduceSessionCheckSucceeded,
+				},
+				{
+					Event:  session.X509CheckedType,
+					Reduce: u.reduceSessionCheckSucceeded,
+				},
 			},
 		},
 	}
@@ -150,16 +285,15 @@ func (u *userNotifier) reduceInitCodeAdded(event eventstore.Event) (*handler.Sta
 			return err
 		}
 
-		template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
 		if err != nil {
 			return err
 		}
-
-		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.InitCodeMessageType)
 		if err != nil {
 			return err
 		}
-		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.InitCodeMessageType)
+		template, err := u.queries.MailTemplate(ctx, e.Aggregate().ResourceOwner, domain.InitCodeMessageType, notifyUser)
 		if err != nil {
 			return err
 		}
@@ -168,7 +302,7 @@ func (u *userNotifier) reduceInitCodeAdded(event eventstore.Event) (*handler.Sta
 		if err != nil {
 			return err
 		}
-		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+		err = types.SendEmail(ctx, u.channels, template, translator, notifyUser, colors, e).
 			SendUserInitCode(ctx, notifyUser, code)
 		if err != nil {
 			return err
@@ -207,25 +341,32 @@ func (u *userNotifier) reduceEmailCodeAdded(event eventstore.Event) (*handler.St
 			return err
 		}
 
-		template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
 		if err != nil {
 			return err
 		}
-
-		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+		// the code of a pending change is sent to the requested email address
+		pendingEmail, err := u.queries.PendingEmail(ctx, e)
 		if err != nil {
 			return err
 		}
+		if pendingEmail != "" {
+			notifyUser.LastEmail = string(pendingEmail)
+		}
 		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.VerifyEmailMessageType)
 		if err != nil {
 			return err
 		}
+		template, err := u.queries.MailTemplate(ctx, e.Aggregate().ResourceOwner, domain.VerifyEmailMessageType, notifyUser)
+		if err != nil {
+			return err
+		}
 
 		ctx, err = u.queries.Origin(ctx, e)
 		if err != nil {
 			return err
 		}
-		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+		err = types.SendEmail(ctx, u.channels, template, translator, notifyUser, colors, e).
 			SendEmailVerificationCode(ctx, notifyUser, code, e.URLTemplate)
 		if err != nil {
 			return err
@@ -234,6 +375,58 @@ func (u *userNotifier) reduceEmailCodeAdded(event eventstore.Event) (*handler.St
 	}), nil
 }
 
+func (u *userNotifier) reduceEmailChangeRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanEmailChangeRequestedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Iex7a", "reduce.wrong.event.type %s", user.HumanEmailChangeRequestedType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		alreadyHandled, err := u.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.RevertExpiry, nil,
+			user.HumanEmailChangeRequestedType, user.HumanEmailChangeRevertCodeSentType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+		code, err := crypto.DecryptString(e.RevertCode, u.queries.UserDataCrypto)
+		if err != nil {
+			return err
+		}
+		colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+
+		// the current (verified) email address of the user is informed
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.EmailChangeRequestedMessageType)
+		if err != nil {
+			return err
+		}
+		template, err := u.queries.MailTemplate(ctx, e.Aggregate().ResourceOwner, domain.EmailChangeRequestedMessageType, notifyUser)
+		if err != nil {
+			return err
+		}
+
+		ctx, err = u.queries.Origin(ctx, e)
+		if err != nil {
+			return err
+		}
+		err = types.SendEmail(ctx, u.channels, template, translator, notifyUser, colors, e).
+			SendEmailChangeRequested(ctx, notifyUser, e.EmailAddress, code, e.RevertExpiry)
+		if err != nil {
+			return err
+		}
+		return u.commands.HumanEmailChangeRevertCodeSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner)
+	}), nil
+}
+
 func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*user.HumanPasswordCodeAddedEvent)
 	if !ok {
@@ -263,16 +456,15 @@ func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler
 			return err
 		}
 
-		template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
 		if err != nil {
 			return err
 		}
-
-		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.PasswordResetMessageType)
 		if err != nil {
 			return err
 		}
-		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.PasswordResetMessageType)
+		template, err := u.queries.MailTemplate(ctx, e.Aggregate().ResourceOwner, domain.PasswordResetMessageType, notifyUser)
 		if err != nil {
 			return err
 		}
@@ -281,7 +473,7 @@ func (u *userNotifier) reducePasswordCodeAdded(event eventstore.Event) (*handler
 		if err != nil {
 			return err
 		}
-		notify := types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e)
+		notify := types.SendEmail(ctx, u.channels, template, translator, notifyUser, colors, e)
 		if e.NotificationType == domain.NotificationTypeSms {
 			notify = types.SendSMSTwilio(ctx, u.channels, translator, notifyUser, colors, e)
 		}
@@ -304,12 +496,34 @@ func (u *userNotifier) reduceOTPSMSCodeAdded(event eventstore.Event) (*handler.S
 		e.Expiry,
 		e.Aggregate().ID,
 		e.Aggregate().ResourceOwner,
+		domain.PhoneChannelSMS,
+		types.Notify.SendOTPSMSCode,
 		u.commands.HumanOTPSMSCodeSent,
 		user.HumanOTPSMSCodeAddedType,
 		user.HumanOTPSMSCodeSentType,
 	)
 }
 
+func (u *userNotifier) reducePhoneLoginCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPhoneLoginCodeAddedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-aeV4o", "reduce.wrong.event.type %s", user.HumanPhoneLoginCodeAddedType)
+	}
+	return u.reduceOTPSMS(
+		e,
+		e.Code,
+		e.Expiry,
+		e.Aggregate().ID,
+		e.Aggregate().ResourceOwner,
+		e.Channel,
+		types.Notify.SendPhoneLoginCode,
+		u.commands.HumanPhoneLoginCodeSent,
+		user.HumanPhoneLoginCodeAddedType,
+		user.HumanPhoneLoginCodeSentType,
+		user.HumanPhoneLoginCheckSucceededType,
+	)
+}
+
 func (u *userNotifier) reduceSessionOTPSMSChallenged(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*session.OTPSMSChallengedEvent)
 	if !ok {
@@ -329,6 +543,8 @@ func (u *userNotifier) reduceSessionOTPSMSChallenged(event eventstore.Event) (*h
 		e.Expiry,
 		s.UserFactor.UserID,
 		s.UserFactor.ResourceOwner,
+		e.Channel,
+		types.Notify.SendOTPSMSCode,
 		u.commands.OTPSMSSent,
 		session.OTPSMSChallengedType,
 		session.OTPSMSSentType,
@@ -341,6 +557,8 @@ func (u *userNotifier) reduceOTPSMS(
 	expiry time.Duration,
 	userID,
 	resourceOwner string,
+	channel domain.PhoneChannel,
+	send func(notify types.Notify, ctx context.Context, code string, expiry time.Duration) error,
 	sentCommand func(ctx context.Context, userID string, resourceOwner string) (err error),
 	eventTypes ...eventstore.EventType,
 ) (*handler.Statement, error) {
@@ -373,8 +591,8 @@ func (u *userNotifier) reduceOTPSMS(
 	if err != nil {
 		return nil, err
 	}
-	notify := types.SendSMSTwilio(ctx, u.channels, translator, notifyUser, colors, event)
-	err = notify.SendOTPSMSCode(ctx, plainCode, expiry)
+	notify := types.SendSMSWithChannel(ctx, u.channels, translator, notifyUser, colors, channel, event)
+	err = send(notify, ctx, plainCode, expiry)
 	if err != nil {
 		return nil, err
 	}
@@ -474,16 +692,15 @@ func (u *userNotifier) reduceOTPEmail(
 		return nil, err
 	}
 
-	template, err := u.queries.MailTemplateByOrg(ctx, resourceOwner, false)
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, userID)
 	if err != nil {
 		return nil, err
 	}
-
-	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, userID)
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, resourceOwner, domain.VerifyEmailOTPMessageType)
 	if err != nil {
 		return nil, err
 	}
-	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, resourceOwner, domain.VerifyEmailOTPMessageType)
+	template, err := u.queries.MailTemplate(ctx, resourceOwner, domain.VerifyEmailOTPMessageType, notifyUser)
 	if err != nil {
 		return nil, err
 	}
@@ -495,7 +712,7 @@ func (u *userNotifier) reduceOTPEmail(
 	if err != nil {
 		return nil, err
 	}
-	notify := types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, event)
+	notify := types.SendEmail(ctx, u.channels, template, translator, notifyUser, colors, event)
 	err = notify.SendOTPEmailCode(ctx, url, plainCode, expiry)
 	if err != nil {
 		return nil, err
@@ -507,6 +724,132 @@ func (u *userNotifier) reduceOTPEmail(
 	return handler.NewNoOpStatement(event), nil
 }
 
+func (u *userNotifier) reduceMagicLinkCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanMagicLinkCodeAddedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Yoo8e", "reduce.wrong.event.type %s", user.HumanMagicLinkCodeAddedType)
+	}
+	var authRequestID string
+	if e.AuthRequestInfo != nil {
+		authRequestID = e.AuthRequestInfo.ID
+	}
+	url := func(code, origin string, notifyUser *query.NotifyUser) (string, error) {
+		return login.MagicLinkLink(origin, authRequestID, notifyUser.ID, code), nil
+	}
+	return u.reduceMagicLink(
+		e,
+		e.Code,
+		e.Expiry,
+		e.Aggregate().ID,
+		e.Aggregate().ResourceOwner,
+		url,
+		u.commands.HumanMagicLinkCodeSent,
+		user.AggregateType,
+		user.HumanMagicLinkCodeAddedType,
+		user.HumanMagicLinkCodeSentType,
+		user.HumanMagicLinkCheckSucceededType,
+	)
+}
+
+func (u *userNotifier) reduceSessionMagicLinkChallenged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.MagicLinkChallengedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Aesh7", "reduce.wrong.event.type %s", session.MagicLinkChallengedType)
+	}
+	if e.ReturnCode {
+		return handler.NewNoOpStatement(e), nil
+	}
+	ctx := HandlerContext(event.Aggregate())
+	s, err := u.queries.SessionByID(ctx, true, e.Aggregate().ID, "")
+	if err != nil {
+		return nil, err
+	}
+	url := func(code, origin string, notifyUser *query.NotifyUser) (string, error) {
+		var buf strings.Builder
+		urlTmpl := origin + u.magicLinkTmpl
+		if e.URLTmpl != "" {
+			urlTmpl = e.URLTmpl
+		}
+		if err := domain.RenderMagicLinkURLTemplate(&buf, urlTmpl, code, e.Aggregate().ID, notifyUser.ID, notifyUser.PreferredLoginName, notifyUser.DisplayName, notifyUser.PreferredLanguage); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	return u.reduceMagicLink(
+		e,
+		e.Code,
+		e.Expiry,
+		s.UserFactor.UserID,
+		s.UserFactor.ResourceOwner,
+		url,
+		u.commands.MagicLinkSent,
+		session.AggregateType,
+		session.MagicLinkChallengedType,
+		session.MagicLinkSentType,
+		session.MagicLinkCheckedType,
+	)
+}
+
+func (u *userNotifier) reduceMagicLink(
+	event eventstore.Event,
+	code *crypto.CryptoValue,
+	expiry time.Duration,
+	userID,
+	resourceOwner string,
+	urlTmpl func(code, origin string, user *query.NotifyUser) (string, error),
+	sentCommand func(ctx context.Context, id string, resourceOwner string) (err error),
+	aggregateType eventstore.AggregateType,
+	eventTypes ...eventstore.EventType,
+) (*handler.Statement, error) {
+	ctx := HandlerContext(event.Aggregate())
+	if event.CreatedAt().Add(expiry).Before(time.Now().UTC()) {
+		return handler.NewNoOpStatement(event), nil
+	}
+	alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, nil, aggregateType, eventTypes...)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return handler.NewNoOpStatement(event), nil
+	}
+	plainCode, err := crypto.DecryptString(code, u.queries.UserDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, resourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+	notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, userID)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, resourceOwner, domain.MagicLinkMessageType)
+	if err != nil {
+		return nil, err
+	}
+	template, err := u.queries.MailTemplate(ctx, resourceOwner, domain.MagicLinkMessageType, notifyUser)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = u.queries.Origin(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	url, err := urlTmpl(plainCode, http_util.ComposedOrigin(ctx), notifyUser)
+	if err != nil {
+		return nil, err
+	}
+	notify := types.SendEmail(ctx, u.channels, template, translator, notifyUser, colors, event)
+	if err = notify.SendMagicLink(ctx, url, expiry); err != nil {
+		return nil, err
+	}
+	if err = sentCommand(ctx, event.Aggregate().ID, event.Aggregate().ResourceOwner); err != nil {
+		return nil, err
+	}
+	return handler.NewNoOpStatement(event), nil
+}
+
 func (u *userNotifier) reduceDomainClaimed(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*user.DomainClaimedEvent)
 	if !ok {
@@ -527,16 +870,15 @@ func (u *userNotifier) reduceDomainClaimed(event eventstore.Event) (*handler.Sta
 			return err
 		}
 
-		template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
 		if err != nil {
 			return err
 		}
-
-		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.DomainClaimedMessageType)
 		if err != nil {
 			return err
 		}
-		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.DomainClaimedMessageType)
+		template, err := u.queries.MailTemplate(ctx, e.Aggregate().ResourceOwner, domain.DomainClaimedMessageType, notifyUser)
 		if err != nil {
 			return err
 		}
@@ -545,7 +887,7 @@ func (u *userNotifier) reduceDomainClaimed(event eventstore.Event) (*handler.Sta
 		if err != nil {
 			return err
 		}
-		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+		err = types.SendEmail(ctx, u.channels, template, translator, notifyUser, colors, e).
 			SendDomainClaimed(ctx, notifyUser, e.UserName)
 		if err != nil {
 			return err
@@ -581,16 +923,15 @@ func (u *userNotifier) reducePasswordlessCodeRequested(event eventstore.Event) (
 			return err
 		}
 
-		template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
 		if err != nil {
 			return err
 		}
-
-		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.PasswordlessRegistrationMessageType)
 		if err != nil {
 			return err
 		}
-		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.PasswordlessRegistrationMessageType)
+		template, err := u.queries.MailTemplate(ctx, e.Aggregate().ResourceOwner, domain.PasswordlessRegistrationMessageType, notifyUser)
 		if err != nil {
 			return err
 		}
@@ -599,7 +940,7 @@ func (u *userNotifier) reducePasswordlessCodeRequested(event eventstore.Event) (
 		if err != nil {
 			return err
 		}
-		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+		err = types.SendEmail(ctx, u.channels, template, translator, notifyUser, colors, e).
 			SendPasswordlessRegistrationLink(ctx, notifyUser, code, e.ID, e.URLTemplate)
 		if err != nil {
 			return err
@@ -641,16 +982,15 @@ func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.S
 			return err
 		}
 
-		template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
 		if err != nil {
 			return err
 		}
-
-		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.PasswordChangeMessageType)
 		if err != nil {
 			return err
 		}
-		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.PasswordChangeMessageType)
+		template, err := u.queries.MailTemplate(ctx, e.Aggregate().ResourceOwner, domain.PasswordChangeMessageType, notifyUser)
 		if err != nil {
 			return err
 		}
@@ -658,7 +998,7 @@ func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.S
 		if err != nil {
 			return err
 		}
-		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+		err = types.SendEmail(ctx, u.channels, template, translator, notifyUser, colors, e).
 			SendPasswordChange(ctx, notifyUser)
 		if err != nil {
 			return err
@@ -718,6 +1058,314 @@ func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.St
 	}), nil
 }
 
+// securityNotification informs a user about a security relevant event on their account
+type securityNotification struct {
+	messageType string
+	// enabled returns if the notification is activated in the notification policy of the organization
+	enabled func(policy *query.NotificationPolicy) bool
+	// recipient returns the user to notify or nil if nobody has to be notified
+	recipient func(ctx context.Context) (*query.NotifyUser, error)
+	args      map[string]interface{}
+	sms       bool
+	// userID and resourceOwner of the user to notify must be set,
+	// if the event is not an event of the user (e.g. of a session)
+	userID        string
+	resourceOwner string
+}
+
+func (u *userNotifier) reduceSignInSucceeded(event eventstore.Event) (*handler.Statement, error) {
+	var authRequestInfo *user.AuthRequestInfo
+	switch e := event.(type) {
+	case *user.HumanPasswordCheckSucceededEvent:
+		authRequestInfo = e.AuthRequestInfo
+	case *user.UserIDPCheckSucceededEvent:
+		authRequestInfo = e.AuthRequestInfo
+	case *user.HumanPasswordlessCheckSucceededEvent:
+		authRequestInfo = e.AuthRequestInfo
+	case *user.HumanMagicLinkCheckSucceededEvent:
+		authRequestInfo = e.AuthRequestInfo
+	case *user.HumanPhoneLoginCheckSucceededEvent:
+		authRequestInfo = e.AuthRequestInfo
+	case *user.HumanX509CheckSucceededEvent:
+		authRequestInfo = e.AuthRequestInfo
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ahc3e", "reduce.wrong.event.type %v", []eventstore.EventType{
+			user.HumanPasswordCheckSucceededType,
+			user.UserIDPLoginCheckSucceededType,
+			user.HumanPasswordlessTokenCheckSucceededType,
+			user.HumanMagicLinkCheckSucceededType,
+			user.HumanPhoneLoginCheckSucceededType,
+			user.HumanX509CheckSucceededType,
+		})
+	}
+	// only sign-ins through the login UI are identified by a user agent
+	if authRequestInfo == nil || authRequestInfo.UserAgentID == "" {
+		return handler.NewNoOpStatement(event), nil
+	}
+	args := make(map[string]interface{})
+	if authRequestInfo.BrowserInfo != nil {
+		args["UserAgent"] = authRequestInfo.UserAgent
+		if authRequestInfo.RemoteIP != nil {
+			args["RemoteIP"] = authRequestInfo.RemoteIP.String()
+		}
+	}
+	return u.reduceSecurityNotification(event, &securityNotification{
+		messageType: domain.UnknownUserAgentSignInMessageType,
+		enabled: func(policy *query.NotificationPolicy) bool {
+			return policy.UnknownUserAgentSignIn
+		},
+		recipient: func(ctx context.Context) (*query.NotifyUser, error) {
+			unknown, err := u.queries.IsUnknownUserAgent(ctx, event, event.Aggregate().ID, authRequestInfo.UserAgentID)
+			if err != nil || !unknown {
+				return nil, err
+			}
+			return u.queries.GetNotifyUserByID(ctx, true, event.Aggregate().ID)
+		},
+		args: args,
+	})
+}
+
+// reduceSessionCheckSucceeded handles the checks of the session API,
+// where the first successful check of a session is the sign-in of the user
+func (u *userNotifier) reduceSessionCheckSucceeded(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *session.PasswordCheckedEvent,
+		*session.IntentCheckedEvent,
+		*session.WebAuthNCheckedEvent,
+		*session.TOTPCheckedEvent,
+		*session.OTPSMSCheckedEvent,
+		*session.OTPEmailCheckedEvent,
+		*session.MagicLinkCheckedEvent,
+		*session.X509CheckedEvent:
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Iexe4", "reduce.wrong.event.type %v", sessionCheckedEventTypes)
+	}
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		signIn, err := u.queries.SessionSignIn(ctx, event)
+		if err != nil {
+			return err
+		}
+		// sessions are only identified by a user agent, if the fingerprint was provided
+		if !signIn.First || signIn.UserID == "" ||
+			signIn.UserAgent == nil || signIn.UserAgent.FingerprintID == nil || *signIn.UserAgent.FingerprintID == "" {
+			return nil
+		}
+		userAgentID := *signIn.UserAgent.FingerprintID
+		args := make(map[string]interface{})
+		if userAgent := signIn.UserAgent.Header.Get("User-Agent"); userAgent != "" {
+			args["UserAgent"] = userAgent
+		} else if signIn.UserAgent.Description != nil {
+			args["UserAgent"] = *signIn.UserAgent.Description
+		}
+		if signIn.UserAgent.IP != nil {
+			args["RemoteIP"] = signIn.UserAgent.IP.String()
+		}
+		return u.sendSecurityNotification(ctx, event, &securityNotification{
+			messageType: domain.UnknownUserAgentSignInMessageType,
+			enabled: func(policy *query.NotificationPolicy) bool {
+				return policy.UnknownUserAgentSignIn
+			},
+			recipient: func(ctx context.Context) (*query.NotifyUser, error) {
+				unknown, err := u.queries.IsUnknownUserAgent(ctx, event, signIn.UserID, userAgentID)
+				if err != nil || !unknown {
+					return nil, err
+				}
+				return u.queries.GetNotifyUserByID(ctx, true, signIn.UserID)
+			},
+			args:          args,
+			userID:        signIn.UserID,
+			resourceOwner: signIn.ResourceOwner,
+		})
+	}), nil
+}
+
+func (u *userNotifier) reduceMFAAdded(event eventstore.Event) (*handler.Statement, error) {
+	return u.reduceSecurityNotification(event, &securityNotification{
+		messageType: domain.MFAAddedMessageType,
+		enabled: func(policy *query.NotificationPolicy) bool {
+			return policy.MFAAdded
+		},
+		recipient: u.notifyAggregateUser(event),
+	})
+}
+
+func (u *userNotifier) reduceMFARemoved(event eventstore.Event) (*handler.Statement, error) {
+	return u.reduceSecurityNotification(event, &securityNotification{
+		messageType: domain.MFARemovedMessageType,
+		enabled: func(policy *query.NotificationPolicy) bool {
+			return policy.MFARemoved
+		},
+		recipient: u.notifyAggregateUser(event),
+	})
+}
+
+func (u *userNotifier) reduceEmailChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanEmailChangedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Oogh5", "reduce.wrong.event.type %s", user.HumanEmailChangedType)
+	}
+	return u.reduceSecurityNotification(event, &securityNotification{
+		messageType: domain.EmailChangedMessageType,
+		enabled: func(policy *query.NotificationPolicy) bool {
+			return policy.EmailChanged
+		},
+		// the notification is sent to the previous email address of the user
+		recipient: func(ctx context.Context) (*query.NotifyUser, error) {
+			previousEmail, err := u.queries.PreviousEmail(ctx, e)
+			if err != nil || previousEmail == "" || previousEmail == e.EmailAddress {
+				return nil, err
+			}
+			notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+			if err != nil {
+				return nil, err
+			}
+			notifyUser.LastEmail = string(previousEmail)
+			return notifyUser, nil
+		},
+		args: map[string]interface{}{
+			"NewEmail": e.EmailAddress,
+		},
+	})
+}
+
+func (u *userNotifier) reducePhoneChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPhoneChangedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-ieK4u", "reduce.wrong.event.type %s", user.HumanPhoneChangedType)
+	}
+	return u.reduceSecurityNotification(event, &securityNotification{
+		messageType: domain.PhoneChangedMessageType,
+		enabled: func(policy *query.NotificationPolicy) bool {
+			return policy.PhoneChanged
+		},
+		// the notification is sent to the previous phone number of the user
+		recipient: func(ctx context.Context) (*query.NotifyUser, error) {
+			previousPhone, err := u.queries.PreviousPhone(ctx, e)
+			if err != nil || previousPhone == "" || previousPhone == e.PhoneNumber {
+				return nil, err
+			}
+			notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+			if err != nil {
+				return nil, err
+			}
+			notifyUser.LastPhone = string(previousPhone)
+			return notifyUser, nil
+		},
+		args: map[string]interface{}{
+			"NewPhone": e.PhoneNumber,
+		},
+		sms: true,
+	})
+}
+
+func (u *userNotifier) reduceUserLocked(event eventstore.Event) (*handler.Statement, error) {
+	return u.reduceSecurityNotification(event, &securityNotification{
+		messageType: domain.AccountLockedMessageType,
+		enabled: func(policy *query.NotificationPolicy) bool {
+			return policy.AccountLocked
+		},
+		recipient: u.notifyAggregateUser(event),
+	})
+}
+
+func (u *userNotifier) reduceMachineCredentialAdded(event eventstore.Event) (*handler.Statement, error) {
+	return u.reduceSecurityNotification(event, &securityNotification{
+		messageType: domain.MachineCredentialAddedMessageType,
+		enabled: func(policy *query.NotificationPolicy) bool {
+			return policy.MachineCredentialAdded
+		},
+		// machine users have no email address, so the user who created the credential is notified
+		recipient: func(ctx context.Context) (*query.NotifyUser, error) {
+			notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, event.Creator())
+			if zerrors.IsNotFound(err) {
+				return nil, nil
+			}
+			if err != nil || notifyUser.Type != domain.UserTypeHuman {
+				return nil, err
+			}
+			return notifyUser, nil
+		},
+		args: map[string]interface{}{
+			"MachineUserID": event.Aggregate().ID,
+		},
+	})
+}
+
+func (u *userNotifier) notifyAggregateUser(event eventstore.Event) func(ctx context.Context) (*query.NotifyUser, error) {
+	return func(ctx context.Context) (*query.NotifyUser, error) {
+		return u.queries.GetNotifyUserByID(ctx, true, event.Aggregate().ID)
+	}
+}
+
+func (u *userNotifier) reduceSecurityNotification(event eventstore.Event, notification *securityNotification) (*handler.Statement, error) {
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		return u.sendSecurityNotification(HandlerContext(event.Aggregate()), event, notification)
+	}), nil
+}
+
+func (u *userNotifier) sendSecurityNotification(ctx context.Context, event eventstore.Event, notification *securityNotification) error {
+	userID, resourceOwner := event.Aggregate().ID, event.Aggregate().ResourceOwner
+	var triggeringAggregateID string
+	if notification.userID != "" {
+		userID, resourceOwner = notification.userID, notification.resourceOwner
+		triggeringAggregateID = event.Aggregate().ID
+	}
+	alreadyHandled, err := u.queries.IsSecurityNotificationSent(ctx, event, userID, triggeringAggregateID, notification.messageType)
+	if err != nil {
+		return err
+	}
+	if alreadyHandled {
+		return nil
+	}
+
+	notificationPolicy, err := u.queries.NotificationPolicyByOrg(ctx, true, resourceOwner, false)
+	if zerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !notification.enabled(notificationPolicy) {
+		return nil
+	}
+
+	notifyUser, err := notification.recipient(ctx)
+	if err != nil {
+		return err
+	}
+	if notifyUser == nil {
+		return nil
+	}
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, notifyUser.ResourceOwner, false)
+	if err != nil {
+		return err
+	}
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, notification.messageType)
+	if err != nil {
+		return err
+	}
+	ctx, err = u.queries.PrimaryDomainOrigin(ctx)
+	if err != nil {
+		return err
+	}
+	var notify types.Notify
+	if notification.sms {
+		notify = types.SendSMSTwilio(ctx, u.channels, translator, notifyUser, colors, event)
+	} else {
+		template, err := u.queries.MailTemplate(ctx, notifyUser.ResourceOwner, notification.messageType, notifyUser)
+		if err != nil {
+			return err
+		}
+		notify = types.SendEmail(ctx, u.channels, template, translator, notifyUser, colors, event)
+	}
+	err = notify.SendSecurityNotification(ctx, notifyUser, notification.messageType, notification.args)
+	if err != nil {
+		return err
+	}
+	return u.commands.SecurityNotificationSent(ctx, resourceOwner, userID, notification.messageType, triggeringAggregateID, event.Sequence())
+}
+
 func (u *userNotifier) checkIfCodeAlreadyHandledOrExpired(ctx context.Context, event eventstore.Event, expiry time.Duration, data map[string]interface{}, eventTypes ...eventstore.EventType) (bool, error) {
 	if event.CreatedAt().Add(expiry).Before(time.Now().UTC()) {
 		return true, nil
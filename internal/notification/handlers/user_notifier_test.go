@@ -3,7 +3,10 @@ package handlers
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
 	"testing"
 	"time"
 
@@ -16,18 +19,20 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 	es_repo_mock "github.com/zitadel/zitadel/internal/eventstore/repository/mock"
+	"github.com/zitadel/zitadel/internal/notification/channels/email"
 	channel_mock "github.com/zitadel/zitadel/internal/notification/channels/mock"
-	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
-	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/sms"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/notification/handlers/mock"
 	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/notification/types"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 const (
@@ -68,24 +73,24 @@ func Test_userNotifier_reduceInitCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanInitCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &user.HumanInitialCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &user.HumanInitialCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:              code,
-						Expiry:            time.Hour,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					Code:              code,
+					Expiry:            time.Hour,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}, {
 		name: "asset url without event trigger url",
@@ -107,23 +112,23 @@ func Test_userNotifier_reduceInitCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanInitCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &user.HumanInitialCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &user.HumanInitialCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:   code,
-						Expiry: time.Hour,
-					},
-				}, w
+					Code:   code,
+					Expiry: time.Hour,
+				},
+			}, w
 		},
 	}, {
 		name: "button url with event trigger url",
@@ -140,24 +145,24 @@ func Test_userNotifier_reduceInitCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanInitCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &user.HumanInitialCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &user.HumanInitialCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:              code,
-						Expiry:            time.Hour,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					Code:              code,
+					Expiry:            time.Hour,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}, {
 		name: "button url without event trigger url",
@@ -180,23 +185,23 @@ func Test_userNotifier_reduceInitCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanInitCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &user.HumanInitialCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &user.HumanInitialCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:   code,
-						Expiry: time.Hour,
-					},
-				}, w
+					Code:   code,
+					Expiry: time.Hour,
+				},
+			}, w
 		},
 	}}
 	// TODO: Why don't we have an url template on user.HumanInitialCodeAddedEvent?
@@ -241,26 +246,26 @@ func Test_userNotifier_reduceEmailCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanEmailVerificationCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &user.HumanEmailCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &user.HumanEmailCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:              code,
-						Expiry:            time.Hour,
-						URLTemplate:       "",
-						CodeReturned:      false,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					Code:              code,
+					Expiry:            time.Hour,
+					URLTemplate:       "",
+					CodeReturned:      false,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}, {
 		name: "asset url without event trigger url",
@@ -282,25 +287,25 @@ func Test_userNotifier_reduceEmailCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanEmailVerificationCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &user.HumanEmailCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &user.HumanEmailCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:         code,
-						Expiry:       time.Hour,
-						URLTemplate:  "",
-						CodeReturned: false,
-					},
-				}, w
+					Code:         code,
+					Expiry:       time.Hour,
+					URLTemplate:  "",
+					CodeReturned: false,
+				},
+			}, w
 		},
 	}, {
 		name: "button url with event trigger url",
@@ -317,27 +322,27 @@ func Test_userNotifier_reduceEmailCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanEmailVerificationCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+				SMSTokenCrypto: nil,
+			}, args{
+				event: &user.HumanEmailCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-					SMSTokenCrypto: nil,
-				}, args{
-					event: &user.HumanEmailCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:              code,
-						Expiry:            time.Hour,
-						URLTemplate:       "",
-						CodeReturned:      false,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					Code:              code,
+					Expiry:            time.Hour,
+					URLTemplate:       "",
+					CodeReturned:      false,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}, {
 		name: "button url without event trigger url",
@@ -360,25 +365,25 @@ func Test_userNotifier_reduceEmailCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanEmailVerificationCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &user.HumanEmailCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &user.HumanEmailCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:         code,
-						Expiry:       time.Hour,
-						URLTemplate:  "",
-						CodeReturned: false,
-					},
-				}, w
+					Code:         code,
+					Expiry:       time.Hour,
+					URLTemplate:  "",
+					CodeReturned: false,
+				},
+			}, w
 		},
 	}, {
 		name: "button url with url template and event trigger url",
@@ -396,27 +401,27 @@ func Test_userNotifier_reduceEmailCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanEmailVerificationCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+				SMSTokenCrypto: nil,
+			}, args{
+				event: &user.HumanEmailCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-					SMSTokenCrypto: nil,
-				}, args{
-					event: &user.HumanEmailCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:              code,
-						Expiry:            time.Hour,
-						URLTemplate:       urlTemplate,
-						CodeReturned:      false,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					Code:              code,
+					Expiry:            time.Hour,
+					URLTemplate:       urlTemplate,
+					CodeReturned:      false,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}}
 	for _, tt := range tests {
@@ -460,26 +465,26 @@ func Test_userNotifier_reducePasswordCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().PasswordCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &user.HumanPasswordCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &user.HumanPasswordCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:              code,
-						Expiry:            time.Hour,
-						URLTemplate:       "",
-						CodeReturned:      false,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					Code:              code,
+					Expiry:            time.Hour,
+					URLTemplate:       "",
+					CodeReturned:      false,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}, {
 		name: "asset url without event trigger url",
@@ -501,25 +506,25 @@ func Test_userNotifier_reducePasswordCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().PasswordCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &user.HumanPasswordCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &user.HumanPasswordCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:         code,
-						Expiry:       time.Hour,
-						URLTemplate:  "",
-						CodeReturned: false,
-					},
-				}, w
+					Code:         code,
+					Expiry:       time.Hour,
+					URLTemplate:  "",
+					CodeReturned: false,
+				},
+			}, w
 		},
 	}, {
 		name: "button url with event trigger url",
@@ -536,27 +541,27 @@ func Test_userNotifier_reducePasswordCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().PasswordCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+				SMSTokenCrypto: nil,
+			}, args{
+				event: &user.HumanPasswordCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-					SMSTokenCrypto: nil,
-				}, args{
-					event: &user.HumanPasswordCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:              code,
-						Expiry:            time.Hour,
-						URLTemplate:       "",
-						CodeReturned:      false,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					Code:              code,
+					Expiry:            time.Hour,
+					URLTemplate:       "",
+					CodeReturned:      false,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}, {
 		name: "button url without event trigger url",
@@ -579,25 +584,25 @@ func Test_userNotifier_reducePasswordCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().PasswordCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &user.HumanPasswordCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &user.HumanPasswordCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:         code,
-						Expiry:       time.Hour,
-						URLTemplate:  "",
-						CodeReturned: false,
-					},
-				}, w
+					Code:         code,
+					Expiry:       time.Hour,
+					URLTemplate:  "",
+					CodeReturned: false,
+				},
+			}, w
 		},
 	}, {
 		name: "button url with url template and event trigger url",
@@ -615,27 +620,27 @@ func Test_userNotifier_reducePasswordCodeAdded(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().PasswordCodeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+				SMSTokenCrypto: nil,
+			}, args{
+				event: &user.HumanPasswordCodeAddedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-					SMSTokenCrypto: nil,
-				}, args{
-					event: &user.HumanPasswordCodeAddedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:              code,
-						Expiry:            time.Hour,
-						URLTemplate:       urlTemplate,
-						CodeReturned:      false,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					Code:              code,
+					Expiry:            time.Hour,
+					URLTemplate:       urlTemplate,
+					CodeReturned:      false,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}}
 	for _, tt := range tests {
@@ -678,21 +683,21 @@ func Test_userNotifier_reduceDomainClaimed(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().UserDomainClaimedSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+			}, args{
+				event: &user.DomainClaimedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-				}, args{
-					event: &user.DomainClaimedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}, {
 		name: "asset url without event trigger url",
@@ -713,20 +718,20 @@ func Test_userNotifier_reduceDomainClaimed(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().UserDomainClaimedSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+			}, args{
+				event: &user.DomainClaimedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-				}, args{
-					event: &user.DomainClaimedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-					},
-				}, w
+				},
+			}, w
 		},
 	}}
 	for _, tt := range tests {
@@ -770,27 +775,27 @@ func Test_userNotifier_reducePasswordlessCodeRequested(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanPasswordlessInitCodeSent(gomock.Any(), userID, orgID, codeID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &user.HumanPasswordlessInitCodeRequestedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &user.HumanPasswordlessInitCodeRequestedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						ID:                codeID,
-						Code:              code,
-						Expiry:            time.Hour,
-						URLTemplate:       "",
-						CodeReturned:      false,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					ID:                codeID,
+					Code:              code,
+					Expiry:            time.Hour,
+					URLTemplate:       "",
+					CodeReturned:      false,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}, {
 		name: "asset url without event trigger url",
@@ -812,26 +817,26 @@ func Test_userNotifier_reducePasswordlessCodeRequested(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanPasswordlessInitCodeSent(gomock.Any(), userID, orgID, codeID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &user.HumanPasswordlessInitCodeRequestedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &user.HumanPasswordlessInitCodeRequestedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						ID:           codeID,
-						Code:         code,
-						Expiry:       time.Hour,
-						URLTemplate:  "",
-						CodeReturned: false,
-					},
-				}, w
+					ID:           codeID,
+					Code:         code,
+					Expiry:       time.Hour,
+					URLTemplate:  "",
+					CodeReturned: false,
+				},
+			}, w
 		},
 	}, {
 		name: "button url with event trigger url",
@@ -848,28 +853,28 @@ func Test_userNotifier_reducePasswordlessCodeRequested(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanPasswordlessInitCodeSent(gomock.Any(), userID, orgID, codeID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+				SMSTokenCrypto: nil,
+			}, args{
+				event: &user.HumanPasswordlessInitCodeRequestedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-					SMSTokenCrypto: nil,
-				}, args{
-					event: &user.HumanPasswordlessInitCodeRequestedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						ID:                codeID,
-						Code:              code,
-						Expiry:            time.Hour,
-						URLTemplate:       "",
-						CodeReturned:      false,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					ID:                codeID,
+					Code:              code,
+					Expiry:            time.Hour,
+					URLTemplate:       "",
+					CodeReturned:      false,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}, {
 		name: "button url without event trigger url",
@@ -892,26 +897,26 @@ func Test_userNotifier_reducePasswordlessCodeRequested(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanPasswordlessInitCodeSent(gomock.Any(), userID, orgID, codeID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &user.HumanPasswordlessInitCodeRequestedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &user.HumanPasswordlessInitCodeRequestedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						ID:           codeID,
-						Code:         code,
-						Expiry:       time.Hour,
-						URLTemplate:  "",
-						CodeReturned: false,
-					},
-				}, w
+					ID:           codeID,
+					Code:         code,
+					Expiry:       time.Hour,
+					URLTemplate:  "",
+					CodeReturned: false,
+				},
+			}, w
 		},
 	}, {
 		name: "button url with url template and event trigger url",
@@ -929,28 +934,28 @@ func Test_userNotifier_reducePasswordlessCodeRequested(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().HumanPasswordlessInitCodeSent(gomock.Any(), userID, orgID, codeID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+				SMSTokenCrypto: nil,
+			}, args{
+				event: &user.HumanPasswordlessInitCodeRequestedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-					SMSTokenCrypto: nil,
-				}, args{
-					event: &user.HumanPasswordlessInitCodeRequestedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						ID:                codeID,
-						Code:              code,
-						Expiry:            time.Hour,
-						URLTemplate:       urlTemplate,
-						CodeReturned:      false,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					ID:                codeID,
+					Code:              code,
+					Expiry:            time.Hour,
+					URLTemplate:       urlTemplate,
+					CodeReturned:      false,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}}
 	for _, tt := range tests {
@@ -996,21 +1001,21 @@ func Test_userNotifier_reducePasswordChanged(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().PasswordChangeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+			}, args{
+				event: &user.HumanPasswordChangedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-				}, args{
-					event: &user.HumanPasswordChangedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}, {
 		name: "asset url without event trigger url",
@@ -1034,20 +1039,20 @@ func Test_userNotifier_reducePasswordChanged(t *testing.T) {
 			expectTemplateQueries(queries, givenTemplate)
 			commands.EXPECT().PasswordChangeSent(gomock.Any(), orgID, userID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+			}, args{
+				event: &user.HumanPasswordChangedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-				}, args{
-					event: &user.HumanPasswordChangedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-					},
-				}, w
+				},
+			}, w
 		},
 	}}
 	for _, tt := range tests {
@@ -1092,26 +1097,26 @@ func Test_userNotifier_reduceOTPEmailChallenged(t *testing.T) {
 			queries.EXPECT().SessionByID(gomock.Any(), gomock.Any(), userID, gomock.Any()).Return(&query.Session{}, nil)
 			commands.EXPECT().OTPEmailSent(gomock.Any(), userID, orgID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &session.OTPEmailChallengedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &session.OTPEmailChallengedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:              code,
-						Expiry:            time.Hour,
-						URLTmpl:           "",
-						ReturnCode:        false,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					Code:              code,
+					Expiry:            time.Hour,
+					URLTmpl:           "",
+					ReturnCode:        false,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}, {
 		name: "asset url without event trigger url",
@@ -1134,25 +1139,25 @@ func Test_userNotifier_reduceOTPEmailChallenged(t *testing.T) {
 			}, nil)
 			commands.EXPECT().OTPEmailSent(gomock.Any(), userID, orgID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &session.OTPEmailChallengedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &session.OTPEmailChallengedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:       code,
-						Expiry:     time.Hour,
-						URLTmpl:    "",
-						ReturnCode: false,
-					},
-				}, w
+					Code:       code,
+					Expiry:     time.Hour,
+					URLTmpl:    "",
+					ReturnCode: false,
+				},
+			}, w
 		},
 	}, {
 		name: "button url with event trigger url",
@@ -1170,27 +1175,27 @@ func Test_userNotifier_reduceOTPEmailChallenged(t *testing.T) {
 			queries.EXPECT().SessionByID(gomock.Any(), gomock.Any(), userID, gomock.Any()).Return(&query.Session{}, nil)
 			commands.EXPECT().OTPEmailSent(gomock.Any(), userID, orgID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+				SMSTokenCrypto: nil,
+			}, args{
+				event: &session.OTPEmailChallengedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-					SMSTokenCrypto: nil,
-				}, args{
-					event: &session.OTPEmailChallengedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:              code,
-						Expiry:            time.Hour,
-						URLTmpl:           "",
-						ReturnCode:        false,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					Code:              code,
+					Expiry:            time.Hour,
+					URLTmpl:           "",
+					ReturnCode:        false,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}, {
 		name: "button url without event trigger url",
@@ -1214,24 +1219,24 @@ func Test_userNotifier_reduceOTPEmailChallenged(t *testing.T) {
 			queries.EXPECT().SessionByID(gomock.Any(), gomock.Any(), userID, gomock.Any()).Return(&query.Session{}, nil)
 			commands.EXPECT().OTPEmailSent(gomock.Any(), userID, orgID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+			}, args{
+				event: &session.OTPEmailChallengedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-				}, args{
-					event: &session.OTPEmailChallengedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:       code,
-						Expiry:     time.Hour,
-						ReturnCode: false,
-					},
-				}, w
+					Code:       code,
+					Expiry:     time.Hour,
+					ReturnCode: false,
+				},
+			}, w
 		},
 	}, {
 		name: "button url with url template and event trigger url",
@@ -1250,27 +1255,27 @@ func Test_userNotifier_reduceOTPEmailChallenged(t *testing.T) {
 			queries.EXPECT().SessionByID(gomock.Any(), gomock.Any(), userID, gomock.Any()).Return(&query.Session{}, nil)
 			commands.EXPECT().OTPEmailSent(gomock.Any(), userID, orgID).Return(nil)
 			return fields{
-					queries:  queries,
-					commands: commands,
-					es: eventstore.NewEventstore(&eventstore.Config{
-						Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				queries:  queries,
+				commands: commands,
+				es: eventstore.NewEventstore(&eventstore.Config{
+					Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+				}),
+				userDataCrypto: codeAlg,
+				SMSTokenCrypto: nil,
+			}, args{
+				event: &session.OTPEmailChallengedEvent{
+					BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+						AggregateID:   userID,
+						ResourceOwner: sql.NullString{String: orgID},
+						CreationDate:  time.Now().UTC(),
 					}),
-					userDataCrypto: codeAlg,
-					SMSTokenCrypto: nil,
-				}, args{
-					event: &session.OTPEmailChallengedEvent{
-						BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
-							AggregateID:   userID,
-							ResourceOwner: sql.NullString{String: orgID},
-							CreationDate:  time.Now().UTC(),
-						}),
-						Code:              code,
-						Expiry:            time.Hour,
-						ReturnCode:        false,
-						URLTmpl:           urlTemplate,
-						TriggeredAtOrigin: eventOrigin,
-					},
-				}, w
+					Code:              code,
+					Expiry:            time.Hour,
+					ReturnCode:        false,
+					URLTmpl:           urlTemplate,
+					TriggeredAtOrigin: eventOrigin,
+				},
+			}, w
 		},
 	}}
 	for _, tt := range tests {
@@ -1289,6 +1294,790 @@ func Test_userNotifier_reduceOTPEmailChallenged(t *testing.T) {
 	}
 }
 
+func Test_userNotifier_reduceSignInSucceeded(t *testing.T) {
+	expectMailSubject := "New sign-in to your account"
+	signInEvent := func(authRequestInfo *user.AuthRequestInfo) *user.HumanPasswordCheckSucceededEvent {
+		return &user.HumanPasswordCheckSucceededEvent{
+			BaseEvent:       *securityEventBase(userID, 5),
+			AuthRequestInfo: authRequestInfo,
+		}
+	}
+	authRequestInfo := &user.AuthRequestInfo{
+		UserAgentID: "agent1",
+		BrowserInfo: &user.BrowserInfo{
+			UserAgent: "browser",
+			RemoteIP:  net.ParseIP("1.2.3.4"),
+		},
+	}
+	tests := []struct {
+		name string
+		test func(*gomock.Controller, *mock.MockQueries, *mock.MockCommands) (fields, args, want)
+	}{{
+		name: "unknown user agent, sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.message = messages.Email{
+				Recipients: []string{lastEmail},
+				Subject:    expectMailSubject,
+				Content:    securityNotificationContent,
+			}
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				UnknownUserAgentSignIn: true,
+			}, nil)
+			expectSecurityNotificationQueries(queries, securityNotifyUser(), true)
+			commands.EXPECT().SecurityNotificationSent(gomock.Any(), orgID, userID, domain.UnknownUserAgentSignInMessageType, "", uint64(5)).Return(nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es: newSecurityEventstore(es_repo_mock.NewRepo(t).
+					// not sent yet
+					ExpectFilterEvents().
+					// no sign-in with the user agent, neither through the login UI nor a session
+					ExpectFilterEvents().
+					ExpectFilterEvents().
+					// but a previous sign-in
+					ExpectFilterEvents(signInEvent(&user.AuthRequestInfo{UserAgentID: "agent2"}))),
+			}, args{
+				event: signInEvent(authRequestInfo),
+			}, w
+		},
+	}, {
+		name: "known user agent, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				UnknownUserAgentSignIn: true,
+			}, nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es: newSecurityEventstore(es_repo_mock.NewRepo(t).
+					ExpectFilterEvents().
+					ExpectFilterEvents(signInEvent(authRequestInfo))),
+			}, args{
+				event: signInEvent(authRequestInfo),
+			}, w
+		},
+	}, {
+		name: "first sign-in, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				UnknownUserAgentSignIn: true,
+			}, nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es: newSecurityEventstore(es_repo_mock.NewRepo(t).
+					ExpectFilterEvents().
+					ExpectFilterEvents().
+					ExpectFilterEvents().
+					ExpectFilterEvents().
+					ExpectFilterEvents()),
+			}, args{
+				event: signInEvent(authRequestInfo),
+			}, w
+		},
+	}, {
+		name: "already sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es: newSecurityEventstore(es_repo_mock.NewRepo(t).
+					ExpectFilterEvents(&user.SecurityNotificationSentEvent{
+						BaseEvent: *securityEventBase(userID, 6),
+					})),
+			}, args{
+				event: signInEvent(authRequestInfo),
+			}, w
+		},
+	}, {
+		name: "disabled by policy, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{}, nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es:       newSecurityEventstore(es_repo_mock.NewRepo(t).ExpectFilterEvents()),
+			}, args{
+				event: signInEvent(authRequestInfo),
+			}, w
+		},
+	}, {
+		name: "without user agent, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			return fields{
+				queries:  queries,
+				commands: commands,
+			}, args{
+				event: signInEvent(nil),
+			}, w
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			f, a, w := tt.test(ctrl, queries, commands)
+			stmt, err := newUserNotifier(t, ctrl, queries, f, a, w).reduceSignInSucceeded(a.event)
+			assert.NoError(t, err)
+			if stmt.Execute != nil {
+				assert.NoError(t, stmt.Execute(nil, ""))
+			}
+		})
+	}
+}
+
+func Test_userNotifier_reduceSessionCheckSucceeded(t *testing.T) {
+	expectMailSubject := "New sign-in to your account"
+	sessionID := "session1"
+	sessionEvents := func(userAgentID string) []eventstore.Event {
+		return []eventstore.Event{
+			securityRepoEvent(session.AggregateType, sessionID, session.AddedType, 1, &session.AddedEvent{
+				UserAgent: &domain.UserAgent{
+					FingerprintID: &userAgentID,
+					IP:            net.ParseIP("1.2.3.4"),
+					Header:        http.Header{"User-Agent": []string{"browser"}},
+				},
+			}),
+			securityRepoEvent(session.AggregateType, sessionID, session.UserCheckedType, 2, &session.UserCheckedEvent{
+				UserID:            userID,
+				UserResourceOwner: orgID,
+			}),
+		}
+	}
+	checkedEvent := &session.PasswordCheckedEvent{
+		BaseEvent: *securityEventBase(sessionID, 3),
+	}
+	tests := []struct {
+		name string
+		test func(*gomock.Controller, *mock.MockQueries, *mock.MockCommands) (fields, args, want)
+	}{{
+		name: "unknown user agent, sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.message = messages.Email{
+				Recipients: []string{lastEmail},
+				Subject:    expectMailSubject,
+				Content:    securityNotificationContent,
+			}
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				UnknownUserAgentSignIn: true,
+			}, nil)
+			expectSecurityNotificationQueries(queries, securityNotifyUser(), true)
+			commands.EXPECT().SecurityNotificationSent(gomock.Any(), orgID, userID, domain.UnknownUserAgentSignInMessageType, sessionID, uint64(3)).Return(nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es: newSecurityEventstore(es_repo_mock.NewRepo(t).
+					ExpectFilterEvents(sessionEvents("agent1")...).
+					// not sent yet
+					ExpectFilterEvents().
+					// no sign-in with the user agent, neither through the login UI nor a session
+					ExpectFilterEvents().
+					ExpectFilterEvents().
+					// but a previous sign-in
+					ExpectFilterEvents(&user.HumanPasswordCheckSucceededEvent{
+						BaseEvent: *securityEventBase(userID, 1),
+					})),
+			}, args{
+				event: checkedEvent,
+			}, w
+		},
+	}, {
+		name: "without fingerprint, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es: newSecurityEventstore(es_repo_mock.NewRepo(t).
+					ExpectFilterEvents(sessionEvents("")...)),
+			}, args{
+				event: checkedEvent,
+			}, w
+		},
+	}, {
+		name: "not the first check, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es: newSecurityEventstore(es_repo_mock.NewRepo(t).
+					ExpectFilterEvents(append(sessionEvents("agent1"),
+						securityRepoEvent(session.AggregateType, sessionID, session.TOTPCheckedType, 2, &session.TOTPCheckedEvent{}),
+					)...)),
+			}, args{
+				event: checkedEvent,
+			}, w
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			f, a, w := tt.test(ctrl, queries, commands)
+			stmt, err := newUserNotifier(t, ctrl, queries, f, a, w).reduceSessionCheckSucceeded(a.event)
+			assert.NoError(t, err)
+			assert.NoError(t, stmt.Execute(nil, ""))
+		})
+	}
+}
+
+func Test_userNotifier_reduceMFAAdded(t *testing.T) {
+	expectMailSubject := "A new authentication factor was added to your account"
+	tests := []struct {
+		name string
+		test func(*gomock.Controller, *mock.MockQueries, *mock.MockCommands) (fields, args, want)
+	}{{
+		name: "sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.message = messages.Email{
+				Recipients: []string{lastEmail},
+				Subject:    expectMailSubject,
+				Content:    securityNotificationContent,
+			}
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				MFAAdded: true,
+			}, nil)
+			expectSecurityNotificationQueries(queries, securityNotifyUser(), true)
+			commands.EXPECT().SecurityNotificationSent(gomock.Any(), orgID, userID, domain.MFAAddedMessageType, "", uint64(5)).Return(nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es:       newSecurityEventstore(es_repo_mock.NewRepo(t).ExpectFilterEvents()),
+			}, args{
+				event: &user.HumanOTPVerifiedEvent{
+					BaseEvent: *securityEventBase(userID, 5),
+				},
+			}, w
+		},
+	}, {
+		name: "disabled by policy, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				MFARemoved: true,
+			}, nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es:       newSecurityEventstore(es_repo_mock.NewRepo(t).ExpectFilterEvents()),
+			}, args{
+				event: &user.HumanOTPVerifiedEvent{
+					BaseEvent: *securityEventBase(userID, 5),
+				},
+			}, w
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			f, a, w := tt.test(ctrl, queries, commands)
+			stmt, err := newUserNotifier(t, ctrl, queries, f, a, w).reduceMFAAdded(a.event)
+			assert.NoError(t, err)
+			assert.NoError(t, stmt.Execute(nil, ""))
+		})
+	}
+}
+
+func Test_userNotifier_reduceMFARemoved(t *testing.T) {
+	expectMailSubject := "An authentication factor was removed from your account"
+	tests := []struct {
+		name string
+		test func(*gomock.Controller, *mock.MockQueries, *mock.MockCommands) (fields, args, want)
+	}{{
+		name: "sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.message = messages.Email{
+				Recipients: []string{lastEmail},
+				Subject:    expectMailSubject,
+				Content:    securityNotificationContent,
+			}
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				MFARemoved: true,
+			}, nil)
+			expectSecurityNotificationQueries(queries, securityNotifyUser(), true)
+			commands.EXPECT().SecurityNotificationSent(gomock.Any(), orgID, userID, domain.MFARemovedMessageType, "", uint64(5)).Return(nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es:       newSecurityEventstore(es_repo_mock.NewRepo(t).ExpectFilterEvents()),
+			}, args{
+				event: &user.HumanOTPRemovedEvent{
+					BaseEvent: *securityEventBase(userID, 5),
+				},
+			}, w
+		},
+	}, {
+		name: "policy not found, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(nil, zerrors.ThrowNotFound(nil, "ID", "not found"))
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es:       newSecurityEventstore(es_repo_mock.NewRepo(t).ExpectFilterEvents()),
+			}, args{
+				event: &user.HumanOTPRemovedEvent{
+					BaseEvent: *securityEventBase(userID, 5),
+				},
+			}, w
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			f, a, w := tt.test(ctrl, queries, commands)
+			stmt, err := newUserNotifier(t, ctrl, queries, f, a, w).reduceMFARemoved(a.event)
+			assert.NoError(t, err)
+			assert.NoError(t, stmt.Execute(nil, ""))
+		})
+	}
+}
+
+func Test_userNotifier_reduceEmailChanged(t *testing.T) {
+	expectMailSubject := "The email address of your account has changed"
+	emailChangedEvent := func(sequence uint64, email domain.EmailAddress) *user.HumanEmailChangedEvent {
+		return &user.HumanEmailChangedEvent{
+			BaseEvent:    *securityEventBase(userID, sequence),
+			EmailAddress: email,
+		}
+	}
+	tests := []struct {
+		name string
+		test func(*gomock.Controller, *mock.MockQueries, *mock.MockCommands) (fields, args, want)
+	}{{
+		name: "sent to previous email",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.message = messages.Email{
+				Recipients: []string{"previous@email.com"},
+				Subject:    expectMailSubject,
+				Content:    securityNotificationContent,
+			}
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				EmailChanged: true,
+			}, nil)
+			expectSecurityNotificationQueries(queries, securityNotifyUser(), true)
+			commands.EXPECT().SecurityNotificationSent(gomock.Any(), orgID, userID, domain.EmailChangedMessageType, "", uint64(5)).Return(nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es: newSecurityEventstore(es_repo_mock.NewRepo(t).
+					ExpectFilterEvents().
+					ExpectFilterEvents(securityRepoEvent(user.AggregateType, userID, user.HumanEmailChangedType, 2, &user.HumanEmailChangedEvent{EmailAddress: "previous@email.com"}))),
+			}, args{
+				event: emailChangedEvent(5, lastEmail),
+			}, w
+		},
+	}, {
+		name: "without previous email, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				EmailChanged: true,
+			}, nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es: newSecurityEventstore(es_repo_mock.NewRepo(t).
+					ExpectFilterEvents().
+					ExpectFilterEvents()),
+			}, args{
+				event: emailChangedEvent(5, lastEmail),
+			}, w
+		},
+	}, {
+		name: "unchanged email, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				EmailChanged: true,
+			}, nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es: newSecurityEventstore(es_repo_mock.NewRepo(t).
+					ExpectFilterEvents().
+					ExpectFilterEvents(securityRepoEvent(user.AggregateType, userID, user.HumanEmailChangedType, 2, &user.HumanEmailChangedEvent{EmailAddress: lastEmail}))),
+			}, args{
+				event: emailChangedEvent(5, lastEmail),
+			}, w
+		},
+	}, {
+		name: "wrong event type",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.err = func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.True(t, zerrors.IsErrorInvalidArgument(err))
+			}
+			return fields{
+				queries:  queries,
+				commands: commands,
+			}, args{
+				event: &user.HumanPhoneChangedEvent{
+					BaseEvent: *securityEventBase(userID, 5),
+				},
+			}, w
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			f, a, w := tt.test(ctrl, queries, commands)
+			stmt, err := newUserNotifier(t, ctrl, queries, f, a, w).reduceEmailChanged(a.event)
+			if w.err != nil {
+				w.err(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, stmt.Execute(nil, ""))
+		})
+	}
+}
+
+func Test_userNotifier_reducePhoneChanged(t *testing.T) {
+	phoneChangedEvent := func(sequence uint64, phone domain.PhoneNumber) *user.HumanPhoneChangedEvent {
+		return &user.HumanPhoneChangedEvent{
+			BaseEvent:   *securityEventBase(userID, sequence),
+			PhoneNumber: phone,
+		}
+	}
+	tests := []struct {
+		name string
+		test func(*gomock.Controller, *mock.MockQueries, *mock.MockCommands) (fields, args, want)
+	}{{
+		name: "sent to previous phone",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.sms = &messages.SMS{
+				RecipientPhoneNumber: "+41791234567",
+				Content:              "The phone number of your account was changed to +41797654321. If this change was not done by you, please contact your administrator immediately.",
+				Channel:              domain.PhoneChannelSMS.String(),
+			}
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				PhoneChanged: true,
+			}, nil)
+			expectSecurityNotificationQueries(queries, securityNotifyUser(), false)
+			commands.EXPECT().SecurityNotificationSent(gomock.Any(), orgID, userID, domain.PhoneChangedMessageType, "", uint64(5)).Return(nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es: newSecurityEventstore(es_repo_mock.NewRepo(t).
+					ExpectFilterEvents().
+					ExpectFilterEvents(securityRepoEvent(user.AggregateType, userID, user.HumanPhoneChangedType, 2, &user.HumanPhoneChangedEvent{PhoneNumber: "+41791234567"}))),
+			}, args{
+				event: phoneChangedEvent(5, "+41797654321"),
+			}, w
+		},
+	}, {
+		name: "previous phone removed, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				PhoneChanged: true,
+			}, nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es: newSecurityEventstore(es_repo_mock.NewRepo(t).
+					ExpectFilterEvents().
+					ExpectFilterEvents(
+						securityRepoEvent(user.AggregateType, userID, user.HumanPhoneChangedType, 2, &user.HumanPhoneChangedEvent{PhoneNumber: "+41791234567"}),
+						securityRepoEvent(user.AggregateType, userID, user.HumanPhoneRemovedType, 3, &user.HumanPhoneRemovedEvent{}),
+					)),
+			}, args{
+				event: phoneChangedEvent(5, "+41797654321"),
+			}, w
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			f, a, w := tt.test(ctrl, queries, commands)
+			stmt, err := newUserNotifier(t, ctrl, queries, f, a, w).reducePhoneChanged(a.event)
+			assert.NoError(t, err)
+			assert.NoError(t, stmt.Execute(nil, ""))
+		})
+	}
+}
+
+func Test_userNotifier_reduceUserLocked(t *testing.T) {
+	expectMailSubject := "Your account has been locked"
+	tests := []struct {
+		name string
+		test func(*gomock.Controller, *mock.MockQueries, *mock.MockCommands) (fields, args, want)
+	}{{
+		name: "sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.message = messages.Email{
+				Recipients: []string{lastEmail},
+				Subject:    expectMailSubject,
+				Content:    securityNotificationContent,
+			}
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				AccountLocked: true,
+			}, nil)
+			expectSecurityNotificationQueries(queries, securityNotifyUser(), true)
+			commands.EXPECT().SecurityNotificationSent(gomock.Any(), orgID, userID, domain.AccountLockedMessageType, "", uint64(5)).Return(nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es:       newSecurityEventstore(es_repo_mock.NewRepo(t).ExpectFilterEvents()),
+			}, args{
+				event: &user.UserLockedEvent{
+					BaseEvent: *securityEventBase(userID, 5),
+				},
+			}, w
+		},
+	}, {
+		name: "disabled by policy, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{}, nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es:       newSecurityEventstore(es_repo_mock.NewRepo(t).ExpectFilterEvents()),
+			}, args{
+				event: &user.UserLockedEvent{
+					BaseEvent: *securityEventBase(userID, 5),
+				},
+			}, w
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			f, a, w := tt.test(ctrl, queries, commands)
+			stmt, err := newUserNotifier(t, ctrl, queries, f, a, w).reduceUserLocked(a.event)
+			assert.NoError(t, err)
+			assert.NoError(t, stmt.Execute(nil, ""))
+		})
+	}
+}
+
+func Test_userNotifier_reduceMachineCredentialAdded(t *testing.T) {
+	expectMailSubject := "A new credential was created for a service user"
+	machineID := "machine1"
+	keyAddedEvent := func() *user.MachineKeyAddedEvent {
+		return &user.MachineKeyAddedEvent{
+			BaseEvent: *securityEventBase(machineID, 5),
+		}
+	}
+	tests := []struct {
+		name string
+		test func(*gomock.Controller, *mock.MockQueries, *mock.MockCommands) (fields, args, want)
+	}{{
+		name: "sent to creator",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.message = messages.Email{
+				Recipients: []string{lastEmail},
+				Subject:    expectMailSubject,
+				Content:    securityNotificationContent,
+			}
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				MachineCredentialAdded: true,
+			}, nil)
+			expectSecurityNotificationQueries(queries, securityNotifyUser(), true)
+			commands.EXPECT().SecurityNotificationSent(gomock.Any(), orgID, machineID, domain.MachineCredentialAddedMessageType, "", uint64(5)).Return(nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es:       newSecurityEventstore(es_repo_mock.NewRepo(t).ExpectFilterEvents()),
+			}, args{
+				event: keyAddedEvent(),
+			}, w
+		},
+	}, {
+		name: "created by machine, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				MachineCredentialAdded: true,
+			}, nil)
+			queries.EXPECT().GetNotifyUserByID(gomock.Any(), gomock.Any(), userID).Return(&query.NotifyUser{
+				ID:            userID,
+				ResourceOwner: orgID,
+				Type:          domain.UserTypeMachine,
+			}, nil)
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es:       newSecurityEventstore(es_repo_mock.NewRepo(t).ExpectFilterEvents()),
+			}, args{
+				event: keyAddedEvent(),
+			}, w
+		},
+	}, {
+		name: "creator not found, not sent",
+		test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+			w.noMessage = true
+			queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+				MachineCredentialAdded: true,
+			}, nil)
+			queries.EXPECT().GetNotifyUserByID(gomock.Any(), gomock.Any(), userID).Return(nil, zerrors.ThrowNotFound(nil, "ID", "not found"))
+			return fields{
+				queries:  queries,
+				commands: commands,
+				es:       newSecurityEventstore(es_repo_mock.NewRepo(t).ExpectFilterEvents()),
+			}, args{
+				event: keyAddedEvent(),
+			}, w
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			f, a, w := tt.test(ctrl, queries, commands)
+			stmt, err := newUserNotifier(t, ctrl, queries, f, a, w).reduceMachineCredentialAdded(a.event)
+			assert.NoError(t, err)
+			assert.NoError(t, stmt.Execute(nil, ""))
+		})
+	}
+}
+
+func TestNotificationQueries_IsSecurityNotificationSent(t *testing.T) {
+	event := &user.UserLockedEvent{
+		BaseEvent: *securityEventBase(userID, 5),
+	}
+	tests := []struct {
+		name                  string
+		triggeringAggregateID string
+		events                []eventstore.Event
+		want                  bool
+	}{
+		{
+			name: "not sent",
+			want: false,
+		},
+		{
+			name: "sent",
+			events: []eventstore.Event{
+				&user.SecurityNotificationSentEvent{BaseEvent: *securityEventBase(userID, 6)},
+			},
+			want: true,
+		},
+		{
+			name:                  "triggered by other aggregate, not sent",
+			triggeringAggregateID: "session1",
+			want:                  false,
+		},
+		{
+			name:                  "triggered by other aggregate, sent",
+			triggeringAggregateID: "session1",
+			events: []eventstore.Event{
+				&user.SecurityNotificationSentEvent{BaseEvent: *securityEventBase(userID, 6)},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := &NotificationQueries{
+				es: newSecurityEventstore(es_repo_mock.NewRepo(t).ExpectFilterEvents(tt.events...)),
+			}
+			got, err := queries.IsSecurityNotificationSent(context.Background(), event, userID, tt.triggeringAggregateID, domain.AccountLockedMessageType)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNotificationQueries_IsUnknownUserAgent(t *testing.T) {
+	event := &user.HumanPasswordCheckSucceededEvent{
+		BaseEvent: *securityEventBase(userID, 5),
+	}
+	previousSignIn := &user.HumanPasswordCheckSucceededEvent{
+		BaseEvent: *securityEventBase(userID, 1),
+	}
+	sessionUserChecked := &session.UserCheckedEvent{
+		BaseEvent: *securityEventBase("session1", 2),
+		UserID:    userID,
+	}
+	sessionChecked := &session.PasswordCheckedEvent{
+		BaseEvent: *securityEventBase("session1", 3),
+	}
+	tests := []struct {
+		name   string
+		filter func(*es_repo_mock.MockRepository) *es_repo_mock.MockRepository
+		want   bool
+	}{
+		{
+			name: "first sign-in",
+			filter: func(repo *es_repo_mock.MockRepository) *es_repo_mock.MockRepository {
+				return repo.
+					ExpectFilterEvents().ExpectFilterEvents().
+					ExpectFilterEvents().ExpectFilterEvents()
+			},
+			want: false,
+		},
+		{
+			name: "known user agent of login UI",
+			filter: func(repo *es_repo_mock.MockRepository) *es_repo_mock.MockRepository {
+				return repo.ExpectFilterEvents(previousSignIn)
+			},
+			want: false,
+		},
+		{
+			name: "known user agent of session",
+			filter: func(repo *es_repo_mock.MockRepository) *es_repo_mock.MockRepository {
+				return repo.
+					ExpectFilterEvents().
+					ExpectFilterEvents(sessionUserChecked).
+					ExpectFilterEvents(&session.AddedEvent{BaseEvent: *securityEventBase("session1", 1)}).
+					ExpectFilterEvents(sessionChecked)
+			},
+			want: false,
+		},
+		{
+			name: "unknown user agent, previous sign-in through login UI",
+			filter: func(repo *es_repo_mock.MockRepository) *es_repo_mock.MockRepository {
+				return repo.
+					ExpectFilterEvents().ExpectFilterEvents().
+					ExpectFilterEvents(previousSignIn)
+			},
+			want: true,
+		},
+		{
+			name: "unknown user agent, previous sign-in through session",
+			filter: func(repo *es_repo_mock.MockRepository) *es_repo_mock.MockRepository {
+				return repo.
+					ExpectFilterEvents().ExpectFilterEvents().
+					ExpectFilterEvents().
+					ExpectFilterEvents(sessionUserChecked).
+					ExpectFilterEvents(sessionChecked)
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := &NotificationQueries{
+				es: newSecurityEventstore(tt.filter(es_repo_mock.NewRepo(t))),
+			}
+			got, err := queries.IsUnknownUserAgent(context.Background(), event, userID, "agent1")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 type fields struct {
 	queries        *mock.MockQueries
 	commands       *mock.MockCommands
@@ -1301,14 +2090,23 @@ type args struct {
 }
 type want struct {
 	message messages.Email
-	err     assert.ErrorAssertionFunc
+	// sms is expected instead of the email message if set
+	sms *messages.SMS
+	// noMessage is set if no notification must be sent
+	noMessage bool
+	err       assert.ErrorAssertionFunc
 }
 
 func newUserNotifier(t *testing.T, ctrl *gomock.Controller, queries *mock.MockQueries, f fields, a args, w want) *userNotifier {
 	queries.EXPECT().NotificationProviderByIDAndType(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(&query.DebugNotificationProvider{}, nil)
 	smtpAlg, _ := cryptoValue(t, ctrl, "smtppw")
 	channel := channel_mock.NewMockNotificationChannel(ctrl)
-	if w.err == nil {
+	switch {
+	case w.err != nil, w.noMessage:
+	case w.sms != nil:
+		w.sms.TriggeringEvent = a.event
+		channel.EXPECT().HandleMessage(w.sms).Return(nil)
+	default:
 		w.message.TriggeringEvent = a.event
 		channel.EXPECT().HandleMessage(&w.message).Return(nil)
 	}
@@ -1321,30 +2119,31 @@ func newUserNotifier(t *testing.T, ctrl *gomock.Controller, queries *mock.MockQu
 			externalPort,
 			externalSecure,
 			"",
+			nil,
 			f.userDataCrypto,
 			smtpAlg,
 			f.SMSTokenCrypto,
 		),
 		otpEmailTmpl: defaultOTPEmailTemplate,
-		channels:     &channels{Chain: *senders.ChainChannels(channel)},
+		channels:     &testChannels{Chain: *senders.ChainChannels(channel)},
 	}
 }
 
-var _ types.ChannelChains = (*channels)(nil)
+var _ types.ChannelChains = (*testChannels)(nil)
 
-type channels struct {
+type testChannels struct {
 	senders.Chain
 }
 
-func (c *channels) Email(context.Context) (*senders.Chain, *smtp.Config, error) {
+func (c *testChannels) Email(context.Context) (*senders.Chain, *email.Config, error) {
 	return &c.Chain, nil, nil
 }
 
-func (c *channels) SMS(context.Context) (*senders.Chain, *twilio.Config, error) {
-	return &c.Chain, nil, nil
+func (c *testChannels) SMS(context.Context) (*senders.Chain, *sms.Config, error) {
+	return &c.Chain, &sms.Config{}, nil
 }
 
-func (c *channels) Webhook(context.Context, webhook.Config) (*senders.Chain, error) {
+func (c *testChannels) Webhook(context.Context, webhook.Config) (*senders.Chain, error) {
 	return &c.Chain, nil
 }
 
@@ -1359,6 +2158,7 @@ func expectTemplateQueries(queries *mock.MockQueries, template string) {
 		},
 	}, nil)
 	queries.EXPECT().MailTemplateByOrg(gomock.Any(), gomock.Any(), gomock.Any()).Return(&query.MailTemplate{Template: []byte(template)}, nil)
+	queries.EXPECT().MailTemplateMessagesByOrg(gomock.Any(), gomock.Any(), gomock.Any()).Return(&query.MailTemplateMessages{}, nil)
 	queries.EXPECT().GetNotifyUserByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(&query.NotifyUser{
 		ID:                 userID,
 		ResourceOwner:      orgID,
@@ -1370,6 +2170,84 @@ func expectTemplateQueries(queries *mock.MockQueries, template string) {
 	queries.EXPECT().CustomTextListByTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(&query.CustomTexts{}, nil)
 }
 
+// securityNotificationContent is the content of the security notifications sent with the template `{{.LogoURL}}`,
+// which are always sent with the primary domain of the instance
+var securityNotificationContent = fmt.Sprintf("%s://%s:%d%s/%s/%s", externalProtocol, instancePrimaryDomain, externalPort, assetsPath, policyID, logoURL)
+
+func securityEventBase(aggregateID string, sequence uint64) *eventstore.BaseEvent {
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		AggregateID:   aggregateID,
+		ResourceOwner: sql.NullString{String: orgID},
+		Seq:           sequence,
+		CreationDate:  time.Now().UTC(),
+		EditorUser:    userID,
+	})
+}
+
+// securityRepoEvent returns the stored event, which is mapped to the typed event by the eventstore
+func securityRepoEvent(aggregateType eventstore.AggregateType, aggregateID string, eventType eventstore.EventType, sequence uint64, payload any) *repository.Event {
+	data, _ := json.Marshal(payload)
+	return &repository.Event{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		ResourceOwner: sql.NullString{String: orgID, Valid: true},
+		Seq:           sequence,
+		CreationDate:  time.Now().UTC(),
+		Typ:           eventType,
+		Data:          data,
+		EditorUser:    userID,
+	}
+}
+
+func newSecurityEventstore(repo *es_repo_mock.MockRepository) *eventstore.Eventstore {
+	es := eventstore.NewEventstore(&eventstore.Config{
+		Querier: repo.MockQuerier,
+	})
+	user.RegisterEventMappers(es)
+	session.RegisterEventMappers(es)
+	return es
+}
+
+func securityNotifyUser() *query.NotifyUser {
+	return &query.NotifyUser{
+		ID:                 userID,
+		ResourceOwner:      orgID,
+		Type:               domain.UserTypeHuman,
+		LastEmail:          lastEmail,
+		VerifiedEmail:      verifiedEmail,
+		LastPhone:          "+41797654321",
+		VerifiedPhone:      "+41797654321",
+		PreferredLoginName: preferredLoginName,
+	}
+}
+
+// expectSecurityNotificationQueries expects the queries of a security notification sent to the user,
+// the mail template is only queried for emails
+func expectSecurityNotificationQueries(queries *mock.MockQueries, notifyUser *query.NotifyUser, email bool) {
+	queries.EXPECT().GetNotifyUserByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(notifyUser, nil)
+	queries.EXPECT().ActiveLabelPolicyByOrg(gomock.Any(), gomock.Any(), gomock.Any()).Return(&query.LabelPolicy{
+		ID: policyID,
+		Light: query.Theme{
+			LogoURL: logoURL,
+		},
+	}, nil)
+	queries.EXPECT().GetInstanceRestrictions(gomock.Any()).Return(query.Restrictions{
+		AllowedLanguages: []language.Tag{language.English},
+	}, nil)
+	queries.EXPECT().GetDefaultLanguage(gomock.Any()).Return(language.English)
+	queries.EXPECT().CustomTextListByTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(&query.CustomTexts{}, nil)
+	queries.EXPECT().SearchInstanceDomains(gomock.Any(), gomock.Any()).Return(&query.InstanceDomains{
+		Domains: []*query.InstanceDomain{{
+			Domain:    instancePrimaryDomain,
+			IsPrimary: true,
+		}},
+	}, nil)
+	if email {
+		queries.EXPECT().MailTemplateMessagesByOrg(gomock.Any(), gomock.Any(), gomock.Any()).Return(&query.MailTemplateMessages{}, nil)
+		queries.EXPECT().MailTemplateByOrg(gomock.Any(), gomock.Any(), gomock.Any()).Return(&query.MailTemplate{Template: []byte("{{.LogoURL}}")}, nil)
+	}
+}
+
 func cryptoValue(t *testing.T, ctrl *gomock.Controller, value string) (*crypto.MockEncryptionAlgorithm, *crypto.CryptoValue) {
 	encAlg := crypto.NewMockEncryptionAlgorithm(ctrl)
 	encAlg.EXPECT().Algorithm().AnyTimes().Return("enc")
@@ -18,15 +18,15 @@ var (
 var _ channels.Message = (*Email)(nil)
 
 type Email struct {
-	Recipients      []string
-	BCC             []string
-	CC              []string
-	SenderEmail     string
-	SenderName      string
-	ReplyToAddress  string
-	Subject         string
-	Content         string
-	TriggeringEvent eventstore.Event
+	Recipients      []string         `json:"recipients,omitempty"`
+	BCC             []string         `json:"bcc,omitempty"`
+	CC              []string         `json:"cc,omitempty"`
+	SenderEmail     string           `json:"senderEmail,omitempty"`
+	SenderName      string           `json:"senderName,omitempty"`
+	ReplyToAddress  string           `json:"replyToAddress,omitempty"`
+	Subject         string           `json:"subject,omitempty"`
+	Content         string           `json:"content,omitempty"`
+	TriggeringEvent eventstore.Event `json:"-"`
 }
 
 func (msg *Email) GetContent() (string, error) {
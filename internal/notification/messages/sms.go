@@ -8,10 +8,13 @@ import (
 var _ channels.Message = (*SMS)(nil)
 
 type SMS struct {
-	SenderPhoneNumber    string
-	RecipientPhoneNumber string
-	Content              string
-	TriggeringEvent      eventstore.Event
+	SenderPhoneNumber    string `json:"senderPhoneNumber,omitempty"`
+	RecipientPhoneNumber string `json:"recipientPhoneNumber,omitempty"`
+	Content              string `json:"content,omitempty"`
+	// Channel is the delivery channel requested for the message (sms or whatsapp).
+	// Providers not supporting the requested channel will send it as sms.
+	Channel         string           `json:"channel,omitempty"`
+	TriggeringEvent eventstore.Event `json:"-"`
 }
 
 func (msg *SMS) GetContent() (string, error) {
@@ -10,12 +10,14 @@ import (
 	_ "github.com/zitadel/zitadel/internal/notification/statik"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/static"
 )
 
 func Start(
 	ctx context.Context,
-	userHandlerCustomConfig, quotaHandlerCustomConfig, telemetryHandlerCustomConfig projection.CustomConfig,
+	userHandlerCustomConfig, quotaHandlerCustomConfig, telemetryHandlerCustomConfig, notificationWorkerCustomConfig projection.CustomConfig,
 	telemetryCfg handlers.TelemetryPusherConfig,
+	notificationWorkerCfg handlers.NotificationWorkerConfig,
 	externalDomain string,
 	externalPort uint16,
 	externalSecure bool,
@@ -23,13 +25,22 @@ func Start(
 	queries *query.Queries,
 	es *eventstore.Eventstore,
 	otpEmailTmpl string,
+	magicLinkTmpl string,
 	fileSystemPath string,
+	storage static.Storage,
 	userEncryption, smtpEncryption, smsEncryption crypto.EncryptionAlgorithm,
+	notificationsQuotaEnabled bool,
 ) {
-	q := handlers.NewNotificationQueries(queries, es, externalDomain, externalPort, externalSecure, fileSystemPath, userEncryption, smtpEncryption, smsEncryption)
-	c := newChannels(q)
-	handlers.NewUserNotifier(ctx, projection.ApplyCustomConfig(userHandlerCustomConfig), commands, q, c, otpEmailTmpl).Start(ctx)
-	handlers.NewQuotaNotifier(ctx, projection.ApplyCustomConfig(quotaHandlerCustomConfig), commands, q, c).Start(ctx)
+	q := handlers.NewNotificationQueries(queries, es, externalDomain, externalPort, externalSecure, fileSystemPath, storage, userEncryption, smtpEncryption, smsEncryption)
+	c := newChannels(q, commands, notificationsQuotaEnabled)
+	notificationWorker, outboxChannels := handlers.NewNotificationWorker(ctx, notificationWorkerCfg, projection.ApplyCustomConfig(notificationWorkerCustomConfig), commands, q, c, c.outboxMetrics())
+	notificationWorker.Start(ctx)
+	userNotifier := handlers.NewUserNotifier(ctx, projection.ApplyCustomConfig(userHandlerCustomConfig), commands, q, outboxChannels, otpEmailTmpl, magicLinkTmpl)
+	projection.AddEventHandler(userNotifier)
+	userNotifier.Start(ctx)
+	quotaNotifier := handlers.NewQuotaNotifier(ctx, projection.ApplyCustomConfig(quotaHandlerCustomConfig), commands, q, c)
+	projection.AddEventHandler(quotaNotifier)
+	quotaNotifier.Start(ctx)
 	if telemetryCfg.Enabled {
 		handlers.NewTelemetryPusher(ctx, telemetryCfg, projection.ApplyCustomConfig(telemetryHandlerCustomConfig), commands, q, c).Start(ctx)
 	}
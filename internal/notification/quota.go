@@ -0,0 +1,52 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/notification/handlers"
+	"github.com/zitadel/zitadel/internal/notification/senders"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var _ senders.UsageQuota = (*notificationsQuota)(nil)
+
+// notificationsQuota enforces the quota of sent emails and SMS of an instance
+type notificationsQuota struct {
+	queries  *handlers.NotificationQueries
+	commands *command.Commands
+}
+
+func (n *notificationsQuota) CheckExhausted(ctx context.Context) error {
+	remaining, err := n.queries.GetRemainingQuotaUsage(ctx, authz.GetInstance(ctx).InstanceID(), quota.NotificationsAllSent)
+	if err != nil {
+		return err
+	}
+	if remaining != nil && *remaining == 0 {
+		return zerrors.ThrowResourceExhausted(nil, "NOTIF-ieC5a", "Errors.Quota.Notifications.Exhausted")
+	}
+	return nil
+}
+
+func (n *notificationsQuota) ReportUsage(ctx context.Context) error {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	q, err := n.queries.GetQuota(ctx, instanceID, quota.NotificationsAllSent)
+	if zerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	sum, err := projection.QuotaProjection.IncrementUsage(ctx, quota.NotificationsAllSent, instanceID, q.CurrentPeriodStart, 1)
+	if err != nil {
+		return err
+	}
+	notifications, err := n.queries.GetDueQuotaNotifications(ctx, instanceID, quota.NotificationsAllSent, q, q.CurrentPeriodStart, sum)
+	if err != nil || len(notifications) == 0 {
+		return err
+	}
+	return n.commands.ReportQuotaUsage(ctx, notifications)
+}
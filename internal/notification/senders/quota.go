@@ -0,0 +1,42 @@
+package senders
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+)
+
+// UsageQuota limits the number of notifications an instance is allowed to send
+type UsageQuota interface {
+	// CheckExhausted returns an error if the instance must not send any further notifications
+	CheckExhausted(ctx context.Context) error
+	// ReportUsage counts a sent notification
+	ReportUsage(ctx context.Context) error
+}
+
+// LimitByQuota checks the quota before a message is sent by the chain and counts the message after it was sent.
+// Notifications about reached quotas are neither limited nor counted, so they're still sent if the quota is exhausted.
+func LimitByQuota(ctx context.Context, chain *Chain, usage UsageQuota) *Chain {
+	if chain == nil || chain.Len() == 0 {
+		return chain
+	}
+	return ChainChannels(channels.HandleMessageFunc(func(message channels.Message) error {
+		if event := message.GetTriggeringEvent(); event != nil && event.Type() == quota.NotificationDueEventType {
+			return chain.HandleMessage(message)
+		}
+		if err := usage.CheckExhausted(ctx); err != nil {
+			return err
+		}
+		if err := chain.HandleMessage(message); err != nil {
+			return err
+		}
+		logging.WithFields("instance", authz.GetInstance(ctx).InstanceID()).
+			OnError(usage.ReportUsage(ctx)).
+			Warn("reporting notification usage failed")
+		return nil
+	}))
+}
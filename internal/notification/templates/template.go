@@ -20,6 +20,13 @@ func GetParsedTemplate(mailhtml string, contentData interface{}) (string, error)
 	return ParseTemplateText(template, contentData)
 }
 
+// ValidateTemplate renders the template with sample data,
+// so errors of a custom template are found before it is used for notifications
+func ValidateTemplate(mailhtml string) error {
+	_, err := GetParsedTemplate(mailhtml, SampleTemplateData())
+	return err
+}
+
 func ParseTemplateFile(mailhtml string, data interface{}) (string, error) {
 	tmpl, err := template.New("tmpl").Parse(mailhtml)
 	if err != nil {
@@ -50,3 +50,49 @@ func (data *TemplateData) Translate(translator *i18n.Translator, msgType string,
 	// id of the string that could not be translated example InitCode.Footer
 	data.IncludeFooter = len(data.FooterText) > 0 && data.FooterText != footerText
 }
+
+// SampleArgs are used instead of the user and message specific arguments
+// to render the message texts of a template preview
+var SampleArgs = map[string]interface{}{
+	"UserName":           "jane.doe",
+	"FirstName":          "Jane",
+	"LastName":           "Doe",
+	"NickName":           "Jane",
+	"DisplayName":        "Jane Doe",
+	"LastEmail":          "jane.doe@example.com",
+	"VerifiedEmail":      "jane.doe@example.com",
+	"LastPhone":          "+41 79 123 45 67",
+	"VerifiedPhone":      "+41 79 123 45 67",
+	"PreferredLoginName": "jane.doe@example.com",
+	"LoginNames":         []string{"jane.doe@example.com"},
+	"Code":               "ABCDEF",
+	"OTP":                "123456",
+	"Expiry":             "5m0s",
+	"Domain":             "example.com",
+	"Origin":             "https://example.com",
+	"TempUsername":       "jane.doe@example.com",
+	"UserAgent":          "Mozilla/5.0",
+	"RemoteIP":           "192.0.2.1",
+	"NewEmail":           "jane.doe@example.org",
+	"NewPhone":           "+41 79 765 43 21",
+	"MachineUserID":      "123456789",
+}
+
+// SampleTemplateData returns placeholder values for all fields a template can use
+func SampleTemplateData() TemplateData {
+	return TemplateData{
+		Title:           "Title",
+		PreHeader:       "PreHeader",
+		Subject:         "Subject",
+		Greeting:        "Hello Jane Doe,",
+		Text:            "Text",
+		URL:             "https://example.com",
+		ButtonText:      "ButtonText",
+		PrimaryColor:    DefaultPrimaryColor,
+		BackgroundColor: DefaultBackgroundColor,
+		FontColor:       DefaultFontColor,
+		FontFamily:      DefaultFontFamily,
+		IncludeFooter:   true,
+		FooterText:      "FooterText",
+	}
+}
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"context"
+	"time"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// SendEmailChangeRequested informs the (verified) email address of the user about the requested change
+// and provides a link to revert it.
+func (notify Notify) SendEmailChangeRequested(ctx context.Context, user *query.NotifyUser, newEmail domain.EmailAddress, code string, expiry time.Duration) error {
+	url := login.MailChangeRevertLink(http_utils.ComposedOrigin(ctx), user.ID, code, user.ResourceOwner)
+	args := make(map[string]interface{})
+	args["NewEmail"] = newEmail
+	args["Expiry"] = expiry
+	return notify(url, args, domain.EmailChangeRequestedMessageType, false)
+}
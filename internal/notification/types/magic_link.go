@@ -0,0 +1,18 @@
+package types
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func (notify Notify) SendMagicLink(ctx context.Context, url string, expiry time.Duration) error {
+	args := make(map[string]interface{})
+	args["Origin"] = http_utils.ComposedOrigin(ctx)
+	args["Domain"] = authz.GetInstance(ctx).RequestedDomain()
+	args["Expiry"] = expiry
+	return notify(url, args, domain.MagicLinkMessageType, false)
+}
@@ -3,10 +3,11 @@ package types
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/i18n"
-	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
-	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/email"
+	"github.com/zitadel/zitadel/internal/notification/channels/sms"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/notification/templates"
@@ -21,8 +22,8 @@ type Notify func(
 ) error
 
 type ChannelChains interface {
-	Email(context.Context) (*senders.Chain, *smtp.Config, error)
-	SMS(context.Context) (*senders.Chain, *twilio.Config, error)
+	Email(context.Context) (*senders.Chain, *email.Config, error)
+	SMS(context.Context) (*senders.Chain, *sms.Config, error)
 	Webhook(context.Context, webhook.Config) (*senders.Chain, error)
 }
 
@@ -66,6 +67,20 @@ func SendSMSTwilio(
 	user *query.NotifyUser,
 	colors *query.LabelPolicy,
 	triggeringEvent eventstore.Event,
+) Notify {
+	return SendSMSWithChannel(ctx, channels, translator, user, colors, domain.PhoneChannelSMS, triggeringEvent)
+}
+
+// SendSMSWithChannel sends the message to the phone of the user over the requested channel (e.g. WhatsApp),
+// if the configured sms provider supports it.
+func SendSMSWithChannel(
+	ctx context.Context,
+	channels ChannelChains,
+	translator *i18n.Translator,
+	user *query.NotifyUser,
+	colors *query.LabelPolicy,
+	channel domain.PhoneChannel,
+	triggeringEvent eventstore.Event,
 ) Notify {
 	return func(
 		url string,
@@ -81,6 +96,7 @@ func SendSMSTwilio(
 			user,
 			data.Text,
 			allowUnverifiedNotificationChannel,
+			channel,
 			triggeringEvent,
 		)
 	}
@@ -16,6 +16,13 @@ func (notify Notify) SendOTPSMSCode(ctx context.Context, code string, expiry tim
 	return notify("", args, domain.VerifySMSOTPMessageType, false)
 }
 
+// SendPhoneLoginCode sends the one time code for a phone number login.
+// The code is also sent to a not yet verified phone number (phone-first sign-up), since a successful check verifies it.
+func (notify Notify) SendPhoneLoginCode(ctx context.Context, code string, expiry time.Duration) error {
+	args := otpArgs(ctx, code, expiry)
+	return notify("", args, domain.VerifySMSOTPMessageType, true)
+}
+
 func (notify Notify) SendOTPEmailCode(ctx context.Context, url, code string, expiry time.Duration) error {
 	args := otpArgs(ctx, code, expiry)
 	return notify(url, args, domain.VerifyEmailOTPMessageType, false)
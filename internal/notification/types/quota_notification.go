@@ -0,0 +1,54 @@
+package types
+
+import (
+	"context"
+	"html"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SendQuotaNotification sends the reached threshold of a quota to the recipients of the notification.
+// The recipients aren't users, so the email is sent in the default language of the instance.
+func SendQuotaNotification(
+	ctx context.Context,
+	channels ChannelChains,
+	mailhtml string,
+	translator *i18n.Translator,
+	lang string,
+	colors *query.LabelPolicy,
+	dueEvent *quota.NotificationDueEvent,
+) error {
+	args := map[string]interface{}{
+		"Unit":        dueEvent.Unit.String(),
+		"Threshold":   dueEvent.Threshold,
+		"Usage":       dueEvent.Usage,
+		"PeriodStart": dueEvent.PeriodStart,
+	}
+	url := http_utils.ComposedOrigin(ctx) + console.HandlerPrefix
+	data := GetTemplateData(ctx, translator, args, url, domain.QuotaNotificationMessageType, lang, colors)
+	template, err := templates.GetParsedTemplate(mailhtml, data)
+	if err != nil {
+		return err
+	}
+	emailChannels, _, err := channels.Email(ctx)
+	if err != nil {
+		return err
+	}
+	if emailChannels == nil || emailChannels.Len() == 0 {
+		return zerrors.ThrowPreconditionFailed(nil, "MAIL-Aeph7", "Errors.Notification.Channels.NotPresent")
+	}
+	return emailChannels.HandleMessage(&messages.Email{
+		Recipients:      dueEvent.Recipients,
+		Subject:         data.Subject,
+		Content:         html.UnescapeString(template),
+		TriggeringEvent: dueEvent,
+	})
+}
@@ -0,0 +1,14 @@
+package types
+
+import (
+	"context"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendSecurityNotification(ctx context.Context, user *query.NotifyUser, messageType string, args map[string]interface{}) error {
+	url := console.LoginHintLink(http_utils.ComposedOrigin(ctx), user.PreferredLoginName)
+	return notify(url, args, messageType, true)
+}
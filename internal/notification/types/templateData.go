@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"fmt"
+	"html"
 	"strings"
 
 	http_util "github.com/zitadel/zitadel/internal/api/http"
@@ -14,7 +15,6 @@ import (
 )
 
 func GetTemplateData(ctx context.Context, translator *i18n.Translator, translateArgs map[string]interface{}, href, msgType, lang string, policy *query.LabelPolicy) templates.TemplateData {
-	assetsPrefix := http_util.ComposedOrigin(ctx) + assets.HandlerPrefix
 	templateData := templates.TemplateData{
 		URL:             href,
 		PrimaryColor:    templates.DefaultPrimaryColor,
@@ -24,6 +24,46 @@ func GetTemplateData(ctx context.Context, translator *i18n.Translator, translate
 		IncludeFooter:   false,
 	}
 	templateData.Translate(translator, msgType, translateArgs, lang)
+	applyLabelPolicy(ctx, &templateData, policy)
+	return templateData
+}
+
+// GetPreviewTemplateData returns the data to render a preview of a template
+// with the message texts of the organization and sample arguments
+func GetPreviewTemplateData(ctx context.Context, text *query.MessageText, policy *query.LabelPolicy) (_ templates.TemplateData, err error) {
+	templateData := templates.TemplateData{
+		URL:             http_util.ComposedOrigin(ctx),
+		PrimaryColor:    templates.DefaultPrimaryColor,
+		BackgroundColor: templates.DefaultBackgroundColor,
+		FontColor:       templates.DefaultFontColor,
+		FontFamily:      templates.DefaultFontFamily,
+		IncludeFooter:   text.Footer != "",
+	}
+	for _, field := range []struct {
+		text   string
+		target *string
+	}{
+		{text.Title, &templateData.Title},
+		{text.PreHeader, &templateData.PreHeader},
+		{text.Subject, &templateData.Subject},
+		{text.Greeting, &templateData.Greeting},
+		{html.UnescapeString(text.Text), &templateData.Text},
+		{text.ButtonText, &templateData.ButtonText},
+		{text.Footer, &templateData.FooterText},
+	} {
+		*field.target, err = templates.ParseTemplateText(field.text, templates.SampleArgs)
+		if err != nil {
+			return templateData, err
+		}
+	}
+	if policy != nil {
+		applyLabelPolicy(ctx, &templateData, policy)
+	}
+	return templateData, nil
+}
+
+func applyLabelPolicy(ctx context.Context, templateData *templates.TemplateData, policy *query.LabelPolicy) {
+	assetsPrefix := http_util.ComposedOrigin(ctx) + assets.HandlerPrefix
 	if policy.Light.PrimaryColor != "" {
 		templateData.PrimaryColor = policy.Light.PrimaryColor
 	}
@@ -42,5 +82,4 @@ func GetTemplateData(ctx context.Context, translator *i18n.Translator, translate
 		templateData.FontURL = fmt.Sprintf("%s/%s/%s", assetsPrefix, policy.ID, policy.FontURL)
 		templateData.FontFamily = templateData.FontFaceFamily + "," + templates.DefaultFontFamily
 	}
-	return templateData
 }
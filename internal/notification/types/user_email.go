@@ -4,6 +4,7 @@ import (
 	"context"
 	"html"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/notification/messages"
 	"github.com/zitadel/zitadel/internal/query"
@@ -29,7 +30,7 @@ func generateEmail(
 	if lastEmail {
 		message.Recipients = []string{user.LastEmail}
 	}
-	emailChannels, _, err := channels.Email(ctx)
+	emailChannels, _, err := channels.Email(withNotifyUserOrg(ctx, user))
 	if err != nil {
 		return err
 	}
@@ -39,6 +40,14 @@ func generateEmail(
 	return emailChannels.HandleMessage(message)
 }
 
+// withNotifyUserOrg sets the organization of the user into the context,
+// so the notification providers of the organization are used if it has any
+func withNotifyUserOrg(ctx context.Context, user *query.NotifyUser) context.Context {
+	ctxData := authz.GetCtxData(ctx)
+	ctxData.OrgID = user.ResourceOwner
+	return authz.SetCtxData(ctx, ctxData)
+}
+
 func mapNotifyUserToArgs(user *query.NotifyUser, args map[string]interface{}) map[string]interface{} {
 	if args == nil {
 		args = make(map[string]interface{})
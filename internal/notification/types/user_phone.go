@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/notification/messages"
 	"github.com/zitadel/zitadel/internal/query"
@@ -17,21 +18,23 @@ func generateSms(
 	user *query.NotifyUser,
 	content string,
 	lastPhone bool,
+	channel domain.PhoneChannel,
 	triggeringEvent eventstore.Event,
 ) error {
 	number := ""
-	smsChannels, twilioConfig, err := channels.SMS(ctx)
+	smsChannels, config, err := channels.SMS(withNotifyUserOrg(ctx, user))
 	logging.OnError(err).Error("could not create sms channel")
 	if smsChannels == nil || smsChannels.Len() == 0 {
 		return zerrors.ThrowPreconditionFailed(nil, "PHONE-w8nfow", "Errors.Notification.Channels.NotPresent")
 	}
-	if err == nil {
-		number = twilioConfig.SenderNumber
+	if err == nil && config.TwilioConfig != nil {
+		number = config.TwilioConfig.SenderNumber
 	}
 	message := &messages.SMS{
 		SenderPhoneNumber:    number,
 		RecipientPhoneNumber: user.VerifiedPhone,
 		Content:              content,
+		Channel:              channel.String(),
 		TriggeringEvent:      triggeringEvent,
 	}
 	if lastPhone {
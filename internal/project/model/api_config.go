@@ -19,4 +19,6 @@ type APIAuthMethodType int32
 const (
 	APIAuthMethodTypeBasic APIAuthMethodType = iota
 	APIAuthMethodTypePrivateKeyJWT
+	APIAuthMethodTypeTLSClientAuth
+	APIAuthMethodTypeSelfSignedTLSClientAuth
 )
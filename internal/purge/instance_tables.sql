@@ -0,0 +1,17 @@
+-- selects all tables which contain data scoped to an instance
+SELECT
+    c.table_schema
+    , c.table_name
+FROM
+    information_schema.columns c
+JOIN
+    information_schema.tables t
+    ON t.table_catalog = c.table_catalog
+    AND t.table_schema = c.table_schema
+    AND t.table_name = c.table_name
+WHERE
+    c.table_catalog = current_database()
+    AND c.column_name = 'instance_id'
+    AND t.table_type = 'BASE TABLE'
+    AND c.table_schema NOT IN ('pg_catalog', 'information_schema', 'crdb_internal', 'pg_extension')
+    AND NOT (c.table_schema = 'system' AND c.table_name = 'instance_purges')
\ No newline at end of file
@@ -0,0 +1,324 @@
+package purge
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed instance_tables.sql
+	instanceTablesStmt string
+	//go:embed purge_start.sql
+	startPurgeStmt string
+	//go:embed purge_progress.sql
+	purgeProgressStmt string
+	//go:embed purge_update.sql
+	updatePurgeStmt string
+)
+
+const (
+	// AssetsTable is the step which removes the objects of the instance from the static storage
+	AssetsTable = "static.objects"
+
+	eventsTable            = "eventstore.events2"
+	uniqueConstraintsTable = "eventstore.unique_constraints"
+	eventstoreSchema       = "eventstore."
+
+	defaultBulkLimit = 1000
+)
+
+// Purger deletes all data of removed instances from the database in batches.
+// The progress is stored in system.instance_purges so an interrupted purge can be resumed.
+type Purger struct {
+	client    *database.DB
+	es        *eventstore.Eventstore
+	static    static.Storage
+	bulkLimit uint32
+
+	running sync.Map
+}
+
+type Config struct {
+	// BulkLimit limits the amount of rows deleted per statement
+	BulkLimit uint32
+}
+
+func NewPurger(config *Config, client *database.DB, es *eventstore.Eventstore, static static.Storage) *Purger {
+	bulkLimit := uint32(defaultBulkLimit)
+	if config != nil && config.BulkLimit > 0 {
+		bulkLimit = config.BulkLimit
+	}
+	return &Purger{
+		client:    client,
+		es:        es,
+		static:    static,
+		bulkLimit: bulkLimit,
+	}
+}
+
+type Progress struct {
+	InstanceID string
+	Tables     []*TableProgress
+}
+
+type TableProgress struct {
+	Table       string
+	DeletedRows uint64
+	Done        bool
+	Error       string
+	CreatedAt   time.Time
+	ChangedAt   time.Time
+}
+
+// Done returns true if the data of all tables is deleted
+func (p *Progress) Done() bool {
+	for _, table := range p.Tables {
+		if !table.Done {
+			return false
+		}
+	}
+	return true
+}
+
+// PurgedTables returns the amount of tables of which all data is deleted
+func (p *Progress) PurgedTables() (purged int) {
+	for _, table := range p.Tables {
+		if table.Done {
+			purged++
+		}
+	}
+	return purged
+}
+
+// DeletedRows returns the amount of rows deleted over all tables
+func (p *Progress) DeletedRows() (deleted uint64) {
+	for _, table := range p.Tables {
+		deleted += table.DeletedRows
+	}
+	return deleted
+}
+
+// Start purges the data of a removed instance in the background.
+// If a purge of the instance was interrupted, it's resumed.
+func (p *Purger) Start(ctx context.Context, instanceID string) error {
+	progress, err := p.Progress(ctx, instanceID)
+	if err != nil && !zerrors.IsNotFound(err) {
+		return err
+	}
+	if progress == nil {
+		if err = p.ensureRemoved(ctx, instanceID); err != nil {
+			return err
+		}
+		if err = p.prepare(ctx, instanceID); err != nil {
+			return err
+		}
+	} else if progress.Done() {
+		return nil
+	}
+	if _, running := p.running.LoadOrStore(instanceID, struct{}{}); running {
+		return nil
+	}
+	go func() {
+		defer p.running.Delete(instanceID)
+		err := p.purge(context.WithoutCancel(ctx), instanceID)
+		logging.WithFields("instance", instanceID).OnError(err).Warn("purge of instance failed")
+	}()
+	return nil
+}
+
+// Progress returns the state of the purge of the instance
+func (p *Purger) Progress(ctx context.Context, instanceID string) (*Progress, error) {
+	progress := &Progress{
+		InstanceID: instanceID,
+	}
+	err := p.client.QueryContext(ctx,
+		func(rows *sql.Rows) error {
+			for rows.Next() {
+				table := new(TableProgress)
+				var purgeErr sql.NullString
+				if err := rows.Scan(&table.Table, &table.DeletedRows, &table.Done, &purgeErr, &table.CreatedAt, &table.ChangedAt); err != nil {
+					return err
+				}
+				table.Error = purgeErr.String
+				progress.Tables = append(progress.Tables, table)
+			}
+			return rows.Err()
+		},
+		purgeProgressStmt,
+		instanceID,
+	)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "PURGE-s8Ufk", "Errors.Internal")
+	}
+	if len(progress.Tables) == 0 {
+		return nil, zerrors.ThrowNotFound(nil, "PURGE-Pq2xB", "Errors.Instance.Purge.NotFound")
+	}
+	return progress, nil
+}
+
+func (p *Purger) ensureRemoved(ctx context.Context, instanceID string) error {
+	events, err := p.es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(instanceID).
+		Limit(1).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(instanceID).
+		EventTypes(instance.InstanceRemovedEventType).
+		Builder(),
+	)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return zerrors.ThrowPreconditionFailed(nil, "PURGE-dM4rz", "Errors.Instance.Purge.NotRemoved")
+	}
+	return nil
+}
+
+// prepare stores a progress entry for each table containing data of the instance
+func (p *Purger) prepare(ctx context.Context, instanceID string) error {
+	tables, err := p.instanceTables(ctx)
+	if err != nil {
+		return err
+	}
+	tables = append([]string{AssetsTable}, tables...)
+	for i, table := range tables {
+		if _, err = p.client.ExecContext(ctx, startPurgeStmt, instanceID, table, i); err != nil {
+			return zerrors.ThrowInternal(err, "PURGE-G4vTt", "Errors.Internal")
+		}
+	}
+	return nil
+}
+
+func (p *Purger) instanceTables(ctx context.Context) ([]string, error) {
+	tables := make([]string, 0)
+	err := p.client.QueryContext(ctx,
+		func(rows *sql.Rows) error {
+			for rows.Next() {
+				var schema, table string
+				if err := rows.Scan(&schema, &table); err != nil {
+					return err
+				}
+				tables = append(tables, schema+"."+table)
+			}
+			return rows.Err()
+		},
+		instanceTablesStmt,
+	)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "PURGE-x0dRs", "Errors.Internal")
+	}
+	sortTables(tables)
+	return tables, nil
+}
+
+// sortTables orders the tables so that the events are deleted last.
+// As long as the events exist the instance is known as removed and the purge can be restarted.
+func sortTables(tables []string) {
+	rank := func(table string) int {
+		switch {
+		case table == eventsTable:
+			return 3
+		case table == uniqueConstraintsTable:
+			return 2
+		case strings.HasPrefix(table, eventstoreSchema):
+			return 1
+		default:
+			return 0
+		}
+	}
+	sort.SliceStable(tables, func(i, j int) bool {
+		if rank(tables[i]) != rank(tables[j]) {
+			return rank(tables[i]) < rank(tables[j])
+		}
+		return tables[i] < tables[j]
+	})
+}
+
+func (p *Purger) purge(ctx context.Context, instanceID string) error {
+	progress, err := p.Progress(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	for _, table := range progress.Tables {
+		if table.Done {
+			continue
+		}
+		if err = p.purgeTable(ctx, instanceID, table.Table); err != nil {
+			updateErr := p.updateProgress(ctx, instanceID, table.Table, 0, false, err)
+			logging.WithFields("instance", instanceID, "table", table.Table).OnError(updateErr).Warn("unable to store purge error")
+			return err
+		}
+		logging.WithFields("instance", instanceID, "table", table.Table).Info("instance data purged")
+	}
+	return nil
+}
+
+func (p *Purger) purgeTable(ctx context.Context, instanceID, table string) error {
+	if table == AssetsTable {
+		if err := p.static.RemoveInstanceObjects(ctx, instanceID); err != nil {
+			return err
+		}
+		return p.updateProgress(ctx, instanceID, table, 0, true, nil)
+	}
+	stmt := p.deleteStmt(table)
+	for {
+		result, err := p.client.ExecContext(ctx, stmt, instanceID, p.bulkLimit)
+		if err != nil {
+			return zerrors.ThrowInternal(err, "PURGE-hF6aL", "Errors.Internal")
+		}
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return zerrors.ThrowInternal(err, "PURGE-Xk2oW", "Errors.Internal")
+		}
+		done := deleted < int64(p.bulkLimit)
+		if err = p.updateProgress(ctx, instanceID, table, uint64(deleted), done, nil); err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// deleteStmt returns the statement deleting a batch of rows of the instance.
+// Postgres does not support a limit on delete statements so the rows are selected by their ctid.
+func (p *Purger) deleteStmt(table string) string {
+	table = quoteTable(table)
+	if p.client.Type() == "cockroach" {
+		return fmt.Sprintf("DELETE FROM %s WHERE instance_id = $1 LIMIT $2", table)
+	}
+	return fmt.Sprintf("DELETE FROM %[1]s WHERE ctid = ANY(ARRAY(SELECT ctid FROM %[1]s WHERE instance_id = $1 LIMIT $2))", table)
+}
+
+func quoteTable(table string) string {
+	schema, name, _ := strings.Cut(table, ".")
+	return `"` + schema + `"."` + name + `"`
+}
+
+func (p *Purger) updateProgress(ctx context.Context, instanceID, table string, deleted uint64, done bool, purgeErr error) error {
+	var errMsg *string
+	if purgeErr != nil {
+		msg := purgeErr.Error()
+		errMsg = &msg
+	}
+	_, err := p.client.ExecContext(ctx, updatePurgeStmt, instanceID, table, deleted, done, errMsg)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "PURGE-Vb1Qs", "Errors.Internal")
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+SELECT
+    table_name
+    , deleted_rows
+    , done
+    , error
+    , created_at
+    , changed_at
+FROM
+    system.instance_purges
+WHERE
+    instance_id = $1
+ORDER BY
+    "position"
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO system.instance_purges (instance_id, table_name, "position") VALUES ($1, $2, $3) ON CONFLICT DO NOTHING
\ No newline at end of file
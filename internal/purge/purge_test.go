@@ -0,0 +1,89 @@
+package purge
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/database/postgres"
+)
+
+func Test_sortTables(t *testing.T) {
+	tables := []string{
+		"eventstore.events2",
+		"projections.users10",
+		"eventstore.unique_constraints",
+		"auth.users2",
+		"eventstore.events2_archive",
+		"logstore.access",
+	}
+	want := []string{
+		"auth.users2",
+		"logstore.access",
+		"projections.users10",
+		"eventstore.events2_archive",
+		"eventstore.unique_constraints",
+		"eventstore.events2",
+	}
+	sortTables(tables)
+	if !reflect.DeepEqual(tables, want) {
+		t.Errorf("sortTables() = %v, want %v", tables, want)
+	}
+}
+
+func TestPurger_purgeTable(t *testing.T) {
+	stmt := `DELETE FROM "projections"."users10" WHERE ctid = ANY(ARRAY(SELECT ctid FROM "projections"."users10" WHERE instance_id = $1 LIMIT $2))`
+	tests := []struct {
+		name    string
+		mock    *mock.SQLMock
+		wantErr bool
+	}{
+		{
+			name: "delete fails",
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(stmt,
+					mock.WithExecArgs("instance", uint32(2)),
+					mock.WithExecErr(errors.New("delete failed")),
+				),
+			),
+			wantErr: true,
+		},
+		{
+			name: "multiple batches",
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(stmt,
+					mock.WithExecArgs("instance", uint32(2)),
+					mock.WithExecRowsAffected(driver.RowsAffected(2)),
+				),
+				mock.ExcpectExec(updatePurgeStmt,
+					mock.WithExecArgs("instance", "projections.users10", uint64(2), false, nil),
+					mock.WithExecRowsAffected(driver.RowsAffected(1)),
+				),
+				mock.ExcpectExec(stmt,
+					mock.WithExecArgs("instance", uint32(2)),
+					mock.WithExecRowsAffected(driver.RowsAffected(1)),
+				),
+				mock.ExcpectExec(updatePurgeStmt,
+					mock.WithExecArgs("instance", "projections.users10", uint64(1), true, nil),
+					mock.WithExecRowsAffected(driver.RowsAffected(1)),
+				),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Purger{
+				client:    &database.DB{DB: tt.mock.DB, Database: &postgres.Config{}},
+				bulkLimit: 2,
+			}
+			if err := p.purgeTable(context.Background(), "instance", "projections.users10"); (err != nil) != tt.wantErr {
+				t.Errorf("purgeTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			tt.mock.Assert(t)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+UPDATE system.instance_purges SET
+    deleted_rows = deleted_rows + $3
+    , done = $4
+    , error = $5
+    , changed_at = now()
+WHERE
+    instance_id = $1
+    AND table_name = $2
\ No newline at end of file
@@ -45,6 +45,7 @@ var (
 		` projections.apps6_oidc_configs.clock_skew,` +
 		` projections.apps6_oidc_configs.additional_origins,` +
 		` projections.apps6_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps6_oidc_configs.consent_required,` +
 		//saml config
 		` projections.apps6_saml_configs.app_id,` +
 		` projections.apps6_saml_configs.entity_id,` +
@@ -85,6 +86,7 @@ var (
 		` projections.apps6_oidc_configs.clock_skew,` +
 		` projections.apps6_oidc_configs.additional_origins,` +
 		` projections.apps6_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps6_oidc_configs.consent_required,` +
 		//saml config
 		` projections.apps6_saml_configs.app_id,` +
 		` projections.apps6_saml_configs.entity_id,` +
@@ -157,6 +159,7 @@ var (
 		"clock_skew",
 		"additional_origins",
 		"skip_native_app_success_page",
+		"consent_required",
 		//saml config
 		"app_id",
 		"entity_id",
@@ -228,6 +231,7 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -294,6 +298,7 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -363,6 +368,7 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -434,6 +440,7 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
@@ -476,6 +483,7 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: false,
+							ConsentRequired:          false,
 						},
 					},
 				},
@@ -520,6 +528,7 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
@@ -562,6 +571,7 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: false,
+							ConsentRequired:          false,
 						},
 					},
 				},
@@ -606,6 +616,7 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
@@ -648,6 +659,7 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: false,
+							ConsentRequired:          false,
 						},
 					},
 				},
@@ -692,6 +704,7 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
@@ -734,6 +747,7 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: false,
+							ConsentRequired:          false,
 						},
 					},
 				},
@@ -778,6 +792,7 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
@@ -820,6 +835,7 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: false,
+							ConsentRequired:          false,
 						},
 					},
 				},
@@ -864,6 +880,7 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							true,
+							false,
 							// saml config
 							nil,
 							nil,
@@ -906,6 +923,7 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: true,
+							ConsentRequired:          false,
 						},
 					},
 				},
@@ -950,6 +968,7 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
@@ -987,6 +1006,7 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1024,6 +1044,7 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							"saml-app-id",
 							"https://test.com/saml/metadata",
@@ -1066,6 +1087,7 @@ func Test_AppsPrepare(t *testing.T) {
 							ComplianceProblems:       nil,
 							AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
 							SkipNativeAppSuccessPage: false,
+							ConsentRequired:          false,
 						},
 					},
 					{
@@ -1192,6 +1214,7 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// saml config
 						nil,
 						nil,
@@ -1250,6 +1273,7 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1313,6 +1337,7 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
@@ -1350,6 +1375,7 @@ func Test_AppPrepare(t *testing.T) {
 					ComplianceProblems:       nil,
 					AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
 					SkipNativeAppSuccessPage: false,
+					ConsentRequired:          false,
 				},
 			},
 		}, {
@@ -1391,6 +1417,7 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -1455,6 +1482,7 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
@@ -1492,6 +1520,7 @@ func Test_AppPrepare(t *testing.T) {
 					ComplianceProblems:       nil,
 					AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
 					SkipNativeAppSuccessPage: false,
+					ConsentRequired:          false,
 				},
 			},
 		},
@@ -1534,6 +1563,7 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
@@ -1571,6 +1601,7 @@ func Test_AppPrepare(t *testing.T) {
 					ComplianceProblems:       nil,
 					AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
 					SkipNativeAppSuccessPage: false,
+					ConsentRequired:          false,
 				},
 			},
 		},
@@ -1613,6 +1644,7 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
@@ -1650,6 +1682,7 @@ func Test_AppPrepare(t *testing.T) {
 					ComplianceProblems:       nil,
 					AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
 					SkipNativeAppSuccessPage: false,
+					ConsentRequired:          false,
 				},
 			},
 		},
@@ -1692,6 +1725,7 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							false,
 							// saml config
 							nil,
 							nil,
@@ -1729,6 +1763,7 @@ func Test_AppPrepare(t *testing.T) {
 					ComplianceProblems:       nil,
 					AllowedOrigins:           database.TextArray[string]{"https://redirect.to", "additional.origin"},
 					SkipNativeAppSuccessPage: false,
+					ConsentRequired:          false,
 				},
 			},
 		},
@@ -0,0 +1,79 @@
+package query
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type AppTLSClientAuthReadModel struct {
+	*eventstore.ReadModel
+
+	AppID         string
+	TLSClientAuth *domain.ApplicationTLSClientAuth
+}
+
+// AppTLSClientAuth returns the client certificate configuration of an application authenticating with mutual TLS.
+// An empty configuration is returned if none was set.
+func (q *Queries) AppTLSClientAuth(ctx context.Context, projectID, appID string) (_ *domain.ApplicationTLSClientAuth, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if projectID == "" || appID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Ahp6e", "Errors.IDMissing")
+	}
+	readModel := NewAppTLSClientAuthReadModel(projectID, appID)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.TLSClientAuth, nil
+}
+
+func NewAppTLSClientAuthReadModel(projectID, appID string) *AppTLSClientAuthReadModel {
+	return &AppTLSClientAuthReadModel{
+		ReadModel: &eventstore.ReadModel{
+			AggregateID: projectID,
+		},
+		AppID:         appID,
+		TLSClientAuth: new(domain.ApplicationTLSClientAuth),
+	}
+}
+
+func (rm *AppTLSClientAuthReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *project.ApplicationTLSClientAuthSetEvent:
+			if e.AppID != rm.AppID {
+				continue
+			}
+			rm.TLSClientAuth = &domain.ApplicationTLSClientAuth{
+				SubjectDN:   e.SubjectDN,
+				Certificate: e.Certificate,
+			}
+		case *project.ApplicationRemovedEvent:
+			if e.AppID != rm.AppID {
+				continue
+			}
+			rm.TLSClientAuth = new(domain.ApplicationTLSClientAuth)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *AppTLSClientAuthReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AllowTimeTravel().
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			project.ApplicationTLSClientAuthSetType,
+			project.ApplicationRemovedType,
+		).
+		Builder()
+}
@@ -38,6 +38,7 @@ func TestQueries_AuthRequestByID(t *testing.T) {
 		projection.AuthRequestColumnLoginHint,
 		projection.AuthRequestColumnMaxAge,
 		projection.AuthRequestColumnHintUserID,
+		projection.AuthRequestColumnLOA,
 	}
 	type args struct {
 		shouldTriggerBulk bool
@@ -70,6 +71,7 @@ func TestQueries_AuthRequestByID(t *testing.T) {
 				"me@example.com",
 				int64(time.Minute),
 				"userID",
+				domain.LevelOfAssuranceMFA,
 			}, "123", "instanceID"),
 			want: &AuthRequest{
 				ID:           "id",
@@ -83,6 +85,8 @@ func TestQueries_AuthRequestByID(t *testing.T) {
 				LoginHint:    gu.Ptr("me@example.com"),
 				MaxAge:       gu.Ptr(time.Minute),
 				HintUserID:   gu.Ptr("userID"),
+
+				LevelOfAssurance: domain.LevelOfAssuranceMFA,
 			},
 		},
 		{
@@ -104,6 +108,7 @@ func TestQueries_AuthRequestByID(t *testing.T) {
 				sql.NullString{},
 				sql.NullInt64{},
 				sql.NullString{},
+				domain.LevelOfAssuranceNone,
 			}, "123", "instanceID"),
 			want: &AuthRequest{
 				ID:           "id",
@@ -156,6 +161,7 @@ func TestQueries_AuthRequestByID(t *testing.T) {
 				sql.NullString{},
 				sql.NullInt64{},
 				sql.NullString{},
+				domain.LevelOfAssuranceNone,
 			}, "123", "instanceID"),
 			wantErr: zerrors.ThrowPermissionDeniedf(nil, "OIDCv2-aL0ag", "Errors.AuthRequest.WrongLoginClient"),
 		},
@@ -1,10 +1,10 @@
 with config as (
-		select app_id, client_id, client_secret
+		select app_id, client_id, client_secret, auth_method as api_auth_method, null::smallint as oidc_auth_method
 		from projections.apps6_api_configs
 		where instance_id = $1
 			and client_id = $2
 	union
-		select app_id, client_id, client_secret
+		select app_id, client_id, client_secret, null::smallint as api_auth_method, auth_method_type as oidc_auth_method
 		from projections.apps6_oidc_configs
 		where instance_id = $1
 			and client_id = $2
@@ -18,6 +18,7 @@ keys as (
 		and expiration > current_timestamp
 	group by identifier
 )
-select config.client_id, config.client_secret, apps.project_id, keys.public_keys from config
+select config.client_id, config.client_secret, apps.project_id, config.app_id, apps.resource_owner,
+	config.api_auth_method, config.oidc_auth_method, keys.public_keys from config
 join projections.apps6 apps on apps.id = config.app_id
 left join keys on keys.client_id = config.client_id;
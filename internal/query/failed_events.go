@@ -3,11 +3,16 @@ package query
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -21,6 +26,9 @@ const (
 	failedEventsColumnLastFailed     = "last_failed"
 	failedEventsColumnError          = "error"
 	failedEventsColumnInstanceID     = "instance_id"
+	failedEventsColumnEventDate      = "event_creation_date"
+	failedEventsColumnSkipped        = "skipped"
+	failedEventsColumnSkipReason     = "skip_reason"
 )
 
 var (
@@ -60,6 +68,18 @@ var (
 		name:  failedEventsColumnInstanceID,
 		table: failedEventsTable,
 	}
+	FailedEventsColumnEventDate = Column{
+		name:  failedEventsColumnEventDate,
+		table: failedEventsTable,
+	}
+	FailedEventsColumnSkipped = Column{
+		name:  failedEventsColumnSkipped,
+		table: failedEventsTable,
+	}
+	FailedEventsColumnSkipReason = Column{
+		name:  failedEventsColumnSkipReason,
+		table: failedEventsTable,
+	}
 )
 
 type FailedEvents struct {
@@ -69,12 +89,25 @@ type FailedEvents struct {
 
 type FailedEvent struct {
 	ProjectionName string
+	InstanceID     string
 	AggregateType  string
 	AggregateID    string
 	FailedSequence uint64
+	EventDate      time.Time
 	FailureCount   uint64
 	Error          string
 	LastFailed     time.Time
+	Skipped        bool
+	SkipReason     string
+}
+
+// FailedEventDetails contains the failure and the event which caused it
+type FailedEventDetails struct {
+	*FailedEvent
+	EventType string
+	Creator   string
+	Position  float64
+	Payload   json.RawMessage
 }
 
 type FailedEventSearchQueries struct {
@@ -118,6 +151,117 @@ func (q *Queries) RemoveFailedEvent(ctx context.Context, projectionName, instanc
 	return nil
 }
 
+// FailedEventByID returns the failure and the payload of the event which caused it
+func (q *Queries) FailedEventByID(ctx context.Context, projectionName, instanceID, aggregateType, aggregateID string, sequence uint64) (details *FailedEventDetails, err error) {
+	query, scan := prepareFailedEventQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		FailedEventsColumnProjectionName.identifier(): projectionName,
+		FailedEventsColumnInstanceID.identifier():     instanceID,
+		FailedeventsColumnAggregateType.identifier():  aggregateType,
+		FailedeventsColumnAggregateID.identifier():    aggregateID,
+		FailedEventsColumnFailedSequence.identifier(): sequence,
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-SAm9B", "Errors.Query.SQLStatement")
+	}
+
+	var failedEvent *FailedEvent
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		failedEvent, err = scan(row)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := q.eventstore.Filter(
+		authz.WithInstanceID(ctx, instanceID),
+		handler.FailedEventQuery(authz.WithInstanceID(ctx, instanceID), eventstore.AggregateType(aggregateType), aggregateID, sequence),
+	)
+	if err != nil {
+		return nil, err
+	}
+	details = &FailedEventDetails{FailedEvent: failedEvent}
+	if len(events) == 0 || events[0].Sequence() != sequence {
+		return details, nil
+	}
+	details.EventType = string(events[0].Type())
+	details.Creator = events[0].Creator()
+	details.Position = events[0].Position()
+	if err = events[0].Unmarshal(&details.Payload); err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Bz3Gv", "Errors.Internal")
+	}
+	return details, nil
+}
+
+// RetryFailedEvent reduces the event of the failure again. The failure is removed on success.
+func (q *Queries) RetryFailedEvent(ctx context.Context, projectionName, instanceID, aggregateType, aggregateID string, sequence uint64) error {
+	return projection.RetryFailedEvent(ctx, projectionName, instanceID, eventstore.AggregateType(aggregateType), aggregateID, sequence)
+}
+
+// RetryFailedEvents retries all failures of the projection of the instance in the order the events were created.
+// It returns the number of failures which are still failing.
+func (q *Queries) RetryFailedEvents(ctx context.Context, projectionName, instanceID string) (stillFailing uint64, err error) {
+	projectionQuery, err := NewTextQuery(FailedEventsColumnProjectionName, projectionName, TextEquals)
+	if err != nil {
+		return 0, err
+	}
+	instanceQuery, err := NewFailedEventInstanceIDSearchQuery(instanceID)
+	if err != nil {
+		return 0, err
+	}
+	failedEvents, err := q.SearchFailedEvents(ctx, &FailedEventSearchQueries{
+		SearchRequest: SearchRequest{
+			SortingColumn: FailedEventsColumnEventDate,
+			Asc:           true,
+		},
+		Queries: []SearchQuery{projectionQuery, instanceQuery},
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, failedEvent := range failedEvents.FailedEvents {
+		err = q.RetryFailedEvent(ctx, failedEvent.ProjectionName, failedEvent.InstanceID, failedEvent.AggregateType, failedEvent.AggregateID, failedEvent.FailedSequence)
+		if zerrors.IsPreconditionFailed(err) || zerrors.IsInternal(err) {
+			stillFailing++
+			continue
+		}
+		if err != nil {
+			return stillFailing, err
+		}
+	}
+	return stillFailing, nil
+}
+
+// SkipFailedEvent marks the failure as skipped, the projection continues with the next event on its next run
+func (q *Queries) SkipFailedEvent(ctx context.Context, projectionName, instanceID, aggregateType, aggregateID string, sequence uint64, reason string) (err error) {
+	stmt, args, err := sq.Update(projection.FailedEventsTable).
+		SetMap(map[string]interface{}{
+			failedEventsColumnSkipped:    true,
+			failedEventsColumnSkipReason: reason,
+		}).
+		Where(sq.Eq{
+			failedEventsColumnProjectionName: projectionName,
+			failedEventsColumnInstanceID:     instanceID,
+			failedEventsColumnAggregateType:  aggregateType,
+			failedEventsColumnAggregateID:    aggregateID,
+			failedEventsColumnFailedSequence: sequence,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return zerrors.ThrowInternal(err, "QUERY-Wq2oT", "Errors.Query.SQLStatement")
+	}
+	res, err := q.client.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "QUERY-Yk9fu", "Errors.Internal")
+	}
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		return zerrors.ThrowNotFound(err, "QUERY-0tpJx", "Errors.FailedEvent.NotFound")
+	}
+	return nil
+}
+
 func NewFailedEventInstanceIDSearchQuery(instanceID string) (SearchQuery, error) {
 	return NewTextQuery(FailedEventsColumnInstanceID, instanceID, TextEquals)
 }
@@ -139,15 +283,65 @@ func (q *FailedEventSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuil
 	return query
 }
 
+func prepareFailedEventQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*FailedEvent, error)) {
+	return sq.Select(
+			FailedEventsColumnProjectionName.identifier(),
+			FailedEventsColumnInstanceID.identifier(),
+			FailedEventsColumnFailedSequence.identifier(),
+			FailedeventsColumnAggregateType.identifier(),
+			FailedeventsColumnAggregateID.identifier(),
+			FailedEventsColumnEventDate.identifier(),
+			FailedEventsColumnFailureCount.identifier(),
+			FailedEventsColumnLastFailed.identifier(),
+			FailedEventsColumnError.identifier(),
+			FailedEventsColumnSkipped.identifier(),
+			FailedEventsColumnSkipReason.identifier()).
+			From(failedEventsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*FailedEvent, error) {
+			failedEvent := new(FailedEvent)
+			var (
+				lastFailed sql.NullTime
+				skipReason sql.NullString
+			)
+			err := row.Scan(
+				&failedEvent.ProjectionName,
+				&failedEvent.InstanceID,
+				&failedEvent.FailedSequence,
+				&failedEvent.AggregateType,
+				&failedEvent.AggregateID,
+				&failedEvent.EventDate,
+				&failedEvent.FailureCount,
+				&lastFailed,
+				&failedEvent.Error,
+				&failedEvent.Skipped,
+				&skipReason,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-4xa1L", "Errors.FailedEvent.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Xm0tj", "Errors.Internal")
+			}
+			failedEvent.LastFailed = lastFailed.Time
+			failedEvent.SkipReason = skipReason.String
+			return failedEvent, nil
+		}
+}
+
 func prepareFailedEventsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*FailedEvents, error)) {
 	return sq.Select(
 			FailedEventsColumnProjectionName.identifier(),
+			FailedEventsColumnInstanceID.identifier(),
 			FailedEventsColumnFailedSequence.identifier(),
 			FailedeventsColumnAggregateType.identifier(),
 			FailedeventsColumnAggregateID.identifier(),
+			FailedEventsColumnEventDate.identifier(),
 			FailedEventsColumnFailureCount.identifier(),
 			FailedEventsColumnLastFailed.identifier(),
 			FailedEventsColumnError.identifier(),
+			FailedEventsColumnSkipped.identifier(),
+			FailedEventsColumnSkipReason.identifier(),
 			countColumn.identifier()).
 			From(failedEventsTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
@@ -156,21 +350,29 @@ func prepareFailedEventsQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			var count uint64
 			for rows.Next() {
 				failedEvent := new(FailedEvent)
-				var lastFailed sql.NullTime
+				var (
+					lastFailed sql.NullTime
+					skipReason sql.NullString
+				)
 				err := rows.Scan(
 					&failedEvent.ProjectionName,
+					&failedEvent.InstanceID,
 					&failedEvent.FailedSequence,
 					&failedEvent.AggregateType,
 					&failedEvent.AggregateID,
+					&failedEvent.EventDate,
 					&failedEvent.FailureCount,
 					&lastFailed,
 					&failedEvent.Error,
+					&failedEvent.Skipped,
+					&skipReason,
 					&count,
 				)
 				if err != nil {
 					return nil, err
 				}
 				failedEvent.LastFailed = lastFailed.Time
+				failedEvent.SkipReason = skipReason.String
 				failedEvents = append(failedEvents, failedEvent)
 			}
 
@@ -7,31 +7,57 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 var (
-	prepareFailedEventsStmt = `SELECT` +
+	prepareFailedEventStmt = `SELECT` +
 		` projections.failed_events2.projection_name,` +
+		` projections.failed_events2.instance_id,` +
 		` projections.failed_events2.failed_sequence,` +
 		` projections.failed_events2.aggregate_type,` +
 		` projections.failed_events2.aggregate_id,` +
+		` projections.failed_events2.event_creation_date,` +
 		` projections.failed_events2.failure_count,` +
 		` projections.failed_events2.last_failed,` +
 		` projections.failed_events2.error,` +
-		` COUNT(*) OVER ()` +
+		` projections.failed_events2.skipped,` +
+		` projections.failed_events2.skip_reason` +
 		` FROM projections.failed_events2` +
 		` AS OF SYSTEM TIME '-1 ms'`
 
-	prepareFailedEventsCols = []string{
+	prepareFailedEventCols = []string{
 		"projection_name",
+		"instance_id",
 		"failed_sequence",
 		"aggregate_type",
 		"aggregate_id",
+		"event_creation_date",
 		"failure_count",
 		"last_failed",
 		"error",
-		"count",
+		"skipped",
+		"skip_reason",
 	}
+
+	prepareFailedEventsStmt = `SELECT` +
+		` projections.failed_events2.projection_name,` +
+		` projections.failed_events2.instance_id,` +
+		` projections.failed_events2.failed_sequence,` +
+		` projections.failed_events2.aggregate_type,` +
+		` projections.failed_events2.aggregate_id,` +
+		` projections.failed_events2.event_creation_date,` +
+		` projections.failed_events2.failure_count,` +
+		` projections.failed_events2.last_failed,` +
+		` projections.failed_events2.error,` +
+		` projections.failed_events2.skipped,` +
+		` projections.failed_events2.skip_reason,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.failed_events2` +
+		` AS OF SYSTEM TIME '-1 ms'`
+
+	prepareFailedEventsCols = append(prepareFailedEventCols, "count")
 )
 
 func Test_FailedEventsPrepares(t *testing.T) {
@@ -67,12 +93,16 @@ func Test_FailedEventsPrepares(t *testing.T) {
 					[][]driver.Value{
 						{
 							"projection-name",
+							"instance-id",
 							uint64(20211108),
 							"agg-type",
 							"agg-id",
+							testNow,
 							uint64(2),
 							testNow,
 							"error",
+							false,
+							nil,
 						},
 					},
 				),
@@ -84,6 +114,8 @@ func Test_FailedEventsPrepares(t *testing.T) {
 				FailedEvents: []*FailedEvent{
 					{
 						ProjectionName: "projection-name",
+						InstanceID:     "instance-id",
+						EventDate:      testNow,
 						FailedSequence: 20211108,
 						FailureCount:   2,
 						LastFailed:     testNow,
@@ -104,21 +136,29 @@ func Test_FailedEventsPrepares(t *testing.T) {
 					[][]driver.Value{
 						{
 							"projection-name",
+							"instance-id",
 							uint64(20211108),
 							"agg-type",
 							"agg-id",
+							testNow,
 							2,
 							testNow,
 							"error",
+							false,
+							nil,
 						},
 						{
 							"projection-name-2",
+							"instance-id",
 							uint64(20211108),
 							"agg-type",
 							"agg-id",
+							testNow,
 							2,
 							nil,
 							"error",
+							true,
+							"reason",
 						},
 					},
 				),
@@ -130,6 +170,8 @@ func Test_FailedEventsPrepares(t *testing.T) {
 				FailedEvents: []*FailedEvent{
 					{
 						ProjectionName: "projection-name",
+						InstanceID:     "instance-id",
+						EventDate:      testNow,
 						FailedSequence: 20211108,
 						FailureCount:   2,
 						LastFailed:     testNow,
@@ -139,9 +181,13 @@ func Test_FailedEventsPrepares(t *testing.T) {
 					},
 					{
 						ProjectionName: "projection-name-2",
+						InstanceID:     "instance-id",
+						EventDate:      testNow,
 						FailedSequence: 20211108,
 						FailureCount:   2,
 						Error:          "error",
+						Skipped:        true,
+						SkipReason:     "reason",
 						AggregateType:  "agg-type",
 						AggregateID:    "agg-id",
 					},
@@ -165,6 +211,60 @@ func Test_FailedEventsPrepares(t *testing.T) {
 			},
 			object: (*FailedEvents)(nil),
 		},
+		{
+			name:    "prepareFailedEventQuery no result",
+			prepare: prepareFailedEventQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareFailedEventStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*FailedEvent)(nil),
+		},
+		{
+			name:    "prepareFailedEventQuery found",
+			prepare: prepareFailedEventQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareFailedEventStmt),
+					prepareFailedEventCols,
+					[]driver.Value{
+						"projection-name",
+						"instance-id",
+						uint64(20211108),
+						"agg-type",
+						"agg-id",
+						testNow,
+						uint64(5),
+						testNow,
+						"error",
+						true,
+						"reason",
+					},
+				),
+			},
+			object: &FailedEvent{
+				ProjectionName: "projection-name",
+				InstanceID:     "instance-id",
+				FailedSequence: 20211108,
+				AggregateType:  "agg-type",
+				AggregateID:    "agg-id",
+				EventDate:      testNow,
+				FailureCount:   5,
+				LastFailed:     testNow,
+				Error:          "error",
+				Skipped:        true,
+				SkipReason:     "reason",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,120 @@
+package query
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type IDPAttributeMappingReadModel struct {
+	*eventstore.ReadModel
+
+	IDPID   string
+	Mapping idp.AttributeMapping
+}
+
+// IDPAttributeMappingByIDPID returns the attribute mapping of the IDP, which is empty if none was set.
+// The resourceOwner is optional, e.g. during the login, where the IDP can either belong to the instance or an organization.
+func (q *Queries) IDPAttributeMappingByIDPID(ctx context.Context, idpID, resourceOwner string) (_ *domain.IDPAttributeMapping, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Chee3", "Errors.IDMissing")
+	}
+	readModel := NewIDPAttributeMappingReadModel(idpID, resourceOwner)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	metadata := make([]*domain.IDPMetadataMapping, len(readModel.Mapping.Metadata))
+	for i, entry := range readModel.Mapping.Metadata {
+		metadata[i] = &domain.IDPMetadataMapping{
+			Key:        entry.Key,
+			Expression: entry.Expression,
+		}
+	}
+	return &domain.IDPAttributeMapping{
+		FirstName:         readModel.Mapping.FirstName,
+		LastName:          readModel.Mapping.LastName,
+		DisplayName:       readModel.Mapping.DisplayName,
+		NickName:          readModel.Mapping.NickName,
+		PreferredUsername: readModel.Mapping.PreferredUsername,
+		Email:             readModel.Mapping.Email,
+		EmailVerified:     readModel.Mapping.EmailVerified,
+		Phone:             readModel.Mapping.Phone,
+		PhoneVerified:     readModel.Mapping.PhoneVerified,
+		PreferredLanguage: readModel.Mapping.PreferredLanguage,
+		Metadata:          metadata,
+	}, nil
+}
+
+func NewIDPAttributeMappingReadModel(idpID, resourceOwner string) *IDPAttributeMappingReadModel {
+	return &IDPAttributeMappingReadModel{
+		ReadModel: &eventstore.ReadModel{
+			ResourceOwner: resourceOwner,
+		},
+		IDPID: idpID,
+	}
+}
+
+func (rm *IDPAttributeMappingReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *instance.IDPAttributeMappingSetEvent:
+			rm.reduceSet(&e.AttributeMappingSetEvent)
+		case *org.IDPAttributeMappingSetEvent:
+			rm.reduceSet(&e.AttributeMappingSetEvent)
+		case *instance.IDPRemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		case *org.IDPRemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *IDPAttributeMappingReadModel) reduceSet(e *idp.AttributeMappingSetEvent) {
+	if e.ID != rm.IDPID {
+		return
+	}
+	rm.Mapping = e.AttributeMapping
+}
+
+func (rm *IDPAttributeMappingReadModel) reduceRemoved(e *idp.RemovedEvent) {
+	if e.ID != rm.IDPID {
+		return
+	}
+	rm.Mapping = idp.AttributeMapping{}
+}
+
+func (rm *IDPAttributeMappingReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AllowTimeTravel().
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPAttributeMappingSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPAttributeMappingSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Builder()
+
+	if rm.ResourceOwner != "" {
+		query.ResourceOwner(rm.ResourceOwner)
+	}
+	return query
+}
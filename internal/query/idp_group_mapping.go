@@ -0,0 +1,116 @@
+package query
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type IDPGroupMappingReadModel struct {
+	*eventstore.ReadModel
+
+	IDPID           string
+	GroupsAttribute string
+	Rules           []idp.GroupMappingRule
+}
+
+// IDPGroupMappingByIDPID returns the group mapping of the IDP, which is empty if none was set.
+// The resourceOwner is optional, e.g. during the login, where the IDP can either belong to the instance or an organization.
+func (q *Queries) IDPGroupMappingByIDPID(ctx context.Context, idpID, resourceOwner string) (_ *domain.IDPGroupMapping, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-eeR8u", "Errors.IDMissing")
+	}
+	readModel := NewIDPGroupMappingReadModel(idpID, resourceOwner)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	mapping := &domain.IDPGroupMapping{
+		GroupsAttribute: readModel.GroupsAttribute,
+		Rules:           make([]*domain.IDPGroupMappingRule, len(readModel.Rules)),
+	}
+	for i, rule := range readModel.Rules {
+		mapping.Rules[i] = &domain.IDPGroupMappingRule{
+			Group:          rule.Group,
+			ProjectID:      rule.ProjectID,
+			RoleKeys:       rule.RoleKeys,
+			OrgMemberRoles: rule.OrgMemberRoles,
+		}
+	}
+	return mapping, nil
+}
+
+func NewIDPGroupMappingReadModel(idpID, resourceOwner string) *IDPGroupMappingReadModel {
+	return &IDPGroupMappingReadModel{
+		ReadModel: &eventstore.ReadModel{
+			ResourceOwner: resourceOwner,
+		},
+		IDPID: idpID,
+	}
+}
+
+func (rm *IDPGroupMappingReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *instance.IDPGroupMappingSetEvent:
+			rm.reduceSet(&e.GroupMappingSetEvent)
+		case *org.IDPGroupMappingSetEvent:
+			rm.reduceSet(&e.GroupMappingSetEvent)
+		case *instance.IDPRemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		case *org.IDPRemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *IDPGroupMappingReadModel) reduceSet(e *idp.GroupMappingSetEvent) {
+	if e.ID != rm.IDPID {
+		return
+	}
+	rm.GroupsAttribute = e.GroupsAttribute
+	rm.Rules = e.Rules
+}
+
+func (rm *IDPGroupMappingReadModel) reduceRemoved(e *idp.RemovedEvent) {
+	if e.ID != rm.IDPID {
+		return
+	}
+	rm.GroupsAttribute = ""
+	rm.Rules = nil
+}
+
+func (rm *IDPGroupMappingReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AllowTimeTravel().
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPGroupMappingSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPGroupMappingSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Builder()
+
+	if rm.ResourceOwner != "" {
+		query.ResourceOwner(rm.ResourceOwner)
+	}
+	return query
+}
@@ -0,0 +1,105 @@
+package query
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type IDPInitiatedLoginReadModel struct {
+	*eventstore.ReadModel
+
+	IDPID string
+	Login *domain.IDPInitiatedLogin
+}
+
+// IDPInitiatedLogin returns if unsolicited responses (IdP-initiated login) of the SAML IDP are accepted.
+// The resourceOwner is optional and restricts the IDP to the instance or an organization.
+func (q *Queries) IDPInitiatedLogin(ctx context.Context, idpID, resourceOwner string) (_ *domain.IDPInitiatedLogin, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Iev5u", "Errors.IDMissing")
+	}
+	readModel := NewIDPInitiatedLoginReadModel(idpID, resourceOwner)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.Login, nil
+}
+
+func NewIDPInitiatedLoginReadModel(idpID, resourceOwner string) *IDPInitiatedLoginReadModel {
+	return &IDPInitiatedLoginReadModel{
+		ReadModel: &eventstore.ReadModel{
+			ResourceOwner: resourceOwner,
+		},
+		IDPID: idpID,
+		Login: new(domain.IDPInitiatedLogin),
+	}
+}
+
+func (rm *IDPInitiatedLoginReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *instance.IDPInitiatedLoginSetEvent:
+			rm.reduceSet(&e.IDPInitiatedLoginSetEvent)
+		case *org.IDPInitiatedLoginSetEvent:
+			rm.reduceSet(&e.IDPInitiatedLoginSetEvent)
+		case *instance.IDPRemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		case *org.IDPRemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *IDPInitiatedLoginReadModel) reduceSet(e *idp.IDPInitiatedLoginSetEvent) {
+	if e.ID != rm.IDPID {
+		return
+	}
+	rm.Login = &domain.IDPInitiatedLogin{
+		Enabled:            e.Enabled,
+		DefaultRedirectURI: e.DefaultRedirectURI,
+	}
+}
+
+func (rm *IDPInitiatedLoginReadModel) reduceRemoved(e *idp.RemovedEvent) {
+	if e.ID != rm.IDPID {
+		return
+	}
+	rm.Login = new(domain.IDPInitiatedLogin)
+}
+
+func (rm *IDPInitiatedLoginReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AllowTimeTravel().
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPInitiatedLoginSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPInitiatedLoginSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Builder()
+
+	if rm.ResourceOwner != "" {
+		query.ResourceOwner(rm.ResourceOwner)
+	}
+	return query
+}
@@ -0,0 +1,272 @@
+package query
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type IDPLDAPSyncReadModel struct {
+	*eventstore.ReadModel
+
+	IDPID string
+	Sync  idp.LDAPSync
+}
+
+// IDPLDAPSyncByIDPID returns the synchronization settings of the LDAP IDP, which are empty (disabled) if none were set.
+// The resourceOwner is optional and restricts the IDP to the instance or an organization.
+func (q *Queries) IDPLDAPSyncByIDPID(ctx context.Context, idpID, resourceOwner string) (_ *domain.IDPLDAPSync, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-ooN3o", "Errors.IDMissing")
+	}
+	readModel := NewIDPLDAPSyncReadModel(idpID, resourceOwner)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return &domain.IDPLDAPSync{
+		Enabled:         readModel.Sync.Enabled,
+		Interval:        readModel.Sync.Interval,
+		SearchBase:      readModel.Sync.SearchBase,
+		Filter:          readModel.Sync.Filter,
+		CreateUsers:     readModel.Sync.CreateUsers,
+		UpdateUsers:     readModel.Sync.UpdateUsers,
+		DeactivateUsers: readModel.Sync.DeactivateUsers,
+	}, nil
+}
+
+func NewIDPLDAPSyncReadModel(idpID, resourceOwner string) *IDPLDAPSyncReadModel {
+	return &IDPLDAPSyncReadModel{
+		ReadModel: &eventstore.ReadModel{
+			ResourceOwner: resourceOwner,
+		},
+		IDPID: idpID,
+	}
+}
+
+func (rm *IDPLDAPSyncReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *instance.IDPLDAPSyncSetEvent:
+			rm.reduceSet(&e.LDAPSyncSetEvent)
+		case *org.IDPLDAPSyncSetEvent:
+			rm.reduceSet(&e.LDAPSyncSetEvent)
+		case *instance.IDPRemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		case *org.IDPRemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *IDPLDAPSyncReadModel) reduceSet(e *idp.LDAPSyncSetEvent) {
+	if e.ID != rm.IDPID {
+		return
+	}
+	rm.Sync = e.LDAPSync
+}
+
+func (rm *IDPLDAPSyncReadModel) reduceRemoved(e *idp.RemovedEvent) {
+	if e.ID != rm.IDPID {
+		return
+	}
+	rm.Sync = idp.LDAPSync{}
+}
+
+func (rm *IDPLDAPSyncReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AllowTimeTravel().
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPLDAPSyncSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPLDAPSyncSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Builder()
+
+	if rm.ResourceOwner != "" {
+		query.ResourceOwner(rm.ResourceOwner)
+	}
+	return query
+}
+
+type IDPLDAPSyncRunsReadModel struct {
+	*eventstore.ReadModel
+
+	IDPID string
+	Limit uint64
+	// Runs are sorted by the end of the run, latest first
+	Runs []*domain.IDPLDAPSyncRun
+}
+
+// IDPLDAPSyncRuns returns the latest synchronization runs of the LDAP IDP, latest first.
+// The resourceOwner is optional and restricts the IDP to the instance or an organization.
+func (q *Queries) IDPLDAPSyncRuns(ctx context.Context, idpID, resourceOwner string, limit uint64) (_ []*domain.IDPLDAPSyncRun, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Iev4a", "Errors.IDMissing")
+	}
+	readModel := NewIDPLDAPSyncRunsReadModel(idpID, resourceOwner, limit)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.Runs, nil
+}
+
+func NewIDPLDAPSyncRunsReadModel(idpID, resourceOwner string, limit uint64) *IDPLDAPSyncRunsReadModel {
+	return &IDPLDAPSyncRunsReadModel{
+		ReadModel: &eventstore.ReadModel{
+			ResourceOwner: resourceOwner,
+		},
+		IDPID: idpID,
+		Limit: limit,
+	}
+}
+
+func (rm *IDPLDAPSyncRunsReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *instance.IDPLDAPSyncRunFinishedEvent:
+			rm.reduceFinished(&e.LDAPSyncRunFinishedEvent)
+		case *org.IDPLDAPSyncRunFinishedEvent:
+			rm.reduceFinished(&e.LDAPSyncRunFinishedEvent)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *IDPLDAPSyncRunsReadModel) reduceFinished(e *idp.LDAPSyncRunFinishedEvent) {
+	if e.ID != rm.IDPID {
+		return
+	}
+	rm.Runs = append(rm.Runs, &domain.IDPLDAPSyncRun{
+		IDPID:       e.ID,
+		StartedAt:   e.StartedAt,
+		FinishedAt:  e.CreatedAt(),
+		Users:       e.Users,
+		Created:     e.Created,
+		Updated:     e.Updated,
+		Deactivated: e.Deactivated,
+		Failed:      e.Failed,
+		Error:       e.Error,
+	})
+}
+
+func (rm *IDPLDAPSyncRunsReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AllowTimeTravel().
+		OrderDesc().
+		Limit(rm.Limit).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(instance.IDPLDAPSyncRunFinishedEventType).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(org.IDPLDAPSyncRunFinishedEventType).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Builder()
+
+	if rm.ResourceOwner != "" {
+		query.ResourceOwner(rm.ResourceOwner)
+	}
+	return query
+}
+
+// IDPLDAPSyncsReadModel collects the synchronization settings of all LDAP IDPs of the instance
+type IDPLDAPSyncsReadModel struct {
+	*eventstore.ReadModel
+
+	Syncs map[string]idp.LDAPSync
+}
+
+// DueIDPLDAPSyncs returns the IDs of the LDAP IDPs of the instance with an enabled synchronization,
+// which did either never run or whose last run finished at least the interval before now.
+func (q *Queries) DueIDPLDAPSyncs(ctx context.Context, now time.Time) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	readModel := NewIDPLDAPSyncsReadModel()
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	idpIDs := make([]string, 0, len(readModel.Syncs))
+	for idpID, sync := range readModel.Syncs {
+		if !sync.Enabled {
+			continue
+		}
+		runs := NewIDPLDAPSyncRunsReadModel(idpID, "", 1)
+		if err = q.eventstore.FilterToQueryReducer(ctx, runs); err != nil {
+			return nil, err
+		}
+		if len(runs.Runs) == 0 || !runs.Runs[0].FinishedAt.Add(sync.Interval).After(now) {
+			idpIDs = append(idpIDs, idpID)
+		}
+	}
+	slices.Sort(idpIDs)
+	return idpIDs, nil
+}
+
+func NewIDPLDAPSyncsReadModel() *IDPLDAPSyncsReadModel {
+	return &IDPLDAPSyncsReadModel{
+		ReadModel: new(eventstore.ReadModel),
+		Syncs:     make(map[string]idp.LDAPSync),
+	}
+}
+
+func (rm *IDPLDAPSyncsReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *instance.IDPLDAPSyncSetEvent:
+			rm.Syncs[e.ID] = e.LDAPSync
+		case *org.IDPLDAPSyncSetEvent:
+			rm.Syncs[e.ID] = e.LDAPSync
+		case *instance.IDPRemovedEvent:
+			delete(rm.Syncs, e.ID)
+		case *org.IDPRemovedEvent:
+			delete(rm.Syncs, e.ID)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *IDPLDAPSyncsReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPLDAPSyncSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPLDAPSyncSetEventType,
+			org.IDPRemovedEventType,
+		).
+		Builder()
+}
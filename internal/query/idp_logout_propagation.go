@@ -0,0 +1,100 @@
+package query
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type IDPLogoutPropagationReadModel struct {
+	*eventstore.ReadModel
+
+	IDPID   string
+	Enabled bool
+}
+
+// IDPLogoutPropagationEnabled returns if the session of the user at the IDP is terminated when the user logs out.
+// The resourceOwner is optional and restricts the IDP to the instance or an organization.
+func (q *Queries) IDPLogoutPropagationEnabled(ctx context.Context, idpID, resourceOwner string) (_ bool, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if idpID == "" {
+		return false, zerrors.ThrowInvalidArgument(nil, "QUERY-Eip6o", "Errors.IDMissing")
+	}
+	readModel := NewIDPLogoutPropagationReadModel(idpID, resourceOwner)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return false, err
+	}
+	return readModel.Enabled, nil
+}
+
+func NewIDPLogoutPropagationReadModel(idpID, resourceOwner string) *IDPLogoutPropagationReadModel {
+	return &IDPLogoutPropagationReadModel{
+		ReadModel: &eventstore.ReadModel{
+			ResourceOwner: resourceOwner,
+		},
+		IDPID: idpID,
+	}
+}
+
+func (rm *IDPLogoutPropagationReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *instance.IDPLogoutPropagationSetEvent:
+			rm.reduceSet(&e.LogoutPropagationSetEvent)
+		case *org.IDPLogoutPropagationSetEvent:
+			rm.reduceSet(&e.LogoutPropagationSetEvent)
+		case *instance.IDPRemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		case *org.IDPRemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *IDPLogoutPropagationReadModel) reduceSet(e *idp.LogoutPropagationSetEvent) {
+	if e.ID != rm.IDPID {
+		return
+	}
+	rm.Enabled = e.Enabled
+}
+
+func (rm *IDPLogoutPropagationReadModel) reduceRemoved(e *idp.RemovedEvent) {
+	if e.ID != rm.IDPID {
+		return
+	}
+	rm.Enabled = false
+}
+
+func (rm *IDPLogoutPropagationReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AllowTimeTravel().
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPLogoutPropagationSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPLogoutPropagationSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Builder()
+
+	if rm.ResourceOwner != "" {
+		query.ResourceOwner(rm.ResourceOwner)
+	}
+	return query
+}
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type IDPTokenVaultReadModel struct {
+	*eventstore.ReadModel
+
+	IDPID   string
+	Enabled bool
+}
+
+// IDPTokenVaultEnabled returns if the tokens issued by the IDP are stored on the links of its users.
+// The resourceOwner is optional and restricts the IDP to the instance or an organization.
+func (q *Queries) IDPTokenVaultEnabled(ctx context.Context, idpID, resourceOwner string) (_ bool, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if idpID == "" {
+		return false, zerrors.ThrowInvalidArgument(nil, "QUERY-Thai3", "Errors.IDMissing")
+	}
+	readModel := NewIDPTokenVaultReadModel(idpID, resourceOwner)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return false, err
+	}
+	return readModel.Enabled, nil
+}
+
+func NewIDPTokenVaultReadModel(idpID, resourceOwner string) *IDPTokenVaultReadModel {
+	return &IDPTokenVaultReadModel{
+		ReadModel: &eventstore.ReadModel{
+			ResourceOwner: resourceOwner,
+		},
+		IDPID: idpID,
+	}
+}
+
+func (rm *IDPTokenVaultReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *instance.IDPTokenVaultSetEvent:
+			rm.reduceSet(&e.TokenVaultSetEvent)
+		case *org.IDPTokenVaultSetEvent:
+			rm.reduceSet(&e.TokenVaultSetEvent)
+		case *instance.IDPRemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		case *org.IDPRemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *IDPTokenVaultReadModel) reduceSet(e *idp.TokenVaultSetEvent) {
+	if e.ID != rm.IDPID {
+		return
+	}
+	rm.Enabled = e.Enabled
+}
+
+func (rm *IDPTokenVaultReadModel) reduceRemoved(e *idp.RemovedEvent) {
+	if e.ID != rm.IDPID {
+		return
+	}
+	rm.Enabled = false
+}
+
+func (rm *IDPTokenVaultReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AllowTimeTravel().
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPTokenVaultSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPTokenVaultSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": rm.IDPID}).
+		Builder()
+
+	if rm.ResourceOwner != "" {
+		query.ResourceOwner(rm.ResourceOwner)
+	}
+	return query
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -31,10 +32,28 @@ func TriggerIntrospectionProjections(ctx context.Context) {
 }
 
 type IntrospectionClient struct {
-	ClientID     string
-	ClientSecret *crypto.CryptoValue
-	ProjectID    string
-	PublicKeys   database.Map[[]byte]
+	ClientID      string
+	ClientSecret  *crypto.CryptoValue
+	ProjectID     string
+	AppID         string
+	ResourceOwner string
+	// APIAuthMethodType is set for API applications
+	APIAuthMethodType *domain.APIAuthMethodType
+	// OIDCAuthMethodType is set for OIDC applications
+	OIDCAuthMethodType *domain.OIDCAuthMethodType
+	PublicKeys         database.Map[[]byte]
+}
+
+// TLSClientAuth returns if the client authenticates with a client certificate (mutual TLS)
+// and if the certificate is a registered self-signed one.
+func (c *IntrospectionClient) TLSClientAuth() (tlsClientAuth, selfSigned bool) {
+	if c.APIAuthMethodType != nil {
+		return c.APIAuthMethodType.IsTLSClientAuth(), *c.APIAuthMethodType == domain.APIAuthMethodTypeSelfSignedTLSClientAuth
+	}
+	if c.OIDCAuthMethodType != nil {
+		return c.OIDCAuthMethodType.IsTLSClientAuth(), *c.OIDCAuthMethodType == domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth
+	}
+	return false, false
 }
 
 //go:embed embed/introspection_client_by_id.sql
@@ -50,7 +69,8 @@ func (q *Queries) GetIntrospectionClientByID(ctx context.Context, clientID strin
 	)
 
 	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
-		return row.Scan(&client.ClientID, &client.ClientSecret, &client.ProjectID, &client.PublicKeys)
+		return row.Scan(&client.ClientID, &client.ClientSecret, &client.ProjectID, &client.AppID, &client.ResourceOwner,
+			&client.APIAuthMethodType, &client.OIDCAuthMethodType, &client.PublicKeys)
 	},
 		introspectionClientByIDQuery,
 		instanceID, clientID, getKeys,
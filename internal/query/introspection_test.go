@@ -8,12 +8,14 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/muhlemmer/gu"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 )
 
 func TestQueries_GetIntrospectionClientByID(t *testing.T) {
@@ -61,14 +63,17 @@ func TestQueries_GetIntrospectionClientByID(t *testing.T) {
 				getKeys:  false,
 			},
 			mock: mockQuery(expQuery,
-				[]string{"client_id", "client_secret", "project_id", "public_keys"},
-				[]driver.Value{"clientID", encSecret, "projectID", nil},
+				[]string{"client_id", "client_secret", "project_id", "app_id", "resource_owner", "api_auth_method", "oidc_auth_method", "public_keys"},
+				[]driver.Value{"clientID", encSecret, "projectID", "appID", "orgID", int64(domain.APIAuthMethodTypeBasic), nil, nil},
 				"instanceID", "clientID", false),
 			want: &IntrospectionClient{
-				ClientID:     "clientID",
-				ClientSecret: secret,
-				ProjectID:    "projectID",
-				PublicKeys:   nil,
+				ClientID:          "clientID",
+				ClientSecret:      secret,
+				ProjectID:         "projectID",
+				AppID:             "appID",
+				ResourceOwner:     "orgID",
+				APIAuthMethodType: gu.Ptr(domain.APIAuthMethodTypeBasic),
+				PublicKeys:        nil,
 			},
 		},
 		{
@@ -78,14 +83,17 @@ func TestQueries_GetIntrospectionClientByID(t *testing.T) {
 				getKeys:  true,
 			},
 			mock: mockQuery(expQuery,
-				[]string{"client_id", "client_secret", "project_id", "public_keys"},
-				[]driver.Value{"clientID", nil, "projectID", encPubkeys},
+				[]string{"client_id", "client_secret", "project_id", "app_id", "resource_owner", "api_auth_method", "oidc_auth_method", "public_keys"},
+				[]driver.Value{"clientID", nil, "projectID", "appID", "orgID", nil, int64(domain.OIDCAuthMethodTypePrivateKeyJWT), encPubkeys},
 				"instanceID", "clientID", true),
 			want: &IntrospectionClient{
-				ClientID:     "clientID",
-				ClientSecret: nil,
-				ProjectID:    "projectID",
-				PublicKeys:   pubkeys,
+				ClientID:           "clientID",
+				ClientSecret:       nil,
+				ProjectID:          "projectID",
+				AppID:              "appID",
+				ResourceOwner:      "orgID",
+				OIDCAuthMethodType: gu.Ptr(domain.OIDCAuthMethodTypePrivateKeyJWT),
+				PublicKeys:         pubkeys,
 			},
 		},
 	}
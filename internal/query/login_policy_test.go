@@ -15,31 +15,33 @@ import (
 )
 
 var (
-	loginPolicyQuery = `SELECT projections.login_policies5.aggregate_id,` +
-		` projections.login_policies5.creation_date,` +
-		` projections.login_policies5.change_date,` +
-		` projections.login_policies5.sequence,` +
-		` projections.login_policies5.allow_register,` +
-		` projections.login_policies5.allow_username_password,` +
-		` projections.login_policies5.allow_external_idps,` +
-		` projections.login_policies5.force_mfa,` +
-		` projections.login_policies5.force_mfa_local_only,` +
-		` projections.login_policies5.second_factors,` +
-		` projections.login_policies5.multi_factors,` +
-		` projections.login_policies5.passwordless_type,` +
-		` projections.login_policies5.is_default,` +
-		` projections.login_policies5.hide_password_reset,` +
-		` projections.login_policies5.ignore_unknown_usernames,` +
-		` projections.login_policies5.allow_domain_discovery,` +
-		` projections.login_policies5.disable_login_with_email,` +
-		` projections.login_policies5.disable_login_with_phone,` +
-		` projections.login_policies5.default_redirect_uri,` +
-		` projections.login_policies5.password_check_lifetime,` +
-		` projections.login_policies5.external_login_check_lifetime,` +
-		` projections.login_policies5.mfa_init_skip_lifetime,` +
-		` projections.login_policies5.second_factor_check_lifetime,` +
-		` projections.login_policies5.multi_factor_check_lifetime` +
-		` FROM projections.login_policies5` +
+	loginPolicyQuery = `SELECT projections.login_policies7.aggregate_id,` +
+		` projections.login_policies7.creation_date,` +
+		` projections.login_policies7.change_date,` +
+		` projections.login_policies7.sequence,` +
+		` projections.login_policies7.allow_register,` +
+		` projections.login_policies7.allow_username_password,` +
+		` projections.login_policies7.allow_external_idps,` +
+		` projections.login_policies7.force_mfa,` +
+		` projections.login_policies7.force_mfa_local_only,` +
+		` projections.login_policies7.second_factors,` +
+		` projections.login_policies7.multi_factors,` +
+		` projections.login_policies7.passwordless_type,` +
+		` projections.login_policies7.is_default,` +
+		` projections.login_policies7.hide_password_reset,` +
+		` projections.login_policies7.ignore_unknown_usernames,` +
+		` projections.login_policies7.allow_domain_discovery,` +
+		` projections.login_policies7.disable_login_with_email,` +
+		` projections.login_policies7.disable_login_with_phone,` +
+		` projections.login_policies7.allow_magic_link,` +
+		` projections.login_policies7.allow_phone_otp_login,` +
+		` projections.login_policies7.default_redirect_uri,` +
+		` projections.login_policies7.password_check_lifetime,` +
+		` projections.login_policies7.external_login_check_lifetime,` +
+		` projections.login_policies7.mfa_init_skip_lifetime,` +
+		` projections.login_policies7.second_factor_check_lifetime,` +
+		` projections.login_policies7.multi_factor_check_lifetime` +
+		` FROM projections.login_policies7` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	loginPolicyCols = []string{
 		"aggregate_id",
@@ -60,6 +62,8 @@ var (
 		"allow_domain_discovery",
 		"disable_login_with_email",
 		"disable_login_with_phone",
+		"allow_magic_link",
+		"allow_phone_otp_login",
 		"default_redirect_uri",
 		"password_check_lifetime",
 		"external_login_check_lifetime",
@@ -68,15 +72,15 @@ var (
 		"multi_factor_check_lifetime",
 	}
 
-	prepareLoginPolicy2FAsStmt = `SELECT projections.login_policies5.second_factors` +
-		` FROM projections.login_policies5` +
+	prepareLoginPolicy2FAsStmt = `SELECT projections.login_policies7.second_factors` +
+		` FROM projections.login_policies7` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareLoginPolicy2FAsCols = []string{
 		"second_factors",
 	}
 
-	prepareLoginPolicyMFAsStmt = `SELECT projections.login_policies5.multi_factors` +
-		` FROM projections.login_policies5` +
+	prepareLoginPolicyMFAsStmt = `SELECT projections.login_policies7.multi_factors` +
+		` FROM projections.login_policies7` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareLoginPolicyMFAsCols = []string{
 		"multi_factors",
@@ -138,6 +142,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 						true,
 						true,
 						true,
+						true,
+						true,
 						"https://example.com/redirect",
 						time.Hour * 2,
 						time.Hour * 2,
@@ -166,6 +172,8 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 				AllowDomainDiscovery:       true,
 				DisableLoginWithEmail:      true,
 				DisableLoginWithPhone:      true,
+				AllowMagicLink:             true,
+				AllowPhoneOTPLogin:         true,
 				DefaultRedirectURI:         "https://example.com/redirect",
 				PasswordCheckLifetime:      time.Hour * 2,
 				ExternalLoginCheckLifetime: time.Hour * 2,
@@ -0,0 +1,151 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	mailTemplateMessagesTable = table{
+		name:          projection.MailTemplateMessagesProjectionTable,
+		instanceIDCol: projection.MailTemplateMessageColumnInstanceID,
+	}
+	MailTemplateMessageColumnOrgID = Column{
+		name:  projection.MailTemplateMessageColumnOrgID,
+		table: mailTemplateMessagesTable,
+	}
+	MailTemplateMessageColumnCreationDate = Column{
+		name:  projection.MailTemplateMessageColumnCreationDate,
+		table: mailTemplateMessagesTable,
+	}
+	MailTemplateMessageColumnChangeDate = Column{
+		name:  projection.MailTemplateMessageColumnChangeDate,
+		table: mailTemplateMessagesTable,
+	}
+	MailTemplateMessageColumnSequence = Column{
+		name:  projection.MailTemplateMessageColumnSequence,
+		table: mailTemplateMessagesTable,
+	}
+	MailTemplateMessageColumnInstanceID = Column{
+		name:  projection.MailTemplateMessageColumnInstanceID,
+		table: mailTemplateMessagesTable,
+	}
+	MailTemplateMessageColumnMessageType = Column{
+		name:  projection.MailTemplateMessageColumnMessageType,
+		table: mailTemplateMessagesTable,
+	}
+	MailTemplateMessageColumnLanguage = Column{
+		name:  projection.MailTemplateMessageColumnLanguage,
+		table: mailTemplateMessagesTable,
+	}
+	MailTemplateMessageColumnStoreKey = Column{
+		name:  projection.MailTemplateMessageColumnStoreKey,
+		table: mailTemplateMessagesTable,
+	}
+	MailTemplateMessageColumnActiveStoreKey = Column{
+		name:  projection.MailTemplateMessageColumnActiveStoreKey,
+		table: mailTemplateMessagesTable,
+	}
+)
+
+// MailTemplateMessage is a custom email template of an organization for a message type and language,
+// the templates are stored as assets, an empty ActiveStoreKey means the preview was never activated
+type MailTemplateMessage struct {
+	OrgID          string
+	CreationDate   time.Time
+	ChangeDate     time.Time
+	Sequence       uint64
+	MessageType    string
+	Language       language.Tag
+	StoreKey       string
+	ActiveStoreKey string
+}
+
+type MailTemplateMessages struct {
+	SearchResponse
+	Messages []*MailTemplateMessage
+}
+
+// MailTemplateMessagesByOrg returns the custom email templates of the organization,
+// filtered by the message type if it is not empty
+func (q *Queries) MailTemplateMessagesByOrg(ctx context.Context, orgID, messageType string) (messages *MailTemplateMessages, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		MailTemplateMessageColumnOrgID.identifier():      orgID,
+		MailTemplateMessageColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if messageType != "" {
+		eq[MailTemplateMessageColumnMessageType.identifier()] = messageType
+	}
+	stmt, scan := prepareMailTemplateMessagesQuery(ctx, q.client)
+	query, args, err := stmt.Where(eq).
+		OrderBy(MailTemplateMessageColumnMessageType.identifier(), MailTemplateMessageColumnLanguage.identifier()).
+		ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-ooR7e", "Errors.Query.SQLStatment")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		messages, err = scan(rows)
+		return err
+	}, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	messages.State, err = q.latestState(ctx, mailTemplateMessagesTable)
+	return messages, err
+}
+
+func prepareMailTemplateMessagesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*MailTemplateMessages, error)) {
+	return sq.Select(
+			MailTemplateMessageColumnOrgID.identifier(),
+			MailTemplateMessageColumnCreationDate.identifier(),
+			MailTemplateMessageColumnChangeDate.identifier(),
+			MailTemplateMessageColumnSequence.identifier(),
+			MailTemplateMessageColumnMessageType.identifier(),
+			MailTemplateMessageColumnLanguage.identifier(),
+			MailTemplateMessageColumnStoreKey.identifier(),
+			MailTemplateMessageColumnActiveStoreKey.identifier(),
+			countColumn.identifier(),
+		).From(mailTemplateMessagesTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*MailTemplateMessages, error) {
+			messages := &MailTemplateMessages{Messages: []*MailTemplateMessage{}}
+			for rows.Next() {
+				message := new(MailTemplateMessage)
+				var lang string
+				err := rows.Scan(
+					&message.OrgID,
+					&message.CreationDate,
+					&message.ChangeDate,
+					&message.Sequence,
+					&message.MessageType,
+					&lang,
+					&message.StoreKey,
+					&message.ActiveStoreKey,
+					&messages.Count,
+				)
+				if err != nil {
+					return nil, zerrors.ThrowInternal(err, "QUERY-Eeph9", "Errors.Internal")
+				}
+				message.Language = language.Make(lang)
+				messages.Messages = append(messages.Messages, message)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-ahk6E", "Errors.Query.CloseRows")
+			}
+			return messages, nil
+		}
+}
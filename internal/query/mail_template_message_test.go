@@ -0,0 +1,142 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	prepareMailTemplateMessagesStmt = `SELECT projections.mail_template_messages.org_id,` +
+		` projections.mail_template_messages.creation_date,` +
+		` projections.mail_template_messages.change_date,` +
+		` projections.mail_template_messages.sequence,` +
+		` projections.mail_template_messages.message_type,` +
+		` projections.mail_template_messages.language,` +
+		` projections.mail_template_messages.store_key,` +
+		` projections.mail_template_messages.active_store_key,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.mail_template_messages`
+	prepareMailTemplateMessagesCols = []string{
+		"org_id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"message_type",
+		"language",
+		"store_key",
+		"active_store_key",
+		"count",
+	}
+)
+
+func Test_MailTemplateMessagesPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareMailTemplateMessagesQuery no result",
+			prepare: prepareMailTemplateMessagesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareMailTemplateMessagesStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &MailTemplateMessages{Messages: []*MailTemplateMessage{}},
+		},
+		{
+			name:    "prepareMailTemplateMessagesQuery multiple result",
+			prepare: prepareMailTemplateMessagesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareMailTemplateMessagesStmt),
+					prepareMailTemplateMessagesCols,
+					[][]driver.Value{
+						{
+							"org-id",
+							testNow,
+							testNow,
+							uint64(20211109),
+							"InitCode",
+							"en",
+							"key2",
+							"key1",
+						},
+						{
+							"org-id",
+							testNow,
+							testNow,
+							uint64(20211110),
+							"InitCode",
+							"de",
+							"key3",
+							"",
+						},
+					},
+				),
+			},
+			object: &MailTemplateMessages{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Messages: []*MailTemplateMessage{
+					{
+						OrgID:          "org-id",
+						CreationDate:   testNow,
+						ChangeDate:     testNow,
+						Sequence:       20211109,
+						MessageType:    "InitCode",
+						Language:       language.English,
+						StoreKey:       "key2",
+						ActiveStoreKey: "key1",
+					},
+					{
+						OrgID:        "org-id",
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						Sequence:     20211110,
+						MessageType:  "InitCode",
+						Language:     language.German,
+						StoreKey:     "key3",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareMailTemplateMessagesQuery sql err",
+			prepare: prepareMailTemplateMessagesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareMailTemplateMessagesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*MailTemplateMessages)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -33,6 +33,15 @@ type MessageTexts struct {
 	DomainClaimed            MessageText
 	PasswordlessRegistration MessageText
 	PasswordChange           MessageText
+	UnknownUserAgentSignIn   MessageText
+	MFAAdded                 MessageText
+	MFARemoved               MessageText
+	EmailChanged             MessageText
+	PhoneChanged             MessageText
+	AccountLocked            MessageText
+	MachineCredentialAdded   MessageText
+	MagicLink                MessageText
+	EmailChangeRequested     MessageText
 }
 
 type MessageText struct {
@@ -346,6 +355,24 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *MessageText {
 		return &m.PasswordlessRegistration
 	case domain.PasswordChangeMessageType:
 		return &m.PasswordChange
+	case domain.UnknownUserAgentSignInMessageType:
+		return &m.UnknownUserAgentSignIn
+	case domain.MFAAddedMessageType:
+		return &m.MFAAdded
+	case domain.MFARemovedMessageType:
+		return &m.MFARemoved
+	case domain.EmailChangedMessageType:
+		return &m.EmailChanged
+	case domain.PhoneChangedMessageType:
+		return &m.PhoneChanged
+	case domain.AccountLockedMessageType:
+		return &m.AccountLocked
+	case domain.MachineCredentialAddedMessageType:
+		return &m.MachineCredentialAdded
+	case domain.MagicLinkMessageType:
+		return &m.MagicLink
+	case domain.EmailChangeRequestedMessageType:
+		return &m.EmailChangeRequested
 	}
 	return nil
 }
@@ -0,0 +1,331 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	notificationOutboxTable = table{
+		name:          projection.NotificationOutboxProjectionTable,
+		instanceIDCol: projection.NotificationOutboxColumnInstanceID,
+	}
+	NotificationOutboxColumnID = Column{
+		name:  projection.NotificationOutboxColumnID,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnCreationDate = Column{
+		name:  projection.NotificationOutboxColumnCreationDate,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnChangeDate = Column{
+		name:  projection.NotificationOutboxColumnChangeDate,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnSequence = Column{
+		name:  projection.NotificationOutboxColumnSequence,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnResourceOwner = Column{
+		name:  projection.NotificationOutboxColumnResourceOwner,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnInstanceID = Column{
+		name:  projection.NotificationOutboxColumnInstanceID,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnState = Column{
+		name:  projection.NotificationOutboxColumnState,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnChannel = Column{
+		name:  projection.NotificationOutboxColumnChannel,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnRecipient = Column{
+		name:  projection.NotificationOutboxColumnRecipient,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnTriggeringAggregateID = Column{
+		name:  projection.NotificationOutboxColumnTriggeringAggregateID,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnTriggeringEventType = Column{
+		name:  projection.NotificationOutboxColumnTriggeringEventType,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnMessage = Column{
+		name:  projection.NotificationOutboxColumnMessage,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnAttempts = Column{
+		name:  projection.NotificationOutboxColumnAttempts,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnNextAttempt = Column{
+		name:  projection.NotificationOutboxColumnNextAttempt,
+		table: notificationOutboxTable,
+	}
+	NotificationOutboxColumnLastError = Column{
+		name:  projection.NotificationOutboxColumnLastError,
+		table: notificationOutboxTable,
+	}
+)
+
+type Notifications struct {
+	SearchResponse
+	Notifications []*Notification
+}
+
+type Notification struct {
+	ID                    string
+	CreationDate          time.Time
+	ChangeDate            time.Time
+	Sequence              uint64
+	ResourceOwner         string
+	State                 domain.NotificationState
+	Channel               domain.NotificationType
+	Recipient             string
+	TriggeringAggregateID string
+	TriggeringEventType   eventstore.EventType
+	// Message is the encrypted JSON of the message, it's only used to send the notification
+	Message     *crypto.CryptoValue
+	Attempts    uint16
+	NextAttempt time.Time
+	LastError   string
+}
+
+type NotificationSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *NotificationSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewNotificationStateSearchQuery(state domain.NotificationState) (SearchQuery, error) {
+	return NewNumberQuery(NotificationOutboxColumnState, state, NumberEquals)
+}
+
+func NewNotificationChannelSearchQuery(channel domain.NotificationType) (SearchQuery, error) {
+	return NewNumberQuery(NotificationOutboxColumnChannel, channel, NumberEquals)
+}
+
+func NewNotificationRecipientSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(NotificationOutboxColumnRecipient, value, method)
+}
+
+func NewNotificationTriggeringAggregateIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(NotificationOutboxColumnTriggeringAggregateID, id, TextEquals)
+}
+
+func (q *Queries) NotificationByID(ctx context.Context, shouldTriggerBulk bool, id string) (notification *Notification, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		ctx = triggerNotificationOutboxProjection(ctx)
+	}
+
+	query, scan := prepareNotificationQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		NotificationOutboxColumnID.identifier():         id,
+		NotificationOutboxColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Hn3lq", "Errors.Query.SQLStatment")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		notification, err = scan(row)
+		return err
+	}, stmt, args...)
+	return notification, err
+}
+
+func (q *Queries) SearchNotifications(ctx context.Context, queries *NotificationSearchQueries) (notifications *Notifications, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareNotificationsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			NotificationOutboxColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "QUERY-Kw9aq", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		notifications, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	notifications.State, err = q.latestState(ctx, notificationOutboxTable)
+	return notifications, err
+}
+
+// DueNotifications returns the notifications of the instance which are scheduled to be sent again until now
+// and the pending notifications requested before pendingBefore, which were neither sent nor scheduled (e.g. because of a crash)
+func (q *Queries) DueNotifications(ctx context.Context, now, pendingBefore time.Time, limit uint64) (notifications *Notifications, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	ctx = triggerNotificationOutboxProjection(ctx)
+
+	query, scan := prepareNotificationsQuery(ctx, q.client)
+	stmt, args, err := query.
+		Where(sq.And{
+			sq.Eq{NotificationOutboxColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()},
+			sq.Or{
+				sq.And{
+					sq.Eq{NotificationOutboxColumnState.identifier(): domain.NotificationStateRetrying},
+					sq.LtOrEq{NotificationOutboxColumnNextAttempt.identifier(): now},
+				},
+				sq.And{
+					sq.Eq{NotificationOutboxColumnState.identifier(): domain.NotificationStatePending},
+					sq.LtOrEq{NotificationOutboxColumnNextAttempt.identifier(): pendingBefore},
+				},
+			},
+		}).
+		OrderBy(NotificationOutboxColumnNextAttempt.identifier()).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Sd0vn", "Errors.Query.SQLStatment")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		notifications, err = scan(rows)
+		return err
+	}, stmt, args...)
+	return notifications, err
+}
+
+func triggerNotificationOutboxProjection(ctx context.Context) context.Context {
+	_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerNotificationOutboxProjection")
+	ctx, err := projection.NotificationOutboxProjection.Trigger(ctx, handler.WithAwaitRunning())
+	logging.OnError(err).Debug("unable to trigger")
+	traceSpan.EndWithError(err)
+	return ctx
+}
+
+func prepareNotificationQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*Notification, error)) {
+	return sq.Select(
+			NotificationOutboxColumnID.identifier(),
+			NotificationOutboxColumnCreationDate.identifier(),
+			NotificationOutboxColumnChangeDate.identifier(),
+			NotificationOutboxColumnSequence.identifier(),
+			NotificationOutboxColumnResourceOwner.identifier(),
+			NotificationOutboxColumnState.identifier(),
+			NotificationOutboxColumnChannel.identifier(),
+			NotificationOutboxColumnRecipient.identifier(),
+			NotificationOutboxColumnTriggeringAggregateID.identifier(),
+			NotificationOutboxColumnTriggeringEventType.identifier(),
+			NotificationOutboxColumnMessage.identifier(),
+			NotificationOutboxColumnAttempts.identifier(),
+			NotificationOutboxColumnNextAttempt.identifier(),
+			NotificationOutboxColumnLastError.identifier(),
+		).From(notificationOutboxTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Notification, error) {
+			notification := new(Notification)
+			err := row.Scan(
+				&notification.ID,
+				&notification.CreationDate,
+				&notification.ChangeDate,
+				&notification.Sequence,
+				&notification.ResourceOwner,
+				&notification.State,
+				&notification.Channel,
+				&notification.Recipient,
+				&notification.TriggeringAggregateID,
+				&notification.TriggeringEventType,
+				&notification.Message,
+				&notification.Attempts,
+				&notification.NextAttempt,
+				&notification.LastError,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Ue7bd", "Errors.Notification.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Ue7be", "Errors.Internal")
+			}
+			return notification, nil
+		}
+}
+
+func prepareNotificationsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*Notifications, error)) {
+	return sq.Select(
+			NotificationOutboxColumnID.identifier(),
+			NotificationOutboxColumnCreationDate.identifier(),
+			NotificationOutboxColumnChangeDate.identifier(),
+			NotificationOutboxColumnSequence.identifier(),
+			NotificationOutboxColumnResourceOwner.identifier(),
+			NotificationOutboxColumnState.identifier(),
+			NotificationOutboxColumnChannel.identifier(),
+			NotificationOutboxColumnRecipient.identifier(),
+			NotificationOutboxColumnTriggeringAggregateID.identifier(),
+			NotificationOutboxColumnTriggeringEventType.identifier(),
+			NotificationOutboxColumnMessage.identifier(),
+			NotificationOutboxColumnAttempts.identifier(),
+			NotificationOutboxColumnNextAttempt.identifier(),
+			NotificationOutboxColumnLastError.identifier(),
+			countColumn.identifier(),
+		).From(notificationOutboxTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Notifications, error) {
+			notifications := &Notifications{Notifications: []*Notification{}}
+			for rows.Next() {
+				notification := new(Notification)
+				err := rows.Scan(
+					&notification.ID,
+					&notification.CreationDate,
+					&notification.ChangeDate,
+					&notification.Sequence,
+					&notification.ResourceOwner,
+					&notification.State,
+					&notification.Channel,
+					&notification.Recipient,
+					&notification.TriggeringAggregateID,
+					&notification.TriggeringEventType,
+					&notification.Message,
+					&notification.Attempts,
+					&notification.NextAttempt,
+					&notification.LastError,
+					&notifications.Count,
+				)
+				if err != nil {
+					return nil, zerrors.ThrowInternal(err, "QUERY-Ue7bf", "Errors.Internal")
+				}
+				notifications.Notifications = append(notifications.Notifications, notification)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Ue7bg", "Errors.Query.CloseRows")
+			}
+			return notifications, nil
+		}
+}
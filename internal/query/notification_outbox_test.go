@@ -0,0 +1,274 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	prepareNotificationStmt = `SELECT projections.notification_outbox.id,` +
+		` projections.notification_outbox.creation_date,` +
+		` projections.notification_outbox.change_date,` +
+		` projections.notification_outbox.sequence,` +
+		` projections.notification_outbox.resource_owner,` +
+		` projections.notification_outbox.state,` +
+		` projections.notification_outbox.channel,` +
+		` projections.notification_outbox.recipient,` +
+		` projections.notification_outbox.triggering_aggregate_id,` +
+		` projections.notification_outbox.triggering_event_type,` +
+		` projections.notification_outbox.message,` +
+		` projections.notification_outbox.attempts,` +
+		` projections.notification_outbox.next_attempt,` +
+		` projections.notification_outbox.last_error` +
+		` FROM projections.notification_outbox` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareNotificationCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"state",
+		"channel",
+		"recipient",
+		"triggering_aggregate_id",
+		"triggering_event_type",
+		"message",
+		"attempts",
+		"next_attempt",
+		"last_error",
+	}
+	prepareNotificationsStmt = `SELECT projections.notification_outbox.id,` +
+		` projections.notification_outbox.creation_date,` +
+		` projections.notification_outbox.change_date,` +
+		` projections.notification_outbox.sequence,` +
+		` projections.notification_outbox.resource_owner,` +
+		` projections.notification_outbox.state,` +
+		` projections.notification_outbox.channel,` +
+		` projections.notification_outbox.recipient,` +
+		` projections.notification_outbox.triggering_aggregate_id,` +
+		` projections.notification_outbox.triggering_event_type,` +
+		` projections.notification_outbox.message,` +
+		` projections.notification_outbox.attempts,` +
+		` projections.notification_outbox.next_attempt,` +
+		` projections.notification_outbox.last_error,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.notification_outbox` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareNotificationsCols = append(prepareNotificationCols, "count")
+)
+
+func Test_NotificationPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareNotificationQuery no result",
+			prepare: prepareNotificationQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareNotificationStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Notification)(nil),
+		},
+		{
+			name:    "prepareNotificationQuery found",
+			prepare: prepareNotificationQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareNotificationStmt),
+					prepareNotificationCols,
+					[]driver.Value{
+						"notification-id",
+						testNow,
+						testNow,
+						uint64(20211108),
+						"ro",
+						domain.NotificationStateRetrying,
+						domain.NotificationTypeEmail,
+						"user@example.com",
+						"user-id",
+						"user.human.initialization.code.added",
+						&crypto.CryptoValue{},
+						2,
+						testNow,
+						"connection refused",
+					},
+				),
+			},
+			object: &Notification{
+				ID:                    "notification-id",
+				CreationDate:          testNow,
+				ChangeDate:            testNow,
+				Sequence:              20211108,
+				ResourceOwner:         "ro",
+				State:                 domain.NotificationStateRetrying,
+				Channel:               domain.NotificationTypeEmail,
+				Recipient:             "user@example.com",
+				TriggeringAggregateID: "user-id",
+				TriggeringEventType:   "user.human.initialization.code.added",
+				Message:               &crypto.CryptoValue{},
+				Attempts:              2,
+				NextAttempt:           testNow,
+				LastError:             "connection refused",
+			},
+		},
+		{
+			name:    "prepareNotificationQuery sql err",
+			prepare: prepareNotificationQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareNotificationStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Notification)(nil),
+		},
+		{
+			name:    "prepareNotificationsQuery no result",
+			prepare: prepareNotificationsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareNotificationsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &Notifications{Notifications: []*Notification{}},
+		},
+		{
+			name:    "prepareNotificationsQuery multiple result",
+			prepare: prepareNotificationsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareNotificationsStmt),
+					prepareNotificationsCols,
+					[][]driver.Value{
+						{
+							"notification-id",
+							testNow,
+							testNow,
+							uint64(20211108),
+							"ro",
+							domain.NotificationStateSent,
+							domain.NotificationTypeEmail,
+							"user@example.com",
+							"user-id",
+							"user.human.initialization.code.added",
+							&crypto.CryptoValue{},
+							1,
+							testNow,
+							"",
+						},
+						{
+							"notification-id2",
+							testNow,
+							testNow,
+							uint64(20211109),
+							"ro",
+							domain.NotificationStateFailed,
+							domain.NotificationTypeSms,
+							"+41791234567",
+							"user-id",
+							"user.human.phone.code.added",
+							&crypto.CryptoValue{},
+							3,
+							testNow,
+							"connection refused",
+						},
+					},
+				),
+			},
+			object: &Notifications{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Notifications: []*Notification{
+					{
+						ID:                    "notification-id",
+						CreationDate:          testNow,
+						ChangeDate:            testNow,
+						Sequence:              20211108,
+						ResourceOwner:         "ro",
+						State:                 domain.NotificationStateSent,
+						Channel:               domain.NotificationTypeEmail,
+						Recipient:             "user@example.com",
+						TriggeringAggregateID: "user-id",
+						TriggeringEventType:   "user.human.initialization.code.added",
+						Message:               &crypto.CryptoValue{},
+						Attempts:              1,
+						NextAttempt:           testNow,
+					},
+					{
+						ID:                    "notification-id2",
+						CreationDate:          testNow,
+						ChangeDate:            testNow,
+						Sequence:              20211109,
+						ResourceOwner:         "ro",
+						State:                 domain.NotificationStateFailed,
+						Channel:               domain.NotificationTypeSms,
+						Recipient:             "+41791234567",
+						TriggeringAggregateID: "user-id",
+						TriggeringEventType:   "user.human.phone.code.added",
+						Message:               &crypto.CryptoValue{},
+						Attempts:              3,
+						NextAttempt:           testNow,
+						LastError:             "connection refused",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareNotificationsQuery sql err",
+			prepare: prepareNotificationsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareNotificationsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Notifications)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
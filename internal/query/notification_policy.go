@@ -25,7 +25,14 @@ type NotificationPolicy struct {
 	ResourceOwner string
 	State         domain.PolicyState
 
-	PasswordChange bool
+	PasswordChange         bool
+	UnknownUserAgentSignIn bool
+	MFAAdded               bool
+	MFARemoved             bool
+	EmailChanged           bool
+	PhoneChanged           bool
+	AccountLocked          bool
+	MachineCredentialAdded bool
 
 	IsDefault bool
 }
@@ -63,6 +70,34 @@ var (
 		name:  projection.NotificationPolicyColumnPasswordChange,
 		table: notificationPolicyTable,
 	}
+	NotificationPolicyColUnknownUserAgentSignIn = Column{
+		name:  projection.NotificationPolicyColumnUnknownUserAgentSignIn,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColMFAAdded = Column{
+		name:  projection.NotificationPolicyColumnMFAAdded,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColMFARemoved = Column{
+		name:  projection.NotificationPolicyColumnMFARemoved,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColEmailChanged = Column{
+		name:  projection.NotificationPolicyColumnEmailChanged,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColPhoneChanged = Column{
+		name:  projection.NotificationPolicyColumnPhoneChanged,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColAccountLocked = Column{
+		name:  projection.NotificationPolicyColumnAccountLocked,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColMachineCredentialAdded = Column{
+		name:  projection.NotificationPolicyColumnMachineCredentialAdded,
+		table: notificationPolicyTable,
+	}
 	NotificationPolicyColIsDefault = Column{
 		name:  projection.NotificationPolicyColumnIsDefault,
 		table: notificationPolicyTable,
@@ -153,6 +188,13 @@ func prepareNotificationPolicyQuery(ctx context.Context, db prepareDatabase) (sq
 			NotificationPolicyColChangeDate.identifier(),
 			NotificationPolicyColResourceOwner.identifier(),
 			NotificationPolicyColPasswordChange.identifier(),
+			NotificationPolicyColUnknownUserAgentSignIn.identifier(),
+			NotificationPolicyColMFAAdded.identifier(),
+			NotificationPolicyColMFARemoved.identifier(),
+			NotificationPolicyColEmailChanged.identifier(),
+			NotificationPolicyColPhoneChanged.identifier(),
+			NotificationPolicyColAccountLocked.identifier(),
+			NotificationPolicyColMachineCredentialAdded.identifier(),
 			NotificationPolicyColIsDefault.identifier(),
 			NotificationPolicyColState.identifier(),
 		).
@@ -167,6 +209,13 @@ func prepareNotificationPolicyQuery(ctx context.Context, db prepareDatabase) (sq
 				&policy.ChangeDate,
 				&policy.ResourceOwner,
 				&policy.PasswordChange,
+				&policy.UnknownUserAgentSignIn,
+				&policy.MFAAdded,
+				&policy.MFARemoved,
+				&policy.EmailChanged,
+				&policy.PhoneChanged,
+				&policy.AccountLocked,
+				&policy.MachineCredentialAdded,
 				&policy.IsDefault,
 				&policy.State,
 			)
@@ -0,0 +1,113 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	orgNotificationProvidersTable = table{
+		name:          projection.OrgNotificationProvidersProjectionTable,
+		instanceIDCol: projection.OrgNotificationProvidersColumnInstanceID,
+	}
+	OrgNotificationProvidersColumnOrgID = Column{
+		name:  projection.OrgNotificationProvidersColumnOrgID,
+		table: orgNotificationProvidersTable,
+	}
+	OrgNotificationProvidersColumnCreationDate = Column{
+		name:  projection.OrgNotificationProvidersColumnCreationDate,
+		table: orgNotificationProvidersTable,
+	}
+	OrgNotificationProvidersColumnChangeDate = Column{
+		name:  projection.OrgNotificationProvidersColumnChangeDate,
+		table: orgNotificationProvidersTable,
+	}
+	OrgNotificationProvidersColumnSequence = Column{
+		name:  projection.OrgNotificationProvidersColumnSequence,
+		table: orgNotificationProvidersTable,
+	}
+	OrgNotificationProvidersColumnInstanceID = Column{
+		name:  projection.OrgNotificationProvidersColumnInstanceID,
+		table: orgNotificationProvidersTable,
+	}
+	OrgNotificationProvidersColumnSMTPConfigID = Column{
+		name:  projection.OrgNotificationProvidersColumnSMTPConfigID,
+		table: orgNotificationProvidersTable,
+	}
+	OrgNotificationProvidersColumnSMSConfigID = Column{
+		name:  projection.OrgNotificationProvidersColumnSMSConfigID,
+		table: orgNotificationProvidersTable,
+	}
+)
+
+// OrgNotificationProviders are the SMTP and SMS configurations of the instance
+// the organization sends its notifications with instead of the active ones,
+// an empty id means the active configuration of the instance is used
+type OrgNotificationProviders struct {
+	OrgID        string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+	SMTPConfigID string
+	SMSConfigID  string
+}
+
+func (q *Queries) OrgNotificationProviders(ctx context.Context, orgID string) (providers *OrgNotificationProviders, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareOrgNotificationProvidersQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		OrgNotificationProvidersColumnOrgID.identifier():      orgID,
+		OrgNotificationProvidersColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Jx8sk", "Errors.Query.SQLStatment")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		providers, err = scan(row)
+		return err
+	}, query, args...)
+	return providers, err
+}
+
+func prepareOrgNotificationProvidersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*OrgNotificationProviders, error)) {
+	return sq.Select(
+			OrgNotificationProvidersColumnOrgID.identifier(),
+			OrgNotificationProvidersColumnCreationDate.identifier(),
+			OrgNotificationProvidersColumnChangeDate.identifier(),
+			OrgNotificationProvidersColumnSequence.identifier(),
+			OrgNotificationProvidersColumnSMTPConfigID.identifier(),
+			OrgNotificationProvidersColumnSMSConfigID.identifier(),
+		).From(orgNotificationProvidersTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*OrgNotificationProviders, error) {
+			providers := new(OrgNotificationProviders)
+			err := row.Scan(
+				&providers.OrgID,
+				&providers.CreationDate,
+				&providers.ChangeDate,
+				&providers.Sequence,
+				&providers.SMTPConfigID,
+				&providers.SMSConfigID,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Jx8sl", "Errors.Org.NotificationProviders.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Jx8sm", "Errors.Internal")
+			}
+			return providers, nil
+		}
+}
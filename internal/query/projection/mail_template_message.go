@@ -0,0 +1,165 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	MailTemplateMessagesProjectionTable = "projections.mail_template_messages"
+
+	MailTemplateMessageColumnOrgID          = "org_id"
+	MailTemplateMessageColumnCreationDate   = "creation_date"
+	MailTemplateMessageColumnChangeDate     = "change_date"
+	MailTemplateMessageColumnSequence       = "sequence"
+	MailTemplateMessageColumnInstanceID     = "instance_id"
+	MailTemplateMessageColumnMessageType    = "message_type"
+	MailTemplateMessageColumnLanguage       = "language"
+	MailTemplateMessageColumnStoreKey       = "store_key"
+	MailTemplateMessageColumnActiveStoreKey = "active_store_key"
+)
+
+type mailTemplateMessageProjection struct{}
+
+func newMailTemplateMessageProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(mailTemplateMessageProjection))
+}
+
+func (*mailTemplateMessageProjection) Name() string {
+	return MailTemplateMessagesProjectionTable
+}
+
+func (*mailTemplateMessageProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(MailTemplateMessageColumnOrgID, handler.ColumnTypeText),
+			handler.NewColumn(MailTemplateMessageColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(MailTemplateMessageColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(MailTemplateMessageColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(MailTemplateMessageColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(MailTemplateMessageColumnMessageType, handler.ColumnTypeText),
+			handler.NewColumn(MailTemplateMessageColumnLanguage, handler.ColumnTypeText),
+			handler.NewColumn(MailTemplateMessageColumnStoreKey, handler.ColumnTypeText),
+			handler.NewColumn(MailTemplateMessageColumnActiveStoreKey, handler.ColumnTypeText, handler.Default("")),
+		},
+			handler.NewPrimaryKey(MailTemplateMessageColumnInstanceID, MailTemplateMessageColumnOrgID, MailTemplateMessageColumnMessageType, MailTemplateMessageColumnLanguage),
+		),
+	)
+}
+
+func (p *mailTemplateMessageProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.MailTemplateMessageSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  org.MailTemplateMessageActivatedEventType,
+					Reduce: p.reduceActivated,
+				},
+				{
+					Event:  org.MailTemplateMessageRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOrgRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(MailTemplateMessageColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *mailTemplateMessageProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.MailTemplateMessageSetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(MailTemplateMessageColumnInstanceID, nil),
+			handler.NewCol(MailTemplateMessageColumnOrgID, nil),
+			handler.NewCol(MailTemplateMessageColumnMessageType, nil),
+			handler.NewCol(MailTemplateMessageColumnLanguage, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(MailTemplateMessageColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(MailTemplateMessageColumnOrgID, e.Aggregate().ID),
+			handler.NewCol(MailTemplateMessageColumnMessageType, e.MessageType),
+			handler.NewCol(MailTemplateMessageColumnLanguage, e.Language.String()),
+			handler.NewCol(MailTemplateMessageColumnCreationDate, e.CreationDate()),
+			handler.NewCol(MailTemplateMessageColumnChangeDate, e.CreationDate()),
+			handler.NewCol(MailTemplateMessageColumnSequence, e.Sequence()),
+			handler.NewCol(MailTemplateMessageColumnStoreKey, e.StoreKey),
+		},
+	), nil
+}
+
+func (p *mailTemplateMessageProjection) reduceActivated(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.MailTemplateMessageActivatedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(MailTemplateMessageColumnChangeDate, e.CreationDate()),
+			handler.NewCol(MailTemplateMessageColumnSequence, e.Sequence()),
+			handler.NewCol(MailTemplateMessageColumnActiveStoreKey, e.StoreKey),
+		},
+		[]handler.Condition{
+			handler.NewCond(MailTemplateMessageColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(MailTemplateMessageColumnOrgID, e.Aggregate().ID),
+			handler.NewCond(MailTemplateMessageColumnMessageType, e.MessageType),
+			handler.NewCond(MailTemplateMessageColumnLanguage, e.Language.String()),
+		},
+	), nil
+}
+
+func (p *mailTemplateMessageProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.MailTemplateMessageRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(MailTemplateMessageColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(MailTemplateMessageColumnOrgID, e.Aggregate().ID),
+			handler.NewCond(MailTemplateMessageColumnMessageType, e.MessageType),
+			handler.NewCond(MailTemplateMessageColumnLanguage, e.Language.String()),
+		},
+	), nil
+}
+
+func (p *mailTemplateMessageProjection) reduceOrgRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(MailTemplateMessageColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(MailTemplateMessageColumnOrgID, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,195 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestMailTemplateMessageProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.MailTemplateMessageSetEventType,
+						org.AggregateType,
+						[]byte(`{
+							"messageType": "InitCode",
+							"language": "en",
+							"storeKey": "key"
+						}`),
+					), org.MailTemplateMessageSetEventMapper),
+			},
+			reduce: (&mailTemplateMessageProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.mail_template_messages (instance_id, org_id, message_type, language, creation_date, change_date, sequence, store_key) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (instance_id, org_id, message_type, language) DO UPDATE SET (creation_date, change_date, sequence, store_key) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.store_key)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"InitCode",
+								"en",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"key",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceActivated",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.MailTemplateMessageActivatedEventType,
+						org.AggregateType,
+						[]byte(`{
+							"messageType": "InitCode",
+							"language": "en",
+							"storeKey": "key"
+						}`),
+					), org.MailTemplateMessageActivatedEventMapper),
+			},
+			reduce: (&mailTemplateMessageProjection{}).reduceActivated,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.mail_template_messages SET (change_date, sequence, active_store_key) = ($1, $2, $3) WHERE (instance_id = $4) AND (org_id = $5) AND (message_type = $6) AND (language = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"key",
+								"instance-id",
+								"agg-id",
+								"InitCode",
+								"en",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.MailTemplateMessageRemovedEventType,
+						org.AggregateType,
+						[]byte(`{
+							"messageType": "InitCode",
+							"language": "en"
+						}`),
+					), org.MailTemplateMessageRemovedEventMapper),
+			},
+			reduce: (&mailTemplateMessageProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.mail_template_messages WHERE (instance_id = $1) AND (org_id = $2) AND (message_type = $3) AND (language = $4)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"InitCode",
+								"en",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOrgRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&mailTemplateMessageProjection{}).reduceOrgRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.mail_template_messages WHERE (instance_id = $1) AND (org_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(MailTemplateMessageColumnInstanceID),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.mail_template_messages WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, MailTemplateMessagesProjectionTable, tt.want)
+		})
+	}
+}
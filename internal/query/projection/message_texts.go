@@ -272,7 +272,16 @@ func isMessageTemplate(template string) bool {
 		template == domain.VerifyEmailOTPMessageType ||
 		template == domain.DomainClaimedMessageType ||
 		template == domain.PasswordlessRegistrationMessageType ||
-		template == domain.PasswordChangeMessageType
+		template == domain.PasswordChangeMessageType ||
+		template == domain.UnknownUserAgentSignInMessageType ||
+		template == domain.MFAAddedMessageType ||
+		template == domain.MFARemovedMessageType ||
+		template == domain.EmailChangedMessageType ||
+		template == domain.PhoneChangedMessageType ||
+		template == domain.AccountLockedMessageType ||
+		template == domain.MachineCredentialAddedMessageType ||
+		template == domain.MagicLinkMessageType ||
+		template == domain.EmailChangeRequestedMessageType
 }
 func isTitle(key string) bool {
 	return key == domain.MessageTitle
@@ -0,0 +1,194 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+)
+
+const (
+	NotificationOutboxProjectionTable = "projections.notification_outbox"
+
+	NotificationOutboxColumnID                    = "id"
+	NotificationOutboxColumnCreationDate          = "creation_date"
+	NotificationOutboxColumnChangeDate            = "change_date"
+	NotificationOutboxColumnSequence              = "sequence"
+	NotificationOutboxColumnResourceOwner         = "resource_owner"
+	NotificationOutboxColumnInstanceID            = "instance_id"
+	NotificationOutboxColumnState                 = "state"
+	NotificationOutboxColumnChannel               = "channel"
+	NotificationOutboxColumnRecipient             = "recipient"
+	NotificationOutboxColumnTriggeringAggregateID = "triggering_aggregate_id"
+	NotificationOutboxColumnTriggeringEventType   = "triggering_event_type"
+	NotificationOutboxColumnMessage               = "message"
+	NotificationOutboxColumnAttempts              = "attempts"
+	NotificationOutboxColumnNextAttempt           = "next_attempt"
+	NotificationOutboxColumnLastError             = "last_error"
+)
+
+type notificationOutboxProjection struct{}
+
+func newNotificationOutboxProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(notificationOutboxProjection))
+}
+
+func (*notificationOutboxProjection) Name() string {
+	return NotificationOutboxProjectionTable
+}
+
+func (*notificationOutboxProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(NotificationOutboxColumnID, handler.ColumnTypeText),
+			handler.NewColumn(NotificationOutboxColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(NotificationOutboxColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(NotificationOutboxColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(NotificationOutboxColumnResourceOwner, handler.ColumnTypeText),
+			handler.NewColumn(NotificationOutboxColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(NotificationOutboxColumnState, handler.ColumnTypeEnum),
+			handler.NewColumn(NotificationOutboxColumnChannel, handler.ColumnTypeEnum),
+			handler.NewColumn(NotificationOutboxColumnRecipient, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(NotificationOutboxColumnTriggeringAggregateID, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(NotificationOutboxColumnTriggeringEventType, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(NotificationOutboxColumnMessage, handler.ColumnTypeJSONB),
+			handler.NewColumn(NotificationOutboxColumnAttempts, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(NotificationOutboxColumnNextAttempt, handler.ColumnTypeTimestamp),
+			handler.NewColumn(NotificationOutboxColumnLastError, handler.ColumnTypeText, handler.Default("")),
+		},
+			handler.NewPrimaryKey(NotificationOutboxColumnInstanceID, NotificationOutboxColumnID),
+			handler.WithIndex(handler.NewIndex("state", []string{NotificationOutboxColumnState, NotificationOutboxColumnNextAttempt})),
+		),
+	)
+}
+
+func (p *notificationOutboxProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: notification.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  notification.RequestedType,
+					Reduce: p.reduceRequested,
+				},
+				{
+					Event:  notification.SentType,
+					Reduce: p.reduceSent,
+				},
+				{
+					Event:  notification.RetryRequestedType,
+					Reduce: p.reduceRetryRequested,
+				},
+				{
+					Event:  notification.FailedType,
+					Reduce: p.reduceFailed,
+				},
+				{
+					Event:  notification.ResendRequestedType,
+					Reduce: p.reduceResendRequested,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(NotificationOutboxColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *notificationOutboxProjection) reduceRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.RequestedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationOutboxColumnID, e.Aggregate().ID),
+			handler.NewCol(NotificationOutboxColumnCreationDate, e.CreationDate()),
+			handler.NewCol(NotificationOutboxColumnChangeDate, e.CreationDate()),
+			handler.NewCol(NotificationOutboxColumnSequence, e.Sequence()),
+			handler.NewCol(NotificationOutboxColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(NotificationOutboxColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(NotificationOutboxColumnState, domain.NotificationStatePending),
+			handler.NewCol(NotificationOutboxColumnChannel, e.Channel),
+			handler.NewCol(NotificationOutboxColumnRecipient, e.Recipient),
+			handler.NewCol(NotificationOutboxColumnTriggeringAggregateID, e.TriggeringAggregateID),
+			handler.NewCol(NotificationOutboxColumnTriggeringEventType, e.TriggeringEventType),
+			handler.NewCol(NotificationOutboxColumnMessage, e.Message),
+			handler.NewCol(NotificationOutboxColumnNextAttempt, e.CreationDate()),
+		},
+	), nil
+}
+
+func (p *notificationOutboxProjection) reduceSent(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.SentEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e, []handler.Column{
+		handler.NewCol(NotificationOutboxColumnState, domain.NotificationStateSent),
+		handler.NewIncrementCol(NotificationOutboxColumnAttempts, 1),
+		handler.NewCol(NotificationOutboxColumnLastError, ""),
+	}), nil
+}
+
+func (p *notificationOutboxProjection) reduceRetryRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.RetryRequestedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e, []handler.Column{
+		handler.NewCol(NotificationOutboxColumnState, domain.NotificationStateRetrying),
+		handler.NewIncrementCol(NotificationOutboxColumnAttempts, 1),
+		handler.NewCol(NotificationOutboxColumnNextAttempt, e.NotBefore),
+		handler.NewCol(NotificationOutboxColumnLastError, e.Error),
+	}), nil
+}
+
+func (p *notificationOutboxProjection) reduceFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.FailedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e, []handler.Column{
+		handler.NewCol(NotificationOutboxColumnState, domain.NotificationStateFailed),
+		handler.NewIncrementCol(NotificationOutboxColumnAttempts, 1),
+		handler.NewCol(NotificationOutboxColumnLastError, e.Error),
+	}), nil
+}
+
+func (p *notificationOutboxProjection) reduceResendRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*notification.ResendRequestedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(e, []handler.Column{
+		handler.NewCol(NotificationOutboxColumnState, domain.NotificationStateRetrying),
+		handler.NewCol(NotificationOutboxColumnAttempts, 0),
+		handler.NewCol(NotificationOutboxColumnNextAttempt, e.CreationDate()),
+	}), nil
+}
+
+func (p *notificationOutboxProjection) updateStatement(event eventstore.Event, columns []handler.Column) *handler.Statement {
+	return handler.NewUpdateStatement(
+		event,
+		append([]handler.Column{
+			handler.NewCol(NotificationOutboxColumnChangeDate, event.CreatedAt()),
+			handler.NewCol(NotificationOutboxColumnSequence, event.Sequence()),
+		}, columns...),
+		[]handler.Condition{
+			handler.NewCond(NotificationOutboxColumnID, event.Aggregate().ID),
+			handler.NewCond(NotificationOutboxColumnInstanceID, event.Aggregate().InstanceID),
+		},
+	)
+}
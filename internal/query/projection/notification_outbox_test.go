@@ -0,0 +1,252 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestNotificationOutboxProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceRequested",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.RequestedType,
+						notification.AggregateType,
+						[]byte(`{
+							"channel": 0,
+							"recipient": "user@example.com",
+							"triggeringAggregateId": "user-id",
+							"triggeringEventType": "user.human.initialization.code.added",
+							"message": {
+								"cryptoType": 0,
+								"algorithm": "RSA-265",
+								"keyId": "key-id"
+							}
+						}`),
+					), eventstore.GenericEventMapper[notification.RequestedEvent]),
+			},
+			reduce: (&notificationOutboxProjection{}).reduceRequested,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_outbox (id, creation_date, change_date, sequence, resource_owner, instance_id, state, channel, recipient, triggering_aggregate_id, triggering_event_type, message, next_attempt) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								domain.NotificationStatePending,
+								domain.NotificationTypeEmail,
+								"user@example.com",
+								"user-id",
+								eventstore.EventType("user.human.initialization.code.added"),
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "RSA-265",
+									KeyID:      "key-id",
+								},
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSent",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.SentType,
+						notification.AggregateType,
+						[]byte(`{}`),
+					), eventstore.GenericEventMapper[notification.SentEvent]),
+			},
+			reduce: (&notificationOutboxProjection{}).reduceSent,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_outbox SET (change_date, sequence, state, attempts, last_error) = ($1, $2, $3, attempts + $4, $5) WHERE (id = $6) AND (instance_id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationStateSent,
+								1,
+								"",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRetryRequested",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.RetryRequestedType,
+						notification.AggregateType,
+						[]byte(`{
+							"error": "connection refused",
+							"notBefore": "2024-01-01T00:00:00Z"
+						}`),
+					), eventstore.GenericEventMapper[notification.RetryRequestedEvent]),
+			},
+			reduce: (&notificationOutboxProjection{}).reduceRetryRequested,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_outbox SET (change_date, sequence, state, attempts, next_attempt, last_error) = ($1, $2, $3, attempts + $4, $5, $6) WHERE (id = $7) AND (instance_id = $8)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationStateRetrying,
+								1,
+								time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+								"connection refused",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceFailed",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.FailedType,
+						notification.AggregateType,
+						[]byte(`{
+							"error": "connection refused"
+						}`),
+					), eventstore.GenericEventMapper[notification.FailedEvent]),
+			},
+			reduce: (&notificationOutboxProjection{}).reduceFailed,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_outbox SET (change_date, sequence, state, attempts, last_error) = ($1, $2, $3, attempts + $4, $5) WHERE (id = $6) AND (instance_id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationStateFailed,
+								1,
+								"connection refused",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceResendRequested",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notification.ResendRequestedType,
+						notification.AggregateType,
+						[]byte(`{}`),
+					), eventstore.GenericEventMapper[notification.ResendRequestedEvent]),
+			},
+			reduce: (&notificationOutboxProjection{}).reduceResendRequested,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("notification"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_outbox SET (change_date, sequence, state, attempts, next_attempt) = ($1, $2, $3, $4, $5) WHERE (id = $6) AND (instance_id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationStateRetrying,
+								0,
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(NotificationOutboxColumnInstanceID),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_outbox WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, NotificationOutboxProjectionTable, tt.want)
+		})
+	}
+}
@@ -14,18 +14,25 @@ import (
 )
 
 const (
-	NotificationPolicyProjectionTable = "projections.notification_policies"
+	NotificationPolicyProjectionTable = "projections.notification_policies2"
 
-	NotificationPolicyColumnID             = "id"
-	NotificationPolicyColumnCreationDate   = "creation_date"
-	NotificationPolicyColumnChangeDate     = "change_date"
-	NotificationPolicyColumnResourceOwner  = "resource_owner"
-	NotificationPolicyColumnInstanceID     = "instance_id"
-	NotificationPolicyColumnSequence       = "sequence"
-	NotificationPolicyColumnStateCol       = "state"
-	NotificationPolicyColumnIsDefault      = "is_default"
-	NotificationPolicyColumnPasswordChange = "password_change"
-	NotificationPolicyColumnOwnerRemoved   = "owner_removed"
+	NotificationPolicyColumnID                     = "id"
+	NotificationPolicyColumnCreationDate           = "creation_date"
+	NotificationPolicyColumnChangeDate             = "change_date"
+	NotificationPolicyColumnResourceOwner          = "resource_owner"
+	NotificationPolicyColumnInstanceID             = "instance_id"
+	NotificationPolicyColumnSequence               = "sequence"
+	NotificationPolicyColumnStateCol               = "state"
+	NotificationPolicyColumnIsDefault              = "is_default"
+	NotificationPolicyColumnPasswordChange         = "password_change"
+	NotificationPolicyColumnUnknownUserAgentSignIn = "unknown_user_agent_sign_in"
+	NotificationPolicyColumnMFAAdded               = "mfa_added"
+	NotificationPolicyColumnMFARemoved             = "mfa_removed"
+	NotificationPolicyColumnEmailChanged           = "email_changed"
+	NotificationPolicyColumnPhoneChanged           = "phone_changed"
+	NotificationPolicyColumnAccountLocked          = "account_locked"
+	NotificationPolicyColumnMachineCredentialAdded = "machine_credential_added"
+	NotificationPolicyColumnOwnerRemoved           = "owner_removed"
 )
 
 type notificationPolicyProjection struct{}
@@ -50,6 +57,13 @@ func (*notificationPolicyProjection) Init() *old_handler.Check {
 			handler.NewColumn(NotificationPolicyColumnStateCol, handler.ColumnTypeEnum),
 			handler.NewColumn(NotificationPolicyColumnIsDefault, handler.ColumnTypeBool),
 			handler.NewColumn(NotificationPolicyColumnPasswordChange, handler.ColumnTypeBool),
+			handler.NewColumn(NotificationPolicyColumnUnknownUserAgentSignIn, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(NotificationPolicyColumnMFAAdded, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(NotificationPolicyColumnMFARemoved, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(NotificationPolicyColumnEmailChanged, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(NotificationPolicyColumnPhoneChanged, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(NotificationPolicyColumnAccountLocked, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(NotificationPolicyColumnMachineCredentialAdded, handler.ColumnTypeBool, handler.Default(false)),
 			handler.NewColumn(NotificationPolicyColumnOwnerRemoved, handler.ColumnTypeBool, handler.Default(false)),
 		},
 			handler.NewPrimaryKey(NotificationPolicyColumnInstanceID, NotificationPolicyColumnID),
@@ -122,6 +136,13 @@ func (p *notificationPolicyProjection) reduceAdded(event eventstore.Event) (*han
 			handler.NewCol(NotificationPolicyColumnID, policyEvent.Aggregate().ID),
 			handler.NewCol(NotificationPolicyColumnStateCol, domain.PolicyStateActive),
 			handler.NewCol(NotificationPolicyColumnPasswordChange, policyEvent.PasswordChange),
+			handler.NewCol(NotificationPolicyColumnUnknownUserAgentSignIn, policyEvent.UnknownUserAgentSignIn),
+			handler.NewCol(NotificationPolicyColumnMFAAdded, policyEvent.MFAAdded),
+			handler.NewCol(NotificationPolicyColumnMFARemoved, policyEvent.MFARemoved),
+			handler.NewCol(NotificationPolicyColumnEmailChanged, policyEvent.EmailChanged),
+			handler.NewCol(NotificationPolicyColumnPhoneChanged, policyEvent.PhoneChanged),
+			handler.NewCol(NotificationPolicyColumnAccountLocked, policyEvent.AccountLocked),
+			handler.NewCol(NotificationPolicyColumnMachineCredentialAdded, policyEvent.MachineCredentialAdded),
 			handler.NewCol(NotificationPolicyColumnIsDefault, isDefault),
 			handler.NewCol(NotificationPolicyColumnResourceOwner, policyEvent.Aggregate().ResourceOwner),
 			handler.NewCol(NotificationPolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
@@ -145,6 +166,27 @@ func (p *notificationPolicyProjection) reduceChanged(event eventstore.Event) (*h
 	if policyEvent.PasswordChange != nil {
 		cols = append(cols, handler.NewCol(NotificationPolicyColumnPasswordChange, *policyEvent.PasswordChange))
 	}
+	if policyEvent.UnknownUserAgentSignIn != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnUnknownUserAgentSignIn, *policyEvent.UnknownUserAgentSignIn))
+	}
+	if policyEvent.MFAAdded != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnMFAAdded, *policyEvent.MFAAdded))
+	}
+	if policyEvent.MFARemoved != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnMFARemoved, *policyEvent.MFARemoved))
+	}
+	if policyEvent.EmailChanged != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnEmailChanged, *policyEvent.EmailChanged))
+	}
+	if policyEvent.PhoneChanged != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnPhoneChanged, *policyEvent.PhoneChanged))
+	}
+	if policyEvent.AccountLocked != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnAccountLocked, *policyEvent.AccountLocked))
+	}
+	if policyEvent.MachineCredentialAdded != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnMachineCredentialAdded, *policyEvent.MachineCredentialAdded))
+	}
 	return handler.NewUpdateStatement(
 		&policyEvent,
 		cols,
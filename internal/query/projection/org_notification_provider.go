@@ -0,0 +1,121 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	OrgNotificationProvidersProjectionTable = "projections.org_notification_providers"
+
+	OrgNotificationProvidersColumnOrgID        = "org_id"
+	OrgNotificationProvidersColumnCreationDate = "creation_date"
+	OrgNotificationProvidersColumnChangeDate   = "change_date"
+	OrgNotificationProvidersColumnSequence     = "sequence"
+	OrgNotificationProvidersColumnInstanceID   = "instance_id"
+	OrgNotificationProvidersColumnSMTPConfigID = "smtp_config_id"
+	OrgNotificationProvidersColumnSMSConfigID  = "sms_config_id"
+)
+
+type orgNotificationProvidersProjection struct{}
+
+func newOrgNotificationProvidersProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(orgNotificationProvidersProjection))
+}
+
+func (*orgNotificationProvidersProjection) Name() string {
+	return OrgNotificationProvidersProjectionTable
+}
+
+func (*orgNotificationProvidersProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(OrgNotificationProvidersColumnOrgID, handler.ColumnTypeText),
+			handler.NewColumn(OrgNotificationProvidersColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(OrgNotificationProvidersColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(OrgNotificationProvidersColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(OrgNotificationProvidersColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(OrgNotificationProvidersColumnSMTPConfigID, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(OrgNotificationProvidersColumnSMSConfigID, handler.ColumnTypeText, handler.Default("")),
+		},
+			handler.NewPrimaryKey(OrgNotificationProvidersColumnInstanceID, OrgNotificationProvidersColumnOrgID),
+		),
+	)
+}
+
+func (p *orgNotificationProvidersProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.NotificationProvidersSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  org.NotificationProvidersRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(OrgNotificationProvidersColumnInstanceID),
+				},
+			},
+		},
+	}
+}
+
+func (p *orgNotificationProvidersProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.NotificationProvidersSetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(OrgNotificationProvidersColumnInstanceID, nil),
+			handler.NewCol(OrgNotificationProvidersColumnOrgID, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(OrgNotificationProvidersColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(OrgNotificationProvidersColumnOrgID, e.Aggregate().ID),
+			handler.NewCol(OrgNotificationProvidersColumnCreationDate, e.CreationDate()),
+			handler.NewCol(OrgNotificationProvidersColumnChangeDate, e.CreationDate()),
+			handler.NewCol(OrgNotificationProvidersColumnSequence, e.Sequence()),
+			handler.NewCol(OrgNotificationProvidersColumnSMTPConfigID, e.SMTPConfigID),
+			handler.NewCol(OrgNotificationProvidersColumnSMSConfigID, e.SMSConfigID),
+		},
+	), nil
+}
+
+func (p *orgNotificationProvidersProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *org.NotificationProvidersRemovedEvent,
+		*org.OrgRemovedEvent:
+		//ok
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Tz7qe", "reduce.wrong.event.type %v", []eventstore.EventType{org.NotificationProvidersRemovedEventType, org.OrgRemovedEventType})
+	}
+	return handler.NewDeleteStatement(
+		event,
+		[]handler.Condition{
+			handler.NewCond(OrgNotificationProvidersColumnOrgID, event.Aggregate().ID),
+			handler.NewCond(OrgNotificationProvidersColumnInstanceID, event.Aggregate().InstanceID),
+		},
+	), nil
+}
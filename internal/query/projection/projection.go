@@ -6,7 +6,9 @@ import (
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 const (
@@ -51,16 +53,20 @@ var (
 	PersonalAccessTokenProjection       *handler.Handler
 	UserGrantProjection                 *handler.Handler
 	UserMetadataProjection              *handler.Handler
+	UserConsentProjection               *handler.Handler
 	UserAuthMethodProjection            *handler.Handler
 	InstanceProjection                  *handler.Handler
 	SecretGeneratorProjection           *handler.Handler
 	SMTPConfigProjection                *handler.Handler
 	SMSConfigProjection                 *handler.Handler
+	OrgNotificationProvidersProjection  *handler.Handler
+	MailTemplateMessageProjection       *handler.Handler
 	OIDCSettingsProjection              *handler.Handler
 	DebugNotificationProviderProjection *handler.Handler
 	KeyProjection                       *handler.Handler
 	SecurityPolicyProjection            *handler.Handler
 	NotificationPolicyProjection        *handler.Handler
+	NotificationOutboxProjection        *handler.Handler
 	NotificationsProjection             interface{}
 	NotificationsQuotaProjection        interface{}
 	TelemetryPusherProjection           interface{}
@@ -80,6 +86,9 @@ type projection interface {
 
 var (
 	projections []projection
+	// eventHandlers are handlers which are not started by this package (e.g. notification handlers)
+	// but whose failed events must be retryable
+	eventHandlers []*handler.Handler
 )
 
 func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore, config Config, keyEncryptionAlgorithm crypto.EncryptionAlgorithm, certEncryptionAlgorithm crypto.EncryptionAlgorithm, systemUsers map[string]*internal_authz.SystemAPIUser) error {
@@ -128,16 +137,20 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	PersonalAccessTokenProjection = newPersonalAccessTokenProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["personal_access_tokens"]))
 	UserGrantProjection = newUserGrantProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_grants"]))
 	UserMetadataProjection = newUserMetadataProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_metadata"]))
+	UserConsentProjection = newUserConsentProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_consents"]))
 	UserAuthMethodProjection = newUserAuthMethodProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_auth_method"]))
 	InstanceProjection = newInstanceProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["instances"]))
 	SecretGeneratorProjection = newSecretGeneratorProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["secret_generators"]))
 	SMTPConfigProjection = newSMTPConfigProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["smtp_configs"]))
 	SMSConfigProjection = newSMSConfigProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sms_config"]))
+	OrgNotificationProvidersProjection = newOrgNotificationProvidersProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["org_notification_providers"]))
+	MailTemplateMessageProjection = newMailTemplateMessageProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["mail_template_messages"]))
 	OIDCSettingsProjection = newOIDCSettingsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["oidc_settings"]))
 	DebugNotificationProviderProjection = newDebugNotificationProviderProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["debug_notification_provider"]))
 	KeyProjection = newKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["keys"]), keyEncryptionAlgorithm, certEncryptionAlgorithm)
 	SecurityPolicyProjection = newSecurityPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["security_policies"]))
 	NotificationPolicyProjection = newNotificationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_policies"]))
+	NotificationOutboxProjection = newNotificationOutboxProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_outbox"]))
 	DeviceAuthProjection = newDeviceAuthProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["device_auth"]))
 	SessionProjection = newSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sessions"]))
 	AuthRequestProjection = newAuthRequestProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["auth_requests"]))
@@ -164,6 +177,34 @@ func Start(ctx context.Context) {
 	}
 }
 
+// AddEventHandler makes failed events of handlers started outside of this package retryable
+func AddEventHandler(h *handler.Handler) {
+	eventHandlers = append(eventHandlers, h)
+}
+
+// RetryFailedEvent retries a failed event of the projection with the given name
+func RetryFailedEvent(ctx context.Context, projectionName, instanceID string, aggregateType eventstore.AggregateType, aggregateID string, sequence uint64) error {
+	h := handlerByName(projectionName)
+	if h == nil {
+		return zerrors.ThrowNotFound(nil, "PROJE-Ka1ic", "Errors.Projection.NotFound")
+	}
+	return h.RetryFailedEvent(internal_authz.WithInstanceID(ctx, instanceID), aggregateType, aggregateID, sequence)
+}
+
+func handlerByName(projectionName string) *handler.Handler {
+	for _, p := range projections {
+		if h, ok := p.(*handler.Handler); ok && h.ProjectionName() == projectionName {
+			return h
+		}
+	}
+	for _, h := range eventHandlers {
+		if h.ProjectionName() == projectionName {
+			return h
+		}
+	}
+	return nil
+}
+
 func ApplyCustomConfig(customConfig CustomConfig) handler.Config {
 	return applyCustomConfig(projectionConfig, customConfig)
 }
@@ -233,16 +274,20 @@ func newProjectionsList() {
 		PersonalAccessTokenProjection,
 		UserGrantProjection,
 		UserMetadataProjection,
+		UserConsentProjection,
 		UserAuthMethodProjection,
 		InstanceProjection,
 		SecretGeneratorProjection,
 		SMTPConfigProjection,
 		SMSConfigProjection,
+		OrgNotificationProvidersProjection,
+		MailTemplateMessageProjection,
 		OIDCSettingsProjection,
 		DebugNotificationProviderProjection,
 		KeyProjection,
 		SecurityPolicyProjection,
 		NotificationPolicyProjection,
+		NotificationOutboxProjection,
 		DeviceAuthProjection,
 		SessionProjection,
 		AuthRequestProjection,
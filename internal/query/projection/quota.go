@@ -37,6 +37,8 @@ const (
 	QuotaNotificationColumnUnit                 = "unit"
 	QuotaNotificationColumnID                   = "id"
 	QuotaNotificationColumnCallURL              = "call_url"
+	QuotaNotificationColumnChannel              = "channel"
+	QuotaNotificationColumnRecipients           = "recipients"
 	QuotaNotificationColumnPercent              = "percent"
 	QuotaNotificationColumnRepeat               = "repeat"
 	QuotaNotificationColumnLatestDuePeriodStart = "latest_due_period_start"
@@ -97,6 +99,8 @@ func (*quotaProjection) Init() *old_handler.Check {
 				handler.NewColumn(QuotaNotificationColumnUnit, handler.ColumnTypeEnum),
 				handler.NewColumn(QuotaNotificationColumnID, handler.ColumnTypeText),
 				handler.NewColumn(QuotaNotificationColumnCallURL, handler.ColumnTypeText),
+				handler.NewColumn(QuotaNotificationColumnChannel, handler.ColumnTypeEnum, handler.Default(0)),
+				handler.NewColumn(QuotaNotificationColumnRecipients, handler.ColumnTypeTextArray, handler.Nullable()),
 				handler.NewColumn(QuotaNotificationColumnPercent, handler.ColumnTypeInt64),
 				handler.NewColumn(QuotaNotificationColumnRepeat, handler.ColumnTypeBool),
 				handler.NewColumn(QuotaNotificationColumnLatestDuePeriodStart, handler.ColumnTypeTimestamp, handler.Nullable()),
@@ -203,6 +207,8 @@ func (q *quotaProjection) reduceQuotaSet(event eventstore.Event) (*handler.State
 				handler.NewCol(QuotaNotificationColumnUnit, e.Unit),
 				handler.NewCol(QuotaNotificationColumnID, notification.ID),
 				handler.NewCol(QuotaNotificationColumnCallURL, notification.CallURL),
+				handler.NewCol(QuotaNotificationColumnChannel, notification.Channel),
+				handler.NewCol(QuotaNotificationColumnRecipients, database.TextArray[string](notification.Recipients)),
 				handler.NewCol(QuotaNotificationColumnPercent, notification.Percent),
 				handler.NewCol(QuotaNotificationColumnRepeat, notification.Repeat),
 			},
@@ -113,12 +113,14 @@ func TestQuotasProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.quotas_notifications (instance_id, unit, id, call_url, percent, repeat) VALUES ($1, $2, $3, $4, $5, $6)",
+							expectedStmt: "INSERT INTO projections.quotas_notifications (instance_id, unit, id, call_url, channel, recipients, percent, repeat) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								quota.RequestsAllAuthenticated,
 								"id",
 								"url",
+								quota.NotificationChannelWebhook,
+								database.TextArray[string](nil),
 								uint16(100),
 								true,
 							},
@@ -213,12 +215,14 @@ func TestQuotasProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.quotas_notifications (instance_id, unit, id, call_url, percent, repeat) VALUES ($1, $2, $3, $4, $5, $6)",
+							expectedStmt: "INSERT INTO projections.quotas_notifications (instance_id, unit, id, call_url, channel, recipients, percent, repeat) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								quota.RequestsAllAuthenticated,
 								"id",
 								"url",
+								quota.NotificationChannelWebhook,
+								database.TextArray[string](nil),
 								uint16(100),
 								true,
 							},
@@ -227,6 +231,56 @@ func TestQuotasProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceQuotaSet with email notification",
+			args: args{
+				event: getEvent(testEvent(
+					quota.SetEventType,
+					quota.AggregateType,
+					[]byte(`{
+							"unit": 3,
+							"notifications": [
+								{
+									"id": "id",
+									"percent": 80,
+									"repeat": false,
+									"channel": 1,
+									"recipients": ["ops@zitadel.com", "billing@zitadel.com"]
+								}
+							]
+					}`),
+				), quota.SetEventMapper),
+			},
+			reduce: (&quotaProjection{}).reduceQuotaSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("quota"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.quotas_notifications WHERE (instance_id = $1) AND (unit = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								quota.NotificationsAllSent,
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.quotas_notifications (instance_id, unit, id, call_url, channel, recipients, percent, repeat) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								quota.NotificationsAllSent,
+								"id",
+								"",
+								quota.NotificationChannelEmail,
+								database.TextArray[string]{"ops@zitadel.com", "billing@zitadel.com"},
+								uint16(80),
+								false,
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceQuotaNotificationDue",
 			args: args{
@@ -3,6 +3,8 @@ package projection
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
@@ -11,14 +13,18 @@ import (
 )
 
 const (
-	SMTPConfigProjectionTable = "projections.smtp_configs1"
+	SMTPConfigProjectionTable = "projections.smtp_configs4"
+	SMTPConfigHTTPTable       = SMTPConfigProjectionTable + "_" + smtpConfigHTTPTableSuffix
 
+	SMTPConfigColumnID             = "id"
 	SMTPConfigColumnAggregateID    = "aggregate_id"
 	SMTPConfigColumnCreationDate   = "creation_date"
 	SMTPConfigColumnChangeDate     = "change_date"
 	SMTPConfigColumnSequence       = "sequence"
 	SMTPConfigColumnResourceOwner  = "resource_owner"
 	SMTPConfigColumnInstanceID     = "instance_id"
+	SMTPConfigColumnDescription    = "description"
+	SMTPConfigColumnState          = "state"
 	SMTPConfigColumnTLS            = "tls"
 	SMTPConfigColumnSenderAddress  = "sender_address"
 	SMTPConfigColumnSenderName     = "sender_name"
@@ -26,6 +32,19 @@ const (
 	SMTPConfigColumnSMTPHost       = "host"
 	SMTPConfigColumnSMTPUser       = "username"
 	SMTPConfigColumnSMTPPassword   = "password"
+	SMTPConfigColumnAuthMechanism  = "auth_mechanism"
+	SMTPConfigColumnTLSMode        = "tls_mode"
+	SMTPConfigColumnTimeout        = "timeout"
+	SMTPConfigColumnXOAuth2Token   = "xoauth2_token_endpoint"
+	SMTPConfigColumnXOAuth2Client  = "xoauth2_client_id"
+	SMTPConfigColumnXOAuth2Scopes  = "xoauth2_scopes"
+
+	smtpConfigHTTPTableSuffix      = "http"
+	SMTPConfigHTTPColumnID         = "id"
+	SMTPConfigHTTPColumnInstanceID = "instance_id"
+	SMTPConfigHTTPColumnEndpoint   = "endpoint"
+	SMTPConfigHTTPColumnHeaders    = "headers"
+	SMTPConfigHTTPColumnSigningKey = "signing_key"
 )
 
 type smtpConfigProjection struct{}
@@ -39,14 +58,17 @@ func (*smtpConfigProjection) Name() string {
 }
 
 func (*smtpConfigProjection) Init() *old_handler.Check {
-	return handler.NewTableCheck(
+	return handler.NewMultiTableCheck(
 		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(SMTPConfigColumnID, handler.ColumnTypeText),
 			handler.NewColumn(SMTPConfigColumnAggregateID, handler.ColumnTypeText),
 			handler.NewColumn(SMTPConfigColumnCreationDate, handler.ColumnTypeTimestamp),
 			handler.NewColumn(SMTPConfigColumnChangeDate, handler.ColumnTypeTimestamp),
 			handler.NewColumn(SMTPConfigColumnSequence, handler.ColumnTypeInt64),
 			handler.NewColumn(SMTPConfigColumnResourceOwner, handler.ColumnTypeText),
 			handler.NewColumn(SMTPConfigColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigColumnDescription, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigColumnState, handler.ColumnTypeEnum),
 			handler.NewColumn(SMTPConfigColumnTLS, handler.ColumnTypeBool),
 			handler.NewColumn(SMTPConfigColumnSenderAddress, handler.ColumnTypeText),
 			handler.NewColumn(SMTPConfigColumnSenderName, handler.ColumnTypeText),
@@ -54,8 +76,25 @@ func (*smtpConfigProjection) Init() *old_handler.Check {
 			handler.NewColumn(SMTPConfigColumnSMTPHost, handler.ColumnTypeText),
 			handler.NewColumn(SMTPConfigColumnSMTPUser, handler.ColumnTypeText),
 			handler.NewColumn(SMTPConfigColumnSMTPPassword, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(SMTPConfigColumnAuthMechanism, handler.ColumnTypeEnum, handler.Default(0)),
+			handler.NewColumn(SMTPConfigColumnTLSMode, handler.ColumnTypeEnum, handler.Default(0)),
+			handler.NewColumn(SMTPConfigColumnTimeout, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(SMTPConfigColumnXOAuth2Token, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(SMTPConfigColumnXOAuth2Client, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(SMTPConfigColumnXOAuth2Scopes, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(SMTPConfigColumnInstanceID, SMTPConfigColumnID),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(SMTPConfigHTTPColumnID, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigHTTPColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigHTTPColumnEndpoint, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigHTTPColumnHeaders, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(SMTPConfigHTTPColumnSigningKey, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
-			handler.NewPrimaryKey(SMTPConfigColumnInstanceID, SMTPConfigColumnAggregateID),
+			handler.NewPrimaryKey(SMTPConfigHTTPColumnInstanceID, SMTPConfigHTTPColumnID),
+			smtpConfigHTTPTableSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
 		),
 	)
 }
@@ -77,6 +116,22 @@ func (p *smtpConfigProjection) Reducers() []handler.AggregateReducer {
 					Event:  instance.SMTPConfigPasswordChangedEventType,
 					Reduce: p.reduceSMTPConfigPasswordChanged,
 				},
+				{
+					Event:  instance.SMTPConfigHTTPAddedEventType,
+					Reduce: p.reduceSMTPConfigHTTPAdded,
+				},
+				{
+					Event:  instance.SMTPConfigHTTPChangedEventType,
+					Reduce: p.reduceSMTPConfigHTTPChanged,
+				},
+				{
+					Event:  instance.SMTPConfigActivatedEventType,
+					Reduce: p.reduceSMTPConfigActivated,
+				},
+				{
+					Event:  instance.SMTPConfigDeactivatedEventType,
+					Reduce: p.reduceSMTPConfigDeactivated,
+				},
 				{
 					Event:  instance.SMTPConfigRemovedEventType,
 					Reduce: p.reduceSMTPConfigRemoved,
@@ -95,24 +150,28 @@ func (p *smtpConfigProjection) reduceSMTPConfigAdded(event eventstore.Event) (*h
 	if !ok {
 		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-sk99F", "reduce.wrong.event.type %s", instance.SMTPConfigAddedEventType)
 	}
-	return handler.NewCreateStatement(
-		e,
-		[]handler.Column{
-			handler.NewCol(SMTPConfigColumnAggregateID, e.Aggregate().ID),
-			handler.NewCol(SMTPConfigColumnCreationDate, e.CreationDate()),
-			handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
-			handler.NewCol(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
-			handler.NewCol(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
-			handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
-			handler.NewCol(SMTPConfigColumnTLS, e.TLS),
-			handler.NewCol(SMTPConfigColumnSenderAddress, e.SenderAddress),
-			handler.NewCol(SMTPConfigColumnSenderName, e.SenderName),
-			handler.NewCol(SMTPConfigColumnReplyToAddress, e.ReplyToAddress),
-			handler.NewCol(SMTPConfigColumnSMTPHost, e.Host),
-			handler.NewCol(SMTPConfigColumnSMTPUser, e.User),
-			handler.NewCol(SMTPConfigColumnSMTPPassword, e.Password),
-		},
-	), nil
+	columns := []handler.Column{
+		handler.NewCol(SMTPConfigColumnID, smtpConfigID(e.ID, e.Aggregate())),
+		handler.NewCol(SMTPConfigColumnAggregateID, e.Aggregate().ID),
+		handler.NewCol(SMTPConfigColumnCreationDate, e.CreationDate()),
+		handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+		handler.NewCol(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+		handler.NewCol(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+		handler.NewCol(SMTPConfigColumnDescription, e.Description),
+		handler.NewCol(SMTPConfigColumnState, smtpConfigAddedState(e.ID)),
+		handler.NewCol(SMTPConfigColumnTLS, e.TLS),
+		handler.NewCol(SMTPConfigColumnSenderAddress, e.SenderAddress),
+		handler.NewCol(SMTPConfigColumnSenderName, e.SenderName),
+		handler.NewCol(SMTPConfigColumnReplyToAddress, e.ReplyToAddress),
+		handler.NewCol(SMTPConfigColumnSMTPHost, e.Host),
+		handler.NewCol(SMTPConfigColumnSMTPUser, e.User),
+		handler.NewCol(SMTPConfigColumnSMTPPassword, e.Password),
+	}
+	if e.Connection != nil {
+		columns = append(columns, smtpConfigConnectionColumns(e.Connection)...)
+	}
+	return handler.NewCreateStatement(e, columns), nil
 }
 
 func (p *smtpConfigProjection) reduceSMTPConfigChanged(event eventstore.Event) (*handler.Statement, error) {
@@ -124,6 +183,9 @@ func (p *smtpConfigProjection) reduceSMTPConfigChanged(event eventstore.Event) (
 	columns := make([]handler.Column, 0, 8)
 	columns = append(columns, handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
 		handler.NewCol(SMTPConfigColumnSequence, e.Sequence()))
+	if e.Description != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnDescription, *e.Description))
+	}
 	if e.TLS != nil {
 		columns = append(columns, handler.NewCol(SMTPConfigColumnTLS, *e.TLS))
 	}
@@ -142,11 +204,14 @@ func (p *smtpConfigProjection) reduceSMTPConfigChanged(event eventstore.Event) (
 	if e.User != nil {
 		columns = append(columns, handler.NewCol(SMTPConfigColumnSMTPUser, *e.User))
 	}
+	if e.Connection != nil {
+		columns = append(columns, smtpConfigConnectionColumns(e.Connection)...)
+	}
 	return handler.NewUpdateStatement(
 		e,
 		columns,
 		[]handler.Condition{
-			handler.NewCond(SMTPConfigColumnAggregateID, e.Aggregate().ID),
+			handler.NewCond(SMTPConfigColumnID, smtpConfigID(e.ID, e.Aggregate())),
 			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
 		},
 	), nil
@@ -166,12 +231,95 @@ func (p *smtpConfigProjection) reduceSMTPConfigPasswordChanged(event eventstore.
 			handler.NewCol(SMTPConfigColumnSMTPPassword, e.Password),
 		},
 		[]handler.Condition{
-			handler.NewCond(SMTPConfigColumnAggregateID, e.Aggregate().ID),
+			handler.NewCond(SMTPConfigColumnID, smtpConfigID(e.ID, e.Aggregate())),
 			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
 		},
 	), nil
 }
 
+func (p *smtpConfigProjection) reduceSMTPConfigHTTPAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigHTTPAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMTPConfigColumnID, smtpConfigID(e.ID, e.Aggregate())),
+				handler.NewCol(SMTPConfigColumnAggregateID, e.Aggregate().ID),
+				handler.NewCol(SMTPConfigColumnCreationDate, e.CreationDate()),
+				handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+				handler.NewCol(SMTPConfigColumnDescription, e.Description),
+				handler.NewCol(SMTPConfigColumnState, smtpConfigAddedState(e.ID)),
+				handler.NewCol(SMTPConfigColumnTLS, false),
+				handler.NewCol(SMTPConfigColumnSenderAddress, ""),
+				handler.NewCol(SMTPConfigColumnSenderName, ""),
+				handler.NewCol(SMTPConfigColumnReplyToAddress, ""),
+				handler.NewCol(SMTPConfigColumnSMTPHost, ""),
+				handler.NewCol(SMTPConfigColumnSMTPUser, ""),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMTPConfigHTTPColumnID, smtpConfigID(e.ID, e.Aggregate())),
+				handler.NewCol(SMTPConfigHTTPColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMTPConfigHTTPColumnEndpoint, e.Endpoint),
+				handler.NewJSONCol(SMTPConfigHTTPColumnHeaders, e.Headers),
+				handler.NewCol(SMTPConfigHTTPColumnSigningKey, e.SigningKey),
+			},
+			handler.WithTableSuffix(smtpConfigHTTPTableSuffix),
+		),
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceSMTPConfigHTTPChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigHTTPChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]handler.Column, 0, 3)
+	if e.Endpoint != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigHTTPColumnEndpoint, *e.Endpoint))
+	}
+	if e.Headers != nil {
+		columns = append(columns, handler.NewJSONCol(SMTPConfigHTTPColumnHeaders, *e.Headers))
+	}
+	if e.SigningKey != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigHTTPColumnSigningKey, e.SigningKey))
+	}
+	configColumns := []handler.Column{
+		handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+		handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+	}
+	if e.Description != nil {
+		configColumns = append(configColumns, handler.NewCol(SMTPConfigColumnDescription, *e.Description))
+	}
+	// a changed description only updates the configuration itself
+	stmts := make([]func(eventstore.Event) handler.Exec, 0, 2)
+	if len(columns) > 0 {
+		stmts = append(stmts, handler.AddUpdateStatement(
+			columns,
+			[]handler.Condition{
+				handler.NewCond(SMTPConfigHTTPColumnID, smtpConfigID(e.ID, e.Aggregate())),
+				handler.NewCond(SMTPConfigHTTPColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(smtpConfigHTTPTableSuffix),
+		))
+	}
+	stmts = append(stmts, handler.AddUpdateStatement(
+		configColumns,
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, smtpConfigID(e.ID, e.Aggregate())),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	))
+	return handler.NewMultiStatement(e, stmts...), nil
+}
+
 func (p *smtpConfigProjection) reduceSMTPConfigRemoved(event eventstore.Event) (*handler.Statement, error) {
 	e, err := assertEvent[*instance.SMTPConfigRemovedEvent](event)
 	if err != nil {
@@ -180,8 +328,84 @@ func (p *smtpConfigProjection) reduceSMTPConfigRemoved(event eventstore.Event) (
 	return handler.NewDeleteStatement(
 		e,
 		[]handler.Condition{
-			handler.NewCond(SMTPConfigColumnAggregateID, e.Aggregate().ID),
+			handler.NewCond(SMTPConfigColumnID, smtpConfigID(e.ID, e.Aggregate())),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceSMTPConfigActivated(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigActivatedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+			handler.NewCol(SMTPConfigColumnState, domain.SMTPConfigStateActive),
+		},
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, smtpConfigID(e.ID, e.Aggregate())),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceSMTPConfigDeactivated(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigDeactivatedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+			handler.NewCol(SMTPConfigColumnState, domain.SMTPConfigStateInactive),
+		},
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, smtpConfigID(e.ID, e.Aggregate())),
 			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
 		},
 	), nil
 }
+
+// smtpConfigID returns the id of the configuration,
+// events of the configuration created before multiple configurations were possible have no id
+// and the configuration gets the id of the instance
+func smtpConfigID(id string, aggregate *eventstore.Aggregate) string {
+	if id == "" {
+		return aggregate.ResourceOwner
+	}
+	return id
+}
+
+// smtpConfigAddedState returns the state of an added configuration,
+// the configuration created before multiple configurations were possible was always active
+func smtpConfigAddedState(id string) domain.SMTPConfigState {
+	if id == "" {
+		return domain.SMTPConfigStateActive
+	}
+	return domain.SMTPConfigStateInactive
+}
+
+// smtpConfigConnectionColumns sets all columns of the connection, a nil connection resets them to the defaults
+func smtpConfigConnectionColumns(connection *instance.SMTPConfigConnection) []handler.Column {
+	if connection == nil {
+		connection = new(instance.SMTPConfigConnection)
+	}
+	xoauth2 := connection.XOAuth2
+	if xoauth2 == nil {
+		xoauth2 = new(instance.SMTPConfigXOAuth2)
+	}
+	return []handler.Column{
+		handler.NewCol(SMTPConfigColumnAuthMechanism, connection.AuthMechanism),
+		handler.NewCol(SMTPConfigColumnTLSMode, connection.TLSMode),
+		handler.NewCol(SMTPConfigColumnTimeout, connection.Timeout),
+		handler.NewCol(SMTPConfigColumnXOAuth2Token, xoauth2.TokenEndpoint),
+		handler.NewCol(SMTPConfigColumnXOAuth2Client, xoauth2.ClientID),
+		handler.NewCol(SMTPConfigColumnXOAuth2Scopes, database.TextArray[string](xoauth2.Scopes)),
+	}
+}
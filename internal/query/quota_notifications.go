@@ -10,6 +10,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 
 	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/repository/quota"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -37,6 +38,14 @@ var (
 		name:  projection.QuotaNotificationColumnCallURL,
 		table: quotaNotificationsTable,
 	}
+	QuotaNotificationColumnChannel = Column{
+		name:  projection.QuotaNotificationColumnChannel,
+		table: quotaNotificationsTable,
+	}
+	QuotaNotificationColumnRecipients = Column{
+		name:  projection.QuotaNotificationColumnRecipients,
+		table: quotaNotificationsTable,
+	}
 	QuotaNotificationColumnPercent = Column{
 		name:  projection.QuotaNotificationColumnPercent,
 		table: quotaNotificationsTable,
@@ -110,6 +119,8 @@ func (q *Queries) GetDueQuotaNotifications(ctx context.Context, instanceID strin
 				unit,
 				notification.ID,
 				notification.CallURL,
+				notification.Channel,
+				notification.Recipients,
 				periodStart,
 				reachedThreshold,
 				usedAbs,
@@ -122,6 +133,8 @@ func (q *Queries) GetDueQuotaNotifications(ctx context.Context, instanceID strin
 type QuotaNotification struct {
 	ID               string
 	CallURL          string
+	Channel          quota.NotificationChannel
+	Recipients       database.TextArray[string]
 	Percent          uint16
 	Repeat           bool
 	NextDueThreshold uint16
@@ -153,6 +166,8 @@ func prepareQuotaNotificationsQuery(ctx context.Context, db prepareDatabase) (sq
 	return sq.Select(
 			QuotaNotificationColumnID.identifier(),
 			QuotaNotificationColumnCallURL.identifier(),
+			QuotaNotificationColumnChannel.identifier(),
+			QuotaNotificationColumnRecipients.identifier(),
 			QuotaNotificationColumnPercent.identifier(),
 			QuotaNotificationColumnRepeat.identifier(),
 			QuotaNotificationColumnNextDueThreshold.identifier(),
@@ -163,7 +178,7 @@ func prepareQuotaNotificationsQuery(ctx context.Context, db prepareDatabase) (sq
 			for rows.Next() {
 				cfg := new(QuotaNotification)
 				var nextDueThreshold sql.NullInt16
-				err := rows.Scan(&cfg.ID, &cfg.CallURL, &cfg.Percent, &cfg.Repeat, &nextDueThreshold)
+				err := rows.Scan(&cfg.ID, &cfg.CallURL, &cfg.Channel, &cfg.Recipients, &cfg.Percent, &cfg.Repeat, &nextDueThreshold)
 				if err != nil {
 					if errors.Is(err, sql.ErrNoRows) {
 						return nil, zerrors.ThrowNotFound(err, "QUERY-bbqWb", "Errors.QuotaNotification.NotExisting")
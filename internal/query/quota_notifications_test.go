@@ -9,6 +9,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/repository/quota"
 )
 
 func Test_calculateThreshold(t *testing.T) {
@@ -89,6 +92,8 @@ func Test_calculateThreshold(t *testing.T) {
 var (
 	expectedQuotaNotificationsQuery = regexp.QuoteMeta(`SELECT projections.quotas_notifications.id,` +
 		` projections.quotas_notifications.call_url,` +
+		` projections.quotas_notifications.channel,` +
+		` projections.quotas_notifications.recipients,` +
 		` projections.quotas_notifications.percent,` +
 		` projections.quotas_notifications.repeat,` +
 		` projections.quotas_notifications.next_due_threshold` +
@@ -98,6 +103,8 @@ var (
 	quotaNotificationsCols = []string{
 		"id",
 		"call_url",
+		"channel",
+		"recipients",
 		"percent",
 		"repeat",
 		"next_due_threshold",
@@ -136,7 +143,9 @@ func Test_prepareQuotaNotificationsQuery(t *testing.T) {
 					quotaNotificationsCols,
 					[]driver.Value{
 						"quota-id",
-						"url",
+						"",
+						quota.NotificationChannelEmail,
+						database.TextArray[string]{"ops@zitadel.com"},
 						uint16(100),
 						true,
 						uint16(100),
@@ -147,7 +156,8 @@ func Test_prepareQuotaNotificationsQuery(t *testing.T) {
 				Configs: []*QuotaNotification{
 					{
 						ID:               "quota-id",
-						CallURL:          "url",
+						Channel:          quota.NotificationChannelEmail,
+						Recipients:       database.TextArray[string]{"ops@zitadel.com"},
 						Percent:          100,
 						Repeat:           true,
 						NextDueThreshold: 100,
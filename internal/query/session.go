@@ -28,23 +28,25 @@ type Sessions struct {
 }
 
 type Session struct {
-	ID             string
-	CreationDate   time.Time
-	ChangeDate     time.Time
-	Sequence       uint64
-	State          domain.SessionState
-	ResourceOwner  string
-	Creator        string
-	UserFactor     SessionUserFactor
-	PasswordFactor SessionPasswordFactor
-	IntentFactor   SessionIntentFactor
-	WebAuthNFactor SessionWebAuthNFactor
-	TOTPFactor     SessionTOTPFactor
-	OTPSMSFactor   SessionOTPFactor
-	OTPEmailFactor SessionOTPFactor
-	Metadata       map[string][]byte
-	UserAgent      domain.UserAgent
-	Expiration     time.Time
+	ID              string
+	CreationDate    time.Time
+	ChangeDate      time.Time
+	Sequence        uint64
+	State           domain.SessionState
+	ResourceOwner   string
+	Creator         string
+	UserFactor      SessionUserFactor
+	PasswordFactor  SessionPasswordFactor
+	IntentFactor    SessionIntentFactor
+	WebAuthNFactor  SessionWebAuthNFactor
+	TOTPFactor      SessionTOTPFactor
+	OTPSMSFactor    SessionOTPFactor
+	OTPEmailFactor  SessionOTPFactor
+	MagicLinkFactor SessionMagicLinkFactor
+	X509Factor      SessionX509Factor
+	Metadata        map[string][]byte
+	UserAgent       domain.UserAgent
+	Expiration      time.Time
 }
 
 type SessionUserFactor struct {
@@ -76,6 +78,14 @@ type SessionOTPFactor struct {
 	OTPCheckedAt time.Time
 }
 
+type SessionMagicLinkFactor struct {
+	MagicLinkCheckedAt time.Time
+}
+
+type SessionX509Factor struct {
+	X509CheckedAt time.Time
+}
+
 type SessionsSearchQueries struct {
 	SearchRequest
 	Queries []SearchQuery
@@ -166,6 +176,14 @@ var (
 		name:  projection.SessionColumnOTPEmailCheckedAt,
 		table: sessionsTable,
 	}
+	SessionColumnMagicLinkCheckedAt = Column{
+		name:  projection.SessionColumnMagicLinkCheckedAt,
+		table: sessionsTable,
+	}
+	SessionColumnX509CheckedAt = Column{
+		name:  projection.SessionColumnX509CheckedAt,
+		table: sessionsTable,
+	}
 	SessionColumnMetadata = Column{
 		name:  projection.SessionColumnMetadata,
 		table: sessionsTable,
@@ -302,6 +320,8 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			SessionColumnTOTPCheckedAt.identifier(),
 			SessionColumnOTPSMSCheckedAt.identifier(),
 			SessionColumnOTPEmailCheckedAt.identifier(),
+			SessionColumnMagicLinkCheckedAt.identifier(),
+			SessionColumnX509CheckedAt.identifier(),
 			SessionColumnMetadata.identifier(),
 			SessionColumnToken.identifier(),
 			SessionColumnUserAgentFingerprintID.identifier(),
@@ -329,6 +349,8 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 				totpCheckedAt       sql.NullTime
 				otpSMSCheckedAt     sql.NullTime
 				otpEmailCheckedAt   sql.NullTime
+				magicLinkCheckedAt  sql.NullTime
+				x509CheckedAt       sql.NullTime
 				metadata            database.Map[[]byte]
 				token               sql.NullString
 				userAgentIP         sql.NullString
@@ -356,6 +378,8 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 				&totpCheckedAt,
 				&otpSMSCheckedAt,
 				&otpEmailCheckedAt,
+				&magicLinkCheckedAt,
+				&x509CheckedAt,
 				&metadata,
 				&token,
 				&session.UserAgent.FingerprintID,
@@ -384,6 +408,8 @@ func prepareSessionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			session.TOTPFactor.TOTPCheckedAt = totpCheckedAt.Time
 			session.OTPSMSFactor.OTPCheckedAt = otpSMSCheckedAt.Time
 			session.OTPEmailFactor.OTPCheckedAt = otpEmailCheckedAt.Time
+			session.MagicLinkFactor.MagicLinkCheckedAt = magicLinkCheckedAt.Time
+			session.X509Factor.X509CheckedAt = x509CheckedAt.Time
 			session.Metadata = metadata
 			session.UserAgent.Header = http.Header(userAgentHeader)
 			if userAgentIP.Valid {
@@ -415,6 +441,8 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 			SessionColumnTOTPCheckedAt.identifier(),
 			SessionColumnOTPSMSCheckedAt.identifier(),
 			SessionColumnOTPEmailCheckedAt.identifier(),
+			SessionColumnMagicLinkCheckedAt.identifier(),
+			SessionColumnX509CheckedAt.identifier(),
 			SessionColumnMetadata.identifier(),
 			SessionColumnExpiration.identifier(),
 			countColumn.identifier(),
@@ -441,6 +469,8 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 					totpCheckedAt       sql.NullTime
 					otpSMSCheckedAt     sql.NullTime
 					otpEmailCheckedAt   sql.NullTime
+					magicLinkCheckedAt  sql.NullTime
+					x509CheckedAt       sql.NullTime
 					metadata            database.Map[[]byte]
 					expiration          sql.NullTime
 				)
@@ -465,6 +495,8 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 					&totpCheckedAt,
 					&otpSMSCheckedAt,
 					&otpEmailCheckedAt,
+					&magicLinkCheckedAt,
+					&x509CheckedAt,
 					&metadata,
 					&expiration,
 					&sessions.Count,
@@ -485,6 +517,8 @@ func prepareSessionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBui
 				session.TOTPFactor.TOTPCheckedAt = totpCheckedAt.Time
 				session.OTPSMSFactor.OTPCheckedAt = otpSMSCheckedAt.Time
 				session.OTPEmailFactor.OTPCheckedAt = otpEmailCheckedAt.Time
+				session.MagicLinkFactor.MagicLinkCheckedAt = magicLinkCheckedAt.Time
+				session.X509Factor.X509CheckedAt = x509CheckedAt.Time
 				session.Metadata = metadata
 				session.Expiration = expiration.Time
 
@@ -20,63 +20,67 @@ import (
 )
 
 var (
-	expectedSessionQuery = regexp.QuoteMeta(`SELECT projections.sessions8.id,` +
-		` projections.sessions8.creation_date,` +
-		` projections.sessions8.change_date,` +
-		` projections.sessions8.sequence,` +
-		` projections.sessions8.state,` +
-		` projections.sessions8.resource_owner,` +
-		` projections.sessions8.creator,` +
-		` projections.sessions8.user_id,` +
-		` projections.sessions8.user_resource_owner,` +
-		` projections.sessions8.user_checked_at,` +
+	expectedSessionQuery = regexp.QuoteMeta(`SELECT projections.sessions10.id,` +
+		` projections.sessions10.creation_date,` +
+		` projections.sessions10.change_date,` +
+		` projections.sessions10.sequence,` +
+		` projections.sessions10.state,` +
+		` projections.sessions10.resource_owner,` +
+		` projections.sessions10.creator,` +
+		` projections.sessions10.user_id,` +
+		` projections.sessions10.user_resource_owner,` +
+		` projections.sessions10.user_checked_at,` +
 		` projections.login_names3.login_name,` +
 		` projections.users10_humans.display_name,` +
-		` projections.sessions8.password_checked_at,` +
-		` projections.sessions8.intent_checked_at,` +
-		` projections.sessions8.webauthn_checked_at,` +
-		` projections.sessions8.webauthn_user_verified,` +
-		` projections.sessions8.totp_checked_at,` +
-		` projections.sessions8.otp_sms_checked_at,` +
-		` projections.sessions8.otp_email_checked_at,` +
-		` projections.sessions8.metadata,` +
-		` projections.sessions8.token_id,` +
-		` projections.sessions8.user_agent_fingerprint_id,` +
-		` projections.sessions8.user_agent_ip,` +
-		` projections.sessions8.user_agent_description,` +
-		` projections.sessions8.user_agent_header,` +
-		` projections.sessions8.expiration` +
-		` FROM projections.sessions8` +
-		` LEFT JOIN projections.login_names3 ON projections.sessions8.user_id = projections.login_names3.user_id AND projections.sessions8.instance_id = projections.login_names3.instance_id` +
-		` LEFT JOIN projections.users10_humans ON projections.sessions8.user_id = projections.users10_humans.user_id AND projections.sessions8.instance_id = projections.users10_humans.instance_id` +
-		` LEFT JOIN projections.users10 ON projections.sessions8.user_id = projections.users10.id AND projections.sessions8.instance_id = projections.users10.instance_id` +
+		` projections.sessions10.password_checked_at,` +
+		` projections.sessions10.intent_checked_at,` +
+		` projections.sessions10.webauthn_checked_at,` +
+		` projections.sessions10.webauthn_user_verified,` +
+		` projections.sessions10.totp_checked_at,` +
+		` projections.sessions10.otp_sms_checked_at,` +
+		` projections.sessions10.otp_email_checked_at,` +
+		` projections.sessions10.magic_link_checked_at,` +
+		` projections.sessions10.x509_checked_at,` +
+		` projections.sessions10.metadata,` +
+		` projections.sessions10.token_id,` +
+		` projections.sessions10.user_agent_fingerprint_id,` +
+		` projections.sessions10.user_agent_ip,` +
+		` projections.sessions10.user_agent_description,` +
+		` projections.sessions10.user_agent_header,` +
+		` projections.sessions10.expiration` +
+		` FROM projections.sessions10` +
+		` LEFT JOIN projections.login_names3 ON projections.sessions10.user_id = projections.login_names3.user_id AND projections.sessions10.instance_id = projections.login_names3.instance_id` +
+		` LEFT JOIN projections.users10_humans ON projections.sessions10.user_id = projections.users10_humans.user_id AND projections.sessions10.instance_id = projections.users10_humans.instance_id` +
+		` LEFT JOIN projections.users10 ON projections.sessions10.user_id = projections.users10.id AND projections.sessions10.instance_id = projections.users10.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedSessionsQuery = regexp.QuoteMeta(`SELECT projections.sessions8.id,` +
-		` projections.sessions8.creation_date,` +
-		` projections.sessions8.change_date,` +
-		` projections.sessions8.sequence,` +
-		` projections.sessions8.state,` +
-		` projections.sessions8.resource_owner,` +
-		` projections.sessions8.creator,` +
-		` projections.sessions8.user_id,` +
-		` projections.sessions8.user_resource_owner,` +
-		` projections.sessions8.user_checked_at,` +
+	expectedSessionsQuery = regexp.QuoteMeta(`SELECT projections.sessions10.id,` +
+		` projections.sessions10.creation_date,` +
+		` projections.sessions10.change_date,` +
+		` projections.sessions10.sequence,` +
+		` projections.sessions10.state,` +
+		` projections.sessions10.resource_owner,` +
+		` projections.sessions10.creator,` +
+		` projections.sessions10.user_id,` +
+		` projections.sessions10.user_resource_owner,` +
+		` projections.sessions10.user_checked_at,` +
 		` projections.login_names3.login_name,` +
 		` projections.users10_humans.display_name,` +
-		` projections.sessions8.password_checked_at,` +
-		` projections.sessions8.intent_checked_at,` +
-		` projections.sessions8.webauthn_checked_at,` +
-		` projections.sessions8.webauthn_user_verified,` +
-		` projections.sessions8.totp_checked_at,` +
-		` projections.sessions8.otp_sms_checked_at,` +
-		` projections.sessions8.otp_email_checked_at,` +
-		` projections.sessions8.metadata,` +
-		` projections.sessions8.expiration,` +
+		` projections.sessions10.password_checked_at,` +
+		` projections.sessions10.intent_checked_at,` +
+		` projections.sessions10.webauthn_checked_at,` +
+		` projections.sessions10.webauthn_user_verified,` +
+		` projections.sessions10.totp_checked_at,` +
+		` projections.sessions10.otp_sms_checked_at,` +
+		` projections.sessions10.otp_email_checked_at,` +
+		` projections.sessions10.magic_link_checked_at,` +
+		` projections.sessions10.x509_checked_at,` +
+		` projections.sessions10.metadata,` +
+		` projections.sessions10.expiration,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.sessions8` +
-		` LEFT JOIN projections.login_names3 ON projections.sessions8.user_id = projections.login_names3.user_id AND projections.sessions8.instance_id = projections.login_names3.instance_id` +
-		` LEFT JOIN projections.users10_humans ON projections.sessions8.user_id = projections.users10_humans.user_id AND projections.sessions8.instance_id = projections.users10_humans.instance_id` +
-		` LEFT JOIN projections.users10 ON projections.sessions8.user_id = projections.users10.id AND projections.sessions8.instance_id = projections.users10.instance_id` +
+		` FROM projections.sessions10` +
+		` LEFT JOIN projections.login_names3 ON projections.sessions10.user_id = projections.login_names3.user_id AND projections.sessions10.instance_id = projections.login_names3.instance_id` +
+		` LEFT JOIN projections.users10_humans ON projections.sessions10.user_id = projections.users10_humans.user_id AND projections.sessions10.instance_id = projections.users10_humans.instance_id` +
+		` LEFT JOIN projections.users10 ON projections.sessions10.user_id = projections.users10.id AND projections.sessions10.instance_id = projections.users10.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 
 	sessionCols = []string{
@@ -99,6 +103,8 @@ var (
 		"totp_checked_at",
 		"otp_sms_checked_at",
 		"otp_email_checked_at",
+		"magic_link_checked_at",
+		"x509_checked_at",
 		"metadata",
 		"token",
 		"user_agent_fingerprint_id",
@@ -128,6 +134,8 @@ var (
 		"totp_checked_at",
 		"otp_sms_checked_at",
 		"otp_email_checked_at",
+		"magic_link_checked_at",
+		"x509_checked_at",
 		"metadata",
 		"expiration",
 		"count",
@@ -185,6 +193,8 @@ func Test_SessionsPrepare(t *testing.T) {
 							testNow,
 							testNow,
 							testNow,
+							testNow,
+							testNow,
 							[]byte(`{"key": "dmFsdWU="}`),
 							testNow,
 						},
@@ -230,6 +240,12 @@ func Test_SessionsPrepare(t *testing.T) {
 						OTPEmailFactor: SessionOTPFactor{
 							OTPCheckedAt: testNow,
 						},
+						MagicLinkFactor: SessionMagicLinkFactor{
+							MagicLinkCheckedAt: testNow,
+						},
+						X509Factor: SessionX509Factor{
+							X509CheckedAt: testNow,
+						},
 						Metadata: map[string][]byte{
 							"key": []byte("value"),
 						},
@@ -266,6 +282,8 @@ func Test_SessionsPrepare(t *testing.T) {
 							testNow,
 							testNow,
 							testNow,
+							testNow,
+							testNow,
 							[]byte(`{"key": "dmFsdWU="}`),
 							testNow,
 						},
@@ -289,6 +307,8 @@ func Test_SessionsPrepare(t *testing.T) {
 							testNow,
 							testNow,
 							testNow,
+							testNow,
+							testNow,
 							[]byte(`{"key": "dmFsdWU="}`),
 							testNow,
 						},
@@ -334,6 +354,12 @@ func Test_SessionsPrepare(t *testing.T) {
 						OTPEmailFactor: SessionOTPFactor{
 							OTPCheckedAt: testNow,
 						},
+						MagicLinkFactor: SessionMagicLinkFactor{
+							MagicLinkCheckedAt: testNow,
+						},
+						X509Factor: SessionX509Factor{
+							X509CheckedAt: testNow,
+						},
 						Metadata: map[string][]byte{
 							"key": []byte("value"),
 						},
@@ -373,6 +399,12 @@ func Test_SessionsPrepare(t *testing.T) {
 						OTPEmailFactor: SessionOTPFactor{
 							OTPCheckedAt: testNow,
 						},
+						MagicLinkFactor: SessionMagicLinkFactor{
+							MagicLinkCheckedAt: testNow,
+						},
+						X509Factor: SessionX509Factor{
+							X509CheckedAt: testNow,
+						},
 						Metadata: map[string][]byte{
 							"key": []byte("value"),
 						},
@@ -462,6 +494,8 @@ func Test_SessionPrepare(t *testing.T) {
 						testNow,
 						testNow,
 						testNow,
+						testNow,
+						testNow,
 						[]byte(`{"key": "dmFsdWU="}`),
 						"tokenID",
 						"fingerPrintID",
@@ -506,6 +540,12 @@ func Test_SessionPrepare(t *testing.T) {
 				OTPEmailFactor: SessionOTPFactor{
 					OTPCheckedAt: testNow,
 				},
+				MagicLinkFactor: SessionMagicLinkFactor{
+					MagicLinkCheckedAt: testNow,
+				},
+				X509Factor: SessionX509Factor{
+					X509CheckedAt: testNow,
+				},
 				Metadata: map[string][]byte{
 					"key": []byte("value"),
 				},
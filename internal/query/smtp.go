@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"net/http"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -11,6 +12,8 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -21,6 +24,10 @@ var (
 		name:          projection.SMTPConfigProjectionTable,
 		instanceIDCol: projection.SMTPConfigColumnInstanceID,
 	}
+	SMTPConfigColumnID = Column{
+		name:  projection.SMTPConfigColumnID,
+		table: smtpConfigsTable,
+	}
 	SMTPConfigColumnAggregateID = Column{
 		name:  projection.SMTPConfigColumnAggregateID,
 		table: smtpConfigsTable,
@@ -45,6 +52,14 @@ var (
 		name:  projection.SMTPConfigColumnSequence,
 		table: smtpConfigsTable,
 	}
+	SMTPConfigColumnDescription = Column{
+		name:  projection.SMTPConfigColumnDescription,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnState = Column{
+		name:  projection.SMTPConfigColumnState,
+		table: smtpConfigsTable,
+	}
 	SMTPConfigColumnTLS = Column{
 		name:  projection.SMTPConfigColumnTLS,
 		table: smtpConfigsTable,
@@ -73,19 +88,82 @@ var (
 		name:  projection.SMTPConfigColumnSMTPPassword,
 		table: smtpConfigsTable,
 	}
+	SMTPConfigColumnAuthMechanism = Column{
+		name:  projection.SMTPConfigColumnAuthMechanism,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnTLSMode = Column{
+		name:  projection.SMTPConfigColumnTLSMode,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnTimeout = Column{
+		name:  projection.SMTPConfigColumnTimeout,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnXOAuth2Token = Column{
+		name:  projection.SMTPConfigColumnXOAuth2Token,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnXOAuth2Client = Column{
+		name:  projection.SMTPConfigColumnXOAuth2Client,
+		table: smtpConfigsTable,
+	}
+	SMTPConfigColumnXOAuth2Scopes = Column{
+		name:  projection.SMTPConfigColumnXOAuth2Scopes,
+		table: smtpConfigsTable,
+	}
 )
 
+var (
+	smtpConfigsHTTPTable = table{
+		name:          projection.SMTPConfigHTTPTable,
+		instanceIDCol: projection.SMTPConfigHTTPColumnInstanceID,
+	}
+	SMTPConfigHTTPColumnID = Column{
+		name:  projection.SMTPConfigHTTPColumnID,
+		table: smtpConfigsHTTPTable,
+	}
+	SMTPConfigHTTPColumnEndpoint = Column{
+		name:  projection.SMTPConfigHTTPColumnEndpoint,
+		table: smtpConfigsHTTPTable,
+	}
+	SMTPConfigHTTPColumnHeaders = Column{
+		name:  projection.SMTPConfigHTTPColumnHeaders,
+		table: smtpConfigsHTTPTable,
+	}
+	SMTPConfigHTTPColumnSigningKey = Column{
+		name:  projection.SMTPConfigHTTPColumnSigningKey,
+		table: smtpConfigsHTTPTable,
+	}
+)
+
+type SMTPConfigsSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *SMTPConfigsSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
 type SMTPConfigs struct {
 	SearchResponse
-	SMTPConfigs []*SMTPConfig
+	Configs []*SMTPConfig
 }
 
 type SMTPConfig struct {
+	ID            string
 	AggregateID   string
 	CreationDate  time.Time
 	ChangeDate    time.Time
 	ResourceOwner string
 	Sequence      uint64
+	Description   string
+	State         domain.SMTPConfigState
 
 	TLS            bool
 	SenderAddress  string
@@ -94,16 +172,29 @@ type SMTPConfig struct {
 	Host           string
 	User           string
 	Password       *crypto.CryptoValue
+	AuthMechanism  domain.SMTPAuthMechanism
+	TLSMode        domain.SMTPTLSMode
+	Timeout        time.Duration
+	XOAuth2        *SMTPXOAuth2
+
+	HTTPConfig *HTTP
+}
+
+type SMTPXOAuth2 struct {
+	TokenEndpoint string
+	ClientID      string
+	Scopes        []string
 }
 
-func (q *Queries) SMTPConfigByAggregateID(ctx context.Context, aggregateID string) (config *SMTPConfig, err error) {
+// SMTPConfigActive returns the active SMTP configuration of the instance
+func (q *Queries) SMTPConfigActive(ctx context.Context, instanceID string) (config *SMTPConfig, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
 	stmt, scan := prepareSMTPConfigQuery(ctx, q.client)
 	query, args, err := stmt.Where(sq.Eq{
-		SMTPConfigColumnAggregateID.identifier(): aggregateID,
-		SMTPConfigColumnInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+		SMTPConfigColumnInstanceID.identifier(): instanceID,
+		SMTPConfigColumnState.identifier():      domain.SMTPConfigStateActive,
 	}).ToSql()
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-3m9sl", "Errors.Query.SQLStatment")
@@ -116,32 +207,98 @@ func (q *Queries) SMTPConfigByAggregateID(ctx context.Context, aggregateID strin
 	return config, err
 }
 
-func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*SMTPConfig, error)) {
-	password := new(crypto.CryptoValue)
+func (q *Queries) SMTPConfigByID(ctx context.Context, instanceID, id string) (config *SMTPConfig, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareSMTPConfigQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		SMTPConfigColumnInstanceID.identifier(): instanceID,
+		SMTPConfigColumnID.identifier():         id,
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-8f8gw", "Errors.Query.SQLStatment")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		config, err = scan(row)
+		return err
+	}, query, args...)
+	return config, err
+}
+
+func (q *Queries) SearchSMTPConfigs(ctx context.Context, queries *SMTPConfigsSearchQueries) (configs *SMTPConfigs, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareSMTPConfigsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			SMTPConfigColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "QUERY-sZ7Cx", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		configs, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-tOpKN", "Errors.Internal")
+	}
+	configs.State, err = q.latestState(ctx, smtpConfigsTable)
+	return configs, err
+}
+
+func NewSMTPConfigStateQuery(state domain.SMTPConfigState) (SearchQuery, error) {
+	return NewNumberQuery(SMTPConfigColumnState, state, NumberEquals)
+}
 
+func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*SMTPConfig, error)) {
 	return sq.Select(
+			SMTPConfigColumnID.identifier(),
 			SMTPConfigColumnAggregateID.identifier(),
 			SMTPConfigColumnCreationDate.identifier(),
 			SMTPConfigColumnChangeDate.identifier(),
 			SMTPConfigColumnResourceOwner.identifier(),
 			SMTPConfigColumnSequence.identifier(),
+			SMTPConfigColumnDescription.identifier(),
+			SMTPConfigColumnState.identifier(),
 			SMTPConfigColumnTLS.identifier(),
 			SMTPConfigColumnSenderAddress.identifier(),
 			SMTPConfigColumnSenderName.identifier(),
 			SMTPConfigColumnReplyToAddress.identifier(),
 			SMTPConfigColumnSMTPHost.identifier(),
 			SMTPConfigColumnSMTPUser.identifier(),
-			SMTPConfigColumnSMTPPassword.identifier()).
-			From(smtpConfigsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			SMTPConfigColumnSMTPPassword.identifier(),
+			SMTPConfigColumnAuthMechanism.identifier(),
+			SMTPConfigColumnTLSMode.identifier(),
+			SMTPConfigColumnTimeout.identifier(),
+			SMTPConfigColumnXOAuth2Token.identifier(),
+			SMTPConfigColumnXOAuth2Client.identifier(),
+			SMTPConfigColumnXOAuth2Scopes.identifier(),
+			SMTPConfigHTTPColumnID.identifier(),
+			SMTPConfigHTTPColumnEndpoint.identifier(),
+			SMTPConfigHTTPColumnHeaders.identifier(),
+			SMTPConfigHTTPColumnSigningKey.identifier()).
+			From(smtpConfigsTable.identifier()).
+			LeftJoin(join(SMTPConfigHTTPColumnID, SMTPConfigColumnID) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*SMTPConfig, error) {
 			config := new(SMTPConfig)
+			password := new(crypto.CryptoValue)
+			httpConfig := sqlSMTPHTTPConfig{}
+			connection := sqlSMTPConnection{}
 			err := row.Scan(
+				&config.ID,
 				&config.AggregateID,
 				&config.CreationDate,
 				&config.ChangeDate,
 				&config.ResourceOwner,
 				&config.Sequence,
+				&config.Description,
+				&config.State,
 				&config.TLS,
 				&config.SenderAddress,
 				&config.SenderName,
@@ -149,6 +306,16 @@ func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				&config.Host,
 				&config.User,
 				&password,
+				&config.AuthMechanism,
+				&config.TLSMode,
+				&connection.timeout,
+				&connection.tokenEndpoint,
+				&connection.clientID,
+				&connection.scopes,
+				&httpConfig.id,
+				&httpConfig.endpoint,
+				&httpConfig.headers,
+				&httpConfig.signingKey,
 			)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
@@ -157,6 +324,123 @@ func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				return nil, zerrors.ThrowInternal(err, "QUERY-9k87F", "Errors.Internal")
 			}
 			config.Password = password
+			connection.set(config)
+			httpConfig.set(config)
 			return config, nil
 		}
 }
+
+func prepareSMTPConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*SMTPConfigs, error)) {
+	return sq.Select(
+			SMTPConfigColumnID.identifier(),
+			SMTPConfigColumnAggregateID.identifier(),
+			SMTPConfigColumnCreationDate.identifier(),
+			SMTPConfigColumnChangeDate.identifier(),
+			SMTPConfigColumnResourceOwner.identifier(),
+			SMTPConfigColumnSequence.identifier(),
+			SMTPConfigColumnDescription.identifier(),
+			SMTPConfigColumnState.identifier(),
+			SMTPConfigColumnTLS.identifier(),
+			SMTPConfigColumnSenderAddress.identifier(),
+			SMTPConfigColumnSenderName.identifier(),
+			SMTPConfigColumnReplyToAddress.identifier(),
+			SMTPConfigColumnSMTPHost.identifier(),
+			SMTPConfigColumnSMTPUser.identifier(),
+			SMTPConfigColumnSMTPPassword.identifier(),
+			SMTPConfigColumnAuthMechanism.identifier(),
+			SMTPConfigColumnTLSMode.identifier(),
+			SMTPConfigColumnTimeout.identifier(),
+			SMTPConfigColumnXOAuth2Token.identifier(),
+			SMTPConfigColumnXOAuth2Client.identifier(),
+			SMTPConfigColumnXOAuth2Scopes.identifier(),
+			SMTPConfigHTTPColumnID.identifier(),
+			SMTPConfigHTTPColumnEndpoint.identifier(),
+			SMTPConfigHTTPColumnHeaders.identifier(),
+			SMTPConfigHTTPColumnSigningKey.identifier(),
+			countColumn.identifier()).
+			From(smtpConfigsTable.identifier()).
+			LeftJoin(join(SMTPConfigHTTPColumnID, SMTPConfigColumnID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*SMTPConfigs, error) {
+			configs := &SMTPConfigs{Configs: []*SMTPConfig{}}
+			for rows.Next() {
+				config := new(SMTPConfig)
+				password := new(crypto.CryptoValue)
+				httpConfig := sqlSMTPHTTPConfig{}
+				connection := sqlSMTPConnection{}
+				err := rows.Scan(
+					&config.ID,
+					&config.AggregateID,
+					&config.CreationDate,
+					&config.ChangeDate,
+					&config.ResourceOwner,
+					&config.Sequence,
+					&config.Description,
+					&config.State,
+					&config.TLS,
+					&config.SenderAddress,
+					&config.SenderName,
+					&config.ReplyToAddress,
+					&config.Host,
+					&config.User,
+					&password,
+					&config.AuthMechanism,
+					&config.TLSMode,
+					&connection.timeout,
+					&connection.tokenEndpoint,
+					&connection.clientID,
+					&connection.scopes,
+					&httpConfig.id,
+					&httpConfig.endpoint,
+					&httpConfig.headers,
+					&httpConfig.signingKey,
+					&configs.Count,
+				)
+				if err != nil {
+					return nil, zerrors.ThrowInternal(err, "QUERY-9k87G", "Errors.Internal")
+				}
+				config.Password = password
+				connection.set(config)
+				httpConfig.set(config)
+				configs.Configs = append(configs.Configs, config)
+			}
+			return configs, nil
+		}
+}
+
+type sqlSMTPHTTPConfig struct {
+	id         sql.NullString
+	endpoint   sql.NullString
+	headers    database.Map[[]string]
+	signingKey *crypto.CryptoValue
+}
+
+func (c sqlSMTPHTTPConfig) set(smtpConfig *SMTPConfig) {
+	if !c.id.Valid {
+		return
+	}
+	smtpConfig.HTTPConfig = &HTTP{
+		Endpoint:   c.endpoint.String,
+		Headers:    http.Header(c.headers),
+		SigningKey: c.signingKey,
+	}
+}
+
+type sqlSMTPConnection struct {
+	timeout       int64
+	tokenEndpoint string
+	clientID      string
+	scopes        database.TextArray[string]
+}
+
+func (c sqlSMTPConnection) set(smtpConfig *SMTPConfig) {
+	smtpConfig.Timeout = time.Duration(c.timeout)
+	if c.tokenEndpoint == "" {
+		return
+	}
+	smtpConfig.XOAuth2 = &SMTPXOAuth2{
+		TokenEndpoint: c.tokenEndpoint,
+		ClientID:      c.clientID,
+		Scopes:        c.scopes,
+	}
+}
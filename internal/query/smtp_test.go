@@ -5,34 +5,55 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 var (
-	prepareSMTPConfigStmt = `SELECT projections.smtp_configs1.aggregate_id,` +
-		` projections.smtp_configs1.creation_date,` +
-		` projections.smtp_configs1.change_date,` +
-		` projections.smtp_configs1.resource_owner,` +
-		` projections.smtp_configs1.sequence,` +
-		` projections.smtp_configs1.tls,` +
-		` projections.smtp_configs1.sender_address,` +
-		` projections.smtp_configs1.sender_name,` +
-		` projections.smtp_configs1.reply_to_address,` +
-		` projections.smtp_configs1.host,` +
-		` projections.smtp_configs1.username,` +
-		` projections.smtp_configs1.password` +
-		` FROM projections.smtp_configs1` +
+	prepareSMTPConfigStmt = `SELECT projections.smtp_configs4.id,` +
+		` projections.smtp_configs4.aggregate_id,` +
+		` projections.smtp_configs4.creation_date,` +
+		` projections.smtp_configs4.change_date,` +
+		` projections.smtp_configs4.resource_owner,` +
+		` projections.smtp_configs4.sequence,` +
+		` projections.smtp_configs4.description,` +
+		` projections.smtp_configs4.state,` +
+		` projections.smtp_configs4.tls,` +
+		` projections.smtp_configs4.sender_address,` +
+		` projections.smtp_configs4.sender_name,` +
+		` projections.smtp_configs4.reply_to_address,` +
+		` projections.smtp_configs4.host,` +
+		` projections.smtp_configs4.username,` +
+		` projections.smtp_configs4.password,` +
+		` projections.smtp_configs4.auth_mechanism,` +
+		` projections.smtp_configs4.tls_mode,` +
+		` projections.smtp_configs4.timeout,` +
+		` projections.smtp_configs4.xoauth2_token_endpoint,` +
+		` projections.smtp_configs4.xoauth2_client_id,` +
+		` projections.smtp_configs4.xoauth2_scopes,` +
+		` projections.smtp_configs4_http.id,` +
+		` projections.smtp_configs4_http.endpoint,` +
+		` projections.smtp_configs4_http.headers,` +
+		` projections.smtp_configs4_http.signing_key` +
+		` FROM projections.smtp_configs4` +
+		` LEFT JOIN projections.smtp_configs4_http ON projections.smtp_configs4.id = projections.smtp_configs4_http.id AND projections.smtp_configs4.instance_id = projections.smtp_configs4_http.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareSMTPConfigCols = []string{
+		"id",
 		"aggregate_id",
 		"creation_date",
 		"change_date",
 		"resource_owner",
 		"sequence",
+		"description",
+		"state",
 		"tls",
 		"sender_address",
 		"sender_name",
@@ -40,7 +61,47 @@ var (
 		"smtp_host",
 		"smtp_user",
 		"smtp_password",
+		"auth_mechanism",
+		"tls_mode",
+		"timeout",
+		"xoauth2_token_endpoint",
+		"xoauth2_client_id",
+		"xoauth2_scopes",
+		"id",
+		"endpoint",
+		"headers",
+		"signing_key",
 	}
+	prepareSMTPConfigsStmt = `SELECT projections.smtp_configs4.id,` +
+		` projections.smtp_configs4.aggregate_id,` +
+		` projections.smtp_configs4.creation_date,` +
+		` projections.smtp_configs4.change_date,` +
+		` projections.smtp_configs4.resource_owner,` +
+		` projections.smtp_configs4.sequence,` +
+		` projections.smtp_configs4.description,` +
+		` projections.smtp_configs4.state,` +
+		` projections.smtp_configs4.tls,` +
+		` projections.smtp_configs4.sender_address,` +
+		` projections.smtp_configs4.sender_name,` +
+		` projections.smtp_configs4.reply_to_address,` +
+		` projections.smtp_configs4.host,` +
+		` projections.smtp_configs4.username,` +
+		` projections.smtp_configs4.password,` +
+		` projections.smtp_configs4.auth_mechanism,` +
+		` projections.smtp_configs4.tls_mode,` +
+		` projections.smtp_configs4.timeout,` +
+		` projections.smtp_configs4.xoauth2_token_endpoint,` +
+		` projections.smtp_configs4.xoauth2_client_id,` +
+		` projections.smtp_configs4.xoauth2_scopes,` +
+		` projections.smtp_configs4_http.id,` +
+		` projections.smtp_configs4_http.endpoint,` +
+		` projections.smtp_configs4_http.headers,` +
+		` projections.smtp_configs4_http.signing_key,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.smtp_configs4` +
+		` LEFT JOIN projections.smtp_configs4_http ON projections.smtp_configs4.id = projections.smtp_configs4_http.id AND projections.smtp_configs4.instance_id = projections.smtp_configs4_http.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareSMTPConfigsCols = append(prepareSMTPConfigCols, "count")
 )
 
 func Test_SMTPConfigsPrepares(t *testing.T) {
@@ -80,11 +141,14 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 					regexp.QuoteMeta(prepareSMTPConfigStmt),
 					prepareSMTPConfigCols,
 					[]driver.Value{
+						"config-id",
 						"agg-id",
 						testNow,
 						testNow,
 						"ro",
 						uint64(20211108),
+						"test",
+						domain.SMTPConfigStateActive,
 						true,
 						"sender",
 						"name",
@@ -92,15 +156,28 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 						"host",
 						"user",
 						&crypto.CryptoValue{},
+						domain.SMTPAuthMechanismXOAuth2,
+						domain.SMTPTLSModeStartTLS,
+						int64(10 * time.Second),
+						"https://idp.example.com/token",
+						"client-id",
+						database.TextArray[string]{"smtp"},
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
 			object: &SMTPConfig{
+				ID:             "config-id",
 				AggregateID:    "agg-id",
 				CreationDate:   testNow,
 				ChangeDate:     testNow,
 				ResourceOwner:  "ro",
 				Sequence:       20211108,
+				Description:    "test",
+				State:          domain.SMTPConfigStateActive,
 				TLS:            true,
 				SenderAddress:  "sender",
 				SenderName:     "name",
@@ -108,6 +185,66 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 				Host:           "host",
 				User:           "user",
 				Password:       &crypto.CryptoValue{},
+				AuthMechanism:  domain.SMTPAuthMechanismXOAuth2,
+				TLSMode:        domain.SMTPTLSModeStartTLS,
+				Timeout:        10 * time.Second,
+				XOAuth2: &SMTPXOAuth2{
+					TokenEndpoint: "https://idp.example.com/token",
+					ClientID:      "client-id",
+					Scopes:        []string{"smtp"},
+				},
+			},
+		},
+		{
+			name:    "prepareSMTPConfigQuery http found",
+			prepare: prepareSMTPConfigQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareSMTPConfigStmt),
+					prepareSMTPConfigCols,
+					[]driver.Value{
+						"config-id",
+						"agg-id",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211108),
+						"test",
+						domain.SMTPConfigStateInactive,
+						false,
+						"",
+						"",
+						"",
+						"",
+						"",
+						nil,
+						domain.SMTPAuthMechanismUnspecified,
+						domain.SMTPTLSModeUnspecified,
+						int64(0),
+						"",
+						"",
+						nil,
+						"config-id",
+						"https://example.com",
+						[]byte(`{"Authorization":["Bearer token"]}`),
+						&crypto.CryptoValue{},
+					},
+				),
+			},
+			object: &SMTPConfig{
+				ID:            "config-id",
+				AggregateID:   "agg-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				Sequence:      20211108,
+				Description:   "test",
+				State:         domain.SMTPConfigStateInactive,
+				HTTPConfig: &HTTP{
+					Endpoint:   "https://example.com",
+					Headers:    http.Header{"Authorization": []string{"Bearer token"}},
+					SigningKey: &crypto.CryptoValue{},
+				},
 			},
 		},
 		{
@@ -127,6 +264,121 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 			},
 			object: (*SMTPConfig)(nil),
 		},
+		{
+			name:    "prepareSMTPConfigsQuery no result",
+			prepare: prepareSMTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSMTPConfigsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &SMTPConfigs{Configs: []*SMTPConfig{}},
+		},
+		{
+			name:    "prepareSMTPConfigsQuery multiple result",
+			prepare: prepareSMTPConfigsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSMTPConfigsStmt),
+					prepareSMTPConfigsCols,
+					[][]driver.Value{
+						{
+							"config-id",
+							"agg-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211108),
+							"test",
+							domain.SMTPConfigStateActive,
+							true,
+							"sender",
+							"name",
+							"reply-to",
+							"host",
+							"user",
+							&crypto.CryptoValue{},
+							domain.SMTPAuthMechanismUnspecified,
+							domain.SMTPTLSModeUnspecified,
+							int64(0),
+							"",
+							"",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+						},
+						{
+							"config-id2",
+							"agg-id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+							"customer",
+							domain.SMTPConfigStateInactive,
+							false,
+							"",
+							"",
+							"",
+							"",
+							"",
+							nil,
+							domain.SMTPAuthMechanismUnspecified,
+							domain.SMTPTLSModeUnspecified,
+							int64(0),
+							"",
+							"",
+							nil,
+							"config-id2",
+							"https://example.com",
+							nil,
+							nil,
+						},
+					},
+				),
+			},
+			object: &SMTPConfigs{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Configs: []*SMTPConfig{
+					{
+						ID:             "config-id",
+						AggregateID:    "agg-id",
+						CreationDate:   testNow,
+						ChangeDate:     testNow,
+						ResourceOwner:  "ro",
+						Sequence:       20211108,
+						Description:    "test",
+						State:          domain.SMTPConfigStateActive,
+						TLS:            true,
+						SenderAddress:  "sender",
+						SenderName:     "name",
+						ReplyToAddress: "reply-to",
+						Host:           "host",
+						User:           "user",
+						Password:       &crypto.CryptoValue{},
+					},
+					{
+						ID:            "config-id2",
+						AggregateID:   "agg-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211109,
+						Description:   "customer",
+						State:         domain.SMTPConfigStateInactive,
+						HTTPConfig: &HTTP{
+							Endpoint: "https://example.com",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,273 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type UserConsents struct {
+	SearchResponse
+	Consents []*UserConsent
+}
+
+type UserConsent struct {
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	UserID        string
+	ClientID      string
+	Scopes        database.TextArray[string]
+
+	// AppID, AppName and ProjectID are empty if the application was removed in the meantime
+	AppID     string
+	AppName   string
+	ProjectID string
+}
+
+type UserConsentSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+var (
+	userConsentTable = table{
+		name:          projection.UserConsentProjectionTable,
+		instanceIDCol: projection.UserConsentColumnInstanceID,
+	}
+	UserConsentColumnUserID = Column{
+		name:  projection.UserConsentColumnUserID,
+		table: userConsentTable,
+	}
+	UserConsentColumnClientID = Column{
+		name:  projection.UserConsentColumnClientID,
+		table: userConsentTable,
+	}
+	UserConsentColumnCreationDate = Column{
+		name:  projection.UserConsentColumnCreationDate,
+		table: userConsentTable,
+	}
+	UserConsentColumnChangeDate = Column{
+		name:  projection.UserConsentColumnChangeDate,
+		table: userConsentTable,
+	}
+	UserConsentColumnSequence = Column{
+		name:  projection.UserConsentColumnSequence,
+		table: userConsentTable,
+	}
+	UserConsentColumnResourceOwner = Column{
+		name:  projection.UserConsentColumnResourceOwner,
+		table: userConsentTable,
+	}
+	UserConsentColumnInstanceID = Column{
+		name:  projection.UserConsentColumnInstanceID,
+		table: userConsentTable,
+	}
+	UserConsentColumnScopes = Column{
+		name:  projection.UserConsentColumnScopes,
+		table: userConsentTable,
+	}
+)
+
+func (q *Queries) UserConsentByClientID(ctx context.Context, shouldTriggerBulk bool, userID, clientID string) (consent *UserConsent, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerUserConsentProjection")
+		ctx, err = projection.UserConsentProjection.Trigger(ctx, handler.WithAwaitRunning())
+		logging.OnError(err).Debug("trigger failed")
+		traceSpan.EndWithError(err)
+	}
+
+	query, scan := prepareUserConsentQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		UserConsentColumnUserID.identifier():     userID,
+		UserConsentColumnClientID.identifier():   clientID,
+		UserConsentColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ieg4a", "Errors.Query.SQLStatment")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		consent, err = scan(row)
+		return err
+	}, stmt, args...)
+	return consent, err
+}
+
+func (q *Queries) SearchUserConsents(ctx context.Context, shouldTriggerBulk bool, queries *UserConsentSearchQueries) (consents *UserConsents, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerUserConsentProjection")
+		ctx, err = projection.UserConsentProjection.Trigger(ctx, handler.WithAwaitRunning())
+		logging.OnError(err).Debug("trigger failed")
+		traceSpan.EndWithError(err)
+	}
+
+	query, scan := prepareUserConsentsQuery(ctx, q.client)
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		UserConsentColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Chu0e", "Errors.Query.SQLStatment")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		consents, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	consents.State, err = q.latestState(ctx, userConsentTable)
+	return consents, err
+}
+
+func (q *UserConsentSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewUserConsentUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(UserConsentColumnUserID, value, TextEquals)
+}
+
+func NewUserConsentClientIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(UserConsentColumnClientID, value, TextEquals)
+}
+
+func NewUserConsentResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(UserConsentColumnResourceOwner, value, TextEquals)
+}
+
+func prepareUserConsentQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*UserConsent, error)) {
+	return sq.Select(
+			UserConsentColumnCreationDate.identifier(),
+			UserConsentColumnChangeDate.identifier(),
+			UserConsentColumnResourceOwner.identifier(),
+			UserConsentColumnSequence.identifier(),
+			UserConsentColumnUserID.identifier(),
+			UserConsentColumnClientID.identifier(),
+			UserConsentColumnScopes.identifier(),
+			AppColumnID.identifier(),
+			AppColumnName.identifier(),
+			AppColumnProjectID.identifier(),
+		).
+			From(userConsentTable.identifier()).
+			LeftJoin(join(AppOIDCConfigColumnClientID, UserConsentColumnClientID)).
+			LeftJoin(join(AppColumnID, AppOIDCConfigColumnAppID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*UserConsent, error) {
+			consent := new(UserConsent)
+			var (
+				appID     sql.NullString
+				appName   sql.NullString
+				projectID sql.NullString
+			)
+			err := row.Scan(
+				&consent.CreationDate,
+				&consent.ChangeDate,
+				&consent.ResourceOwner,
+				&consent.Sequence,
+				&consent.UserID,
+				&consent.ClientID,
+				&consent.Scopes,
+				&appID,
+				&appName,
+				&projectID,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Gei1u", "Errors.User.Consent.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-ahB4o", "Errors.Internal")
+			}
+			consent.AppID = appID.String
+			consent.AppName = appName.String
+			consent.ProjectID = projectID.String
+			return consent, nil
+		}
+}
+
+func prepareUserConsentsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*UserConsents, error)) {
+	return sq.Select(
+			UserConsentColumnCreationDate.identifier(),
+			UserConsentColumnChangeDate.identifier(),
+			UserConsentColumnResourceOwner.identifier(),
+			UserConsentColumnSequence.identifier(),
+			UserConsentColumnUserID.identifier(),
+			UserConsentColumnClientID.identifier(),
+			UserConsentColumnScopes.identifier(),
+			AppColumnID.identifier(),
+			AppColumnName.identifier(),
+			AppColumnProjectID.identifier(),
+			countColumn.identifier(),
+		).
+			From(userConsentTable.identifier()).
+			LeftJoin(join(AppOIDCConfigColumnClientID, UserConsentColumnClientID)).
+			LeftJoin(join(AppColumnID, AppOIDCConfigColumnAppID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*UserConsents, error) {
+			consents := make([]*UserConsent, 0)
+			var count uint64
+			for rows.Next() {
+				consent := new(UserConsent)
+				var (
+					appID     sql.NullString
+					appName   sql.NullString
+					projectID sql.NullString
+				)
+				err := rows.Scan(
+					&consent.CreationDate,
+					&consent.ChangeDate,
+					&consent.ResourceOwner,
+					&consent.Sequence,
+					&consent.UserID,
+					&consent.ClientID,
+					&consent.Scopes,
+					&appID,
+					&appName,
+					&projectID,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				consent.AppID = appID.String
+				consent.AppName = appName.String
+				consent.ProjectID = projectID.String
+				consents = append(consents, consent)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Oov3i", "Errors.Query.CloseRows")
+			}
+
+			return &UserConsents{
+				Consents: consents,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
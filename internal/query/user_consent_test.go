@@ -0,0 +1,239 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	userConsentQuery = `SELECT projections.user_consents.creation_date,` +
+		` projections.user_consents.change_date,` +
+		` projections.user_consents.resource_owner,` +
+		` projections.user_consents.sequence,` +
+		` projections.user_consents.user_id,` +
+		` projections.user_consents.client_id,` +
+		` projections.user_consents.scopes,` +
+		` projections.apps6.id,` +
+		` projections.apps6.name,` +
+		` projections.apps6.project_id` +
+		` FROM projections.user_consents` +
+		` LEFT JOIN projections.apps6_oidc_configs ON projections.user_consents.client_id = projections.apps6_oidc_configs.client_id AND projections.user_consents.instance_id = projections.apps6_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps6 ON projections.apps6_oidc_configs.app_id = projections.apps6.id AND projections.apps6_oidc_configs.instance_id = projections.apps6.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	userConsentCols = []string{
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"user_id",
+		"client_id",
+		"scopes",
+		"id",
+		"name",
+		"project_id",
+	}
+	userConsentsQuery = `SELECT projections.user_consents.creation_date,` +
+		` projections.user_consents.change_date,` +
+		` projections.user_consents.resource_owner,` +
+		` projections.user_consents.sequence,` +
+		` projections.user_consents.user_id,` +
+		` projections.user_consents.client_id,` +
+		` projections.user_consents.scopes,` +
+		` projections.apps6.id,` +
+		` projections.apps6.name,` +
+		` projections.apps6.project_id,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.user_consents` +
+		` LEFT JOIN projections.apps6_oidc_configs ON projections.user_consents.client_id = projections.apps6_oidc_configs.client_id AND projections.user_consents.instance_id = projections.apps6_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps6 ON projections.apps6_oidc_configs.app_id = projections.apps6.id AND projections.apps6_oidc_configs.instance_id = projections.apps6.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	userConsentsCols = append(userConsentCols, "count")
+)
+
+func Test_UserConsentPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareUserConsentQuery no result",
+			prepare: prepareUserConsentQuery,
+			want: want{
+				sqlExpectations: mockQueryScanErr(
+					regexp.QuoteMeta(userConsentQuery),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserConsent)(nil),
+		},
+		{
+			name:    "prepareUserConsentQuery found",
+			prepare: prepareUserConsentQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(userConsentQuery),
+					userConsentCols,
+					[]driver.Value{
+						testNow,
+						testNow,
+						"resource_owner",
+						uint64(20211108),
+						"user-id",
+						"client-id",
+						database.TextArray[string]{"openid", "profile"},
+						"app-id",
+						"app-name",
+						"project-id",
+					},
+				),
+			},
+			object: &UserConsent{
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "resource_owner",
+				Sequence:      20211108,
+				UserID:        "user-id",
+				ClientID:      "client-id",
+				Scopes:        database.TextArray[string]{"openid", "profile"},
+				AppID:         "app-id",
+				AppName:       "app-name",
+				ProjectID:     "project-id",
+			},
+		},
+		{
+			name:    "prepareUserConsentQuery sql err",
+			prepare: prepareUserConsentQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(userConsentQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserConsent)(nil),
+		},
+		{
+			name:    "prepareUserConsentsQuery no result",
+			prepare: prepareUserConsentsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(userConsentsQuery),
+					nil,
+					nil,
+				),
+			},
+			object: &UserConsents{Consents: []*UserConsent{}},
+		},
+		{
+			name:    "prepareUserConsentsQuery multiple results, app removed",
+			prepare: prepareUserConsentsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(userConsentsQuery),
+					userConsentsCols,
+					[][]driver.Value{
+						{
+							testNow,
+							testNow,
+							"resource_owner",
+							uint64(20211108),
+							"user-id",
+							"client-id",
+							database.TextArray[string]{"openid", "profile"},
+							"app-id",
+							"app-name",
+							"project-id",
+						},
+						{
+							testNow,
+							testNow,
+							"resource_owner",
+							uint64(20211109),
+							"user-id",
+							"client-id2",
+							database.TextArray[string]{"openid"},
+							nil,
+							nil,
+							nil,
+						},
+					},
+				),
+			},
+			object: &UserConsents{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Consents: []*UserConsent{
+					{
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "resource_owner",
+						Sequence:      20211108,
+						UserID:        "user-id",
+						ClientID:      "client-id",
+						Scopes:        database.TextArray[string]{"openid", "profile"},
+						AppID:         "app-id",
+						AppName:       "app-name",
+						ProjectID:     "project-id",
+					},
+					{
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "resource_owner",
+						Sequence:      20211109,
+						UserID:        "user-id",
+						ClientID:      "client-id2",
+						Scopes:        database.TextArray[string]{"openid"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareUserConsentsQuery sql err",
+			prepare: prepareUserConsentsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(userConsentsQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserConsents)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// PendingEmailChange is a requested email address, which replaces the current address as soon as it's verified
+type PendingEmailChange struct {
+	Email       domain.EmailAddress
+	RequestDate time.Time
+}
+
+type HumanEmailChangeReadModel struct {
+	*eventstore.ReadModel
+
+	PendingEmail domain.EmailAddress
+	RequestDate  time.Time
+}
+
+// PendingEmailChange returns the pending change of the email address of the user or nil if there is none.
+func (q *Queries) PendingEmailChange(ctx context.Context, userID, resourceOwner string) (_ *PendingEmailChange, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Eiy4o", "Errors.User.UserIDMissing")
+	}
+	readModel := NewHumanEmailChangeReadModel(userID, resourceOwner)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	if readModel.PendingEmail == "" {
+		return nil, nil
+	}
+	return &PendingEmailChange{
+		Email:       readModel.PendingEmail,
+		RequestDate: readModel.RequestDate,
+	}, nil
+}
+
+func NewHumanEmailChangeReadModel(userID, resourceOwner string) *HumanEmailChangeReadModel {
+	return &HumanEmailChangeReadModel{
+		ReadModel: &eventstore.ReadModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (rm *HumanEmailChangeReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *user.HumanEmailChangeRequestedEvent:
+			rm.PendingEmail = e.EmailAddress
+			rm.RequestDate = e.CreationDate()
+		case *user.HumanEmailChangedEvent,
+			*user.HumanEmailChangeRevertedEvent,
+			*user.UserRemovedEvent:
+			rm.PendingEmail = ""
+			rm.RequestDate = time.Time{}
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *HumanEmailChangeReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AllowTimeTravel().
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			user.UserV1EmailChangedType,
+			user.HumanEmailChangedType,
+			user.HumanEmailChangeRequestedType,
+			user.HumanEmailChangeRevertedType,
+			user.UserRemovedType,
+		).
+		Builder()
+
+	if rm.ResourceOwner != "" {
+		query.ResourceOwner(rm.ResourceOwner)
+	}
+	return query
+}
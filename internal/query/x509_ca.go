@@ -0,0 +1,113 @@
+package query
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/x509ca"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type X509CAsReadModel struct {
+	*eventstore.ReadModel
+
+	// CAs are sorted by their creation, oldest first
+	CAs []*domain.X509CA
+}
+
+// X509CAsByResourceOwner returns the trusted certificate authorities of the instance or the organization
+// (resourceOwner), without the ones of the instance for an organization.
+func (q *Queries) X509CAsByResourceOwner(ctx context.Context, resourceOwner string) (_ []*domain.X509CA, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Feih5", "Errors.ResourceOwnerMissing")
+	}
+	readModel := NewX509CAsReadModel(resourceOwner)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.CAs, nil
+}
+
+func NewX509CAsReadModel(resourceOwner string) *X509CAsReadModel {
+	return &X509CAsReadModel{
+		ReadModel: &eventstore.ReadModel{
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (rm *X509CAsReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *instance.X509CAAddedEvent:
+			rm.reduceAdded(&e.AddedEvent)
+		case *org.X509CAAddedEvent:
+			rm.reduceAdded(&e.AddedEvent)
+		case *instance.X509CACRLSetEvent:
+			rm.reduceCRLSet(&e.CRLSetEvent)
+		case *org.X509CACRLSetEvent:
+			rm.reduceCRLSet(&e.CRLSetEvent)
+		case *instance.X509CARemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		case *org.X509CARemovedEvent:
+			rm.reduceRemoved(&e.RemovedEvent)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *X509CAsReadModel) reduceAdded(e *x509ca.AddedEvent) {
+	rm.CAs = append(rm.CAs, &domain.X509CA{
+		ID:          e.ID,
+		Name:        e.Name,
+		Certificate: e.Certificate,
+		CRL:         e.CRL,
+		UserMapping: e.UserMapping,
+	})
+}
+
+func (rm *X509CAsReadModel) reduceCRLSet(e *x509ca.CRLSetEvent) {
+	for _, ca := range rm.CAs {
+		if ca.ID == e.ID {
+			ca.CRL = e.CRL
+		}
+	}
+}
+
+func (rm *X509CAsReadModel) reduceRemoved(e *x509ca.RemovedEvent) {
+	for i, ca := range rm.CAs {
+		if ca.ID == e.ID {
+			rm.CAs = append(rm.CAs[:i], rm.CAs[i+1:]...)
+			return
+		}
+	}
+}
+
+func (rm *X509CAsReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AllowTimeTravel().
+		ResourceOwner(rm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.X509CAAddedEventType,
+			instance.X509CACRLSetEventType,
+			instance.X509CARemovedEventType,
+		).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.X509CAAddedEventType,
+			org.X509CACRLSetEventType,
+			org.X509CARemovedEventType,
+		).
+		Builder()
+}
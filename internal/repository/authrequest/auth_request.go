@@ -36,6 +36,10 @@ type AddedEvent struct {
 	MaxAge        *time.Duration            `json:"max_age,omitempty"`
 	LoginHint     *string                   `json:"login_hint,omitempty"`
 	HintUserID    *string                   `json:"hint_user_id,omitempty"`
+	// LevelOfAssurance is the lowest level requested by the client through the acr_values
+	LevelOfAssurance domain.LevelOfAssurance `json:"level_of_assurance,omitempty"`
+	// ConsentRequired is set if the client requires the consent of the user to the requested scopes
+	ConsentRequired bool `json:"consent_required,omitempty"`
 }
 
 func (e *AddedEvent) Payload() interface{} {
@@ -62,6 +66,8 @@ func NewAddedEvent(ctx context.Context,
 	maxAge *time.Duration,
 	loginHint,
 	hintUserID *string,
+	levelOfAssurance domain.LevelOfAssurance,
+	consentRequired bool,
 ) *AddedEvent {
 	return &AddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -83,6 +89,9 @@ func NewAddedEvent(ctx context.Context,
 		MaxAge:        maxAge,
 		LoginHint:     loginHint,
 		HintUserID:    hintUserID,
+
+		LevelOfAssurance: levelOfAssurance,
+		ConsentRequired:  consentRequired,
 	}
 }
 
@@ -0,0 +1,69 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AttributeMapping contains the expressions (claim paths or templates),
+// which are mapped to the fields of the user
+type AttributeMapping struct {
+	FirstName         string            `json:"firstName,omitempty"`
+	LastName          string            `json:"lastName,omitempty"`
+	DisplayName       string            `json:"displayName,omitempty"`
+	NickName          string            `json:"nickName,omitempty"`
+	PreferredUsername string            `json:"preferredUsername,omitempty"`
+	Email             string            `json:"email,omitempty"`
+	EmailVerified     string            `json:"emailVerified,omitempty"`
+	Phone             string            `json:"phone,omitempty"`
+	PhoneVerified     string            `json:"phoneVerified,omitempty"`
+	PreferredLanguage string            `json:"preferredLanguage,omitempty"`
+	Metadata          []MetadataMapping `json:"metadata,omitempty"`
+}
+
+type MetadataMapping struct {
+	Key        string `json:"key"`
+	Expression string `json:"expression"`
+}
+
+// AttributeMappingSetEvent replaces the complete attribute mapping of the IDP.
+// An event with an empty mapping restores the default mapping of the provider.
+type AttributeMappingSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+	AttributeMapping
+}
+
+func NewAttributeMappingSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	mapping AttributeMapping,
+) *AttributeMappingSetEvent {
+	return &AttributeMappingSetEvent{
+		BaseEvent:        *base,
+		ID:               id,
+		AttributeMapping: mapping,
+	}
+}
+
+func (e *AttributeMappingSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *AttributeMappingSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func AttributeMappingSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &AttributeMappingSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-uoL4e", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
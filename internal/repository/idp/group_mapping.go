@@ -0,0 +1,58 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type GroupMappingRule struct {
+	Group          string   `json:"group,omitempty"`
+	ProjectID      string   `json:"projectId,omitempty"`
+	RoleKeys       []string `json:"roleKeys,omitempty"`
+	OrgMemberRoles []string `json:"orgMemberRoles,omitempty"`
+}
+
+// GroupMappingSetEvent replaces the complete group mapping of the IDP.
+// An event without rules disables the mapping.
+type GroupMappingSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID              string             `json:"id"`
+	GroupsAttribute string             `json:"groupsAttribute,omitempty"`
+	Rules           []GroupMappingRule `json:"rules,omitempty"`
+}
+
+func NewGroupMappingSetEvent(
+	base *eventstore.BaseEvent,
+	id,
+	groupsAttribute string,
+	rules []GroupMappingRule,
+) *GroupMappingSetEvent {
+	return &GroupMappingSetEvent{
+		BaseEvent:       *base,
+		ID:              id,
+		GroupsAttribute: groupsAttribute,
+		Rules:           rules,
+	}
+}
+
+func (e *GroupMappingSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *GroupMappingSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func GroupMappingSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &GroupMappingSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Ooz3a", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,50 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// IDPInitiatedLoginSetEvent enables or disables unsolicited responses of a SAML IDP (IdP-initiated login).
+type IDPInitiatedLoginSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID                 string `json:"id"`
+	Enabled            bool   `json:"enabled,omitempty"`
+	DefaultRedirectURI string `json:"defaultRedirectUri,omitempty"`
+}
+
+func NewIDPInitiatedLoginSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	enabled bool,
+	defaultRedirectURI string,
+) *IDPInitiatedLoginSetEvent {
+	return &IDPInitiatedLoginSetEvent{
+		BaseEvent:          *base,
+		ID:                 id,
+		Enabled:            enabled,
+		DefaultRedirectURI: defaultRedirectURI,
+	}
+}
+
+func (e *IDPInitiatedLoginSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *IDPInitiatedLoginSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func IDPInitiatedLoginSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &IDPInitiatedLoginSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Quu8a", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
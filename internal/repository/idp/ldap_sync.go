@@ -0,0 +1,112 @@
+package idp
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// LDAPSync contains the settings of the periodic synchronization of the users of an LDAP IDP
+type LDAPSync struct {
+	Enabled         bool          `json:"enabled,omitempty"`
+	Interval        time.Duration `json:"interval,omitempty"`
+	SearchBase      string        `json:"searchBase,omitempty"`
+	Filter          string        `json:"filter,omitempty"`
+	CreateUsers     bool          `json:"createUsers,omitempty"`
+	UpdateUsers     bool          `json:"updateUsers,omitempty"`
+	DeactivateUsers bool          `json:"deactivateUsers,omitempty"`
+}
+
+// LDAPSyncSetEvent replaces the synchronization settings of the LDAP IDP.
+type LDAPSyncSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+	LDAPSync
+}
+
+func NewLDAPSyncSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	sync LDAPSync,
+) *LDAPSyncSetEvent {
+	return &LDAPSyncSetEvent{
+		BaseEvent: *base,
+		ID:        id,
+		LDAPSync:  sync,
+	}
+}
+
+func (e *LDAPSyncSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LDAPSyncSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func LDAPSyncSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &LDAPSyncSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Ohb4e", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+// LDAPSyncRun contains the result of a synchronization run,
+// the end of the run is the creation date of the event
+type LDAPSyncRun struct {
+	StartedAt   time.Time `json:"startedAt"`
+	Users       uint32    `json:"users,omitempty"`
+	Created     uint32    `json:"created,omitempty"`
+	Updated     uint32    `json:"updated,omitempty"`
+	Deactivated uint32    `json:"deactivated,omitempty"`
+	Failed      uint32    `json:"failed,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+type LDAPSyncRunFinishedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+	LDAPSyncRun
+}
+
+func NewLDAPSyncRunFinishedEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	run LDAPSyncRun,
+) *LDAPSyncRunFinishedEvent {
+	return &LDAPSyncRunFinishedEvent{
+		BaseEvent:   *base,
+		ID:          id,
+		LDAPSyncRun: run,
+	}
+}
+
+func (e *LDAPSyncRunFinishedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LDAPSyncRunFinishedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func LDAPSyncRunFinishedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &LDAPSyncRunFinishedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-ahM1a", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,48 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// LogoutPropagationSetEvent enables or disables the termination of the user's session at the IDP
+// when the user logs out of ZITADEL.
+type LogoutPropagationSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID      string `json:"id"`
+	Enabled bool   `json:"enabled,omitempty"`
+}
+
+func NewLogoutPropagationSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	enabled bool,
+) *LogoutPropagationSetEvent {
+	return &LogoutPropagationSetEvent{
+		BaseEvent: *base,
+		ID:        id,
+		Enabled:   enabled,
+	}
+}
+
+func (e *LogoutPropagationSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LogoutPropagationSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func LogoutPropagationSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &LogoutPropagationSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-ohD4e", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
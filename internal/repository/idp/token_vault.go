@@ -0,0 +1,47 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// TokenVaultSetEvent enables or disables the storage of the tokens issued by the IDP on the links of the users.
+type TokenVaultSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID      string `json:"id"`
+	Enabled bool   `json:"enabled,omitempty"`
+}
+
+func NewTokenVaultSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	enabled bool,
+) *TokenVaultSetEvent {
+	return &TokenVaultSetEvent{
+		BaseEvent: *base,
+		ID:        id,
+		Enabled:   enabled,
+	}
+}
+
+func (e *TokenVaultSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *TokenVaultSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func TokenVaultSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &TokenVaultSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Ij3ae", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
@@ -14,10 +14,41 @@ const (
 	SucceededEventType     = instanceEventTypePrefix + "succeeded"
 	SAMLSucceededEventType = instanceEventTypePrefix + "saml.succeeded"
 	SAMLRequestEventType   = instanceEventTypePrefix + "saml.requested"
+	SAMLUnsolicitedType    = instanceEventTypePrefix + "saml.unsolicited"
 	LDAPSucceededEventType = instanceEventTypePrefix + "ldap.succeeded"
 	FailedEventType        = instanceEventTypePrefix + "failed"
+
+	UniqueSAMLAssertionType = "saml_assertion"
 )
 
+// NewAddSAMLAssertionUniqueConstraint ensures that an assertion of an unsolicited SAML response is only accepted once.
+func NewAddSAMLAssertionUniqueConstraint(idpID, assertionID string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueSAMLAssertionType,
+		idpID+":"+assertionID,
+		"Errors.Intent.ResponseReplayed")
+}
+
+// MappedUser is the information of the external user after the attribute mapping of the IDP was applied.
+type MappedUser struct {
+	Username          string            `json:"username,omitempty"`
+	FirstName         string            `json:"firstName,omitempty"`
+	LastName          string            `json:"lastName,omitempty"`
+	NickName          string            `json:"nickName,omitempty"`
+	DisplayName       string            `json:"displayName,omitempty"`
+	PreferredLanguage string            `json:"preferredLanguage,omitempty"`
+	Email             string            `json:"email,omitempty"`
+	EmailVerified     bool              `json:"emailVerified,omitempty"`
+	Phone             string            `json:"phone,omitempty"`
+	PhoneVerified     bool              `json:"phoneVerified,omitempty"`
+	Metadata          []*MappedMetadata `json:"metadata,omitempty"`
+}
+
+type MappedMetadata struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
 type StartedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -69,10 +100,11 @@ func StartedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 type SucceededEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	IDPUser     []byte `json:"idpUser"`
-	IDPUserID   string `json:"idpUserId,omitempty"`
-	IDPUserName string `json:"idpUserName,omitempty"`
-	UserID      string `json:"userId,omitempty"`
+	IDPUser     []byte      `json:"idpUser"`
+	MappedUser  *MappedUser `json:"mappedUser,omitempty"`
+	IDPUserID   string      `json:"idpUserId,omitempty"`
+	IDPUserName string      `json:"idpUserName,omitempty"`
+	UserID      string      `json:"userId,omitempty"`
 
 	IDPAccessToken *crypto.CryptoValue `json:"idpAccessToken,omitempty"`
 	IDPIDToken     string              `json:"idpIdToken,omitempty"`
@@ -82,6 +114,7 @@ func NewSucceededEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
 	idpUser []byte,
+	mappedUser *MappedUser,
 	idpUserID,
 	idpUserName,
 	userID string,
@@ -95,6 +128,7 @@ func NewSucceededEvent(
 			SucceededEventType,
 		),
 		IDPUser:        idpUser,
+		MappedUser:     mappedUser,
 		IDPUserID:      idpUserID,
 		IDPUserName:    idpUserName,
 		UserID:         userID,
@@ -127,10 +161,11 @@ func SucceededEventMapper(event eventstore.Event) (eventstore.Event, error) {
 type SAMLSucceededEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	IDPUser     []byte `json:"idpUser"`
-	IDPUserID   string `json:"idpUserId,omitempty"`
-	IDPUserName string `json:"idpUserName,omitempty"`
-	UserID      string `json:"userId,omitempty"`
+	IDPUser     []byte      `json:"idpUser"`
+	MappedUser  *MappedUser `json:"mappedUser,omitempty"`
+	IDPUserID   string      `json:"idpUserId,omitempty"`
+	IDPUserName string      `json:"idpUserName,omitempty"`
+	UserID      string      `json:"userId,omitempty"`
 
 	Assertion *crypto.CryptoValue `json:"assertion,omitempty"`
 }
@@ -139,6 +174,7 @@ func NewSAMLSucceededEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
 	idpUser []byte,
+	mappedUser *MappedUser,
 	idpUserID,
 	idpUserName,
 	userID string,
@@ -151,6 +187,7 @@ func NewSAMLSucceededEvent(
 			SAMLSucceededEventType,
 		),
 		IDPUser:     idpUser,
+		MappedUser:  mappedUser,
 		IDPUserID:   idpUserID,
 		IDPUserName: idpUserName,
 		UserID:      userID,
@@ -221,13 +258,60 @@ func SAMLRequestEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	return e, nil
 }
 
+// SAMLUnsolicitedEvent marks an intent as started by an unsolicited response of the SAML IDP (IdP-initiated login).
+type SAMLUnsolicitedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPID       string `json:"idpId"`
+	AssertionID string `json:"assertionId"`
+}
+
+func NewSAMLUnsolicitedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpID,
+	assertionID string,
+) *SAMLUnsolicitedEvent {
+	return &SAMLUnsolicitedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SAMLUnsolicitedType,
+		),
+		IDPID:       idpID,
+		AssertionID: assertionID,
+	}
+}
+
+func (e *SAMLUnsolicitedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SAMLUnsolicitedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewAddSAMLAssertionUniqueConstraint(e.IDPID, e.AssertionID)}
+}
+
+func SAMLUnsolicitedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &SAMLUnsolicitedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Ahb3o", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
 type LDAPSucceededEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	IDPUser     []byte `json:"idpUser"`
-	IDPUserID   string `json:"idpUserId,omitempty"`
-	IDPUserName string `json:"idpUserName,omitempty"`
-	UserID      string `json:"userId,omitempty"`
+	IDPUser     []byte      `json:"idpUser"`
+	MappedUser  *MappedUser `json:"mappedUser,omitempty"`
+	IDPUserID   string      `json:"idpUserId,omitempty"`
+	IDPUserName string      `json:"idpUserName,omitempty"`
+	UserID      string      `json:"userId,omitempty"`
 
 	EntryAttributes map[string][]string `json:"user,omitempty"`
 }
@@ -236,6 +320,7 @@ func NewLDAPSucceededEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
 	idpUser []byte,
+	mappedUser *MappedUser,
 	idpUserID,
 	idpUserName,
 	userID string,
@@ -248,6 +333,7 @@ func NewLDAPSucceededEvent(
 			LDAPSucceededEventType,
 		),
 		IDPUser:         idpUser,
+		MappedUser:      mappedUser,
 		IDPUserID:       idpUserID,
 		IDPUserName:     idpUserName,
 		UserID:          userID,
@@ -16,9 +16,15 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, SMTPConfigChangedEventType, SMTPConfigChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMTPConfigPasswordChangedEventType, SMTPConfigPasswordChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMTPConfigRemovedEventType, SMTPConfigRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SMTPConfigHTTPAddedEventType, SMTPConfigHTTPAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SMTPConfigHTTPChangedEventType, SMTPConfigHTTPChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SMTPConfigActivatedEventType, SMTPConfigActivatedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SMTPConfigDeactivatedEventType, SMTPConfigDeactivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigTwilioAddedEventType, SMSConfigTwilioAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigTwilioChangedEventType, SMSConfigTwilioChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigTwilioTokenChangedEventType, SMSConfigTwilioTokenChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SMSConfigHTTPAddedEventType, SMSConfigHTTPAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SMSConfigHTTPChangedEventType, SMSConfigHTTPChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigActivatedEventType, SMSConfigActivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigDeactivatedEventType, SMSConfigDeactivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SMSConfigRemovedEventType, SMSConfigRemovedEventMapper).
@@ -97,6 +103,16 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, SAMLIDPAddedEventType, SAMLIDPAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SAMLIDPChangedEventType, SAMLIDPChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, IDPRemovedEventType, IDPRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPGroupMappingSetEventType, IDPGroupMappingSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPAttributeMappingSetEventType, IDPAttributeMappingSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPLDAPSyncSetEventType, IDPLDAPSyncSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPLDAPSyncRunFinishedEventType, IDPLDAPSyncRunFinishedEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPTokenVaultSetEventType, IDPTokenVaultSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPLogoutPropagationSetEventType, IDPLogoutPropagationSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPInitiatedLoginSetEventType, IDPInitiatedLoginSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, X509CAAddedEventType, X509CAAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, X509CACRLSetEventType, X509CACRLSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, X509CARemovedEventType, X509CARemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, LoginPolicyIDPProviderAddedEventType, IdentityProviderAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, LoginPolicyIDPProviderRemovedEventType, IdentityProviderRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, LoginPolicyIDPProviderCascadeRemovedEventType, IdentityProviderCascadeRemovedEventMapper).
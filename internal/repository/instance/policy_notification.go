@@ -19,7 +19,14 @@ type NotificationPolicyAddedEvent struct {
 func NewNotificationPolicyAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	unknownUserAgentSignIn,
+	mfaAdded,
+	mfaRemoved,
+	emailChanged,
+	phoneChanged,
+	accountLocked,
+	machineCredentialAdded bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
 		NotificationPolicyAddedEvent: *policy.NewNotificationPolicyAddedEvent(
@@ -27,7 +34,15 @@ func NewNotificationPolicyAddedEvent(
 				ctx,
 				aggregate,
 				NotificationPolicyAddedEventType),
-			passwordChange),
+			passwordChange,
+			unknownUserAgentSignIn,
+			mfaAdded,
+			mfaRemoved,
+			emailChanged,
+			phoneChanged,
+			accountLocked,
+			machineCredentialAdded,
+		),
 	}
 }
 
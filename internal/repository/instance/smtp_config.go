@@ -2,8 +2,12 @@ package instance
 
 import (
 	"context"
+	"net/http"
+	"slices"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -14,23 +18,69 @@ const (
 	SMTPConfigChangedEventType         = instanceEventTypePrefix + smtpConfigPrefix + "changed"
 	SMTPConfigPasswordChangedEventType = instanceEventTypePrefix + smtpConfigPrefix + "password.changed"
 	SMTPConfigRemovedEventType         = instanceEventTypePrefix + smtpConfigPrefix + "removed"
+	SMTPConfigHTTPAddedEventType       = instanceEventTypePrefix + smtpConfigPrefix + "http.added"
+	SMTPConfigHTTPChangedEventType     = instanceEventTypePrefix + smtpConfigPrefix + "http.changed"
+	SMTPConfigActivatedEventType       = instanceEventTypePrefix + smtpConfigPrefix + "activated"
+	SMTPConfigDeactivatedEventType     = instanceEventTypePrefix + smtpConfigPrefix + "deactivated"
 )
 
 type SMTPConfigAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	SenderAddress  string              `json:"senderAddress,omitempty"`
-	SenderName     string              `json:"senderName,omitempty"`
-	ReplyToAddress string              `json:"replyToAddress,omitempty"`
-	TLS            bool                `json:"tls,omitempty"`
-	Host           string              `json:"host,omitempty"`
-	User           string              `json:"user,omitempty"`
-	Password       *crypto.CryptoValue `json:"password,omitempty"`
+	// ID is empty for the configuration added before multiple configurations were possible
+	ID             string                `json:"id,omitempty"`
+	Description    string                `json:"description,omitempty"`
+	SenderAddress  string                `json:"senderAddress,omitempty"`
+	SenderName     string                `json:"senderName,omitempty"`
+	ReplyToAddress string                `json:"replyToAddress,omitempty"`
+	TLS            bool                  `json:"tls,omitempty"`
+	Host           string                `json:"host,omitempty"`
+	User           string                `json:"user,omitempty"`
+	Password       *crypto.CryptoValue   `json:"password,omitempty"`
+	Connection     *SMTPConfigConnection `json:"connection,omitempty"`
+}
+
+// SMTPConfigConnection defines how the connection to the SMTP server is established and authenticated,
+// it is nil if the defaults are used
+type SMTPConfigConnection struct {
+	AuthMechanism domain.SMTPAuthMechanism `json:"authMechanism,omitempty"`
+	TLSMode       domain.SMTPTLSMode       `json:"tlsMode,omitempty"`
+	Timeout       time.Duration            `json:"timeout,omitempty"`
+	XOAuth2       *SMTPConfigXOAuth2       `json:"xoauth2,omitempty"`
+}
+
+func (c *SMTPConfigConnection) Equal(other *SMTPConfigConnection) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	return c.AuthMechanism == other.AuthMechanism &&
+		c.TLSMode == other.TLSMode &&
+		c.Timeout == other.Timeout &&
+		c.XOAuth2.Equal(other.XOAuth2)
+}
+
+// SMTPConfigXOAuth2 is the client used to request the access token for the XOAUTH2 mechanism,
+// the password of the configuration is the secret of the client
+type SMTPConfigXOAuth2 struct {
+	TokenEndpoint string   `json:"tokenEndpoint,omitempty"`
+	ClientID      string   `json:"clientId,omitempty"`
+	Scopes        []string `json:"scopes,omitempty"`
+}
+
+func (c *SMTPConfigXOAuth2) Equal(other *SMTPConfigXOAuth2) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	return c.TokenEndpoint == other.TokenEndpoint &&
+		c.ClientID == other.ClientID &&
+		slices.Equal(c.Scopes, other.Scopes)
 }
 
 func NewSMTPConfigAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id,
+	description string,
 	tls bool,
 	senderAddress,
 	senderName,
@@ -38,6 +88,7 @@ func NewSMTPConfigAddedEvent(
 	host,
 	user string,
 	password *crypto.CryptoValue,
+	connection *SMTPConfigConnection,
 ) *SMTPConfigAddedEvent {
 	return &SMTPConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -45,6 +96,8 @@ func NewSMTPConfigAddedEvent(
 			aggregate,
 			SMTPConfigAddedEventType,
 		),
+		ID:             id,
+		Description:    description,
 		TLS:            tls,
 		SenderAddress:  senderAddress,
 		SenderName:     senderName,
@@ -52,6 +105,7 @@ func NewSMTPConfigAddedEvent(
 		Host:           host,
 		User:           user,
 		Password:       password,
+		Connection:     connection,
 	}
 }
 
@@ -78,12 +132,16 @@ func SMTPConfigAddedEventMapper(event eventstore.Event) (eventstore.Event, error
 type SMTPConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
+	ID             string  `json:"id,omitempty"`
+	Description    *string `json:"description,omitempty"`
 	FromAddress    *string `json:"senderAddress,omitempty"`
 	FromName       *string `json:"senderName,omitempty"`
 	ReplyToAddress *string `json:"replyToAddress,omitempty"`
 	TLS            *bool   `json:"tls,omitempty"`
 	Host           *string `json:"host,omitempty"`
 	User           *string `json:"user,omitempty"`
+	// Connection replaces all settings of the connection if set
+	Connection *SMTPConfigConnection `json:"connection,omitempty"`
 }
 
 func (e *SMTPConfigChangedEvent) Payload() interface{} {
@@ -97,6 +155,7 @@ func (e *SMTPConfigChangedEvent) UniqueConstraints() []*eventstore.UniqueConstra
 func NewSMTPConfigChangeEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
 	changes []SMTPConfigChanges,
 ) (*SMTPConfigChangedEvent, error) {
 	if len(changes) == 0 {
@@ -108,6 +167,7 @@ func NewSMTPConfigChangeEvent(
 			aggregate,
 			SMTPConfigChangedEventType,
 		),
+		ID: id,
 	}
 	for _, change := range changes {
 		change(changeEvent)
@@ -117,6 +177,12 @@ func NewSMTPConfigChangeEvent(
 
 type SMTPConfigChanges func(event *SMTPConfigChangedEvent)
 
+func ChangeSMTPConfigDescription(description string) func(event *SMTPConfigChangedEvent) {
+	return func(e *SMTPConfigChangedEvent) {
+		e.Description = &description
+	}
+}
+
 func ChangeSMTPConfigTLS(tls bool) func(event *SMTPConfigChangedEvent) {
 	return func(e *SMTPConfigChangedEvent) {
 		e.TLS = &tls
@@ -153,6 +219,14 @@ func ChangeSMTPConfigSMTPUser(smtpUser string) func(event *SMTPConfigChangedEven
 	}
 }
 
+// ChangeSMTPConfigConnection replaces the settings of the connection,
+// an empty connection resets them to the defaults
+func ChangeSMTPConfigConnection(connection SMTPConfigConnection) func(event *SMTPConfigChangedEvent) {
+	return func(e *SMTPConfigChangedEvent) {
+		e.Connection = &connection
+	}
+}
+
 func SMTPConfigChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &SMTPConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -169,12 +243,14 @@ func SMTPConfigChangedEventMapper(event eventstore.Event) (eventstore.Event, err
 type SMTPConfigPasswordChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
+	ID       string              `json:"id,omitempty"`
 	Password *crypto.CryptoValue `json:"password,omitempty"`
 }
 
 func NewSMTPConfigPasswordChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
 	password *crypto.CryptoValue,
 ) *SMTPConfigPasswordChangedEvent {
 	return &SMTPConfigPasswordChangedEvent{
@@ -183,6 +259,7 @@ func NewSMTPConfigPasswordChangedEvent(
 			aggregate,
 			SMTPConfigPasswordChangedEventType,
 		),
+		ID:       id,
 		Password: password,
 	}
 }
@@ -209,11 +286,14 @@ func SMTPConfigPasswordChangedEventMapper(event eventstore.Event) (eventstore.Ev
 
 type SMTPConfigRemovedEvent struct {
 	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id,omitempty"`
 }
 
 func NewSMTPConfigRemovedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	id string,
 ) *SMTPConfigRemovedEvent {
 	return &SMTPConfigRemovedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -221,6 +301,7 @@ func NewSMTPConfigRemovedEvent(
 			aggregate,
 			SMTPConfigRemovedEventType,
 		),
+		ID: id,
 	}
 }
 
@@ -243,3 +324,218 @@ func SMTPConfigRemovedEventMapper(event eventstore.Event) (eventstore.Event, err
 
 	return smtpConfigRemoved, nil
 }
+
+type SMTPConfigHTTPAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID          string              `json:"id,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Endpoint    string              `json:"endpoint,omitempty"`
+	Headers     http.Header         `json:"headers,omitempty"`
+	SigningKey  *crypto.CryptoValue `json:"signingKey,omitempty"`
+}
+
+func NewSMTPConfigHTTPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	description,
+	endpoint string,
+	headers http.Header,
+	signingKey *crypto.CryptoValue,
+) *SMTPConfigHTTPAddedEvent {
+	return &SMTPConfigHTTPAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigHTTPAddedEventType,
+		),
+		ID:          id,
+		Description: description,
+		Endpoint:    endpoint,
+		Headers:     headers,
+		SigningKey:  signingKey,
+	}
+}
+
+func (e *SMTPConfigHTTPAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigHTTPAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SMTPConfigHTTPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	smtpConfigAdded := &SMTPConfigHTTPAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(smtpConfigAdded)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IAM-Hf3qa", "unable to unmarshal smtp config http added")
+	}
+
+	return smtpConfigAdded, nil
+}
+
+type SMTPConfigHTTPChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID          string              `json:"id,omitempty"`
+	Description *string             `json:"description,omitempty"`
+	Endpoint    *string             `json:"endpoint,omitempty"`
+	Headers     *http.Header        `json:"headers,omitempty"`
+	SigningKey  *crypto.CryptoValue `json:"signingKey,omitempty"`
+}
+
+func (e *SMTPConfigHTTPChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigHTTPChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewSMTPConfigHTTPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []SMTPConfigHTTPChanges,
+) (*SMTPConfigHTTPChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "IAM-Wq7rb", "Errors.NoChangesFound")
+	}
+	changeEvent := &SMTPConfigHTTPChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigHTTPChangedEventType,
+		),
+		ID: id,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type SMTPConfigHTTPChanges func(event *SMTPConfigHTTPChangedEvent)
+
+func ChangeSMTPConfigHTTPDescription(description string) func(event *SMTPConfigHTTPChangedEvent) {
+	return func(e *SMTPConfigHTTPChangedEvent) {
+		e.Description = &description
+	}
+}
+
+func ChangeSMTPConfigHTTPEndpoint(endpoint string) func(event *SMTPConfigHTTPChangedEvent) {
+	return func(e *SMTPConfigHTTPChangedEvent) {
+		e.Endpoint = &endpoint
+	}
+}
+
+func ChangeSMTPConfigHTTPHeaders(headers http.Header) func(event *SMTPConfigHTTPChangedEvent) {
+	return func(e *SMTPConfigHTTPChangedEvent) {
+		e.Headers = &headers
+	}
+}
+
+func ChangeSMTPConfigHTTPSigningKey(signingKey *crypto.CryptoValue) func(event *SMTPConfigHTTPChangedEvent) {
+	return func(e *SMTPConfigHTTPChangedEvent) {
+		e.SigningKey = signingKey
+	}
+}
+
+func SMTPConfigHTTPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &SMTPConfigHTTPChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IAM-bX0vs", "unable to unmarshal smtp config http changed")
+	}
+
+	return e, nil
+}
+
+type SMTPConfigActivatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id,omitempty"`
+}
+
+func NewSMTPConfigActivatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *SMTPConfigActivatedEvent {
+	return &SMTPConfigActivatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigActivatedEventType,
+		),
+		ID: id,
+	}
+}
+
+func (e *SMTPConfigActivatedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigActivatedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SMTPConfigActivatedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	smtpConfigActivated := &SMTPConfigActivatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(smtpConfigActivated)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IAM-KPr5t", "unable to unmarshal smtp config activated")
+	}
+
+	return smtpConfigActivated, nil
+}
+
+type SMTPConfigDeactivatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id,omitempty"`
+}
+
+func NewSMTPConfigDeactivatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *SMTPConfigDeactivatedEvent {
+	return &SMTPConfigDeactivatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigDeactivatedEventType,
+		),
+		ID: id,
+	}
+}
+
+func (e *SMTPConfigDeactivatedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigDeactivatedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func SMTPConfigDeactivatedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	smtpConfigDeactivated := &SMTPConfigDeactivatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(smtpConfigDeactivated)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IAM-Zo9fk", "unable to unmarshal smtp config deactivated")
+	}
+
+	return smtpConfigDeactivated, nil
+}
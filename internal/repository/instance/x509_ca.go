@@ -0,0 +1,116 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/x509ca"
+)
+
+const (
+	x509CAEventPrefix      = "instance.x509.ca."
+	X509CAAddedEventType   = x509CAEventPrefix + "added"
+	X509CACRLSetEventType  = x509CAEventPrefix + "crl.set"
+	X509CARemovedEventType = x509CAEventPrefix + "removed"
+)
+
+type X509CAAddedEvent struct {
+	x509ca.AddedEvent
+}
+
+func NewX509CAAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name string,
+	certificate,
+	crl []byte,
+	userMapping domain.X509UserMapping,
+) *X509CAAddedEvent {
+	return &X509CAAddedEvent{
+		AddedEvent: *x509ca.NewAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				X509CAAddedEventType,
+			),
+			id,
+			name,
+			certificate,
+			crl,
+			userMapping,
+		),
+	}
+}
+
+func X509CAAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := x509ca.AddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &X509CAAddedEvent{AddedEvent: *e.(*x509ca.AddedEvent)}, nil
+}
+
+type X509CACRLSetEvent struct {
+	x509ca.CRLSetEvent
+}
+
+func NewX509CACRLSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	crl []byte,
+) *X509CACRLSetEvent {
+	return &X509CACRLSetEvent{
+		CRLSetEvent: *x509ca.NewCRLSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				X509CACRLSetEventType,
+			),
+			id,
+			crl,
+		),
+	}
+}
+
+func X509CACRLSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := x509ca.CRLSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &X509CACRLSetEvent{CRLSetEvent: *e.(*x509ca.CRLSetEvent)}, nil
+}
+
+type X509CARemovedEvent struct {
+	x509ca.RemovedEvent
+}
+
+func NewX509CARemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *X509CARemovedEvent {
+	return &X509CARemovedEvent{
+		RemovedEvent: *x509ca.NewRemovedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				X509CARemovedEventType,
+			),
+			id,
+		),
+	}
+}
+
+func X509CARemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := x509ca.RemovedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &X509CARemovedEvent{RemovedEvent: *e.(*x509ca.RemovedEvent)}, nil
+}
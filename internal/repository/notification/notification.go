@@ -0,0 +1,186 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix     = eventstore.EventType("notification.")
+	RequestedType       = eventTypePrefix + "requested"
+	SentType            = eventTypePrefix + "sent"
+	RetryRequestedType  = eventTypePrefix + "retry.requested"
+	FailedType          = eventTypePrefix + "failed"
+	ResendRequestedType = eventTypePrefix + "resend.requested"
+)
+
+type RequestedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Channel               domain.NotificationType `json:"channel"`
+	Recipient             string                  `json:"recipient,omitempty"`
+	TriggeringAggregateID string                  `json:"triggeringAggregateId,omitempty"`
+	TriggeringEventType   eventstore.EventType    `json:"triggeringEventType,omitempty"`
+	// Message is the encrypted JSON of the message, as it might contain codes
+	Message *crypto.CryptoValue `json:"message"`
+}
+
+func (e *RequestedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *RequestedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *RequestedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func NewRequestedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	channel domain.NotificationType,
+	recipient,
+	triggeringAggregateID string,
+	triggeringEventType eventstore.EventType,
+	message *crypto.CryptoValue,
+) *RequestedEvent {
+	return &RequestedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RequestedType,
+		),
+		Channel:               channel,
+		Recipient:             recipient,
+		TriggeringAggregateID: triggeringAggregateID,
+		TriggeringEventType:   triggeringEventType,
+		Message:               message,
+	}
+}
+
+type SentEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+}
+
+func (e *SentEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *SentEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func NewSentEvent(ctx context.Context, aggregate *eventstore.Aggregate) *SentEvent {
+	return &SentEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SentType,
+		),
+	}
+}
+
+type RetryRequestedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Error     string    `json:"error,omitempty"`
+	NotBefore time.Time `json:"notBefore"`
+}
+
+func (e *RetryRequestedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *RetryRequestedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *RetryRequestedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func NewRetryRequestedEvent(ctx context.Context, aggregate *eventstore.Aggregate, sendErr error, notBefore time.Time) *RetryRequestedEvent {
+	return &RetryRequestedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RetryRequestedType,
+		),
+		Error:     errorMessage(sendErr),
+		NotBefore: notBefore,
+	}
+}
+
+// FailedEvent is pushed if the message could not be sent within the maximum amount of attempts
+type FailedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Error string `json:"error,omitempty"`
+}
+
+func (e *FailedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *FailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *FailedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func NewFailedEvent(ctx context.Context, aggregate *eventstore.Aggregate, sendErr error) *FailedEvent {
+	return &FailedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			FailedType,
+		),
+		Error: errorMessage(sendErr),
+	}
+}
+
+// ResendRequestedEvent schedules a sent or failed message to be sent again
+type ResendRequestedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+}
+
+func (e *ResendRequestedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ResendRequestedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *ResendRequestedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func NewResendRequestedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *ResendRequestedEvent {
+	return &ResendRequestedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ResendRequestedType,
+		),
+	}
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -74,6 +74,8 @@ type AccessTokenAddedEvent struct {
 	ID       string        `json:"id"`
 	Scope    []string      `json:"scope"`
 	Lifetime time.Duration `json:"lifetime"`
+	// CertificateThumbprint (x5t#S256) of the client certificate the token is bound to (RFC 8705)
+	CertificateThumbprint string `json:"certificateThumbprint,omitempty"`
 }
 
 func (e *AccessTokenAddedEvent) Payload() interface{} {
@@ -94,6 +96,7 @@ func NewAccessTokenAddedEvent(
 	id string,
 	scope []string,
 	lifetime time.Duration,
+	certificateThumbprint string,
 ) *AccessTokenAddedEvent {
 	return &AccessTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -101,9 +104,10 @@ func NewAccessTokenAddedEvent(
 			aggregate,
 			AccessTokenAddedType,
 		),
-		ID:       id,
-		Scope:    scope,
-		Lifetime: lifetime,
+		ID:                    id,
+		Scope:                 scope,
+		Lifetime:              lifetime,
+		CertificateThumbprint: certificateThumbprint,
 	}
 }
 
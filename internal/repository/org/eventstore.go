@@ -105,6 +105,16 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, SAMLIDPAddedEventType, SAMLIDPAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SAMLIDPChangedEventType, SAMLIDPChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, IDPRemovedEventType, IDPRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPGroupMappingSetEventType, IDPGroupMappingSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPAttributeMappingSetEventType, IDPAttributeMappingSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPLDAPSyncSetEventType, IDPLDAPSyncSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPLDAPSyncRunFinishedEventType, IDPLDAPSyncRunFinishedEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPTokenVaultSetEventType, IDPTokenVaultSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPLogoutPropagationSetEventType, IDPLogoutPropagationSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, IDPInitiatedLoginSetEventType, IDPInitiatedLoginSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, X509CAAddedEventType, X509CAAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, X509CACRLSetEventType, X509CACRLSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, X509CARemovedEventType, X509CARemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, TriggerActionsSetEventType, TriggerActionsSetEventMapper).
 		RegisterFilterEventMapper(AggregateType, TriggerActionsCascadeRemovedEventType, TriggerActionsCascadeRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, FlowClearedEventType, FlowClearedEventMapper).
@@ -113,5 +123,10 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, MetadataRemovedAllType, MetadataRemovedAllEventMapper).
 		RegisterFilterEventMapper(AggregateType, NotificationPolicyAddedEventType, NotificationPolicyAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, NotificationPolicyChangedEventType, NotificationPolicyChangedEventMapper).
-		RegisterFilterEventMapper(AggregateType, NotificationPolicyRemovedEventType, NotificationPolicyRemovedEventMapper)
+		RegisterFilterEventMapper(AggregateType, NotificationPolicyRemovedEventType, NotificationPolicyRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, NotificationProvidersSetEventType, NotificationProvidersSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, NotificationProvidersRemovedEventType, NotificationProvidersRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MailTemplateMessageSetEventType, MailTemplateMessageSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, MailTemplateMessageActivatedEventType, MailTemplateMessageActivatedEventMapper).
+		RegisterFilterEventMapper(AggregateType, MailTemplateMessageRemovedEventType, MailTemplateMessageRemovedEventMapper)
 }
@@ -37,6 +37,13 @@ const (
 	SAMLIDPAddedEventType               eventstore.EventType = "org.idp.saml.added"
 	SAMLIDPChangedEventType             eventstore.EventType = "org.idp.saml.changed"
 	IDPRemovedEventType                 eventstore.EventType = "org.idp.removed"
+	IDPGroupMappingSetEventType         eventstore.EventType = "org.idp.group_mapping.set"
+	IDPAttributeMappingSetEventType     eventstore.EventType = "org.idp.attribute_mapping.set"
+	IDPLDAPSyncSetEventType             eventstore.EventType = "org.idp.ldap.sync.set"
+	IDPLDAPSyncRunFinishedEventType     eventstore.EventType = "org.idp.ldap.sync.run.finished"
+	IDPTokenVaultSetEventType           eventstore.EventType = "org.idp.token.vault.set"
+	IDPLogoutPropagationSetEventType    eventstore.EventType = "org.idp.logout.propagation.set"
+	IDPInitiatedLoginSetEventType       eventstore.EventType = "org.idp.initiated.login.set"
 )
 
 type OAuthIDPAddedEvent struct {
@@ -1115,3 +1122,259 @@ func IDPRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 
 	return &IDPRemovedEvent{RemovedEvent: *e.(*idp.RemovedEvent)}, nil
 }
+
+type IDPGroupMappingSetEvent struct {
+	idp.GroupMappingSetEvent
+}
+
+func NewIDPGroupMappingSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	groupsAttribute string,
+	rules []idp.GroupMappingRule,
+) *IDPGroupMappingSetEvent {
+	return &IDPGroupMappingSetEvent{
+		GroupMappingSetEvent: *idp.NewGroupMappingSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPGroupMappingSetEventType,
+			),
+			id,
+			groupsAttribute,
+			rules,
+		),
+	}
+}
+
+func (e *IDPGroupMappingSetEvent) Payload() interface{} {
+	return e
+}
+
+func IDPGroupMappingSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.GroupMappingSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPGroupMappingSetEvent{GroupMappingSetEvent: *e.(*idp.GroupMappingSetEvent)}, nil
+}
+
+type IDPAttributeMappingSetEvent struct {
+	idp.AttributeMappingSetEvent
+}
+
+func NewIDPAttributeMappingSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	mapping idp.AttributeMapping,
+) *IDPAttributeMappingSetEvent {
+	return &IDPAttributeMappingSetEvent{
+		AttributeMappingSetEvent: *idp.NewAttributeMappingSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPAttributeMappingSetEventType,
+			),
+			id,
+			mapping,
+		),
+	}
+}
+
+func (e *IDPAttributeMappingSetEvent) Payload() interface{} {
+	return e
+}
+
+func IDPAttributeMappingSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.AttributeMappingSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPAttributeMappingSetEvent{AttributeMappingSetEvent: *e.(*idp.AttributeMappingSetEvent)}, nil
+}
+
+type IDPLDAPSyncSetEvent struct {
+	idp.LDAPSyncSetEvent
+}
+
+func NewIDPLDAPSyncSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	sync idp.LDAPSync,
+) *IDPLDAPSyncSetEvent {
+	return &IDPLDAPSyncSetEvent{
+		LDAPSyncSetEvent: *idp.NewLDAPSyncSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPLDAPSyncSetEventType,
+			),
+			id,
+			sync,
+		),
+	}
+}
+
+func (e *IDPLDAPSyncSetEvent) Payload() interface{} {
+	return e
+}
+
+func IDPLDAPSyncSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.LDAPSyncSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPLDAPSyncSetEvent{LDAPSyncSetEvent: *e.(*idp.LDAPSyncSetEvent)}, nil
+}
+
+type IDPLDAPSyncRunFinishedEvent struct {
+	idp.LDAPSyncRunFinishedEvent
+}
+
+func NewIDPLDAPSyncRunFinishedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	run idp.LDAPSyncRun,
+) *IDPLDAPSyncRunFinishedEvent {
+	return &IDPLDAPSyncRunFinishedEvent{
+		LDAPSyncRunFinishedEvent: *idp.NewLDAPSyncRunFinishedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPLDAPSyncRunFinishedEventType,
+			),
+			id,
+			run,
+		),
+	}
+}
+
+func (e *IDPLDAPSyncRunFinishedEvent) Payload() interface{} {
+	return e
+}
+
+func IDPLDAPSyncRunFinishedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.LDAPSyncRunFinishedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPLDAPSyncRunFinishedEvent{LDAPSyncRunFinishedEvent: *e.(*idp.LDAPSyncRunFinishedEvent)}, nil
+}
+
+type IDPTokenVaultSetEvent struct {
+	idp.TokenVaultSetEvent
+}
+
+func NewIDPTokenVaultSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	enabled bool,
+) *IDPTokenVaultSetEvent {
+	return &IDPTokenVaultSetEvent{
+		TokenVaultSetEvent: *idp.NewTokenVaultSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPTokenVaultSetEventType,
+			),
+			id,
+			enabled,
+		),
+	}
+}
+
+func (e *IDPTokenVaultSetEvent) Payload() interface{} {
+	return e
+}
+
+func IDPTokenVaultSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.TokenVaultSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPTokenVaultSetEvent{TokenVaultSetEvent: *e.(*idp.TokenVaultSetEvent)}, nil
+}
+
+type IDPLogoutPropagationSetEvent struct {
+	idp.LogoutPropagationSetEvent
+}
+
+func NewIDPLogoutPropagationSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	enabled bool,
+) *IDPLogoutPropagationSetEvent {
+	return &IDPLogoutPropagationSetEvent{
+		LogoutPropagationSetEvent: *idp.NewLogoutPropagationSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPLogoutPropagationSetEventType,
+			),
+			id,
+			enabled,
+		),
+	}
+}
+
+func (e *IDPLogoutPropagationSetEvent) Payload() interface{} {
+	return e
+}
+
+func IDPLogoutPropagationSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.LogoutPropagationSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPLogoutPropagationSetEvent{LogoutPropagationSetEvent: *e.(*idp.LogoutPropagationSetEvent)}, nil
+}
+
+type IDPInitiatedLoginSetEvent struct {
+	idp.IDPInitiatedLoginSetEvent
+}
+
+func NewIDPInitiatedLoginSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	enabled bool,
+	defaultRedirectURI string,
+) *IDPInitiatedLoginSetEvent {
+	return &IDPInitiatedLoginSetEvent{
+		IDPInitiatedLoginSetEvent: *idp.NewIDPInitiatedLoginSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPInitiatedLoginSetEventType,
+			),
+			id,
+			enabled,
+			defaultRedirectURI,
+		),
+	}
+}
+
+func (e *IDPInitiatedLoginSetEvent) Payload() interface{} {
+	return e
+}
+
+func IDPInitiatedLoginSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.IDPInitiatedLoginSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPInitiatedLoginSetEvent{IDPInitiatedLoginSetEvent: *e.(*idp.IDPInitiatedLoginSetEvent)}, nil
+}
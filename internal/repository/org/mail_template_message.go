@@ -0,0 +1,159 @@
+package org
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	mailTemplateMessagePrefix             = orgEventTypePrefix + "mail.template.message."
+	MailTemplateMessageSetEventType       = mailTemplateMessagePrefix + "set"
+	MailTemplateMessageActivatedEventType = mailTemplateMessagePrefix + "activated"
+	MailTemplateMessageRemovedEventType   = mailTemplateMessagePrefix + "removed"
+)
+
+// MailTemplateMessageSetEvent stores a new preview of the email template
+// used for a message type and language of the organization,
+// the template itself is stored as asset under the store key
+type MailTemplateMessageSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string       `json:"messageType"`
+	Language    language.Tag `json:"language"`
+	StoreKey    string       `json:"storeKey"`
+}
+
+func NewMailTemplateMessageSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+	lang language.Tag,
+	storeKey string,
+) *MailTemplateMessageSetEvent {
+	return &MailTemplateMessageSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MailTemplateMessageSetEventType,
+		),
+		MessageType: messageType,
+		Language:    lang,
+		StoreKey:    storeKey,
+	}
+}
+
+func (e *MailTemplateMessageSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *MailTemplateMessageSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func MailTemplateMessageSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &MailTemplateMessageSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ORG-Ohb5e", "unable to unmarshal mail template message set")
+	}
+	return e, nil
+}
+
+// MailTemplateMessageActivatedEvent activates the preview template,
+// which is used for the notifications afterward
+type MailTemplateMessageActivatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string       `json:"messageType"`
+	Language    language.Tag `json:"language"`
+	StoreKey    string       `json:"storeKey"`
+}
+
+func NewMailTemplateMessageActivatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+	lang language.Tag,
+	storeKey string,
+) *MailTemplateMessageActivatedEvent {
+	return &MailTemplateMessageActivatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MailTemplateMessageActivatedEventType,
+		),
+		MessageType: messageType,
+		Language:    lang,
+		StoreKey:    storeKey,
+	}
+}
+
+func (e *MailTemplateMessageActivatedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *MailTemplateMessageActivatedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func MailTemplateMessageActivatedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &MailTemplateMessageActivatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ORG-Eiqu6", "unable to unmarshal mail template message activated")
+	}
+	return e, nil
+}
+
+// MailTemplateMessageRemovedEvent removes the preview and active template,
+// the notifications fall back to the mail template policy
+type MailTemplateMessageRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string       `json:"messageType"`
+	Language    language.Tag `json:"language"`
+}
+
+func NewMailTemplateMessageRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+	lang language.Tag,
+) *MailTemplateMessageRemovedEvent {
+	return &MailTemplateMessageRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MailTemplateMessageRemovedEventType,
+		),
+		MessageType: messageType,
+		Language:    lang,
+	}
+}
+
+func (e *MailTemplateMessageRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *MailTemplateMessageRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func MailTemplateMessageRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &MailTemplateMessageRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ORG-ahT2o", "unable to unmarshal mail template message removed")
+	}
+	return e, nil
+}
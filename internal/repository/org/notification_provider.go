@@ -0,0 +1,91 @@
+package org
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	notificationProvidersPrefix           = orgEventTypePrefix + "notification.providers."
+	NotificationProvidersSetEventType     = notificationProvidersPrefix + "set"
+	NotificationProvidersRemovedEventType = notificationProvidersPrefix + "removed"
+)
+
+// NotificationProvidersSetEvent overrides the active providers of the instance
+// with the referenced SMTP and SMS configurations of the instance,
+// an empty ID falls back to the active provider of the instance
+type NotificationProvidersSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	SMTPConfigID string `json:"smtpConfigId,omitempty"`
+	SMSConfigID  string `json:"smsConfigId,omitempty"`
+}
+
+func NewNotificationProvidersSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	smtpConfigID,
+	smsConfigID string,
+) *NotificationProvidersSetEvent {
+	return &NotificationProvidersSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			NotificationProvidersSetEventType,
+		),
+		SMTPConfigID: smtpConfigID,
+		SMSConfigID:  smsConfigID,
+	}
+}
+
+func (e *NotificationProvidersSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *NotificationProvidersSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NotificationProvidersSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &NotificationProvidersSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ORG-Vb3ks", "unable to unmarshal notification providers set")
+	}
+	return e, nil
+}
+
+type NotificationProvidersRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func NewNotificationProvidersRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *NotificationProvidersRemovedEvent {
+	return &NotificationProvidersRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			NotificationProvidersRemovedEventType,
+		),
+	}
+}
+
+func (e *NotificationProvidersRemovedEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *NotificationProvidersRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NotificationProvidersRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return &NotificationProvidersRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
@@ -20,7 +20,14 @@ type NotificationPolicyAddedEvent struct {
 func NewNotificationPolicyAddedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	passwordChange,
+	unknownUserAgentSignIn,
+	mfaAdded,
+	mfaRemoved,
+	emailChanged,
+	phoneChanged,
+	accountLocked,
+	machineCredentialAdded bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
 		NotificationPolicyAddedEvent: *policy.NewNotificationPolicyAddedEvent(
@@ -29,6 +36,13 @@ func NewNotificationPolicyAddedEvent(
 				aggregate,
 				NotificationPolicyAddedEventType),
 			passwordChange,
+			unknownUserAgentSignIn,
+			mfaAdded,
+			mfaRemoved,
+			emailChanged,
+			phoneChanged,
+			accountLocked,
+			machineCredentialAdded,
 		),
 	}
 }
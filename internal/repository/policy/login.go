@@ -28,6 +28,8 @@ type LoginPolicyAddedEvent struct {
 	AllowDomainDiscovery       bool                    `json:"allowDomainDiscovery,omitempty"`
 	DisableLoginWithEmail      bool                    `json:"disableLoginWithEmail,omitempty"`
 	DisableLoginWithPhone      bool                    `json:"disableLoginWithPhone,omitempty"`
+	AllowMagicLink             bool                    `json:"allowMagicLink,omitempty"`
+	AllowPhoneOTPLogin         bool                    `json:"allowPhoneOTPLogin,omitempty"`
 	PasswordlessType           domain.PasswordlessType `json:"passwordlessType,omitempty"`
 	DefaultRedirectURI         string                  `json:"defaultRedirectURI,omitempty"`
 	PasswordCheckLifetime      time.Duration           `json:"passwordCheckLifetime,omitempty"`
@@ -56,7 +58,9 @@ func NewLoginPolicyAddedEvent(
 	ignoreUnknownUsernames,
 	allowDomainDiscovery,
 	disableLoginWithEmail,
-	disableLoginWithPhone bool,
+	disableLoginWithPhone,
+	allowMagicLink,
+	allowPhoneOTPLogin bool,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
@@ -84,6 +88,8 @@ func NewLoginPolicyAddedEvent(
 		MultiFactorCheckLifetime:   multiFactorCheckLifetime,
 		DisableLoginWithEmail:      disableLoginWithEmail,
 		DisableLoginWithPhone:      disableLoginWithPhone,
+		AllowMagicLink:             allowMagicLink,
+		AllowPhoneOTPLogin:         allowPhoneOTPLogin,
 	}
 }
 
@@ -113,6 +119,8 @@ type LoginPolicyChangedEvent struct {
 	AllowDomainDiscovery       *bool                    `json:"allowDomainDiscovery,omitempty"`
 	DisableLoginWithEmail      *bool                    `json:"disableLoginWithEmail,omitempty"`
 	DisableLoginWithPhone      *bool                    `json:"disableLoginWithPhone,omitempty"`
+	AllowMagicLink             *bool                    `json:"allowMagicLink,omitempty"`
+	AllowPhoneOTPLogin         *bool                    `json:"allowPhoneOTPLogin,omitempty"`
 	PasswordlessType           *domain.PasswordlessType `json:"passwordlessType,omitempty"`
 	DefaultRedirectURI         *string                  `json:"defaultRedirectURI,omitempty"`
 	PasswordCheckLifetime      *time.Duration           `json:"passwordCheckLifetime,omitempty"`
@@ -250,6 +258,18 @@ func ChangeDisableLoginWithPhone(DisableLoginWithPhone bool) func(*LoginPolicyCh
 	}
 }
 
+func ChangeAllowMagicLink(allowMagicLink bool) func(*LoginPolicyChangedEvent) {
+	return func(e *LoginPolicyChangedEvent) {
+		e.AllowMagicLink = &allowMagicLink
+	}
+}
+
+func ChangeAllowPhoneOTPLogin(allowPhoneOTPLogin bool) func(*LoginPolicyChangedEvent) {
+	return func(e *LoginPolicyChangedEvent) {
+		e.AllowPhoneOTPLogin = &allowPhoneOTPLogin
+	}
+}
+
 func LoginPolicyChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &LoginPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -14,7 +14,14 @@ const (
 type NotificationPolicyAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	PasswordChange bool `json:"passwordChange,omitempty"`
+	PasswordChange         bool `json:"passwordChange,omitempty"`
+	UnknownUserAgentSignIn bool `json:"unknownUserAgentSignIn,omitempty"`
+	MFAAdded               bool `json:"mfaAdded,omitempty"`
+	MFARemoved             bool `json:"mfaRemoved,omitempty"`
+	EmailChanged           bool `json:"emailChanged,omitempty"`
+	PhoneChanged           bool `json:"phoneChanged,omitempty"`
+	AccountLocked          bool `json:"accountLocked,omitempty"`
+	MachineCredentialAdded bool `json:"machineCredentialAdded,omitempty"`
 }
 
 func (e *NotificationPolicyAddedEvent) Payload() interface{} {
@@ -27,11 +34,25 @@ func (e *NotificationPolicyAddedEvent) UniqueConstraints() []*eventstore.UniqueC
 
 func NewNotificationPolicyAddedEvent(
 	base *eventstore.BaseEvent,
-	passwordChange bool,
+	passwordChange,
+	unknownUserAgentSignIn,
+	mfaAdded,
+	mfaRemoved,
+	emailChanged,
+	phoneChanged,
+	accountLocked,
+	machineCredentialAdded bool,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
-		BaseEvent:      *base,
-		PasswordChange: passwordChange,
+		BaseEvent:              *base,
+		PasswordChange:         passwordChange,
+		UnknownUserAgentSignIn: unknownUserAgentSignIn,
+		MFAAdded:               mfaAdded,
+		MFARemoved:             mfaRemoved,
+		EmailChanged:           emailChanged,
+		PhoneChanged:           phoneChanged,
+		AccountLocked:          accountLocked,
+		MachineCredentialAdded: machineCredentialAdded,
 	}
 }
 
@@ -51,7 +72,14 @@ func NotificationPolicyAddedEventMapper(event eventstore.Event) (eventstore.Even
 type NotificationPolicyChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	PasswordChange *bool `json:"passwordChange,omitempty"`
+	PasswordChange         *bool `json:"passwordChange,omitempty"`
+	UnknownUserAgentSignIn *bool `json:"unknownUserAgentSignIn,omitempty"`
+	MFAAdded               *bool `json:"mfaAdded,omitempty"`
+	MFARemoved             *bool `json:"mfaRemoved,omitempty"`
+	EmailChanged           *bool `json:"emailChanged,omitempty"`
+	PhoneChanged           *bool `json:"phoneChanged,omitempty"`
+	AccountLocked          *bool `json:"accountLocked,omitempty"`
+	MachineCredentialAdded *bool `json:"machineCredentialAdded,omitempty"`
 }
 
 func (e *NotificationPolicyChangedEvent) Payload() interface{} {
@@ -86,6 +114,48 @@ func ChangePasswordChange(passwordChange bool) func(*NotificationPolicyChangedEv
 	}
 }
 
+func ChangeUnknownUserAgentSignIn(unknownUserAgentSignIn bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.UnknownUserAgentSignIn = &unknownUserAgentSignIn
+	}
+}
+
+func ChangeMFAAdded(mfaAdded bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.MFAAdded = &mfaAdded
+	}
+}
+
+func ChangeMFARemoved(mfaRemoved bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.MFARemoved = &mfaRemoved
+	}
+}
+
+func ChangeEmailChanged(emailChanged bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.EmailChanged = &emailChanged
+	}
+}
+
+func ChangePhoneChanged(phoneChanged bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.PhoneChanged = &phoneChanged
+	}
+}
+
+func ChangeAccountLocked(accountLocked bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.AccountLocked = &accountLocked
+	}
+}
+
+func ChangeMachineCredentialAdded(machineCredentialAdded bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.MachineCredentialAdded = &machineCredentialAdded
+	}
+}
+
 func NotificationPolicyChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &NotificationPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -0,0 +1,61 @@
+package project
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	ApplicationTLSClientAuthSetType = applicationEventTypePrefix + "tls.client.auth.set"
+)
+
+// ApplicationTLSClientAuthSetEvent sets the client certificate of an application authenticating with mutual TLS.
+type ApplicationTLSClientAuthSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID       string `json:"appId"`
+	SubjectDN   string `json:"subjectDN,omitempty"`
+	Certificate []byte `json:"certificate,omitempty"`
+}
+
+func (e *ApplicationTLSClientAuthSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ApplicationTLSClientAuthSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewApplicationTLSClientAuthSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID,
+	subjectDN string,
+	certificate []byte,
+) *ApplicationTLSClientAuthSetEvent {
+	return &ApplicationTLSClientAuthSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ApplicationTLSClientAuthSetType,
+		),
+		AppID:       appID,
+		SubjectDN:   subjectDN,
+		Certificate: certificate,
+	}
+}
+
+func ApplicationTLSClientAuthSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &ApplicationTLSClientAuthSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "PROJECT-Ohc8i", "unable to unmarshal tls client auth")
+	}
+
+	return e, nil
+}
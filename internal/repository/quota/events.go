@@ -25,6 +25,33 @@ const (
 	Unimplemented Unit = iota
 	RequestsAllAuthenticated
 	ActionsAllRunsSeconds
+	NotificationsAllSent
+)
+
+// String returns the name of the unit as it's used in the quota configuration
+func (u Unit) String() string {
+	switch u {
+	case RequestsAllAuthenticated:
+		return "requests.all.authenticated"
+	case ActionsAllRunsSeconds:
+		return "actions.all.runs.seconds"
+	case NotificationsAllSent:
+		return "notifications.all.sent"
+	default:
+		return "unimplemented"
+	}
+}
+
+// NotificationChannel defines how a quota notification is delivered
+type NotificationChannel uint
+
+const (
+	// NotificationChannelWebhook calls the CallURL of the notification with a JSON payload
+	NotificationChannelWebhook NotificationChannel = iota
+	// NotificationChannelEmail sends an email to the Recipients of the notification
+	NotificationChannelEmail
+	// NotificationChannelEvent only pushes the notified event to the event stream of the instance
+	NotificationChannelEvent
 )
 
 func NewRemoveQuotaNameUniqueConstraint(unit Unit) *eventstore.UniqueConstraint {
@@ -46,10 +73,12 @@ type SetEvent struct {
 }
 
 type SetEventNotification struct {
-	ID      string `json:"id"`
-	Percent uint16 `json:"percent"`
-	Repeat  bool   `json:"repeat"`
-	CallURL string `json:"callUrl"`
+	ID         string              `json:"id"`
+	Percent    uint16              `json:"percent"`
+	Repeat     bool                `json:"repeat"`
+	CallURL    string              `json:"callUrl"`
+	Channel    NotificationChannel `json:"channel,omitempty"`
+	Recipients []string            `json:"recipients,omitempty"`
 }
 
 func (e *SetEvent) Payload() interface{} {
@@ -121,12 +150,14 @@ func SetEventMapper(event eventstore.Event) (eventstore.Event, error) {
 
 type NotificationDueEvent struct {
 	eventstore.BaseEvent `json:"-"`
-	Unit                 Unit      `json:"unit"`
-	ID                   string    `json:"id"`
-	CallURL              string    `json:"callURL"`
-	PeriodStart          time.Time `json:"periodStart"`
-	Threshold            uint16    `json:"threshold"`
-	Usage                uint64    `json:"usage"`
+	Unit                 Unit                `json:"unit"`
+	ID                   string              `json:"id"`
+	CallURL              string              `json:"callURL"`
+	Channel              NotificationChannel `json:"channel,omitempty"`
+	Recipients           []string            `json:"recipients,omitempty"`
+	PeriodStart          time.Time           `json:"periodStart"`
+	Threshold            uint16              `json:"threshold"`
+	Usage                uint64              `json:"usage"`
 }
 
 func (n *NotificationDueEvent) Payload() interface{} {
@@ -143,6 +174,8 @@ func NewNotificationDueEvent(
 	unit Unit,
 	id string,
 	callURL string,
+	channel NotificationChannel,
+	recipients []string,
 	periodStart time.Time,
 	threshold uint16,
 	usage uint64,
@@ -156,6 +189,8 @@ func NewNotificationDueEvent(
 		Unit:        unit,
 		ID:          id,
 		CallURL:     callURL,
+		Channel:     channel,
+		Recipients:  recipients,
 		PeriodStart: periodStart,
 		Threshold:   threshold,
 		Usage:       usage,
@@ -177,13 +212,14 @@ func NotificationDueEventMapper(event eventstore.Event) (eventstore.Event, error
 
 type NotifiedEvent struct {
 	eventstore.BaseEvent `json:"-"`
-	Unit                 Unit      `json:"unit"`
-	ID                   string    `json:"id"`
-	CallURL              string    `json:"callURL"`
-	PeriodStart          time.Time `json:"periodStart"`
-	Threshold            uint16    `json:"threshold"`
-	Usage                uint64    `json:"usage"`
-	DueEventID           string    `json:"dueEventID"`
+	Unit                 Unit                `json:"unit"`
+	ID                   string              `json:"id"`
+	CallURL              string              `json:"callURL"`
+	Channel              NotificationChannel `json:"channel,omitempty"`
+	PeriodStart          time.Time           `json:"periodStart"`
+	Threshold            uint16              `json:"threshold"`
+	Usage                uint64              `json:"usage"`
+	DueEventID           string              `json:"dueEventID"`
 }
 
 func (e *NotifiedEvent) Payload() interface{} {
@@ -208,6 +244,7 @@ func NewNotifiedEvent(
 		),
 		ID:         id,
 		DueEventID: dueEvent.ID,
+		Channel:    dueEvent.Channel,
 		// Deprecated: dereference the NotificationDueEvent
 		Unit: dueEvent.Unit,
 		// Deprecated: dereference the NotificationDueEvent
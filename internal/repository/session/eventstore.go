@@ -16,6 +16,11 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, OTPEmailChallengedType, eventstore.GenericEventMapper[OTPEmailChallengedEvent]).
 		RegisterFilterEventMapper(AggregateType, OTPEmailSentType, eventstore.GenericEventMapper[OTPEmailSentEvent]).
 		RegisterFilterEventMapper(AggregateType, OTPEmailCheckedType, eventstore.GenericEventMapper[OTPEmailCheckedEvent]).
+		RegisterFilterEventMapper(AggregateType, MagicLinkChallengedType, eventstore.GenericEventMapper[MagicLinkChallengedEvent]).
+		RegisterFilterEventMapper(AggregateType, MagicLinkSentType, eventstore.GenericEventMapper[MagicLinkSentEvent]).
+		RegisterFilterEventMapper(AggregateType, MagicLinkCheckedType, eventstore.GenericEventMapper[MagicLinkCheckedEvent]).
+		RegisterFilterEventMapper(AggregateType, X509ChallengedType, eventstore.GenericEventMapper[X509ChallengedEvent]).
+		RegisterFilterEventMapper(AggregateType, X509CheckedType, eventstore.GenericEventMapper[X509CheckedEvent]).
 		RegisterFilterEventMapper(AggregateType, TokenSetType, TokenSetEventMapper).
 		RegisterFilterEventMapper(AggregateType, MetadataSetType, MetadataSetEventMapper).
 		RegisterFilterEventMapper(AggregateType, LifetimeSetType, eventstore.GenericEventMapper[LifetimeSetEvent]).
@@ -12,24 +12,29 @@ import (
 )
 
 const (
-	sessionEventPrefix     = "session."
-	AddedType              = sessionEventPrefix + "added"
-	UserCheckedType        = sessionEventPrefix + "user.checked"
-	PasswordCheckedType    = sessionEventPrefix + "password.checked"
-	IntentCheckedType      = sessionEventPrefix + "intent.checked"
-	WebAuthNChallengedType = sessionEventPrefix + "webAuthN.challenged"
-	WebAuthNCheckedType    = sessionEventPrefix + "webAuthN.checked"
-	TOTPCheckedType        = sessionEventPrefix + "totp.checked"
-	OTPSMSChallengedType   = sessionEventPrefix + "otp.sms.challenged"
-	OTPSMSSentType         = sessionEventPrefix + "otp.sms.sent"
-	OTPSMSCheckedType      = sessionEventPrefix + "otp.sms.checked"
-	OTPEmailChallengedType = sessionEventPrefix + "otp.email.challenged"
-	OTPEmailSentType       = sessionEventPrefix + "otp.email.sent"
-	OTPEmailCheckedType    = sessionEventPrefix + "otp.email.checked"
-	TokenSetType           = sessionEventPrefix + "token.set"
-	MetadataSetType        = sessionEventPrefix + "metadata.set"
-	LifetimeSetType        = sessionEventPrefix + "lifetime.set"
-	TerminateType          = sessionEventPrefix + "terminated"
+	sessionEventPrefix      = "session."
+	AddedType               = sessionEventPrefix + "added"
+	UserCheckedType         = sessionEventPrefix + "user.checked"
+	PasswordCheckedType     = sessionEventPrefix + "password.checked"
+	IntentCheckedType       = sessionEventPrefix + "intent.checked"
+	WebAuthNChallengedType  = sessionEventPrefix + "webAuthN.challenged"
+	WebAuthNCheckedType     = sessionEventPrefix + "webAuthN.checked"
+	TOTPCheckedType         = sessionEventPrefix + "totp.checked"
+	OTPSMSChallengedType    = sessionEventPrefix + "otp.sms.challenged"
+	OTPSMSSentType          = sessionEventPrefix + "otp.sms.sent"
+	OTPSMSCheckedType       = sessionEventPrefix + "otp.sms.checked"
+	OTPEmailChallengedType  = sessionEventPrefix + "otp.email.challenged"
+	OTPEmailSentType        = sessionEventPrefix + "otp.email.sent"
+	OTPEmailCheckedType     = sessionEventPrefix + "otp.email.checked"
+	MagicLinkChallengedType = sessionEventPrefix + "magicLink.challenged"
+	MagicLinkSentType       = sessionEventPrefix + "magicLink.sent"
+	MagicLinkCheckedType    = sessionEventPrefix + "magicLink.checked"
+	X509ChallengedType      = sessionEventPrefix + "x509.challenged"
+	X509CheckedType         = sessionEventPrefix + "x509.checked"
+	TokenSetType            = sessionEventPrefix + "token.set"
+	MetadataSetType         = sessionEventPrefix + "metadata.set"
+	LifetimeSetType         = sessionEventPrefix + "lifetime.set"
+	TerminateType           = sessionEventPrefix + "terminated"
 )
 
 type AddedEvent struct {
@@ -164,6 +169,7 @@ type IntentCheckedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
 	CheckedAt time.Time `json:"checkedAt"`
+	IntentID  string    `json:"intentID,omitempty"`
 }
 
 func (e *IntentCheckedEvent) Payload() interface{} {
@@ -178,6 +184,7 @@ func NewIntentCheckedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
 	checkedAt time.Time,
+	intentID string,
 ) *IntentCheckedEvent {
 	return &IntentCheckedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -186,6 +193,7 @@ func NewIntentCheckedEvent(
 			IntentCheckedType,
 		),
 		CheckedAt: checkedAt,
+		IntentID:  intentID,
 	}
 }
 
@@ -318,6 +326,7 @@ type OTPSMSChallengedEvent struct {
 	Code              *crypto.CryptoValue `json:"code"`
 	Expiry            time.Duration       `json:"expiry"`
 	CodeReturned      bool                `json:"codeReturned,omitempty"`
+	Channel           domain.PhoneChannel `json:"channel,omitempty"`
 	TriggeredAtOrigin string              `json:"triggerOrigin,omitempty"`
 }
 
@@ -343,6 +352,7 @@ func NewOTPSMSChallengedEvent(
 	code *crypto.CryptoValue,
 	expiry time.Duration,
 	codeReturned bool,
+	channel domain.PhoneChannel,
 ) *OTPSMSChallengedEvent {
 	return &OTPSMSChallengedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -353,6 +363,7 @@ func NewOTPSMSChallengedEvent(
 		Code:              code,
 		Expiry:            expiry,
 		CodeReturned:      codeReturned,
+		Channel:           channel,
 		TriggeredAtOrigin: http.ComposedOrigin(ctx),
 	}
 }
@@ -529,6 +540,194 @@ func NewOTPEmailCheckedEvent(
 	}
 }
 
+type MagicLinkChallengedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Code              *crypto.CryptoValue `json:"code"`
+	Expiry            time.Duration       `json:"expiry"`
+	ReturnCode        bool                `json:"returnCode,omitempty"`
+	URLTmpl           string              `json:"urlTmpl,omitempty"`
+	FingerprintID     string              `json:"fingerprintID,omitempty"`
+	TriggeredAtOrigin string              `json:"triggerOrigin,omitempty"`
+}
+
+func (e *MagicLinkChallengedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *MagicLinkChallengedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *MagicLinkChallengedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func (e *MagicLinkChallengedEvent) TriggerOrigin() string {
+	return e.TriggeredAtOrigin
+}
+
+func NewMagicLinkChallengedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	code *crypto.CryptoValue,
+	expiry time.Duration,
+	returnCode bool,
+	urlTmpl string,
+	fingerprintID string,
+) *MagicLinkChallengedEvent {
+	return &MagicLinkChallengedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MagicLinkChallengedType,
+		),
+		Code:              code,
+		Expiry:            expiry,
+		ReturnCode:        returnCode,
+		URLTmpl:           urlTmpl,
+		FingerprintID:     fingerprintID,
+		TriggeredAtOrigin: http.ComposedOrigin(ctx),
+	}
+}
+
+type MagicLinkSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *MagicLinkSentEvent) Payload() interface{} {
+	return e
+}
+
+func (e *MagicLinkSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *MagicLinkSentEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewMagicLinkSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *MagicLinkSentEvent {
+	return &MagicLinkSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MagicLinkSentType,
+		),
+	}
+}
+
+type MagicLinkCheckedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+func (e *MagicLinkCheckedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *MagicLinkCheckedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *MagicLinkCheckedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewMagicLinkCheckedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	checkedAt time.Time,
+) *MagicLinkCheckedEvent {
+	return &MagicLinkCheckedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MagicLinkCheckedType,
+		),
+		CheckedAt: checkedAt,
+	}
+}
+
+type X509ChallengedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Challenge string        `json:"challenge"`
+	Expiry    time.Duration `json:"expiry"`
+}
+
+func (e *X509ChallengedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *X509ChallengedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *X509ChallengedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewX509ChallengedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	challenge string,
+	expiry time.Duration,
+) *X509ChallengedEvent {
+	return &X509ChallengedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			X509ChallengedType,
+		),
+		Challenge: challenge,
+		Expiry:    expiry,
+	}
+}
+
+type X509CheckedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CheckedAt    time.Time `json:"checkedAt"`
+	CAID         string    `json:"caId,omitempty"`
+	SerialNumber string    `json:"serialNumber,omitempty"`
+}
+
+func (e *X509CheckedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *X509CheckedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *X509CheckedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewX509CheckedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	checkedAt time.Time,
+	caID,
+	serialNumber string,
+) *X509CheckedEvent {
+	return &X509CheckedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			X509CheckedType,
+		),
+		CheckedAt:    checkedAt,
+		CAID:         caID,
+		SerialNumber: serialNumber,
+	}
+}
+
 type TokenSetEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -45,6 +45,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, UserTokenRemovedType, UserTokenRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserDomainClaimedType, DomainClaimedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserDomainClaimedSentType, DomainClaimedSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserSecurityNotificationSentType, eventstore.GenericEventMapper[SecurityNotificationSentEvent]).
 		RegisterFilterEventMapper(AggregateType, UserUserNameChangedType, UsernameChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MetadataSetType, MetadataSetEventMapper).
 		RegisterFilterEventMapper(AggregateType, MetadataRemovedType, MetadataRemovedEventMapper).
@@ -67,8 +68,13 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, UserIDPLinkRemovedType, UserIDPLinkRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserIDPLinkCascadeRemovedType, UserIDPLinkCascadeRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserIDPLoginCheckSucceededType, UserIDPCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserIDPLogoutHintSetType, eventstore.GenericEventMapper[UserIDPLogoutHintSetEvent]).
+		RegisterFilterEventMapper(AggregateType, UserIDPLogoutStartedType, eventstore.GenericEventMapper[UserIDPLogoutStartedEvent]).
+		RegisterFilterEventMapper(AggregateType, UserIDPLogoutFinishedType, eventstore.GenericEventMapper[UserIDPLogoutFinishedEvent]).
 		RegisterFilterEventMapper(AggregateType, UserIDPExternalIDMigratedType, eventstore.GenericEventMapper[UserIDPExternalIDMigratedEvent]).
 		RegisterFilterEventMapper(AggregateType, UserIDPExternalUsernameChangedType, eventstore.GenericEventMapper[UserIDPExternalUsernameEvent]).
+		RegisterFilterEventMapper(AggregateType, UserIDPLinkTokensSetType, eventstore.GenericEventMapper[UserIDPLinkTokensSetEvent]).
+		RegisterFilterEventMapper(AggregateType, UserIDPLinkTokensRemovedType, eventstore.GenericEventMapper[UserIDPLinkTokensRemovedEvent]).
 		RegisterFilterEventMapper(AggregateType, HumanEmailChangedType, HumanEmailChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanEmailVerifiedType, HumanEmailVerifiedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanEmailVerificationFailedType, HumanEmailVerificationFailedEventMapper).
@@ -102,6 +108,20 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanOTPEmailCodeSentType, eventstore.GenericEventMapper[HumanOTPEmailCodeSentEvent]).
 		RegisterFilterEventMapper(AggregateType, HumanOTPEmailCheckSucceededType, eventstore.GenericEventMapper[HumanOTPEmailCheckSucceededEvent]).
 		RegisterFilterEventMapper(AggregateType, HumanOTPEmailCheckFailedType, eventstore.GenericEventMapper[HumanOTPEmailCheckFailedEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanMagicLinkCodeAddedType, eventstore.GenericEventMapper[HumanMagicLinkCodeAddedEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanMagicLinkCodeSentType, eventstore.GenericEventMapper[HumanMagicLinkCodeSentEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanMagicLinkCheckSucceededType, eventstore.GenericEventMapper[HumanMagicLinkCheckSucceededEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanMagicLinkCheckFailedType, eventstore.GenericEventMapper[HumanMagicLinkCheckFailedEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanX509CheckSucceededType, eventstore.GenericEventMapper[HumanX509CheckSucceededEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanX509CheckFailedType, eventstore.GenericEventMapper[HumanX509CheckFailedEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanPhoneLoginCodeAddedType, eventstore.GenericEventMapper[HumanPhoneLoginCodeAddedEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanPhoneLoginCodeSentType, eventstore.GenericEventMapper[HumanPhoneLoginCodeSentEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanPhoneLoginCheckSucceededType, eventstore.GenericEventMapper[HumanPhoneLoginCheckSucceededEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanPhoneLoginCheckFailedType, eventstore.GenericEventMapper[HumanPhoneLoginCheckFailedEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanEmailChangeRequestedType, eventstore.GenericEventMapper[HumanEmailChangeRequestedEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanEmailChangeRevertCodeSentType, eventstore.GenericEventMapper[HumanEmailChangeRevertCodeSentEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanEmailChangeRevertedType, eventstore.GenericEventMapper[HumanEmailChangeRevertedEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanEmailChangeRevertCheckFailedType, eventstore.GenericEventMapper[HumanEmailChangeRevertCheckFailedEvent]).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenAddedType, HumanU2FAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenVerifiedType, HumanU2FVerifiedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenSignCountChangedType, HumanU2FSignCountChangedEventMapper).
@@ -124,6 +144,8 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenAddedType, HumanRefreshTokenAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenRenewedType, HumanRefreshTokenRenewedEventEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanRefreshTokenRemovedType, HumanRefreshTokenRemovedEventEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanConsentGrantedType, eventstore.GenericEventMapper[HumanConsentGrantedEvent]).
+		RegisterFilterEventMapper(AggregateType, HumanConsentRevokedType, eventstore.GenericEventMapper[HumanConsentRevokedEvent]).
 		RegisterFilterEventMapper(AggregateType, MachineAddedEventType, MachineAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MachineChangedEventType, MachineChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MachineKeyAddedEventType, MachineKeyAddedEventMapper).
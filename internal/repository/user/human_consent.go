@@ -0,0 +1,86 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	consentEventPrefix      = humanEventPrefix + "consent."
+	HumanConsentGrantedType = consentEventPrefix + "granted"
+	HumanConsentRevokedType = consentEventPrefix + "revoked"
+)
+
+// HumanConsentGrantedEvent is pushed when the user consents to a client (OIDC application)
+// accessing the listed scopes on their behalf.
+// Scopes always contain all scopes granted to the client so far.
+type HumanConsentGrantedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID string   `json:"clientId"`
+	Scopes   []string `json:"scopes"`
+}
+
+func (e *HumanConsentGrantedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanConsentGrantedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanConsentGrantedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanConsentGrantedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID string,
+	scopes []string,
+) *HumanConsentGrantedEvent {
+	return &HumanConsentGrantedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanConsentGrantedType,
+		),
+		ClientID: clientID,
+		Scopes:   scopes,
+	}
+}
+
+// HumanConsentRevokedEvent is pushed when the user revokes the consent previously given to a client.
+type HumanConsentRevokedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID string `json:"clientId"`
+}
+
+func (e *HumanConsentRevokedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanConsentRevokedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanConsentRevokedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanConsentRevokedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID string,
+) *HumanConsentRevokedEvent {
+	return &HumanConsentRevokedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanConsentRevokedType,
+		),
+		ClientID: clientID,
+	}
+}
@@ -0,0 +1,160 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	emailChangeEventPrefix                = emailEventPrefix + "change."
+	HumanEmailChangeRequestedType         = emailChangeEventPrefix + "requested"
+	HumanEmailChangeRevertCodeSentType    = emailChangeEventPrefix + "revert.code.sent"
+	HumanEmailChangeRevertedType          = emailChangeEventPrefix + "reverted"
+	HumanEmailChangeRevertCheckFailedType = emailChangeEventPrefix + "revert.check.failed"
+)
+
+// HumanEmailChangeRequestedEvent is pushed, if a verified email address is changed.
+// The new address is pending (and the current one stays active) until it is verified.
+// The revert code is sent to the current address, so the owner is able to revert the change.
+type HumanEmailChangeRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	EmailAddress      domain.EmailAddress `json:"email,omitempty"`
+	RevertCode        *crypto.CryptoValue `json:"revertCode,omitempty"`
+	RevertExpiry      time.Duration       `json:"revertExpiry,omitempty"`
+	TriggeredAtOrigin string              `json:"triggerOrigin,omitempty"`
+}
+
+func (e *HumanEmailChangeRequestedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanEmailChangeRequestedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanEmailChangeRequestedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func (e *HumanEmailChangeRequestedEvent) TriggerOrigin() string {
+	return e.TriggeredAtOrigin
+}
+
+func NewHumanEmailChangeRequestedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	emailAddress domain.EmailAddress,
+	revertCode *crypto.CryptoValue,
+	revertExpiry time.Duration,
+) *HumanEmailChangeRequestedEvent {
+	return &HumanEmailChangeRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanEmailChangeRequestedType,
+		),
+		EmailAddress:      emailAddress,
+		RevertCode:        revertCode,
+		RevertExpiry:      revertExpiry,
+		TriggeredAtOrigin: http.ComposedOrigin(ctx),
+	}
+}
+
+type HumanEmailChangeRevertCodeSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *HumanEmailChangeRevertCodeSentEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanEmailChangeRevertCodeSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanEmailChangeRevertCodeSentEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanEmailChangeRevertCodeSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *HumanEmailChangeRevertCodeSentEvent {
+	return &HumanEmailChangeRevertCodeSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanEmailChangeRevertCodeSentType,
+		),
+	}
+}
+
+// HumanEmailChangeRevertedEvent is pushed, if the owner of the previous email address reverted the change.
+// A pending change is discarded, an already applied change is rolled back to EmailAddress.
+type HumanEmailChangeRevertedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	EmailAddress domain.EmailAddress `json:"email,omitempty"`
+}
+
+func (e *HumanEmailChangeRevertedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanEmailChangeRevertedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanEmailChangeRevertedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanEmailChangeRevertedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	emailAddress domain.EmailAddress,
+) *HumanEmailChangeRevertedEvent {
+	return &HumanEmailChangeRevertedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanEmailChangeRevertedType,
+		),
+		EmailAddress: emailAddress,
+	}
+}
+
+type HumanEmailChangeRevertCheckFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *HumanEmailChangeRevertCheckFailedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanEmailChangeRevertCheckFailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanEmailChangeRevertCheckFailedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanEmailChangeRevertCheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *HumanEmailChangeRevertCheckFailedEvent {
+	return &HumanEmailChangeRevertCheckFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanEmailChangeRevertCheckFailedType,
+		),
+	}
+}
@@ -2,7 +2,9 @@ package user
 
 import (
 	"context"
+	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -17,8 +19,13 @@ const (
 	UserIDPLinkCascadeRemovedType      = UserIDPLinkEventPrefix + "cascade.removed"
 	UserIDPExternalIDMigratedType      = UserIDPLinkEventPrefix + "id.migrated"
 	UserIDPExternalUsernameChangedType = UserIDPLinkEventPrefix + "username.changed"
+	UserIDPLinkTokensSetType           = UserIDPLinkEventPrefix + "tokens.set"
+	UserIDPLinkTokensRemovedType       = UserIDPLinkEventPrefix + "tokens.removed"
 
 	UserIDPLoginCheckSucceededType = idpLoginEventPrefix + "check.succeeded"
+	UserIDPLogoutHintSetType       = idpLoginEventPrefix + "logout.hint.set"
+	UserIDPLogoutStartedType       = idpLoginEventPrefix + "logout.started"
+	UserIDPLogoutFinishedType      = idpLoginEventPrefix + "logout.finished"
 )
 
 func NewAddUserIDPLinkUniqueConstraint(idpConfigID, externalUserID string) *eventstore.UniqueConstraint {
@@ -287,3 +294,206 @@ func NewUserIDPExternalUsernameEvent(
 		ExternalUsername: externalUsername,
 	}
 }
+
+// UserIDPLinkTokensSetEvent stores the (encrypted) tokens issued by the IDP for the linked external user
+type UserIDPLinkTokensSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	IDPConfigID          string              `json:"idpConfigId"`
+	ExternalUserID       string              `json:"userId"`
+	AccessToken          *crypto.CryptoValue `json:"accessToken,omitempty"`
+	RefreshToken         *crypto.CryptoValue `json:"refreshToken,omitempty"`
+	IDToken              *crypto.CryptoValue `json:"idToken,omitempty"`
+	TokenType            string              `json:"tokenType,omitempty"`
+	Expiry               time.Time           `json:"expiry,omitempty"`
+}
+
+func (e *UserIDPLinkTokensSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPLinkTokensSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPLinkTokensSetEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPLinkTokensSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	externalUserID string,
+	accessToken,
+	refreshToken,
+	idToken *crypto.CryptoValue,
+	tokenType string,
+	expiry time.Time,
+) *UserIDPLinkTokensSetEvent {
+	return &UserIDPLinkTokensSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLinkTokensSetType,
+		),
+		IDPConfigID:    idpConfigID,
+		ExternalUserID: externalUserID,
+		AccessToken:    accessToken,
+		RefreshToken:   refreshToken,
+		IDToken:        idToken,
+		TokenType:      tokenType,
+		Expiry:         expiry,
+	}
+}
+
+// UserIDPLinkTokensRemovedEvent removes the stored tokens of the linked external user
+type UserIDPLinkTokensRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	IDPConfigID          string `json:"idpConfigId"`
+	ExternalUserID       string `json:"userId"`
+}
+
+func (e *UserIDPLinkTokensRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPLinkTokensRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPLinkTokensRemovedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPLinkTokensRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	externalUserID string,
+) *UserIDPLinkTokensRemovedEvent {
+	return &UserIDPLinkTokensRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLinkTokensRemovedType,
+		),
+		IDPConfigID:    idpConfigID,
+		ExternalUserID: externalUserID,
+	}
+}
+
+// UserIDPLogoutHintSetEvent stores the (encrypted) hint identifying the session at the IDP
+// the user authenticated with on the user agent, e.g. the id_token of an OIDC or the NameID of a SAML IDP.
+type UserIDPLogoutHintSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	IDPConfigID          string              `json:"idpConfigId"`
+	UserAgentID          string              `json:"userAgentID"`
+	Hint                 *crypto.CryptoValue `json:"hint"`
+}
+
+func (e *UserIDPLogoutHintSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPLogoutHintSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPLogoutHintSetEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPLogoutHintSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	userAgentID string,
+	hint *crypto.CryptoValue,
+) *UserIDPLogoutHintSetEvent {
+	return &UserIDPLogoutHintSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLogoutHintSetType,
+		),
+		IDPConfigID: idpConfigID,
+		UserAgentID: userAgentID,
+		Hint:        hint,
+	}
+}
+
+// UserIDPLogoutStartedEvent stores the redirect uri of a logout at the IDP under a random state,
+// so the user agent can be sent back to it, after the IDP terminated its session.
+type UserIDPLogoutStartedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	StateID              string `json:"stateId"`
+	IDPConfigID          string `json:"idpConfigId"`
+	ClientID             string `json:"clientId,omitempty"`
+	RedirectURI          string `json:"redirectUri"`
+}
+
+func (e *UserIDPLogoutStartedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPLogoutStartedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPLogoutStartedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPLogoutStartedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	stateID,
+	idpConfigID,
+	clientID,
+	redirectURI string,
+) *UserIDPLogoutStartedEvent {
+	return &UserIDPLogoutStartedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLogoutStartedType,
+		),
+		StateID:     stateID,
+		IDPConfigID: idpConfigID,
+		ClientID:    clientID,
+		RedirectURI: redirectURI,
+	}
+}
+
+// UserIDPLogoutFinishedEvent marks the state of a logout at the IDP as used.
+type UserIDPLogoutFinishedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	StateID              string `json:"stateId"`
+}
+
+func (e *UserIDPLogoutFinishedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPLogoutFinishedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPLogoutFinishedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPLogoutFinishedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	stateID string,
+) *UserIDPLogoutFinishedEvent {
+	return &UserIDPLogoutFinishedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLogoutFinishedType,
+		),
+		StateID: stateID,
+	}
+}
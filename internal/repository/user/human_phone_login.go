@@ -0,0 +1,160 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	phoneLoginEventPrefix             = humanEventPrefix + "phone.login."
+	HumanPhoneLoginCodeAddedType      = phoneLoginEventPrefix + "code.added"
+	HumanPhoneLoginCodeSentType       = phoneLoginEventPrefix + "code.sent"
+	HumanPhoneLoginCheckSucceededType = phoneLoginEventPrefix + "check.succeeded"
+	HumanPhoneLoginCheckFailedType    = phoneLoginEventPrefix + "check.failed"
+)
+
+type HumanPhoneLoginCodeAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Code              *crypto.CryptoValue `json:"code,omitempty"`
+	Expiry            time.Duration       `json:"expiry,omitempty"`
+	Channel           domain.PhoneChannel `json:"channel,omitempty"`
+	TriggeredAtOrigin string              `json:"triggerOrigin,omitempty"`
+	*AuthRequestInfo
+}
+
+func (e *HumanPhoneLoginCodeAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanPhoneLoginCodeAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanPhoneLoginCodeAddedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func (e *HumanPhoneLoginCodeAddedEvent) TriggerOrigin() string {
+	return e.TriggeredAtOrigin
+}
+
+func NewHumanPhoneLoginCodeAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	code *crypto.CryptoValue,
+	expiry time.Duration,
+	channel domain.PhoneChannel,
+	info *AuthRequestInfo,
+) *HumanPhoneLoginCodeAddedEvent {
+	return &HumanPhoneLoginCodeAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPhoneLoginCodeAddedType,
+		),
+		Code:              code,
+		Expiry:            expiry,
+		Channel:           channel,
+		AuthRequestInfo:   info,
+		TriggeredAtOrigin: http.ComposedOrigin(ctx),
+	}
+}
+
+type HumanPhoneLoginCodeSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *HumanPhoneLoginCodeSentEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanPhoneLoginCodeSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanPhoneLoginCodeSentEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanPhoneLoginCodeSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *HumanPhoneLoginCodeSentEvent {
+	return &HumanPhoneLoginCodeSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPhoneLoginCodeSentType,
+		),
+	}
+}
+
+type HumanPhoneLoginCheckSucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	*AuthRequestInfo
+}
+
+func (e *HumanPhoneLoginCheckSucceededEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanPhoneLoginCheckSucceededEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanPhoneLoginCheckSucceededEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanPhoneLoginCheckSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	info *AuthRequestInfo,
+) *HumanPhoneLoginCheckSucceededEvent {
+	return &HumanPhoneLoginCheckSucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPhoneLoginCheckSucceededType,
+		),
+		AuthRequestInfo: info,
+	}
+}
+
+type HumanPhoneLoginCheckFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	*AuthRequestInfo
+}
+
+func (e *HumanPhoneLoginCheckFailedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanPhoneLoginCheckFailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanPhoneLoginCheckFailedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanPhoneLoginCheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	info *AuthRequestInfo,
+) *HumanPhoneLoginCheckFailedEvent {
+	return &HumanPhoneLoginCheckFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPhoneLoginCheckFailedType,
+		),
+		AuthRequestInfo: info,
+	}
+}
@@ -0,0 +1,86 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	x509EventPrefix             = humanEventPrefix + "x509."
+	HumanX509CheckSucceededType = x509EventPrefix + "check.succeeded"
+	HumanX509CheckFailedType    = x509EventPrefix + "check.failed"
+)
+
+// HumanX509CheckSucceededEvent is pushed when the user authenticated with a client certificate (during login).
+// The trusted CA and the serial number identify the used certificate.
+type HumanX509CheckSucceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	CAID         string `json:"caId,omitempty"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+	*AuthRequestInfo
+}
+
+func (e *HumanX509CheckSucceededEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanX509CheckSucceededEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanX509CheckSucceededEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanX509CheckSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	caID,
+	serialNumber string,
+	info *AuthRequestInfo,
+) *HumanX509CheckSucceededEvent {
+	return &HumanX509CheckSucceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanX509CheckSucceededType,
+		),
+		CAID:            caID,
+		SerialNumber:    serialNumber,
+		AuthRequestInfo: info,
+	}
+}
+
+type HumanX509CheckFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	*AuthRequestInfo
+}
+
+func (e *HumanX509CheckFailedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanX509CheckFailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanX509CheckFailedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanX509CheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	info *AuthRequestInfo,
+) *HumanX509CheckFailedEvent {
+	return &HumanX509CheckFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanX509CheckFailedType,
+		),
+		AuthRequestInfo: info,
+	}
+}
@@ -0,0 +1,53 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UserSecurityNotificationSentType = userEventTypePrefix + "security.notification.sent"
+)
+
+// SecurityNotificationSentEvent is pushed after the user has been notified
+// about a security relevant event on their account
+type SecurityNotificationSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string `json:"messageType"`
+	// TriggeringAggregateID is only set, if the triggering event is not an event of the user (e.g. of a session)
+	TriggeringAggregateID   string `json:"triggeringAggregateId,omitempty"`
+	TriggeringEventSequence uint64 `json:"triggeringEventSequence"`
+}
+
+func (e *SecurityNotificationSentEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SecurityNotificationSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *SecurityNotificationSentEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewSecurityNotificationSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType,
+	triggeringAggregateID string,
+	triggeringEventSequence uint64,
+) *SecurityNotificationSentEvent {
+	return &SecurityNotificationSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserSecurityNotificationSentType,
+		),
+		MessageType:             messageType,
+		TriggeringAggregateID:   triggeringAggregateID,
+		TriggeringEventSequence: triggeringEventSequence,
+	}
+}
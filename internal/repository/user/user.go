@@ -217,6 +217,10 @@ type UserTokenAddedEvent struct {
 	Scopes            []string  `json:"scopes"`
 	Expiration        time.Time `json:"expiration"`
 	PreferredLanguage string    `json:"preferredLanguage"`
+	// AuthMethodsReferences (amr) of the authentication the token was issued for
+	AuthMethodsReferences []string `json:"authMethodsReference,omitempty"`
+	// CertificateThumbprint (x5t#S256) of the client certificate the token is bound to (RFC 8705)
+	CertificateThumbprint string `json:"certificateThumbprint,omitempty"`
 }
 
 func (e *UserTokenAddedEvent) Payload() interface{} {
@@ -236,8 +240,10 @@ func NewUserTokenAddedEvent(
 	preferredLanguage,
 	refreshTokenID string,
 	audience,
-	scopes []string,
+	scopes,
+	authMethodsReferences []string,
 	expiration time.Time,
+	certificateThumbprint string,
 ) *UserTokenAddedEvent {
 	return &UserTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -253,6 +259,9 @@ func NewUserTokenAddedEvent(
 		Scopes:            scopes,
 		Expiration:        expiration,
 		PreferredLanguage: preferredLanguage,
+
+		AuthMethodsReferences: authMethodsReferences,
+		CertificateThumbprint: certificateThumbprint,
 	}
 }
 
@@ -19,6 +19,7 @@ const (
 	UserGrantCascadeRemovedType = userGrantEventTypePrefix + "cascade.removed"
 	UserGrantDeactivatedType    = userGrantEventTypePrefix + "deactivated"
 	UserGrantReactivatedType    = userGrantEventTypePrefix + "reactivated"
+	UserGrantIDPManagedType     = userGrantEventTypePrefix + "idp.managed"
 )
 
 func NewAddUserGrantUniqueConstraint(resourceOwner, userID, projectID, projectGrantID string) *eventstore.UniqueConstraint {
@@ -299,3 +300,36 @@ func UserGrantReactivatedEventMapper(event eventstore.Event) (eventstore.Event,
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
 	}, nil
 }
+
+// UserGrantIDPManagedEvent marks a user grant as created by the group mapping of an IDP,
+// which may therefore remove it again, when the groups of the user no longer match.
+type UserGrantIDPManagedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID string `json:"userId"`
+	IDPID  string `json:"idpId"`
+}
+
+func (e *UserGrantIDPManagedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserGrantIDPManagedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserGrantIDPManagedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewUserGrantIDPManagedEvent(ctx context.Context, aggregate *eventstore.Aggregate, userID, idpID string) *UserGrantIDPManagedEvent {
+	return &UserGrantIDPManagedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserGrantIDPManagedType,
+		),
+		UserID: userID,
+		IDPID:  idpID,
+	}
+}
@@ -0,0 +1,137 @@
+package x509ca
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AddedEvent adds a certificate authority trusted to issue client certificates (X.509 authentication)
+// of the users of the instance or organization.
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name,omitempty"`
+	Certificate []byte                 `json:"certificate,omitempty"`
+	CRL         []byte                 `json:"crl,omitempty"`
+	UserMapping domain.X509UserMapping `json:"userMapping,omitempty"`
+}
+
+func NewAddedEvent(
+	base *eventstore.BaseEvent,
+	id,
+	name string,
+	certificate,
+	crl []byte,
+	userMapping domain.X509UserMapping,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent:   *base,
+		ID:          id,
+		Name:        name,
+		Certificate: certificate,
+		CRL:         crl,
+		UserMapping: userMapping,
+	}
+}
+
+func (e *AddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func AddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &AddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "X509CA-eiV4a", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+// CRLSetEvent replaces the certificate revocation list of the certificate authority.
+// An empty CRL disables the revocation check.
+type CRLSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID  string `json:"id"`
+	CRL []byte `json:"crl,omitempty"`
+}
+
+func NewCRLSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	crl []byte,
+) *CRLSetEvent {
+	return &CRLSetEvent{
+		BaseEvent: *base,
+		ID:        id,
+		CRL:       crl,
+	}
+}
+
+func (e *CRLSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *CRLSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func CRLSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &CRLSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "X509CA-Xoh4a", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type RemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+}
+
+func NewRemovedEvent(
+	base *eventstore.BaseEvent,
+	id string,
+) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: *base,
+		ID:        id,
+	}
+}
+
+func (e *RemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func RemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &RemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "X509CA-ooL5u", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
@@ -101,6 +101,8 @@ func (m *Minio) RemoveObjects(ctx context.Context, instanceID, resourceOwner str
 	switch objectType {
 	case static.ObjectTypeStyling:
 		path = domain.LabelPolicyPrefix + "/"
+	case static.ObjectTypeMailTemplate:
+		path = domain.MailTemplatePrefix + "/"
 	default:
 		return nil
 	}
@@ -8,20 +8,23 @@ import (
 )
 
 type TokenView struct {
-	ID                string
-	CreationDate      time.Time
-	ChangeDate        time.Time
-	ResourceOwner     string
-	UserID            string
-	ApplicationID     string
-	UserAgentID       string
-	Audience          []string
-	Expiration        time.Time
-	Scopes            []string
-	Sequence          uint64
-	PreferredLanguage string
-	RefreshTokenID    string
-	IsPAT             bool
+	ID                    string
+	CreationDate          time.Time
+	ChangeDate            time.Time
+	ResourceOwner         string
+	UserID                string
+	ApplicationID         string
+	UserAgentID           string
+	Audience              []string
+	Expiration            time.Time
+	Scopes                []string
+	Sequence              uint64
+	PreferredLanguage     string
+	RefreshTokenID        string
+	IsPAT                 bool
+	AuthMethodsReferences []string
+	// CertificateThumbprint (x5t#S256) of the client certificate the token is bound to
+	CertificateThumbprint string
 }
 
 type TokenSearchRequest struct {
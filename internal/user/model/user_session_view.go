@@ -22,6 +22,9 @@ type UserSessionView struct {
 	PasswordVerification         time.Time
 	PasswordlessVerification     time.Time
 	ExternalLoginVerification    time.Time
+	MagicLinkVerification        time.Time
+	PhoneLoginVerification       time.Time
+	X509Verification             time.Time
 	SecondFactorVerification     time.Time
 	SecondFactorVerificationType domain.MFAType
 	MultiFactorVerification      time.Time
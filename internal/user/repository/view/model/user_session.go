@@ -37,6 +37,9 @@ type UserSessionView struct {
 	PasswordVerification         time.Time `json:"-" gorm:"column:password_verification"`
 	PasswordlessVerification     time.Time `json:"-" gorm:"column:passwordless_verification"`
 	ExternalLoginVerification    time.Time `json:"-" gorm:"column:external_login_verification"`
+	MagicLinkVerification        time.Time `json:"-" gorm:"column:magic_link_verification"`
+	PhoneLoginVerification       time.Time `json:"-" gorm:"column:phone_login_verification"`
+	X509Verification             time.Time `json:"-" gorm:"column:x509_verification"`
 	SecondFactorVerification     time.Time `json:"-" gorm:"column:second_factor_verification"`
 	SecondFactorVerificationType int32     `json:"-" gorm:"column:second_factor_verification_type"`
 	MultiFactorVerification      time.Time `json:"-" gorm:"column:multi_factor_verification"`
@@ -70,6 +73,9 @@ func UserSessionToModel(userSession *UserSessionView) *model.UserSessionView {
 		PasswordVerification:         userSession.PasswordVerification,
 		PasswordlessVerification:     userSession.PasswordlessVerification,
 		ExternalLoginVerification:    userSession.ExternalLoginVerification,
+		MagicLinkVerification:        userSession.MagicLinkVerification,
+		PhoneLoginVerification:       userSession.PhoneLoginVerification,
+		X509Verification:             userSession.X509Verification,
 		SecondFactorVerification:     userSession.SecondFactorVerification,
 		SecondFactorVerificationType: domain.MFAType(userSession.SecondFactorVerificationType),
 		MultiFactorVerification:      userSession.MultiFactorVerification,
@@ -115,6 +121,21 @@ func (v *UserSessionView) AppendEvent(event eventstore.Event) error {
 	case user.UserV1PasswordCheckFailedType,
 		user.HumanPasswordCheckFailedType:
 		v.PasswordVerification = time.Time{}
+	case user.HumanMagicLinkCheckSucceededType:
+		v.MagicLinkVerification = event.CreatedAt()
+		v.State = int32(domain.UserSessionStateActive)
+	case user.HumanMagicLinkCheckFailedType:
+		v.MagicLinkVerification = time.Time{}
+	case user.HumanPhoneLoginCheckSucceededType:
+		v.PhoneLoginVerification = event.CreatedAt()
+		v.State = int32(domain.UserSessionStateActive)
+	case user.HumanPhoneLoginCheckFailedType:
+		v.PhoneLoginVerification = time.Time{}
+	case user.HumanX509CheckSucceededType:
+		v.X509Verification = event.CreatedAt()
+		v.State = int32(domain.UserSessionStateActive)
+	case user.HumanX509CheckFailedType:
+		v.X509Verification = time.Time{}
 	case user.UserV1PasswordChangedType,
 		user.HumanPasswordChangedType:
 		data := new(es_model.PasswordChange)
@@ -181,6 +202,9 @@ func (v *UserSessionView) AppendEvent(event eventstore.Event) error {
 		user.UserDeactivatedType:
 		v.PasswordlessVerification = time.Time{}
 		v.PasswordVerification = time.Time{}
+		v.MagicLinkVerification = time.Time{}
+		v.PhoneLoginVerification = time.Time{}
+		v.X509Verification = time.Time{}
 		v.SecondFactorVerification = time.Time{}
 		v.SecondFactorVerificationType = int32(domain.MFALevelNotSetUp)
 		v.MultiFactorVerification = time.Time{}
@@ -227,6 +251,12 @@ func (v *UserSessionView) EventTypes() []eventstore.EventType {
 		user.HumanPasswordlessTokenRemovedType,
 		user.UserV1PasswordCheckFailedType,
 		user.HumanPasswordCheckFailedType,
+		user.HumanMagicLinkCheckSucceededType,
+		user.HumanMagicLinkCheckFailedType,
+		user.HumanPhoneLoginCheckSucceededType,
+		user.HumanPhoneLoginCheckFailedType,
+		user.HumanX509CheckSucceededType,
+		user.HumanX509CheckFailedType,
 		user.UserV1PasswordChangedType,
 		user.HumanPasswordChangedType,
 		user.HumanMFAOTPVerifiedType,